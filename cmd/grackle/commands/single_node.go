@@ -29,11 +29,12 @@ import (
 )
 
 var singleNodeCmdCfg struct {
-	port           int
-	prometheusPort int
-	authKeysPath   string
-	shardsCount    int
-	dataDir        string
+	port                       int
+	prometheusPort             int
+	authKeysPath               string
+	shardsCount                int
+	dataDir                    string
+	barrierGenerationsRetained int64
 }
 
 var singleNodeCmd = &cobra.Command{
@@ -103,7 +104,7 @@ var singleNodeCmd = &cobra.Command{
 		grackeSemaphoresGarbageCollectionWorker.Start()
 		grackeWaitGroupsGarbageCollectionWorker := workers.NewGrackleWaitGroupsGCWorker(grackleCoreApiClient)
 		grackeWaitGroupsGarbageCollectionWorker.Start()
-		grackeBarriersGarbageCollectionWorker := workers.NewGrackleBarriersGCWorker(grackleCoreApiClient)
+		grackeBarriersGarbageCollectionWorker := workers.NewGrackleBarriersGCWorker(grackleCoreApiClient, singleNodeCmdCfg.barrierGenerationsRetained)
 		grackeBarriersGarbageCollectionWorker.Start()
 
 		grpcServer := grpc.NewServer(
@@ -162,4 +163,6 @@ func init() {
 	}
 
 	singleNodeCmd.PersistentFlags().StringVarP(&singleNodeCmdCfg.authKeysPath, "auth-keys-path", "", "", "Path to the directory with auth keys. No authn if empty.")
+
+	singleNodeCmd.PersistentFlags().Int64VarP(&singleNodeCmdCfg.barrierGenerationsRetained, "barrier-generations-retained", "", 1000, "Number of most recent generation history rows kept per barrier. 0 keeps the whole history.")
 }
@@ -19,8 +19,9 @@ import (
 )
 
 var workerCmdCfg struct {
-	prometheusPort int
-	nodes          monsteraNodesFlags
+	prometheusPort             int
+	nodes                      monsteraNodesFlags
+	barrierGenerationsRetained int64
 }
 
 var workerCmd = &cobra.Command{
@@ -62,7 +63,7 @@ var workerCmd = &cobra.Command{
 		grackeSemaphoresGarbageCollectionWorker.Start()
 		grackeWaitGroupsGarbageCollectionWorker := workers.NewGrackleWaitGroupsGCWorker(grackleCoreApiClient)
 		grackeWaitGroupsGarbageCollectionWorker.Start()
-		grackeBarriersGarbageCollectionWorker := workers.NewGrackleBarriersGCWorker(grackleCoreApiClient)
+		grackeBarriersGarbageCollectionWorker := workers.NewGrackleBarriersGCWorker(grackleCoreApiClient, workerCmdCfg.barrierGenerationsRetained)
 		grackeBarriersGarbageCollectionWorker.Start()
 
 		wg := sync.WaitGroup{}
@@ -97,5 +98,7 @@ func init() {
 
 	workerCmd.PersistentFlags().IntVarP(&workerCmdCfg.prometheusPort, "prometheus-port", "", 2112, "Prometheus metrics port")
 
+	workerCmd.PersistentFlags().Int64VarP(&workerCmdCfg.barrierGenerationsRetained, "barrier-generations-retained", "", 1000, "Number of most recent generation history rows kept per barrier. 0 keeps the whole history.")
+
 	addMonsteraNodesFlags(workerCmd, &workerCmdCfg.nodes)
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/evrblk/monstera"
@@ -28,6 +29,8 @@ type Core struct {
 
 	barriers        *barriersTable
 	participants    *participantsTable
+	generations     *generationsTable
+	generationTrims *generationTrimsTable
 	counters        *tables.CountersTable[*corepb.BarriersCounter, corepb.BarriersCounter]
 	gcRecords       *tables.GCRecordsTable[*corepb.BarriersGarbageCollectionRecord, corepb.BarriersGarbageCollectionRecord]
 	deletionRecords *deletionRecordsTable
@@ -35,6 +38,10 @@ type Core struct {
 
 var _ coreapis.GrackleBarriersCoreApi = &Core{}
 
+// defaultRetainedGenerations is the number of past generations whose
+// participants are kept by a barrier without RetainedGenerations.
+const defaultRetainedGenerations = 10
+
 // NewCore constructs a Core bound to a single shard of the barriers keyspace.
 // replicaPrefix is a replica-unique prefix (a node-local two-byte prefix assigned
 // by honey.ReplicaPrefixRegistry) nested
@@ -49,8 +56,10 @@ func NewCore(badgerStore *store.BadgerStore, replicaPrefix []byte, shardLowerBou
 		shardLowerBound: shardLowerBound,
 		shardUpperBound: shardUpperBound,
 
-		barriers:     newBarriersTable(replicaPrefix),
-		participants: newParticipantsTable(replicaPrefix),
+		barriers:        newBarriersTable(replicaPrefix),
+		participants:    newParticipantsTable(replicaPrefix),
+		generations:     newGenerationsTable(replicaPrefix),
+		generationTrims: newGenerationTrimsTable(replicaPrefix),
 		counters: tables.NewCountersTable[*corepb.BarriersCounter, corepb.BarriersCounter](
 			utils.ConcatBytes(replicaPrefix, tablePrefixCounters),
 		),
@@ -74,6 +83,8 @@ func (c *Core) snapshotSections() []tables.Section {
 		{Name: "Counters", Table: c.counters},
 		{Name: "GarbageCollectionRecords", Table: c.gcRecords},
 		{Name: "DeletionRecords", Table: c.deletionRecords},
+		{Name: "Generations", Table: c.generations},
+		{Name: "GenerationTrims", Table: c.generationTrims},
 	}
 }
 
@@ -118,7 +129,8 @@ func (c *Core) GetBarrier(req *coreapis.GetBarrierRequest) (*coreapis.GetBarrier
 
 	return &coreapis.GetBarrierResponse{
 		Payload: &corepb.GetBarrierResponse{
-			Barrier: barrier,
+			Barrier:           barrier,
+			MissingProcessIds: missingProcessIds(barrier),
 		},
 	}, nil
 }
@@ -148,7 +160,8 @@ func (c *Core) GetBarrierByName(req *coreapis.GetBarrierByNameRequest) (*coreapi
 
 	return &coreapis.GetBarrierByNameResponse{
 		Payload: &corepb.GetBarrierByNameResponse{
-			Barrier: barrier,
+			Barrier:           barrier,
+			MissingProcessIds: missingProcessIds(barrier),
 		},
 	}, nil
 }
@@ -175,8 +188,9 @@ func (c *Core) ListBarriers(req *coreapis.ListBarriersRequest) (*coreapis.ListBa
 }
 
 // ListBarrierParticipants returns a page of participants currently recorded
-// for the named barrier, across all generations. Returns a NotFound
-// application error if the barrier does not exist.
+// for the named barrier in the requested generation, or across all retained
+// generations if Generation is 0. Returns a NotFound application error if the
+// barrier does not exist.
 func (c *Core) ListBarrierParticipants(req *coreapis.ListBarrierParticipantsRequest) (*coreapis.ListBarrierParticipantsResponse, error) {
 	txn := c.badgerStore.View()
 	defer txn.Discard()
@@ -197,7 +211,12 @@ func (c *Core) ListBarrierParticipants(req *coreapis.ListBarrierParticipantsRequ
 		return nil, err
 	}
 
-	result, err := c.participants.List(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, barrier.Id.BarrierId, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	var result *listParticipantResult
+	if req.Payload.Generation > 0 {
+		result, err = c.participants.ListByGeneration(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, barrier.Id.BarrierId, req.Payload.Generation, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	} else {
+		result, err = c.participants.List(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, barrier.Id.BarrierId, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -211,11 +230,51 @@ func (c *Core) ListBarrierParticipants(req *coreapis.ListBarrierParticipantsRequ
 	}, nil
 }
 
+// ListBarrierGenerations returns a page of the named barrier's generation
+// history, oldest first, including the current (still open) generation.
+// Generations older than the GC retention bound may already have been
+// trimmed. Returns NotFound if the barrier does not exist.
+func (c *Core) ListBarrierGenerations(req *coreapis.ListBarrierGenerationsRequest) (*coreapis.ListBarrierGenerationsResponse, error) {
+	txn := c.badgerStore.View()
+	defer txn.Discard()
+
+	barrier, err := c.barriers.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.BarrierName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.ListBarrierGenerationsResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"barrier not found",
+					map[string]string{
+						"barrier_name": req.Payload.BarrierName,
+					}),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := c.generations.List(txn, barrier.Id, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.ListBarrierGenerationsResponse{
+		Payload: &corepb.ListBarrierGenerationsResponse{
+			Generations:             result.generations,
+			NextPaginationToken:     result.nextPaginationToken,
+			PreviousPaginationToken: result.previousPaginationToken,
+		},
+	}, nil
+}
+
 // CreateBarrier creates a new barrier at generation 1 with the given
 // ExpectedProcesses and bumps the per-namespace barrier counter. Returns
 // AlreadyExists if a barrier with the same name already exists in the
-// namespace, or ResourceExhausted if creating it would exceed
-// MaxNumberOfBarriersPerNamespace.
+// namespace, ResourceExhausted if creating it would exceed
+// MaxNumberOfBarriersPerNamespace, or InvalidRequest if a non-empty Roster is
+// smaller than ExpectedProcesses or ReleaseThreshold exceeds
+// ExpectedProcesses.
 func (c *Core) CreateBarrier(req *coreapis.CreateBarrierRequest) (*coreapis.CreateBarrierResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -232,6 +291,34 @@ func (c *Core) CreateBarrier(req *coreapis.CreateBarrierRequest) (*coreapis.Crea
 		}, nil
 	}
 
+	if req.Payload.ReleaseThreshold < 0 || req.Payload.ReleaseThreshold > req.Payload.ExpectedProcesses {
+		return &coreapis.CreateBarrierResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"release threshold must be between 0 and expected processes",
+				map[string]string{
+					"barrier_name":       req.Payload.Name,
+					"release_threshold":  fmt.Sprintf("%d", req.Payload.ReleaseThreshold),
+					"expected_processes": fmt.Sprintf("%d", req.Payload.ExpectedProcesses),
+				}),
+		}, nil
+	}
+
+	// A barrier with a roster can never trip if it expects more processes than
+	// the roster allows to arrive.
+	if len(req.Payload.Roster) > 0 && req.Payload.ExpectedProcesses > int64(len(req.Payload.Roster)) {
+		return &coreapis.CreateBarrierResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"expected processes must not exceed the roster size",
+				map[string]string{
+					"barrier_name":       req.Payload.Name,
+					"expected_processes": fmt.Sprintf("%d", req.Payload.ExpectedProcesses),
+					"roster_size":        fmt.Sprintf("%d", len(req.Payload.Roster)),
+				}),
+		}, nil
+	}
+
 	// Get counters for that namespace
 	counters, err := c.counters.Get(txn, req.Payload.BarrierId.AccountId, req.Payload.BarrierId.NamespaceId)
 	if err != nil {
@@ -263,6 +350,9 @@ func (c *Core) CreateBarrier(req *coreapis.CreateBarrierRequest) (*coreapis.Crea
 		Version:                    1,
 		LastActivityAt:             req.Now,
 		DeleteInactiveAfterSeconds: req.Payload.DeleteInactiveAfterSeconds,
+		Roster:                     req.Payload.Roster,
+		RetainedGenerations:        req.Payload.RetainedGenerations,
+		ReleaseThreshold:           req.Payload.ReleaseThreshold,
 	}
 
 	appErr, err := c.barriers.Create(txn, barrier)
@@ -281,6 +371,15 @@ func (c *Core) CreateBarrier(req *coreapis.CreateBarrierRequest) (*coreapis.Crea
 		return nil, err
 	}
 
+	// Open the history row of the first generation.
+	err = c.generations.Set(txn, barrier.Id, &corepb.BarrierGeneration{
+		Generation: barrier.Generation,
+		OpenedAt:   req.Now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Update counters
 	counters.NumberOfBarriers += 1
 	err = c.counters.Set(txn, req.Payload.BarrierId.AccountId, req.Payload.BarrierId.NamespaceId, counters)
@@ -302,9 +401,9 @@ func (c *Core) CreateBarrier(req *coreapis.CreateBarrierRequest) (*coreapis.Crea
 
 // DeleteBarrier removes the named barrier and decrements the per-namespace
 // barrier counter. Deleting a barrier that does not exist is a no-op and
-// returns success. Leftover participant rows are not deleted synchronously;
-// instead a GC record is created so that RunBarriersGarbageCollection can
-// drain them in bounded batches.
+// returns success. Leftover participant and generation history rows are not
+// deleted synchronously; instead a GC record is created so that
+// RunBarriersGarbageCollection can drain them in bounded batches.
 func (c *Core) DeleteBarrier(req *coreapis.DeleteBarrierRequest) (*coreapis.DeleteBarrierResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -367,13 +466,14 @@ func (c *Core) DeleteBarrier(req *coreapis.DeleteBarrierRequest) (*coreapis.Dele
 	}, nil
 }
 
-// UpdateBarrier updates the barrier's description and ExpectedProcesses.
-// Returns NotFound if the barrier does not exist, or InvalidArgument if
-// ExpectedProcesses is 0 or is smaller than the number of participants that
-// have already arrived (which would leave the barrier in an inconsistent
-// state). Lowering ExpectedProcesses to exactly ArrivedProcesses trips the
-// barrier (resetting arrived and advancing the generation) rather than leaving
-// it wedged — see the trip logic below.
+// UpdateBarrier updates the barrier's description, ExpectedProcesses,
+// ReleaseThreshold and Roster. Returns NotFound if the barrier does not exist,
+// or InvalidArgument if ExpectedProcesses is 0, is smaller than the number of
+// participants that have already arrived (which would leave the barrier in an
+// inconsistent state), is smaller than ReleaseThreshold, or exceeds the size of
+// a non-empty Roster. Lowering the release threshold to ArrivedProcesses or
+// below trips the barrier (resetting arrived and advancing the generation)
+// rather than leaving it wedged — see the trip logic below.
 func (c *Core) UpdateBarrier(req *coreapis.UpdateBarrierRequest) (*coreapis.UpdateBarrierResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -420,6 +520,19 @@ func (c *Core) UpdateBarrier(req *coreapis.UpdateBarrierRequest) (*coreapis.Upda
 		}, nil
 	}
 
+	if req.Payload.ReleaseThreshold < 0 || req.Payload.ReleaseThreshold > req.Payload.ExpectedProcesses {
+		return &coreapis.UpdateBarrierResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"release threshold must be between 0 and expected processes",
+				map[string]string{
+					"barrier_id":         ids.EncodeBarrierId(req.Payload.BarrierId),
+					"release_threshold":  fmt.Sprintf("%d", req.Payload.ReleaseThreshold),
+					"expected_processes": fmt.Sprintf("%d", req.Payload.ExpectedProcesses),
+				}),
+		}, nil
+	}
+
 	// If there are currently more arrived processes than the new expected processes
 	if barrier.ArrivedProcesses > req.Payload.ExpectedProcesses {
 		return &coreapis.UpdateBarrierResponse{
@@ -430,6 +543,21 @@ func (c *Core) UpdateBarrier(req *coreapis.UpdateBarrierRequest) (*coreapis.Upda
 		}, nil
 	}
 
+	// A barrier with a roster can never trip if it expects more processes than
+	// the roster allows to arrive.
+	if len(req.Payload.Roster) > 0 && req.Payload.ExpectedProcesses > int64(len(req.Payload.Roster)) {
+		return &coreapis.UpdateBarrierResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"expected processes must not exceed the roster size",
+				map[string]string{
+					"barrier_id":         ids.EncodeBarrierId(req.Payload.BarrierId),
+					"expected_processes": fmt.Sprintf("%d", req.Payload.ExpectedProcesses),
+					"roster_size":        fmt.Sprintf("%d", len(req.Payload.Roster)),
+				}),
+		}, nil
+	}
+
 	// Reconcile the auto-deletion schedule if the inactivity window changed. An
 	// update is not itself activity, so last_activity_at (and thus the base of
 	// the deletion time) does not change here.
@@ -452,18 +580,45 @@ func (c *Core) UpdateBarrier(req *coreapis.UpdateBarrierRequest) (*coreapis.Upda
 	barrier.Metadata = req.Payload.Metadata
 	barrier.Version += 1
 	barrier.DeleteInactiveAfterSeconds = req.Payload.DeleteInactiveAfterSeconds
+	// Replacing the roster does not touch arrivals already recorded in the
+	// current generation, even for processes no longer on it.
+	if !slices.Equal(barrier.Roster, req.Payload.Roster) {
+		barrier.Roster = req.Payload.Roster
+		barrier.ArrivedRosterMembers, err = c.arrivedRosterMembers(txn, barrier)
+		if err != nil {
+			return nil, err
+		}
+	}
+	oldRetainedGenerations := barrier.RetainedGenerations
+	barrier.RetainedGenerations = req.Payload.RetainedGenerations
+	barrier.ReleaseThreshold = req.Payload.ReleaseThreshold
 
 	allArrived := false
 
-	// Lowering ExpectedProcesses down to the number of already-arrived processes satisfies the
-	// release condition, but the trip logic only runs on arrival (in ArriveAtBarrier). Without
-	// tripping here the barrier would wedge: it can no longer trip on its own, and the next
-	// ArriveAtBarrier is rejected by the ArrivedProcesses >= ExpectedProcesses guard. Trip it now —
-	// reset arrived and advance the generation — exactly as the final arrival would.
-	if barrier.ArrivedProcesses >= barrier.ExpectedProcesses {
+	// Lowering the release threshold (ExpectedProcesses or ReleaseThreshold) down to the number of
+	// already-arrived processes satisfies the release condition, but the trip logic only runs on
+	// arrival (in ArriveAtBarrier). Without tripping here the barrier would wedge: it can no longer
+	// trip on its own, and the next ArriveAtBarrier is rejected by the ArrivedProcesses >= threshold
+	// guard. Trip it now — reset arrived and advance the generation — exactly as the final arrival
+	// would.
+	if barrier.ArrivedProcesses >= releaseThreshold(barrier) {
 		barrier.ArrivedProcesses = 0
+		barrier.LateProcesses = 0
+		barrier.ArrivedRosterMembers = nil
 		barrier.Generation += 1
 		allArrived = true
+
+		err = c.recordTrip(txn, barrier, req.Now)
+		if err != nil {
+			return nil, err
+		}
+	} else if barrier.RetainedGenerations != oldRetainedGenerations {
+		// A lowered retention leaves generations to prune; queue the barrier
+		// for garbage collection.
+		err = c.generationTrims.Add(txn, barrier.Id)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	err = c.barriers.Update(txn, barrier)
@@ -478,21 +633,31 @@ func (c *Core) UpdateBarrier(req *coreapis.UpdateBarrierRequest) (*coreapis.Upda
 
 	return &coreapis.UpdateBarrierResponse{
 		Payload: &corepb.UpdateBarrierResponse{
-			Barrier:    barrier,
-			AllArrived: allArrived,
+			Barrier:           barrier,
+			AllArrived:        allArrived,
+			MissingProcessIds: missingProcessIds(barrier),
 		},
 	}, nil
 }
 
 // ArriveAtBarrier records the given process as having reached the named
 // barrier for req.Generation, and increments ArrivedProcesses. When the
-// increment makes ArrivedProcesses equal to ExpectedProcesses the barrier
-// auto-trips: ArrivedProcesses is reset to 0 and Generation is incremented,
-// releasing anyone polling WaitAtBarrier at the old generation. A process
-// arriving twice for the same generation is a no-op. Returns NotFound if the
-// barrier does not exist, or InvalidArgument if req.Generation is different
-// from the barrier's current generation; in the InvalidArgument case the
-// transaction is discarded and no participant rows are persisted.
+// increment makes ArrivedProcesses equal to the release threshold
+// (ReleaseThreshold, or ExpectedProcesses if unset) the barrier auto-trips:
+// ArrivedProcesses is reset to 0 and Generation is incremented, releasing
+// anyone polling WaitAtBarrier at the old generation, and the barrier is
+// queued for garbage collection, which prunes the participants of generations
+// outside the RetainedGenerations window. The arrival's Payload is stored with
+// the participant so peers can read it once the generation trips. A process
+// arriving twice for the same generation is a no-op. A quorum barrier (ReleaseThreshold below
+// ExpectedProcesses) also accepts arrivals for the generation that just
+// tripped: they are recorded as late participants of that generation, up to
+// ExpectedProcesses-ReleaseThreshold of them, and never count toward the
+// current generation. Returns NotFound if the barrier does not exist, or
+// InvalidArgument if req.Generation is different from the barrier's current
+// generation or the barrier has a roster that does not include the process; in
+// the InvalidArgument case the transaction is discarded and no participant
+// rows are persisted.
 func (c *Core) ArriveAtBarrier(req *coreapis.ArriveAtBarrierRequest) (*coreapis.ArriveAtBarrierResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -513,8 +678,11 @@ func (c *Core) ArriveAtBarrier(req *coreapis.ArriveAtBarrierRequest) (*coreapis.
 		return nil, err
 	}
 
-	// Reject arrivals for a generation other than the barrier's current one.
-	if req.Payload.Generation != barrier.Generation {
+	// Reject arrivals for a generation other than the barrier's current one. A
+	// quorum barrier also accepts late arrivals for the generation that just
+	// tripped; those are recorded as late and never count toward the current one.
+	late := isQuorum(barrier) && barrier.Generation > 1 && req.Payload.Generation == barrier.Generation-1
+	if req.Payload.Generation != barrier.Generation && !late {
 		return &coreapis.ArriveAtBarrierResponse{
 			ApplicationError: mrpc.NewErrorWithContext(
 				mrpc.InvalidRequest,
@@ -527,7 +695,20 @@ func (c *Core) ArriveAtBarrier(req *coreapis.ArriveAtBarrierRequest) (*coreapis.
 		}, nil
 	}
 
-	_, err = c.participants.Get(txn, barrier.Id.AccountId, barrier.Id.NamespaceId, barrier.Id.BarrierId, req.Payload.Generation, req.Payload.ProcessId)
+	// Reject processes that are not on the barrier's roster, if it has one.
+	if len(barrier.Roster) > 0 && !slices.Contains(barrier.Roster, req.Payload.ProcessId) {
+		return &coreapis.ArriveAtBarrierResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"process is not on the barrier roster",
+				map[string]string{
+					"barrier_name": req.Payload.BarrierName,
+					"process_id":   req.Payload.ProcessId,
+				}),
+		}, nil
+	}
+
+	existing, err := c.participants.Get(txn, barrier.Id.AccountId, barrier.Id.NamespaceId, barrier.Id.BarrierId, req.Payload.Generation, req.Payload.ProcessId)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			// Not arrived yet
@@ -538,24 +719,42 @@ func (c *Core) ArriveAtBarrier(req *coreapis.ArriveAtBarrierRequest) (*coreapis.
 		// This process has already arrived, nothing to do
 		return &coreapis.ArriveAtBarrierResponse{
 			Payload: &corepb.ArriveAtBarrierResponse{
-				Barrier: barrier,
+				Barrier:           barrier,
+				MissingProcessIds: missingProcessIds(barrier),
+				Late:              existing.Late,
 			},
 		}, nil
 	}
 
-	// Defense in depth: auto-trip below should make this unreachable, since ArrivedProcesses
-	// is reset to 0 the moment it would reach ExpectedProcesses. If we ever observe the
-	// invariant broken, reject loudly rather than silently overflowing the counter.
-	if barrier.ArrivedProcesses >= barrier.ExpectedProcesses {
-		return &coreapis.ArriveAtBarrierResponse{
-			ApplicationError: mrpc.NewErrorWithContext(
-				mrpc.InvalidRequest,
-				"too many participants arrived at the barrier",
-				map[string]string{
-					"barrier_name":       req.Payload.BarrierName,
-					"expected_processes": fmt.Sprintf("%d", barrier.ExpectedProcesses),
-				}),
-		}, nil
+	if late {
+		// A generation holds at most ExpectedProcesses arrivals: the quorum that
+		// tripped it plus the late ones.
+		if barrier.LateProcesses >= barrier.ExpectedProcesses-releaseThreshold(barrier) {
+			return &coreapis.ArriveAtBarrierResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"too many participants arrived at the barrier",
+					map[string]string{
+						"barrier_name":       req.Payload.BarrierName,
+						"expected_processes": fmt.Sprintf("%d", barrier.ExpectedProcesses),
+					}),
+			}, nil
+		}
+	} else {
+		// Defense in depth: auto-trip below should make this unreachable, since ArrivedProcesses
+		// is reset to 0 the moment it would reach the release threshold. If we ever observe the
+		// invariant broken, reject loudly rather than silently overflowing the counter.
+		if barrier.ArrivedProcesses >= releaseThreshold(barrier) {
+			return &coreapis.ArriveAtBarrierResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"too many participants arrived at the barrier",
+					map[string]string{
+						"barrier_name":       req.Payload.BarrierName,
+						"expected_processes": fmt.Sprintf("%d", barrier.ExpectedProcesses),
+					}),
+			}, nil
+		}
 	}
 
 	participant := &corepb.BarrierParticipant{
@@ -563,6 +762,8 @@ func (c *Core) ArriveAtBarrier(req *coreapis.ArriveAtBarrierRequest) (*coreapis.
 		Generation: req.Payload.Generation,
 		ArrivedAt:  req.Now,
 		Metadata:   req.Payload.Metadata,
+		Payload:    req.Payload.Payload,
+		Late:       late,
 	}
 
 	err = c.participants.Create(txn, barrier.Id.AccountId, barrier.Id.NamespaceId, barrier.Id.BarrierId, participant)
@@ -572,15 +773,35 @@ func (c *Core) ArriveAtBarrier(req *coreapis.ArriveAtBarrierRequest) (*coreapis.
 
 	allArrived := false
 
-	// Increment the counter of arrived processes
-	barrier.ArrivedProcesses += 1
+	if late {
+		// Late arrivals are only recorded; the generation has already tripped
+		barrier.LateProcesses += 1
+	} else {
+		// Increment the counter of arrived processes
+		barrier.ArrivedProcesses += 1
+		if len(barrier.Roster) > 0 {
+			barrier.ArrivedRosterMembers = append(barrier.ArrivedRosterMembers, req.Payload.ProcessId)
+		}
 
-	// Auto-trip on the last expected arrival: reset the counter and advance the generation
-	// so any waiter polling WaitAtBarrier at the old generation observes the trip.
-	if barrier.ArrivedProcesses == barrier.ExpectedProcesses {
-		barrier.ArrivedProcesses = 0
-		barrier.Generation += 1
-		allArrived = true
+		err = c.recordArrival(txn, barrier, req.Payload.ProcessId, req.Now)
+		if err != nil {
+			return nil, err
+		}
+
+		// Auto-trip on reaching the release threshold: reset the counter and advance the
+		// generation so any waiter polling WaitAtBarrier at the old generation observes the trip.
+		if barrier.ArrivedProcesses == releaseThreshold(barrier) {
+			barrier.ArrivedProcesses = 0
+			barrier.LateProcesses = 0
+			barrier.ArrivedRosterMembers = nil
+			barrier.Generation += 1
+			allArrived = true
+
+			err = c.recordTrip(txn, barrier, req.Now)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Arriving is activity: advance last_activity_at and push the auto-deletion
@@ -611,20 +832,23 @@ func (c *Core) ArriveAtBarrier(req *coreapis.ArriveAtBarrierRequest) (*coreapis.
 
 	return &coreapis.ArriveAtBarrierResponse{
 		Payload: &corepb.ArriveAtBarrierResponse{
-			Barrier:    barrier,
-			AllArrived: allArrived,
+			Barrier:           barrier,
+			AllArrived:        allArrived,
+			MissingProcessIds: missingProcessIds(barrier),
+			Late:              late,
 		},
 	}, nil
 }
 
 // RunBarriersGarbageCollection performs a single bounded GC pass. It
-// processes namespace deletion records (deleting participant rows for every
-// barrier in the namespace, then the barrier itself) and barrier deletion
-// records (draining the leftover participants of a previously deleted
-// barrier). The pass stops once MaxVisited total records (participants +
-// barriers + counter rows) have been touched so that one invocation cannot
-// produce an unbounded transaction. Intended to be invoked periodically by
-// the scheduler.
+// processes namespace deletion records (deleting participant and generation
+// rows for every barrier in the namespace, then the barrier itself) and barrier
+// deletion records (draining the leftover rows of a previously deleted
+// barrier), then trims each recently tripped barrier's generation history to
+// its MaxGenerationsPerBarrier most recent rows. The pass stops once MaxVisited
+// total records (participants + generations + barriers + counter rows) have
+// been touched so that one invocation cannot produce an unbounded transaction.
+// Intended to be invoked periodically by the scheduler.
 func (c *Core) RunBarriersGarbageCollection(req *coreapis.RunBarriersGarbageCollectionRequest) (*coreapis.RunBarriersGarbageCollectionResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -655,21 +879,21 @@ func (c *Core) RunBarriersGarbageCollection(req *coreapis.RunBarriersGarbageColl
 
 			allBarriersDeleted := true
 			for _, barrier := range result.barriers {
-				// Drain one page of participants first; if there are more participants than fit on the
-				// page, leave the barrier record in place and let a later GC pass continue.
-				participantsDrained, err := c.gcDeleteBarrierParticipants(txn, barrier.Id, participantsPageSize, &visited, req.Payload.MaxVisited)
+				// Drain one page of participants and generations first; if there are more rows than fit
+				// on the page, leave the barrier record in place and let a later GC pass continue.
+				drained, err := c.gcDeleteBarrierData(txn, barrier.Id, participantsPageSize, &visited, req.Payload.MaxVisited)
 				if err != nil {
 					return nil, err
 				}
 				if visited >= req.Payload.MaxVisited {
 					goto commit
 				}
-				if !participantsDrained {
+				if !drained {
 					allBarriersDeleted = false
 					break
 				}
 
-				// All participants for this barrier are gone — delete the barrier record itself.
+				// All participants and generations for this barrier are gone — delete the barrier record itself.
 				err = c.barriers.Delete(txn, barrier.Id)
 				if err != nil {
 					return nil, err
@@ -696,15 +920,15 @@ func (c *Core) RunBarriersGarbageCollection(req *coreapis.RunBarriersGarbageColl
 			}
 		case *corepb.BarriersGarbageCollectionRecord_BarrierId:
 			// The barrier record itself is already deleted by DeleteBarrier; we just need to drain
-			// whatever participants are still attached to its id.
-			participantsDrained, err := c.gcDeleteBarrierParticipants(txn, r.BarrierId, participantsPageSize, &visited, req.Payload.MaxVisited)
+			// whatever participants and generations are still attached to its id.
+			drained, err := c.gcDeleteBarrierData(txn, r.BarrierId, participantsPageSize, &visited, req.Payload.MaxVisited)
 			if err != nil {
 				return nil, err
 			}
 			if visited >= req.Payload.MaxVisited {
 				goto commit
 			}
-			if participantsDrained {
+			if drained {
 				err = c.gcRecords.Delete(txn, gcRecord)
 				if err != nil {
 					return nil, err
@@ -721,6 +945,12 @@ commit:
 		return nil, err
 	}
 
+	// Trim generation history down to the retention bound.
+	err = c.trimGenerations(txn, req.Payload.MaxGenerationsPerBarrier, int(req.Payload.GenerationTrimsPageSize), &visited, req.Payload.MaxVisited)
+	if err != nil {
+		return nil, err
+	}
+
 	err = txn.Commit()
 	if err != nil {
 		return nil, err
@@ -767,6 +997,242 @@ func deletionTime(lastActivityAt int64, deleteInactiveAfterSeconds int64) int64
 	return lastActivityAt + deleteInactiveAfterSeconds*int64(time.Second)
 }
 
+// releaseThreshold returns the number of arrivals that trips the barrier:
+// ReleaseThreshold if set, otherwise ExpectedProcesses.
+func releaseThreshold(barrier *corepb.Barrier) int64 {
+	if barrier.ReleaseThreshold > 0 {
+		return barrier.ReleaseThreshold
+	}
+	return barrier.ExpectedProcesses
+}
+
+// isQuorum reports whether the barrier trips before every expected process has
+// arrived, and so accepts late arrivals.
+func isQuorum(barrier *corepb.Barrier) bool {
+	return releaseThreshold(barrier) < barrier.ExpectedProcesses
+}
+
+// retainedGenerations returns the number of past generations whose
+// participants the barrier keeps: RetainedGenerations if set, otherwise
+// defaultRetainedGenerations.
+func retainedGenerations(barrier *corepb.Barrier) int64 {
+	if barrier.RetainedGenerations > 0 {
+		return barrier.RetainedGenerations
+	}
+	return defaultRetainedGenerations
+}
+
+// pruneParticipants deletes the participants of generations that fell out of
+// the barrier's retention window, keeping the current generation and the
+// retainedGenerations generations before it. The scan starts at the barrier's
+// PrunedGeneration low-water mark, which is advanced as generations are
+// pruned, so already pruned generations are never visited again. It shares the
+// GC pass's visit budget and returns false if the budget ran out before every
+// such participant was deleted.
+func (c *Core) pruneParticipants(txn *store.Txn, barrier *corepb.Barrier, visited *int64, maxVisited int64) (bool, error) {
+	from := max(barrier.PrunedGeneration, 1)
+	to := barrier.Generation - retainedGenerations(barrier)
+	if from >= to {
+		return true, nil
+	}
+
+	participants := make([]*corepb.BarrierParticipant, 0)
+	err := c.participants.ListInGenerationRange(txn, barrier.Id.AccountId, barrier.Id.NamespaceId, barrier.Id.BarrierId, from, to, func(participant *corepb.BarrierParticipant) (bool, error) {
+		participants = append(participants, participant)
+		return *visited+int64(len(participants)) < maxVisited, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, participant := range participants {
+		err := c.participants.Delete(txn, barrier.Id.AccountId, barrier.Id.NamespaceId, barrier.Id.BarrierId, participant.Generation, participant.ProcessId)
+		if err != nil {
+			return false, err
+		}
+		*visited++
+	}
+
+	pruned := *visited < maxVisited
+	if pruned {
+		barrier.PrunedGeneration = to
+	} else if len(participants) > 0 {
+		// The last visited generation may still have participants left; resume
+		// from it on the next pass.
+		barrier.PrunedGeneration = participants[len(participants)-1].Generation
+	}
+
+	return pruned, c.barriers.Update(txn, barrier)
+}
+
+// missingProcessIds returns the roster members that have not arrived in the
+// barrier's current generation, in roster order. Returns nil for a barrier
+// without a roster.
+func missingProcessIds(barrier *corepb.Barrier) []string {
+	arrived := make(map[string]bool, len(barrier.ArrivedRosterMembers))
+	for _, processId := range barrier.ArrivedRosterMembers {
+		arrived[processId] = true
+	}
+
+	var missing []string
+	for _, processId := range barrier.Roster {
+		if !arrived[processId] {
+			missing = append(missing, processId)
+		}
+	}
+	return missing
+}
+
+// arrivedRosterMembers returns the members of the barrier's roster that have
+// arrived in its current generation, looking each of them up among the
+// participants. Used when the roster is replaced; arrivals keep the list up to
+// date otherwise.
+func (c *Core) arrivedRosterMembers(txn *store.Txn, barrier *corepb.Barrier) ([]string, error) {
+	var arrived []string
+	for _, processId := range barrier.Roster {
+		_, err := c.participants.Get(txn, barrier.Id.AccountId, barrier.Id.NamespaceId, barrier.Id.BarrierId, barrier.Generation, processId)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		arrived = append(arrived, processId)
+	}
+	return arrived, nil
+}
+
+// recordArrival updates the history row of the barrier's current generation
+// with an on-time arrival. A generation that predates history tracking gets a
+// row without opened_at.
+func (c *Core) recordArrival(txn *store.Txn, barrier *corepb.Barrier, processId string, now int64) error {
+	generation, err := c.generations.Get(txn, barrier.Id, barrier.Generation)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+		generation = &corepb.BarrierGeneration{
+			Generation: barrier.Generation,
+		}
+	}
+
+	if generation.FirstArrivalAt == 0 {
+		generation.FirstArrivalAt = now
+	}
+	generation.LastArrivalAt = now
+	generation.LastProcessId = processId
+	generation.ArrivedProcesses += 1
+
+	return c.generations.Set(txn, barrier.Id, generation)
+}
+
+// recordTrip closes the history row of the generation that just tripped (the
+// one before the barrier's now-advanced Generation), opens the row of the new
+// one, and queues the barrier for history trimming by garbage collection.
+func (c *Core) recordTrip(txn *store.Txn, barrier *corepb.Barrier, now int64) error {
+	tripped, err := c.generations.Get(txn, barrier.Id, barrier.Generation-1)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+		tripped = &corepb.BarrierGeneration{
+			Generation: barrier.Generation - 1,
+		}
+	}
+	tripped.TrippedAt = now
+
+	err = c.generations.Set(txn, barrier.Id, tripped)
+	if err != nil {
+		return err
+	}
+
+	err = c.generations.Set(txn, barrier.Id, &corepb.BarrierGeneration{
+		Generation: barrier.Generation,
+		OpenedAt:   now,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.generationTrims.Add(txn, barrier.Id)
+}
+
+// trimGenerations prunes the participants of every barrier queued for
+// trimming down to its retention window (see pruneParticipants), and deletes
+// the generation history rows that fell out of the retention bound, keeping
+// the maxGenerations most recent rows (the current generation included). A
+// maxGenerations of 0 keeps the whole history. It shares the GC pass's visit
+// budget; a barrier that is not fully trimmed within the budget stays queued
+// and is resumed on the next tick.
+func (c *Core) trimGenerations(txn *store.Txn, maxGenerations int64, pageSize int, visited *int64, maxVisited int64) error {
+	if *visited >= maxVisited {
+		return nil
+	}
+
+	records, err := c.generationTrims.List(txn, pagination.GetLimitWithDefaults(pageSize))
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if *visited >= maxVisited {
+			return nil
+		}
+
+		barrier, err := c.barriers.Get(txn, record.BarrierId)
+		if err != nil {
+			if !errors.Is(err, store.ErrNotFound) {
+				return err
+			}
+			// Barrier already gone; its history is drained with the rest of its
+			// data, so just drop the stale record.
+			if err := c.generationTrims.Delete(txn, record.BarrierId); err != nil {
+				return err
+			}
+			*visited++
+			continue
+		}
+
+		pruned, err := c.pruneParticipants(txn, barrier, visited, maxVisited)
+		if err != nil {
+			return err
+		}
+		if !pruned {
+			// Possibly more participants to prune; keep the barrier queued.
+			return nil
+		}
+
+		if maxGenerations > 0 {
+			generations := make([]int64, 0)
+			err = c.generations.ListBeforeGeneration(txn, barrier.Id, barrier.Generation-maxGenerations+1, func(generation *corepb.BarrierGeneration) (bool, error) {
+				generations = append(generations, generation.Generation)
+				return *visited+int64(len(generations)) < maxVisited, nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, generation := range generations {
+				if err := c.generations.Delete(txn, barrier.Id, generation); err != nil {
+					return err
+				}
+				*visited++
+			}
+			if *visited >= maxVisited {
+				// Possibly more rows to trim; keep the barrier queued.
+				return nil
+			}
+		}
+
+		if err := c.generationTrims.Delete(txn, barrier.Id); err != nil {
+			return err
+		}
+		*visited++
+	}
+
+	return nil
+}
+
 // deleteInactiveBarriers deletes barriers whose auto-deletion time (delete_at)
 // has passed, draining their participants first. It shares the GC pass's visit
 // budget; a barrier whose participants do not fully drain within the budget is
@@ -805,18 +1271,18 @@ func (c *Core) deleteInactiveBarriers(txn *store.Txn, now int64, barriersPageSiz
 			return err
 		}
 
-		// Drain one page of participants first.
-		participantsDrained, err := c.gcDeleteBarrierParticipants(txn, barrier.Id, participantsPageSize, visited, maxVisited)
+		// Drain one page of participants and generations first.
+		drained, err := c.gcDeleteBarrierData(txn, barrier.Id, participantsPageSize, visited, maxVisited)
 		if err != nil {
 			return err
 		}
-		if !participantsDrained {
+		if !drained {
 			// More participants remain (or the visit budget was exhausted). Leave the
 			// barrier and its deletion record in place; a later GC pass resumes.
 			return nil
 		}
 
-		// All participants drained — delete the barrier and its bookkeeping.
+		// All participants and generations drained — delete the barrier and its bookkeeping.
 		counters, err := c.counters.Get(txn, barrier.Id.AccountId, barrier.Id.NamespaceId)
 		if err != nil {
 			return err
@@ -839,6 +1305,24 @@ func (c *Core) deleteInactiveBarriers(txn *store.Txn, now int64, barriersPageSiz
 	return nil
 }
 
+// gcDeleteBarrierData drains a deleted barrier's dependent rows: one page of
+// participants, then one page of generation history, then its trim record.
+// Returns true once nothing is left; see gcDeleteBarrierParticipants.
+func (c *Core) gcDeleteBarrierData(txn *store.Txn, barrierId *corepb.BarrierId, pageSize int, visited *int64, maxVisited int64) (bool, error) {
+	participantsDrained, err := c.gcDeleteBarrierParticipants(txn, barrierId, pageSize, visited, maxVisited)
+	if err != nil || !participantsDrained {
+		return false, err
+	}
+
+	generationsDrained, err := c.gcDeleteBarrierGenerations(txn, barrierId, pageSize, visited, maxVisited)
+	if err != nil || !generationsDrained {
+		return false, err
+	}
+
+	// No-op if the barrier was never queued for trimming.
+	return true, c.generationTrims.Delete(txn, barrierId)
+}
+
 // gcDeleteBarrierParticipants deletes up to one page of participants for the given barrier,
 // decrementing the visit budget for each one. Returns true if the barrier has no remaining
 // participants (every page drained); false if the page-size limit or the visit budget cut the
@@ -863,3 +1347,27 @@ func (c *Core) gcDeleteBarrierParticipants(txn *store.Txn, barrierId *corepb.Bar
 	// Drained iff this was the last page.
 	return result.nextPaginationToken == nil, nil
 }
+
+// gcDeleteBarrierGenerations deletes up to one page of generation history rows
+// for the given barrier, with the same budget and return semantics as
+// gcDeleteBarrierParticipants.
+func (c *Core) gcDeleteBarrierGenerations(txn *store.Txn, barrierId *corepb.BarrierId, pageSize int, visited *int64, maxVisited int64) (bool, error) {
+	result, err := c.generations.List(txn, barrierId, nil, pageSize)
+	if err != nil {
+		return false, err
+	}
+
+	for _, generation := range result.generations {
+		err := c.generations.Delete(txn, barrierId, generation.Generation)
+		if err != nil {
+			return false, err
+		}
+		*visited++
+		if *visited >= maxVisited {
+			return false, nil
+		}
+	}
+
+	// Drained iff this was the last page.
+	return result.nextPaginationToken == nil, nil
+}
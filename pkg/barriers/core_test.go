@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand/v2"
+	"slices"
 	"testing"
 	"time"
 
@@ -125,6 +126,31 @@ func TestCore_CreateBarrier(t *testing.T) {
 		require.Contains(t, appErr.Message, "max number of barriers per namespace reached")
 	})
 
+	t.Run("cannot create with release threshold above expected_processes", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+
+		resp, err := core.CreateBarrier(&coreapis.CreateBarrierRequest{
+			Payload: &corepb.CreateBarrierRequest{
+				BarrierId: &corepb.BarrierId{
+					AccountId:   rand.Uint64(),
+					NamespaceId: rand.Uint64(),
+					BarrierId:   rand.Uint64(),
+				},
+				Name:                            "quorum_barrier",
+				ExpectedProcesses:               3,
+				ReleaseThreshold:                4,
+				MaxNumberOfBarriersPerNamespace: 10,
+				DeleteInactiveAfterSeconds:      int64((time.Hour).Seconds()),
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.Payload)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+	})
+
 	t.Run("cannot create with zero expected_processes", func(t *testing.T) {
 		core := newBarriersCore(t)
 		now := time.Now()
@@ -564,6 +590,44 @@ func TestCore_UpdateBarrier(t *testing.T) {
 		require.EqualValues(t, 2, barrier.Generation)
 	})
 
+	t.Run("lowering release threshold to arrived_processes trips the barrier", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		_ = createBarrier(t, core, barrierId, "test_barrier", 4, 10, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", 1, now.Add(time.Minute))
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_2", 1, now.Add(time.Minute))
+
+		resp, err := core.UpdateBarrier(&coreapis.UpdateBarrierRequest{
+			Payload: &corepb.UpdateBarrierRequest{
+				BarrierId:                  barrierId,
+				ExpectedProcesses:          4,
+				ReleaseThreshold:           2,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: int64((time.Hour).Seconds()),
+			},
+			Now: now.Add(2 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.True(t, resp.Payload.AllArrived)
+		require.EqualValues(t, 2, resp.Payload.Barrier.Generation)
+		require.EqualValues(t, 0, resp.Payload.Barrier.ArrivedProcesses)
+
+		// The two processes that missed the quorum can still arrive late
+		barrier := arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_3", 1, now.Add(3*time.Minute))
+		require.EqualValues(t, 1, barrier.LateProcesses)
+	})
+
 	t.Run("cannot update expected_processes to zero", func(t *testing.T) {
 		core := newBarriersCore(t)
 		now := time.Now()
@@ -776,6 +840,149 @@ func TestCore_ArriveAtBarrier(t *testing.T) {
 		barrier = getBarrier(t, core, barrierId)
 		require.EqualValues(t, 1, barrier.ArrivedProcesses)
 	})
+
+	t.Run("quorum trips at release threshold", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// T+0: Create barrier expecting 5 processes that releases on 3 of them
+		barrier := createQuorumBarrier(t, core, barrierId, "quorum_barrier", 5, 3, now)
+		require.EqualValues(t, 3, barrier.ReleaseThreshold)
+
+		_ = arriveAtBarrier(t, core, namespaceId, "quorum_barrier", "process_1", 1, now.Add(time.Minute))
+		_ = arriveAtBarrier(t, core, namespaceId, "quorum_barrier", "process_2", 1, now.Add(time.Minute))
+
+		barrier = getBarrier(t, core, barrierId)
+		require.EqualValues(t, 2, barrier.ArrivedProcesses)
+		require.EqualValues(t, 1, barrier.Generation)
+
+		// T+2m: The third arrival reaches the quorum and trips the barrier
+		resp, err := core.ArriveAtBarrier(&coreapis.ArriveAtBarrierRequest{
+			Payload: &corepb.ArriveAtBarrierRequest{
+				NamespaceId: namespaceId,
+				BarrierName: "quorum_barrier",
+				ProcessId:   "process_3",
+				Generation:  1,
+			},
+			Now: now.Add(2 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.True(t, resp.Payload.AllArrived)
+		require.False(t, resp.Payload.Late)
+		require.EqualValues(t, 0, resp.Payload.Barrier.ArrivedProcesses)
+		require.EqualValues(t, 2, resp.Payload.Barrier.Generation)
+	})
+
+	t.Run("late arrivals are recorded for the tripped generation", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// T+0: 2 of 3 processes trip the barrier
+		_ = createQuorumBarrier(t, core, barrierId, "quorum_barrier", 3, 2, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "quorum_barrier", "process_1", 1, now.Add(time.Minute))
+		_ = arriveAtBarrier(t, core, namespaceId, "quorum_barrier", "process_2", 1, now.Add(time.Minute))
+
+		// T+2m: process_3 arrives for generation 1, which has already tripped
+		resp, err := core.ArriveAtBarrier(&coreapis.ArriveAtBarrierRequest{
+			Payload: &corepb.ArriveAtBarrierRequest{
+				NamespaceId: namespaceId,
+				BarrierName: "quorum_barrier",
+				ProcessId:   "process_3",
+				Generation:  1,
+			},
+			Now: now.Add(2 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.True(t, resp.Payload.Late)
+		require.False(t, resp.Payload.AllArrived)
+
+		// It does not count toward generation 2
+		barrier := getBarrier(t, core, barrierId)
+		require.EqualValues(t, 2, barrier.Generation)
+		require.EqualValues(t, 0, barrier.ArrivedProcesses)
+		require.EqualValues(t, 1, barrier.LateProcesses)
+
+		participants := listBarrierParticipantsByGeneration(t, core, namespaceId, "quorum_barrier", 1).Participants
+		require.Len(t, participants, 3)
+		require.False(t, participants[0].Late)
+		require.False(t, participants[1].Late)
+		require.Equal(t, "process_3", participants[2].ProcessId)
+		require.True(t, participants[2].Late)
+		require.Empty(t, listBarrierParticipantsByGeneration(t, core, namespaceId, "quorum_barrier", 2).Participants)
+
+		// Retrying the late arrival is a no-op
+		resp, err = core.ArriveAtBarrier(&coreapis.ArriveAtBarrierRequest{
+			Payload: &corepb.ArriveAtBarrierRequest{
+				NamespaceId: namespaceId,
+				BarrierName: "quorum_barrier",
+				ProcessId:   "process_3",
+				Generation:  1,
+			},
+			Now: now.Add(3 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.True(t, resp.Payload.Late)
+		require.EqualValues(t, 1, resp.Payload.Barrier.LateProcesses)
+
+		// A generation holds at most expected_processes arrivals, so no more late
+		// arrivals are accepted
+		appErr := arriveAtBarrierWithError(t, core, namespaceId, "quorum_barrier", "process_4", 1, now.Add(4*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// The next trip resets the late counter
+		_ = arriveAtBarrier(t, core, namespaceId, "quorum_barrier", "process_1", 2, now.Add(5*time.Minute))
+		barrier = arriveAtBarrier(t, core, namespaceId, "quorum_barrier", "process_2", 2, now.Add(5*time.Minute))
+		require.EqualValues(t, 3, barrier.Generation)
+		require.EqualValues(t, 0, barrier.LateProcesses)
+
+		// Generation 1 no longer accepts late arrivals
+		appErr = arriveAtBarrierWithError(t, core, namespaceId, "quorum_barrier", "process_3", 1, now.Add(6*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		require.Contains(t, appErr.Message, "generation")
+	})
+
+	t.Run("previous generation is rejected without a quorum", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// A release threshold equal to expected_processes is not a quorum
+		_ = createQuorumBarrier(t, core, barrierId, "test_barrier", 1, 1, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", 1, now.Add(time.Minute))
+
+		appErr := arriveAtBarrierWithError(t, core, namespaceId, "test_barrier", "process_2", 1, now.Add(2*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		require.Contains(t, appErr.Message, "generation")
+	})
 }
 
 func TestCore_BarrierMetadata(t *testing.T) {
@@ -867,8 +1074,8 @@ func TestCore_BarrierMetadata(t *testing.T) {
 	require.Equal(t, participantMetadata, listResp.Participants[0].Metadata)
 }
 
-func TestCore_ListBarrierParticipants(t *testing.T) {
-	t.Run("multiple participants", func(t *testing.T) {
+func TestCore_BarrierRoster(t *testing.T) {
+	t.Run("reports missing roster members", func(t *testing.T) {
 		core := newBarriersCore(t)
 		now := time.Now()
 		namespaceId := &corepb.NamespaceId{
@@ -881,31 +1088,44 @@ func TestCore_ListBarrierParticipants(t *testing.T) {
 			BarrierId:   rand.Uint64(),
 		}
 
-		// T+0: Create barrier
-		_ = createBarrier(t, core, barrierId, "test_barrier", 3, 10, now)
-
-		// Have multiple processes arrive
-		for i := range 3 {
-			_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", fmt.Sprintf("process_%d", i), 1, now.Add(time.Duration(i+1)*time.Minute))
-		}
-
-		// List participants
-		resp := listBarrierParticipants(t, core, namespaceId, "test_barrier")
-		require.Len(t, resp.Participants, 3)
+		// T+0: Create barrier with a roster of 3 expecting all 3 of them
+		barrier := createBarrierWithRoster(t, core, barrierId, "roster_barrier", 3, []string{"shard-0", "shard-1", "shard-2"}, now)
+		require.Equal(t, []string{"shard-0", "shard-1", "shard-2"}, barrier.Roster)
 
-		// Verify all participants are present
-		participantIds := make([]string, len(resp.Participants))
-		for i, participant := range resp.Participants {
-			participantIds[i] = participant.ProcessId
-			require.EqualValues(t, 1, participant.Generation)
-		}
+		resp := getBarrierByName(t, core, namespaceId, "roster_barrier")
+		require.Equal(t, []string{"shard-0", "shard-1", "shard-2"}, resp.MissingProcessIds)
 
-		require.Contains(t, participantIds, "process_0")
-		require.Contains(t, participantIds, "process_1")
-		require.Contains(t, participantIds, "process_2")
+		// T+1m: shard-1 arrives
+		arriveResp, err := core.ArriveAtBarrier(&coreapis.ArriveAtBarrierRequest{
+			Payload: &corepb.ArriveAtBarrierRequest{
+				NamespaceId: namespaceId,
+				BarrierName: "roster_barrier",
+				ProcessId:   "shard-1",
+				Generation:  1,
+			},
+			Now: now.Add(time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, arriveResp.ApplicationError)
+		require.Equal(t, []string{"shard-0", "shard-2"}, arriveResp.Payload.MissingProcessIds)
+
+		// T+2m: shard-0 and shard-2 arrive and trip the barrier; the whole roster
+		// is missing again in generation 2
+		_ = arriveAtBarrier(t, core, namespaceId, "roster_barrier", "shard-0", 1, now.Add(2*time.Minute))
+		_ = arriveAtBarrier(t, core, namespaceId, "roster_barrier", "shard-2", 1, now.Add(2*time.Minute))
+
+		getResp, err := core.GetBarrier(&coreapis.GetBarrierRequest{
+			Payload: &corepb.GetBarrierRequest{
+				BarrierId: barrierId,
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, getResp.ApplicationError)
+		require.EqualValues(t, 2, getResp.Payload.Barrier.Generation)
+		require.Equal(t, []string{"shard-0", "shard-1", "shard-2"}, getResp.Payload.MissingProcessIds)
 	})
 
-	t.Run("empty", func(t *testing.T) {
+	t.Run("rejects processes not on the roster", func(t *testing.T) {
 		core := newBarriersCore(t)
 		now := time.Now()
 		namespaceId := &corepb.NamespaceId{
@@ -918,83 +1138,160 @@ func TestCore_ListBarrierParticipants(t *testing.T) {
 			BarrierId:   rand.Uint64(),
 		}
 
-		// T+0: Create barrier
-		_ = createBarrier(t, core, barrierId, "test_barrier", 3, 10, now)
+		_ = createBarrierWithRoster(t, core, barrierId, "roster_barrier", 2, []string{"shard-0", "shard-1"}, now)
 
-		// List participants before any have arrived
-		resp1 := listBarrierParticipants(t, core, namespaceId, "test_barrier")
-		require.Empty(t, resp1.Participants)
+		appErr := arriveAtBarrierWithError(t, core, namespaceId, "roster_barrier", "shard-7", 1, now.Add(time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// No participant row was recorded
+		barrier := getBarrier(t, core, barrierId)
+		require.EqualValues(t, 0, barrier.ArrivedProcesses)
+		require.Empty(t, listBarrierParticipants(t, core, namespaceId, "roster_barrier").Participants)
 	})
 
-	t.Run("nonexistent barrier", func(t *testing.T) {
+	t.Run("barrier without roster accepts any process", func(t *testing.T) {
 		core := newBarriersCore(t)
+		now := time.Now()
 		namespaceId := &corepb.NamespaceId{
 			AccountId:   rand.Uint64(),
 			NamespaceId: rand.Uint64(),
 		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
 
-		// Try to list participants of nonexistent barrier
-		appErr := listBarrierParticipantsWithError(t, core, namespaceId, "nonexistent_barrier")
-		require.Contains(t, appErr.Message, "barrier not found")
-		require.Equal(t, mrpc.NotFound, appErr.Code)
+		_ = createBarrier(t, core, barrierId, "open_barrier", 2, 10, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "open_barrier", "anyone", 1, now.Add(time.Minute))
+
+		resp := getBarrierByName(t, core, namespaceId, "open_barrier")
+		require.Empty(t, resp.MissingProcessIds)
 	})
-}
 
-func TestCore_SnapshotAndRestore(t *testing.T) {
-	now := time.Now()
-	namespaceId := &corepb.NamespaceId{
-		AccountId:   rand.Uint64(),
-		NamespaceId: rand.Uint64(),
-	}
-	barrierId := &corepb.BarrierId{
-		AccountId:   namespaceId.AccountId,
-		NamespaceId: namespaceId.NamespaceId,
-		BarrierId:   rand.Uint64(),
-	}
+	t.Run("cannot expect more processes than the roster holds", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		barrierId := &corepb.BarrierId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+			BarrierId:   rand.Uint64(),
+		}
 
-	// Create two barrier cores for testing snapshot and restore
-	core1 := newBarriersCore(t)
-	core2 := newBarriersCore(t)
+		resp, err := core.CreateBarrier(&coreapis.CreateBarrierRequest{
+			Payload: &corepb.CreateBarrierRequest{
+				BarrierId:                       barrierId,
+				Name:                            "roster_barrier",
+				ExpectedProcesses:               3,
+				MaxNumberOfBarriersPerNamespace: 10,
+				DeleteInactiveAfterSeconds:      int64((time.Hour).Seconds()),
+				Roster:                          []string{"shard-0", "shard-1"},
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.Payload)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+
+		_ = createBarrierWithRoster(t, core, barrierId, "roster_barrier", 2, []string{"shard-0", "shard-1"}, now)
+
+		updateResp, err := core.UpdateBarrier(&coreapis.UpdateBarrierRequest{
+			Payload: &corepb.UpdateBarrierRequest{
+				BarrierId:                  barrierId,
+				ExpectedProcesses:          2,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: int64((time.Hour).Seconds()),
+				Roster:                     []string{"shard-0"},
+			},
+			Now: now.Add(time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, updateResp.Payload)
+		require.NotNil(t, updateResp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, updateResp.ApplicationError.Code)
+	})
 
-	// T+0: Create barrier
-	_ = createBarrier(t, core1, barrierId, "test_barrier", 3, 10, now)
+	t.Run("update replaces the roster", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
 
-	// T+1m: First process arrives
-	_ = arriveAtBarrier(t, core1, namespaceId, "test_barrier", "process_1", 1, now.Add(time.Minute))
+		_ = createBarrierWithRoster(t, core, barrierId, "roster_barrier", 2, []string{"shard-0", "shard-1"}, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "roster_barrier", "shard-0", 1, now.Add(time.Minute))
 
-	// Take snapshot at this point
-	snapshot := core1.Snapshot()
+		// T+2m: Swap shard-1 for shard-2. The arrival of shard-0 still counts.
+		updateResp, err := core.UpdateBarrier(&coreapis.UpdateBarrierRequest{
+			Payload: &corepb.UpdateBarrierRequest{
+				BarrierId:                  barrierId,
+				ExpectedProcesses:          2,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: int64((time.Hour).Seconds()),
+				Roster:                     []string{"shard-0", "shard-2"},
+			},
+			Now: now.Add(2 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, updateResp.ApplicationError)
+		require.EqualValues(t, 1, updateResp.Payload.Barrier.ArrivedProcesses)
+		require.Equal(t, []string{"shard-2"}, updateResp.Payload.MissingProcessIds)
 
-	// T+2m: Second process arrives (after snapshot)
-	_ = arriveAtBarrier(t, core1, namespaceId, "test_barrier", "process_2", 1, now.Add(2*time.Minute))
+		appErr := arriveAtBarrierWithError(t, core, namespaceId, "roster_barrier", "shard-1", 1, now.Add(3*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
 
-	// Write snapshot to buffer
-	buf := bytes.NewBuffer(nil)
-	err := snapshot.Write(buf)
-	require.NoError(t, err)
+		barrier := arriveAtBarrier(t, core, namespaceId, "roster_barrier", "shard-2", 1, now.Add(3*time.Minute))
+		require.EqualValues(t, 2, barrier.Generation)
+	})
 
-	// Restore snapshot to second core
-	err = core2.Restore(io.NopCloser(buf))
-	require.NoError(t, err)
+	t.Run("roster set later counts earlier arrivals", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
 
-	// Verify the restored state matches the snapshot state
-	barrier := getBarrier(t, core2, barrierId)
+		_ = createBarrier(t, core, barrierId, "open_barrier", 3, 10, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "open_barrier", "shard-1", 1, now.Add(time.Minute))
 
-	require.Equal(t, "test_barrier", barrier.Name)
-	require.EqualValues(t, 1, barrier.ArrivedProcesses) // Only process_1 arrived before snapshot
+		// T+2m: Add a roster; shard-1 has already arrived in this generation
+		updateResp, err := core.UpdateBarrier(&coreapis.UpdateBarrierRequest{
+			Payload: &corepb.UpdateBarrierRequest{
+				BarrierId:                  barrierId,
+				ExpectedProcesses:          3,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: int64((time.Hour).Seconds()),
+				Roster:                     []string{"shard-0", "shard-1", "shard-2"},
+			},
+			Now: now.Add(2 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, updateResp.ApplicationError)
+		require.Equal(t, []string{"shard-0", "shard-2"}, updateResp.Payload.MissingProcessIds)
 
-	// List participants in restored state
-	resp := listBarrierParticipants(t, core2, namespaceId, "test_barrier")
-	require.Len(t, resp.Participants, 1)
-	require.Equal(t, "process_1", resp.Participants[0].ProcessId)
+		// T+3m: shard-2 arrives
+		_ = arriveAtBarrier(t, core, namespaceId, "open_barrier", "shard-2", 1, now.Add(3*time.Minute))
 
-	// Verify that the original core has different state (it should have 2 participants)
-	resp2 := listBarrierParticipants(t, core1, namespaceId, "test_barrier")
-	require.Len(t, resp2.Participants, 2)
+		resp := getBarrierByName(t, core, namespaceId, "open_barrier")
+		require.Equal(t, []string{"shard-0"}, resp.MissingProcessIds)
+	})
 }
 
-func TestCore_RunBarriersGarbageCollection(t *testing.T) {
-	t.Run("delete_barrier_drains_participants", func(t *testing.T) {
+func TestCore_BarrierPayloads(t *testing.T) {
+	t.Run("payloads are returned per generation", func(t *testing.T) {
 		core := newBarriersCore(t)
 		now := time.Now()
 		namespaceId := &corepb.NamespaceId{
@@ -1007,23 +1304,492 @@ func TestCore_RunBarriersGarbageCollection(t *testing.T) {
 			BarrierId:   rand.Uint64(),
 		}
 
-		// Barrier with enough participants that a single GC pass cannot drain everything.
-		const expectedParticipants = 25
-		_ = createBarrier(t, core, barrierId, "barrier_to_delete", expectedParticipants, 10, now)
-		for i := range expectedParticipants {
-			_ = arriveAtBarrier(t, core, namespaceId, "barrier_to_delete", fmt.Sprintf("process_%d", i), 1, now)
-		}
+		_ = createBarrier(t, core, barrierId, "gather_barrier", 2, 10, now)
 
-		// Sibling barrier in the same namespace — must survive GC.
-		siblingId := &corepb.BarrierId{
+		// T+1m: Generation 1 trips with two payloads
+		arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-0", 1, []byte("boundary-0"), now.Add(time.Minute))
+		arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-1", 1, []byte("boundary-1"), now.Add(time.Minute))
+
+		// T+2m: One arrival in generation 2
+		arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-0", 2, []byte("checkpoint-7"), now.Add(2*time.Minute))
+
+		resp := listBarrierParticipantsByGeneration(t, core, namespaceId, "gather_barrier", 1)
+		require.Len(t, resp.Participants, 2)
+		require.Equal(t, "shard-0", resp.Participants[0].ProcessId)
+		require.Equal(t, []byte("boundary-0"), resp.Participants[0].Payload)
+		require.Equal(t, "shard-1", resp.Participants[1].ProcessId)
+		require.Equal(t, []byte("boundary-1"), resp.Participants[1].Payload)
+
+		resp = listBarrierParticipantsByGeneration(t, core, namespaceId, "gather_barrier", 2)
+		require.Len(t, resp.Participants, 1)
+		require.Equal(t, []byte("checkpoint-7"), resp.Participants[0].Payload)
+
+		// Generation 0 lists every generation
+		resp = listBarrierParticipants(t, core, namespaceId, "gather_barrier")
+		require.Len(t, resp.Participants, 3)
+	})
+
+	t.Run("past generations beyond retention are pruned by GC", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
 			AccountId:   namespaceId.AccountId,
 			NamespaceId: namespaceId.NamespaceId,
 			BarrierId:   rand.Uint64(),
 		}
-		_ = createBarrier(t, core, siblingId, "barrier_to_keep", 2, 10, now)
-		_ = arriveAtBarrier(t, core, namespaceId, "barrier_to_keep", "alive_process", 1, now)
 
-		// Delete the target barrier — enqueues a barrier-scoped GC record. The barrier row is
+		resp, err := core.CreateBarrier(&coreapis.CreateBarrierRequest{
+			Payload: &corepb.CreateBarrierRequest{
+				BarrierId:                       barrierId,
+				Name:                            "gather_barrier",
+				ExpectedProcesses:               1,
+				MaxNumberOfBarriersPerNamespace: 10,
+				DeleteInactiveAfterSeconds:      int64((time.Hour).Seconds()),
+				RetainedGenerations:             2,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.EqualValues(t, 2, resp.Payload.Barrier.RetainedGenerations)
+
+		// Trip generations 1 through 4
+		for generation := int64(1); generation <= 4; generation++ {
+			arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-0", generation, []byte("payload"), now.Add(time.Duration(generation)*time.Minute))
+		}
+
+		barrier := getBarrier(t, core, barrierId)
+		require.EqualValues(t, 5, barrier.Generation)
+
+		// Trips leave pruning to GC
+		require.Len(t, listBarrierParticipants(t, core, namespaceId, "gather_barrier").Participants, 4)
+
+		runBarriersGarbageCollection(t, core, now.Add(5*time.Minute), 10, 10, 100, 100)
+
+		barrier = getBarrier(t, core, barrierId)
+		require.EqualValues(t, 3, barrier.PrunedGeneration)
+
+		// Only the 2 most recent tripped generations are kept
+		require.Empty(t, listBarrierParticipantsByGeneration(t, core, namespaceId, "gather_barrier", 1).Participants)
+		require.Empty(t, listBarrierParticipantsByGeneration(t, core, namespaceId, "gather_barrier", 2).Participants)
+		require.Len(t, listBarrierParticipantsByGeneration(t, core, namespaceId, "gather_barrier", 3).Participants, 1)
+		require.Len(t, listBarrierParticipantsByGeneration(t, core, namespaceId, "gather_barrier", 4).Participants, 1)
+	})
+
+	t.Run("zero retention keeps the default number of generations", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		_ = createBarrier(t, core, barrierId, "gather_barrier", 1, 10, now)
+		for generation := int64(1); generation <= defaultRetainedGenerations+2; generation++ {
+			arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-0", generation, nil, now.Add(time.Duration(generation)*time.Minute))
+		}
+
+		runBarriersGarbageCollection(t, core, now.Add(time.Hour), 10, 10, 100, 100)
+
+		participants := listBarrierParticipants(t, core, namespaceId, "gather_barrier").Participants
+		require.Len(t, participants, defaultRetainedGenerations)
+		require.EqualValues(t, 3, participants[0].Generation)
+	})
+
+	t.Run("lowering retention on update queues pruning", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		_ = createBarrier(t, core, barrierId, "gather_barrier", 1, 10, now)
+		for generation := int64(1); generation <= 4; generation++ {
+			arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-0", generation, nil, now)
+		}
+
+		// The default retention keeps all 4 tripped generations
+		runBarriersGarbageCollection(t, core, now, 10, 10, 100, 100)
+		require.Len(t, listBarrierParticipants(t, core, namespaceId, "gather_barrier").Participants, 4)
+
+		updateResp, err := core.UpdateBarrier(&coreapis.UpdateBarrierRequest{
+			Payload: &corepb.UpdateBarrierRequest{
+				BarrierId:                  barrierId,
+				ExpectedProcesses:          1,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: int64((time.Hour).Seconds()),
+				RetainedGenerations:        1,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, updateResp.ApplicationError)
+
+		runBarriersGarbageCollection(t, core, now, 10, 10, 100, 100)
+
+		participants := listBarrierParticipants(t, core, namespaceId, "gather_barrier").Participants
+		require.Len(t, participants, 1)
+		require.EqualValues(t, 4, participants[0].Generation)
+	})
+
+	t.Run("pruning resumes from the low-water mark under a tight budget", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		resp, err := core.CreateBarrier(&coreapis.CreateBarrierRequest{
+			Payload: &corepb.CreateBarrierRequest{
+				BarrierId:                       barrierId,
+				Name:                            "gather_barrier",
+				ExpectedProcesses:               2,
+				MaxNumberOfBarriersPerNamespace: 10,
+				DeleteInactiveAfterSeconds:      int64((time.Hour).Seconds()),
+				RetainedGenerations:             1,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+
+		// Trip generations 1 through 5, two participants each
+		for generation := int64(1); generation <= 5; generation++ {
+			arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-0", generation, nil, now)
+			arriveAtBarrierWithPayload(t, core, namespaceId, "gather_barrier", "shard-1", generation, nil, now)
+		}
+
+		// One participant per pass: the low-water mark advances with the pruned generations
+		marks := make([]int64, 0)
+		passes := 0
+		for ; passes < 100; passes++ {
+			runBarriersGarbageCollection(t, core, now, 10, 10, 100, 1)
+			marks = append(marks, getBarrier(t, core, barrierId).PrunedGeneration)
+
+			if len(listBarrierParticipants(t, core, namespaceId, "gather_barrier").Participants) == 2 {
+				break
+			}
+		}
+		require.Less(t, passes, 100, "GC did not converge within 100 passes")
+		require.True(t, slices.IsSorted(marks))
+
+		// One more pass finishes the barrier and records the final mark
+		runBarriersGarbageCollection(t, core, now, 10, 10, 100, 100)
+		require.EqualValues(t, 5, getBarrier(t, core, barrierId).PrunedGeneration)
+
+		participants := listBarrierParticipants(t, core, namespaceId, "gather_barrier").Participants
+		require.Len(t, participants, 2)
+		require.EqualValues(t, 5, participants[0].Generation)
+
+		txn := core.badgerStore.View()
+		records, err := core.generationTrims.List(txn, 10)
+		txn.Discard()
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+}
+
+func TestCore_ListBarrierParticipants(t *testing.T) {
+	t.Run("multiple participants", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// T+0: Create barrier
+		_ = createBarrier(t, core, barrierId, "test_barrier", 3, 10, now)
+
+		// Have multiple processes arrive
+		for i := range 3 {
+			_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", fmt.Sprintf("process_%d", i), 1, now.Add(time.Duration(i+1)*time.Minute))
+		}
+
+		// List participants
+		resp := listBarrierParticipants(t, core, namespaceId, "test_barrier")
+		require.Len(t, resp.Participants, 3)
+
+		// Verify all participants are present
+		participantIds := make([]string, len(resp.Participants))
+		for i, participant := range resp.Participants {
+			participantIds[i] = participant.ProcessId
+			require.EqualValues(t, 1, participant.Generation)
+		}
+
+		require.Contains(t, participantIds, "process_0")
+		require.Contains(t, participantIds, "process_1")
+		require.Contains(t, participantIds, "process_2")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// T+0: Create barrier
+		_ = createBarrier(t, core, barrierId, "test_barrier", 3, 10, now)
+
+		// List participants before any have arrived
+		resp1 := listBarrierParticipants(t, core, namespaceId, "test_barrier")
+		require.Empty(t, resp1.Participants)
+	})
+
+	t.Run("nonexistent barrier", func(t *testing.T) {
+		core := newBarriersCore(t)
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		// Try to list participants of nonexistent barrier
+		appErr := listBarrierParticipantsWithError(t, core, namespaceId, "nonexistent_barrier")
+		require.Contains(t, appErr.Message, "barrier not found")
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+}
+
+func TestCore_ListBarrierGenerations(t *testing.T) {
+	t.Run("records history", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// T+0: Create barrier, generation 1 is open
+		_ = createBarrier(t, core, barrierId, "test_barrier", 2, 10, now)
+
+		resp := listBarrierGenerations(t, core, namespaceId, "test_barrier")
+		require.Len(t, resp.Generations, 1)
+		require.EqualValues(t, 1, resp.Generations[0].Generation)
+		require.Equal(t, now.UnixNano(), resp.Generations[0].OpenedAt)
+		require.Zero(t, resp.Generations[0].TrippedAt)
+		require.Zero(t, resp.Generations[0].FirstArrivalAt)
+
+		// T+1m, T+2m: Both processes arrive and trip generation 1
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", 1, now.Add(time.Minute))
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_2", 1, now.Add(2*time.Minute))
+
+		// T+3m: One process arrives in generation 2
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_2", 2, now.Add(3*time.Minute))
+
+		resp = listBarrierGenerations(t, core, namespaceId, "test_barrier")
+		require.Len(t, resp.Generations, 2)
+
+		first := resp.Generations[0]
+		require.EqualValues(t, 1, first.Generation)
+		require.Equal(t, now.UnixNano(), first.OpenedAt)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), first.TrippedAt)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), first.FirstArrivalAt)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), first.LastArrivalAt)
+		require.Equal(t, "process_2", first.LastProcessId)
+		require.EqualValues(t, 2, first.ArrivedProcesses)
+
+		second := resp.Generations[1]
+		require.EqualValues(t, 2, second.Generation)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), second.OpenedAt)
+		require.Zero(t, second.TrippedAt)
+		require.Equal(t, now.Add(3*time.Minute).UnixNano(), second.FirstArrivalAt)
+		require.Equal(t, "process_2", second.LastProcessId)
+		require.EqualValues(t, 1, second.ArrivedProcesses)
+	})
+
+	t.Run("trip on update", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// T+0: Create barrier, one of three processes arrives
+		barrier := createBarrier(t, core, barrierId, "test_barrier", 3, 10, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", 1, now.Add(time.Minute))
+
+		// T+2m: Lowering expected processes to 1 trips generation 1
+		_ = updateBarrier(t, core, barrierId, "", 1, barrier.Version, now.Add(2*time.Minute))
+
+		resp := listBarrierGenerations(t, core, namespaceId, "test_barrier")
+		require.Len(t, resp.Generations, 2)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), resp.Generations[0].TrippedAt)
+		require.Equal(t, "process_1", resp.Generations[0].LastProcessId)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), resp.Generations[1].OpenedAt)
+	})
+
+	t.Run("late arrivals are not recorded", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// Quorum of 1 out of 2: the first arrival trips, the second is late
+		_ = createQuorumBarrier(t, core, barrierId, "test_barrier", 2, 1, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", 1, now.Add(time.Minute))
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_2", 1, now.Add(2*time.Minute))
+
+		resp := listBarrierGenerations(t, core, namespaceId, "test_barrier")
+		require.Len(t, resp.Generations, 2)
+		require.Equal(t, "process_1", resp.Generations[0].LastProcessId)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), resp.Generations[0].LastArrivalAt)
+		require.EqualValues(t, 1, resp.Generations[0].ArrivedProcesses)
+	})
+
+	t.Run("nonexistent barrier", func(t *testing.T) {
+		core := newBarriersCore(t)
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		resp, err := core.ListBarrierGenerations(&coreapis.ListBarrierGenerationsRequest{
+			Payload: &corepb.ListBarrierGenerationsRequest{
+				NamespaceId: namespaceId,
+				BarrierName: "nonexistent_barrier",
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+	})
+}
+
+func TestCore_SnapshotAndRestore(t *testing.T) {
+	now := time.Now()
+	namespaceId := &corepb.NamespaceId{
+		AccountId:   rand.Uint64(),
+		NamespaceId: rand.Uint64(),
+	}
+	barrierId := &corepb.BarrierId{
+		AccountId:   namespaceId.AccountId,
+		NamespaceId: namespaceId.NamespaceId,
+		BarrierId:   rand.Uint64(),
+	}
+
+	// Create two barrier cores for testing snapshot and restore
+	core1 := newBarriersCore(t)
+	core2 := newBarriersCore(t)
+
+	// T+0: Create barrier
+	_ = createBarrier(t, core1, barrierId, "test_barrier", 3, 10, now)
+
+	// T+1m: First process arrives
+	_ = arriveAtBarrier(t, core1, namespaceId, "test_barrier", "process_1", 1, now.Add(time.Minute))
+
+	// Take snapshot at this point
+	snapshot := core1.Snapshot()
+
+	// T+2m: Second process arrives (after snapshot)
+	_ = arriveAtBarrier(t, core1, namespaceId, "test_barrier", "process_2", 1, now.Add(2*time.Minute))
+
+	// Write snapshot to buffer
+	buf := bytes.NewBuffer(nil)
+	err := snapshot.Write(buf)
+	require.NoError(t, err)
+
+	// Restore snapshot to second core
+	err = core2.Restore(io.NopCloser(buf))
+	require.NoError(t, err)
+
+	// Verify the restored state matches the snapshot state
+	barrier := getBarrier(t, core2, barrierId)
+
+	require.Equal(t, "test_barrier", barrier.Name)
+	require.EqualValues(t, 1, barrier.ArrivedProcesses) // Only process_1 arrived before snapshot
+
+	// List participants in restored state
+	resp := listBarrierParticipants(t, core2, namespaceId, "test_barrier")
+	require.Len(t, resp.Participants, 1)
+	require.Equal(t, "process_1", resp.Participants[0].ProcessId)
+
+	// Verify that the original core has different state (it should have 2 participants)
+	resp2 := listBarrierParticipants(t, core1, namespaceId, "test_barrier")
+	require.Len(t, resp2.Participants, 2)
+}
+
+func TestCore_RunBarriersGarbageCollection(t *testing.T) {
+	t.Run("delete_barrier_drains_participants", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// Barrier with enough participants that a single GC pass cannot drain everything.
+		const expectedParticipants = 25
+		_ = createBarrier(t, core, barrierId, "barrier_to_delete", expectedParticipants, 10, now)
+		for i := range expectedParticipants {
+			_ = arriveAtBarrier(t, core, namespaceId, "barrier_to_delete", fmt.Sprintf("process_%d", i), 1, now)
+		}
+
+		// Sibling barrier in the same namespace — must survive GC.
+		siblingId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+		_ = createBarrier(t, core, siblingId, "barrier_to_keep", 2, 10, now)
+		_ = arriveAtBarrier(t, core, namespaceId, "barrier_to_keep", "alive_process", 1, now)
+
+		// Delete the target barrier — enqueues a barrier-scoped GC record. The barrier row is
 		// gone immediately, but participants are left for the GC to drain.
 		_ = deleteBarrier(t, core, namespaceId, "barrier_to_delete", rand.Uint64(), now)
 
@@ -1057,6 +1823,13 @@ func TestCore_RunBarriersGarbageCollection(t *testing.T) {
 		require.NoError(t, err)
 		require.Empty(t, leftover.participants)
 
+		// Its generation history must be gone too.
+		txn = core.badgerStore.View()
+		leftoverGenerations, err := core.generations.List(txn, barrierId, nil, 100)
+		txn.Discard()
+		require.NoError(t, err)
+		require.Empty(t, leftoverGenerations.generations)
+
 		// The sibling barrier and its participant must still be intact.
 		sibling := getBarrier(t, core, siblingId)
 		require.EqualValues(t, 1, sibling.ArrivedProcesses)
@@ -1125,6 +1898,65 @@ func TestCore_RunBarriersGarbageCollection(t *testing.T) {
 		keepParticipants := listBarrierParticipants(t, core, keepNs, "keep_barrier")
 		require.Len(t, keepParticipants.Participants, 1)
 	})
+
+	t.Run("trims_generation_history", func(t *testing.T) {
+		core := newBarriersCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		barrierId := &corepb.BarrierId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			BarrierId:   rand.Uint64(),
+		}
+
+		// Trip a single-process barrier 8 times: generations 1..9 have history rows.
+		_ = createBarrier(t, core, barrierId, "test_barrier", 1, 10, now)
+		for generation := int64(1); generation <= 8; generation++ {
+			_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", generation, now)
+		}
+		require.Len(t, listBarrierGenerations(t, core, namespaceId, "test_barrier").Generations, 9)
+
+		// GC without a retention bound keeps everything.
+		runBarriersGarbageCollectionWithGenerations(t, core, now, 0, 100)
+		require.Len(t, listBarrierGenerations(t, core, namespaceId, "test_barrier").Generations, 9)
+
+		// One more trip: generations 1..10 have history rows, and the barrier is queued again.
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", 9, now)
+		require.Len(t, listBarrierGenerations(t, core, namespaceId, "test_barrier").Generations, 10)
+
+		// With a bound of 3 and a tight budget, GC converges over several passes to the
+		// three most recent generations.
+		passes := 0
+		for ; passes < 100; passes++ {
+			runBarriersGarbageCollectionWithGenerations(t, core, now, 3, 2)
+
+			txn := core.badgerStore.View()
+			records, err := core.generationTrims.List(txn, 10)
+			txn.Discard()
+			require.NoError(t, err)
+			if len(records) == 0 {
+				break
+			}
+		}
+		require.Greater(t, passes, 1, "GC should require multiple passes under a tight budget")
+		require.Less(t, passes, 100, "GC did not converge within 100 passes")
+
+		generations := listBarrierGenerations(t, core, namespaceId, "test_barrier").Generations
+		require.Len(t, generations, 3)
+		require.EqualValues(t, 8, generations[0].Generation)
+		require.EqualValues(t, 9, generations[1].Generation)
+		require.EqualValues(t, 10, generations[2].Generation)
+
+		// The next trip queues the barrier again.
+		_ = arriveAtBarrier(t, core, namespaceId, "test_barrier", "process_1", 10, now)
+		runBarriersGarbageCollectionWithGenerations(t, core, now, 3, 100)
+		generations = listBarrierGenerations(t, core, namespaceId, "test_barrier").Generations
+		require.Len(t, generations, 3)
+		require.EqualValues(t, 9, generations[0].Generation)
+	})
 }
 
 func TestCore_LastActivityAt(t *testing.T) {
@@ -1241,6 +2073,56 @@ func createBarrierWithDeletion(t *testing.T, core *Core, barrierId *corepb.Barri
 	return resp.Payload.Barrier
 }
 
+func createBarrierWithRoster(t *testing.T, core *Core, barrierId *corepb.BarrierId, name string, expectedProcesses int64, roster []string, now time.Time) *corepb.Barrier {
+	t.Helper()
+
+	resp, err := core.CreateBarrier(&coreapis.CreateBarrierRequest{
+		Payload: &corepb.CreateBarrierRequest{
+			BarrierId:                       barrierId,
+			Name:                            name,
+			Description:                     "Test barrier description",
+			ExpectedProcesses:               expectedProcesses,
+			MaxNumberOfBarriersPerNamespace: 100,
+			DeleteInactiveAfterSeconds:      int64((time.Hour).Seconds()),
+			Roster:                          roster,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Barrier)
+
+	return resp.Payload.Barrier
+}
+
+func createQuorumBarrier(t *testing.T, core *Core, barrierId *corepb.BarrierId, name string, expectedProcesses int64, releaseThreshold int64, now time.Time) *corepb.Barrier {
+	t.Helper()
+
+	resp, err := core.CreateBarrier(&coreapis.CreateBarrierRequest{
+		Payload: &corepb.CreateBarrierRequest{
+			BarrierId:                       barrierId,
+			Name:                            name,
+			Description:                     "Test barrier description",
+			ExpectedProcesses:               expectedProcesses,
+			ReleaseThreshold:                releaseThreshold,
+			MaxNumberOfBarriersPerNamespace: 100,
+			DeleteInactiveAfterSeconds:      int64((time.Hour).Seconds()),
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Barrier)
+
+	return resp.Payload.Barrier
+}
+
 func newBarriersCore(t *testing.T) *Core {
 	t.Helper()
 
@@ -1320,6 +2202,29 @@ func arriveAtBarrier(t *testing.T, core *Core, namespaceId *corepb.NamespaceId,
 	return resp.Payload.Barrier
 }
 
+func arriveAtBarrierWithPayload(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, barrierName string, processId string, generation int64, payload []byte, now time.Time) *corepb.Barrier {
+	t.Helper()
+
+	resp, err := core.ArriveAtBarrier(&coreapis.ArriveAtBarrierRequest{
+		Payload: &corepb.ArriveAtBarrierRequest{
+			NamespaceId: namespaceId,
+			BarrierName: barrierName,
+			ProcessId:   processId,
+			Generation:  generation,
+			Payload:     payload,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Barrier)
+
+	return resp.Payload.Barrier
+}
+
 func arriveAtBarrierWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, barrierName string, processId string, generation int64, now time.Time) *mrpc.Error {
 	t.Helper()
 
@@ -1411,6 +2316,43 @@ func listBarrierParticipants(t *testing.T, core *Core, namespaceId *corepb.Names
 	return resp.Payload
 }
 
+func listBarrierGenerations(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, barrierName string) *corepb.ListBarrierGenerationsResponse {
+	t.Helper()
+
+	resp, err := core.ListBarrierGenerations(&coreapis.ListBarrierGenerationsRequest{
+		Payload: &corepb.ListBarrierGenerationsRequest{
+			NamespaceId: namespaceId,
+			BarrierName: barrierName,
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload
+}
+
+func listBarrierParticipantsByGeneration(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, barrierName string, generation int64) *corepb.ListBarrierParticipantsResponse {
+	t.Helper()
+
+	resp, err := core.ListBarrierParticipants(&coreapis.ListBarrierParticipantsRequest{
+		Payload: &corepb.ListBarrierParticipantsRequest{
+			NamespaceId: namespaceId,
+			BarrierName: barrierName,
+			Generation:  generation,
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload
+}
+
 func listBarrierParticipantsWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, barrierName string) *mrpc.Error {
 	t.Helper()
 
@@ -1565,6 +2507,27 @@ func runBarriersGarbageCollection(t *testing.T, core *Core, now time.Time, gcRec
 	require.NotNil(t, resp.Payload)
 }
 
+func runBarriersGarbageCollectionWithGenerations(t *testing.T, core *Core, now time.Time, maxGenerationsPerBarrier, maxVisited int64) {
+	t.Helper()
+
+	resp, err := core.RunBarriersGarbageCollection(&coreapis.RunBarriersGarbageCollectionRequest{
+		Payload: &corepb.RunBarriersGarbageCollectionRequest{
+			GcRecordsPageSize:            10,
+			GcRecordBarriersPageSize:     10,
+			GcRecordParticipantsPageSize: 100,
+			MaxVisited:                   maxVisited,
+			MaxGenerationsPerBarrier:     maxGenerationsPerBarrier,
+			GenerationTrimsPageSize:      10,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+}
+
 // TestCore_SplitSnapshotRestore proves the portable, bounds-filtered snapshot
 // contract on the barriers core: a parent core's snapshot is restored into
 // two child cores with disjoint bounds (sharing ONE Badger store with the
@@ -1653,6 +2616,8 @@ var ownedTablePrefixes = [][]byte{
 	tablePrefixCounters,
 	tablePrefixGCRecords,
 	tablePrefixDeletionRecords,
+	tablePrefixGenerations,
+	tablePrefixGenerationTrims,
 }
 
 // countOwnedRows counts the physical rows under every storage prefix the core
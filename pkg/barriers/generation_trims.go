@@ -0,0 +1,88 @@
+package barriers
+
+import (
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/sharding"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// generationTrimsTable queues barriers whose participants or generation
+// history may exceed the retention bound. A barrier is added every time it
+// trips or its retention changes, and removed once garbage collection has
+// trimmed it, so a GC pass only visits barriers that actually grew.
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. barrier id
+type generationTrimsTable struct {
+	table *honey.BinaryTable[*corepb.BarriersGenerationTrimRecord, corepb.BarriersGenerationTrimRecord]
+}
+
+func newGenerationTrimsTable(replicaPrefix []byte) *generationTrimsTable {
+	return &generationTrimsTable{
+		table: honey.NewBinaryTable[*corepb.BarriersGenerationTrimRecord, corepb.BarriersGenerationTrimRecord](
+			utils.ConcatBytes(replicaPrefix, tablePrefixGenerationTrims),
+		),
+	}
+}
+
+// Clear deletes every trim record row of this shard.
+func (t *generationTrimsTable) Clear(badgerStore *store.BadgerStore) error {
+	return badgerStore.DeletePrefix(t.table.TableId())
+}
+
+// EachEntity streams every trim record as (canonical key, stored value).
+func (t *generationTrimsTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed trim record and, if owned, inserts it
+// through Add — which re-derives its key under this table's own prefix.
+func (t *generationTrimsTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	record := &corepb.BarriersGenerationTrimRecord{}
+	if err := record.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(sharding.ByAccountAndNamespace(record.BarrierId.AccountId, record.BarrierId.NamespaceId)) {
+		return false, nil
+	}
+	return true, t.Add(txn, record.BarrierId)
+}
+
+// Add queues the barrier for trimming. Adding an already queued barrier is a
+// no-op.
+func (t *generationTrimsTable) Add(txn *store.Txn, barrierId *corepb.BarrierId) error {
+	return t.table.Set(txn,
+		t.tablePK(barrierId.AccountId, barrierId.NamespaceId, barrierId.BarrierId),
+		&corepb.BarriersGenerationTrimRecord{
+			BarrierId: barrierId,
+		},
+	)
+}
+
+func (t *generationTrimsTable) Delete(txn *store.Txn, barrierId *corepb.BarrierId) error {
+	return t.table.Delete(txn,
+		t.tablePK(barrierId.AccountId, barrierId.NamespaceId, barrierId.BarrierId))
+}
+
+// List returns up to limit queued records.
+func (t *generationTrimsTable) List(txn *store.Txn, limit int) ([]*corepb.BarriersGenerationTrimRecord, error) {
+	result, err := t.table.ListPaginated(txn, nil, nil, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+func (t *generationTrimsTable) tablePK(accountId uint64, namespaceId uint64, barrierId uint64) []byte {
+	return utils.ConcatBytes(
+		accountId,
+		namespaceId,
+		barrierId,
+	)
+}
@@ -0,0 +1,207 @@
+package barriers
+
+import (
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestGenerationTrimsTable_Add(t *testing.T) {
+	t.Run("adds a trim record", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationTrimsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId := randomBarrierId()
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Add(txn, barrierId))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		records, err := table.List(txn, 10)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, barrierId.BarrierId, records[0].BarrierId.BarrierId)
+	})
+
+	t.Run("adding an already queued barrier is a no-op", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationTrimsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId := randomBarrierId()
+
+		for range 3 {
+			txn := badgerStore.Update()
+			require.NoError(t, table.Add(txn, barrierId))
+			require.NoError(t, txn.Commit())
+		}
+
+		txn := badgerStore.View()
+		defer txn.Discard()
+
+		records, err := table.List(txn, 10)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+	})
+}
+
+func TestGenerationTrimsTable_Delete(t *testing.T) {
+	t.Run("deletes one of multiple trim records", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationTrimsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId1 := randomBarrierId()
+		barrierId2 := randomBarrierId()
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Add(txn, barrierId1))
+		require.NoError(t, table.Add(txn, barrierId2))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.Update()
+		require.NoError(t, table.Delete(txn, barrierId1))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		records, err := table.List(txn, 10)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, barrierId2.BarrierId, records[0].BarrierId.BarrierId)
+	})
+
+	t.Run("deletes a non-existent trim record", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationTrimsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Delete(txn, randomBarrierId()))
+		require.NoError(t, txn.Commit())
+	})
+}
+
+func TestGenerationTrimsTable_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		records  int
+		limit    int
+		expected int
+	}{
+		{
+			name:     "lists empty trim records",
+			records:  0,
+			limit:    10,
+			expected: 0,
+		},
+		{
+			name:     "lists every record under the limit",
+			records:  3,
+			limit:    10,
+			expected: 3,
+		},
+		{
+			name:     "lists up to limit records",
+			records:  5,
+			limit:    2,
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badgerStore, err := store.NewBadgerInMemoryStore()
+			require.NoError(t, err)
+
+			table := newGenerationTrimsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+			txn := badgerStore.Update()
+			for range tt.records {
+				require.NoError(t, table.Add(txn, randomBarrierId()))
+			}
+			require.NoError(t, txn.Commit())
+
+			txn = badgerStore.View()
+			defer txn.Discard()
+
+			records, err := table.List(txn, tt.limit)
+			require.NoError(t, err)
+			require.Len(t, records, tt.expected)
+		})
+	}
+
+	t.Run("pages through records by deleting listed ones", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationTrimsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		queued := make(map[uint64]bool)
+		txn := badgerStore.Update()
+		for range 5 {
+			barrierId := randomBarrierId()
+			queued[barrierId.BarrierId] = true
+			require.NoError(t, table.Add(txn, barrierId))
+		}
+		require.NoError(t, txn.Commit())
+
+		// Drain the queue two records at a time, the way garbage collection does
+		seen := make(map[uint64]bool)
+		for pages := 0; pages < 3; pages++ {
+			txn := badgerStore.Update()
+			records, err := table.List(txn, 2)
+			require.NoError(t, err)
+			for _, record := range records {
+				require.False(t, seen[record.BarrierId.BarrierId])
+				seen[record.BarrierId.BarrierId] = true
+				require.NoError(t, table.Delete(txn, record.BarrierId))
+			}
+			require.NoError(t, txn.Commit())
+		}
+		require.Equal(t, queued, seen)
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		records, err := table.List(txn, 10)
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+
+	t.Run("lists records of every account", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationTrimsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId1 := &corepb.BarrierId{AccountId: 1, NamespaceId: 1, BarrierId: 1}
+		barrierId2 := &corepb.BarrierId{AccountId: 2, NamespaceId: 1, BarrierId: 1}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Add(txn, barrierId2))
+		require.NoError(t, table.Add(txn, barrierId1))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		records, err := table.List(txn, 10)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.EqualValues(t, 1, records[0].BarrierId.AccountId)
+		require.EqualValues(t, 2, records[1].BarrierId.AccountId)
+	})
+}
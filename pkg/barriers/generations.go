@@ -0,0 +1,145 @@
+package barriers
+
+import (
+	"fmt"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/pagination"
+	"github.com/evrblk/grackle/pkg/sharding"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// generationsTable is a table of per-generation barrier history rows, one per
+// generation of a barrier
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. barrier id
+//
+// Table Sort Key:
+// 1. generation
+type generationsTable struct {
+	table *honey.BinaryTable[*corepb.BarrierGeneration, corepb.BarrierGeneration]
+}
+
+// newGenerationsTable scopes the table under the shard-unique prefix; see
+// newBarriersTable.
+func newGenerationsTable(replicaPrefix []byte) *generationsTable {
+	return &generationsTable{
+		table: honey.NewBinaryTable[*corepb.BarrierGeneration, corepb.BarrierGeneration](
+			utils.ConcatBytes(replicaPrefix, tablePrefixGenerations),
+		),
+	}
+}
+
+// Clear deletes every generation row.
+func (t *generationsTable) Clear(badgerStore *store.BadgerStore) error {
+	return badgerStore.DeletePrefix(t.table.TableId())
+}
+
+// EachEntity streams every generation as (canonical key, stored value).
+func (t *generationsTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed generation and, if owned, inserts it. As
+// with participants, the identity lives in the canonical key:
+// <8-byte account id><8-byte namespace id><8-byte barrier id><8-byte generation>.
+func (t *generationsTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	if len(key) < 8+8+8 {
+		return false, fmt.Errorf("generation key has %d bytes, want at least 24", len(key))
+	}
+	accountId := utils.BytesToUint64(key[0:8])
+	namespaceId := utils.BytesToUint64(key[8:16])
+	if !bounds.Owns(sharding.ByAccountAndNamespace(accountId, namespaceId)) {
+		return false, nil
+	}
+
+	generation := &corepb.BarrierGeneration{}
+	if err := generation.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	return true, t.table.Set(txn, key, generation)
+}
+
+func (t *generationsTable) Get(txn *store.Txn, barrierId *corepb.BarrierId, generation int64) (*corepb.BarrierGeneration, error) {
+	return t.table.Get(txn,
+		utils.ConcatBytes(
+			t.tablePK(barrierId.AccountId, barrierId.NamespaceId, barrierId.BarrierId),
+			t.tableSK(generation)))
+}
+
+func (t *generationsTable) Set(txn *store.Txn, barrierId *corepb.BarrierId, generation *corepb.BarrierGeneration) error {
+	return t.table.Set(txn,
+		utils.ConcatBytes(
+			t.tablePK(barrierId.AccountId, barrierId.NamespaceId, barrierId.BarrierId),
+			t.tableSK(generation.Generation)),
+		generation)
+}
+
+func (t *generationsTable) Delete(txn *store.Txn, barrierId *corepb.BarrierId, generation int64) error {
+	return t.table.Delete(txn,
+		utils.ConcatBytes(
+			t.tablePK(barrierId.AccountId, barrierId.NamespaceId, barrierId.BarrierId),
+			t.tableSK(generation)))
+}
+
+type listGenerationsResult struct {
+	generations             []*corepb.BarrierGeneration
+	nextPaginationToken     *corepb.PaginationToken
+	previousPaginationToken *corepb.PaginationToken
+}
+
+// List returns a page of a barrier's generations, oldest first.
+func (t *generationsTable) List(txn *store.Txn, barrierId *corepb.BarrierId,
+	paginationToken *corepb.PaginationToken, limit int) (*listGenerationsResult, error) {
+	result, err := t.table.ListPaginated(txn,
+		t.tablePK(barrierId.AccountId, barrierId.NamespaceId, barrierId.BarrierId),
+		pagination.CoreToMonstera(paginationToken),
+		limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listGenerationsResult{
+		generations:             result.Items,
+		nextPaginationToken:     pagination.MonsteraToCore(result.NextPaginationToken),
+		previousPaginationToken: pagination.MonsteraToCore(result.PreviousPaginationToken),
+	}, nil
+}
+
+// ListBeforeGeneration calls fn for each of a barrier's generations older than
+// the given one, oldest first, until fn returns false.
+func (t *generationsTable) ListBeforeGeneration(txn *store.Txn, barrierId *corepb.BarrierId, generation int64, fn func(generation *corepb.BarrierGeneration) (bool, error)) error {
+	if generation <= 1 {
+		// Generations start at 1, nothing precedes it
+		return nil
+	}
+
+	pk := t.tablePK(barrierId.AccountId, barrierId.NamespaceId, barrierId.BarrierId)
+	return t.table.ListInRange(txn, utils.ConcatBytes(pk, int64(1)), utils.ConcatBytes(pk, generation), false, func(g *corepb.BarrierGeneration) (bool, error) {
+		if g.Generation >= generation {
+			return false, nil
+		}
+		return fn(g)
+	})
+}
+
+func (t *generationsTable) tablePK(accountId uint64, namespaceId uint64, barrierId uint64) []byte {
+	return utils.ConcatBytes(
+		accountId,
+		namespaceId,
+		barrierId,
+	)
+}
+
+func (t *generationsTable) tableSK(generation int64) []byte {
+	return utils.ConcatBytes(
+		generation,
+	)
+}
@@ -0,0 +1,281 @@
+package barriers
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestGenerationsTable_Get(t *testing.T) {
+	t.Run("get existing generation", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId := randomBarrierId()
+		generation := &corepb.BarrierGeneration{
+			Generation:       1,
+			OpenedAt:         rand.Int64(),
+			ArrivedProcesses: 2,
+			LastProcessId:    "process_1",
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, barrierId, generation))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		actual, err := table.Get(txn, barrierId, 1)
+		require.NoError(t, err)
+		require.Equal(t, generation.OpenedAt, actual.OpenedAt)
+		require.Equal(t, generation.ArrivedProcesses, actual.ArrivedProcesses)
+		require.Equal(t, generation.LastProcessId, actual.LastProcessId)
+	})
+
+	t.Run("get non-existent generation", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		txn := badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, randomBarrierId(), 1)
+		require.True(t, errors.Is(err, store.ErrNotFound))
+	})
+}
+
+func TestGenerationsTable_Delete(t *testing.T) {
+	t.Run("delete only the targeted generation", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId := randomBarrierId()
+		setGenerations(t, badgerStore, table, barrierId, 1, 3)
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Delete(txn, barrierId, 2))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, barrierId, 2)
+		require.True(t, errors.Is(err, store.ErrNotFound))
+
+		_, err = table.Get(txn, barrierId, 1)
+		require.NoError(t, err)
+		_, err = table.Get(txn, barrierId, 3)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete non-existent generation", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Delete(txn, randomBarrierId(), 1))
+		require.NoError(t, txn.Commit())
+	})
+}
+
+func TestGenerationsTable_List(t *testing.T) {
+	t.Run("list generations oldest first", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId := randomBarrierId()
+		setGenerations(t, badgerStore, table, barrierId, 1, 5)
+
+		txn := badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, barrierId, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.generations, 5)
+		for i, generation := range result.generations {
+			require.EqualValues(t, i+1, generation.Generation)
+		}
+		require.Nil(t, result.nextPaginationToken)
+		require.Nil(t, result.previousPaginationToken)
+	})
+
+	t.Run("list generations with pagination", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId := randomBarrierId()
+		setGenerations(t, badgerStore, table, barrierId, 1, 5)
+
+		txn := badgerStore.View()
+		defer txn.Discard()
+
+		page1, err := table.List(txn, barrierId, nil, 3)
+		require.NoError(t, err)
+		require.Len(t, page1.generations, 3)
+		require.NotNil(t, page1.nextPaginationToken)
+		require.Nil(t, page1.previousPaginationToken)
+
+		page2, err := table.List(txn, barrierId, page1.nextPaginationToken, 3)
+		require.NoError(t, err)
+		require.Len(t, page2.generations, 2)
+		require.Nil(t, page2.nextPaginationToken)
+		require.NotNil(t, page2.previousPaginationToken)
+
+		require.EqualValues(t, 4, page2.generations[0].Generation)
+		require.EqualValues(t, 5, page2.generations[1].Generation)
+	})
+
+	t.Run("list generations from different barriers are isolated", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId1 := randomBarrierId()
+		barrierId2 := &corepb.BarrierId{
+			AccountId:   barrierId1.AccountId,
+			NamespaceId: barrierId1.NamespaceId,
+			BarrierId:   barrierId1.BarrierId + 1,
+		}
+		setGenerations(t, badgerStore, table, barrierId1, 1, 2)
+		setGenerations(t, badgerStore, table, barrierId2, 1, 3)
+
+		txn := badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, barrierId1, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.generations, 2)
+
+		result, err = table.List(txn, barrierId2, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.generations, 3)
+	})
+
+	t.Run("list empty barrier", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		txn := badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, randomBarrierId(), nil, 100)
+		require.NoError(t, err)
+		require.Empty(t, result.generations)
+		require.Nil(t, result.nextPaginationToken)
+		require.Nil(t, result.previousPaginationToken)
+	})
+}
+
+func TestGenerationsTable_ListBeforeGeneration(t *testing.T) {
+	tests := []struct {
+		name       string
+		generation int64
+		expected   []int64
+	}{
+		{
+			name:       "nothing precedes the first generation",
+			generation: 1,
+			expected:   nil,
+		},
+		{
+			name:       "lists older generations only",
+			generation: 4,
+			expected:   []int64{1, 2, 3},
+		},
+		{
+			name:       "lists every generation past the last one",
+			generation: 10,
+			expected:   []int64{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badgerStore, err := store.NewBadgerInMemoryStore()
+			require.NoError(t, err)
+
+			table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+			barrierId := randomBarrierId()
+			setGenerations(t, badgerStore, table, barrierId, 1, 5)
+
+			txn := badgerStore.View()
+			defer txn.Discard()
+
+			var actual []int64
+			err = table.ListBeforeGeneration(txn, barrierId, tt.generation, func(generation *corepb.BarrierGeneration) (bool, error) {
+				actual = append(actual, generation.Generation)
+				return true, nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+
+	t.Run("stops early", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newGenerationsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		barrierId := randomBarrierId()
+		setGenerations(t, badgerStore, table, barrierId, 1, 5)
+
+		txn := badgerStore.View()
+		defer txn.Discard()
+
+		var actual []int64
+		err = table.ListBeforeGeneration(txn, barrierId, 5, func(generation *corepb.BarrierGeneration) (bool, error) {
+			actual = append(actual, generation.Generation)
+			return len(actual) < 2, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2}, actual)
+	})
+}
+
+func randomBarrierId() *corepb.BarrierId {
+	return &corepb.BarrierId{
+		AccountId:   rand.Uint64(),
+		NamespaceId: rand.Uint64(),
+		BarrierId:   rand.Uint64(),
+	}
+}
+
+// setGenerations stores history rows for generations from through to, inclusive.
+func setGenerations(t *testing.T, badgerStore *store.BadgerStore, table *generationsTable, barrierId *corepb.BarrierId, from int64, to int64) {
+	t.Helper()
+
+	txn := badgerStore.Update()
+	defer txn.Discard()
+
+	for generation := from; generation <= to; generation++ {
+		err := table.Set(txn, barrierId, &corepb.BarrierGeneration{
+			Generation: generation,
+			OpenedAt:   rand.Int64(),
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, txn.Commit())
+}
@@ -114,6 +114,43 @@ func (t *participantsTable) List(txn *store.Txn, accountId uint64, namespaceId u
 	}, nil
 }
 
+// ListByGeneration returns a page of the participants that arrived in a single
+// generation.
+func (t *participantsTable) ListByGeneration(txn *store.Txn, accountId uint64, namespaceId uint64, barrierId uint64, generation int64,
+	paginationToken *corepb.PaginationToken, limit int) (*listParticipantResult, error) {
+	result, err := t.table.ListPaginated(txn,
+		utils.ConcatBytes(
+			t.tablePK(accountId, namespaceId, barrierId),
+			generation),
+		pagination.CoreToMonstera(paginationToken),
+		limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listParticipantResult{
+		participants:            result.Items,
+		nextPaginationToken:     pagination.MonsteraToCore(result.NextPaginationToken),
+		previousPaginationToken: pagination.MonsteraToCore(result.PreviousPaginationToken),
+	}, nil
+}
+
+// ListInGenerationRange calls fn for each participant of the generations in
+// [from, to), in generation and process id order, until fn returns false.
+func (t *participantsTable) ListInGenerationRange(txn *store.Txn, accountId uint64, namespaceId uint64, barrierId uint64, from int64, to int64, fn func(participant *corepb.BarrierParticipant) (bool, error)) error {
+	if from >= to {
+		return nil
+	}
+
+	pk := t.tablePK(accountId, namespaceId, barrierId)
+	return t.table.ListInRange(txn, utils.ConcatBytes(pk, from), utils.ConcatBytes(pk, to), false, func(participant *corepb.BarrierParticipant) (bool, error) {
+		if participant.Generation >= to {
+			return false, nil
+		}
+		return fn(participant)
+	})
+}
+
 func (t *participantsTable) tablePK(accountId uint64, namespaceId uint64, barrierId uint64) []byte {
 	return utils.ConcatBytes(
 		accountId,
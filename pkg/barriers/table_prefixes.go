@@ -15,4 +15,6 @@ var (
 	tablePrefixGCRecords          = []byte{0x03}
 	tablePrefixDeletionRecords    = []byte{0x04}
 	tablePrefixParticipants       = []byte{0x05}
+	tablePrefixGenerations        = []byte{0x06}
+	tablePrefixGenerationTrims    = []byte{0x07}
 )
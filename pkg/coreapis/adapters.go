@@ -298,6 +298,33 @@ func (a *GrackleLocksCoreAdapter) Update(rpcReqBytes []byte) (*monstera.UpdateRe
 			}
 			rpcResp.Data = methodRespBytes
 		}
+	case 9:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleLocks", "TransferLock", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleLocks", "TransferLock", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.TransferLockRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleLocksCore.TransferLock(&TransferLockRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
 	default:
 		return nil, fmt.Errorf("no matching handlers")
 	}
@@ -486,6 +513,33 @@ func (a *GrackleLocksCoreAdapter) Read(rpcReqBytes []byte) (*monstera.ReadRespon
 			}
 			rpcResp.Data = methodRespBytes
 		}
+	case 7:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleLocks", "ListLockWaiters", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleLocks", "ListLockWaiters", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.ListLockWaitersRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleLocksCore.ListLockWaiters(&ListLockWaitersRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
 	default:
 		return nil, fmt.Errorf("no matching handlers")
 	}
@@ -815,6 +869,60 @@ func (a *GrackleSemaphoresCoreAdapter) Update(rpcReqBytes []byte) (*monstera.Upd
 			}
 			rpcResp.Data = methodRespBytes
 		}
+	case 11:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleSemaphores", "AdjustSemaphoreHold", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleSemaphores", "AdjustSemaphoreHold", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.AdjustSemaphoreHoldRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleSemaphoresCore.AdjustSemaphoreHold(&AdjustSemaphoreHoldRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 12:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleSemaphores", "TransferSemaphoreHold", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleSemaphores", "TransferSemaphoreHold", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.TransferSemaphoreHoldRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleSemaphoresCore.TransferSemaphoreHold(&TransferSemaphoreHoldRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
 	default:
 		return nil, fmt.Errorf("no matching handlers")
 	}
@@ -1057,6 +1165,60 @@ func (a *GrackleSemaphoresCoreAdapter) Read(rpcReqBytes []byte) (*monstera.ReadR
 			}
 			rpcResp.Data = methodRespBytes
 		}
+	case 9:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleSemaphores", "ListSemaphoreWaiters", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleSemaphores", "ListSemaphoreWaiters", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.ListSemaphoreWaitersRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleSemaphoresCore.ListSemaphoreWaiters(&ListSemaphoreWaitersRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 10:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleSemaphores", "GetSemaphoreStats", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleSemaphores", "GetSemaphoreStats", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.GetSemaphoreStatsRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleSemaphoresCore.GetSemaphoreStats(&GetSemaphoreStatsRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
 	default:
 		return nil, fmt.Errorf("no matching handlers")
 	}
@@ -1528,6 +1690,222 @@ func (a *GrackleWaitGroupsCoreAdapter) Update(rpcReqBytes []byte) (*monstera.Upd
 			}
 			rpcResp.Data = methodRespBytes
 		}
+	case 7:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "AddJobsToWaitGroup", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "AddJobsToWaitGroup", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.AddJobsToWaitGroupRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.AddJobsToWaitGroup(&AddJobsToWaitGroupRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 8:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "AddToWaitGroup", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "AddToWaitGroup", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.AddToWaitGroupRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.AddToWaitGroup(&AddToWaitGroupRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 9:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "UncompleteJobsFromWaitGroup", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "UncompleteJobsFromWaitGroup", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.UncompleteJobsFromWaitGroupRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.UncompleteJobsFromWaitGroup(&UncompleteJobsFromWaitGroupRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 10:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "CancelWaitGroup", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "CancelWaitGroup", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.CancelWaitGroupRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.CancelWaitGroup(&CancelWaitGroupRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 11:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "CompleteJobsFromWaitGroupStripe", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "CompleteJobsFromWaitGroupStripe", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.CompleteJobsFromWaitGroupStripeRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.CompleteJobsFromWaitGroupStripe(&CompleteJobsFromWaitGroupStripeRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 12:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "AggregateWaitGroupStripes", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "AggregateWaitGroupStripes", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.AggregateWaitGroupStripesRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.AggregateWaitGroupStripes(&AggregateWaitGroupStripesRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 13:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "ExtendWaitGroupStripe", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "ExtendWaitGroupStripe", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.ExtendWaitGroupStripeRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.ExtendWaitGroupStripe(&ExtendWaitGroupStripeRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 14:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "DeleteWaitGroupStripe", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "DeleteWaitGroupStripe", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.DeleteWaitGroupStripeRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.DeleteWaitGroupStripe(&DeleteWaitGroupStripeRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
 	default:
 		return nil, fmt.Errorf("no matching handlers")
 	}
@@ -1662,6 +2040,87 @@ func (a *GrackleWaitGroupsCoreAdapter) Read(rpcReqBytes []byte) (*monstera.ReadR
 			}
 			rpcResp.Data = methodRespBytes
 		}
+	case 5:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "ListWaitGroupPendingJobs", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "ListWaitGroupPendingJobs", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.ListWaitGroupPendingJobsRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.ListWaitGroupPendingJobs(&ListWaitGroupPendingJobsRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 6:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "GetWaitGroupStripe", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "GetWaitGroupStripe", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.GetWaitGroupStripeRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.GetWaitGroupStripe(&GetWaitGroupStripeRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
+	case 7:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleWaitGroups", "ListWaitGroupStripeCompletedJobs", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleWaitGroups", "ListWaitGroupStripeCompletedJobs", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.ListWaitGroupStripeCompletedJobsRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleWaitGroupsCore.ListWaitGroupStripeCompletedJobs(&ListWaitGroupStripeCompletedJobsRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
 	default:
 		return nil, fmt.Errorf("no matching handlers")
 	}
@@ -2017,6 +2476,33 @@ func (a *GrackleBarriersCoreAdapter) Read(rpcReqBytes []byte) (*monstera.ReadRes
 			}
 			rpcResp.Data = methodRespBytes
 		}
+	case 5:
+		rpcMethodsTotal.WithLabelValues(a.nodeId, "GrackleBarriers", "ListBarrierGenerations", a.shardId, a.replicaId).Inc()
+		defer measureSince(rpcMethodDuration.WithLabelValues(a.nodeId, "GrackleBarriers", "ListBarrierGenerations", a.shardId, a.replicaId), t1)
+
+		methodReq := corepb.ListBarrierGenerationsRequest{}
+		err := methodReq.UnmarshalBinary(rpcReq.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkShardBounds(methodReq.ShardKey(), a.shardLowerBound, a.shardUpperBound); err != nil {
+			return nil, err
+		}
+		methodResp, err := a.grackleBarriersCore.ListBarrierGenerations(&ListBarrierGenerationsRequest{
+			Now:     rpcReq.Now,
+			Payload: &methodReq,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.Error = methodResp.ApplicationError
+		if methodResp.Payload != nil {
+			methodRespBytes, err := methodResp.Payload.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			rpcResp.Data = methodRespBytes
+		}
 	default:
 		return nil, fmt.Errorf("no matching handlers")
 	}
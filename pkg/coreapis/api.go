@@ -22,6 +22,8 @@ type ListLockLeasesByProcessIdRequest = mrpc.ReadRequest[*corepb.ListLockLeasesB
 type ListLockLeasesByProcessIdResponse = mrpc.ReadResponse[*corepb.ListLockLeasesByProcessIdResponse]
 type GetLockLeaseRequest = mrpc.ReadRequest[*corepb.GetLockLeaseRequest]
 type GetLockLeaseResponse = mrpc.ReadResponse[*corepb.GetLockLeaseResponse]
+type ListLockWaitersRequest = mrpc.ReadRequest[*corepb.ListLockWaitersRequest]
+type ListLockWaitersResponse = mrpc.ReadResponse[*corepb.ListLockWaitersResponse]
 type AcquireLockRequest = mrpc.UpdateRequest[*corepb.AcquireLockRequest]
 type AcquireLockResponse = mrpc.UpdateResponse[*corepb.AcquireLockResponse]
 type ReleaseLockRequest = mrpc.UpdateRequest[*corepb.ReleaseLockRequest]
@@ -38,6 +40,8 @@ type RefreshLockLeaseRequest = mrpc.UpdateRequest[*corepb.RefreshLockLeaseReques
 type RefreshLockLeaseResponse = mrpc.UpdateResponse[*corepb.RefreshLockLeaseResponse]
 type RevokeLockLeaseRequest = mrpc.UpdateRequest[*corepb.RevokeLockLeaseRequest]
 type RevokeLockLeaseResponse = mrpc.UpdateResponse[*corepb.RevokeLockLeaseResponse]
+type TransferLockRequest = mrpc.UpdateRequest[*corepb.TransferLockRequest]
+type TransferLockResponse = mrpc.UpdateResponse[*corepb.TransferLockResponse]
 type GetSemaphoreRequest = mrpc.ReadRequest[*corepb.GetSemaphoreRequest]
 type GetSemaphoreResponse = mrpc.ReadResponse[*corepb.GetSemaphoreResponse]
 type GetSemaphoreByNameRequest = mrpc.ReadRequest[*corepb.GetSemaphoreByNameRequest]
@@ -54,6 +58,10 @@ type ListSemaphoreLeasesByProcessIdRequest = mrpc.ReadRequest[*corepb.ListSemaph
 type ListSemaphoreLeasesByProcessIdResponse = mrpc.ReadResponse[*corepb.ListSemaphoreLeasesByProcessIdResponse]
 type GetSemaphoreLeaseRequest = mrpc.ReadRequest[*corepb.GetSemaphoreLeaseRequest]
 type GetSemaphoreLeaseResponse = mrpc.ReadResponse[*corepb.GetSemaphoreLeaseResponse]
+type ListSemaphoreWaitersRequest = mrpc.ReadRequest[*corepb.ListSemaphoreWaitersRequest]
+type ListSemaphoreWaitersResponse = mrpc.ReadResponse[*corepb.ListSemaphoreWaitersResponse]
+type GetSemaphoreStatsRequest = mrpc.ReadRequest[*corepb.GetSemaphoreStatsRequest]
+type GetSemaphoreStatsResponse = mrpc.ReadResponse[*corepb.GetSemaphoreStatsResponse]
 type AcquireSemaphoreRequest = mrpc.UpdateRequest[*corepb.AcquireSemaphoreRequest]
 type AcquireSemaphoreResponse = mrpc.UpdateResponse[*corepb.AcquireSemaphoreResponse]
 type ReleaseSemaphoreRequest = mrpc.UpdateRequest[*corepb.ReleaseSemaphoreRequest]
@@ -74,6 +82,10 @@ type RevokeSemaphoreLeaseRequest = mrpc.UpdateRequest[*corepb.RevokeSemaphoreLea
 type RevokeSemaphoreLeaseResponse = mrpc.UpdateResponse[*corepb.RevokeSemaphoreLeaseResponse]
 type RefreshSemaphoreLeaseRequest = mrpc.UpdateRequest[*corepb.RefreshSemaphoreLeaseRequest]
 type RefreshSemaphoreLeaseResponse = mrpc.UpdateResponse[*corepb.RefreshSemaphoreLeaseResponse]
+type AdjustSemaphoreHoldRequest = mrpc.UpdateRequest[*corepb.AdjustSemaphoreHoldRequest]
+type AdjustSemaphoreHoldResponse = mrpc.UpdateResponse[*corepb.AdjustSemaphoreHoldResponse]
+type TransferSemaphoreHoldRequest = mrpc.UpdateRequest[*corepb.TransferSemaphoreHoldRequest]
+type TransferSemaphoreHoldResponse = mrpc.UpdateResponse[*corepb.TransferSemaphoreHoldResponse]
 type GetNamespaceRequest = mrpc.ReadRequest[*corepb.GetNamespaceRequest]
 type GetNamespaceResponse = mrpc.ReadResponse[*corepb.GetNamespaceResponse]
 type GetNamespaceByNameRequest = mrpc.ReadRequest[*corepb.GetNamespaceByNameRequest]
@@ -94,6 +106,12 @@ type ListWaitGroupsRequest = mrpc.ReadRequest[*corepb.ListWaitGroupsRequest]
 type ListWaitGroupsResponse = mrpc.ReadResponse[*corepb.ListWaitGroupsResponse]
 type ListWaitGroupCompletedJobsRequest = mrpc.ReadRequest[*corepb.ListWaitGroupCompletedJobsRequest]
 type ListWaitGroupCompletedJobsResponse = mrpc.ReadResponse[*corepb.ListWaitGroupCompletedJobsResponse]
+type ListWaitGroupPendingJobsRequest = mrpc.ReadRequest[*corepb.ListWaitGroupPendingJobsRequest]
+type ListWaitGroupPendingJobsResponse = mrpc.ReadResponse[*corepb.ListWaitGroupPendingJobsResponse]
+type GetWaitGroupStripeRequest = mrpc.ReadRequest[*corepb.GetWaitGroupStripeRequest]
+type GetWaitGroupStripeResponse = mrpc.ReadResponse[*corepb.GetWaitGroupStripeResponse]
+type ListWaitGroupStripeCompletedJobsRequest = mrpc.ReadRequest[*corepb.ListWaitGroupStripeCompletedJobsRequest]
+type ListWaitGroupStripeCompletedJobsResponse = mrpc.ReadResponse[*corepb.ListWaitGroupStripeCompletedJobsResponse]
 type UpdateWaitGroupRequest = mrpc.UpdateRequest[*corepb.UpdateWaitGroupRequest]
 type UpdateWaitGroupResponse = mrpc.UpdateResponse[*corepb.UpdateWaitGroupResponse]
 type CompleteJobsFromWaitGroupRequest = mrpc.UpdateRequest[*corepb.CompleteJobsFromWaitGroupRequest]
@@ -106,6 +124,22 @@ type RunWaitGroupsGarbageCollectionRequest = mrpc.UpdateUnshardedRequest[*corepb
 type RunWaitGroupsGarbageCollectionResponse = mrpc.UpdateResponse[*corepb.RunWaitGroupsGarbageCollectionResponse]
 type WaitGroupsDeleteNamespaceRequest = mrpc.UpdateRequest[*corepb.WaitGroupsDeleteNamespaceRequest]
 type WaitGroupsDeleteNamespaceResponse = mrpc.UpdateResponse[*corepb.WaitGroupsDeleteNamespaceResponse]
+type AddJobsToWaitGroupRequest = mrpc.UpdateRequest[*corepb.AddJobsToWaitGroupRequest]
+type AddJobsToWaitGroupResponse = mrpc.UpdateResponse[*corepb.AddJobsToWaitGroupResponse]
+type AddToWaitGroupRequest = mrpc.UpdateRequest[*corepb.AddToWaitGroupRequest]
+type AddToWaitGroupResponse = mrpc.UpdateResponse[*corepb.AddToWaitGroupResponse]
+type UncompleteJobsFromWaitGroupRequest = mrpc.UpdateRequest[*corepb.UncompleteJobsFromWaitGroupRequest]
+type UncompleteJobsFromWaitGroupResponse = mrpc.UpdateResponse[*corepb.UncompleteJobsFromWaitGroupResponse]
+type CancelWaitGroupRequest = mrpc.UpdateRequest[*corepb.CancelWaitGroupRequest]
+type CancelWaitGroupResponse = mrpc.UpdateResponse[*corepb.CancelWaitGroupResponse]
+type CompleteJobsFromWaitGroupStripeRequest = mrpc.UpdateRequest[*corepb.CompleteJobsFromWaitGroupStripeRequest]
+type CompleteJobsFromWaitGroupStripeResponse = mrpc.UpdateResponse[*corepb.CompleteJobsFromWaitGroupStripeResponse]
+type AggregateWaitGroupStripesRequest = mrpc.UpdateRequest[*corepb.AggregateWaitGroupStripesRequest]
+type AggregateWaitGroupStripesResponse = mrpc.UpdateResponse[*corepb.AggregateWaitGroupStripesResponse]
+type ExtendWaitGroupStripeRequest = mrpc.UpdateRequest[*corepb.ExtendWaitGroupStripeRequest]
+type ExtendWaitGroupStripeResponse = mrpc.UpdateResponse[*corepb.ExtendWaitGroupStripeResponse]
+type DeleteWaitGroupStripeRequest = mrpc.UpdateRequest[*corepb.DeleteWaitGroupStripeRequest]
+type DeleteWaitGroupStripeResponse = mrpc.UpdateResponse[*corepb.DeleteWaitGroupStripeResponse]
 type GetBarrierRequest = mrpc.ReadRequest[*corepb.GetBarrierRequest]
 type GetBarrierResponse = mrpc.ReadResponse[*corepb.GetBarrierResponse]
 type GetBarrierByNameRequest = mrpc.ReadRequest[*corepb.GetBarrierByNameRequest]
@@ -114,6 +148,8 @@ type ListBarriersRequest = mrpc.ReadRequest[*corepb.ListBarriersRequest]
 type ListBarriersResponse = mrpc.ReadResponse[*corepb.ListBarriersResponse]
 type ListBarrierParticipantsRequest = mrpc.ReadRequest[*corepb.ListBarrierParticipantsRequest]
 type ListBarrierParticipantsResponse = mrpc.ReadResponse[*corepb.ListBarrierParticipantsResponse]
+type ListBarrierGenerationsRequest = mrpc.ReadRequest[*corepb.ListBarrierGenerationsRequest]
+type ListBarrierGenerationsResponse = mrpc.ReadResponse[*corepb.ListBarrierGenerationsResponse]
 type CreateBarrierRequest = mrpc.UpdateRequest[*corepb.CreateBarrierRequest]
 type CreateBarrierResponse = mrpc.UpdateResponse[*corepb.CreateBarrierResponse]
 type DeleteBarrierRequest = mrpc.UpdateRequest[*corepb.DeleteBarrierRequest]
@@ -134,6 +170,7 @@ type GrackleClientApi interface {
 	ListLockLeases(ctx context.Context, req *corepb.ListLockLeasesRequest) (*corepb.ListLockLeasesResponse, error)
 	ListLockLeasesByProcessId(ctx context.Context, req *corepb.ListLockLeasesByProcessIdRequest) (*corepb.ListLockLeasesByProcessIdResponse, error)
 	GetLockLease(ctx context.Context, req *corepb.GetLockLeaseRequest) (*corepb.GetLockLeaseResponse, error)
+	ListLockWaiters(ctx context.Context, req *corepb.ListLockWaitersRequest) (*corepb.ListLockWaitersResponse, error)
 	AcquireLock(ctx context.Context, req *corepb.AcquireLockRequest) (*corepb.AcquireLockResponse, error)
 	ReleaseLock(ctx context.Context, req *corepb.ReleaseLockRequest) (*corepb.ReleaseLockResponse, error)
 	DeleteLock(ctx context.Context, req *corepb.DeleteLockRequest) (*corepb.DeleteLockResponse, error)
@@ -142,6 +179,7 @@ type GrackleClientApi interface {
 	CreateLockLease(ctx context.Context, req *corepb.CreateLockLeaseRequest) (*corepb.CreateLockLeaseResponse, error)
 	RefreshLockLease(ctx context.Context, req *corepb.RefreshLockLeaseRequest) (*corepb.RefreshLockLeaseResponse, error)
 	RevokeLockLease(ctx context.Context, req *corepb.RevokeLockLeaseRequest) (*corepb.RevokeLockLeaseResponse, error)
+	TransferLock(ctx context.Context, req *corepb.TransferLockRequest) (*corepb.TransferLockResponse, error)
 
 	GetSemaphore(ctx context.Context, req *corepb.GetSemaphoreRequest) (*corepb.GetSemaphoreResponse, error)
 	GetSemaphoreByName(ctx context.Context, req *corepb.GetSemaphoreByNameRequest) (*corepb.GetSemaphoreByNameResponse, error)
@@ -151,6 +189,8 @@ type GrackleClientApi interface {
 	ListSemaphoreLeases(ctx context.Context, req *corepb.ListSemaphoreLeasesRequest) (*corepb.ListSemaphoreLeasesResponse, error)
 	ListSemaphoreLeasesByProcessId(ctx context.Context, req *corepb.ListSemaphoreLeasesByProcessIdRequest) (*corepb.ListSemaphoreLeasesByProcessIdResponse, error)
 	GetSemaphoreLease(ctx context.Context, req *corepb.GetSemaphoreLeaseRequest) (*corepb.GetSemaphoreLeaseResponse, error)
+	ListSemaphoreWaiters(ctx context.Context, req *corepb.ListSemaphoreWaitersRequest) (*corepb.ListSemaphoreWaitersResponse, error)
+	GetSemaphoreStats(ctx context.Context, req *corepb.GetSemaphoreStatsRequest) (*corepb.GetSemaphoreStatsResponse, error)
 	AcquireSemaphore(ctx context.Context, req *corepb.AcquireSemaphoreRequest) (*corepb.AcquireSemaphoreResponse, error)
 	ReleaseSemaphore(ctx context.Context, req *corepb.ReleaseSemaphoreRequest) (*corepb.ReleaseSemaphoreResponse, error)
 	CreateSemaphore(ctx context.Context, req *corepb.CreateSemaphoreRequest) (*corepb.CreateSemaphoreResponse, error)
@@ -161,6 +201,8 @@ type GrackleClientApi interface {
 	CreateSemaphoreLease(ctx context.Context, req *corepb.CreateSemaphoreLeaseRequest) (*corepb.CreateSemaphoreLeaseResponse, error)
 	RevokeSemaphoreLease(ctx context.Context, req *corepb.RevokeSemaphoreLeaseRequest) (*corepb.RevokeSemaphoreLeaseResponse, error)
 	RefreshSemaphoreLease(ctx context.Context, req *corepb.RefreshSemaphoreLeaseRequest) (*corepb.RefreshSemaphoreLeaseResponse, error)
+	AdjustSemaphoreHold(ctx context.Context, req *corepb.AdjustSemaphoreHoldRequest) (*corepb.AdjustSemaphoreHoldResponse, error)
+	TransferSemaphoreHold(ctx context.Context, req *corepb.TransferSemaphoreHoldRequest) (*corepb.TransferSemaphoreHoldResponse, error)
 
 	GetNamespace(ctx context.Context, req *corepb.GetNamespaceRequest) (*corepb.GetNamespaceResponse, error)
 	GetNamespaceByName(ctx context.Context, req *corepb.GetNamespaceByNameRequest) (*corepb.GetNamespaceByNameResponse, error)
@@ -173,17 +215,29 @@ type GrackleClientApi interface {
 	GetWaitGroupByName(ctx context.Context, req *corepb.GetWaitGroupByNameRequest) (*corepb.GetWaitGroupByNameResponse, error)
 	ListWaitGroups(ctx context.Context, req *corepb.ListWaitGroupsRequest) (*corepb.ListWaitGroupsResponse, error)
 	ListWaitGroupCompletedJobs(ctx context.Context, req *corepb.ListWaitGroupCompletedJobsRequest) (*corepb.ListWaitGroupCompletedJobsResponse, error)
+	ListWaitGroupPendingJobs(ctx context.Context, req *corepb.ListWaitGroupPendingJobsRequest) (*corepb.ListWaitGroupPendingJobsResponse, error)
+	GetWaitGroupStripe(ctx context.Context, req *corepb.GetWaitGroupStripeRequest) (*corepb.GetWaitGroupStripeResponse, error)
+	ListWaitGroupStripeCompletedJobs(ctx context.Context, req *corepb.ListWaitGroupStripeCompletedJobsRequest) (*corepb.ListWaitGroupStripeCompletedJobsResponse, error)
 	UpdateWaitGroup(ctx context.Context, req *corepb.UpdateWaitGroupRequest) (*corepb.UpdateWaitGroupResponse, error)
 	CompleteJobsFromWaitGroup(ctx context.Context, req *corepb.CompleteJobsFromWaitGroupRequest) (*corepb.CompleteJobsFromWaitGroupResponse, error)
 	CreateWaitGroup(ctx context.Context, req *corepb.CreateWaitGroupRequest) (*corepb.CreateWaitGroupResponse, error)
 	DeleteWaitGroup(ctx context.Context, req *corepb.DeleteWaitGroupRequest) (*corepb.DeleteWaitGroupResponse, error)
 	RunWaitGroupsGarbageCollection(ctx context.Context, req *corepb.RunWaitGroupsGarbageCollectionRequest, shardId string) (*corepb.RunWaitGroupsGarbageCollectionResponse, error)
 	WaitGroupsDeleteNamespace(ctx context.Context, req *corepb.WaitGroupsDeleteNamespaceRequest) (*corepb.WaitGroupsDeleteNamespaceResponse, error)
+	AddJobsToWaitGroup(ctx context.Context, req *corepb.AddJobsToWaitGroupRequest) (*corepb.AddJobsToWaitGroupResponse, error)
+	AddToWaitGroup(ctx context.Context, req *corepb.AddToWaitGroupRequest) (*corepb.AddToWaitGroupResponse, error)
+	UncompleteJobsFromWaitGroup(ctx context.Context, req *corepb.UncompleteJobsFromWaitGroupRequest) (*corepb.UncompleteJobsFromWaitGroupResponse, error)
+	CancelWaitGroup(ctx context.Context, req *corepb.CancelWaitGroupRequest) (*corepb.CancelWaitGroupResponse, error)
+	CompleteJobsFromWaitGroupStripe(ctx context.Context, req *corepb.CompleteJobsFromWaitGroupStripeRequest) (*corepb.CompleteJobsFromWaitGroupStripeResponse, error)
+	AggregateWaitGroupStripes(ctx context.Context, req *corepb.AggregateWaitGroupStripesRequest) (*corepb.AggregateWaitGroupStripesResponse, error)
+	ExtendWaitGroupStripe(ctx context.Context, req *corepb.ExtendWaitGroupStripeRequest) (*corepb.ExtendWaitGroupStripeResponse, error)
+	DeleteWaitGroupStripe(ctx context.Context, req *corepb.DeleteWaitGroupStripeRequest) (*corepb.DeleteWaitGroupStripeResponse, error)
 
 	GetBarrier(ctx context.Context, req *corepb.GetBarrierRequest) (*corepb.GetBarrierResponse, error)
 	GetBarrierByName(ctx context.Context, req *corepb.GetBarrierByNameRequest) (*corepb.GetBarrierByNameResponse, error)
 	ListBarriers(ctx context.Context, req *corepb.ListBarriersRequest) (*corepb.ListBarriersResponse, error)
 	ListBarrierParticipants(ctx context.Context, req *corepb.ListBarrierParticipantsRequest) (*corepb.ListBarrierParticipantsResponse, error)
+	ListBarrierGenerations(ctx context.Context, req *corepb.ListBarrierGenerationsRequest) (*corepb.ListBarrierGenerationsResponse, error)
 	CreateBarrier(ctx context.Context, req *corepb.CreateBarrierRequest) (*corepb.CreateBarrierResponse, error)
 	DeleteBarrier(ctx context.Context, req *corepb.DeleteBarrierRequest) (*corepb.DeleteBarrierResponse, error)
 	UpdateBarrier(ctx context.Context, req *corepb.UpdateBarrierRequest) (*corepb.UpdateBarrierResponse, error)
@@ -203,6 +257,7 @@ type GrackleLocksCoreApi interface {
 	ListLockLeases(req *ListLockLeasesRequest) (*ListLockLeasesResponse, error)
 	ListLockLeasesByProcessId(req *ListLockLeasesByProcessIdRequest) (*ListLockLeasesByProcessIdResponse, error)
 	GetLockLease(req *GetLockLeaseRequest) (*GetLockLeaseResponse, error)
+	ListLockWaiters(req *ListLockWaitersRequest) (*ListLockWaitersResponse, error)
 	AcquireLock(req *AcquireLockRequest) (*AcquireLockResponse, error)
 	ReleaseLock(req *ReleaseLockRequest) (*ReleaseLockResponse, error)
 	DeleteLock(req *DeleteLockRequest) (*DeleteLockResponse, error)
@@ -211,6 +266,7 @@ type GrackleLocksCoreApi interface {
 	CreateLockLease(req *CreateLockLeaseRequest) (*CreateLockLeaseResponse, error)
 	RefreshLockLease(req *RefreshLockLeaseRequest) (*RefreshLockLeaseResponse, error)
 	RevokeLockLease(req *RevokeLockLeaseRequest) (*RevokeLockLeaseResponse, error)
+	TransferLock(req *TransferLockRequest) (*TransferLockResponse, error)
 }
 
 type GrackleSemaphoresCoreApi interface {
@@ -225,6 +281,8 @@ type GrackleSemaphoresCoreApi interface {
 	ListSemaphoreLeases(req *ListSemaphoreLeasesRequest) (*ListSemaphoreLeasesResponse, error)
 	ListSemaphoreLeasesByProcessId(req *ListSemaphoreLeasesByProcessIdRequest) (*ListSemaphoreLeasesByProcessIdResponse, error)
 	GetSemaphoreLease(req *GetSemaphoreLeaseRequest) (*GetSemaphoreLeaseResponse, error)
+	ListSemaphoreWaiters(req *ListSemaphoreWaitersRequest) (*ListSemaphoreWaitersResponse, error)
+	GetSemaphoreStats(req *GetSemaphoreStatsRequest) (*GetSemaphoreStatsResponse, error)
 	AcquireSemaphore(req *AcquireSemaphoreRequest) (*AcquireSemaphoreResponse, error)
 	ReleaseSemaphore(req *ReleaseSemaphoreRequest) (*ReleaseSemaphoreResponse, error)
 	CreateSemaphore(req *CreateSemaphoreRequest) (*CreateSemaphoreResponse, error)
@@ -235,6 +293,8 @@ type GrackleSemaphoresCoreApi interface {
 	CreateSemaphoreLease(req *CreateSemaphoreLeaseRequest) (*CreateSemaphoreLeaseResponse, error)
 	RevokeSemaphoreLease(req *RevokeSemaphoreLeaseRequest) (*RevokeSemaphoreLeaseResponse, error)
 	RefreshSemaphoreLease(req *RefreshSemaphoreLeaseRequest) (*RefreshSemaphoreLeaseResponse, error)
+	AdjustSemaphoreHold(req *AdjustSemaphoreHoldRequest) (*AdjustSemaphoreHoldResponse, error)
+	TransferSemaphoreHold(req *TransferSemaphoreHoldRequest) (*TransferSemaphoreHoldResponse, error)
 }
 
 type GrackleNamespacesCoreApi interface {
@@ -257,12 +317,23 @@ type GrackleWaitGroupsCoreApi interface {
 	GetWaitGroupByName(req *GetWaitGroupByNameRequest) (*GetWaitGroupByNameResponse, error)
 	ListWaitGroups(req *ListWaitGroupsRequest) (*ListWaitGroupsResponse, error)
 	ListWaitGroupCompletedJobs(req *ListWaitGroupCompletedJobsRequest) (*ListWaitGroupCompletedJobsResponse, error)
+	ListWaitGroupPendingJobs(req *ListWaitGroupPendingJobsRequest) (*ListWaitGroupPendingJobsResponse, error)
+	GetWaitGroupStripe(req *GetWaitGroupStripeRequest) (*GetWaitGroupStripeResponse, error)
+	ListWaitGroupStripeCompletedJobs(req *ListWaitGroupStripeCompletedJobsRequest) (*ListWaitGroupStripeCompletedJobsResponse, error)
 	UpdateWaitGroup(req *UpdateWaitGroupRequest) (*UpdateWaitGroupResponse, error)
 	CompleteJobsFromWaitGroup(req *CompleteJobsFromWaitGroupRequest) (*CompleteJobsFromWaitGroupResponse, error)
 	CreateWaitGroup(req *CreateWaitGroupRequest) (*CreateWaitGroupResponse, error)
 	DeleteWaitGroup(req *DeleteWaitGroupRequest) (*DeleteWaitGroupResponse, error)
 	RunWaitGroupsGarbageCollection(req *RunWaitGroupsGarbageCollectionRequest) (*RunWaitGroupsGarbageCollectionResponse, error)
 	WaitGroupsDeleteNamespace(req *WaitGroupsDeleteNamespaceRequest) (*WaitGroupsDeleteNamespaceResponse, error)
+	AddJobsToWaitGroup(req *AddJobsToWaitGroupRequest) (*AddJobsToWaitGroupResponse, error)
+	AddToWaitGroup(req *AddToWaitGroupRequest) (*AddToWaitGroupResponse, error)
+	UncompleteJobsFromWaitGroup(req *UncompleteJobsFromWaitGroupRequest) (*UncompleteJobsFromWaitGroupResponse, error)
+	CancelWaitGroup(req *CancelWaitGroupRequest) (*CancelWaitGroupResponse, error)
+	CompleteJobsFromWaitGroupStripe(req *CompleteJobsFromWaitGroupStripeRequest) (*CompleteJobsFromWaitGroupStripeResponse, error)
+	AggregateWaitGroupStripes(req *AggregateWaitGroupStripesRequest) (*AggregateWaitGroupStripesResponse, error)
+	ExtendWaitGroupStripe(req *ExtendWaitGroupStripeRequest) (*ExtendWaitGroupStripeResponse, error)
+	DeleteWaitGroupStripe(req *DeleteWaitGroupStripeRequest) (*DeleteWaitGroupStripeResponse, error)
 }
 
 type GrackleBarriersCoreApi interface {
@@ -273,6 +344,7 @@ type GrackleBarriersCoreApi interface {
 	GetBarrierByName(req *GetBarrierByNameRequest) (*GetBarrierByNameResponse, error)
 	ListBarriers(req *ListBarriersRequest) (*ListBarriersResponse, error)
 	ListBarrierParticipants(req *ListBarrierParticipantsRequest) (*ListBarrierParticipantsResponse, error)
+	ListBarrierGenerations(req *ListBarrierGenerationsRequest) (*ListBarrierGenerationsResponse, error)
 	CreateBarrier(req *CreateBarrierRequest) (*CreateBarrierResponse, error)
 	DeleteBarrier(req *DeleteBarrierRequest) (*DeleteBarrierResponse, error)
 	UpdateBarrier(req *UpdateBarrierRequest) (*UpdateBarrierResponse, error)
@@ -237,6 +237,41 @@ func (s *GrackleMonsteraStub) GetLockLease(ctx context.Context, methodReq *corep
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
+func (s *GrackleMonsteraStub) ListLockWaiters(ctx context.Context, methodReq *corepb.ListLockWaitersRequest) (*corepb.ListLockWaitersResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 7,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleLocks", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.ListLockWaitersResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
 func (s *GrackleMonsteraStub) AcquireLock(ctx context.Context, methodReq *corepb.AcquireLockRequest) (*corepb.AcquireLockResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
@@ -517,6 +552,41 @@ func (s *GrackleMonsteraStub) RevokeLockLease(ctx context.Context, methodReq *co
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
+func (s *GrackleMonsteraStub) TransferLock(ctx context.Context, methodReq *corepb.TransferLockRequest) (*corepb.TransferLockResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 9,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleLocks", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.TransferLockResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
 func (s *GrackleMonsteraStub) GetSemaphore(ctx context.Context, methodReq *corepb.GetSemaphoreRequest) (*corepb.GetSemaphoreResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
@@ -797,6 +867,76 @@ func (s *GrackleMonsteraStub) GetSemaphoreLease(ctx context.Context, methodReq *
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
+func (s *GrackleMonsteraStub) ListSemaphoreWaiters(ctx context.Context, methodReq *corepb.ListSemaphoreWaitersRequest) (*corepb.ListSemaphoreWaitersResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 9,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleSemaphores", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.ListSemaphoreWaitersResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) GetSemaphoreStats(ctx context.Context, methodReq *corepb.GetSemaphoreStatsRequest) (*corepb.GetSemaphoreStatsResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 10,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleSemaphores", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.GetSemaphoreStatsResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
 func (s *GrackleMonsteraStub) AcquireSemaphore(ctx context.Context, methodReq *corepb.AcquireSemaphoreRequest) (*corepb.AcquireSemaphoreResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
@@ -1147,6 +1287,76 @@ func (s *GrackleMonsteraStub) RefreshSemaphoreLease(ctx context.Context, methodR
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
+func (s *GrackleMonsteraStub) AdjustSemaphoreHold(ctx context.Context, methodReq *corepb.AdjustSemaphoreHoldRequest) (*corepb.AdjustSemaphoreHoldResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 11,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleSemaphores", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.AdjustSemaphoreHoldResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) TransferSemaphoreHold(ctx context.Context, methodReq *corepb.TransferSemaphoreHoldRequest) (*corepb.TransferSemaphoreHoldResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 12,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleSemaphores", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.TransferSemaphoreHoldResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
 func (s *GrackleMonsteraStub) GetNamespace(ctx context.Context, methodReq *corepb.GetNamespaceRequest) (*corepb.GetNamespaceResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
@@ -1497,7 +1707,7 @@ func (s *GrackleMonsteraStub) ListWaitGroupCompletedJobs(ctx context.Context, me
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) UpdateWaitGroup(ctx context.Context, methodReq *corepb.UpdateWaitGroupRequest) (*corepb.UpdateWaitGroupResponse, error) {
+func (s *GrackleMonsteraStub) ListWaitGroupPendingJobs(ctx context.Context, methodReq *corepb.ListWaitGroupPendingJobsRequest) (*corepb.ListWaitGroupPendingJobsResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1505,7 +1715,7 @@ func (s *GrackleMonsteraStub) UpdateWaitGroup(ctx context.Context, methodReq *co
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 1,
+		MethodNumber: 5,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1513,7 +1723,7 @@ func (s *GrackleMonsteraStub) UpdateWaitGroup(ctx context.Context, methodReq *co
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleWaitGroups", methodReq.ShardKey(), false, rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1523,7 +1733,7 @@ func (s *GrackleMonsteraStub) UpdateWaitGroup(ctx context.Context, methodReq *co
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.UpdateWaitGroupResponse{}
+	methodResp := &corepb.ListWaitGroupPendingJobsResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1532,7 +1742,7 @@ func (s *GrackleMonsteraStub) UpdateWaitGroup(ctx context.Context, methodReq *co
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) CompleteJobsFromWaitGroup(ctx context.Context, methodReq *corepb.CompleteJobsFromWaitGroupRequest) (*corepb.CompleteJobsFromWaitGroupResponse, error) {
+func (s *GrackleMonsteraStub) GetWaitGroupStripe(ctx context.Context, methodReq *corepb.GetWaitGroupStripeRequest) (*corepb.GetWaitGroupStripeResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1540,7 +1750,7 @@ func (s *GrackleMonsteraStub) CompleteJobsFromWaitGroup(ctx context.Context, met
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 2,
+		MethodNumber: 6,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1548,7 +1758,7 @@ func (s *GrackleMonsteraStub) CompleteJobsFromWaitGroup(ctx context.Context, met
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleWaitGroups", methodReq.ShardKey(), false, rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1558,7 +1768,7 @@ func (s *GrackleMonsteraStub) CompleteJobsFromWaitGroup(ctx context.Context, met
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.CompleteJobsFromWaitGroupResponse{}
+	methodResp := &corepb.GetWaitGroupStripeResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1567,7 +1777,7 @@ func (s *GrackleMonsteraStub) CompleteJobsFromWaitGroup(ctx context.Context, met
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) CreateWaitGroup(ctx context.Context, methodReq *corepb.CreateWaitGroupRequest) (*corepb.CreateWaitGroupResponse, error) {
+func (s *GrackleMonsteraStub) ListWaitGroupStripeCompletedJobs(ctx context.Context, methodReq *corepb.ListWaitGroupStripeCompletedJobsRequest) (*corepb.ListWaitGroupStripeCompletedJobsResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1575,7 +1785,7 @@ func (s *GrackleMonsteraStub) CreateWaitGroup(ctx context.Context, methodReq *co
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 3,
+		MethodNumber: 7,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1583,7 +1793,7 @@ func (s *GrackleMonsteraStub) CreateWaitGroup(ctx context.Context, methodReq *co
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleWaitGroups", methodReq.ShardKey(), false, rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1593,7 +1803,7 @@ func (s *GrackleMonsteraStub) CreateWaitGroup(ctx context.Context, methodReq *co
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.CreateWaitGroupResponse{}
+	methodResp := &corepb.ListWaitGroupStripeCompletedJobsResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1602,7 +1812,7 @@ func (s *GrackleMonsteraStub) CreateWaitGroup(ctx context.Context, methodReq *co
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) DeleteWaitGroup(ctx context.Context, methodReq *corepb.DeleteWaitGroupRequest) (*corepb.DeleteWaitGroupResponse, error) {
+func (s *GrackleMonsteraStub) UpdateWaitGroup(ctx context.Context, methodReq *corepb.UpdateWaitGroupRequest) (*corepb.UpdateWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1610,7 +1820,7 @@ func (s *GrackleMonsteraStub) DeleteWaitGroup(ctx context.Context, methodReq *co
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 4,
+		MethodNumber: 1,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1628,7 +1838,7 @@ func (s *GrackleMonsteraStub) DeleteWaitGroup(ctx context.Context, methodReq *co
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.DeleteWaitGroupResponse{}
+	methodResp := &corepb.UpdateWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1637,7 +1847,7 @@ func (s *GrackleMonsteraStub) DeleteWaitGroup(ctx context.Context, methodReq *co
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) RunWaitGroupsGarbageCollection(ctx context.Context, methodReq *corepb.RunWaitGroupsGarbageCollectionRequest, shardId string) (*corepb.RunWaitGroupsGarbageCollectionResponse, error) {
+func (s *GrackleMonsteraStub) CompleteJobsFromWaitGroup(ctx context.Context, methodReq *corepb.CompleteJobsFromWaitGroupRequest) (*corepb.CompleteJobsFromWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1645,7 +1855,7 @@ func (s *GrackleMonsteraStub) RunWaitGroupsGarbageCollection(ctx context.Context
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 5,
+		MethodNumber: 2,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1653,7 +1863,7 @@ func (s *GrackleMonsteraStub) RunWaitGroupsGarbageCollection(ctx context.Context
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.UpdateShard(ctx, "GrackleWaitGroups", shardId, rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1663,7 +1873,7 @@ func (s *GrackleMonsteraStub) RunWaitGroupsGarbageCollection(ctx context.Context
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.RunWaitGroupsGarbageCollectionResponse{}
+	methodResp := &corepb.CompleteJobsFromWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1672,7 +1882,7 @@ func (s *GrackleMonsteraStub) RunWaitGroupsGarbageCollection(ctx context.Context
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) WaitGroupsDeleteNamespace(ctx context.Context, methodReq *corepb.WaitGroupsDeleteNamespaceRequest) (*corepb.WaitGroupsDeleteNamespaceResponse, error) {
+func (s *GrackleMonsteraStub) CreateWaitGroup(ctx context.Context, methodReq *corepb.CreateWaitGroupRequest) (*corepb.CreateWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1680,7 +1890,7 @@ func (s *GrackleMonsteraStub) WaitGroupsDeleteNamespace(ctx context.Context, met
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 6,
+		MethodNumber: 3,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1698,7 +1908,7 @@ func (s *GrackleMonsteraStub) WaitGroupsDeleteNamespace(ctx context.Context, met
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.WaitGroupsDeleteNamespaceResponse{}
+	methodResp := &corepb.CreateWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1707,7 +1917,7 @@ func (s *GrackleMonsteraStub) WaitGroupsDeleteNamespace(ctx context.Context, met
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) GetBarrier(ctx context.Context, methodReq *corepb.GetBarrierRequest) (*corepb.GetBarrierResponse, error) {
+func (s *GrackleMonsteraStub) DeleteWaitGroup(ctx context.Context, methodReq *corepb.DeleteWaitGroupRequest) (*corepb.DeleteWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1715,7 +1925,7 @@ func (s *GrackleMonsteraStub) GetBarrier(ctx context.Context, methodReq *corepb.
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 1,
+		MethodNumber: 4,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1723,7 +1933,7 @@ func (s *GrackleMonsteraStub) GetBarrier(ctx context.Context, methodReq *corepb.
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1733,7 +1943,7 @@ func (s *GrackleMonsteraStub) GetBarrier(ctx context.Context, methodReq *corepb.
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.GetBarrierResponse{}
+	methodResp := &corepb.DeleteWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1742,7 +1952,7 @@ func (s *GrackleMonsteraStub) GetBarrier(ctx context.Context, methodReq *corepb.
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) GetBarrierByName(ctx context.Context, methodReq *corepb.GetBarrierByNameRequest) (*corepb.GetBarrierByNameResponse, error) {
+func (s *GrackleMonsteraStub) RunWaitGroupsGarbageCollection(ctx context.Context, methodReq *corepb.RunWaitGroupsGarbageCollectionRequest, shardId string) (*corepb.RunWaitGroupsGarbageCollectionResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1750,7 +1960,7 @@ func (s *GrackleMonsteraStub) GetBarrierByName(ctx context.Context, methodReq *c
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 2,
+		MethodNumber: 5,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1758,7 +1968,7 @@ func (s *GrackleMonsteraStub) GetBarrierByName(ctx context.Context, methodReq *c
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.UpdateShard(ctx, "GrackleWaitGroups", shardId, rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1768,7 +1978,7 @@ func (s *GrackleMonsteraStub) GetBarrierByName(ctx context.Context, methodReq *c
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.GetBarrierByNameResponse{}
+	methodResp := &corepb.RunWaitGroupsGarbageCollectionResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1777,7 +1987,7 @@ func (s *GrackleMonsteraStub) GetBarrierByName(ctx context.Context, methodReq *c
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) ListBarriers(ctx context.Context, methodReq *corepb.ListBarriersRequest) (*corepb.ListBarriersResponse, error) {
+func (s *GrackleMonsteraStub) WaitGroupsDeleteNamespace(ctx context.Context, methodReq *corepb.WaitGroupsDeleteNamespaceRequest) (*corepb.WaitGroupsDeleteNamespaceResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1785,7 +1995,7 @@ func (s *GrackleMonsteraStub) ListBarriers(ctx context.Context, methodReq *corep
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 3,
+		MethodNumber: 6,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1793,7 +2003,7 @@ func (s *GrackleMonsteraStub) ListBarriers(ctx context.Context, methodReq *corep
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1803,7 +2013,7 @@ func (s *GrackleMonsteraStub) ListBarriers(ctx context.Context, methodReq *corep
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.ListBarriersResponse{}
+	methodResp := &corepb.WaitGroupsDeleteNamespaceResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1812,7 +2022,7 @@ func (s *GrackleMonsteraStub) ListBarriers(ctx context.Context, methodReq *corep
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) ListBarrierParticipants(ctx context.Context, methodReq *corepb.ListBarrierParticipantsRequest) (*corepb.ListBarrierParticipantsResponse, error) {
+func (s *GrackleMonsteraStub) AddJobsToWaitGroup(ctx context.Context, methodReq *corepb.AddJobsToWaitGroupRequest) (*corepb.AddJobsToWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1820,7 +2030,7 @@ func (s *GrackleMonsteraStub) ListBarrierParticipants(ctx context.Context, metho
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 4,
+		MethodNumber: 7,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1828,7 +2038,7 @@ func (s *GrackleMonsteraStub) ListBarrierParticipants(ctx context.Context, metho
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1838,7 +2048,7 @@ func (s *GrackleMonsteraStub) ListBarrierParticipants(ctx context.Context, metho
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.ListBarrierParticipantsResponse{}
+	methodResp := &corepb.AddJobsToWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1847,7 +2057,7 @@ func (s *GrackleMonsteraStub) ListBarrierParticipants(ctx context.Context, metho
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) CreateBarrier(ctx context.Context, methodReq *corepb.CreateBarrierRequest) (*corepb.CreateBarrierResponse, error) {
+func (s *GrackleMonsteraStub) AddToWaitGroup(ctx context.Context, methodReq *corepb.AddToWaitGroupRequest) (*corepb.AddToWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1855,7 +2065,7 @@ func (s *GrackleMonsteraStub) CreateBarrier(ctx context.Context, methodReq *core
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 1,
+		MethodNumber: 8,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1863,7 +2073,7 @@ func (s *GrackleMonsteraStub) CreateBarrier(ctx context.Context, methodReq *core
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1873,7 +2083,7 @@ func (s *GrackleMonsteraStub) CreateBarrier(ctx context.Context, methodReq *core
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.CreateBarrierResponse{}
+	methodResp := &corepb.AddToWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1882,7 +2092,7 @@ func (s *GrackleMonsteraStub) CreateBarrier(ctx context.Context, methodReq *core
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) DeleteBarrier(ctx context.Context, methodReq *corepb.DeleteBarrierRequest) (*corepb.DeleteBarrierResponse, error) {
+func (s *GrackleMonsteraStub) UncompleteJobsFromWaitGroup(ctx context.Context, methodReq *corepb.UncompleteJobsFromWaitGroupRequest) (*corepb.UncompleteJobsFromWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1890,7 +2100,7 @@ func (s *GrackleMonsteraStub) DeleteBarrier(ctx context.Context, methodReq *core
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 2,
+		MethodNumber: 9,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1898,7 +2108,7 @@ func (s *GrackleMonsteraStub) DeleteBarrier(ctx context.Context, methodReq *core
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1908,7 +2118,7 @@ func (s *GrackleMonsteraStub) DeleteBarrier(ctx context.Context, methodReq *core
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.DeleteBarrierResponse{}
+	methodResp := &corepb.UncompleteJobsFromWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1917,7 +2127,7 @@ func (s *GrackleMonsteraStub) DeleteBarrier(ctx context.Context, methodReq *core
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) UpdateBarrier(ctx context.Context, methodReq *corepb.UpdateBarrierRequest) (*corepb.UpdateBarrierResponse, error) {
+func (s *GrackleMonsteraStub) CancelWaitGroup(ctx context.Context, methodReq *corepb.CancelWaitGroupRequest) (*corepb.CancelWaitGroupResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1925,7 +2135,7 @@ func (s *GrackleMonsteraStub) UpdateBarrier(ctx context.Context, methodReq *core
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 3,
+		MethodNumber: 10,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1933,7 +2143,7 @@ func (s *GrackleMonsteraStub) UpdateBarrier(ctx context.Context, methodReq *core
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1943,7 +2153,7 @@ func (s *GrackleMonsteraStub) UpdateBarrier(ctx context.Context, methodReq *core
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.UpdateBarrierResponse{}
+	methodResp := &corepb.CancelWaitGroupResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1952,7 +2162,7 @@ func (s *GrackleMonsteraStub) UpdateBarrier(ctx context.Context, methodReq *core
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) ArriveAtBarrier(ctx context.Context, methodReq *corepb.ArriveAtBarrierRequest) (*corepb.ArriveAtBarrierResponse, error) {
+func (s *GrackleMonsteraStub) CompleteJobsFromWaitGroupStripe(ctx context.Context, methodReq *corepb.CompleteJobsFromWaitGroupStripeRequest) (*corepb.CompleteJobsFromWaitGroupStripeResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1960,7 +2170,7 @@ func (s *GrackleMonsteraStub) ArriveAtBarrier(ctx context.Context, methodReq *co
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 4,
+		MethodNumber: 11,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -1968,7 +2178,7 @@ func (s *GrackleMonsteraStub) ArriveAtBarrier(ctx context.Context, methodReq *co
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1978,7 +2188,7 @@ func (s *GrackleMonsteraStub) ArriveAtBarrier(ctx context.Context, methodReq *co
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.ArriveAtBarrierResponse{}
+	methodResp := &corepb.CompleteJobsFromWaitGroupStripeResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -1987,7 +2197,7 @@ func (s *GrackleMonsteraStub) ArriveAtBarrier(ctx context.Context, methodReq *co
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) RunBarriersGarbageCollection(ctx context.Context, methodReq *corepb.RunBarriersGarbageCollectionRequest, shardId string) (*corepb.RunBarriersGarbageCollectionResponse, error) {
+func (s *GrackleMonsteraStub) AggregateWaitGroupStripes(ctx context.Context, methodReq *corepb.AggregateWaitGroupStripesRequest) (*corepb.AggregateWaitGroupStripesResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -1995,7 +2205,7 @@ func (s *GrackleMonsteraStub) RunBarriersGarbageCollection(ctx context.Context,
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 5,
+		MethodNumber: 12,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -2003,7 +2213,7 @@ func (s *GrackleMonsteraStub) RunBarriersGarbageCollection(ctx context.Context,
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.UpdateShard(ctx, "GrackleBarriers", shardId, rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -2013,7 +2223,7 @@ func (s *GrackleMonsteraStub) RunBarriersGarbageCollection(ctx context.Context,
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.RunBarriersGarbageCollectionResponse{}
+	methodResp := &corepb.AggregateWaitGroupStripesResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
@@ -2022,7 +2232,7 @@ func (s *GrackleMonsteraStub) RunBarriersGarbageCollection(ctx context.Context,
 	return methodResp, nilifyIfEmpty(rpcResp.Error)
 }
 
-func (s *GrackleMonsteraStub) BarriersDeleteNamespace(ctx context.Context, methodReq *corepb.BarriersDeleteNamespaceRequest) (*corepb.BarriersDeleteNamespaceResponse, error) {
+func (s *GrackleMonsteraStub) ExtendWaitGroupStripe(ctx context.Context, methodReq *corepb.ExtendWaitGroupStripeRequest) (*corepb.ExtendWaitGroupStripeResponse, error) {
 	methodReqBytes, err := methodReq.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -2030,7 +2240,7 @@ func (s *GrackleMonsteraStub) BarriersDeleteNamespace(ctx context.Context, metho
 
 	rpcReq := &mrpc.Request{
 		Data:         methodReqBytes,
-		MethodNumber: 6,
+		MethodNumber: 13,
 		Now:          time.Now().UnixNano(),
 	}
 	rpcReqBytes, err := rpcReq.MarshalVT()
@@ -2038,7 +2248,7 @@ func (s *GrackleMonsteraStub) BarriersDeleteNamespace(ctx context.Context, metho
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -2048,96 +2258,940 @@ func (s *GrackleMonsteraStub) BarriersDeleteNamespace(ctx context.Context, metho
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	methodResp := &corepb.BarriersDeleteNamespaceResponse{}
+	methodResp := &corepb.ExtendWaitGroupStripeResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) DeleteWaitGroupStripe(ctx context.Context, methodReq *corepb.DeleteWaitGroupStripeRequest) (*corepb.DeleteWaitGroupStripeResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 14,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleWaitGroups", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.DeleteWaitGroupStripeResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) GetBarrier(ctx context.Context, methodReq *corepb.GetBarrierRequest) (*corepb.GetBarrierResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 1,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.GetBarrierResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) GetBarrierByName(ctx context.Context, methodReq *corepb.GetBarrierByNameRequest) (*corepb.GetBarrierByNameResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 2,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.GetBarrierByNameResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) ListBarriers(ctx context.Context, methodReq *corepb.ListBarriersRequest) (*corepb.ListBarriersResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 3,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.ListBarriersResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) ListBarrierParticipants(ctx context.Context, methodReq *corepb.ListBarrierParticipantsRequest) (*corepb.ListBarrierParticipantsResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 4,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.ListBarrierParticipantsResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) ListBarrierGenerations(ctx context.Context, methodReq *corepb.ListBarrierGenerationsRequest) (*corepb.ListBarrierGenerationsResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 5,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Read(ctx, "GrackleBarriers", methodReq.ShardKey(), false, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.ListBarrierGenerationsResponse{}
 	err = methodResp.UnmarshalBinary(rpcResp.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	return methodResp, nilifyIfEmpty(rpcResp.Error)
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) CreateBarrier(ctx context.Context, methodReq *corepb.CreateBarrierRequest) (*corepb.CreateBarrierResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 1,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.CreateBarrierResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) DeleteBarrier(ctx context.Context, methodReq *corepb.DeleteBarrierRequest) (*corepb.DeleteBarrierResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 2,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.DeleteBarrierResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) UpdateBarrier(ctx context.Context, methodReq *corepb.UpdateBarrierRequest) (*corepb.UpdateBarrierResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 3,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.UpdateBarrierResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) ArriveAtBarrier(ctx context.Context, methodReq *corepb.ArriveAtBarrierRequest) (*corepb.ArriveAtBarrierResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 4,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.ArriveAtBarrierResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) RunBarriersGarbageCollection(ctx context.Context, methodReq *corepb.RunBarriersGarbageCollectionRequest, shardId string) (*corepb.RunBarriersGarbageCollectionResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 5,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.UpdateShard(ctx, "GrackleBarriers", shardId, rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.RunBarriersGarbageCollectionResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) BarriersDeleteNamespace(ctx context.Context, methodReq *corepb.BarriersDeleteNamespaceRequest) (*corepb.BarriersDeleteNamespaceResponse, error) {
+	methodReqBytes, err := methodReq.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcReq := &mrpc.Request{
+		Data:         methodReqBytes,
+		MethodNumber: 6,
+		Now:          time.Now().UnixNano(),
+	}
+	rpcReqBytes, err := rpcReq.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	rpcRespBytes, err := s.monsteraClient.Update(ctx, "GrackleBarriers", methodReq.ShardKey(), rpcReqBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResp := &mrpc.Response{}
+	err = rpcResp.UnmarshalVT(rpcRespBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	methodResp := &corepb.BarriersDeleteNamespaceResponse{}
+	err = methodResp.UnmarshalBinary(rpcResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return methodResp, nilifyIfEmpty(rpcResp.Error)
+}
+
+func (s *GrackleMonsteraStub) ListShards(applicationName string) ([]string, error) {
+	shards, err := s.monsteraClient.ListShards(applicationName)
+	if err != nil {
+		return nil, err
+	}
+	shardIds := make([]string, len(shards))
+	for i := range shards {
+		shardIds[i] = shards[i].Id
+	}
+	return shardIds, nil
+}
+
+func NewGrackleMonsteraStub(monsteraClient *monstera.Client) *GrackleMonsteraStub {
+	return &GrackleMonsteraStub{monsteraClient: monsteraClient}
+}
+
+type grackleLocksCoreNonclusteredAdapter struct {
+	core       GrackleLocksCoreApi
+	mu         sync.RWMutex
+	id         string
+	lowerBound cluster.ShardKey
+	upperBound cluster.ShardKey
+}
+
+type grackleSemaphoresCoreNonclusteredAdapter struct {
+	core       GrackleSemaphoresCoreApi
+	mu         sync.RWMutex
+	id         string
+	lowerBound cluster.ShardKey
+	upperBound cluster.ShardKey
+}
+
+type grackleNamespacesCoreNonclusteredAdapter struct {
+	core       GrackleNamespacesCoreApi
+	mu         sync.RWMutex
+	id         string
+	lowerBound cluster.ShardKey
+	upperBound cluster.ShardKey
+}
+
+type grackleWaitGroupsCoreNonclusteredAdapter struct {
+	core       GrackleWaitGroupsCoreApi
+	mu         sync.RWMutex
+	id         string
+	lowerBound cluster.ShardKey
+	upperBound cluster.ShardKey
+}
+
+type grackleBarriersCoreNonclusteredAdapter struct {
+	core       GrackleBarriersCoreApi
+	mu         sync.RWMutex
+	id         string
+	lowerBound cluster.ShardKey
+	upperBound cluster.ShardKey
+}
+
+type GrackleNonclusteredApplicationCoresFactory struct {
+	GrackleLocksCoreFactoryFunc      func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleLocksCoreApi
+	GrackleSemaphoresCoreFactoryFunc func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleSemaphoresCoreApi
+	GrackleNamespacesCoreFactoryFunc func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleNamespacesCoreApi
+	GrackleWaitGroupsCoreFactoryFunc func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleWaitGroupsCoreApi
+	GrackleBarriersCoreFactoryFunc   func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleBarriersCoreApi
+}
+type GrackleNonclusteredStub struct {
+	grackleLocksCores      []*grackleLocksCoreNonclusteredAdapter
+	grackleSemaphoresCores []*grackleSemaphoresCoreNonclusteredAdapter
+	grackleNamespacesCores []*grackleNamespacesCoreNonclusteredAdapter
+	grackleWaitGroupsCores []*grackleWaitGroupsCoreNonclusteredAdapter
+	grackleBarriersCores   []*grackleBarriersCoreNonclusteredAdapter
+}
+
+var _ GrackleClientApi = &GrackleNonclusteredStub{}
+
+func (s *GrackleNonclusteredStub) GetLock(ctx context.Context, req *corepb.GetLockRequest) (*corepb.GetLockResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.GetLock(&mrpc.ReadRequest[*corepb.GetLockRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) ListLocks(ctx context.Context, req *corepb.ListLocksRequest) (*corepb.ListLocksResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.ListLocks(&mrpc.ReadRequest[*corepb.ListLocksRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) ListLocksByLeaseId(ctx context.Context, req *corepb.ListLocksByLeaseIdRequest) (*corepb.ListLocksByLeaseIdResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.ListLocksByLeaseId(&mrpc.ReadRequest[*corepb.ListLocksByLeaseIdRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) ListLockLeases(ctx context.Context, req *corepb.ListLockLeasesRequest) (*corepb.ListLockLeasesResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.ListLockLeases(&mrpc.ReadRequest[*corepb.ListLockLeasesRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) ListLockLeasesByProcessId(ctx context.Context, req *corepb.ListLockLeasesByProcessIdRequest) (*corepb.ListLockLeasesByProcessIdResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.ListLockLeasesByProcessId(&mrpc.ReadRequest[*corepb.ListLockLeasesByProcessIdRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) GetLockLease(ctx context.Context, req *corepb.GetLockLeaseRequest) (*corepb.GetLockLeaseResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.GetLockLease(&mrpc.ReadRequest[*corepb.GetLockLeaseRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) ListLockWaiters(ctx context.Context, req *corepb.ListLockWaitersRequest) (*corepb.ListLockWaitersResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.ListLockWaiters(&mrpc.ReadRequest[*corepb.ListLockWaitersRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) AcquireLock(ctx context.Context, req *corepb.AcquireLockRequest) (*corepb.AcquireLockResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+
+			resp, err := adapter.core.AcquireLock(&mrpc.UpdateRequest[*corepb.AcquireLockRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) ReleaseLock(ctx context.Context, req *corepb.ReleaseLockRequest) (*corepb.ReleaseLockResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+
+			resp, err := adapter.core.ReleaseLock(&mrpc.UpdateRequest[*corepb.ReleaseLockRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) DeleteLock(ctx context.Context, req *corepb.DeleteLockRequest) (*corepb.DeleteLockResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+
+			resp, err := adapter.core.DeleteLock(&mrpc.UpdateRequest[*corepb.DeleteLockRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) RunLocksGarbageCollection(ctx context.Context, req *corepb.RunLocksGarbageCollectionRequest, shardId string) (*corepb.RunLocksGarbageCollectionResponse, error) {
+	for _, adapter := range s.grackleLocksCores {
+		if adapter.id == shardId {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+
+			resp, err := adapter.core.RunLocksGarbageCollection(&mrpc.UpdateUnshardedRequest[*corepb.RunLocksGarbageCollectionRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardId: %s", shardId)
+}
+
+func (s *GrackleNonclusteredStub) LocksDeleteNamespace(ctx context.Context, req *corepb.LocksDeleteNamespaceRequest) (*corepb.LocksDeleteNamespaceResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+
+			resp, err := adapter.core.LocksDeleteNamespace(&mrpc.UpdateRequest[*corepb.LocksDeleteNamespaceRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) CreateLockLease(ctx context.Context, req *corepb.CreateLockLeaseRequest) (*corepb.CreateLockLeaseResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+
+			resp, err := adapter.core.CreateLockLease(&mrpc.UpdateRequest[*corepb.CreateLockLeaseRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleMonsteraStub) ListShards(applicationName string) ([]string, error) {
-	shards, err := s.monsteraClient.ListShards(applicationName)
-	if err != nil {
-		return nil, err
-	}
-	shardIds := make([]string, len(shards))
-	for i := range shards {
-		shardIds[i] = shards[i].Id
+func (s *GrackleNonclusteredStub) RefreshLockLease(ctx context.Context, req *corepb.RefreshLockLeaseRequest) (*corepb.RefreshLockLeaseResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+
+			resp, err := adapter.core.RefreshLockLease(&mrpc.UpdateRequest[*corepb.RefreshLockLeaseRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
 	}
-	return shardIds, nil
-}
 
-func NewGrackleMonsteraStub(monsteraClient *monstera.Client) *GrackleMonsteraStub {
-	return &GrackleMonsteraStub{monsteraClient: monsteraClient}
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-type grackleLocksCoreNonclusteredAdapter struct {
-	core       GrackleLocksCoreApi
-	mu         sync.RWMutex
-	id         string
-	lowerBound cluster.ShardKey
-	upperBound cluster.ShardKey
-}
+func (s *GrackleNonclusteredStub) RevokeLockLease(ctx context.Context, req *corepb.RevokeLockLeaseRequest) (*corepb.RevokeLockLeaseResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-type grackleSemaphoresCoreNonclusteredAdapter struct {
-	core       GrackleSemaphoresCoreApi
-	mu         sync.RWMutex
-	id         string
-	lowerBound cluster.ShardKey
-	upperBound cluster.ShardKey
-}
+			resp, err := adapter.core.RevokeLockLease(&mrpc.UpdateRequest[*corepb.RevokeLockLeaseRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
 
-type grackleNamespacesCoreNonclusteredAdapter struct {
-	core       GrackleNamespacesCoreApi
-	mu         sync.RWMutex
-	id         string
-	lowerBound cluster.ShardKey
-	upperBound cluster.ShardKey
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-type grackleWaitGroupsCoreNonclusteredAdapter struct {
-	core       GrackleWaitGroupsCoreApi
-	mu         sync.RWMutex
-	id         string
-	lowerBound cluster.ShardKey
-	upperBound cluster.ShardKey
-}
+func (s *GrackleNonclusteredStub) TransferLock(ctx context.Context, req *corepb.TransferLockRequest) (*corepb.TransferLockResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleLocksCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-type grackleBarriersCoreNonclusteredAdapter struct {
-	core       GrackleBarriersCoreApi
-	mu         sync.RWMutex
-	id         string
-	lowerBound cluster.ShardKey
-	upperBound cluster.ShardKey
-}
+			resp, err := adapter.core.TransferLock(&mrpc.UpdateRequest[*corepb.TransferLockRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
 
-type GrackleNonclusteredApplicationCoresFactory struct {
-	GrackleLocksCoreFactoryFunc      func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleLocksCoreApi
-	GrackleSemaphoresCoreFactoryFunc func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleSemaphoresCoreApi
-	GrackleNamespacesCoreFactoryFunc func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleNamespacesCoreApi
-	GrackleWaitGroupsCoreFactoryFunc func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleWaitGroupsCoreApi
-	GrackleBarriersCoreFactoryFunc   func(shardId string, lowerBound cluster.ShardKey, upperBound cluster.ShardKey) GrackleBarriersCoreApi
-}
-type GrackleNonclusteredStub struct {
-	grackleLocksCores      []*grackleLocksCoreNonclusteredAdapter
-	grackleSemaphoresCores []*grackleSemaphoresCoreNonclusteredAdapter
-	grackleNamespacesCores []*grackleNamespacesCoreNonclusteredAdapter
-	grackleWaitGroupsCores []*grackleWaitGroupsCoreNonclusteredAdapter
-	grackleBarriersCores   []*grackleBarriersCoreNonclusteredAdapter
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-var _ GrackleClientApi = &GrackleNonclusteredStub{}
+func (s *GrackleNonclusteredStub) GetSemaphore(ctx context.Context, req *corepb.GetSemaphoreRequest) (*corepb.GetSemaphoreResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleSemaphoresCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-func (s *GrackleNonclusteredStub) GetLock(ctx context.Context, req *corepb.GetLockRequest) (*corepb.GetLockResponse, error) {
+			resp, err := adapter.core.GetSemaphore(&mrpc.ReadRequest[*corepb.GetSemaphoreRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
+func (s *GrackleNonclusteredStub) GetSemaphoreByName(ctx context.Context, req *corepb.GetSemaphoreByNameRequest) (*corepb.GetSemaphoreByNameResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.GetLock(&mrpc.ReadRequest[*corepb.GetLockRequest]{
+			resp, err := adapter.core.GetSemaphoreByName(&mrpc.ReadRequest[*corepb.GetSemaphoreByNameRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2155,14 +3209,14 @@ func (s *GrackleNonclusteredStub) GetLock(ctx context.Context, req *corepb.GetLo
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListLocks(ctx context.Context, req *corepb.ListLocksRequest) (*corepb.ListLocksResponse, error) {
+func (s *GrackleNonclusteredStub) ListSemaphores(ctx context.Context, req *corepb.ListSemaphoresRequest) (*corepb.ListSemaphoresResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.ListLocks(&mrpc.ReadRequest[*corepb.ListLocksRequest]{
+			resp, err := adapter.core.ListSemaphores(&mrpc.ReadRequest[*corepb.ListSemaphoresRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2180,14 +3234,14 @@ func (s *GrackleNonclusteredStub) ListLocks(ctx context.Context, req *corepb.Lis
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListLocksByLeaseId(ctx context.Context, req *corepb.ListLocksByLeaseIdRequest) (*corepb.ListLocksByLeaseIdResponse, error) {
+func (s *GrackleNonclusteredStub) ListSemaphoresByLeaseId(ctx context.Context, req *corepb.ListSemaphoresByLeaseIdRequest) (*corepb.ListSemaphoresByLeaseIdResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.ListLocksByLeaseId(&mrpc.ReadRequest[*corepb.ListLocksByLeaseIdRequest]{
+			resp, err := adapter.core.ListSemaphoresByLeaseId(&mrpc.ReadRequest[*corepb.ListSemaphoresByLeaseIdRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2205,14 +3259,14 @@ func (s *GrackleNonclusteredStub) ListLocksByLeaseId(ctx context.Context, req *c
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListLockLeases(ctx context.Context, req *corepb.ListLockLeasesRequest) (*corepb.ListLockLeasesResponse, error) {
+func (s *GrackleNonclusteredStub) ListSemaphoreHolders(ctx context.Context, req *corepb.ListSemaphoreHoldersRequest) (*corepb.ListSemaphoreHoldersResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.ListLockLeases(&mrpc.ReadRequest[*corepb.ListLockLeasesRequest]{
+			resp, err := adapter.core.ListSemaphoreHolders(&mrpc.ReadRequest[*corepb.ListSemaphoreHoldersRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2230,14 +3284,14 @@ func (s *GrackleNonclusteredStub) ListLockLeases(ctx context.Context, req *corep
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListLockLeasesByProcessId(ctx context.Context, req *corepb.ListLockLeasesByProcessIdRequest) (*corepb.ListLockLeasesByProcessIdResponse, error) {
+func (s *GrackleNonclusteredStub) ListSemaphoreLeases(ctx context.Context, req *corepb.ListSemaphoreLeasesRequest) (*corepb.ListSemaphoreLeasesResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.ListLockLeasesByProcessId(&mrpc.ReadRequest[*corepb.ListLockLeasesByProcessIdRequest]{
+			resp, err := adapter.core.ListSemaphoreLeases(&mrpc.ReadRequest[*corepb.ListSemaphoreLeasesRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2255,14 +3309,14 @@ func (s *GrackleNonclusteredStub) ListLockLeasesByProcessId(ctx context.Context,
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) GetLockLease(ctx context.Context, req *corepb.GetLockLeaseRequest) (*corepb.GetLockLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) ListSemaphoreLeasesByProcessId(ctx context.Context, req *corepb.ListSemaphoreLeasesByProcessIdRequest) (*corepb.ListSemaphoreLeasesByProcessIdResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.GetLockLease(&mrpc.ReadRequest[*corepb.GetLockLeaseRequest]{
+			resp, err := adapter.core.ListSemaphoreLeasesByProcessId(&mrpc.ReadRequest[*corepb.ListSemaphoreLeasesByProcessIdRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2280,14 +3334,14 @@ func (s *GrackleNonclusteredStub) GetLockLease(ctx context.Context, req *corepb.
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) AcquireLock(ctx context.Context, req *corepb.AcquireLockRequest) (*corepb.AcquireLockResponse, error) {
+func (s *GrackleNonclusteredStub) GetSemaphoreLease(ctx context.Context, req *corepb.GetSemaphoreLeaseRequest) (*corepb.GetSemaphoreLeaseResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.AcquireLock(&mrpc.UpdateRequest[*corepb.AcquireLockRequest]{
+			resp, err := adapter.core.GetSemaphoreLease(&mrpc.ReadRequest[*corepb.GetSemaphoreLeaseRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2305,14 +3359,14 @@ func (s *GrackleNonclusteredStub) AcquireLock(ctx context.Context, req *corepb.A
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ReleaseLock(ctx context.Context, req *corepb.ReleaseLockRequest) (*corepb.ReleaseLockResponse, error) {
+func (s *GrackleNonclusteredStub) ListSemaphoreWaiters(ctx context.Context, req *corepb.ListSemaphoreWaitersRequest) (*corepb.ListSemaphoreWaitersResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.ReleaseLock(&mrpc.UpdateRequest[*corepb.ReleaseLockRequest]{
+			resp, err := adapter.core.ListSemaphoreWaiters(&mrpc.ReadRequest[*corepb.ListSemaphoreWaitersRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2330,14 +3384,14 @@ func (s *GrackleNonclusteredStub) ReleaseLock(ctx context.Context, req *corepb.R
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) DeleteLock(ctx context.Context, req *corepb.DeleteLockRequest) (*corepb.DeleteLockResponse, error) {
+func (s *GrackleNonclusteredStub) GetSemaphoreStats(ctx context.Context, req *corepb.GetSemaphoreStatsRequest) (*corepb.GetSemaphoreStatsResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.DeleteLock(&mrpc.UpdateRequest[*corepb.DeleteLockRequest]{
+			resp, err := adapter.core.GetSemaphoreStats(&mrpc.ReadRequest[*corepb.GetSemaphoreStatsRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2355,13 +3409,14 @@ func (s *GrackleNonclusteredStub) DeleteLock(ctx context.Context, req *corepb.De
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) RunLocksGarbageCollection(ctx context.Context, req *corepb.RunLocksGarbageCollectionRequest, shardId string) (*corepb.RunLocksGarbageCollectionResponse, error) {
-	for _, adapter := range s.grackleLocksCores {
-		if adapter.id == shardId {
+func (s *GrackleNonclusteredStub) AcquireSemaphore(ctx context.Context, req *corepb.AcquireSemaphoreRequest) (*corepb.AcquireSemaphoreResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleSemaphoresCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.RunLocksGarbageCollection(&mrpc.UpdateUnshardedRequest[*corepb.RunLocksGarbageCollectionRequest]{
+			resp, err := adapter.core.AcquireSemaphore(&mrpc.UpdateRequest[*corepb.AcquireSemaphoreRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2376,17 +3431,17 @@ func (s *GrackleNonclusteredStub) RunLocksGarbageCollection(ctx context.Context,
 		}
 	}
 
-	return nil, fmt.Errorf("no shard found for shardId: %s", shardId)
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) LocksDeleteNamespace(ctx context.Context, req *corepb.LocksDeleteNamespaceRequest) (*corepb.LocksDeleteNamespaceResponse, error) {
+func (s *GrackleNonclusteredStub) ReleaseSemaphore(ctx context.Context, req *corepb.ReleaseSemaphoreRequest) (*corepb.ReleaseSemaphoreResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.LocksDeleteNamespace(&mrpc.UpdateRequest[*corepb.LocksDeleteNamespaceRequest]{
+			resp, err := adapter.core.ReleaseSemaphore(&mrpc.UpdateRequest[*corepb.ReleaseSemaphoreRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2404,14 +3459,14 @@ func (s *GrackleNonclusteredStub) LocksDeleteNamespace(ctx context.Context, req
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) CreateLockLease(ctx context.Context, req *corepb.CreateLockLeaseRequest) (*corepb.CreateLockLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) CreateSemaphore(ctx context.Context, req *corepb.CreateSemaphoreRequest) (*corepb.CreateSemaphoreResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.CreateLockLease(&mrpc.UpdateRequest[*corepb.CreateLockLeaseRequest]{
+			resp, err := adapter.core.CreateSemaphore(&mrpc.UpdateRequest[*corepb.CreateSemaphoreRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2429,14 +3484,14 @@ func (s *GrackleNonclusteredStub) CreateLockLease(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) RefreshLockLease(ctx context.Context, req *corepb.RefreshLockLeaseRequest) (*corepb.RefreshLockLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) UpdateSemaphore(ctx context.Context, req *corepb.UpdateSemaphoreRequest) (*corepb.UpdateSemaphoreResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.RefreshLockLease(&mrpc.UpdateRequest[*corepb.RefreshLockLeaseRequest]{
+			resp, err := adapter.core.UpdateSemaphore(&mrpc.UpdateRequest[*corepb.UpdateSemaphoreRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2454,14 +3509,14 @@ func (s *GrackleNonclusteredStub) RefreshLockLease(ctx context.Context, req *cor
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) RevokeLockLease(ctx context.Context, req *corepb.RevokeLockLeaseRequest) (*corepb.RevokeLockLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) DeleteSemaphore(ctx context.Context, req *corepb.DeleteSemaphoreRequest) (*corepb.DeleteSemaphoreResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleLocksCores {
+	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.RevokeLockLease(&mrpc.UpdateRequest[*corepb.RevokeLockLeaseRequest]{
+			resp, err := adapter.core.DeleteSemaphore(&mrpc.UpdateRequest[*corepb.DeleteSemaphoreRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2479,14 +3534,13 @@ func (s *GrackleNonclusteredStub) RevokeLockLease(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) GetSemaphore(ctx context.Context, req *corepb.GetSemaphoreRequest) (*corepb.GetSemaphoreResponse, error) {
-	shardKey := req.ShardKey()
+func (s *GrackleNonclusteredStub) RunSemaphoresGarbageCollection(ctx context.Context, req *corepb.RunSemaphoresGarbageCollectionRequest, shardId string) (*corepb.RunSemaphoresGarbageCollectionResponse, error) {
 	for _, adapter := range s.grackleSemaphoresCores {
-		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+		if adapter.id == shardId {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.GetSemaphore(&mrpc.ReadRequest[*corepb.GetSemaphoreRequest]{
+			resp, err := adapter.core.RunSemaphoresGarbageCollection(&mrpc.UpdateUnshardedRequest[*corepb.RunSemaphoresGarbageCollectionRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2501,17 +3555,17 @@ func (s *GrackleNonclusteredStub) GetSemaphore(ctx context.Context, req *corepb.
 		}
 	}
 
-	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+	return nil, fmt.Errorf("no shard found for shardId: %s", shardId)
 }
 
-func (s *GrackleNonclusteredStub) GetSemaphoreByName(ctx context.Context, req *corepb.GetSemaphoreByNameRequest) (*corepb.GetSemaphoreByNameResponse, error) {
+func (s *GrackleNonclusteredStub) SemaphoresDeleteNamespace(ctx context.Context, req *corepb.SemaphoresDeleteNamespaceRequest) (*corepb.SemaphoresDeleteNamespaceResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.GetSemaphoreByName(&mrpc.ReadRequest[*corepb.GetSemaphoreByNameRequest]{
+			resp, err := adapter.core.SemaphoresDeleteNamespace(&mrpc.UpdateRequest[*corepb.SemaphoresDeleteNamespaceRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2529,14 +3583,14 @@ func (s *GrackleNonclusteredStub) GetSemaphoreByName(ctx context.Context, req *c
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListSemaphores(ctx context.Context, req *corepb.ListSemaphoresRequest) (*corepb.ListSemaphoresResponse, error) {
+func (s *GrackleNonclusteredStub) CreateSemaphoreLease(ctx context.Context, req *corepb.CreateSemaphoreLeaseRequest) (*corepb.CreateSemaphoreLeaseResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListSemaphores(&mrpc.ReadRequest[*corepb.ListSemaphoresRequest]{
+			resp, err := adapter.core.CreateSemaphoreLease(&mrpc.UpdateRequest[*corepb.CreateSemaphoreLeaseRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2554,14 +3608,14 @@ func (s *GrackleNonclusteredStub) ListSemaphores(ctx context.Context, req *corep
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListSemaphoresByLeaseId(ctx context.Context, req *corepb.ListSemaphoresByLeaseIdRequest) (*corepb.ListSemaphoresByLeaseIdResponse, error) {
+func (s *GrackleNonclusteredStub) RevokeSemaphoreLease(ctx context.Context, req *corepb.RevokeSemaphoreLeaseRequest) (*corepb.RevokeSemaphoreLeaseResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListSemaphoresByLeaseId(&mrpc.ReadRequest[*corepb.ListSemaphoresByLeaseIdRequest]{
+			resp, err := adapter.core.RevokeSemaphoreLease(&mrpc.UpdateRequest[*corepb.RevokeSemaphoreLeaseRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2579,14 +3633,14 @@ func (s *GrackleNonclusteredStub) ListSemaphoresByLeaseId(ctx context.Context, r
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListSemaphoreHolders(ctx context.Context, req *corepb.ListSemaphoreHoldersRequest) (*corepb.ListSemaphoreHoldersResponse, error) {
+func (s *GrackleNonclusteredStub) RefreshSemaphoreLease(ctx context.Context, req *corepb.RefreshSemaphoreLeaseRequest) (*corepb.RefreshSemaphoreLeaseResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListSemaphoreHolders(&mrpc.ReadRequest[*corepb.ListSemaphoreHoldersRequest]{
+			resp, err := adapter.core.RefreshSemaphoreLease(&mrpc.UpdateRequest[*corepb.RefreshSemaphoreLeaseRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2604,14 +3658,14 @@ func (s *GrackleNonclusteredStub) ListSemaphoreHolders(ctx context.Context, req
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListSemaphoreLeases(ctx context.Context, req *corepb.ListSemaphoreLeasesRequest) (*corepb.ListSemaphoreLeasesResponse, error) {
+func (s *GrackleNonclusteredStub) AdjustSemaphoreHold(ctx context.Context, req *corepb.AdjustSemaphoreHoldRequest) (*corepb.AdjustSemaphoreHoldResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListSemaphoreLeases(&mrpc.ReadRequest[*corepb.ListSemaphoreLeasesRequest]{
+			resp, err := adapter.core.AdjustSemaphoreHold(&mrpc.UpdateRequest[*corepb.AdjustSemaphoreHoldRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2629,14 +3683,14 @@ func (s *GrackleNonclusteredStub) ListSemaphoreLeases(ctx context.Context, req *
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListSemaphoreLeasesByProcessId(ctx context.Context, req *corepb.ListSemaphoreLeasesByProcessIdRequest) (*corepb.ListSemaphoreLeasesByProcessIdResponse, error) {
+func (s *GrackleNonclusteredStub) TransferSemaphoreHold(ctx context.Context, req *corepb.TransferSemaphoreHoldRequest) (*corepb.TransferSemaphoreHoldResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleSemaphoresCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListSemaphoreLeasesByProcessId(&mrpc.ReadRequest[*corepb.ListSemaphoreLeasesByProcessIdRequest]{
+			resp, err := adapter.core.TransferSemaphoreHold(&mrpc.UpdateRequest[*corepb.TransferSemaphoreHoldRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2654,14 +3708,14 @@ func (s *GrackleNonclusteredStub) ListSemaphoreLeasesByProcessId(ctx context.Con
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) GetSemaphoreLease(ctx context.Context, req *corepb.GetSemaphoreLeaseRequest) (*corepb.GetSemaphoreLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) GetNamespace(ctx context.Context, req *corepb.GetNamespaceRequest) (*corepb.GetNamespaceResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleNamespacesCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.GetSemaphoreLease(&mrpc.ReadRequest[*corepb.GetSemaphoreLeaseRequest]{
+			resp, err := adapter.core.GetNamespace(&mrpc.ReadRequest[*corepb.GetNamespaceRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2679,14 +3733,14 @@ func (s *GrackleNonclusteredStub) GetSemaphoreLease(ctx context.Context, req *co
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) AcquireSemaphore(ctx context.Context, req *corepb.AcquireSemaphoreRequest) (*corepb.AcquireSemaphoreResponse, error) {
+func (s *GrackleNonclusteredStub) GetNamespaceByName(ctx context.Context, req *corepb.GetNamespaceByNameRequest) (*corepb.GetNamespaceByNameResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleNamespacesCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.AcquireSemaphore(&mrpc.UpdateRequest[*corepb.AcquireSemaphoreRequest]{
+			resp, err := adapter.core.GetNamespaceByName(&mrpc.ReadRequest[*corepb.GetNamespaceByNameRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2704,14 +3758,14 @@ func (s *GrackleNonclusteredStub) AcquireSemaphore(ctx context.Context, req *cor
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ReleaseSemaphore(ctx context.Context, req *corepb.ReleaseSemaphoreRequest) (*corepb.ReleaseSemaphoreResponse, error) {
+func (s *GrackleNonclusteredStub) ListNamespaces(ctx context.Context, req *corepb.ListNamespacesRequest) (*corepb.ListNamespacesResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleNamespacesCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.ReleaseSemaphore(&mrpc.UpdateRequest[*corepb.ReleaseSemaphoreRequest]{
+			resp, err := adapter.core.ListNamespaces(&mrpc.ReadRequest[*corepb.ListNamespacesRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2729,14 +3783,14 @@ func (s *GrackleNonclusteredStub) ReleaseSemaphore(ctx context.Context, req *cor
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) CreateSemaphore(ctx context.Context, req *corepb.CreateSemaphoreRequest) (*corepb.CreateSemaphoreResponse, error) {
+func (s *GrackleNonclusteredStub) CreateNamespace(ctx context.Context, req *corepb.CreateNamespaceRequest) (*corepb.CreateNamespaceResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleNamespacesCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.CreateSemaphore(&mrpc.UpdateRequest[*corepb.CreateSemaphoreRequest]{
+			resp, err := adapter.core.CreateNamespace(&mrpc.UpdateRequest[*corepb.CreateNamespaceRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2754,14 +3808,14 @@ func (s *GrackleNonclusteredStub) CreateSemaphore(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) UpdateSemaphore(ctx context.Context, req *corepb.UpdateSemaphoreRequest) (*corepb.UpdateSemaphoreResponse, error) {
+func (s *GrackleNonclusteredStub) UpdateNamespace(ctx context.Context, req *corepb.UpdateNamespaceRequest) (*corepb.UpdateNamespaceResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleNamespacesCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.UpdateSemaphore(&mrpc.UpdateRequest[*corepb.UpdateSemaphoreRequest]{
+			resp, err := adapter.core.UpdateNamespace(&mrpc.UpdateRequest[*corepb.UpdateNamespaceRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2779,14 +3833,14 @@ func (s *GrackleNonclusteredStub) UpdateSemaphore(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) DeleteSemaphore(ctx context.Context, req *corepb.DeleteSemaphoreRequest) (*corepb.DeleteSemaphoreResponse, error) {
+func (s *GrackleNonclusteredStub) DeleteNamespace(ctx context.Context, req *corepb.DeleteNamespaceRequest) (*corepb.DeleteNamespaceResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleNamespacesCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.DeleteSemaphore(&mrpc.UpdateRequest[*corepb.DeleteSemaphoreRequest]{
+			resp, err := adapter.core.DeleteNamespace(&mrpc.UpdateRequest[*corepb.DeleteNamespaceRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2804,13 +3858,14 @@ func (s *GrackleNonclusteredStub) DeleteSemaphore(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) RunSemaphoresGarbageCollection(ctx context.Context, req *corepb.RunSemaphoresGarbageCollectionRequest, shardId string) (*corepb.RunSemaphoresGarbageCollectionResponse, error) {
-	for _, adapter := range s.grackleSemaphoresCores {
-		if adapter.id == shardId {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+func (s *GrackleNonclusteredStub) GetWaitGroup(ctx context.Context, req *corepb.GetWaitGroupRequest) (*corepb.GetWaitGroupResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleWaitGroupsCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.RunSemaphoresGarbageCollection(&mrpc.UpdateUnshardedRequest[*corepb.RunSemaphoresGarbageCollectionRequest]{
+			resp, err := adapter.core.GetWaitGroup(&mrpc.ReadRequest[*corepb.GetWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2825,17 +3880,17 @@ func (s *GrackleNonclusteredStub) RunSemaphoresGarbageCollection(ctx context.Con
 		}
 	}
 
-	return nil, fmt.Errorf("no shard found for shardId: %s", shardId)
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) SemaphoresDeleteNamespace(ctx context.Context, req *corepb.SemaphoresDeleteNamespaceRequest) (*corepb.SemaphoresDeleteNamespaceResponse, error) {
+func (s *GrackleNonclusteredStub) GetWaitGroupByName(ctx context.Context, req *corepb.GetWaitGroupByNameRequest) (*corepb.GetWaitGroupByNameResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.SemaphoresDeleteNamespace(&mrpc.UpdateRequest[*corepb.SemaphoresDeleteNamespaceRequest]{
+			resp, err := adapter.core.GetWaitGroupByName(&mrpc.ReadRequest[*corepb.GetWaitGroupByNameRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2853,14 +3908,14 @@ func (s *GrackleNonclusteredStub) SemaphoresDeleteNamespace(ctx context.Context,
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) CreateSemaphoreLease(ctx context.Context, req *corepb.CreateSemaphoreLeaseRequest) (*corepb.CreateSemaphoreLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) ListWaitGroups(ctx context.Context, req *corepb.ListWaitGroupsRequest) (*corepb.ListWaitGroupsResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.CreateSemaphoreLease(&mrpc.UpdateRequest[*corepb.CreateSemaphoreLeaseRequest]{
+			resp, err := adapter.core.ListWaitGroups(&mrpc.ReadRequest[*corepb.ListWaitGroupsRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2878,14 +3933,14 @@ func (s *GrackleNonclusteredStub) CreateSemaphoreLease(ctx context.Context, req
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) RevokeSemaphoreLease(ctx context.Context, req *corepb.RevokeSemaphoreLeaseRequest) (*corepb.RevokeSemaphoreLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) ListWaitGroupCompletedJobs(ctx context.Context, req *corepb.ListWaitGroupCompletedJobsRequest) (*corepb.ListWaitGroupCompletedJobsResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.RevokeSemaphoreLease(&mrpc.UpdateRequest[*corepb.RevokeSemaphoreLeaseRequest]{
+			resp, err := adapter.core.ListWaitGroupCompletedJobs(&mrpc.ReadRequest[*corepb.ListWaitGroupCompletedJobsRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2903,14 +3958,14 @@ func (s *GrackleNonclusteredStub) RevokeSemaphoreLease(ctx context.Context, req
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) RefreshSemaphoreLease(ctx context.Context, req *corepb.RefreshSemaphoreLeaseRequest) (*corepb.RefreshSemaphoreLeaseResponse, error) {
+func (s *GrackleNonclusteredStub) ListWaitGroupPendingJobs(ctx context.Context, req *corepb.ListWaitGroupPendingJobsRequest) (*corepb.ListWaitGroupPendingJobsResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleSemaphoresCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.Lock()
-			defer adapter.mu.Unlock()
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.RefreshSemaphoreLease(&mrpc.UpdateRequest[*corepb.RefreshSemaphoreLeaseRequest]{
+			resp, err := adapter.core.ListWaitGroupPendingJobs(&mrpc.ReadRequest[*corepb.ListWaitGroupPendingJobsRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2928,14 +3983,14 @@ func (s *GrackleNonclusteredStub) RefreshSemaphoreLease(ctx context.Context, req
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) GetNamespace(ctx context.Context, req *corepb.GetNamespaceRequest) (*corepb.GetNamespaceResponse, error) {
+func (s *GrackleNonclusteredStub) GetWaitGroupStripe(ctx context.Context, req *corepb.GetWaitGroupStripeRequest) (*corepb.GetWaitGroupStripeResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleNamespacesCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.GetNamespace(&mrpc.ReadRequest[*corepb.GetNamespaceRequest]{
+			resp, err := adapter.core.GetWaitGroupStripe(&mrpc.ReadRequest[*corepb.GetWaitGroupStripeRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2953,14 +4008,14 @@ func (s *GrackleNonclusteredStub) GetNamespace(ctx context.Context, req *corepb.
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) GetNamespaceByName(ctx context.Context, req *corepb.GetNamespaceByNameRequest) (*corepb.GetNamespaceByNameResponse, error) {
+func (s *GrackleNonclusteredStub) ListWaitGroupStripeCompletedJobs(ctx context.Context, req *corepb.ListWaitGroupStripeCompletedJobsRequest) (*corepb.ListWaitGroupStripeCompletedJobsResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleNamespacesCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.RLock()
 			defer adapter.mu.RUnlock()
 
-			resp, err := adapter.core.GetNamespaceByName(&mrpc.ReadRequest[*corepb.GetNamespaceByNameRequest]{
+			resp, err := adapter.core.ListWaitGroupStripeCompletedJobs(&mrpc.ReadRequest[*corepb.ListWaitGroupStripeCompletedJobsRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -2978,14 +4033,14 @@ func (s *GrackleNonclusteredStub) GetNamespaceByName(ctx context.Context, req *c
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListNamespaces(ctx context.Context, req *corepb.ListNamespacesRequest) (*corepb.ListNamespacesResponse, error) {
+func (s *GrackleNonclusteredStub) UpdateWaitGroup(ctx context.Context, req *corepb.UpdateWaitGroupRequest) (*corepb.UpdateWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleNamespacesCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListNamespaces(&mrpc.ReadRequest[*corepb.ListNamespacesRequest]{
+			resp, err := adapter.core.UpdateWaitGroup(&mrpc.UpdateRequest[*corepb.UpdateWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3003,14 +4058,14 @@ func (s *GrackleNonclusteredStub) ListNamespaces(ctx context.Context, req *corep
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) CreateNamespace(ctx context.Context, req *corepb.CreateNamespaceRequest) (*corepb.CreateNamespaceResponse, error) {
+func (s *GrackleNonclusteredStub) CompleteJobsFromWaitGroup(ctx context.Context, req *corepb.CompleteJobsFromWaitGroupRequest) (*corepb.CompleteJobsFromWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleNamespacesCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.CreateNamespace(&mrpc.UpdateRequest[*corepb.CreateNamespaceRequest]{
+			resp, err := adapter.core.CompleteJobsFromWaitGroup(&mrpc.UpdateRequest[*corepb.CompleteJobsFromWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3028,14 +4083,14 @@ func (s *GrackleNonclusteredStub) CreateNamespace(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) UpdateNamespace(ctx context.Context, req *corepb.UpdateNamespaceRequest) (*corepb.UpdateNamespaceResponse, error) {
+func (s *GrackleNonclusteredStub) CreateWaitGroup(ctx context.Context, req *corepb.CreateWaitGroupRequest) (*corepb.CreateWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleNamespacesCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.UpdateNamespace(&mrpc.UpdateRequest[*corepb.UpdateNamespaceRequest]{
+			resp, err := adapter.core.CreateWaitGroup(&mrpc.UpdateRequest[*corepb.CreateWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3053,14 +4108,14 @@ func (s *GrackleNonclusteredStub) UpdateNamespace(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) DeleteNamespace(ctx context.Context, req *corepb.DeleteNamespaceRequest) (*corepb.DeleteNamespaceResponse, error) {
+func (s *GrackleNonclusteredStub) DeleteWaitGroup(ctx context.Context, req *corepb.DeleteWaitGroupRequest) (*corepb.DeleteWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
-	for _, adapter := range s.grackleNamespacesCores {
+	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.DeleteNamespace(&mrpc.UpdateRequest[*corepb.DeleteNamespaceRequest]{
+			resp, err := adapter.core.DeleteWaitGroup(&mrpc.UpdateRequest[*corepb.DeleteWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3078,14 +4133,13 @@ func (s *GrackleNonclusteredStub) DeleteNamespace(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) GetWaitGroup(ctx context.Context, req *corepb.GetWaitGroupRequest) (*corepb.GetWaitGroupResponse, error) {
-	shardKey := req.ShardKey()
+func (s *GrackleNonclusteredStub) RunWaitGroupsGarbageCollection(ctx context.Context, req *corepb.RunWaitGroupsGarbageCollectionRequest, shardId string) (*corepb.RunWaitGroupsGarbageCollectionResponse, error) {
 	for _, adapter := range s.grackleWaitGroupsCores {
-		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+		if adapter.id == shardId {
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.GetWaitGroup(&mrpc.ReadRequest[*corepb.GetWaitGroupRequest]{
+			resp, err := adapter.core.RunWaitGroupsGarbageCollection(&mrpc.UpdateUnshardedRequest[*corepb.RunWaitGroupsGarbageCollectionRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3100,17 +4154,17 @@ func (s *GrackleNonclusteredStub) GetWaitGroup(ctx context.Context, req *corepb.
 		}
 	}
 
-	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+	return nil, fmt.Errorf("no shard found for shardId: %s", shardId)
 }
 
-func (s *GrackleNonclusteredStub) GetWaitGroupByName(ctx context.Context, req *corepb.GetWaitGroupByNameRequest) (*corepb.GetWaitGroupByNameResponse, error) {
+func (s *GrackleNonclusteredStub) WaitGroupsDeleteNamespace(ctx context.Context, req *corepb.WaitGroupsDeleteNamespaceRequest) (*corepb.WaitGroupsDeleteNamespaceResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.GetWaitGroupByName(&mrpc.ReadRequest[*corepb.GetWaitGroupByNameRequest]{
+			resp, err := adapter.core.WaitGroupsDeleteNamespace(&mrpc.UpdateRequest[*corepb.WaitGroupsDeleteNamespaceRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3128,14 +4182,14 @@ func (s *GrackleNonclusteredStub) GetWaitGroupByName(ctx context.Context, req *c
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListWaitGroups(ctx context.Context, req *corepb.ListWaitGroupsRequest) (*corepb.ListWaitGroupsResponse, error) {
+func (s *GrackleNonclusteredStub) AddJobsToWaitGroup(ctx context.Context, req *corepb.AddJobsToWaitGroupRequest) (*corepb.AddJobsToWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListWaitGroups(&mrpc.ReadRequest[*corepb.ListWaitGroupsRequest]{
+			resp, err := adapter.core.AddJobsToWaitGroup(&mrpc.UpdateRequest[*corepb.AddJobsToWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3153,14 +4207,14 @@ func (s *GrackleNonclusteredStub) ListWaitGroups(ctx context.Context, req *corep
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) ListWaitGroupCompletedJobs(ctx context.Context, req *corepb.ListWaitGroupCompletedJobsRequest) (*corepb.ListWaitGroupCompletedJobsResponse, error) {
+func (s *GrackleNonclusteredStub) AddToWaitGroup(ctx context.Context, req *corepb.AddToWaitGroupRequest) (*corepb.AddToWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
-			adapter.mu.RLock()
-			defer adapter.mu.RUnlock()
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.ListWaitGroupCompletedJobs(&mrpc.ReadRequest[*corepb.ListWaitGroupCompletedJobsRequest]{
+			resp, err := adapter.core.AddToWaitGroup(&mrpc.UpdateRequest[*corepb.AddToWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3178,14 +4232,14 @@ func (s *GrackleNonclusteredStub) ListWaitGroupCompletedJobs(ctx context.Context
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) UpdateWaitGroup(ctx context.Context, req *corepb.UpdateWaitGroupRequest) (*corepb.UpdateWaitGroupResponse, error) {
+func (s *GrackleNonclusteredStub) UncompleteJobsFromWaitGroup(ctx context.Context, req *corepb.UncompleteJobsFromWaitGroupRequest) (*corepb.UncompleteJobsFromWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.UpdateWaitGroup(&mrpc.UpdateRequest[*corepb.UpdateWaitGroupRequest]{
+			resp, err := adapter.core.UncompleteJobsFromWaitGroup(&mrpc.UpdateRequest[*corepb.UncompleteJobsFromWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3203,14 +4257,14 @@ func (s *GrackleNonclusteredStub) UpdateWaitGroup(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) CompleteJobsFromWaitGroup(ctx context.Context, req *corepb.CompleteJobsFromWaitGroupRequest) (*corepb.CompleteJobsFromWaitGroupResponse, error) {
+func (s *GrackleNonclusteredStub) CancelWaitGroup(ctx context.Context, req *corepb.CancelWaitGroupRequest) (*corepb.CancelWaitGroupResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.CompleteJobsFromWaitGroup(&mrpc.UpdateRequest[*corepb.CompleteJobsFromWaitGroupRequest]{
+			resp, err := adapter.core.CancelWaitGroup(&mrpc.UpdateRequest[*corepb.CancelWaitGroupRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3228,14 +4282,14 @@ func (s *GrackleNonclusteredStub) CompleteJobsFromWaitGroup(ctx context.Context,
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) CreateWaitGroup(ctx context.Context, req *corepb.CreateWaitGroupRequest) (*corepb.CreateWaitGroupResponse, error) {
+func (s *GrackleNonclusteredStub) CompleteJobsFromWaitGroupStripe(ctx context.Context, req *corepb.CompleteJobsFromWaitGroupStripeRequest) (*corepb.CompleteJobsFromWaitGroupStripeResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.CreateWaitGroup(&mrpc.UpdateRequest[*corepb.CreateWaitGroupRequest]{
+			resp, err := adapter.core.CompleteJobsFromWaitGroupStripe(&mrpc.UpdateRequest[*corepb.CompleteJobsFromWaitGroupStripeRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3253,14 +4307,14 @@ func (s *GrackleNonclusteredStub) CreateWaitGroup(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) DeleteWaitGroup(ctx context.Context, req *corepb.DeleteWaitGroupRequest) (*corepb.DeleteWaitGroupResponse, error) {
+func (s *GrackleNonclusteredStub) AggregateWaitGroupStripes(ctx context.Context, req *corepb.AggregateWaitGroupStripesRequest) (*corepb.AggregateWaitGroupStripesResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.DeleteWaitGroup(&mrpc.UpdateRequest[*corepb.DeleteWaitGroupRequest]{
+			resp, err := adapter.core.AggregateWaitGroupStripes(&mrpc.UpdateRequest[*corepb.AggregateWaitGroupStripesRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3278,13 +4332,14 @@ func (s *GrackleNonclusteredStub) DeleteWaitGroup(ctx context.Context, req *core
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) RunWaitGroupsGarbageCollection(ctx context.Context, req *corepb.RunWaitGroupsGarbageCollectionRequest, shardId string) (*corepb.RunWaitGroupsGarbageCollectionResponse, error) {
+func (s *GrackleNonclusteredStub) ExtendWaitGroupStripe(ctx context.Context, req *corepb.ExtendWaitGroupStripeRequest) (*corepb.ExtendWaitGroupStripeResponse, error) {
+	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
-		if adapter.id == shardId {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.RunWaitGroupsGarbageCollection(&mrpc.UpdateUnshardedRequest[*corepb.RunWaitGroupsGarbageCollectionRequest]{
+			resp, err := adapter.core.ExtendWaitGroupStripe(&mrpc.UpdateRequest[*corepb.ExtendWaitGroupStripeRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3299,17 +4354,17 @@ func (s *GrackleNonclusteredStub) RunWaitGroupsGarbageCollection(ctx context.Con
 		}
 	}
 
-	return nil, fmt.Errorf("no shard found for shardId: %s", shardId)
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
-func (s *GrackleNonclusteredStub) WaitGroupsDeleteNamespace(ctx context.Context, req *corepb.WaitGroupsDeleteNamespaceRequest) (*corepb.WaitGroupsDeleteNamespaceResponse, error) {
+func (s *GrackleNonclusteredStub) DeleteWaitGroupStripe(ctx context.Context, req *corepb.DeleteWaitGroupStripeRequest) (*corepb.DeleteWaitGroupStripeResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleWaitGroupsCores {
 		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
 			adapter.mu.Lock()
 			defer adapter.mu.Unlock()
 
-			resp, err := adapter.core.WaitGroupsDeleteNamespace(&mrpc.UpdateRequest[*corepb.WaitGroupsDeleteNamespaceRequest]{
+			resp, err := adapter.core.DeleteWaitGroupStripe(&mrpc.UpdateRequest[*corepb.DeleteWaitGroupStripeRequest]{
 				Now:     time.Now().UnixNano(),
 				Payload: req,
 			})
@@ -3427,6 +4482,31 @@ func (s *GrackleNonclusteredStub) ListBarrierParticipants(ctx context.Context, r
 	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
 }
 
+func (s *GrackleNonclusteredStub) ListBarrierGenerations(ctx context.Context, req *corepb.ListBarrierGenerationsRequest) (*corepb.ListBarrierGenerationsResponse, error) {
+	shardKey := req.ShardKey()
+	for _, adapter := range s.grackleBarriersCores {
+		if shardKey >= adapter.lowerBound && shardKey <= adapter.upperBound {
+			adapter.mu.RLock()
+			defer adapter.mu.RUnlock()
+
+			resp, err := adapter.core.ListBarrierGenerations(&mrpc.ReadRequest[*corepb.ListBarrierGenerationsRequest]{
+				Now:     time.Now().UnixNano(),
+				Payload: req,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = nilifyIfEmpty(resp.ApplicationError)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no shard found for shardKey: %s", shardKey)
+}
+
 func (s *GrackleNonclusteredStub) CreateBarrier(ctx context.Context, req *corepb.CreateBarrierRequest) (*corepb.CreateBarrierResponse, error) {
 	shardKey := req.ShardKey()
 	for _, adapter := range s.grackleBarriersCores {
@@ -35,8 +35,15 @@ type CreateBarrierRequest struct {
 	// Inactivity window: the barrier is auto-deleted this many seconds after its
 	// last activity (each arrival pushes the deadline out).
 	DeleteInactiveAfterSeconds int64 `protobuf:"varint,7,opt,name=delete_inactive_after_seconds,json=deleteInactiveAfterSeconds,proto3" json:"delete_inactive_after_seconds,omitempty"`
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+	// Optional roster of process ids allowed to arrive; see Barrier.roster.
+	Roster []string `protobuf:"bytes,8,rep,name=roster,proto3" json:"roster,omitempty"`
+	// Number of past generations whose participants are kept; see
+	// Barrier.retained_generations.
+	RetainedGenerations int64 `protobuf:"varint,9,opt,name=retained_generations,json=retainedGenerations,proto3" json:"retained_generations,omitempty"`
+	// Number of arrivals that trips the barrier; see Barrier.release_threshold.
+	ReleaseThreshold int64 `protobuf:"varint,10,opt,name=release_threshold,json=releaseThreshold,proto3" json:"release_threshold,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *CreateBarrierRequest) Reset() {
@@ -118,6 +125,27 @@ func (x *CreateBarrierRequest) GetDeleteInactiveAfterSeconds() int64 {
 	return 0
 }
 
+func (x *CreateBarrierRequest) GetRoster() []string {
+	if x != nil {
+		return x.Roster
+	}
+	return nil
+}
+
+func (x *CreateBarrierRequest) GetRetainedGenerations() int64 {
+	if x != nil {
+		return x.RetainedGenerations
+	}
+	return 0
+}
+
+func (x *CreateBarrierRequest) GetReleaseThreshold() int64 {
+	if x != nil {
+		return x.ReleaseThreshold
+	}
+	return 0
+}
+
 type CreateBarrierResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Barrier       *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
@@ -172,8 +200,12 @@ type UpdateBarrierRequest struct {
 	// update is rejected.
 	ExpectedVersion            int64 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	DeleteInactiveAfterSeconds int64 `protobuf:"varint,6,opt,name=delete_inactive_after_seconds,json=deleteInactiveAfterSeconds,proto3" json:"delete_inactive_after_seconds,omitempty"`
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+	// Replaces the barrier's roster; empty clears it. See Barrier.roster.
+	Roster              []string `protobuf:"bytes,7,rep,name=roster,proto3" json:"roster,omitempty"`
+	RetainedGenerations int64    `protobuf:"varint,8,opt,name=retained_generations,json=retainedGenerations,proto3" json:"retained_generations,omitempty"`
+	ReleaseThreshold    int64    `protobuf:"varint,9,opt,name=release_threshold,json=releaseThreshold,proto3" json:"release_threshold,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *UpdateBarrierRequest) Reset() {
@@ -248,12 +280,36 @@ func (x *UpdateBarrierRequest) GetDeleteInactiveAfterSeconds() int64 {
 	return 0
 }
 
+func (x *UpdateBarrierRequest) GetRoster() []string {
+	if x != nil {
+		return x.Roster
+	}
+	return nil
+}
+
+func (x *UpdateBarrierRequest) GetRetainedGenerations() int64 {
+	if x != nil {
+		return x.RetainedGenerations
+	}
+	return 0
+}
+
+func (x *UpdateBarrierRequest) GetReleaseThreshold() int64 {
+	if x != nil {
+		return x.ReleaseThreshold
+	}
+	return 0
+}
+
 type UpdateBarrierResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Barrier       *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
-	AllArrived    bool                   `protobuf:"varint,2,opt,name=all_arrived,json=allArrived,proto3" json:"all_arrived,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Barrier    *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
+	AllArrived bool                   `protobuf:"varint,2,opt,name=all_arrived,json=allArrived,proto3" json:"all_arrived,omitempty"`
+	// Roster members that have not arrived in the barrier's current generation.
+	// Empty if the barrier has no roster.
+	MissingProcessIds []string `protobuf:"bytes,3,rep,name=missing_process_ids,json=missingProcessIds,proto3" json:"missing_process_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *UpdateBarrierResponse) Reset() {
@@ -300,6 +356,13 @@ func (x *UpdateBarrierResponse) GetAllArrived() bool {
 	return false
 }
 
+func (x *UpdateBarrierResponse) GetMissingProcessIds() []string {
+	if x != nil {
+		return x.MissingProcessIds
+	}
+	return nil
+}
+
 type ArriveAtBarrierRequest struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -308,8 +371,12 @@ type ArriveAtBarrierRequest struct {
 	ProcessId string `protobuf:"bytes,3,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
 	// The generation (cycle) the caller intends to arrive at, so a process never
 	// accidentally contributes to a later cycle than the one it observed.
-	Generation    int64             `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Generation int64             `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`
+	Metadata   map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Opaque, size-bounded value shared with the other participants of this
+	// generation (all-gather). Returned with the participant once the barrier
+	// trips.
+	Payload       []byte `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -379,10 +446,23 @@ func (x *ArriveAtBarrierRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *ArriveAtBarrierRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
 type ArriveAtBarrierResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Barrier       *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
-	AllArrived    bool                   `protobuf:"varint,2,opt,name=all_arrived,json=allArrived,proto3" json:"all_arrived,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Barrier    *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
+	AllArrived bool                   `protobuf:"varint,2,opt,name=all_arrived,json=allArrived,proto3" json:"all_arrived,omitempty"`
+	// Roster members that have not arrived in the barrier's current generation.
+	// Empty if the barrier has no roster.
+	MissingProcessIds []string `protobuf:"bytes,3,rep,name=missing_process_ids,json=missingProcessIds,proto3" json:"missing_process_ids,omitempty"`
+	// True if the arrival was recorded late, for the generation that already
+	// tripped (quorum barriers only).
+	Late          bool `protobuf:"varint,4,opt,name=late,proto3" json:"late,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -431,6 +511,20 @@ func (x *ArriveAtBarrierResponse) GetAllArrived() bool {
 	return false
 }
 
+func (x *ArriveAtBarrierResponse) GetMissingProcessIds() []string {
+	if x != nil {
+		return x.MissingProcessIds
+	}
+	return nil
+}
+
+func (x *ArriveAtBarrierResponse) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
+}
+
 type GetBarrierRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BarrierId     *BarrierId             `protobuf:"bytes,1,opt,name=barrier_id,json=barrierId,proto3" json:"barrier_id,omitempty"`
@@ -476,10 +570,13 @@ func (x *GetBarrierRequest) GetBarrierId() *BarrierId {
 }
 
 type GetBarrierResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Barrier       *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Barrier *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
+	// Roster members that have not arrived in the barrier's current generation.
+	// Empty if the barrier has no roster.
+	MissingProcessIds []string `protobuf:"bytes,2,rep,name=missing_process_ids,json=missingProcessIds,proto3" json:"missing_process_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetBarrierResponse) Reset() {
@@ -519,6 +616,13 @@ func (x *GetBarrierResponse) GetBarrier() *Barrier {
 	return nil
 }
 
+func (x *GetBarrierResponse) GetMissingProcessIds() []string {
+	if x != nil {
+		return x.MissingProcessIds
+	}
+	return nil
+}
+
 type GetBarrierByNameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -572,10 +676,13 @@ func (x *GetBarrierByNameRequest) GetBarrierName() string {
 }
 
 type GetBarrierByNameResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Barrier       *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Barrier *Barrier               `protobuf:"bytes,1,opt,name=barrier,proto3" json:"barrier,omitempty"`
+	// Roster members that have not arrived in the barrier's current generation.
+	// Empty if the barrier has no roster.
+	MissingProcessIds []string `protobuf:"bytes,2,rep,name=missing_process_ids,json=missingProcessIds,proto3" json:"missing_process_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetBarrierByNameResponse) Reset() {
@@ -615,6 +722,13 @@ func (x *GetBarrierByNameResponse) GetBarrier() *Barrier {
 	return nil
 }
 
+func (x *GetBarrierByNameResponse) GetMissingProcessIds() []string {
+	if x != nil {
+		return x.MissingProcessIds
+	}
+	return nil
+}
+
 type DeleteBarrierRequest struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -834,12 +948,13 @@ func (x *ListBarriersResponse) GetPreviousPaginationToken() *PaginationToken {
 }
 
 type ListBarrierParticipantsRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
-	BarrierName     string                 `protobuf:"bytes,2,opt,name=barrier_name,json=barrierName,proto3" json:"barrier_name,omitempty"`
-	Generation      int64                  `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
-	PaginationToken *PaginationToken       `protobuf:"bytes,4,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
-	Limit           int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	BarrierName string                 `protobuf:"bytes,2,opt,name=barrier_name,json=barrierName,proto3" json:"barrier_name,omitempty"`
+	// Generation to list participants of; 0 lists every retained generation.
+	Generation      int64            `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
+	PaginationToken *PaginationToken `protobuf:"bytes,4,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32            `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -969,19 +1084,152 @@ func (x *ListBarrierParticipantsResponse) GetPreviousPaginationToken() *Paginati
 	return nil
 }
 
+type ListBarrierGenerationsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	BarrierName     string                 `protobuf:"bytes,2,opt,name=barrier_name,json=barrierName,proto3" json:"barrier_name,omitempty"`
+	PaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListBarrierGenerationsRequest) Reset() {
+	*x = ListBarrierGenerationsRequest{}
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBarrierGenerationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBarrierGenerationsRequest) ProtoMessage() {}
+
+func (x *ListBarrierGenerationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBarrierGenerationsRequest.ProtoReflect.Descriptor instead.
+func (*ListBarrierGenerationsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListBarrierGenerationsRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *ListBarrierGenerationsRequest) GetBarrierName() string {
+	if x != nil {
+		return x.BarrierName
+	}
+	return ""
+}
+
+func (x *ListBarrierGenerationsRequest) GetPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PaginationToken
+	}
+	return nil
+}
+
+func (x *ListBarrierGenerationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListBarrierGenerationsResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Generations             []*BarrierGeneration   `protobuf:"bytes,1,rep,name=generations,proto3" json:"generations,omitempty"`
+	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
+	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ListBarrierGenerationsResponse) Reset() {
+	*x = ListBarrierGenerationsResponse{}
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBarrierGenerationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBarrierGenerationsResponse) ProtoMessage() {}
+
+func (x *ListBarrierGenerationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBarrierGenerationsResponse.ProtoReflect.Descriptor instead.
+func (*ListBarrierGenerationsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListBarrierGenerationsResponse) GetGenerations() []*BarrierGeneration {
+	if x != nil {
+		return x.Generations
+	}
+	return nil
+}
+
+func (x *ListBarrierGenerationsResponse) GetNextPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.NextPaginationToken
+	}
+	return nil
+}
+
+func (x *ListBarrierGenerationsResponse) GetPreviousPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PreviousPaginationToken
+	}
+	return nil
+}
+
 type RunBarriersGarbageCollectionRequest struct {
 	state                        protoimpl.MessageState `protogen:"open.v1"`
 	GcRecordsPageSize            int64                  `protobuf:"varint,1,opt,name=gc_records_page_size,json=gcRecordsPageSize,proto3" json:"gc_records_page_size,omitempty"`
 	GcRecordBarriersPageSize     int64                  `protobuf:"varint,2,opt,name=gc_record_barriers_page_size,json=gcRecordBarriersPageSize,proto3" json:"gc_record_barriers_page_size,omitempty"`
 	GcRecordParticipantsPageSize int64                  `protobuf:"varint,3,opt,name=gc_record_participants_page_size,json=gcRecordParticipantsPageSize,proto3" json:"gc_record_participants_page_size,omitempty"`
 	MaxVisited                   int64                  `protobuf:"varint,4,opt,name=max_visited,json=maxVisited,proto3" json:"max_visited,omitempty"`
-	unknownFields                protoimpl.UnknownFields
-	sizeCache                    protoimpl.SizeCache
+	// Number of most recent generation history rows kept per barrier; older rows
+	// are deleted. 0 keeps every row until the barrier is deleted.
+	MaxGenerationsPerBarrier int64 `protobuf:"varint,5,opt,name=max_generations_per_barrier,json=maxGenerationsPerBarrier,proto3" json:"max_generations_per_barrier,omitempty"`
+	// Number of barriers queued for generation trimming that are read per pass.
+	GenerationTrimsPageSize int64 `protobuf:"varint,6,opt,name=generation_trims_page_size,json=generationTrimsPageSize,proto3" json:"generation_trims_page_size,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *RunBarriersGarbageCollectionRequest) Reset() {
 	*x = RunBarriersGarbageCollectionRequest{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[16]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -993,7 +1241,7 @@ func (x *RunBarriersGarbageCollectionRequest) String() string {
 func (*RunBarriersGarbageCollectionRequest) ProtoMessage() {}
 
 func (x *RunBarriersGarbageCollectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[16]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1006,7 +1254,7 @@ func (x *RunBarriersGarbageCollectionRequest) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use RunBarriersGarbageCollectionRequest.ProtoReflect.Descriptor instead.
 func (*RunBarriersGarbageCollectionRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{16}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RunBarriersGarbageCollectionRequest) GetGcRecordsPageSize() int64 {
@@ -1037,6 +1285,20 @@ func (x *RunBarriersGarbageCollectionRequest) GetMaxVisited() int64 {
 	return 0
 }
 
+func (x *RunBarriersGarbageCollectionRequest) GetMaxGenerationsPerBarrier() int64 {
+	if x != nil {
+		return x.MaxGenerationsPerBarrier
+	}
+	return 0
+}
+
+func (x *RunBarriersGarbageCollectionRequest) GetGenerationTrimsPageSize() int64 {
+	if x != nil {
+		return x.GenerationTrimsPageSize
+	}
+	return 0
+}
+
 type RunBarriersGarbageCollectionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1045,7 +1307,7 @@ type RunBarriersGarbageCollectionResponse struct {
 
 func (x *RunBarriersGarbageCollectionResponse) Reset() {
 	*x = RunBarriersGarbageCollectionResponse{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[17]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1057,7 +1319,7 @@ func (x *RunBarriersGarbageCollectionResponse) String() string {
 func (*RunBarriersGarbageCollectionResponse) ProtoMessage() {}
 
 func (x *RunBarriersGarbageCollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[17]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,7 +1332,7 @@ func (x *RunBarriersGarbageCollectionResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use RunBarriersGarbageCollectionResponse.ProtoReflect.Descriptor instead.
 func (*RunBarriersGarbageCollectionResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{17}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{19}
 }
 
 type BarriersDeleteNamespaceRequest struct {
@@ -1083,7 +1345,7 @@ type BarriersDeleteNamespaceRequest struct {
 
 func (x *BarriersDeleteNamespaceRequest) Reset() {
 	*x = BarriersDeleteNamespaceRequest{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[18]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1095,7 +1357,7 @@ func (x *BarriersDeleteNamespaceRequest) String() string {
 func (*BarriersDeleteNamespaceRequest) ProtoMessage() {}
 
 func (x *BarriersDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[18]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1108,7 +1370,7 @@ func (x *BarriersDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BarriersDeleteNamespaceRequest.ProtoReflect.Descriptor instead.
 func (*BarriersDeleteNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{18}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *BarriersDeleteNamespaceRequest) GetNamespaceId() *NamespaceId {
@@ -1133,7 +1395,7 @@ type BarriersDeleteNamespaceResponse struct {
 
 func (x *BarriersDeleteNamespaceResponse) Reset() {
 	*x = BarriersDeleteNamespaceResponse{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[19]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1145,7 +1407,7 @@ func (x *BarriersDeleteNamespaceResponse) String() string {
 func (*BarriersDeleteNamespaceResponse) ProtoMessage() {}
 
 func (x *BarriersDeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[19]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1158,7 +1420,7 @@ func (x *BarriersDeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BarriersDeleteNamespaceResponse.ProtoReflect.Descriptor instead.
 func (*BarriersDeleteNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{19}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{21}
 }
 
 // Barrier is a reusable, generational rendezvous point for a fixed number of
@@ -1195,13 +1457,41 @@ type Barrier struct {
 	// last_activity_at + delete_inactive_after_seconds has passed. Every activity
 	// pushes the deletion further out.
 	DeleteInactiveAfterSeconds int64 `protobuf:"varint,12,opt,name=delete_inactive_after_seconds,json=deleteInactiveAfterSeconds,proto3" json:"delete_inactive_after_seconds,omitempty"`
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+	// Optional roster of the process ids allowed to arrive at this barrier. When
+	// non-empty, ArriveAtBarrier rejects any other process_id and the barrier
+	// reports which roster members are still missing in the current generation.
+	// expected_processes may not exceed the roster size. Empty means any
+	// process_id may arrive.
+	Roster []string `protobuf:"bytes,13,rep,name=roster,proto3" json:"roster,omitempty"`
+	// Number of past (tripped) generations whose participants, and thus their
+	// payloads, are kept. Participants of older generations are deleted by
+	// garbage collection after the barrier trips. 0 means the default of 10
+	// generations. The current generation's participants are always kept.
+	RetainedGenerations int64 `protobuf:"varint,14,opt,name=retained_generations,json=retainedGenerations,proto3" json:"retained_generations,omitempty"`
+	// Number of arrivals that trips the barrier (a quorum of K out of
+	// expected_processes). 0 means expected_processes. A quorum barrier, with
+	// release_threshold < expected_processes, also accepts late arrivals for the
+	// generation that just tripped: they are recorded as late participants of
+	// that generation and never count toward the next one.
+	ReleaseThreshold int64 `protobuf:"varint,15,opt,name=release_threshold,json=releaseThreshold,proto3" json:"release_threshold,omitempty"`
+	// Late arrivals recorded for the previous generation (generation - 1), at
+	// most expected_processes - release_threshold. Resets to 0 on each trip.
+	LateProcesses int64 `protobuf:"varint,16,opt,name=late_processes,json=lateProcesses,proto3" json:"late_processes,omitempty"`
+	// Low-water mark of participant pruning: participants of every generation
+	// below it have been deleted by garbage collection, so the next pruning pass
+	// starts its scan here. 0 means nothing has been pruned yet.
+	PrunedGeneration int64 `protobuf:"varint,17,opt,name=pruned_generation,json=prunedGeneration,proto3" json:"pruned_generation,omitempty"`
+	// Roster members that have arrived in the current generation, in arrival
+	// order, so the missing ones are known without reading the participants.
+	// Rebuilt when the roster is replaced and cleared on each trip.
+	ArrivedRosterMembers []string `protobuf:"bytes,18,rep,name=arrived_roster_members,json=arrivedRosterMembers,proto3" json:"arrived_roster_members,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *Barrier) Reset() {
 	*x = Barrier{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[20]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1213,7 +1503,7 @@ func (x *Barrier) String() string {
 func (*Barrier) ProtoMessage() {}
 
 func (x *Barrier) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[20]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,7 +1516,7 @@ func (x *Barrier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Barrier.ProtoReflect.Descriptor instead.
 func (*Barrier) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{20}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Barrier) GetId() *BarrierId {
@@ -1313,6 +1603,48 @@ func (x *Barrier) GetDeleteInactiveAfterSeconds() int64 {
 	return 0
 }
 
+func (x *Barrier) GetRoster() []string {
+	if x != nil {
+		return x.Roster
+	}
+	return nil
+}
+
+func (x *Barrier) GetRetainedGenerations() int64 {
+	if x != nil {
+		return x.RetainedGenerations
+	}
+	return 0
+}
+
+func (x *Barrier) GetReleaseThreshold() int64 {
+	if x != nil {
+		return x.ReleaseThreshold
+	}
+	return 0
+}
+
+func (x *Barrier) GetLateProcesses() int64 {
+	if x != nil {
+		return x.LateProcesses
+	}
+	return 0
+}
+
+func (x *Barrier) GetPrunedGeneration() int64 {
+	if x != nil {
+		return x.PrunedGeneration
+	}
+	return 0
+}
+
+func (x *Barrier) GetArrivedRosterMembers() []string {
+	if x != nil {
+		return x.ArrivedRosterMembers
+	}
+	return nil
+}
+
 // BarrierId uniquely identifies a barrier within an account and namespace.
 type BarrierId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1325,7 +1657,7 @@ type BarrierId struct {
 
 func (x *BarrierId) Reset() {
 	*x = BarrierId{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[21]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1337,7 +1669,7 @@ func (x *BarrierId) String() string {
 func (*BarrierId) ProtoMessage() {}
 
 func (x *BarrierId) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[21]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1350,7 +1682,7 @@ func (x *BarrierId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BarrierId.ProtoReflect.Descriptor instead.
 func (*BarrierId) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{21}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *BarrierId) GetAccountId() uint64 {
@@ -1385,7 +1717,7 @@ type BarriersCounter struct {
 
 func (x *BarriersCounter) Reset() {
 	*x = BarriersCounter{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[22]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1397,7 +1729,7 @@ func (x *BarriersCounter) String() string {
 func (*BarriersCounter) ProtoMessage() {}
 
 func (x *BarriersCounter) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[22]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1410,7 +1742,7 @@ func (x *BarriersCounter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BarriersCounter.ProtoReflect.Descriptor instead.
 func (*BarriersCounter) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{22}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *BarriersCounter) GetNumberOfBarriers() int64 {
@@ -1437,7 +1769,7 @@ type BarriersGarbageCollectionRecord struct {
 
 func (x *BarriersGarbageCollectionRecord) Reset() {
 	*x = BarriersGarbageCollectionRecord{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[23]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1449,7 +1781,7 @@ func (x *BarriersGarbageCollectionRecord) String() string {
 func (*BarriersGarbageCollectionRecord) ProtoMessage() {}
 
 func (x *BarriersGarbageCollectionRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[23]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,7 +1794,7 @@ func (x *BarriersGarbageCollectionRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BarriersGarbageCollectionRecord.ProtoReflect.Descriptor instead.
 func (*BarriersGarbageCollectionRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{23}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *BarriersGarbageCollectionRecord) GetId() uint64 {
@@ -1526,7 +1858,7 @@ type BarriersDeletionRecord struct {
 
 func (x *BarriersDeletionRecord) Reset() {
 	*x = BarriersDeletionRecord{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[24]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1538,7 +1870,7 @@ func (x *BarriersDeletionRecord) String() string {
 func (*BarriersDeletionRecord) ProtoMessage() {}
 
 func (x *BarriersDeletionRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[24]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1551,7 +1883,7 @@ func (x *BarriersDeletionRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BarriersDeletionRecord.ProtoReflect.Descriptor instead.
 func (*BarriersDeletionRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{24}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *BarriersDeletionRecord) GetBarrierId() *BarrierId {
@@ -1568,6 +1900,155 @@ func (x *BarriersDeletionRecord) GetDeleteAt() int64 {
 	return 0
 }
 
+// BarriersGenerationTrimRecord queues a barrier whose generation history may
+// have grown past the retention bound, so garbage collection can trim it
+// without scanning every barrier.
+type BarriersGenerationTrimRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BarrierId     *BarrierId             `protobuf:"bytes,1,opt,name=barrier_id,json=barrierId,proto3" json:"barrier_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BarriersGenerationTrimRecord) Reset() {
+	*x = BarriersGenerationTrimRecord{}
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BarriersGenerationTrimRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BarriersGenerationTrimRecord) ProtoMessage() {}
+
+func (x *BarriersGenerationTrimRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BarriersGenerationTrimRecord.ProtoReflect.Descriptor instead.
+func (*BarriersGenerationTrimRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BarriersGenerationTrimRecord) GetBarrierId() *BarrierId {
+	if x != nil {
+		return x.BarrierId
+	}
+	return nil
+}
+
+// BarrierGeneration is the history row of one generation (cycle) of a barrier.
+// It is opened when the generation starts and closed when it trips.
+type BarrierGeneration struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Generation int64                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	// When the generation started (barrier creation or the previous trip), Unix
+	// nanoseconds.
+	OpenedAt int64 `protobuf:"fixed64,2,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	// When the generation tripped, Unix nanoseconds. 0 while it is still open.
+	TrippedAt int64 `protobuf:"fixed64,3,opt,name=tripped_at,json=trippedAt,proto3" json:"tripped_at,omitempty"`
+	// First and last on-time arrivals, Unix nanoseconds. 0 until someone
+	// arrives. Late arrivals are not recorded here.
+	FirstArrivalAt int64 `protobuf:"fixed64,4,opt,name=first_arrival_at,json=firstArrivalAt,proto3" json:"first_arrival_at,omitempty"`
+	LastArrivalAt  int64 `protobuf:"fixed64,5,opt,name=last_arrival_at,json=lastArrivalAt,proto3" json:"last_arrival_at,omitempty"`
+	// Process id of the last on-time arrival; for a tripped generation, the
+	// process that tripped it.
+	LastProcessId string `protobuf:"bytes,6,opt,name=last_process_id,json=lastProcessId,proto3" json:"last_process_id,omitempty"`
+	// Number of on-time arrivals in this generation.
+	ArrivedProcesses int64 `protobuf:"varint,7,opt,name=arrived_processes,json=arrivedProcesses,proto3" json:"arrived_processes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BarrierGeneration) Reset() {
+	*x = BarrierGeneration{}
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BarrierGeneration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BarrierGeneration) ProtoMessage() {}
+
+func (x *BarrierGeneration) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BarrierGeneration.ProtoReflect.Descriptor instead.
+func (*BarrierGeneration) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BarrierGeneration) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *BarrierGeneration) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return 0
+}
+
+func (x *BarrierGeneration) GetTrippedAt() int64 {
+	if x != nil {
+		return x.TrippedAt
+	}
+	return 0
+}
+
+func (x *BarrierGeneration) GetFirstArrivalAt() int64 {
+	if x != nil {
+		return x.FirstArrivalAt
+	}
+	return 0
+}
+
+func (x *BarrierGeneration) GetLastArrivalAt() int64 {
+	if x != nil {
+		return x.LastArrivalAt
+	}
+	return 0
+}
+
+func (x *BarrierGeneration) GetLastProcessId() string {
+	if x != nil {
+		return x.LastProcessId
+	}
+	return ""
+}
+
+func (x *BarrierGeneration) GetArrivedProcesses() int64 {
+	if x != nil {
+		return x.ArrivedProcesses
+	}
+	return 0
+}
+
 // BarrierParticipant is one process's arrival in a given generation. Participants
 // are listed per generation, so callers can see who has and has not arrived in a
 // cycle.
@@ -1578,15 +2059,20 @@ type BarrierParticipant struct {
 	// When the process arrived, Unix nanoseconds.
 	ArrivedAt int64 `protobuf:"fixed64,2,opt,name=arrived_at,json=arrivedAt,proto3" json:"arrived_at,omitempty"`
 	// The generation (cycle) this arrival belongs to.
-	Generation    int64             `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Generation int64             `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
+	Metadata   map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Opaque payload supplied on arrival (all-gather).
+	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	// True if the process arrived after its generation had already tripped on a
+	// quorum; late participants did not count toward the trip.
+	Late          bool `protobuf:"varint,6,opt,name=late,proto3" json:"late,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BarrierParticipant) Reset() {
 	*x = BarrierParticipant{}
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[25]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1598,7 +2084,7 @@ func (x *BarrierParticipant) String() string {
 func (*BarrierParticipant) ProtoMessage() {}
 
 func (x *BarrierParticipant) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_barriers_proto_msgTypes[25]
+	mi := &file_pkg_corepb_barriers_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1611,7 +2097,7 @@ func (x *BarrierParticipant) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BarrierParticipant.ProtoReflect.Descriptor instead.
 func (*BarrierParticipant) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{25}
+	return file_pkg_corepb_barriers_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *BarrierParticipant) GetProcessId() string {
@@ -1642,11 +2128,25 @@ func (x *BarrierParticipant) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *BarrierParticipant) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *BarrierParticipant) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
+}
+
 var File_pkg_corepb_barriers_proto protoreflect.FileDescriptor
 
 const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\n" +
-	"\x19pkg/corepb/barriers.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\xea\x03\n" +
+	"\x19pkg/corepb/barriers.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\xe2\x04\n" +
 	"\x14CreateBarrierRequest\x12C\n" +
 	"\n" +
 	"barrier_id\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.BarrierIdR\tbarrierId\x12\x12\n" +
@@ -1655,12 +2155,16 @@ const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\x12expected_processes\x18\x04 \x01(\x03R\x11expectedProcesses\x12Y\n" +
 	"\bmetadata\x18\x05 \x03(\v2=.com.evrblk.grackle.corepb.CreateBarrierRequest.MetadataEntryR\bmetadata\x12M\n" +
 	"$max_number_of_barriers_per_namespace\x18\x06 \x01(\x03R\x1fmaxNumberOfBarriersPerNamespace\x12A\n" +
-	"\x1ddelete_inactive_after_seconds\x18\a \x01(\x03R\x1adeleteInactiveAfterSeconds\x1a;\n" +
+	"\x1ddelete_inactive_after_seconds\x18\a \x01(\x03R\x1adeleteInactiveAfterSeconds\x12\x16\n" +
+	"\x06roster\x18\b \x03(\tR\x06roster\x121\n" +
+	"\x14retained_generations\x18\t \x01(\x03R\x13retainedGenerations\x12+\n" +
+	"\x11release_threshold\x18\n" +
+	" \x01(\x03R\x10releaseThreshold\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"U\n" +
 	"\x15CreateBarrierResponse\x12<\n" +
-	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\"\xb2\x03\n" +
+	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\"\xaa\x04\n" +
 	"\x14UpdateBarrierRequest\x12C\n" +
 	"\n" +
 	"barrier_id\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.BarrierIdR\tbarrierId\x12 \n" +
@@ -1668,14 +2172,18 @@ const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\x12expected_processes\x18\x03 \x01(\x03R\x11expectedProcesses\x12Y\n" +
 	"\bmetadata\x18\x04 \x03(\v2=.com.evrblk.grackle.corepb.UpdateBarrierRequest.MetadataEntryR\bmetadata\x12)\n" +
 	"\x10expected_version\x18\x05 \x01(\x03R\x0fexpectedVersion\x12A\n" +
-	"\x1ddelete_inactive_after_seconds\x18\x06 \x01(\x03R\x1adeleteInactiveAfterSeconds\x1a;\n" +
+	"\x1ddelete_inactive_after_seconds\x18\x06 \x01(\x03R\x1adeleteInactiveAfterSeconds\x12\x16\n" +
+	"\x06roster\x18\a \x03(\tR\x06roster\x121\n" +
+	"\x14retained_generations\x18\b \x01(\x03R\x13retainedGenerations\x12+\n" +
+	"\x11release_threshold\x18\t \x01(\x03R\x10releaseThreshold\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"v\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
 	"\x15UpdateBarrierResponse\x12<\n" +
 	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\x12\x1f\n" +
 	"\vall_arrived\x18\x02 \x01(\bR\n" +
-	"allArrived\"\xdf\x02\n" +
+	"allArrived\x12.\n" +
+	"\x13missing_process_ids\x18\x03 \x03(\tR\x11missingProcessIds\"\xf9\x02\n" +
 	"\x16ArriveAtBarrierRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12!\n" +
 	"\fbarrier_name\x18\x02 \x01(\tR\vbarrierName\x12\x1d\n" +
@@ -1684,24 +2192,29 @@ const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\n" +
 	"generation\x18\x04 \x01(\x03R\n" +
 	"generation\x12[\n" +
-	"\bmetadata\x18\x05 \x03(\v2?.com.evrblk.grackle.corepb.ArriveAtBarrierRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x05 \x03(\v2?.com.evrblk.grackle.corepb.ArriveAtBarrierRequest.MetadataEntryR\bmetadata\x12\x18\n" +
+	"\apayload\x18\x06 \x01(\fR\apayload\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"x\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbc\x01\n" +
 	"\x17ArriveAtBarrierResponse\x12<\n" +
 	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\x12\x1f\n" +
 	"\vall_arrived\x18\x02 \x01(\bR\n" +
-	"allArrived\"X\n" +
+	"allArrived\x12.\n" +
+	"\x13missing_process_ids\x18\x03 \x03(\tR\x11missingProcessIds\x12\x12\n" +
+	"\x04late\x18\x04 \x01(\bR\x04late\"X\n" +
 	"\x11GetBarrierRequest\x12C\n" +
 	"\n" +
-	"barrier_id\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.BarrierIdR\tbarrierId\"R\n" +
+	"barrier_id\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.BarrierIdR\tbarrierId\"\x82\x01\n" +
 	"\x12GetBarrierResponse\x12<\n" +
-	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\"\x87\x01\n" +
+	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\x12.\n" +
+	"\x13missing_process_ids\x18\x02 \x03(\tR\x11missingProcessIds\"\x87\x01\n" +
 	"\x17GetBarrierByNameRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12!\n" +
-	"\fbarrier_name\x18\x02 \x01(\tR\vbarrierName\"X\n" +
+	"\fbarrier_name\x18\x02 \x01(\tR\vbarrierName\"\x88\x01\n" +
 	"\x18GetBarrierByNameResponse\x12<\n" +
-	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\"\xa1\x01\n" +
+	"\abarrier\x18\x01 \x01(\v2\".com.evrblk.grackle.corepb.BarrierR\abarrier\x12.\n" +
+	"\x13missing_process_ids\x18\x02 \x03(\tR\x11missingProcessIds\"\xa1\x01\n" +
 	"\x14DeleteBarrierRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12!\n" +
 	"\fbarrier_name\x18\x02 \x01(\tR\vbarrierName\x12\x1b\n" +
@@ -1726,18 +2239,29 @@ const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\x1fListBarrierParticipantsResponse\x12Q\n" +
 	"\fparticipants\x18\x01 \x03(\v2-.com.evrblk.grackle.corepb.BarrierParticipantR\fparticipants\x12^\n" +
 	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
-	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\xff\x01\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\xfa\x01\n" +
+	"\x1dListBarrierGenerationsRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12!\n" +
+	"\fbarrier_name\x18\x02 \x01(\tR\vbarrierName\x12U\n" +
+	"\x10pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xb8\x02\n" +
+	"\x1eListBarrierGenerationsResponse\x12N\n" +
+	"\vgenerations\x18\x01 \x03(\v2,.com.evrblk.grackle.corepb.BarrierGenerationR\vgenerations\x12^\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\xfb\x02\n" +
 	"#RunBarriersGarbageCollectionRequest\x12/\n" +
 	"\x14gc_records_page_size\x18\x01 \x01(\x03R\x11gcRecordsPageSize\x12>\n" +
 	"\x1cgc_record_barriers_page_size\x18\x02 \x01(\x03R\x18gcRecordBarriersPageSize\x12F\n" +
 	" gc_record_participants_page_size\x18\x03 \x01(\x03R\x1cgcRecordParticipantsPageSize\x12\x1f\n" +
 	"\vmax_visited\x18\x04 \x01(\x03R\n" +
-	"maxVisited\"&\n" +
+	"maxVisited\x12=\n" +
+	"\x1bmax_generations_per_barrier\x18\x05 \x01(\x03R\x18maxGenerationsPerBarrier\x12;\n" +
+	"\x1ageneration_trims_page_size\x18\x06 \x01(\x03R\x17generationTrimsPageSize\"&\n" +
 	"$RunBarriersGarbageCollectionResponse\"\x88\x01\n" +
 	"\x1eBarriersDeleteNamespaceRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12\x1b\n" +
 	"\trecord_id\x18\x02 \x01(\x06R\brecordId\"!\n" +
-	"\x1fBarriersDeleteNamespaceResponse\"\xc1\x04\n" +
+	"\x1fBarriersDeleteNamespaceResponse\"\xc3\x06\n" +
 	"\aBarrier\x124\n" +
 	"\x02id\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.BarrierIdR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1755,7 +2279,13 @@ const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\bmetadata\x18\n" +
 	" \x03(\v20.com.evrblk.grackle.corepb.Barrier.MetadataEntryR\bmetadata\x12(\n" +
 	"\x10last_activity_at\x18\v \x01(\x10R\x0elastActivityAt\x12A\n" +
-	"\x1ddelete_inactive_after_seconds\x18\f \x01(\x03R\x1adeleteInactiveAfterSeconds\x1a;\n" +
+	"\x1ddelete_inactive_after_seconds\x18\f \x01(\x03R\x1adeleteInactiveAfterSeconds\x12\x16\n" +
+	"\x06roster\x18\r \x03(\tR\x06roster\x121\n" +
+	"\x14retained_generations\x18\x0e \x01(\x03R\x13retainedGenerations\x12+\n" +
+	"\x11release_threshold\x18\x0f \x01(\x03R\x10releaseThreshold\x12%\n" +
+	"\x0elate_processes\x18\x10 \x01(\x03R\rlateProcesses\x12+\n" +
+	"\x11pruned_generation\x18\x11 \x01(\x03R\x10prunedGeneration\x124\n" +
+	"\x16arrived_roster_members\x18\x12 \x03(\tR\x14arrivedRosterMembers\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"l\n" +
@@ -1776,7 +2306,21 @@ const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\x16BarriersDeletionRecord\x12C\n" +
 	"\n" +
 	"barrier_id\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.BarrierIdR\tbarrierId\x12\x1b\n" +
-	"\tdelete_at\x18\x02 \x01(\x10R\bdeleteAt\"\x88\x02\n" +
+	"\tdelete_at\x18\x02 \x01(\x10R\bdeleteAt\"c\n" +
+	"\x1cBarriersGenerationTrimRecord\x12C\n" +
+	"\n" +
+	"barrier_id\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.BarrierIdR\tbarrierId\"\x96\x02\n" +
+	"\x11BarrierGeneration\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\x03R\n" +
+	"generation\x12\x1b\n" +
+	"\topened_at\x18\x02 \x01(\x10R\bopenedAt\x12\x1d\n" +
+	"\n" +
+	"tripped_at\x18\x03 \x01(\x10R\ttrippedAt\x12(\n" +
+	"\x10first_arrival_at\x18\x04 \x01(\x10R\x0efirstArrivalAt\x12&\n" +
+	"\x0flast_arrival_at\x18\x05 \x01(\x10R\rlastArrivalAt\x12&\n" +
+	"\x0flast_process_id\x18\x06 \x01(\tR\rlastProcessId\x12+\n" +
+	"\x11arrived_processes\x18\a \x01(\x03R\x10arrivedProcesses\"\xb6\x02\n" +
 	"\x12BarrierParticipant\x12\x1d\n" +
 	"\n" +
 	"process_id\x18\x01 \x01(\tR\tprocessId\x12\x1d\n" +
@@ -1785,7 +2329,9 @@ const file_pkg_corepb_barriers_proto_rawDesc = "" +
 	"\n" +
 	"generation\x18\x03 \x01(\x03R\n" +
 	"generation\x12W\n" +
-	"\bmetadata\x18\x04 \x03(\v2;.com.evrblk.grackle.corepb.BarrierParticipant.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x04 \x03(\v2;.com.evrblk.grackle.corepb.BarrierParticipant.MetadataEntryR\bmetadata\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12\x12\n" +
+	"\x04late\x18\x06 \x01(\bR\x04late\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B&Z$github.com/evrblk/grackle/pkg/corepbb\x06proto3"
@@ -1802,7 +2348,7 @@ func file_pkg_corepb_barriers_proto_rawDescGZIP() []byte {
 	return file_pkg_corepb_barriers_proto_rawDescData
 }
 
-var file_pkg_corepb_barriers_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_pkg_corepb_barriers_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_pkg_corepb_barriers_proto_goTypes = []any{
 	(*CreateBarrierRequest)(nil),                 // 0: com.evrblk.grackle.corepb.CreateBarrierRequest
 	(*CreateBarrierResponse)(nil),                // 1: com.evrblk.grackle.corepb.CreateBarrierResponse
@@ -1820,61 +2366,71 @@ var file_pkg_corepb_barriers_proto_goTypes = []any{
 	(*ListBarriersResponse)(nil),                 // 13: com.evrblk.grackle.corepb.ListBarriersResponse
 	(*ListBarrierParticipantsRequest)(nil),       // 14: com.evrblk.grackle.corepb.ListBarrierParticipantsRequest
 	(*ListBarrierParticipantsResponse)(nil),      // 15: com.evrblk.grackle.corepb.ListBarrierParticipantsResponse
-	(*RunBarriersGarbageCollectionRequest)(nil),  // 16: com.evrblk.grackle.corepb.RunBarriersGarbageCollectionRequest
-	(*RunBarriersGarbageCollectionResponse)(nil), // 17: com.evrblk.grackle.corepb.RunBarriersGarbageCollectionResponse
-	(*BarriersDeleteNamespaceRequest)(nil),       // 18: com.evrblk.grackle.corepb.BarriersDeleteNamespaceRequest
-	(*BarriersDeleteNamespaceResponse)(nil),      // 19: com.evrblk.grackle.corepb.BarriersDeleteNamespaceResponse
-	(*Barrier)(nil),                              // 20: com.evrblk.grackle.corepb.Barrier
-	(*BarrierId)(nil),                            // 21: com.evrblk.grackle.corepb.BarrierId
-	(*BarriersCounter)(nil),                      // 22: com.evrblk.grackle.corepb.BarriersCounter
-	(*BarriersGarbageCollectionRecord)(nil),      // 23: com.evrblk.grackle.corepb.BarriersGarbageCollectionRecord
-	(*BarriersDeletionRecord)(nil),               // 24: com.evrblk.grackle.corepb.BarriersDeletionRecord
-	(*BarrierParticipant)(nil),                   // 25: com.evrblk.grackle.corepb.BarrierParticipant
-	nil,                                          // 26: com.evrblk.grackle.corepb.CreateBarrierRequest.MetadataEntry
-	nil,                                          // 27: com.evrblk.grackle.corepb.UpdateBarrierRequest.MetadataEntry
-	nil,                                          // 28: com.evrblk.grackle.corepb.ArriveAtBarrierRequest.MetadataEntry
-	nil,                                          // 29: com.evrblk.grackle.corepb.Barrier.MetadataEntry
-	nil,                                          // 30: com.evrblk.grackle.corepb.BarrierParticipant.MetadataEntry
-	(*NamespaceId)(nil),                          // 31: com.evrblk.grackle.corepb.NamespaceId
-	(*PaginationToken)(nil),                      // 32: com.evrblk.grackle.corepb.PaginationToken
+	(*ListBarrierGenerationsRequest)(nil),        // 16: com.evrblk.grackle.corepb.ListBarrierGenerationsRequest
+	(*ListBarrierGenerationsResponse)(nil),       // 17: com.evrblk.grackle.corepb.ListBarrierGenerationsResponse
+	(*RunBarriersGarbageCollectionRequest)(nil),  // 18: com.evrblk.grackle.corepb.RunBarriersGarbageCollectionRequest
+	(*RunBarriersGarbageCollectionResponse)(nil), // 19: com.evrblk.grackle.corepb.RunBarriersGarbageCollectionResponse
+	(*BarriersDeleteNamespaceRequest)(nil),       // 20: com.evrblk.grackle.corepb.BarriersDeleteNamespaceRequest
+	(*BarriersDeleteNamespaceResponse)(nil),      // 21: com.evrblk.grackle.corepb.BarriersDeleteNamespaceResponse
+	(*Barrier)(nil),                              // 22: com.evrblk.grackle.corepb.Barrier
+	(*BarrierId)(nil),                            // 23: com.evrblk.grackle.corepb.BarrierId
+	(*BarriersCounter)(nil),                      // 24: com.evrblk.grackle.corepb.BarriersCounter
+	(*BarriersGarbageCollectionRecord)(nil),      // 25: com.evrblk.grackle.corepb.BarriersGarbageCollectionRecord
+	(*BarriersDeletionRecord)(nil),               // 26: com.evrblk.grackle.corepb.BarriersDeletionRecord
+	(*BarriersGenerationTrimRecord)(nil),         // 27: com.evrblk.grackle.corepb.BarriersGenerationTrimRecord
+	(*BarrierGeneration)(nil),                    // 28: com.evrblk.grackle.corepb.BarrierGeneration
+	(*BarrierParticipant)(nil),                   // 29: com.evrblk.grackle.corepb.BarrierParticipant
+	nil,                                          // 30: com.evrblk.grackle.corepb.CreateBarrierRequest.MetadataEntry
+	nil,                                          // 31: com.evrblk.grackle.corepb.UpdateBarrierRequest.MetadataEntry
+	nil,                                          // 32: com.evrblk.grackle.corepb.ArriveAtBarrierRequest.MetadataEntry
+	nil,                                          // 33: com.evrblk.grackle.corepb.Barrier.MetadataEntry
+	nil,                                          // 34: com.evrblk.grackle.corepb.BarrierParticipant.MetadataEntry
+	(*NamespaceId)(nil),                          // 35: com.evrblk.grackle.corepb.NamespaceId
+	(*PaginationToken)(nil),                      // 36: com.evrblk.grackle.corepb.PaginationToken
 }
 var file_pkg_corepb_barriers_proto_depIdxs = []int32{
-	21, // 0: com.evrblk.grackle.corepb.CreateBarrierRequest.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
-	26, // 1: com.evrblk.grackle.corepb.CreateBarrierRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateBarrierRequest.MetadataEntry
-	20, // 2: com.evrblk.grackle.corepb.CreateBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
-	21, // 3: com.evrblk.grackle.corepb.UpdateBarrierRequest.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
-	27, // 4: com.evrblk.grackle.corepb.UpdateBarrierRequest.metadata:type_name -> com.evrblk.grackle.corepb.UpdateBarrierRequest.MetadataEntry
-	20, // 5: com.evrblk.grackle.corepb.UpdateBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
-	31, // 6: com.evrblk.grackle.corepb.ArriveAtBarrierRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	28, // 7: com.evrblk.grackle.corepb.ArriveAtBarrierRequest.metadata:type_name -> com.evrblk.grackle.corepb.ArriveAtBarrierRequest.MetadataEntry
-	20, // 8: com.evrblk.grackle.corepb.ArriveAtBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
-	21, // 9: com.evrblk.grackle.corepb.GetBarrierRequest.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
-	20, // 10: com.evrblk.grackle.corepb.GetBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
-	31, // 11: com.evrblk.grackle.corepb.GetBarrierByNameRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	20, // 12: com.evrblk.grackle.corepb.GetBarrierByNameResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
-	31, // 13: com.evrblk.grackle.corepb.DeleteBarrierRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	31, // 14: com.evrblk.grackle.corepb.ListBarriersRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	32, // 15: com.evrblk.grackle.corepb.ListBarriersRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	20, // 16: com.evrblk.grackle.corepb.ListBarriersResponse.barriers:type_name -> com.evrblk.grackle.corepb.Barrier
-	32, // 17: com.evrblk.grackle.corepb.ListBarriersResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	32, // 18: com.evrblk.grackle.corepb.ListBarriersResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	31, // 19: com.evrblk.grackle.corepb.ListBarrierParticipantsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	32, // 20: com.evrblk.grackle.corepb.ListBarrierParticipantsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	25, // 21: com.evrblk.grackle.corepb.ListBarrierParticipantsResponse.participants:type_name -> com.evrblk.grackle.corepb.BarrierParticipant
-	32, // 22: com.evrblk.grackle.corepb.ListBarrierParticipantsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	32, // 23: com.evrblk.grackle.corepb.ListBarrierParticipantsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	31, // 24: com.evrblk.grackle.corepb.BarriersDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	21, // 25: com.evrblk.grackle.corepb.Barrier.id:type_name -> com.evrblk.grackle.corepb.BarrierId
-	29, // 26: com.evrblk.grackle.corepb.Barrier.metadata:type_name -> com.evrblk.grackle.corepb.Barrier.MetadataEntry
-	31, // 27: com.evrblk.grackle.corepb.BarriersGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	21, // 28: com.evrblk.grackle.corepb.BarriersGarbageCollectionRecord.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
-	21, // 29: com.evrblk.grackle.corepb.BarriersDeletionRecord.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
-	30, // 30: com.evrblk.grackle.corepb.BarrierParticipant.metadata:type_name -> com.evrblk.grackle.corepb.BarrierParticipant.MetadataEntry
-	31, // [31:31] is the sub-list for method output_type
-	31, // [31:31] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
+	23, // 0: com.evrblk.grackle.corepb.CreateBarrierRequest.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
+	30, // 1: com.evrblk.grackle.corepb.CreateBarrierRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateBarrierRequest.MetadataEntry
+	22, // 2: com.evrblk.grackle.corepb.CreateBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
+	23, // 3: com.evrblk.grackle.corepb.UpdateBarrierRequest.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
+	31, // 4: com.evrblk.grackle.corepb.UpdateBarrierRequest.metadata:type_name -> com.evrblk.grackle.corepb.UpdateBarrierRequest.MetadataEntry
+	22, // 5: com.evrblk.grackle.corepb.UpdateBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
+	35, // 6: com.evrblk.grackle.corepb.ArriveAtBarrierRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	32, // 7: com.evrblk.grackle.corepb.ArriveAtBarrierRequest.metadata:type_name -> com.evrblk.grackle.corepb.ArriveAtBarrierRequest.MetadataEntry
+	22, // 8: com.evrblk.grackle.corepb.ArriveAtBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
+	23, // 9: com.evrblk.grackle.corepb.GetBarrierRequest.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
+	22, // 10: com.evrblk.grackle.corepb.GetBarrierResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
+	35, // 11: com.evrblk.grackle.corepb.GetBarrierByNameRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	22, // 12: com.evrblk.grackle.corepb.GetBarrierByNameResponse.barrier:type_name -> com.evrblk.grackle.corepb.Barrier
+	35, // 13: com.evrblk.grackle.corepb.DeleteBarrierRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	35, // 14: com.evrblk.grackle.corepb.ListBarriersRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	36, // 15: com.evrblk.grackle.corepb.ListBarriersRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	22, // 16: com.evrblk.grackle.corepb.ListBarriersResponse.barriers:type_name -> com.evrblk.grackle.corepb.Barrier
+	36, // 17: com.evrblk.grackle.corepb.ListBarriersResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	36, // 18: com.evrblk.grackle.corepb.ListBarriersResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	35, // 19: com.evrblk.grackle.corepb.ListBarrierParticipantsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	36, // 20: com.evrblk.grackle.corepb.ListBarrierParticipantsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	29, // 21: com.evrblk.grackle.corepb.ListBarrierParticipantsResponse.participants:type_name -> com.evrblk.grackle.corepb.BarrierParticipant
+	36, // 22: com.evrblk.grackle.corepb.ListBarrierParticipantsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	36, // 23: com.evrblk.grackle.corepb.ListBarrierParticipantsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	35, // 24: com.evrblk.grackle.corepb.ListBarrierGenerationsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	36, // 25: com.evrblk.grackle.corepb.ListBarrierGenerationsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	28, // 26: com.evrblk.grackle.corepb.ListBarrierGenerationsResponse.generations:type_name -> com.evrblk.grackle.corepb.BarrierGeneration
+	36, // 27: com.evrblk.grackle.corepb.ListBarrierGenerationsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	36, // 28: com.evrblk.grackle.corepb.ListBarrierGenerationsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	35, // 29: com.evrblk.grackle.corepb.BarriersDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	23, // 30: com.evrblk.grackle.corepb.Barrier.id:type_name -> com.evrblk.grackle.corepb.BarrierId
+	33, // 31: com.evrblk.grackle.corepb.Barrier.metadata:type_name -> com.evrblk.grackle.corepb.Barrier.MetadataEntry
+	35, // 32: com.evrblk.grackle.corepb.BarriersGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	23, // 33: com.evrblk.grackle.corepb.BarriersGarbageCollectionRecord.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
+	23, // 34: com.evrblk.grackle.corepb.BarriersDeletionRecord.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
+	23, // 35: com.evrblk.grackle.corepb.BarriersGenerationTrimRecord.barrier_id:type_name -> com.evrblk.grackle.corepb.BarrierId
+	34, // 36: com.evrblk.grackle.corepb.BarrierParticipant.metadata:type_name -> com.evrblk.grackle.corepb.BarrierParticipant.MetadataEntry
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_pkg_corepb_barriers_proto_init() }
@@ -1884,7 +2440,7 @@ func file_pkg_corepb_barriers_proto_init() {
 	}
 	file_pkg_corepb_common_proto_init()
 	file_pkg_corepb_namespaces_proto_init()
-	file_pkg_corepb_barriers_proto_msgTypes[23].OneofWrappers = []any{
+	file_pkg_corepb_barriers_proto_msgTypes[25].OneofWrappers = []any{
 		(*BarriersGarbageCollectionRecord_NamespaceId)(nil),
 		(*BarriersGarbageCollectionRecord_BarrierId)(nil),
 	}
@@ -1894,7 +2450,7 @@ func file_pkg_corepb_barriers_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_corepb_barriers_proto_rawDesc), len(file_pkg_corepb_barriers_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -78,7 +78,9 @@ type PaginationToken struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Type  PaginationToken_Type   `protobuf:"varint,1,opt,name=type,proto3,enum=com.evrblk.grackle.corepb.PaginationToken_Type" json:"type,omitempty"`
 	// Opaque encoded position (an internal store key); do not interpret.
-	Value         []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Stripe the token pages in, when listing the jobs of a striped wait group.
+	Stripe        uint32 `protobuf:"varint,3,opt,name=stripe,proto3" json:"stripe,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,6 +129,13 @@ func (x *PaginationToken) GetValue() []byte {
 	return nil
 }
 
+func (x *PaginationToken) GetStripe() uint32 {
+	if x != nil {
+		return x.Stripe
+	}
+	return 0
+}
+
 // LeaseId uniquely identifies a lease within an account and namespace. Lock and
 // semaphore leases share this type but live in separate keyspaces, so the same
 // numeric id can refer to a different lease for each primitive.
@@ -283,10 +292,11 @@ var File_pkg_corepb_common_proto protoreflect.FileDescriptor
 
 const file_pkg_corepb_common_proto_rawDesc = "" +
 	"\n" +
-	"\x17pkg/corepb/common.proto\x12\x19com.evrblk.grackle.corepb\"\x99\x01\n" +
+	"\x17pkg/corepb/common.proto\x12\x19com.evrblk.grackle.corepb\"\xb1\x01\n" +
 	"\x0fPaginationToken\x12C\n" +
 	"\x04type\x18\x01 \x01(\x0e2/.com.evrblk.grackle.corepb.PaginationToken.TypeR\x04type\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value\"+\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12\x16\n" +
+	"\x06stripe\x18\x03 \x01(\rR\x06stripe\"+\n" +
 	"\x04Type\x12\v\n" +
 	"\aINVALID\x10\x00\x12\b\n" +
 	"\x04NEXT\x10\x01\x12\f\n" +
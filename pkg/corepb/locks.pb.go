@@ -147,8 +147,16 @@ type AcquireLockRequest struct {
 	// Per-namespace quota enforced by the core; acquiring a brand-new lock is
 	// rejected if it would exceed this.
 	MaxNumberOfLocksPerNamespace int64 `protobuf:"varint,5,opt,name=max_number_of_locks_per_namespace,json=maxNumberOfLocksPerNamespace,proto3" json:"max_number_of_locks_per_namespace,omitempty"`
-	unknownFields                protoimpl.UnknownFields
-	sizeCache                    protoimpl.SizeCache
+	// Optional limit on how long the hold lasts, in seconds: it is released once
+	// reached even while the lease is refreshed. 0 means as long as the lease
+	// lives. Re-acquiring restarts it.
+	MaxHoldSeconds int64 `protobuf:"varint,6,opt,name=max_hold_seconds,json=maxHoldSeconds,proto3" json:"max_hold_seconds,omitempty"`
+	// Until when the caller keeps retrying, Unix nanoseconds. While it is in the
+	// future and the lock cannot be acquired, the lease is recorded as a waiter
+	// on the lock (see LockWaiter). 0 for a non-blocking acquire.
+	WaitUntil     int64 `protobuf:"varint,7,opt,name=wait_until,json=waitUntil,proto3" json:"wait_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AcquireLockRequest) Reset() {
@@ -216,6 +224,20 @@ func (x *AcquireLockRequest) GetMaxNumberOfLocksPerNamespace() int64 {
 	return 0
 }
 
+func (x *AcquireLockRequest) GetMaxHoldSeconds() int64 {
+	if x != nil {
+		return x.MaxHoldSeconds
+	}
+	return 0
+}
+
+func (x *AcquireLockRequest) GetWaitUntil() int64 {
+	if x != nil {
+		return x.WaitUntil
+	}
+	return 0
+}
+
 type AcquireLockResponse struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
 	Lock    *Lock                  `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
@@ -386,6 +408,113 @@ func (x *ReleaseLockResponse) GetLock() *Lock {
 	return nil
 }
 
+type TransferLockRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	LockId *LockId                `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+	// Lease that holds the lock now.
+	FromLeaseId uint64 `protobuf:"fixed64,2,opt,name=from_lease_id,json=fromLeaseId,proto3" json:"from_lease_id,omitempty"`
+	// Lease that takes the hold over. It must be alive and must not hold the lock
+	// already.
+	ToLeaseId     uint64 `protobuf:"fixed64,3,opt,name=to_lease_id,json=toLeaseId,proto3" json:"to_lease_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferLockRequest) Reset() {
+	*x = TransferLockRequest{}
+	mi := &file_pkg_corepb_locks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLockRequest) ProtoMessage() {}
+
+func (x *TransferLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_locks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLockRequest.ProtoReflect.Descriptor instead.
+func (*TransferLockRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransferLockRequest) GetLockId() *LockId {
+	if x != nil {
+		return x.LockId
+	}
+	return nil
+}
+
+func (x *TransferLockRequest) GetFromLeaseId() uint64 {
+	if x != nil {
+		return x.FromLeaseId
+	}
+	return 0
+}
+
+func (x *TransferLockRequest) GetToLeaseId() uint64 {
+	if x != nil {
+		return x.ToLeaseId
+	}
+	return 0
+}
+
+type TransferLockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lock          *Lock                  `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferLockResponse) Reset() {
+	*x = TransferLockResponse{}
+	mi := &file_pkg_corepb_locks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLockResponse) ProtoMessage() {}
+
+func (x *TransferLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_locks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLockResponse.ProtoReflect.Descriptor instead.
+func (*TransferLockResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransferLockResponse) GetLock() *Lock {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
 type GetLockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LockId        *LockId                `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
@@ -395,7 +524,7 @@ type GetLockRequest struct {
 
 func (x *GetLockRequest) Reset() {
 	*x = GetLockRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[4]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -407,7 +536,7 @@ func (x *GetLockRequest) String() string {
 func (*GetLockRequest) ProtoMessage() {}
 
 func (x *GetLockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[4]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +549,7 @@ func (x *GetLockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLockRequest.ProtoReflect.Descriptor instead.
 func (*GetLockRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{4}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetLockRequest) GetLockId() *LockId {
@@ -431,15 +560,17 @@ func (x *GetLockRequest) GetLockId() *LockId {
 }
 
 type GetLockResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Lock          *Lock                  `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lock  *Lock                  `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	// Number of leases currently waiting to acquire the lock.
+	WaitersCount  int64 `protobuf:"varint,2,opt,name=waiters_count,json=waitersCount,proto3" json:"waiters_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetLockResponse) Reset() {
 	*x = GetLockResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[5]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -451,7 +582,7 @@ func (x *GetLockResponse) String() string {
 func (*GetLockResponse) ProtoMessage() {}
 
 func (x *GetLockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[5]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +595,7 @@ func (x *GetLockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLockResponse.ProtoReflect.Descriptor instead.
 func (*GetLockResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{5}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetLockResponse) GetLock() *Lock {
@@ -474,6 +605,133 @@ func (x *GetLockResponse) GetLock() *Lock {
 	return nil
 }
 
+func (x *GetLockResponse) GetWaitersCount() int64 {
+	if x != nil {
+		return x.WaitersCount
+	}
+	return 0
+}
+
+type ListLockWaitersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	LockId          *LockId                `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+	PaginationToken *PaginationToken       `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListLockWaitersRequest) Reset() {
+	*x = ListLockWaitersRequest{}
+	mi := &file_pkg_corepb_locks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLockWaitersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLockWaitersRequest) ProtoMessage() {}
+
+func (x *ListLockWaitersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_locks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLockWaitersRequest.ProtoReflect.Descriptor instead.
+func (*ListLockWaitersRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListLockWaitersRequest) GetLockId() *LockId {
+	if x != nil {
+		return x.LockId
+	}
+	return nil
+}
+
+func (x *ListLockWaitersRequest) GetPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PaginationToken
+	}
+	return nil
+}
+
+func (x *ListLockWaitersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLockWaitersResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Waiters                 []*LockWaiter          `protobuf:"bytes,1,rep,name=waiters,proto3" json:"waiters,omitempty"`
+	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
+	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ListLockWaitersResponse) Reset() {
+	*x = ListLockWaitersResponse{}
+	mi := &file_pkg_corepb_locks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLockWaitersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLockWaitersResponse) ProtoMessage() {}
+
+func (x *ListLockWaitersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_locks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLockWaitersResponse.ProtoReflect.Descriptor instead.
+func (*ListLockWaitersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListLockWaitersResponse) GetWaiters() []*LockWaiter {
+	if x != nil {
+		return x.Waiters
+	}
+	return nil
+}
+
+func (x *ListLockWaitersResponse) GetNextPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.NextPaginationToken
+	}
+	return nil
+}
+
+func (x *ListLockWaitersResponse) GetPreviousPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PreviousPaginationToken
+	}
+	return nil
+}
+
 type DeleteLockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LockId        *LockId                `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
@@ -483,7 +741,7 @@ type DeleteLockRequest struct {
 
 func (x *DeleteLockRequest) Reset() {
 	*x = DeleteLockRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[6]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -495,7 +753,7 @@ func (x *DeleteLockRequest) String() string {
 func (*DeleteLockRequest) ProtoMessage() {}
 
 func (x *DeleteLockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[6]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,7 +766,7 @@ func (x *DeleteLockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteLockRequest.ProtoReflect.Descriptor instead.
 func (*DeleteLockRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{6}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteLockRequest) GetLockId() *LockId {
@@ -526,7 +784,7 @@ type DeleteLockResponse struct {
 
 func (x *DeleteLockResponse) Reset() {
 	*x = DeleteLockResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[7]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -538,7 +796,7 @@ func (x *DeleteLockResponse) String() string {
 func (*DeleteLockResponse) ProtoMessage() {}
 
 func (x *DeleteLockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[7]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,7 +809,7 @@ func (x *DeleteLockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteLockResponse.ProtoReflect.Descriptor instead.
 func (*DeleteLockResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{7}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{11}
 }
 
 type ListLocksRequest struct {
@@ -565,7 +823,7 @@ type ListLocksRequest struct {
 
 func (x *ListLocksRequest) Reset() {
 	*x = ListLocksRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[8]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +835,7 @@ func (x *ListLocksRequest) String() string {
 func (*ListLocksRequest) ProtoMessage() {}
 
 func (x *ListLocksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[8]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +848,7 @@ func (x *ListLocksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLocksRequest.ProtoReflect.Descriptor instead.
 func (*ListLocksRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{8}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListLocksRequest) GetNamespaceId() *NamespaceId {
@@ -625,7 +883,7 @@ type ListLocksResponse struct {
 
 func (x *ListLocksResponse) Reset() {
 	*x = ListLocksResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[9]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -637,7 +895,7 @@ func (x *ListLocksResponse) String() string {
 func (*ListLocksResponse) ProtoMessage() {}
 
 func (x *ListLocksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[9]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -650,7 +908,7 @@ func (x *ListLocksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLocksResponse.ProtoReflect.Descriptor instead.
 func (*ListLocksResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{9}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListLocksResponse) GetLocks() []*Lock {
@@ -685,7 +943,7 @@ type ListLocksByLeaseIdRequest struct {
 
 func (x *ListLocksByLeaseIdRequest) Reset() {
 	*x = ListLocksByLeaseIdRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[10]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -697,7 +955,7 @@ func (x *ListLocksByLeaseIdRequest) String() string {
 func (*ListLocksByLeaseIdRequest) ProtoMessage() {}
 
 func (x *ListLocksByLeaseIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[10]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -710,7 +968,7 @@ func (x *ListLocksByLeaseIdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLocksByLeaseIdRequest.ProtoReflect.Descriptor instead.
 func (*ListLocksByLeaseIdRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{10}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListLocksByLeaseIdRequest) GetLeaseId() *LeaseId {
@@ -745,7 +1003,7 @@ type ListLocksByLeaseIdResponse struct {
 
 func (x *ListLocksByLeaseIdResponse) Reset() {
 	*x = ListLocksByLeaseIdResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[11]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -757,7 +1015,7 @@ func (x *ListLocksByLeaseIdResponse) String() string {
 func (*ListLocksByLeaseIdResponse) ProtoMessage() {}
 
 func (x *ListLocksByLeaseIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[11]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -770,7 +1028,7 @@ func (x *ListLocksByLeaseIdResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLocksByLeaseIdResponse.ProtoReflect.Descriptor instead.
 func (*ListLocksByLeaseIdResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{11}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListLocksByLeaseIdResponse) GetLocks() []*Lock {
@@ -805,7 +1063,7 @@ type RunLocksGarbageCollectionRequest struct {
 
 func (x *RunLocksGarbageCollectionRequest) Reset() {
 	*x = RunLocksGarbageCollectionRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[12]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -817,7 +1075,7 @@ func (x *RunLocksGarbageCollectionRequest) String() string {
 func (*RunLocksGarbageCollectionRequest) ProtoMessage() {}
 
 func (x *RunLocksGarbageCollectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[12]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,7 +1088,7 @@ func (x *RunLocksGarbageCollectionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunLocksGarbageCollectionRequest.ProtoReflect.Descriptor instead.
 func (*RunLocksGarbageCollectionRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{12}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RunLocksGarbageCollectionRequest) GetGcRecordsPageSize() int64 {
@@ -862,7 +1120,7 @@ type RunLocksGarbageCollectionResponse struct {
 
 func (x *RunLocksGarbageCollectionResponse) Reset() {
 	*x = RunLocksGarbageCollectionResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[13]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -874,7 +1132,7 @@ func (x *RunLocksGarbageCollectionResponse) String() string {
 func (*RunLocksGarbageCollectionResponse) ProtoMessage() {}
 
 func (x *RunLocksGarbageCollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[13]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -887,7 +1145,7 @@ func (x *RunLocksGarbageCollectionResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use RunLocksGarbageCollectionResponse.ProtoReflect.Descriptor instead.
 func (*RunLocksGarbageCollectionResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{13}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{17}
 }
 
 type LocksDeleteNamespaceRequest struct {
@@ -900,7 +1158,7 @@ type LocksDeleteNamespaceRequest struct {
 
 func (x *LocksDeleteNamespaceRequest) Reset() {
 	*x = LocksDeleteNamespaceRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[14]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -912,7 +1170,7 @@ func (x *LocksDeleteNamespaceRequest) String() string {
 func (*LocksDeleteNamespaceRequest) ProtoMessage() {}
 
 func (x *LocksDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[14]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,7 +1183,7 @@ func (x *LocksDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocksDeleteNamespaceRequest.ProtoReflect.Descriptor instead.
 func (*LocksDeleteNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{14}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *LocksDeleteNamespaceRequest) GetNamespaceId() *NamespaceId {
@@ -950,7 +1208,7 @@ type LocksDeleteNamespaceResponse struct {
 
 func (x *LocksDeleteNamespaceResponse) Reset() {
 	*x = LocksDeleteNamespaceResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[15]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -962,7 +1220,7 @@ func (x *LocksDeleteNamespaceResponse) String() string {
 func (*LocksDeleteNamespaceResponse) ProtoMessage() {}
 
 func (x *LocksDeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[15]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -975,7 +1233,7 @@ func (x *LocksDeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocksDeleteNamespaceResponse.ProtoReflect.Descriptor instead.
 func (*LocksDeleteNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{15}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{19}
 }
 
 type CreateLockLeaseRequest struct {
@@ -991,7 +1249,7 @@ type CreateLockLeaseRequest struct {
 
 func (x *CreateLockLeaseRequest) Reset() {
 	*x = CreateLockLeaseRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[16]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1003,7 +1261,7 @@ func (x *CreateLockLeaseRequest) String() string {
 func (*CreateLockLeaseRequest) ProtoMessage() {}
 
 func (x *CreateLockLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[16]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +1274,7 @@ func (x *CreateLockLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateLockLeaseRequest.ProtoReflect.Descriptor instead.
 func (*CreateLockLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{16}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CreateLockLeaseRequest) GetLeaseId() *LeaseId {
@@ -1063,7 +1321,7 @@ type CreateLockLeaseResponse struct {
 
 func (x *CreateLockLeaseResponse) Reset() {
 	*x = CreateLockLeaseResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[17]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1075,7 +1333,7 @@ func (x *CreateLockLeaseResponse) String() string {
 func (*CreateLockLeaseResponse) ProtoMessage() {}
 
 func (x *CreateLockLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[17]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1088,7 +1346,7 @@ func (x *CreateLockLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateLockLeaseResponse.ProtoReflect.Descriptor instead.
 func (*CreateLockLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{17}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CreateLockLeaseResponse) GetLease() *Lease {
@@ -1107,7 +1365,7 @@ type RevokeLockLeaseRequest struct {
 
 func (x *RevokeLockLeaseRequest) Reset() {
 	*x = RevokeLockLeaseRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[18]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1119,7 +1377,7 @@ func (x *RevokeLockLeaseRequest) String() string {
 func (*RevokeLockLeaseRequest) ProtoMessage() {}
 
 func (x *RevokeLockLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[18]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1132,7 +1390,7 @@ func (x *RevokeLockLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeLockLeaseRequest.ProtoReflect.Descriptor instead.
 func (*RevokeLockLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{18}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RevokeLockLeaseRequest) GetLeaseId() *LeaseId {
@@ -1150,7 +1408,7 @@ type RevokeLockLeaseResponse struct {
 
 func (x *RevokeLockLeaseResponse) Reset() {
 	*x = RevokeLockLeaseResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[19]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1162,7 +1420,7 @@ func (x *RevokeLockLeaseResponse) String() string {
 func (*RevokeLockLeaseResponse) ProtoMessage() {}
 
 func (x *RevokeLockLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[19]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1175,7 +1433,7 @@ func (x *RevokeLockLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeLockLeaseResponse.ProtoReflect.Descriptor instead.
 func (*RevokeLockLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{19}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{23}
 }
 
 type RefreshLockLeaseRequest struct {
@@ -1188,7 +1446,7 @@ type RefreshLockLeaseRequest struct {
 
 func (x *RefreshLockLeaseRequest) Reset() {
 	*x = RefreshLockLeaseRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[20]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1200,7 +1458,7 @@ func (x *RefreshLockLeaseRequest) String() string {
 func (*RefreshLockLeaseRequest) ProtoMessage() {}
 
 func (x *RefreshLockLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[20]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1213,7 +1471,7 @@ func (x *RefreshLockLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshLockLeaseRequest.ProtoReflect.Descriptor instead.
 func (*RefreshLockLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{20}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *RefreshLockLeaseRequest) GetLeaseId() *LeaseId {
@@ -1239,7 +1497,7 @@ type RefreshLockLeaseResponse struct {
 
 func (x *RefreshLockLeaseResponse) Reset() {
 	*x = RefreshLockLeaseResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[21]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1251,7 +1509,7 @@ func (x *RefreshLockLeaseResponse) String() string {
 func (*RefreshLockLeaseResponse) ProtoMessage() {}
 
 func (x *RefreshLockLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[21]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1264,7 +1522,7 @@ func (x *RefreshLockLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshLockLeaseResponse.ProtoReflect.Descriptor instead.
 func (*RefreshLockLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{21}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *RefreshLockLeaseResponse) GetLease() *Lease {
@@ -1283,7 +1541,7 @@ type GetLockLeaseRequest struct {
 
 func (x *GetLockLeaseRequest) Reset() {
 	*x = GetLockLeaseRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[22]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1295,7 +1553,7 @@ func (x *GetLockLeaseRequest) String() string {
 func (*GetLockLeaseRequest) ProtoMessage() {}
 
 func (x *GetLockLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[22]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1308,7 +1566,7 @@ func (x *GetLockLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLockLeaseRequest.ProtoReflect.Descriptor instead.
 func (*GetLockLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{22}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetLockLeaseRequest) GetLeaseId() *LeaseId {
@@ -1327,7 +1585,7 @@ type GetLockLeaseResponse struct {
 
 func (x *GetLockLeaseResponse) Reset() {
 	*x = GetLockLeaseResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[23]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1339,7 +1597,7 @@ func (x *GetLockLeaseResponse) String() string {
 func (*GetLockLeaseResponse) ProtoMessage() {}
 
 func (x *GetLockLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[23]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1352,7 +1610,7 @@ func (x *GetLockLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLockLeaseResponse.ProtoReflect.Descriptor instead.
 func (*GetLockLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{23}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetLockLeaseResponse) GetLease() *Lease {
@@ -1373,7 +1631,7 @@ type ListLockLeasesRequest struct {
 
 func (x *ListLockLeasesRequest) Reset() {
 	*x = ListLockLeasesRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[24]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1385,7 +1643,7 @@ func (x *ListLockLeasesRequest) String() string {
 func (*ListLockLeasesRequest) ProtoMessage() {}
 
 func (x *ListLockLeasesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[24]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1398,7 +1656,7 @@ func (x *ListLockLeasesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLockLeasesRequest.ProtoReflect.Descriptor instead.
 func (*ListLockLeasesRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{24}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ListLockLeasesRequest) GetNamespaceId() *NamespaceId {
@@ -1433,7 +1691,7 @@ type ListLockLeasesResponse struct {
 
 func (x *ListLockLeasesResponse) Reset() {
 	*x = ListLockLeasesResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[25]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1445,7 +1703,7 @@ func (x *ListLockLeasesResponse) String() string {
 func (*ListLockLeasesResponse) ProtoMessage() {}
 
 func (x *ListLockLeasesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[25]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1458,7 +1716,7 @@ func (x *ListLockLeasesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLockLeasesResponse.ProtoReflect.Descriptor instead.
 func (*ListLockLeasesResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{25}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ListLockLeasesResponse) GetLeases() []*Lease {
@@ -1494,7 +1752,7 @@ type ListLockLeasesByProcessIdRequest struct {
 
 func (x *ListLockLeasesByProcessIdRequest) Reset() {
 	*x = ListLockLeasesByProcessIdRequest{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[26]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1506,7 +1764,7 @@ func (x *ListLockLeasesByProcessIdRequest) String() string {
 func (*ListLockLeasesByProcessIdRequest) ProtoMessage() {}
 
 func (x *ListLockLeasesByProcessIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[26]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1519,7 +1777,7 @@ func (x *ListLockLeasesByProcessIdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLockLeasesByProcessIdRequest.ProtoReflect.Descriptor instead.
 func (*ListLockLeasesByProcessIdRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{26}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ListLockLeasesByProcessIdRequest) GetNamespaceId() *NamespaceId {
@@ -1561,7 +1819,7 @@ type ListLockLeasesByProcessIdResponse struct {
 
 func (x *ListLockLeasesByProcessIdResponse) Reset() {
 	*x = ListLockLeasesByProcessIdResponse{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[27]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1573,7 +1831,7 @@ func (x *ListLockLeasesByProcessIdResponse) String() string {
 func (*ListLockLeasesByProcessIdResponse) ProtoMessage() {}
 
 func (x *ListLockLeasesByProcessIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[27]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1586,7 +1844,7 @@ func (x *ListLockLeasesByProcessIdResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ListLockLeasesByProcessIdResponse.ProtoReflect.Descriptor instead.
 func (*ListLockLeasesByProcessIdResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{27}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ListLockLeasesByProcessIdResponse) GetLeases() []*Lease {
@@ -1635,7 +1893,7 @@ type Lock struct {
 
 func (x *Lock) Reset() {
 	*x = Lock{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[28]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1647,7 +1905,7 @@ func (x *Lock) String() string {
 func (*Lock) ProtoMessage() {}
 
 func (x *Lock) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[28]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1660,7 +1918,7 @@ func (x *Lock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Lock.ProtoReflect.Descriptor instead.
 func (*Lock) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{28}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *Lock) GetId() *LockId {
@@ -1704,15 +1962,18 @@ type LockHolder struct {
 	// The lease holding the lock.
 	LeaseId uint64 `protobuf:"fixed64,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
 	// When this holder acquired (or last refreshed) the lock, Unix nanoseconds.
-	LockedAt      int64             `protobuf:"fixed64,2,opt,name=locked_at,json=lockedAt,proto3" json:"locked_at,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	LockedAt int64             `protobuf:"fixed64,2,opt,name=locked_at,json=lockedAt,proto3" json:"locked_at,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// When the hold is released regardless of the lease (max_hold_seconds), Unix
+	// nanoseconds; 0 when it lasts as long as the lease.
+	HoldExpiresAt int64 `protobuf:"fixed64,4,opt,name=hold_expires_at,json=holdExpiresAt,proto3" json:"hold_expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LockHolder) Reset() {
 	*x = LockHolder{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[29]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1724,7 +1985,7 @@ func (x *LockHolder) String() string {
 func (*LockHolder) ProtoMessage() {}
 
 func (x *LockHolder) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[29]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,7 +1998,7 @@ func (x *LockHolder) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LockHolder.ProtoReflect.Descriptor instead.
 func (*LockHolder) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{29}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *LockHolder) GetLeaseId() uint64 {
@@ -1761,6 +2022,107 @@ func (x *LockHolder) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *LockHolder) GetHoldExpiresAt() int64 {
+	if x != nil {
+		return x.HoldExpiresAt
+	}
+	return 0
+}
+
+// LockWaiter is a lease blocked in AcquireLock on a lock it could not get yet.
+// Waiters are short-lived diagnostic records: they are refreshed by every retry
+// of the acquire, removed once it succeeds or gives up, and otherwise lapse at
+// expires_at. They have no effect on who acquires the lock next.
+type LockWaiter struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	LockId *LockId                `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+	// The lease waiting for the lock.
+	LeaseId uint64 `protobuf:"fixed64,2,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	// Mode the lease is waiting for.
+	Exclusive bool `protobuf:"varint,3,opt,name=exclusive,proto3" json:"exclusive,omitempty"`
+	// process_id of the waiting lease.
+	ProcessId string `protobuf:"bytes,4,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
+	// When the lease started waiting, Unix nanoseconds.
+	WaitingSince int64 `protobuf:"fixed64,5,opt,name=waiting_since,json=waitingSince,proto3" json:"waiting_since,omitempty"`
+	// When the record lapses unless refreshed: the end of the wait or the
+	// expiration of the lease, whichever comes first. Unix nanoseconds.
+	ExpiresAt     int64 `protobuf:"fixed64,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockWaiter) Reset() {
+	*x = LockWaiter{}
+	mi := &file_pkg_corepb_locks_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockWaiter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockWaiter) ProtoMessage() {}
+
+func (x *LockWaiter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_locks_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockWaiter.ProtoReflect.Descriptor instead.
+func (*LockWaiter) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LockWaiter) GetLockId() *LockId {
+	if x != nil {
+		return x.LockId
+	}
+	return nil
+}
+
+func (x *LockWaiter) GetLeaseId() uint64 {
+	if x != nil {
+		return x.LeaseId
+	}
+	return 0
+}
+
+func (x *LockWaiter) GetExclusive() bool {
+	if x != nil {
+		return x.Exclusive
+	}
+	return false
+}
+
+func (x *LockWaiter) GetProcessId() string {
+	if x != nil {
+		return x.ProcessId
+	}
+	return ""
+}
+
+func (x *LockWaiter) GetWaitingSince() int64 {
+	if x != nil {
+		return x.WaitingSince
+	}
+	return 0
+}
+
+func (x *LockWaiter) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 // LockId uniquely identifies a lock. lock_name is the '/'-separated hierarchical
 // path.
 type LockId struct {
@@ -1774,7 +2136,7 @@ type LockId struct {
 
 func (x *LockId) Reset() {
 	*x = LockId{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[30]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1786,7 +2148,7 @@ func (x *LockId) String() string {
 func (*LockId) ProtoMessage() {}
 
 func (x *LockId) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[30]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1799,7 +2161,7 @@ func (x *LockId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LockId.ProtoReflect.Descriptor instead.
 func (*LockId) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{30}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *LockId) GetAccountId() uint64 {
@@ -1835,7 +2197,7 @@ type LocksCounter struct {
 
 func (x *LocksCounter) Reset() {
 	*x = LocksCounter{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[31]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1847,7 +2209,7 @@ func (x *LocksCounter) String() string {
 func (*LocksCounter) ProtoMessage() {}
 
 func (x *LocksCounter) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[31]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1860,7 +2222,7 @@ func (x *LocksCounter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocksCounter.ProtoReflect.Descriptor instead.
 func (*LocksCounter) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{31}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *LocksCounter) GetNumberOfLocks() int64 {
@@ -1877,6 +2239,62 @@ func (x *LocksCounter) GetNumberOfLeases() int64 {
 	return 0
 }
 
+// LocksHoldExpirationRecord is an index entry the core uses to find locks with
+// holders due to reach their max hold time, so they can be released in time
+// order.
+type LocksHoldExpirationRecord struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	LockId *LockId                `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+	// The earliest hold_expires_at across the lock's holders, Unix nanoseconds.
+	ExpiresAt     int64 `protobuf:"fixed64,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocksHoldExpirationRecord) Reset() {
+	*x = LocksHoldExpirationRecord{}
+	mi := &file_pkg_corepb_locks_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocksHoldExpirationRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocksHoldExpirationRecord) ProtoMessage() {}
+
+func (x *LocksHoldExpirationRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_locks_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocksHoldExpirationRecord.ProtoReflect.Descriptor instead.
+func (*LocksHoldExpirationRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *LocksHoldExpirationRecord) GetLockId() *LockId {
+	if x != nil {
+		return x.LockId
+	}
+	return nil
+}
+
+func (x *LocksHoldExpirationRecord) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 // LocksGarbageCollectionRecord is an internal bookkeeping entry queuing a
 // namespace's locks for asynchronous deletion (e.g. after the namespace itself
 // is deleted).
@@ -1890,7 +2308,7 @@ type LocksGarbageCollectionRecord struct {
 
 func (x *LocksGarbageCollectionRecord) Reset() {
 	*x = LocksGarbageCollectionRecord{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[32]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1902,7 +2320,7 @@ func (x *LocksGarbageCollectionRecord) String() string {
 func (*LocksGarbageCollectionRecord) ProtoMessage() {}
 
 func (x *LocksGarbageCollectionRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[32]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,7 +2333,7 @@ func (x *LocksGarbageCollectionRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocksGarbageCollectionRecord.ProtoReflect.Descriptor instead.
 func (*LocksGarbageCollectionRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{32}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *LocksGarbageCollectionRecord) GetId() uint64 {
@@ -1952,7 +2370,7 @@ type LockAncestor struct {
 
 func (x *LockAncestor) Reset() {
 	*x = LockAncestor{}
-	mi := &file_pkg_corepb_locks_proto_msgTypes[33]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1964,7 +2382,7 @@ func (x *LockAncestor) String() string {
 func (*LockAncestor) ProtoMessage() {}
 
 func (x *LockAncestor) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_locks_proto_msgTypes[33]
+	mi := &file_pkg_corepb_locks_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1977,7 +2395,7 @@ func (x *LockAncestor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LockAncestor.ProtoReflect.Descriptor instead.
 func (*LockAncestor) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{33}
+	return file_pkg_corepb_locks_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *LockAncestor) GetId() *LockId {
@@ -2005,13 +2423,16 @@ var File_pkg_corepb_locks_proto protoreflect.FileDescriptor
 
 const file_pkg_corepb_locks_proto_rawDesc = "" +
 	"\n" +
-	"\x16pkg/corepb/locks.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\xe8\x02\n" +
+	"\x16pkg/corepb/locks.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\xb1\x03\n" +
 	"\x12AcquireLockRequest\x12:\n" +
 	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\x12\x19\n" +
 	"\blease_id\x18\x02 \x01(\x06R\aleaseId\x12\x1c\n" +
 	"\texclusive\x18\x03 \x01(\bR\texclusive\x12W\n" +
 	"\bmetadata\x18\x04 \x03(\v2;.com.evrblk.grackle.corepb.AcquireLockRequest.MetadataEntryR\bmetadata\x12G\n" +
-	"!max_number_of_locks_per_namespace\x18\x05 \x01(\x03R\x1cmaxNumberOfLocksPerNamespace\x1a;\n" +
+	"!max_number_of_locks_per_namespace\x18\x05 \x01(\x03R\x1cmaxNumberOfLocksPerNamespace\x12(\n" +
+	"\x10max_hold_seconds\x18\x06 \x01(\x03R\x0emaxHoldSeconds\x12\x1d\n" +
+	"\n" +
+	"wait_until\x18\a \x01(\x03R\twaitUntil\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf1\x01\n" +
@@ -2024,11 +2445,26 @@ const file_pkg_corepb_locks_proto_rawDesc = "" +
 	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\x12\x19\n" +
 	"\blease_id\x18\x02 \x01(\x06R\aleaseId\"J\n" +
 	"\x13ReleaseLockResponse\x123\n" +
+	"\x04lock\x18\x01 \x01(\v2\x1f.com.evrblk.grackle.corepb.LockR\x04lock\"\x95\x01\n" +
+	"\x13TransferLockRequest\x12:\n" +
+	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\x12\"\n" +
+	"\rfrom_lease_id\x18\x02 \x01(\x06R\vfromLeaseId\x12\x1e\n" +
+	"\vto_lease_id\x18\x03 \x01(\x06R\ttoLeaseId\"K\n" +
+	"\x14TransferLockResponse\x123\n" +
 	"\x04lock\x18\x01 \x01(\v2\x1f.com.evrblk.grackle.corepb.LockR\x04lock\"L\n" +
 	"\x0eGetLockRequest\x12:\n" +
-	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\"F\n" +
+	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\"k\n" +
 	"\x0fGetLockResponse\x123\n" +
-	"\x04lock\x18\x01 \x01(\v2\x1f.com.evrblk.grackle.corepb.LockR\x04lock\"O\n" +
+	"\x04lock\x18\x01 \x01(\v2\x1f.com.evrblk.grackle.corepb.LockR\x04lock\x12#\n" +
+	"\rwaiters_count\x18\x02 \x01(\x03R\fwaitersCount\"\xc1\x01\n" +
+	"\x16ListLockWaitersRequest\x12:\n" +
+	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\x12U\n" +
+	"\x10pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xa2\x02\n" +
+	"\x17ListLockWaitersResponse\x12?\n" +
+	"\awaiters\x18\x01 \x03(\v2%.com.evrblk.grackle.corepb.LockWaiterR\awaiters\x12^\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"O\n" +
 	"\x11DeleteLockRequest\x12:\n" +
 	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\"\x14\n" +
 	"\x12DeleteLockResponse\"\xca\x01\n" +
@@ -2106,15 +2542,26 @@ const file_pkg_corepb_locks_proto_rawDesc = "" +
 	"\x05state\x18\x02 \x01(\x0e2$.com.evrblk.grackle.corepb.LockStateR\x05state\x12\x1b\n" +
 	"\tlocked_at\x18\x03 \x01(\x10R\blockedAt\x12H\n" +
 	"\flock_holders\x18\x04 \x03(\v2%.com.evrblk.grackle.corepb.LockHolderR\vlockHolders\x12(\n" +
-	"\x10last_activity_at\x18\x05 \x01(\x10R\x0elastActivityAt\"\xd2\x01\n" +
+	"\x10last_activity_at\x18\x05 \x01(\x10R\x0elastActivityAt\"\xfa\x01\n" +
 	"\n" +
 	"LockHolder\x12\x19\n" +
 	"\blease_id\x18\x01 \x01(\x06R\aleaseId\x12\x1b\n" +
 	"\tlocked_at\x18\x02 \x01(\x10R\blockedAt\x12O\n" +
-	"\bmetadata\x18\x03 \x03(\v23.com.evrblk.grackle.corepb.LockHolder.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x03 \x03(\v23.com.evrblk.grackle.corepb.LockHolder.MetadataEntryR\bmetadata\x12&\n" +
+	"\x0fhold_expires_at\x18\x04 \x01(\x10R\rholdExpiresAt\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"g\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe4\x01\n" +
+	"\n" +
+	"LockWaiter\x12:\n" +
+	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\x12\x19\n" +
+	"\blease_id\x18\x02 \x01(\x06R\aleaseId\x12\x1c\n" +
+	"\texclusive\x18\x03 \x01(\bR\texclusive\x12\x1d\n" +
+	"\n" +
+	"process_id\x18\x04 \x01(\tR\tprocessId\x12#\n" +
+	"\rwaiting_since\x18\x05 \x01(\x10R\fwaitingSince\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x10R\texpiresAt\"g\n" +
 	"\x06LockId\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\x06R\taccountId\x12!\n" +
@@ -2122,7 +2569,11 @@ const file_pkg_corepb_locks_proto_rawDesc = "" +
 	"\tlock_name\x18\x03 \x01(\tR\blockName\"`\n" +
 	"\fLocksCounter\x12&\n" +
 	"\x0fnumber_of_locks\x18\x01 \x01(\x03R\rnumberOfLocks\x12(\n" +
-	"\x10number_of_leases\x18\x02 \x01(\x03R\x0enumberOfLeases\"y\n" +
+	"\x10number_of_leases\x18\x02 \x01(\x03R\x0enumberOfLeases\"v\n" +
+	"\x19LocksHoldExpirationRecord\x12:\n" +
+	"\alock_id\x18\x01 \x01(\v2!.com.evrblk.grackle.corepb.LockIdR\x06lockId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x10R\texpiresAt\"y\n" +
 	"\x1cLocksGarbageCollectionRecord\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x06R\x02id\x12I\n" +
 	"\fnamespace_id\x18\x02 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\"\x8d\x01\n" +
@@ -2154,7 +2605,7 @@ func file_pkg_corepb_locks_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_corepb_locks_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_pkg_corepb_locks_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_pkg_corepb_locks_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_pkg_corepb_locks_proto_goTypes = []any{
 	(ContentionReason)(0),                     // 0: com.evrblk.grackle.corepb.ContentionReason
 	(LockState)(0),                            // 1: com.evrblk.grackle.corepb.LockState
@@ -2162,95 +2613,110 @@ var file_pkg_corepb_locks_proto_goTypes = []any{
 	(*AcquireLockResponse)(nil),               // 3: com.evrblk.grackle.corepb.AcquireLockResponse
 	(*ReleaseLockRequest)(nil),                // 4: com.evrblk.grackle.corepb.ReleaseLockRequest
 	(*ReleaseLockResponse)(nil),               // 5: com.evrblk.grackle.corepb.ReleaseLockResponse
-	(*GetLockRequest)(nil),                    // 6: com.evrblk.grackle.corepb.GetLockRequest
-	(*GetLockResponse)(nil),                   // 7: com.evrblk.grackle.corepb.GetLockResponse
-	(*DeleteLockRequest)(nil),                 // 8: com.evrblk.grackle.corepb.DeleteLockRequest
-	(*DeleteLockResponse)(nil),                // 9: com.evrblk.grackle.corepb.DeleteLockResponse
-	(*ListLocksRequest)(nil),                  // 10: com.evrblk.grackle.corepb.ListLocksRequest
-	(*ListLocksResponse)(nil),                 // 11: com.evrblk.grackle.corepb.ListLocksResponse
-	(*ListLocksByLeaseIdRequest)(nil),         // 12: com.evrblk.grackle.corepb.ListLocksByLeaseIdRequest
-	(*ListLocksByLeaseIdResponse)(nil),        // 13: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse
-	(*RunLocksGarbageCollectionRequest)(nil),  // 14: com.evrblk.grackle.corepb.RunLocksGarbageCollectionRequest
-	(*RunLocksGarbageCollectionResponse)(nil), // 15: com.evrblk.grackle.corepb.RunLocksGarbageCollectionResponse
-	(*LocksDeleteNamespaceRequest)(nil),       // 16: com.evrblk.grackle.corepb.LocksDeleteNamespaceRequest
-	(*LocksDeleteNamespaceResponse)(nil),      // 17: com.evrblk.grackle.corepb.LocksDeleteNamespaceResponse
-	(*CreateLockLeaseRequest)(nil),            // 18: com.evrblk.grackle.corepb.CreateLockLeaseRequest
-	(*CreateLockLeaseResponse)(nil),           // 19: com.evrblk.grackle.corepb.CreateLockLeaseResponse
-	(*RevokeLockLeaseRequest)(nil),            // 20: com.evrblk.grackle.corepb.RevokeLockLeaseRequest
-	(*RevokeLockLeaseResponse)(nil),           // 21: com.evrblk.grackle.corepb.RevokeLockLeaseResponse
-	(*RefreshLockLeaseRequest)(nil),           // 22: com.evrblk.grackle.corepb.RefreshLockLeaseRequest
-	(*RefreshLockLeaseResponse)(nil),          // 23: com.evrblk.grackle.corepb.RefreshLockLeaseResponse
-	(*GetLockLeaseRequest)(nil),               // 24: com.evrblk.grackle.corepb.GetLockLeaseRequest
-	(*GetLockLeaseResponse)(nil),              // 25: com.evrblk.grackle.corepb.GetLockLeaseResponse
-	(*ListLockLeasesRequest)(nil),             // 26: com.evrblk.grackle.corepb.ListLockLeasesRequest
-	(*ListLockLeasesResponse)(nil),            // 27: com.evrblk.grackle.corepb.ListLockLeasesResponse
-	(*ListLockLeasesByProcessIdRequest)(nil),  // 28: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdRequest
-	(*ListLockLeasesByProcessIdResponse)(nil), // 29: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse
-	(*Lock)(nil),                              // 30: com.evrblk.grackle.corepb.Lock
-	(*LockHolder)(nil),                        // 31: com.evrblk.grackle.corepb.LockHolder
-	(*LockId)(nil),                            // 32: com.evrblk.grackle.corepb.LockId
-	(*LocksCounter)(nil),                      // 33: com.evrblk.grackle.corepb.LocksCounter
-	(*LocksGarbageCollectionRecord)(nil),      // 34: com.evrblk.grackle.corepb.LocksGarbageCollectionRecord
-	(*LockAncestor)(nil),                      // 35: com.evrblk.grackle.corepb.LockAncestor
-	nil,                                       // 36: com.evrblk.grackle.corepb.AcquireLockRequest.MetadataEntry
-	nil,                                       // 37: com.evrblk.grackle.corepb.CreateLockLeaseRequest.MetadataEntry
-	nil,                                       // 38: com.evrblk.grackle.corepb.LockHolder.MetadataEntry
-	(*NamespaceId)(nil),                       // 39: com.evrblk.grackle.corepb.NamespaceId
-	(*PaginationToken)(nil),                   // 40: com.evrblk.grackle.corepb.PaginationToken
-	(*LeaseId)(nil),                           // 41: com.evrblk.grackle.corepb.LeaseId
-	(*Lease)(nil),                             // 42: com.evrblk.grackle.corepb.Lease
+	(*TransferLockRequest)(nil),               // 6: com.evrblk.grackle.corepb.TransferLockRequest
+	(*TransferLockResponse)(nil),              // 7: com.evrblk.grackle.corepb.TransferLockResponse
+	(*GetLockRequest)(nil),                    // 8: com.evrblk.grackle.corepb.GetLockRequest
+	(*GetLockResponse)(nil),                   // 9: com.evrblk.grackle.corepb.GetLockResponse
+	(*ListLockWaitersRequest)(nil),            // 10: com.evrblk.grackle.corepb.ListLockWaitersRequest
+	(*ListLockWaitersResponse)(nil),           // 11: com.evrblk.grackle.corepb.ListLockWaitersResponse
+	(*DeleteLockRequest)(nil),                 // 12: com.evrblk.grackle.corepb.DeleteLockRequest
+	(*DeleteLockResponse)(nil),                // 13: com.evrblk.grackle.corepb.DeleteLockResponse
+	(*ListLocksRequest)(nil),                  // 14: com.evrblk.grackle.corepb.ListLocksRequest
+	(*ListLocksResponse)(nil),                 // 15: com.evrblk.grackle.corepb.ListLocksResponse
+	(*ListLocksByLeaseIdRequest)(nil),         // 16: com.evrblk.grackle.corepb.ListLocksByLeaseIdRequest
+	(*ListLocksByLeaseIdResponse)(nil),        // 17: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse
+	(*RunLocksGarbageCollectionRequest)(nil),  // 18: com.evrblk.grackle.corepb.RunLocksGarbageCollectionRequest
+	(*RunLocksGarbageCollectionResponse)(nil), // 19: com.evrblk.grackle.corepb.RunLocksGarbageCollectionResponse
+	(*LocksDeleteNamespaceRequest)(nil),       // 20: com.evrblk.grackle.corepb.LocksDeleteNamespaceRequest
+	(*LocksDeleteNamespaceResponse)(nil),      // 21: com.evrblk.grackle.corepb.LocksDeleteNamespaceResponse
+	(*CreateLockLeaseRequest)(nil),            // 22: com.evrblk.grackle.corepb.CreateLockLeaseRequest
+	(*CreateLockLeaseResponse)(nil),           // 23: com.evrblk.grackle.corepb.CreateLockLeaseResponse
+	(*RevokeLockLeaseRequest)(nil),            // 24: com.evrblk.grackle.corepb.RevokeLockLeaseRequest
+	(*RevokeLockLeaseResponse)(nil),           // 25: com.evrblk.grackle.corepb.RevokeLockLeaseResponse
+	(*RefreshLockLeaseRequest)(nil),           // 26: com.evrblk.grackle.corepb.RefreshLockLeaseRequest
+	(*RefreshLockLeaseResponse)(nil),          // 27: com.evrblk.grackle.corepb.RefreshLockLeaseResponse
+	(*GetLockLeaseRequest)(nil),               // 28: com.evrblk.grackle.corepb.GetLockLeaseRequest
+	(*GetLockLeaseResponse)(nil),              // 29: com.evrblk.grackle.corepb.GetLockLeaseResponse
+	(*ListLockLeasesRequest)(nil),             // 30: com.evrblk.grackle.corepb.ListLockLeasesRequest
+	(*ListLockLeasesResponse)(nil),            // 31: com.evrblk.grackle.corepb.ListLockLeasesResponse
+	(*ListLockLeasesByProcessIdRequest)(nil),  // 32: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdRequest
+	(*ListLockLeasesByProcessIdResponse)(nil), // 33: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse
+	(*Lock)(nil),                              // 34: com.evrblk.grackle.corepb.Lock
+	(*LockHolder)(nil),                        // 35: com.evrblk.grackle.corepb.LockHolder
+	(*LockWaiter)(nil),                        // 36: com.evrblk.grackle.corepb.LockWaiter
+	(*LockId)(nil),                            // 37: com.evrblk.grackle.corepb.LockId
+	(*LocksCounter)(nil),                      // 38: com.evrblk.grackle.corepb.LocksCounter
+	(*LocksHoldExpirationRecord)(nil),         // 39: com.evrblk.grackle.corepb.LocksHoldExpirationRecord
+	(*LocksGarbageCollectionRecord)(nil),      // 40: com.evrblk.grackle.corepb.LocksGarbageCollectionRecord
+	(*LockAncestor)(nil),                      // 41: com.evrblk.grackle.corepb.LockAncestor
+	nil,                                       // 42: com.evrblk.grackle.corepb.AcquireLockRequest.MetadataEntry
+	nil,                                       // 43: com.evrblk.grackle.corepb.CreateLockLeaseRequest.MetadataEntry
+	nil,                                       // 44: com.evrblk.grackle.corepb.LockHolder.MetadataEntry
+	(*PaginationToken)(nil),                   // 45: com.evrblk.grackle.corepb.PaginationToken
+	(*NamespaceId)(nil),                       // 46: com.evrblk.grackle.corepb.NamespaceId
+	(*LeaseId)(nil),                           // 47: com.evrblk.grackle.corepb.LeaseId
+	(*Lease)(nil),                             // 48: com.evrblk.grackle.corepb.Lease
 }
 var file_pkg_corepb_locks_proto_depIdxs = []int32{
-	32, // 0: com.evrblk.grackle.corepb.AcquireLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
-	36, // 1: com.evrblk.grackle.corepb.AcquireLockRequest.metadata:type_name -> com.evrblk.grackle.corepb.AcquireLockRequest.MetadataEntry
-	30, // 2: com.evrblk.grackle.corepb.AcquireLockResponse.lock:type_name -> com.evrblk.grackle.corepb.Lock
+	37, // 0: com.evrblk.grackle.corepb.AcquireLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	42, // 1: com.evrblk.grackle.corepb.AcquireLockRequest.metadata:type_name -> com.evrblk.grackle.corepb.AcquireLockRequest.MetadataEntry
+	34, // 2: com.evrblk.grackle.corepb.AcquireLockResponse.lock:type_name -> com.evrblk.grackle.corepb.Lock
 	0,  // 3: com.evrblk.grackle.corepb.AcquireLockResponse.reason:type_name -> com.evrblk.grackle.corepb.ContentionReason
-	30, // 4: com.evrblk.grackle.corepb.AcquireLockResponse.blocking_locks:type_name -> com.evrblk.grackle.corepb.Lock
-	32, // 5: com.evrblk.grackle.corepb.ReleaseLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
-	30, // 6: com.evrblk.grackle.corepb.ReleaseLockResponse.lock:type_name -> com.evrblk.grackle.corepb.Lock
-	32, // 7: com.evrblk.grackle.corepb.GetLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
-	30, // 8: com.evrblk.grackle.corepb.GetLockResponse.lock:type_name -> com.evrblk.grackle.corepb.Lock
-	32, // 9: com.evrblk.grackle.corepb.DeleteLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
-	39, // 10: com.evrblk.grackle.corepb.ListLocksRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	40, // 11: com.evrblk.grackle.corepb.ListLocksRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	30, // 12: com.evrblk.grackle.corepb.ListLocksResponse.locks:type_name -> com.evrblk.grackle.corepb.Lock
-	40, // 13: com.evrblk.grackle.corepb.ListLocksResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	40, // 14: com.evrblk.grackle.corepb.ListLocksResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	41, // 15: com.evrblk.grackle.corepb.ListLocksByLeaseIdRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	40, // 16: com.evrblk.grackle.corepb.ListLocksByLeaseIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	30, // 17: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse.locks:type_name -> com.evrblk.grackle.corepb.Lock
-	40, // 18: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	40, // 19: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	39, // 20: com.evrblk.grackle.corepb.LocksDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	41, // 21: com.evrblk.grackle.corepb.CreateLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	37, // 22: com.evrblk.grackle.corepb.CreateLockLeaseRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateLockLeaseRequest.MetadataEntry
-	42, // 23: com.evrblk.grackle.corepb.CreateLockLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
-	41, // 24: com.evrblk.grackle.corepb.RevokeLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	41, // 25: com.evrblk.grackle.corepb.RefreshLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	42, // 26: com.evrblk.grackle.corepb.RefreshLockLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
-	41, // 27: com.evrblk.grackle.corepb.GetLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	42, // 28: com.evrblk.grackle.corepb.GetLockLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
-	39, // 29: com.evrblk.grackle.corepb.ListLockLeasesRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	40, // 30: com.evrblk.grackle.corepb.ListLockLeasesRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	42, // 31: com.evrblk.grackle.corepb.ListLockLeasesResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
-	40, // 32: com.evrblk.grackle.corepb.ListLockLeasesResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	40, // 33: com.evrblk.grackle.corepb.ListLockLeasesResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	39, // 34: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	40, // 35: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	42, // 36: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
-	40, // 37: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	40, // 38: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	32, // 39: com.evrblk.grackle.corepb.Lock.id:type_name -> com.evrblk.grackle.corepb.LockId
-	1,  // 40: com.evrblk.grackle.corepb.Lock.state:type_name -> com.evrblk.grackle.corepb.LockState
-	31, // 41: com.evrblk.grackle.corepb.Lock.lock_holders:type_name -> com.evrblk.grackle.corepb.LockHolder
-	38, // 42: com.evrblk.grackle.corepb.LockHolder.metadata:type_name -> com.evrblk.grackle.corepb.LockHolder.MetadataEntry
-	39, // 43: com.evrblk.grackle.corepb.LocksGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	32, // 44: com.evrblk.grackle.corepb.LockAncestor.id:type_name -> com.evrblk.grackle.corepb.LockId
-	45, // [45:45] is the sub-list for method output_type
-	45, // [45:45] is the sub-list for method input_type
-	45, // [45:45] is the sub-list for extension type_name
-	45, // [45:45] is the sub-list for extension extendee
-	0,  // [0:45] is the sub-list for field type_name
+	34, // 4: com.evrblk.grackle.corepb.AcquireLockResponse.blocking_locks:type_name -> com.evrblk.grackle.corepb.Lock
+	37, // 5: com.evrblk.grackle.corepb.ReleaseLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	34, // 6: com.evrblk.grackle.corepb.ReleaseLockResponse.lock:type_name -> com.evrblk.grackle.corepb.Lock
+	37, // 7: com.evrblk.grackle.corepb.TransferLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	34, // 8: com.evrblk.grackle.corepb.TransferLockResponse.lock:type_name -> com.evrblk.grackle.corepb.Lock
+	37, // 9: com.evrblk.grackle.corepb.GetLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	34, // 10: com.evrblk.grackle.corepb.GetLockResponse.lock:type_name -> com.evrblk.grackle.corepb.Lock
+	37, // 11: com.evrblk.grackle.corepb.ListLockWaitersRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	45, // 12: com.evrblk.grackle.corepb.ListLockWaitersRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	36, // 13: com.evrblk.grackle.corepb.ListLockWaitersResponse.waiters:type_name -> com.evrblk.grackle.corepb.LockWaiter
+	45, // 14: com.evrblk.grackle.corepb.ListLockWaitersResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	45, // 15: com.evrblk.grackle.corepb.ListLockWaitersResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	37, // 16: com.evrblk.grackle.corepb.DeleteLockRequest.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	46, // 17: com.evrblk.grackle.corepb.ListLocksRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	45, // 18: com.evrblk.grackle.corepb.ListLocksRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	34, // 19: com.evrblk.grackle.corepb.ListLocksResponse.locks:type_name -> com.evrblk.grackle.corepb.Lock
+	45, // 20: com.evrblk.grackle.corepb.ListLocksResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	45, // 21: com.evrblk.grackle.corepb.ListLocksResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	47, // 22: com.evrblk.grackle.corepb.ListLocksByLeaseIdRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	45, // 23: com.evrblk.grackle.corepb.ListLocksByLeaseIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	34, // 24: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse.locks:type_name -> com.evrblk.grackle.corepb.Lock
+	45, // 25: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	45, // 26: com.evrblk.grackle.corepb.ListLocksByLeaseIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	46, // 27: com.evrblk.grackle.corepb.LocksDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	47, // 28: com.evrblk.grackle.corepb.CreateLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	43, // 29: com.evrblk.grackle.corepb.CreateLockLeaseRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateLockLeaseRequest.MetadataEntry
+	48, // 30: com.evrblk.grackle.corepb.CreateLockLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
+	47, // 31: com.evrblk.grackle.corepb.RevokeLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	47, // 32: com.evrblk.grackle.corepb.RefreshLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	48, // 33: com.evrblk.grackle.corepb.RefreshLockLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
+	47, // 34: com.evrblk.grackle.corepb.GetLockLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	48, // 35: com.evrblk.grackle.corepb.GetLockLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
+	46, // 36: com.evrblk.grackle.corepb.ListLockLeasesRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	45, // 37: com.evrblk.grackle.corepb.ListLockLeasesRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	48, // 38: com.evrblk.grackle.corepb.ListLockLeasesResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
+	45, // 39: com.evrblk.grackle.corepb.ListLockLeasesResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	45, // 40: com.evrblk.grackle.corepb.ListLockLeasesResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	46, // 41: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	45, // 42: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	48, // 43: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
+	45, // 44: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	45, // 45: com.evrblk.grackle.corepb.ListLockLeasesByProcessIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	37, // 46: com.evrblk.grackle.corepb.Lock.id:type_name -> com.evrblk.grackle.corepb.LockId
+	1,  // 47: com.evrblk.grackle.corepb.Lock.state:type_name -> com.evrblk.grackle.corepb.LockState
+	35, // 48: com.evrblk.grackle.corepb.Lock.lock_holders:type_name -> com.evrblk.grackle.corepb.LockHolder
+	44, // 49: com.evrblk.grackle.corepb.LockHolder.metadata:type_name -> com.evrblk.grackle.corepb.LockHolder.MetadataEntry
+	37, // 50: com.evrblk.grackle.corepb.LockWaiter.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	37, // 51: com.evrblk.grackle.corepb.LocksHoldExpirationRecord.lock_id:type_name -> com.evrblk.grackle.corepb.LockId
+	46, // 52: com.evrblk.grackle.corepb.LocksGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	37, // 53: com.evrblk.grackle.corepb.LockAncestor.id:type_name -> com.evrblk.grackle.corepb.LockId
+	54, // [54:54] is the sub-list for method output_type
+	54, // [54:54] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_pkg_corepb_locks_proto_init() }
@@ -2266,7 +2732,7 @@ func file_pkg_corepb_locks_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_corepb_locks_proto_rawDesc), len(file_pkg_corepb_locks_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   37,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -56,6 +56,110 @@ func (m *AcquireSemaphoreResponse) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// AddJobsToWaitGroupRequest
+
+var _ encoding.BinaryMarshaler = (*AddJobsToWaitGroupRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*AddJobsToWaitGroupRequest)(nil)
+
+func (m *AddJobsToWaitGroupRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AddJobsToWaitGroupRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// AddJobsToWaitGroupResponse
+
+var _ encoding.BinaryMarshaler = (*AddJobsToWaitGroupResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*AddJobsToWaitGroupResponse)(nil)
+
+func (m *AddJobsToWaitGroupResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AddJobsToWaitGroupResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// AddToWaitGroupRequest
+
+var _ encoding.BinaryMarshaler = (*AddToWaitGroupRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*AddToWaitGroupRequest)(nil)
+
+func (m *AddToWaitGroupRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AddToWaitGroupRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// AddToWaitGroupResponse
+
+var _ encoding.BinaryMarshaler = (*AddToWaitGroupResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*AddToWaitGroupResponse)(nil)
+
+func (m *AddToWaitGroupResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AddToWaitGroupResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// AdjustSemaphoreHoldRequest
+
+var _ encoding.BinaryMarshaler = (*AdjustSemaphoreHoldRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*AdjustSemaphoreHoldRequest)(nil)
+
+func (m *AdjustSemaphoreHoldRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AdjustSemaphoreHoldRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// AdjustSemaphoreHoldResponse
+
+var _ encoding.BinaryMarshaler = (*AdjustSemaphoreHoldResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*AdjustSemaphoreHoldResponse)(nil)
+
+func (m *AdjustSemaphoreHoldResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AdjustSemaphoreHoldResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// AggregateWaitGroupStripesRequest
+
+var _ encoding.BinaryMarshaler = (*AggregateWaitGroupStripesRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*AggregateWaitGroupStripesRequest)(nil)
+
+func (m *AggregateWaitGroupStripesRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AggregateWaitGroupStripesRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// AggregateWaitGroupStripesResponse
+
+var _ encoding.BinaryMarshaler = (*AggregateWaitGroupStripesResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*AggregateWaitGroupStripesResponse)(nil)
+
+func (m *AggregateWaitGroupStripesResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *AggregateWaitGroupStripesResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // ArriveAtBarrierRequest
 
 var _ encoding.BinaryMarshaler = (*ArriveAtBarrierRequest)(nil)
@@ -95,6 +199,19 @@ func (m *Barrier) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// BarrierGeneration
+
+var _ encoding.BinaryMarshaler = (*BarrierGeneration)(nil)
+var _ encoding.BinaryUnmarshaler = (*BarrierGeneration)(nil)
+
+func (m *BarrierGeneration) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *BarrierGeneration) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // BarrierId
 
 var _ encoding.BinaryMarshaler = (*BarrierId)(nil)
@@ -186,6 +303,45 @@ func (m *BarriersGarbageCollectionRecord) MarshalBinary() (data []byte, err erro
 	return m.MarshalVT()
 }
 
+// BarriersGenerationTrimRecord
+
+var _ encoding.BinaryMarshaler = (*BarriersGenerationTrimRecord)(nil)
+var _ encoding.BinaryUnmarshaler = (*BarriersGenerationTrimRecord)(nil)
+
+func (m *BarriersGenerationTrimRecord) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *BarriersGenerationTrimRecord) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// CancelWaitGroupRequest
+
+var _ encoding.BinaryMarshaler = (*CancelWaitGroupRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*CancelWaitGroupRequest)(nil)
+
+func (m *CancelWaitGroupRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *CancelWaitGroupRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// CancelWaitGroupResponse
+
+var _ encoding.BinaryMarshaler = (*CancelWaitGroupResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*CancelWaitGroupResponse)(nil)
+
+func (m *CancelWaitGroupResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *CancelWaitGroupResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // CompleteJobRequest
 
 var _ encoding.BinaryMarshaler = (*CompleteJobRequest)(nil)
@@ -225,6 +381,32 @@ func (m *CompleteJobsFromWaitGroupResponse) MarshalBinary() (data []byte, err er
 	return m.MarshalVT()
 }
 
+// CompleteJobsFromWaitGroupStripeRequest
+
+var _ encoding.BinaryMarshaler = (*CompleteJobsFromWaitGroupStripeRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*CompleteJobsFromWaitGroupStripeRequest)(nil)
+
+func (m *CompleteJobsFromWaitGroupStripeRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *CompleteJobsFromWaitGroupStripeRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// CompleteJobsFromWaitGroupStripeResponse
+
+var _ encoding.BinaryMarshaler = (*CompleteJobsFromWaitGroupStripeResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*CompleteJobsFromWaitGroupStripeResponse)(nil)
+
+func (m *CompleteJobsFromWaitGroupStripeResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *CompleteJobsFromWaitGroupStripeResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // CreateBarrierRequest
 
 var _ encoding.BinaryMarshaler = (*CreateBarrierRequest)(nil)
@@ -511,6 +693,58 @@ func (m *DeleteWaitGroupResponse) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// DeleteWaitGroupStripeRequest
+
+var _ encoding.BinaryMarshaler = (*DeleteWaitGroupStripeRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*DeleteWaitGroupStripeRequest)(nil)
+
+func (m *DeleteWaitGroupStripeRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *DeleteWaitGroupStripeRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// DeleteWaitGroupStripeResponse
+
+var _ encoding.BinaryMarshaler = (*DeleteWaitGroupStripeResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*DeleteWaitGroupStripeResponse)(nil)
+
+func (m *DeleteWaitGroupStripeResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *DeleteWaitGroupStripeResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ExtendWaitGroupStripeRequest
+
+var _ encoding.BinaryMarshaler = (*ExtendWaitGroupStripeRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*ExtendWaitGroupStripeRequest)(nil)
+
+func (m *ExtendWaitGroupStripeRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ExtendWaitGroupStripeRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ExtendWaitGroupStripeResponse
+
+var _ encoding.BinaryMarshaler = (*ExtendWaitGroupStripeResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*ExtendWaitGroupStripeResponse)(nil)
+
+func (m *ExtendWaitGroupStripeResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ExtendWaitGroupStripeResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // GetBarrierByNameRequest
 
 var _ encoding.BinaryMarshaler = (*GetBarrierByNameRequest)(nil)
@@ -745,6 +979,32 @@ func (m *GetSemaphoreResponse) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// GetSemaphoreStatsRequest
+
+var _ encoding.BinaryMarshaler = (*GetSemaphoreStatsRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*GetSemaphoreStatsRequest)(nil)
+
+func (m *GetSemaphoreStatsRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *GetSemaphoreStatsRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// GetSemaphoreStatsResponse
+
+var _ encoding.BinaryMarshaler = (*GetSemaphoreStatsResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*GetSemaphoreStatsResponse)(nil)
+
+func (m *GetSemaphoreStatsResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *GetSemaphoreStatsResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // GetWaitGroupByNameRequest
 
 var _ encoding.BinaryMarshaler = (*GetWaitGroupByNameRequest)(nil)
@@ -797,6 +1057,32 @@ func (m *GetWaitGroupResponse) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// GetWaitGroupStripeRequest
+
+var _ encoding.BinaryMarshaler = (*GetWaitGroupStripeRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*GetWaitGroupStripeRequest)(nil)
+
+func (m *GetWaitGroupStripeRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *GetWaitGroupStripeRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// GetWaitGroupStripeResponse
+
+var _ encoding.BinaryMarshaler = (*GetWaitGroupStripeResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*GetWaitGroupStripeResponse)(nil)
+
+func (m *GetWaitGroupStripeResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *GetWaitGroupStripeResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // Lease
 
 var _ encoding.BinaryMarshaler = (*Lease)(nil)
@@ -823,6 +1109,32 @@ func (m *LeaseId) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// ListBarrierGenerationsRequest
+
+var _ encoding.BinaryMarshaler = (*ListBarrierGenerationsRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListBarrierGenerationsRequest)(nil)
+
+func (m *ListBarrierGenerationsRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListBarrierGenerationsRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ListBarrierGenerationsResponse
+
+var _ encoding.BinaryMarshaler = (*ListBarrierGenerationsResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListBarrierGenerationsResponse)(nil)
+
+func (m *ListBarrierGenerationsResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListBarrierGenerationsResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // ListBarrierParticipantsRequest
 
 var _ encoding.BinaryMarshaler = (*ListBarrierParticipantsRequest)(nil)
@@ -927,6 +1239,32 @@ func (m *ListLockLeasesResponse) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// ListLockWaitersRequest
+
+var _ encoding.BinaryMarshaler = (*ListLockWaitersRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListLockWaitersRequest)(nil)
+
+func (m *ListLockWaitersRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListLockWaitersRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ListLockWaitersResponse
+
+var _ encoding.BinaryMarshaler = (*ListLockWaitersResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListLockWaitersResponse)(nil)
+
+func (m *ListLockWaitersResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListLockWaitersResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // ListLocksByLeaseIdRequest
 
 var _ encoding.BinaryMarshaler = (*ListLocksByLeaseIdRequest)(nil)
@@ -1083,6 +1421,32 @@ func (m *ListSemaphoreLeasesResponse) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// ListSemaphoreWaitersRequest
+
+var _ encoding.BinaryMarshaler = (*ListSemaphoreWaitersRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListSemaphoreWaitersRequest)(nil)
+
+func (m *ListSemaphoreWaitersRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListSemaphoreWaitersRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ListSemaphoreWaitersResponse
+
+var _ encoding.BinaryMarshaler = (*ListSemaphoreWaitersResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListSemaphoreWaitersResponse)(nil)
+
+func (m *ListSemaphoreWaitersResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListSemaphoreWaitersResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // ListSemaphoresByLeaseIdRequest
 
 var _ encoding.BinaryMarshaler = (*ListSemaphoresByLeaseIdRequest)(nil)
@@ -1161,6 +1525,58 @@ func (m *ListWaitGroupCompletedJobsResponse) MarshalBinary() (data []byte, err e
 	return m.MarshalVT()
 }
 
+// ListWaitGroupPendingJobsRequest
+
+var _ encoding.BinaryMarshaler = (*ListWaitGroupPendingJobsRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListWaitGroupPendingJobsRequest)(nil)
+
+func (m *ListWaitGroupPendingJobsRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListWaitGroupPendingJobsRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ListWaitGroupPendingJobsResponse
+
+var _ encoding.BinaryMarshaler = (*ListWaitGroupPendingJobsResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListWaitGroupPendingJobsResponse)(nil)
+
+func (m *ListWaitGroupPendingJobsResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListWaitGroupPendingJobsResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ListWaitGroupStripeCompletedJobsRequest
+
+var _ encoding.BinaryMarshaler = (*ListWaitGroupStripeCompletedJobsRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListWaitGroupStripeCompletedJobsRequest)(nil)
+
+func (m *ListWaitGroupStripeCompletedJobsRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListWaitGroupStripeCompletedJobsRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// ListWaitGroupStripeCompletedJobsResponse
+
+var _ encoding.BinaryMarshaler = (*ListWaitGroupStripeCompletedJobsResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*ListWaitGroupStripeCompletedJobsResponse)(nil)
+
+func (m *ListWaitGroupStripeCompletedJobsResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *ListWaitGroupStripeCompletedJobsResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // ListWaitGroupsRequest
 
 var _ encoding.BinaryMarshaler = (*ListWaitGroupsRequest)(nil)
@@ -1239,6 +1655,19 @@ func (m *LockId) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// LockWaiter
+
+var _ encoding.BinaryMarshaler = (*LockWaiter)(nil)
+var _ encoding.BinaryUnmarshaler = (*LockWaiter)(nil)
+
+func (m *LockWaiter) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *LockWaiter) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // LocksCounter
 
 var _ encoding.BinaryMarshaler = (*LocksCounter)(nil)
@@ -1291,6 +1720,19 @@ func (m *LocksGarbageCollectionRecord) MarshalBinary() (data []byte, err error)
 	return m.MarshalVT()
 }
 
+// LocksHoldExpirationRecord
+
+var _ encoding.BinaryMarshaler = (*LocksHoldExpirationRecord)(nil)
+var _ encoding.BinaryUnmarshaler = (*LocksHoldExpirationRecord)(nil)
+
+func (m *LocksHoldExpirationRecord) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *LocksHoldExpirationRecord) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // Namespace
 
 var _ encoding.BinaryMarshaler = (*Namespace)(nil)
@@ -1616,6 +2058,32 @@ func (m *Semaphore) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// SemaphoreClass
+
+var _ encoding.BinaryMarshaler = (*SemaphoreClass)(nil)
+var _ encoding.BinaryUnmarshaler = (*SemaphoreClass)(nil)
+
+func (m *SemaphoreClass) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *SemaphoreClass) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// SemaphoreClassSpec
+
+var _ encoding.BinaryMarshaler = (*SemaphoreClassSpec)(nil)
+var _ encoding.BinaryUnmarshaler = (*SemaphoreClassSpec)(nil)
+
+func (m *SemaphoreClassSpec) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *SemaphoreClassSpec) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // SemaphoreHolder
 
 var _ encoding.BinaryMarshaler = (*SemaphoreHolder)(nil)
@@ -1655,6 +2123,45 @@ func (m *SemaphoreId) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// SemaphoreStatsBucket
+
+var _ encoding.BinaryMarshaler = (*SemaphoreStatsBucket)(nil)
+var _ encoding.BinaryUnmarshaler = (*SemaphoreStatsBucket)(nil)
+
+func (m *SemaphoreStatsBucket) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *SemaphoreStatsBucket) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// SemaphoreStatsRecord
+
+var _ encoding.BinaryMarshaler = (*SemaphoreStatsRecord)(nil)
+var _ encoding.BinaryUnmarshaler = (*SemaphoreStatsRecord)(nil)
+
+func (m *SemaphoreStatsRecord) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *SemaphoreStatsRecord) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// SemaphoreWaiter
+
+var _ encoding.BinaryMarshaler = (*SemaphoreWaiter)(nil)
+var _ encoding.BinaryUnmarshaler = (*SemaphoreWaiter)(nil)
+
+func (m *SemaphoreWaiter) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *SemaphoreWaiter) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // SemaphoresCounter
 
 var _ encoding.BinaryMarshaler = (*SemaphoresCounter)(nil)
@@ -1694,6 +2201,19 @@ func (m *SemaphoresDeleteNamespaceResponse) MarshalBinary() (data []byte, err er
 	return m.MarshalVT()
 }
 
+// SemaphoresDeletionRecord
+
+var _ encoding.BinaryMarshaler = (*SemaphoresDeletionRecord)(nil)
+var _ encoding.BinaryUnmarshaler = (*SemaphoresDeletionRecord)(nil)
+
+func (m *SemaphoresDeletionRecord) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *SemaphoresDeletionRecord) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // SemaphoresExpirationRecord
 
 var _ encoding.BinaryMarshaler = (*SemaphoresExpirationRecord)(nil)
@@ -1720,6 +2240,84 @@ func (m *SemaphoresGarbageCollectionRecord) MarshalBinary() (data []byte, err er
 	return m.MarshalVT()
 }
 
+// TransferLockRequest
+
+var _ encoding.BinaryMarshaler = (*TransferLockRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*TransferLockRequest)(nil)
+
+func (m *TransferLockRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *TransferLockRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// TransferLockResponse
+
+var _ encoding.BinaryMarshaler = (*TransferLockResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*TransferLockResponse)(nil)
+
+func (m *TransferLockResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *TransferLockResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// TransferSemaphoreHoldRequest
+
+var _ encoding.BinaryMarshaler = (*TransferSemaphoreHoldRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*TransferSemaphoreHoldRequest)(nil)
+
+func (m *TransferSemaphoreHoldRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *TransferSemaphoreHoldRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// TransferSemaphoreHoldResponse
+
+var _ encoding.BinaryMarshaler = (*TransferSemaphoreHoldResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*TransferSemaphoreHoldResponse)(nil)
+
+func (m *TransferSemaphoreHoldResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *TransferSemaphoreHoldResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// UncompleteJobsFromWaitGroupRequest
+
+var _ encoding.BinaryMarshaler = (*UncompleteJobsFromWaitGroupRequest)(nil)
+var _ encoding.BinaryUnmarshaler = (*UncompleteJobsFromWaitGroupRequest)(nil)
+
+func (m *UncompleteJobsFromWaitGroupRequest) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *UncompleteJobsFromWaitGroupRequest) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// UncompleteJobsFromWaitGroupResponse
+
+var _ encoding.BinaryMarshaler = (*UncompleteJobsFromWaitGroupResponse)(nil)
+var _ encoding.BinaryUnmarshaler = (*UncompleteJobsFromWaitGroupResponse)(nil)
+
+func (m *UncompleteJobsFromWaitGroupResponse) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *UncompleteJobsFromWaitGroupResponse) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // UpdateBarrierRequest
 
 var _ encoding.BinaryMarshaler = (*UpdateBarrierRequest)(nil)
@@ -1837,6 +2435,32 @@ func (m *WaitGroup) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// WaitGroupAggregate
+
+var _ encoding.BinaryMarshaler = (*WaitGroupAggregate)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupAggregate)(nil)
+
+func (m *WaitGroupAggregate) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupAggregate) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// WaitGroupAggregationSpec
+
+var _ encoding.BinaryMarshaler = (*WaitGroupAggregationSpec)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupAggregationSpec)(nil)
+
+func (m *WaitGroupAggregationSpec) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupAggregationSpec) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // WaitGroupId
 
 var _ encoding.BinaryMarshaler = (*WaitGroupId)(nil)
@@ -1863,6 +2487,45 @@ func (m *WaitGroupJob) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// WaitGroupJobAttempt
+
+var _ encoding.BinaryMarshaler = (*WaitGroupJobAttempt)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupJobAttempt)(nil)
+
+func (m *WaitGroupJobAttempt) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupJobAttempt) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// WaitGroupJobChunk
+
+var _ encoding.BinaryMarshaler = (*WaitGroupJobChunk)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupJobChunk)(nil)
+
+func (m *WaitGroupJobChunk) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupJobChunk) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// WaitGroupJobChunkId
+
+var _ encoding.BinaryMarshaler = (*WaitGroupJobChunkId)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupJobChunkId)(nil)
+
+func (m *WaitGroupJobChunkId) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupJobChunkId) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // WaitGroupJobId
 
 var _ encoding.BinaryMarshaler = (*WaitGroupJobId)(nil)
@@ -1876,6 +2539,58 @@ func (m *WaitGroupJobId) MarshalBinary() (data []byte, err error) {
 	return m.MarshalVT()
 }
 
+// WaitGroupStripe
+
+var _ encoding.BinaryMarshaler = (*WaitGroupStripe)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupStripe)(nil)
+
+func (m *WaitGroupStripe) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupStripe) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// WaitGroupStripeCounts
+
+var _ encoding.BinaryMarshaler = (*WaitGroupStripeCounts)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupStripeCounts)(nil)
+
+func (m *WaitGroupStripeCounts) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupStripeCounts) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// WaitGroupStripeId
+
+var _ encoding.BinaryMarshaler = (*WaitGroupStripeId)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupStripeId)(nil)
+
+func (m *WaitGroupStripeId) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupStripeId) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
+// WaitGroupStripesDeletionRecord
+
+var _ encoding.BinaryMarshaler = (*WaitGroupStripesDeletionRecord)(nil)
+var _ encoding.BinaryUnmarshaler = (*WaitGroupStripesDeletionRecord)(nil)
+
+func (m *WaitGroupStripesDeletionRecord) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalVT(data)
+}
+
+func (m *WaitGroupStripesDeletionRecord) MarshalBinary() (data []byte, err error) {
+	return m.MarshalVT()
+}
+
 // WaitGroupsCounter
 
 var _ encoding.BinaryMarshaler = (*WaitGroupsCounter)(nil)
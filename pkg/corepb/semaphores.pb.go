@@ -32,8 +32,18 @@ type CreateSemaphoreRequest struct {
 	// Per-namespace quota enforced by the core; the create is rejected if it would
 	// be exceeded.
 	MaxNumberOfSemaphoresPerNamespace int64 `protobuf:"varint,6,opt,name=max_number_of_semaphores_per_namespace,json=maxNumberOfSemaphoresPerNamespace,proto3" json:"max_number_of_semaphores_per_namespace,omitempty"`
-	unknownFields                     protoimpl.UnknownFields
-	sizeCache                         protoimpl.SizeCache
+	// Optional name of the parent semaphore in the same namespace. Every hold on
+	// the new semaphore also consumes the same weight on the parent and its
+	// ancestors.
+	ParentSemaphoreName string `protobuf:"bytes,7,opt,name=parent_semaphore_name,json=parentSemaphoreName,proto3" json:"parent_semaphore_name,omitempty"`
+	// Optional named classes sharing the permits, each with a reserved minimum and
+	// a maximum. Set at creation and never changed.
+	Classes []*SemaphoreClassSpec `protobuf:"bytes,8,rep,name=classes,proto3" json:"classes,omitempty"`
+	// Inactivity window in seconds after which an idle semaphore is deleted; 0
+	// keeps it until it is deleted explicitly.
+	DeleteInactiveAfterSeconds int64 `protobuf:"varint,9,opt,name=delete_inactive_after_seconds,json=deleteInactiveAfterSeconds,proto3" json:"delete_inactive_after_seconds,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *CreateSemaphoreRequest) Reset() {
@@ -108,6 +118,27 @@ func (x *CreateSemaphoreRequest) GetMaxNumberOfSemaphoresPerNamespace() int64 {
 	return 0
 }
 
+func (x *CreateSemaphoreRequest) GetParentSemaphoreName() string {
+	if x != nil {
+		return x.ParentSemaphoreName
+	}
+	return ""
+}
+
+func (x *CreateSemaphoreRequest) GetClasses() []*SemaphoreClassSpec {
+	if x != nil {
+		return x.Classes
+	}
+	return nil
+}
+
+func (x *CreateSemaphoreRequest) GetDeleteInactiveAfterSeconds() int64 {
+	if x != nil {
+		return x.DeleteInactiveAfterSeconds
+	}
+	return 0
+}
+
 type CreateSemaphoreResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Semaphore     *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
@@ -437,8 +468,10 @@ func (x *GetSemaphoreRequest) GetSemaphoreId() *SemaphoreId {
 }
 
 type GetSemaphoreResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Semaphore     *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Semaphore *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	// Number of leases currently waiting to acquire the semaphore.
+	WaitersCount  int64 `protobuf:"varint,2,opt,name=waiters_count,json=waitersCount,proto3" json:"waiters_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -480,6 +513,13 @@ func (x *GetSemaphoreResponse) GetSemaphore() *Semaphore {
 	return nil
 }
 
+func (x *GetSemaphoreResponse) GetWaitersCount() int64 {
+	if x != nil {
+		return x.WaitersCount
+	}
+	return 0
+}
+
 type GetSemaphoreByNameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -533,8 +573,10 @@ func (x *GetSemaphoreByNameRequest) GetSemaphoreName() string {
 }
 
 type GetSemaphoreByNameResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Semaphore     *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Semaphore *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	// Number of leases currently waiting to acquire the semaphore.
+	WaitersCount  int64 `protobuf:"varint,2,opt,name=waiters_count,json=waitersCount,proto3" json:"waiters_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -576,6 +618,13 @@ func (x *GetSemaphoreByNameResponse) GetSemaphore() *Semaphore {
 	return nil
 }
 
+func (x *GetSemaphoreByNameResponse) GetWaitersCount() int64 {
+	if x != nil {
+		return x.WaitersCount
+	}
+	return 0
+}
+
 type AcquireSemaphoreRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -584,8 +633,35 @@ type AcquireSemaphoreRequest struct {
 	// Number of permits to acquire. All-or-nothing: the acquire succeeds only when
 	// weight permits are free. Re-acquiring under the same lease adjusts that
 	// lease's existing hold rather than adding a second one.
-	Weight        int64             `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Weight   int64             `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Class the weight is acquired in. Empty means the shared permits that are not
+	// reserved for any class.
+	Class string `protobuf:"bytes,6,opt,name=class,proto3" json:"class,omitempty"`
+	// Optional limit on how long the weight is held, in seconds: it is released
+	// once reached even while the lease is refreshed. 0 means as long as the lease
+	// lives. Re-acquiring restarts it. On a child semaphore the weight inherited
+	// by its ancestors is released along with it.
+	MaxHoldSeconds int64 `protobuf:"varint,7,opt,name=max_hold_seconds,json=maxHoldSeconds,proto3" json:"max_hold_seconds,omitempty"`
+	// Until when the caller keeps retrying, Unix nanoseconds. While it is in the
+	// future and the permits are not available, the lease is recorded as a waiter
+	// on the semaphore (see SemaphoreWaiter). 0 for a non-blocking acquire.
+	WaitUntil int64 `protobuf:"varint,8,opt,name=wait_until,json=waitUntil,proto3" json:"wait_until,omitempty"`
+	// When greater than 0, a missing semaphore is created with this many permits
+	// (and default_delete_inactive_after_seconds) instead of failing with
+	// NotFound. Ignored when the semaphore exists.
+	DefaultPermits                    int64 `protobuf:"varint,9,opt,name=default_permits,json=defaultPermits,proto3" json:"default_permits,omitempty"`
+	DefaultDeleteInactiveAfterSeconds int64 `protobuf:"varint,10,opt,name=default_delete_inactive_after_seconds,json=defaultDeleteInactiveAfterSeconds,proto3" json:"default_delete_inactive_after_seconds,omitempty"`
+	// Id for the semaphore created through default_permits, generated by the
+	// caller like CreateSemaphoreRequest.semaphore_id.
+	NewSemaphoreId uint64 `protobuf:"fixed64,11,opt,name=new_semaphore_id,json=newSemaphoreId,proto3" json:"new_semaphore_id,omitempty"`
+	// Per-namespace quota enforced when the semaphore is created through
+	// default_permits.
+	MaxNumberOfSemaphoresPerNamespace int64 `protobuf:"varint,12,opt,name=max_number_of_semaphores_per_namespace,json=maxNumberOfSemaphoresPerNamespace,proto3" json:"max_number_of_semaphores_per_namespace,omitempty"`
+	// Set when the caller retries the acquire if this attempt fails. A failed
+	// attempt is then not counted in the semaphore stats, so an acquire that
+	// polls until its deadline counts one failure, on its last attempt.
+	WillRetry     bool `protobuf:"varint,13,opt,name=will_retry,json=willRetry,proto3" json:"will_retry,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -655,12 +731,82 @@ func (x *AcquireSemaphoreRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *AcquireSemaphoreRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *AcquireSemaphoreRequest) GetMaxHoldSeconds() int64 {
+	if x != nil {
+		return x.MaxHoldSeconds
+	}
+	return 0
+}
+
+func (x *AcquireSemaphoreRequest) GetWaitUntil() int64 {
+	if x != nil {
+		return x.WaitUntil
+	}
+	return 0
+}
+
+func (x *AcquireSemaphoreRequest) GetDefaultPermits() int64 {
+	if x != nil {
+		return x.DefaultPermits
+	}
+	return 0
+}
+
+func (x *AcquireSemaphoreRequest) GetDefaultDeleteInactiveAfterSeconds() int64 {
+	if x != nil {
+		return x.DefaultDeleteInactiveAfterSeconds
+	}
+	return 0
+}
+
+func (x *AcquireSemaphoreRequest) GetNewSemaphoreId() uint64 {
+	if x != nil {
+		return x.NewSemaphoreId
+	}
+	return 0
+}
+
+func (x *AcquireSemaphoreRequest) GetMaxNumberOfSemaphoresPerNamespace() int64 {
+	if x != nil {
+		return x.MaxNumberOfSemaphoresPerNamespace
+	}
+	return 0
+}
+
+func (x *AcquireSemaphoreRequest) GetWillRetry() bool {
+	if x != nil {
+		return x.WillRetry
+	}
+	return false
+}
+
 type AcquireSemaphoreResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Semaphore     *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Semaphore *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	Success   bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// How many more permits the acquire needed than were available on this
+	// attempt (weight - available). With ancestors it is the largest shortfall
+	// across the semaphore and its ancestors. 0 when success is true. Best effort
+	// and point-in-time, like the rest of the diagnostics below.
+	MissingPermits int64 `protobuf:"varint,3,opt,name=missing_permits,json=missingPermits,proto3" json:"missing_permits,omitempty"`
+	// The holders whose release would satisfy the acquire soonest: holders of the
+	// semaphore with the shortfall, in order of expiration, up to the first one
+	// whose weight covers missing_permits. Capped at 50.
+	BlockingHolders []*SemaphoreHolder `protobuf:"bytes,4,rep,name=blocking_holders,json=blockingHolders,proto3" json:"blocking_holders,omitempty"`
+	// Earliest time the acquire could be granted if blocking_holders lapse
+	// without being refreshed, Unix nanoseconds; never before the semaphore's
+	// earliest_holder_expires_at. 0 when success is true or when the listed
+	// holders do not cover missing_permits.
+	EarliestGrantAt int64 `protobuf:"fixed64,5,opt,name=earliest_grant_at,json=earliestGrantAt,proto3" json:"earliest_grant_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *AcquireSemaphoreResponse) Reset() {
@@ -707,6 +853,27 @@ func (x *AcquireSemaphoreResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *AcquireSemaphoreResponse) GetMissingPermits() int64 {
+	if x != nil {
+		return x.MissingPermits
+	}
+	return 0
+}
+
+func (x *AcquireSemaphoreResponse) GetBlockingHolders() []*SemaphoreHolder {
+	if x != nil {
+		return x.BlockingHolders
+	}
+	return nil
+}
+
+func (x *AcquireSemaphoreResponse) GetEarliestGrantAt() int64 {
+	if x != nil {
+		return x.EarliestGrantAt
+	}
+	return 0
+}
+
 type ReleaseSemaphoreRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -811,36 +978,33 @@ func (x *ReleaseSemaphoreResponse) GetSemaphore() *Semaphore {
 	return nil
 }
 
-type UpdateSemaphoreRequest struct {
+type AdjustSemaphoreHoldRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
 	SemaphoreName string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	// New total capacity. Cannot be lowered below the permits currently held
-	// (active_holds).
-	Permits  int64             `protobuf:"varint,4,opt,name=permits,proto3" json:"permits,omitempty"`
-	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// Optimistic concurrency check: must equal the semaphore's current version or
-	// the update is rejected.
-	ExpectedVersion int64 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	LeaseId       uint64                 `protobuf:"fixed64,3,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	// New weight of the lease's existing hold. A smaller weight always succeeds
+	// and frees the difference; a larger one succeeds only when the extra permits
+	// are free.
+	Weight        int64 `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSemaphoreRequest) Reset() {
-	*x = UpdateSemaphoreRequest{}
+func (x *AdjustSemaphoreHoldRequest) Reset() {
+	*x = AdjustSemaphoreHoldRequest{}
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSemaphoreRequest) String() string {
+func (x *AdjustSemaphoreHoldRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSemaphoreRequest) ProtoMessage() {}
+func (*AdjustSemaphoreHoldRequest) ProtoMessage() {}
 
-func (x *UpdateSemaphoreRequest) ProtoReflect() protoreflect.Message {
+func (x *AdjustSemaphoreHoldRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -852,74 +1016,63 @@ func (x *UpdateSemaphoreRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSemaphoreRequest.ProtoReflect.Descriptor instead.
-func (*UpdateSemaphoreRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AdjustSemaphoreHoldRequest.ProtoReflect.Descriptor instead.
+func (*AdjustSemaphoreHoldRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *UpdateSemaphoreRequest) GetNamespaceId() *NamespaceId {
+func (x *AdjustSemaphoreHoldRequest) GetNamespaceId() *NamespaceId {
 	if x != nil {
 		return x.NamespaceId
 	}
 	return nil
 }
 
-func (x *UpdateSemaphoreRequest) GetSemaphoreName() string {
+func (x *AdjustSemaphoreHoldRequest) GetSemaphoreName() string {
 	if x != nil {
 		return x.SemaphoreName
 	}
 	return ""
 }
 
-func (x *UpdateSemaphoreRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *UpdateSemaphoreRequest) GetPermits() int64 {
+func (x *AdjustSemaphoreHoldRequest) GetLeaseId() uint64 {
 	if x != nil {
-		return x.Permits
+		return x.LeaseId
 	}
 	return 0
 }
 
-func (x *UpdateSemaphoreRequest) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-func (x *UpdateSemaphoreRequest) GetExpectedVersion() int64 {
+func (x *AdjustSemaphoreHoldRequest) GetWeight() int64 {
 	if x != nil {
-		return x.ExpectedVersion
+		return x.Weight
 	}
 	return 0
 }
 
-type UpdateSemaphoreResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Semaphore     *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+type AdjustSemaphoreHoldResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Semaphore *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	// The adjusted holder (unchanged when success is false).
+	Holder        *SemaphoreHolder `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder,omitempty"`
+	Success       bool             `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSemaphoreResponse) Reset() {
-	*x = UpdateSemaphoreResponse{}
+func (x *AdjustSemaphoreHoldResponse) Reset() {
+	*x = AdjustSemaphoreHoldResponse{}
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSemaphoreResponse) String() string {
+func (x *AdjustSemaphoreHoldResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSemaphoreResponse) ProtoMessage() {}
+func (*AdjustSemaphoreHoldResponse) ProtoMessage() {}
 
-func (x *UpdateSemaphoreResponse) ProtoReflect() protoreflect.Message {
+func (x *AdjustSemaphoreHoldResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -931,43 +1084,59 @@ func (x *UpdateSemaphoreResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSemaphoreResponse.ProtoReflect.Descriptor instead.
-func (*UpdateSemaphoreResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AdjustSemaphoreHoldResponse.ProtoReflect.Descriptor instead.
+func (*AdjustSemaphoreHoldResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *UpdateSemaphoreResponse) GetSemaphore() *Semaphore {
+func (x *AdjustSemaphoreHoldResponse) GetSemaphore() *Semaphore {
 	if x != nil {
 		return x.Semaphore
 	}
 	return nil
 }
 
-type DeleteSemaphoreRequest struct {
+func (x *AdjustSemaphoreHoldResponse) GetHolder() *SemaphoreHolder {
+	if x != nil {
+		return x.Holder
+	}
+	return nil
+}
+
+func (x *AdjustSemaphoreHoldResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type TransferSemaphoreHoldRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
 	SemaphoreName string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
-	// Idempotency / bookkeeping id the core uses to track the asynchronous deletion
-	// of this object's data. Recurs on delete and namespace-teardown requests.
-	RecordId      uint64 `protobuf:"fixed64,3,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	// Lease that holds the semaphore directly now.
+	FromLeaseId uint64 `protobuf:"fixed64,3,opt,name=from_lease_id,json=fromLeaseId,proto3" json:"from_lease_id,omitempty"`
+	// Lease that takes the hold over. It must be alive and must not hold the
+	// semaphore directly already.
+	ToLeaseId     uint64 `protobuf:"fixed64,4,opt,name=to_lease_id,json=toLeaseId,proto3" json:"to_lease_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSemaphoreRequest) Reset() {
-	*x = DeleteSemaphoreRequest{}
+func (x *TransferSemaphoreHoldRequest) Reset() {
+	*x = TransferSemaphoreHoldRequest{}
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSemaphoreRequest) String() string {
+func (x *TransferSemaphoreHoldRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSemaphoreRequest) ProtoMessage() {}
+func (*TransferSemaphoreHoldRequest) ProtoMessage() {}
 
-func (x *DeleteSemaphoreRequest) ProtoReflect() protoreflect.Message {
+func (x *TransferSemaphoreHoldRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -979,52 +1148,62 @@ func (x *DeleteSemaphoreRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSemaphoreRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSemaphoreRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransferSemaphoreHoldRequest.ProtoReflect.Descriptor instead.
+func (*TransferSemaphoreHoldRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DeleteSemaphoreRequest) GetNamespaceId() *NamespaceId {
+func (x *TransferSemaphoreHoldRequest) GetNamespaceId() *NamespaceId {
 	if x != nil {
 		return x.NamespaceId
 	}
 	return nil
 }
 
-func (x *DeleteSemaphoreRequest) GetSemaphoreName() string {
+func (x *TransferSemaphoreHoldRequest) GetSemaphoreName() string {
 	if x != nil {
 		return x.SemaphoreName
 	}
 	return ""
 }
 
-func (x *DeleteSemaphoreRequest) GetRecordId() uint64 {
+func (x *TransferSemaphoreHoldRequest) GetFromLeaseId() uint64 {
 	if x != nil {
-		return x.RecordId
+		return x.FromLeaseId
 	}
 	return 0
 }
 
-type DeleteSemaphoreResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *TransferSemaphoreHoldRequest) GetToLeaseId() uint64 {
+	if x != nil {
+		return x.ToLeaseId
+	}
+	return 0
+}
+
+type TransferSemaphoreHoldResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Semaphore *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	// The target lease's holder after the transfer.
+	Holder        *SemaphoreHolder `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSemaphoreResponse) Reset() {
-	*x = DeleteSemaphoreResponse{}
+func (x *TransferSemaphoreHoldResponse) Reset() {
+	*x = TransferSemaphoreHoldResponse{}
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSemaphoreResponse) String() string {
+func (x *TransferSemaphoreHoldResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSemaphoreResponse) ProtoMessage() {}
+func (*TransferSemaphoreHoldResponse) ProtoMessage() {}
 
-func (x *DeleteSemaphoreResponse) ProtoReflect() protoreflect.Message {
+func (x *TransferSemaphoreHoldResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1036,35 +1215,61 @@ func (x *DeleteSemaphoreResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSemaphoreResponse.ProtoReflect.Descriptor instead.
-func (*DeleteSemaphoreResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransferSemaphoreHoldResponse.ProtoReflect.Descriptor instead.
+func (*TransferSemaphoreHoldResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{17}
 }
 
-type ListSemaphoreHoldersRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
-	SemaphoreName   string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
-	PaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
-	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *TransferSemaphoreHoldResponse) GetSemaphore() *Semaphore {
+	if x != nil {
+		return x.Semaphore
+	}
+	return nil
 }
 
-func (x *ListSemaphoreHoldersRequest) Reset() {
-	*x = ListSemaphoreHoldersRequest{}
+func (x *TransferSemaphoreHoldResponse) GetHolder() *SemaphoreHolder {
+	if x != nil {
+		return x.Holder
+	}
+	return nil
+}
+
+type UpdateSemaphoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	SemaphoreName string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// New total capacity. Cannot be lowered below the permits currently held
+	// (active_holds) unless allow_draining is set.
+	Permits  int64             `protobuf:"varint,4,opt,name=permits,proto3" json:"permits,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optimistic concurrency check: must equal the semaphore's current version or
+	// the update is rejected.
+	ExpectedVersion int64 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Accept permits below active_holds. The semaphore then drains: nothing new
+	// is granted until active_holds falls to permits.
+	AllowDraining bool `protobuf:"varint,7,opt,name=allow_draining,json=allowDraining,proto3" json:"allow_draining,omitempty"`
+	// New inactivity window in seconds; -1 turns auto-deletion off and 0 keeps
+	// the current window.
+	DeleteInactiveAfterSeconds int64 `protobuf:"varint,8,opt,name=delete_inactive_after_seconds,json=deleteInactiveAfterSeconds,proto3" json:"delete_inactive_after_seconds,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *UpdateSemaphoreRequest) Reset() {
+	*x = UpdateSemaphoreRequest{}
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSemaphoreHoldersRequest) String() string {
+func (x *UpdateSemaphoreRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSemaphoreHoldersRequest) ProtoMessage() {}
+func (*UpdateSemaphoreRequest) ProtoMessage() {}
 
-func (x *ListSemaphoreHoldersRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateSemaphoreRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_corepb_semaphores_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1076,63 +1281,695 @@ func (x *ListSemaphoreHoldersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSemaphoreHoldersRequest.ProtoReflect.Descriptor instead.
-func (*ListSemaphoreHoldersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateSemaphoreRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSemaphoreRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ListSemaphoreHoldersRequest) GetNamespaceId() *NamespaceId {
+func (x *UpdateSemaphoreRequest) GetNamespaceId() *NamespaceId {
 	if x != nil {
 		return x.NamespaceId
 	}
 	return nil
 }
 
-func (x *ListSemaphoreHoldersRequest) GetSemaphoreName() string {
+func (x *UpdateSemaphoreRequest) GetSemaphoreName() string {
 	if x != nil {
 		return x.SemaphoreName
 	}
 	return ""
 }
 
-func (x *ListSemaphoreHoldersRequest) GetPaginationToken() *PaginationToken {
+func (x *UpdateSemaphoreRequest) GetDescription() string {
 	if x != nil {
-		return x.PaginationToken
+		return x.Description
 	}
-	return nil
+	return ""
 }
 
-func (x *ListSemaphoreHoldersRequest) GetLimit() int32 {
+func (x *UpdateSemaphoreRequest) GetPermits() int64 {
 	if x != nil {
-		return x.Limit
+		return x.Permits
 	}
 	return 0
 }
 
-type ListSemaphoreHoldersResponse struct {
-	state                   protoimpl.MessageState `protogen:"open.v1"`
-	Holders                 []*SemaphoreHolder     `protobuf:"bytes,1,rep,name=holders,proto3" json:"holders,omitempty"`
-	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
-	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
-}
-
+func (x *UpdateSemaphoreRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateSemaphoreRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateSemaphoreRequest) GetAllowDraining() bool {
+	if x != nil {
+		return x.AllowDraining
+	}
+	return false
+}
+
+func (x *UpdateSemaphoreRequest) GetDeleteInactiveAfterSeconds() int64 {
+	if x != nil {
+		return x.DeleteInactiveAfterSeconds
+	}
+	return 0
+}
+
+type UpdateSemaphoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Semaphore     *Semaphore             `protobuf:"bytes,1,opt,name=semaphore,proto3" json:"semaphore,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSemaphoreResponse) Reset() {
+	*x = UpdateSemaphoreResponse{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSemaphoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSemaphoreResponse) ProtoMessage() {}
+
+func (x *UpdateSemaphoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSemaphoreResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSemaphoreResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateSemaphoreResponse) GetSemaphore() *Semaphore {
+	if x != nil {
+		return x.Semaphore
+	}
+	return nil
+}
+
+type DeleteSemaphoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	SemaphoreName string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
+	// Idempotency / bookkeeping id the core uses to track the asynchronous deletion
+	// of this object's data. Recurs on delete and namespace-teardown requests.
+	RecordId      uint64 `protobuf:"fixed64,3,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSemaphoreRequest) Reset() {
+	*x = DeleteSemaphoreRequest{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSemaphoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSemaphoreRequest) ProtoMessage() {}
+
+func (x *DeleteSemaphoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSemaphoreRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSemaphoreRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteSemaphoreRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *DeleteSemaphoreRequest) GetSemaphoreName() string {
+	if x != nil {
+		return x.SemaphoreName
+	}
+	return ""
+}
+
+func (x *DeleteSemaphoreRequest) GetRecordId() uint64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+type DeleteSemaphoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSemaphoreResponse) Reset() {
+	*x = DeleteSemaphoreResponse{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSemaphoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSemaphoreResponse) ProtoMessage() {}
+
+func (x *DeleteSemaphoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSemaphoreResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSemaphoreResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{21}
+}
+
+type ListSemaphoreHoldersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	SemaphoreName   string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
+	PaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListSemaphoreHoldersRequest) Reset() {
+	*x = ListSemaphoreHoldersRequest{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSemaphoreHoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSemaphoreHoldersRequest) ProtoMessage() {}
+
+func (x *ListSemaphoreHoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSemaphoreHoldersRequest.ProtoReflect.Descriptor instead.
+func (*ListSemaphoreHoldersRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListSemaphoreHoldersRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *ListSemaphoreHoldersRequest) GetSemaphoreName() string {
+	if x != nil {
+		return x.SemaphoreName
+	}
+	return ""
+}
+
+func (x *ListSemaphoreHoldersRequest) GetPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PaginationToken
+	}
+	return nil
+}
+
+func (x *ListSemaphoreHoldersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSemaphoreHoldersResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Holders                 []*SemaphoreHolder     `protobuf:"bytes,1,rep,name=holders,proto3" json:"holders,omitempty"`
+	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
+	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
+	// The semaphore's classes with their current active_holds.
+	Classes       []*SemaphoreClass `protobuf:"bytes,4,rep,name=classes,proto3" json:"classes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
 func (x *ListSemaphoreHoldersResponse) Reset() {
 	*x = ListSemaphoreHoldersResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[19]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSemaphoreHoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSemaphoreHoldersResponse) ProtoMessage() {}
+
+func (x *ListSemaphoreHoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSemaphoreHoldersResponse.ProtoReflect.Descriptor instead.
+func (*ListSemaphoreHoldersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListSemaphoreHoldersResponse) GetHolders() []*SemaphoreHolder {
+	if x != nil {
+		return x.Holders
+	}
+	return nil
+}
+
+func (x *ListSemaphoreHoldersResponse) GetNextPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.NextPaginationToken
+	}
+	return nil
+}
+
+func (x *ListSemaphoreHoldersResponse) GetPreviousPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PreviousPaginationToken
+	}
+	return nil
+}
+
+func (x *ListSemaphoreHoldersResponse) GetClasses() []*SemaphoreClass {
+	if x != nil {
+		return x.Classes
+	}
+	return nil
+}
+
+type ListSemaphoreWaitersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	SemaphoreName   string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
+	PaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListSemaphoreWaitersRequest) Reset() {
+	*x = ListSemaphoreWaitersRequest{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSemaphoreWaitersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSemaphoreWaitersRequest) ProtoMessage() {}
+
+func (x *ListSemaphoreWaitersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSemaphoreWaitersRequest.ProtoReflect.Descriptor instead.
+func (*ListSemaphoreWaitersRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListSemaphoreWaitersRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *ListSemaphoreWaitersRequest) GetSemaphoreName() string {
+	if x != nil {
+		return x.SemaphoreName
+	}
+	return ""
+}
+
+func (x *ListSemaphoreWaitersRequest) GetPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PaginationToken
+	}
+	return nil
+}
+
+func (x *ListSemaphoreWaitersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSemaphoreWaitersResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Waiters                 []*SemaphoreWaiter     `protobuf:"bytes,1,rep,name=waiters,proto3" json:"waiters,omitempty"`
+	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
+	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ListSemaphoreWaitersResponse) Reset() {
+	*x = ListSemaphoreWaitersResponse{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSemaphoreWaitersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSemaphoreWaitersResponse) ProtoMessage() {}
+
+func (x *ListSemaphoreWaitersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSemaphoreWaitersResponse.ProtoReflect.Descriptor instead.
+func (*ListSemaphoreWaitersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSemaphoreWaitersResponse) GetWaiters() []*SemaphoreWaiter {
+	if x != nil {
+		return x.Waiters
+	}
+	return nil
+}
+
+func (x *ListSemaphoreWaitersResponse) GetNextPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.NextPaginationToken
+	}
+	return nil
+}
+
+func (x *ListSemaphoreWaitersResponse) GetPreviousPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PreviousPaginationToken
+	}
+	return nil
+}
+
+type GetSemaphoreStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	SemaphoreName string                 `protobuf:"bytes,2,opt,name=semaphore_name,json=semaphoreName,proto3" json:"semaphore_name,omitempty"`
+	// How far back from now to report, in seconds. Rounded up to whole buckets.
+	WindowSeconds int64 `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSemaphoreStatsRequest) Reset() {
+	*x = GetSemaphoreStatsRequest{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSemaphoreStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSemaphoreStatsRequest) ProtoMessage() {}
+
+func (x *GetSemaphoreStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSemaphoreStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetSemaphoreStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetSemaphoreStatsRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *GetSemaphoreStatsRequest) GetSemaphoreName() string {
+	if x != nil {
+		return x.SemaphoreName
+	}
+	return ""
+}
+
+func (x *GetSemaphoreStatsRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+type GetSemaphoreStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One bucket per minute of the window, oldest first, including the current, partial minute.
+	Buckets       []*SemaphoreStatsBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSemaphoreStatsResponse) Reset() {
+	*x = GetSemaphoreStatsResponse{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSemaphoreStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSemaphoreStatsResponse) ProtoMessage() {}
+
+func (x *GetSemaphoreStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSemaphoreStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetSemaphoreStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetSemaphoreStatsResponse) GetBuckets() []*SemaphoreStatsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// SemaphoreStatsBucket is the utilization of a semaphore during one minute.
+type SemaphoreStatsBucket struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	StartAt int64                  `protobuf:"fixed64,1,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	// Highest active_holds during the bucket.
+	MaxActiveHolds int64 `protobuf:"varint,2,opt,name=max_active_holds,json=maxActiveHolds,proto3" json:"max_active_holds,omitempty"`
+	// Time-weighted average of active_holds during the bucket (up to now for the current one).
+	AverageActiveHolds float64 `protobuf:"fixed64,3,opt,name=average_active_holds,json=averageActiveHolds,proto3" json:"average_active_holds,omitempty"`
+	// Number of acquire attempts that were granted or not. Re-acquires count too.
+	AcquiresSucceeded int64 `protobuf:"varint,4,opt,name=acquires_succeeded,json=acquiresSucceeded,proto3" json:"acquires_succeeded,omitempty"`
+	AcquiresFailed    int64 `protobuf:"varint,5,opt,name=acquires_failed,json=acquiresFailed,proto3" json:"acquires_failed,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SemaphoreStatsBucket) Reset() {
+	*x = SemaphoreStatsBucket{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SemaphoreStatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SemaphoreStatsBucket) ProtoMessage() {}
+
+func (x *SemaphoreStatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SemaphoreStatsBucket.ProtoReflect.Descriptor instead.
+func (*SemaphoreStatsBucket) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SemaphoreStatsBucket) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsBucket) GetMaxActiveHolds() int64 {
+	if x != nil {
+		return x.MaxActiveHolds
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsBucket) GetAverageActiveHolds() float64 {
+	if x != nil {
+		return x.AverageActiveHolds
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsBucket) GetAcquiresSucceeded() int64 {
+	if x != nil {
+		return x.AcquiresSucceeded
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsBucket) GetAcquiresFailed() int64 {
+	if x != nil {
+		return x.AcquiresFailed
+	}
+	return 0
+}
+
+// SemaphoreStatsRecord is the stored form of a SemaphoreStatsBucket. Buckets without any
+// activity are not stored; their active_holds carries over from the previous bucket.
+type SemaphoreStatsRecord struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SemaphoreId *SemaphoreId           `protobuf:"bytes,1,opt,name=semaphore_id,json=semaphoreId,proto3" json:"semaphore_id,omitempty"`
+	StartAt     int64                  `protobuf:"fixed64,2,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	// active_holds when the bucket started.
+	OpeningActiveHolds int64 `protobuf:"varint,3,opt,name=opening_active_holds,json=openingActiveHolds,proto3" json:"opening_active_holds,omitempty"`
+	// active_holds as of updated_at.
+	ActiveHolds    int64 `protobuf:"varint,4,opt,name=active_holds,json=activeHolds,proto3" json:"active_holds,omitempty"`
+	UpdatedAt      int64 `protobuf:"fixed64,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	MaxActiveHolds int64 `protobuf:"varint,6,opt,name=max_active_holds,json=maxActiveHolds,proto3" json:"max_active_holds,omitempty"`
+	// Sum of active_holds × nanoseconds over [start_at, updated_at).
+	ActiveHoldsIntegral int64 `protobuf:"varint,7,opt,name=active_holds_integral,json=activeHoldsIntegral,proto3" json:"active_holds_integral,omitempty"`
+	AcquiresSucceeded   int64 `protobuf:"varint,8,opt,name=acquires_succeeded,json=acquiresSucceeded,proto3" json:"acquires_succeeded,omitempty"`
+	AcquiresFailed      int64 `protobuf:"varint,9,opt,name=acquires_failed,json=acquiresFailed,proto3" json:"acquires_failed,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SemaphoreStatsRecord) Reset() {
+	*x = SemaphoreStatsRecord{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSemaphoreHoldersResponse) String() string {
+func (x *SemaphoreStatsRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSemaphoreHoldersResponse) ProtoMessage() {}
+func (*SemaphoreStatsRecord) ProtoMessage() {}
 
-func (x *ListSemaphoreHoldersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[19]
+func (x *SemaphoreStatsRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1143,30 +1980,72 @@ func (x *ListSemaphoreHoldersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSemaphoreHoldersResponse.ProtoReflect.Descriptor instead.
-func (*ListSemaphoreHoldersResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SemaphoreStatsRecord.ProtoReflect.Descriptor instead.
+func (*SemaphoreStatsRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ListSemaphoreHoldersResponse) GetHolders() []*SemaphoreHolder {
+func (x *SemaphoreStatsRecord) GetSemaphoreId() *SemaphoreId {
 	if x != nil {
-		return x.Holders
+		return x.SemaphoreId
 	}
 	return nil
 }
 
-func (x *ListSemaphoreHoldersResponse) GetNextPaginationToken() *PaginationToken {
+func (x *SemaphoreStatsRecord) GetStartAt() int64 {
 	if x != nil {
-		return x.NextPaginationToken
+		return x.StartAt
 	}
-	return nil
+	return 0
 }
 
-func (x *ListSemaphoreHoldersResponse) GetPreviousPaginationToken() *PaginationToken {
+func (x *SemaphoreStatsRecord) GetOpeningActiveHolds() int64 {
 	if x != nil {
-		return x.PreviousPaginationToken
+		return x.OpeningActiveHolds
 	}
-	return nil
+	return 0
+}
+
+func (x *SemaphoreStatsRecord) GetActiveHolds() int64 {
+	if x != nil {
+		return x.ActiveHolds
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsRecord) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsRecord) GetMaxActiveHolds() int64 {
+	if x != nil {
+		return x.MaxActiveHolds
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsRecord) GetActiveHoldsIntegral() int64 {
+	if x != nil {
+		return x.ActiveHoldsIntegral
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsRecord) GetAcquiresSucceeded() int64 {
+	if x != nil {
+		return x.AcquiresSucceeded
+	}
+	return 0
+}
+
+func (x *SemaphoreStatsRecord) GetAcquiresFailed() int64 {
+	if x != nil {
+		return x.AcquiresFailed
+	}
+	return 0
 }
 
 type ListSemaphoreLeasesRequest struct {
@@ -1180,7 +2059,7 @@ type ListSemaphoreLeasesRequest struct {
 
 func (x *ListSemaphoreLeasesRequest) Reset() {
 	*x = ListSemaphoreLeasesRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[20]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1192,7 +2071,7 @@ func (x *ListSemaphoreLeasesRequest) String() string {
 func (*ListSemaphoreLeasesRequest) ProtoMessage() {}
 
 func (x *ListSemaphoreLeasesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[20]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,7 +2084,7 @@ func (x *ListSemaphoreLeasesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSemaphoreLeasesRequest.ProtoReflect.Descriptor instead.
 func (*ListSemaphoreLeasesRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{20}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ListSemaphoreLeasesRequest) GetNamespaceId() *NamespaceId {
@@ -1240,7 +2119,7 @@ type ListSemaphoreLeasesResponse struct {
 
 func (x *ListSemaphoreLeasesResponse) Reset() {
 	*x = ListSemaphoreLeasesResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[21]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1252,7 +2131,7 @@ func (x *ListSemaphoreLeasesResponse) String() string {
 func (*ListSemaphoreLeasesResponse) ProtoMessage() {}
 
 func (x *ListSemaphoreLeasesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[21]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1265,7 +2144,7 @@ func (x *ListSemaphoreLeasesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSemaphoreLeasesResponse.ProtoReflect.Descriptor instead.
 func (*ListSemaphoreLeasesResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{21}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ListSemaphoreLeasesResponse) GetLeases() []*Lease {
@@ -1301,7 +2180,7 @@ type ListSemaphoreLeasesByProcessIdRequest struct {
 
 func (x *ListSemaphoreLeasesByProcessIdRequest) Reset() {
 	*x = ListSemaphoreLeasesByProcessIdRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[22]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1313,7 +2192,7 @@ func (x *ListSemaphoreLeasesByProcessIdRequest) String() string {
 func (*ListSemaphoreLeasesByProcessIdRequest) ProtoMessage() {}
 
 func (x *ListSemaphoreLeasesByProcessIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[22]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1326,7 +2205,7 @@ func (x *ListSemaphoreLeasesByProcessIdRequest) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use ListSemaphoreLeasesByProcessIdRequest.ProtoReflect.Descriptor instead.
 func (*ListSemaphoreLeasesByProcessIdRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{22}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ListSemaphoreLeasesByProcessIdRequest) GetNamespaceId() *NamespaceId {
@@ -1368,7 +2247,7 @@ type ListSemaphoreLeasesByProcessIdResponse struct {
 
 func (x *ListSemaphoreLeasesByProcessIdResponse) Reset() {
 	*x = ListSemaphoreLeasesByProcessIdResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[23]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1380,7 +2259,7 @@ func (x *ListSemaphoreLeasesByProcessIdResponse) String() string {
 func (*ListSemaphoreLeasesByProcessIdResponse) ProtoMessage() {}
 
 func (x *ListSemaphoreLeasesByProcessIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[23]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1393,7 +2272,7 @@ func (x *ListSemaphoreLeasesByProcessIdResponse) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use ListSemaphoreLeasesByProcessIdResponse.ProtoReflect.Descriptor instead.
 func (*ListSemaphoreLeasesByProcessIdResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{23}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListSemaphoreLeasesByProcessIdResponse) GetLeases() []*Lease {
@@ -1426,7 +2305,7 @@ type GetSemaphoreLeaseRequest struct {
 
 func (x *GetSemaphoreLeaseRequest) Reset() {
 	*x = GetSemaphoreLeaseRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[24]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1438,7 +2317,7 @@ func (x *GetSemaphoreLeaseRequest) String() string {
 func (*GetSemaphoreLeaseRequest) ProtoMessage() {}
 
 func (x *GetSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[24]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1451,7 +2330,7 @@ func (x *GetSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSemaphoreLeaseRequest.ProtoReflect.Descriptor instead.
 func (*GetSemaphoreLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{24}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetSemaphoreLeaseRequest) GetLeaseId() *LeaseId {
@@ -1470,7 +2349,7 @@ type GetSemaphoreLeaseResponse struct {
 
 func (x *GetSemaphoreLeaseResponse) Reset() {
 	*x = GetSemaphoreLeaseResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[25]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1482,7 +2361,7 @@ func (x *GetSemaphoreLeaseResponse) String() string {
 func (*GetSemaphoreLeaseResponse) ProtoMessage() {}
 
 func (x *GetSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[25]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1495,7 +2374,7 @@ func (x *GetSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSemaphoreLeaseResponse.ProtoReflect.Descriptor instead.
 func (*GetSemaphoreLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{25}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetSemaphoreLeaseResponse) GetLease() *Lease {
@@ -1518,7 +2397,7 @@ type CreateSemaphoreLeaseRequest struct {
 
 func (x *CreateSemaphoreLeaseRequest) Reset() {
 	*x = CreateSemaphoreLeaseRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[26]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1530,7 +2409,7 @@ func (x *CreateSemaphoreLeaseRequest) String() string {
 func (*CreateSemaphoreLeaseRequest) ProtoMessage() {}
 
 func (x *CreateSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[26]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1543,7 +2422,7 @@ func (x *CreateSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSemaphoreLeaseRequest.ProtoReflect.Descriptor instead.
 func (*CreateSemaphoreLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{26}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *CreateSemaphoreLeaseRequest) GetLeaseId() *LeaseId {
@@ -1590,7 +2469,7 @@ type CreateSemaphoreLeaseResponse struct {
 
 func (x *CreateSemaphoreLeaseResponse) Reset() {
 	*x = CreateSemaphoreLeaseResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[27]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1602,7 +2481,7 @@ func (x *CreateSemaphoreLeaseResponse) String() string {
 func (*CreateSemaphoreLeaseResponse) ProtoMessage() {}
 
 func (x *CreateSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[27]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1615,7 +2494,7 @@ func (x *CreateSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSemaphoreLeaseResponse.ProtoReflect.Descriptor instead.
 func (*CreateSemaphoreLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{27}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *CreateSemaphoreLeaseResponse) GetLease() *Lease {
@@ -1634,7 +2513,7 @@ type RevokeSemaphoreLeaseRequest struct {
 
 func (x *RevokeSemaphoreLeaseRequest) Reset() {
 	*x = RevokeSemaphoreLeaseRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[28]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1646,7 +2525,7 @@ func (x *RevokeSemaphoreLeaseRequest) String() string {
 func (*RevokeSemaphoreLeaseRequest) ProtoMessage() {}
 
 func (x *RevokeSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[28]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1659,7 +2538,7 @@ func (x *RevokeSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeSemaphoreLeaseRequest.ProtoReflect.Descriptor instead.
 func (*RevokeSemaphoreLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{28}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *RevokeSemaphoreLeaseRequest) GetLeaseId() *LeaseId {
@@ -1677,7 +2556,7 @@ type RevokeSemaphoreLeaseResponse struct {
 
 func (x *RevokeSemaphoreLeaseResponse) Reset() {
 	*x = RevokeSemaphoreLeaseResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[29]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1689,7 +2568,7 @@ func (x *RevokeSemaphoreLeaseResponse) String() string {
 func (*RevokeSemaphoreLeaseResponse) ProtoMessage() {}
 
 func (x *RevokeSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[29]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1702,7 +2581,7 @@ func (x *RevokeSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeSemaphoreLeaseResponse.ProtoReflect.Descriptor instead.
 func (*RevokeSemaphoreLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{29}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{39}
 }
 
 type RefreshSemaphoreLeaseRequest struct {
@@ -1715,7 +2594,7 @@ type RefreshSemaphoreLeaseRequest struct {
 
 func (x *RefreshSemaphoreLeaseRequest) Reset() {
 	*x = RefreshSemaphoreLeaseRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[30]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1727,7 +2606,7 @@ func (x *RefreshSemaphoreLeaseRequest) String() string {
 func (*RefreshSemaphoreLeaseRequest) ProtoMessage() {}
 
 func (x *RefreshSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[30]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1740,7 +2619,7 @@ func (x *RefreshSemaphoreLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshSemaphoreLeaseRequest.ProtoReflect.Descriptor instead.
 func (*RefreshSemaphoreLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{30}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *RefreshSemaphoreLeaseRequest) GetLeaseId() *LeaseId {
@@ -1766,7 +2645,7 @@ type RefreshSemaphoreLeaseResponse struct {
 
 func (x *RefreshSemaphoreLeaseResponse) Reset() {
 	*x = RefreshSemaphoreLeaseResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[31]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1778,7 +2657,7 @@ func (x *RefreshSemaphoreLeaseResponse) String() string {
 func (*RefreshSemaphoreLeaseResponse) ProtoMessage() {}
 
 func (x *RefreshSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[31]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1791,7 +2670,7 @@ func (x *RefreshSemaphoreLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshSemaphoreLeaseResponse.ProtoReflect.Descriptor instead.
 func (*RefreshSemaphoreLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{31}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *RefreshSemaphoreLeaseResponse) GetLease() *Lease {
@@ -1811,7 +2690,7 @@ type SemaphoresDeleteNamespaceRequest struct {
 
 func (x *SemaphoresDeleteNamespaceRequest) Reset() {
 	*x = SemaphoresDeleteNamespaceRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[32]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1823,7 +2702,7 @@ func (x *SemaphoresDeleteNamespaceRequest) String() string {
 func (*SemaphoresDeleteNamespaceRequest) ProtoMessage() {}
 
 func (x *SemaphoresDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[32]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1836,7 +2715,7 @@ func (x *SemaphoresDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SemaphoresDeleteNamespaceRequest.ProtoReflect.Descriptor instead.
 func (*SemaphoresDeleteNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{32}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *SemaphoresDeleteNamespaceRequest) GetRecordId() uint64 {
@@ -1861,7 +2740,7 @@ type SemaphoresDeleteNamespaceResponse struct {
 
 func (x *SemaphoresDeleteNamespaceResponse) Reset() {
 	*x = SemaphoresDeleteNamespaceResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[33]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1873,7 +2752,7 @@ func (x *SemaphoresDeleteNamespaceResponse) String() string {
 func (*SemaphoresDeleteNamespaceResponse) ProtoMessage() {}
 
 func (x *SemaphoresDeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[33]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1886,7 +2765,7 @@ func (x *SemaphoresDeleteNamespaceResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SemaphoresDeleteNamespaceResponse.ProtoReflect.Descriptor instead.
 func (*SemaphoresDeleteNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{33}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{43}
 }
 
 type RunSemaphoresGarbageCollectionRequest struct {
@@ -1903,7 +2782,7 @@ type RunSemaphoresGarbageCollectionRequest struct {
 
 func (x *RunSemaphoresGarbageCollectionRequest) Reset() {
 	*x = RunSemaphoresGarbageCollectionRequest{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[34]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1915,7 +2794,7 @@ func (x *RunSemaphoresGarbageCollectionRequest) String() string {
 func (*RunSemaphoresGarbageCollectionRequest) ProtoMessage() {}
 
 func (x *RunSemaphoresGarbageCollectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[34]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1928,7 +2807,7 @@ func (x *RunSemaphoresGarbageCollectionRequest) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use RunSemaphoresGarbageCollectionRequest.ProtoReflect.Descriptor instead.
 func (*RunSemaphoresGarbageCollectionRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{34}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *RunSemaphoresGarbageCollectionRequest) GetGcRecordsPageSize() int64 {
@@ -1967,7 +2846,7 @@ type RunSemaphoresGarbageCollectionResponse struct {
 
 func (x *RunSemaphoresGarbageCollectionResponse) Reset() {
 	*x = RunSemaphoresGarbageCollectionResponse{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[35]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1979,7 +2858,7 @@ func (x *RunSemaphoresGarbageCollectionResponse) String() string {
 func (*RunSemaphoresGarbageCollectionResponse) ProtoMessage() {}
 
 func (x *RunSemaphoresGarbageCollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[35]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1992,7 +2871,7 @@ func (x *RunSemaphoresGarbageCollectionResponse) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use RunSemaphoresGarbageCollectionResponse.ProtoReflect.Descriptor instead.
 func (*RunSemaphoresGarbageCollectionResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{35}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{45}
 }
 
 // Semaphore is a weighted counting semaphore: it admits concurrent holders as
@@ -2014,7 +2893,8 @@ type Semaphore struct {
 	Permits  int64             `protobuf:"varint,7,opt,name=permits,proto3" json:"permits,omitempty"`
 	Metadata map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Currently consumed capacity: the sum of all active holders' weights
-	// (0 <= active_holds <= permits).
+	// (0 <= active_holds <= permits, except while the semaphore drains after its
+	// permits were lowered below the holds).
 	ActiveHolds int64 `protobuf:"varint,9,opt,name=active_holds,json=activeHolds,proto3" json:"active_holds,omitempty"`
 	// Number of distinct leases currently holding permits.
 	ActiveHoldersCount int64 `protobuf:"varint,10,opt,name=active_holders_count,json=activeHoldersCount,proto3" json:"active_holders_count,omitempty"`
@@ -2024,25 +2904,200 @@ type Semaphore struct {
 	// last_activity_at is the timestamp (ns) of the most recent activity on this
 	// semaphore (an acquire attempt or a release). Not affected by reads.
 	LastActivityAt int64 `protobuf:"fixed64,12,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Parent semaphore (0 and empty for a top-level semaphore). Set at creation
+	// and never changed, so the hierarchy cannot form a cycle.
+	ParentSemaphoreId   uint64 `protobuf:"fixed64,13,opt,name=parent_semaphore_id,json=parentSemaphoreId,proto3" json:"parent_semaphore_id,omitempty"`
+	ParentSemaphoreName string `protobuf:"bytes,14,opt,name=parent_semaphore_name,json=parentSemaphoreName,proto3" json:"parent_semaphore_name,omitempty"`
+	// Number of semaphores that have this one as their parent. A semaphore with
+	// children cannot be deleted.
+	NumberOfChildren int64 `protobuf:"varint,15,opt,name=number_of_children,json=numberOfChildren,proto3" json:"number_of_children,omitempty"`
+	// Classes sharing the permits, in the order they were declared.
+	Classes []*SemaphoreClass `protobuf:"bytes,16,rep,name=classes,proto3" json:"classes,omitempty"`
+	// Inactivity window: once the semaphore has no holders and no children, it is
+	// deleted by garbage collection this many seconds after last_activity_at. 0
+	// means it is never deleted automatically.
+	DeleteInactiveAfterSeconds int64 `protobuf:"varint,17,opt,name=delete_inactive_after_seconds,json=deleteInactiveAfterSeconds,proto3" json:"delete_inactive_after_seconds,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *Semaphore) Reset() {
+	*x = Semaphore{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Semaphore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Semaphore) ProtoMessage() {}
+
+func (x *Semaphore) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Semaphore.ProtoReflect.Descriptor instead.
+func (*Semaphore) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Semaphore) GetId() *SemaphoreId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Semaphore) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Semaphore) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Semaphore) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Semaphore) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Semaphore) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Semaphore) GetPermits() int64 {
+	if x != nil {
+		return x.Permits
+	}
+	return 0
+}
+
+func (x *Semaphore) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Semaphore) GetActiveHolds() int64 {
+	if x != nil {
+		return x.ActiveHolds
+	}
+	return 0
+}
+
+func (x *Semaphore) GetActiveHoldersCount() int64 {
+	if x != nil {
+		return x.ActiveHoldersCount
+	}
+	return 0
+}
+
+func (x *Semaphore) GetEarliestHolderExpiresAt() int64 {
+	if x != nil {
+		return x.EarliestHolderExpiresAt
+	}
+	return 0
+}
+
+func (x *Semaphore) GetLastActivityAt() int64 {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return 0
+}
+
+func (x *Semaphore) GetParentSemaphoreId() uint64 {
+	if x != nil {
+		return x.ParentSemaphoreId
+	}
+	return 0
 }
 
-func (x *Semaphore) Reset() {
-	*x = Semaphore{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[36]
+func (x *Semaphore) GetParentSemaphoreName() string {
+	if x != nil {
+		return x.ParentSemaphoreName
+	}
+	return ""
+}
+
+func (x *Semaphore) GetNumberOfChildren() int64 {
+	if x != nil {
+		return x.NumberOfChildren
+	}
+	return 0
+}
+
+func (x *Semaphore) GetClasses() []*SemaphoreClass {
+	if x != nil {
+		return x.Classes
+	}
+	return nil
+}
+
+func (x *Semaphore) GetDeleteInactiveAfterSeconds() int64 {
+	if x != nil {
+		return x.DeleteInactiveAfterSeconds
+	}
+	return 0
+}
+
+// SemaphoreClassSpec declares a class of holders on a semaphore.
+type SemaphoreClassSpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Permits reserved for the class: holders outside of it cannot take them even
+	// while they are free.
+	MinPermits int64 `protobuf:"varint,2,opt,name=min_permits,json=minPermits,proto3" json:"min_permits,omitempty"`
+	// Most permits the class may hold at once; 0 means no limit beyond permits.
+	MaxPermits    int64 `protobuf:"varint,3,opt,name=max_permits,json=maxPermits,proto3" json:"max_permits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SemaphoreClassSpec) Reset() {
+	*x = SemaphoreClassSpec{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Semaphore) String() string {
+func (x *SemaphoreClassSpec) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Semaphore) ProtoMessage() {}
+func (*SemaphoreClassSpec) ProtoMessage() {}
 
-func (x *Semaphore) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[36]
+func (x *SemaphoreClassSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2053,91 +3108,99 @@ func (x *Semaphore) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Semaphore.ProtoReflect.Descriptor instead.
-func (*Semaphore) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{36}
-}
-
-func (x *Semaphore) GetId() *SemaphoreId {
-	if x != nil {
-		return x.Id
-	}
-	return nil
+// Deprecated: Use SemaphoreClassSpec.ProtoReflect.Descriptor instead.
+func (*SemaphoreClassSpec) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *Semaphore) GetName() string {
+func (x *SemaphoreClassSpec) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Semaphore) GetDescription() string {
+func (x *SemaphoreClassSpec) GetMinPermits() int64 {
 	if x != nil {
-		return x.Description
+		return x.MinPermits
 	}
-	return ""
+	return 0
 }
 
-func (x *Semaphore) GetCreatedAt() int64 {
+func (x *SemaphoreClassSpec) GetMaxPermits() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.MaxPermits
 	}
 	return 0
 }
 
-func (x *Semaphore) GetUpdatedAt() int64 {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return 0
+// SemaphoreClass is a class of holders on a semaphore with its current usage.
+type SemaphoreClass struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MinPermits int64                  `protobuf:"varint,2,opt,name=min_permits,json=minPermits,proto3" json:"min_permits,omitempty"`
+	MaxPermits int64                  `protobuf:"varint,3,opt,name=max_permits,json=maxPermits,proto3" json:"max_permits,omitempty"`
+	// Sum of the weights acquired directly in this class (inherited weight is not
+	// part of any class).
+	ActiveHolds   int64 `protobuf:"varint,4,opt,name=active_holds,json=activeHolds,proto3" json:"active_holds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Semaphore) GetVersion() int64 {
-	if x != nil {
-		return x.Version
-	}
-	return 0
+func (x *SemaphoreClass) Reset() {
+	*x = SemaphoreClass{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Semaphore) GetPermits() int64 {
-	if x != nil {
-		return x.Permits
-	}
-	return 0
+func (x *SemaphoreClass) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Semaphore) GetMetadata() map[string]string {
+func (*SemaphoreClass) ProtoMessage() {}
+
+func (x *SemaphoreClass) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[48]
 	if x != nil {
-		return x.Metadata
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Semaphore) GetActiveHolds() int64 {
+// Deprecated: Use SemaphoreClass.ProtoReflect.Descriptor instead.
+func (*SemaphoreClass) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SemaphoreClass) GetName() string {
 	if x != nil {
-		return x.ActiveHolds
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *Semaphore) GetActiveHoldersCount() int64 {
+func (x *SemaphoreClass) GetMinPermits() int64 {
 	if x != nil {
-		return x.ActiveHoldersCount
+		return x.MinPermits
 	}
 	return 0
 }
 
-func (x *Semaphore) GetEarliestHolderExpiresAt() int64 {
+func (x *SemaphoreClass) GetMaxPermits() int64 {
 	if x != nil {
-		return x.EarliestHolderExpiresAt
+		return x.MaxPermits
 	}
 	return 0
 }
 
-func (x *Semaphore) GetLastActivityAt() int64 {
+func (x *SemaphoreClass) GetActiveHolds() int64 {
 	if x != nil {
-		return x.LastActivityAt
+		return x.ActiveHolds
 	}
 	return 0
 }
@@ -2149,18 +3212,30 @@ type SemaphoreHolder struct {
 	// When the hold was acquired (or last refreshed), Unix nanoseconds.
 	LockedAt int64 `protobuf:"fixed64,2,opt,name=locked_at,json=lockedAt,proto3" json:"locked_at,omitempty"`
 	// When this hold lapses if its lease is not refreshed, Unix nanoseconds; mirrors
-	// the owning lease's expiration.
+	// the owning lease's expiration (see also hold_expires_at).
 	ExpiresAt int64 `protobuf:"fixed64,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	// Number of permits this holder consumes.
-	Weight        int64             `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Weight   int64             `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Part of weight consumed through holds of the same lease on descendant
+	// semaphores. weight - inherited_weight is what the lease acquired on this
+	// semaphore directly.
+	InheritedWeight int64 `protobuf:"varint,6,opt,name=inherited_weight,json=inheritedWeight,proto3" json:"inherited_weight,omitempty"`
+	// Class the directly acquired weight belongs to; empty for none.
+	Class string `protobuf:"bytes,7,opt,name=class,proto3" json:"class,omitempty"`
+	// When the directly acquired weight is released regardless of the lease
+	// (max_hold_seconds), Unix nanoseconds; 0 when it lasts as long as the lease.
+	// Weight inherited from descendants always lasts as long as the lease. The
+	// holder is indexed by whichever of expires_at and hold_expires_at comes
+	// first.
+	HoldExpiresAt int64 `protobuf:"fixed64,8,opt,name=hold_expires_at,json=holdExpiresAt,proto3" json:"hold_expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SemaphoreHolder) Reset() {
 	*x = SemaphoreHolder{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[37]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2172,7 +3247,7 @@ func (x *SemaphoreHolder) String() string {
 func (*SemaphoreHolder) ProtoMessage() {}
 
 func (x *SemaphoreHolder) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[37]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2185,7 +3260,7 @@ func (x *SemaphoreHolder) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SemaphoreHolder.ProtoReflect.Descriptor instead.
 func (*SemaphoreHolder) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{37}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *SemaphoreHolder) GetId() *SemaphoreHolderId {
@@ -2223,6 +3298,131 @@ func (x *SemaphoreHolder) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *SemaphoreHolder) GetInheritedWeight() int64 {
+	if x != nil {
+		return x.InheritedWeight
+	}
+	return 0
+}
+
+func (x *SemaphoreHolder) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *SemaphoreHolder) GetHoldExpiresAt() int64 {
+	if x != nil {
+		return x.HoldExpiresAt
+	}
+	return 0
+}
+
+// SemaphoreWaiter is a lease blocked in AcquireSemaphore on a semaphore that does
+// not have enough free permits yet. Waiters are short-lived diagnostic records:
+// they are refreshed by every retry of the acquire, removed once it succeeds or
+// gives up, and otherwise lapse at expires_at. They have no effect on who gets
+// the permits next.
+type SemaphoreWaiter struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SemaphoreId *SemaphoreId           `protobuf:"bytes,1,opt,name=semaphore_id,json=semaphoreId,proto3" json:"semaphore_id,omitempty"`
+	// The lease waiting for the semaphore.
+	LeaseId uint64 `protobuf:"fixed64,2,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	// Number of permits the lease is waiting for.
+	Weight int64 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Class the weight is requested in; empty for none.
+	Class string `protobuf:"bytes,4,opt,name=class,proto3" json:"class,omitempty"`
+	// process_id of the waiting lease.
+	ProcessId string `protobuf:"bytes,5,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
+	// When the lease started waiting, Unix nanoseconds.
+	WaitingSince int64 `protobuf:"fixed64,6,opt,name=waiting_since,json=waitingSince,proto3" json:"waiting_since,omitempty"`
+	// When the record lapses unless refreshed: the end of the wait or the
+	// expiration of the lease, whichever comes first. Unix nanoseconds.
+	ExpiresAt     int64 `protobuf:"fixed64,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SemaphoreWaiter) Reset() {
+	*x = SemaphoreWaiter{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SemaphoreWaiter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SemaphoreWaiter) ProtoMessage() {}
+
+func (x *SemaphoreWaiter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SemaphoreWaiter.ProtoReflect.Descriptor instead.
+func (*SemaphoreWaiter) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SemaphoreWaiter) GetSemaphoreId() *SemaphoreId {
+	if x != nil {
+		return x.SemaphoreId
+	}
+	return nil
+}
+
+func (x *SemaphoreWaiter) GetLeaseId() uint64 {
+	if x != nil {
+		return x.LeaseId
+	}
+	return 0
+}
+
+func (x *SemaphoreWaiter) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *SemaphoreWaiter) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *SemaphoreWaiter) GetProcessId() string {
+	if x != nil {
+		return x.ProcessId
+	}
+	return ""
+}
+
+func (x *SemaphoreWaiter) GetWaitingSince() int64 {
+	if x != nil {
+		return x.WaitingSince
+	}
+	return 0
+}
+
+func (x *SemaphoreWaiter) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 // SemaphoreHolderId uniquely identifies a single lease's hold on a semaphore: at
 // most one holder exists per (semaphore, lease) pair.
 type SemaphoreHolderId struct {
@@ -2237,7 +3437,7 @@ type SemaphoreHolderId struct {
 
 func (x *SemaphoreHolderId) Reset() {
 	*x = SemaphoreHolderId{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[38]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2249,7 +3449,7 @@ func (x *SemaphoreHolderId) String() string {
 func (*SemaphoreHolderId) ProtoMessage() {}
 
 func (x *SemaphoreHolderId) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[38]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2262,7 +3462,7 @@ func (x *SemaphoreHolderId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SemaphoreHolderId.ProtoReflect.Descriptor instead.
 func (*SemaphoreHolderId) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{38}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *SemaphoreHolderId) GetAccountId() uint64 {
@@ -2305,7 +3505,7 @@ type SemaphoreId struct {
 
 func (x *SemaphoreId) Reset() {
 	*x = SemaphoreId{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[39]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2317,7 +3517,7 @@ func (x *SemaphoreId) String() string {
 func (*SemaphoreId) ProtoMessage() {}
 
 func (x *SemaphoreId) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[39]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2330,7 +3530,7 @@ func (x *SemaphoreId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SemaphoreId.ProtoReflect.Descriptor instead.
 func (*SemaphoreId) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{39}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *SemaphoreId) GetAccountId() uint64 {
@@ -2364,14 +3564,17 @@ type SemaphoresGarbageCollectionRecord struct {
 	//
 	//	*SemaphoresGarbageCollectionRecord_NamespaceId
 	//	*SemaphoresGarbageCollectionRecord_SemaphoreId
-	Record        isSemaphoresGarbageCollectionRecord_Record `protobuf_oneof:"record"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Record isSemaphoresGarbageCollectionRecord_Record `protobuf_oneof:"record"`
+	// Parent of the deleted semaphore in a semaphore_id record. The weight of its
+	// leftover holders is given back to the parent chain as they are drained.
+	ParentSemaphoreId uint64 `protobuf:"fixed64,4,opt,name=parent_semaphore_id,json=parentSemaphoreId,proto3" json:"parent_semaphore_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *SemaphoresGarbageCollectionRecord) Reset() {
 	*x = SemaphoresGarbageCollectionRecord{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[40]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2383,7 +3586,7 @@ func (x *SemaphoresGarbageCollectionRecord) String() string {
 func (*SemaphoresGarbageCollectionRecord) ProtoMessage() {}
 
 func (x *SemaphoresGarbageCollectionRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[40]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2396,7 +3599,7 @@ func (x *SemaphoresGarbageCollectionRecord) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SemaphoresGarbageCollectionRecord.ProtoReflect.Descriptor instead.
 func (*SemaphoresGarbageCollectionRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{40}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *SemaphoresGarbageCollectionRecord) GetId() uint64 {
@@ -2431,6 +3634,13 @@ func (x *SemaphoresGarbageCollectionRecord) GetSemaphoreId() *SemaphoreId {
 	return nil
 }
 
+func (x *SemaphoresGarbageCollectionRecord) GetParentSemaphoreId() uint64 {
+	if x != nil {
+		return x.ParentSemaphoreId
+	}
+	return 0
+}
+
 type isSemaphoresGarbageCollectionRecord_Record interface {
 	isSemaphoresGarbageCollectionRecord_Record()
 }
@@ -2459,7 +3669,7 @@ type SemaphoresCounter struct {
 
 func (x *SemaphoresCounter) Reset() {
 	*x = SemaphoresCounter{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[41]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2471,7 +3681,7 @@ func (x *SemaphoresCounter) String() string {
 func (*SemaphoresCounter) ProtoMessage() {}
 
 func (x *SemaphoresCounter) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[41]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2484,7 +3694,7 @@ func (x *SemaphoresCounter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SemaphoresCounter.ProtoReflect.Descriptor instead.
 func (*SemaphoresCounter) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{41}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *SemaphoresCounter) GetNumberOfSemaphores() int64 {
@@ -2515,7 +3725,7 @@ type SemaphoresExpirationRecord struct {
 
 func (x *SemaphoresExpirationRecord) Reset() {
 	*x = SemaphoresExpirationRecord{}
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[42]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2527,7 +3737,7 @@ func (x *SemaphoresExpirationRecord) String() string {
 func (*SemaphoresExpirationRecord) ProtoMessage() {}
 
 func (x *SemaphoresExpirationRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_semaphores_proto_msgTypes[42]
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2540,7 +3750,7 @@ func (x *SemaphoresExpirationRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SemaphoresExpirationRecord.ProtoReflect.Descriptor instead.
 func (*SemaphoresExpirationRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{42}
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *SemaphoresExpirationRecord) GetSemaphoreId() *SemaphoreId {
@@ -2557,18 +3767,76 @@ func (x *SemaphoresExpirationRecord) GetExpiresAt() int64 {
 	return 0
 }
 
+// SemaphoresDeletionRecord schedules the auto-deletion of an idle semaphore: one
+// with delete_inactive_after_seconds set, no holders and no children. delete_at
+// is last_activity_at + delete_inactive_after_seconds, Unix nanoseconds.
+type SemaphoresDeletionRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SemaphoreId   *SemaphoreId           `protobuf:"bytes,1,opt,name=semaphore_id,json=semaphoreId,proto3" json:"semaphore_id,omitempty"`
+	DeleteAt      int64                  `protobuf:"fixed64,2,opt,name=delete_at,json=deleteAt,proto3" json:"delete_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SemaphoresDeletionRecord) Reset() {
+	*x = SemaphoresDeletionRecord{}
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SemaphoresDeletionRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SemaphoresDeletionRecord) ProtoMessage() {}
+
+func (x *SemaphoresDeletionRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_semaphores_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SemaphoresDeletionRecord.ProtoReflect.Descriptor instead.
+func (*SemaphoresDeletionRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_semaphores_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SemaphoresDeletionRecord) GetSemaphoreId() *SemaphoreId {
+	if x != nil {
+		return x.SemaphoreId
+	}
+	return nil
+}
+
+func (x *SemaphoresDeletionRecord) GetDeleteAt() int64 {
+	if x != nil {
+		return x.DeleteAt
+	}
+	return 0
+}
+
 var File_pkg_corepb_semaphores_proto protoreflect.FileDescriptor
 
 const file_pkg_corepb_semaphores_proto_rawDesc = "" +
 	"\n" +
-	"\x1bpkg/corepb/semaphores.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\xa0\x03\n" +
+	"\x1bpkg/corepb/semaphores.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\xe0\x04\n" +
 	"\x16CreateSemaphoreRequest\x12I\n" +
 	"\fsemaphore_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\vsemaphoreId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
 	"\apermits\x18\x04 \x01(\x03R\apermits\x12[\n" +
 	"\bmetadata\x18\x05 \x03(\v2?.com.evrblk.grackle.corepb.CreateSemaphoreRequest.MetadataEntryR\bmetadata\x12Q\n" +
-	"&max_number_of_semaphores_per_namespace\x18\x06 \x01(\x03R!maxNumberOfSemaphoresPerNamespace\x1a;\n" +
+	"&max_number_of_semaphores_per_namespace\x18\x06 \x01(\x03R!maxNumberOfSemaphoresPerNamespace\x122\n" +
+	"\x15parent_semaphore_name\x18\a \x01(\tR\x13parentSemaphoreName\x12G\n" +
+	"\aclasses\x18\b \x03(\v2-.com.evrblk.grackle.corepb.SemaphoreClassSpecR\aclasses\x12A\n" +
+	"\x1ddelete_inactive_after_seconds\x18\t \x01(\x03R\x1adeleteInactiveAfterSeconds\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"]\n" +
@@ -2595,39 +3863,74 @@ const file_pkg_corepb_semaphores_proto_rawDesc = "" +
 	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
 	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"`\n" +
 	"\x13GetSemaphoreRequest\x12I\n" +
-	"\fsemaphore_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\vsemaphoreId\"Z\n" +
+	"\fsemaphore_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\vsemaphoreId\"\x7f\n" +
 	"\x14GetSemaphoreResponse\x12B\n" +
-	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\"\x8d\x01\n" +
+	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\x12#\n" +
+	"\rwaiters_count\x18\x02 \x01(\x03R\fwaitersCount\"\x8d\x01\n" +
 	"\x19GetSemaphoreByNameRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
-	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\"`\n" +
+	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\"\x85\x01\n" +
 	"\x1aGetSemaphoreByNameResponse\x12B\n" +
-	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\"\xd9\x02\n" +
+	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\x12#\n" +
+	"\rwaiters_count\x18\x02 \x01(\x03R\fwaitersCount\"\xcf\x05\n" +
 	"\x17AcquireSemaphoreRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
 	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12\x19\n" +
 	"\blease_id\x18\x03 \x01(\x06R\aleaseId\x12\x16\n" +
 	"\x06weight\x18\x04 \x01(\x03R\x06weight\x12\\\n" +
-	"\bmetadata\x18\x05 \x03(\v2@.com.evrblk.grackle.corepb.AcquireSemaphoreRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x05 \x03(\v2@.com.evrblk.grackle.corepb.AcquireSemaphoreRequest.MetadataEntryR\bmetadata\x12\x14\n" +
+	"\x05class\x18\x06 \x01(\tR\x05class\x12(\n" +
+	"\x10max_hold_seconds\x18\a \x01(\x03R\x0emaxHoldSeconds\x12\x1d\n" +
+	"\n" +
+	"wait_until\x18\b \x01(\x03R\twaitUntil\x12'\n" +
+	"\x0fdefault_permits\x18\t \x01(\x03R\x0edefaultPermits\x12P\n" +
+	"%default_delete_inactive_after_seconds\x18\n" +
+	" \x01(\x03R!defaultDeleteInactiveAfterSeconds\x12(\n" +
+	"\x10new_semaphore_id\x18\v \x01(\x06R\x0enewSemaphoreId\x12Q\n" +
+	"&max_number_of_semaphores_per_namespace\x18\f \x01(\x03R!maxNumberOfSemaphoresPerNamespace\x12\x1d\n" +
+	"\n" +
+	"will_retry\x18\r \x01(\bR\twillRetry\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"x\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x02\n" +
 	"\x18AcquireSemaphoreResponse\x12B\n" +
 	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\"\xa6\x01\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12'\n" +
+	"\x0fmissing_permits\x18\x03 \x01(\x03R\x0emissingPermits\x12U\n" +
+	"\x10blocking_holders\x18\x04 \x03(\v2*.com.evrblk.grackle.corepb.SemaphoreHolderR\x0fblockingHolders\x12*\n" +
+	"\x11earliest_grant_at\x18\x05 \x01(\x10R\x0fearliestGrantAt\"\xa6\x01\n" +
 	"\x17ReleaseSemaphoreRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
 	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12\x19\n" +
 	"\blease_id\x18\x03 \x01(\x06R\aleaseId\"^\n" +
 	"\x18ReleaseSemaphoreResponse\x12B\n" +
-	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\"\x8b\x03\n" +
+	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\"\xc1\x01\n" +
+	"\x1aAdjustSemaphoreHoldRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
+	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12\x19\n" +
+	"\blease_id\x18\x03 \x01(\x06R\aleaseId\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x03R\x06weight\"\xbf\x01\n" +
+	"\x1bAdjustSemaphoreHoldResponse\x12B\n" +
+	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\x12B\n" +
+	"\x06holder\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.SemaphoreHolderR\x06holder\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\"\xd4\x01\n" +
+	"\x1cTransferSemaphoreHoldRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
+	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12\"\n" +
+	"\rfrom_lease_id\x18\x03 \x01(\x06R\vfromLeaseId\x12\x1e\n" +
+	"\vto_lease_id\x18\x04 \x01(\x06R\ttoLeaseId\"\xa7\x01\n" +
+	"\x1dTransferSemaphoreHoldResponse\x12B\n" +
+	"\tsemaphore\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.SemaphoreR\tsemaphore\x12B\n" +
+	"\x06holder\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.SemaphoreHolderR\x06holder\"\xf5\x03\n" +
 	"\x16UpdateSemaphoreRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
 	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
 	"\apermits\x18\x04 \x01(\x03R\apermits\x12[\n" +
 	"\bmetadata\x18\x05 \x03(\v2?.com.evrblk.grackle.corepb.UpdateSemaphoreRequest.MetadataEntryR\bmetadata\x12)\n" +
-	"\x10expected_version\x18\x06 \x01(\x03R\x0fexpectedVersion\x1a;\n" +
+	"\x10expected_version\x18\x06 \x01(\x03R\x0fexpectedVersion\x12%\n" +
+	"\x0eallow_draining\x18\a \x01(\bR\rallowDraining\x12A\n" +
+	"\x1ddelete_inactive_after_seconds\x18\b \x01(\x03R\x1adeleteInactiveAfterSeconds\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"]\n" +
@@ -2642,11 +3945,44 @@ const file_pkg_corepb_semaphores_proto_rawDesc = "" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
 	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12U\n" +
 	"\x10pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xac\x02\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xf1\x02\n" +
 	"\x1cListSemaphoreHoldersResponse\x12D\n" +
 	"\aholders\x18\x01 \x03(\v2*.com.evrblk.grackle.corepb.SemaphoreHolderR\aholders\x12^\n" +
 	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
-	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\xd4\x01\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\x12C\n" +
+	"\aclasses\x18\x04 \x03(\v2).com.evrblk.grackle.corepb.SemaphoreClassR\aclasses\"\xfc\x01\n" +
+	"\x1bListSemaphoreWaitersRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
+	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12U\n" +
+	"\x10pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xac\x02\n" +
+	"\x1cListSemaphoreWaitersResponse\x12D\n" +
+	"\awaiters\x18\x01 \x03(\v2*.com.evrblk.grackle.corepb.SemaphoreWaiterR\awaiters\x12^\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\xb3\x01\n" +
+	"\x18GetSemaphoreStatsRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12%\n" +
+	"\x0esemaphore_name\x18\x02 \x01(\tR\rsemaphoreName\x12%\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\"f\n" +
+	"\x19GetSemaphoreStatsResponse\x12I\n" +
+	"\abuckets\x18\x01 \x03(\v2/.com.evrblk.grackle.corepb.SemaphoreStatsBucketR\abuckets\"\xe5\x01\n" +
+	"\x14SemaphoreStatsBucket\x12\x19\n" +
+	"\bstart_at\x18\x01 \x01(\x10R\astartAt\x12(\n" +
+	"\x10max_active_holds\x18\x02 \x01(\x03R\x0emaxActiveHolds\x120\n" +
+	"\x14average_active_holds\x18\x03 \x01(\x01R\x12averageActiveHolds\x12-\n" +
+	"\x12acquires_succeeded\x18\x04 \x01(\x03R\x11acquiresSucceeded\x12'\n" +
+	"\x0facquires_failed\x18\x05 \x01(\x03R\x0eacquiresFailed\"\xa6\x03\n" +
+	"\x14SemaphoreStatsRecord\x12I\n" +
+	"\fsemaphore_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\vsemaphoreId\x12\x19\n" +
+	"\bstart_at\x18\x02 \x01(\x10R\astartAt\x120\n" +
+	"\x14opening_active_holds\x18\x03 \x01(\x03R\x12openingActiveHolds\x12!\n" +
+	"\factive_holds\x18\x04 \x01(\x03R\vactiveHolds\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x10R\tupdatedAt\x12(\n" +
+	"\x10max_active_holds\x18\x06 \x01(\x03R\x0emaxActiveHolds\x122\n" +
+	"\x15active_holds_integral\x18\a \x01(\x03R\x13activeHoldsIntegral\x12-\n" +
+	"\x12acquires_succeeded\x18\b \x01(\x03R\x11acquiresSucceeded\x12'\n" +
+	"\x0facquires_failed\x18\t \x01(\x03R\x0eacquiresFailed\"\xd4\x01\n" +
 	"\x1aListSemaphoreLeasesRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12U\n" +
 	"\x10pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
@@ -2701,7 +4037,7 @@ const file_pkg_corepb_semaphores_proto_rawDesc = "" +
 	"\vmax_visited\x18\x03 \x01(\x03R\n" +
 	"maxVisited\x12<\n" +
 	"\x1bgc_record_holders_page_size\x18\x04 \x01(\x03R\x17gcRecordHoldersPageSize\"(\n" +
-	"&RunSemaphoresGarbageCollectionResponse\"\xb4\x04\n" +
+	"&RunSemaphoresGarbageCollectionResponse\"\xce\x06\n" +
 	"\tSemaphore\x126\n" +
 	"\x02id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -2717,20 +4053,51 @@ const file_pkg_corepb_semaphores_proto_rawDesc = "" +
 	"\x14active_holders_count\x18\n" +
 	" \x01(\x03R\x12activeHoldersCount\x12;\n" +
 	"\x1aearliest_holder_expires_at\x18\v \x01(\x10R\x17earliestHolderExpiresAt\x12(\n" +
-	"\x10last_activity_at\x18\f \x01(\x10R\x0elastActivityAt\x1a;\n" +
+	"\x10last_activity_at\x18\f \x01(\x10R\x0elastActivityAt\x12.\n" +
+	"\x13parent_semaphore_id\x18\r \x01(\x06R\x11parentSemaphoreId\x122\n" +
+	"\x15parent_semaphore_name\x18\x0e \x01(\tR\x13parentSemaphoreName\x12,\n" +
+	"\x12number_of_children\x18\x0f \x01(\x03R\x10numberOfChildren\x12C\n" +
+	"\aclasses\x18\x10 \x03(\v2).com.evrblk.grackle.corepb.SemaphoreClassR\aclasses\x12A\n" +
+	"\x1ddelete_inactive_after_seconds\x18\x11 \x01(\x03R\x1adeleteInactiveAfterSeconds\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb6\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"j\n" +
+	"\x12SemaphoreClassSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vmin_permits\x18\x02 \x01(\x03R\n" +
+	"minPermits\x12\x1f\n" +
+	"\vmax_permits\x18\x03 \x01(\x03R\n" +
+	"maxPermits\"\x89\x01\n" +
+	"\x0eSemaphoreClass\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vmin_permits\x18\x02 \x01(\x03R\n" +
+	"minPermits\x12\x1f\n" +
+	"\vmax_permits\x18\x03 \x01(\x03R\n" +
+	"maxPermits\x12!\n" +
+	"\factive_holds\x18\x04 \x01(\x03R\vactiveHolds\"\x9f\x03\n" +
 	"\x0fSemaphoreHolder\x12<\n" +
 	"\x02id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.SemaphoreHolderIdR\x02id\x12\x1b\n" +
 	"\tlocked_at\x18\x02 \x01(\x10R\blockedAt\x12\x1d\n" +
 	"\n" +
 	"expires_at\x18\x03 \x01(\x10R\texpiresAt\x12\x16\n" +
 	"\x06weight\x18\x04 \x01(\x03R\x06weight\x12T\n" +
-	"\bmetadata\x18\x05 \x03(\v28.com.evrblk.grackle.corepb.SemaphoreHolder.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x05 \x03(\v28.com.evrblk.grackle.corepb.SemaphoreHolder.MetadataEntryR\bmetadata\x12)\n" +
+	"\x10inherited_weight\x18\x06 \x01(\x03R\x0finheritedWeight\x12\x14\n" +
+	"\x05class\x18\a \x01(\tR\x05class\x12&\n" +
+	"\x0fhold_expires_at\x18\b \x01(\x10R\rholdExpiresAt\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x93\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x88\x02\n" +
+	"\x0fSemaphoreWaiter\x12I\n" +
+	"\fsemaphore_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\vsemaphoreId\x12\x19\n" +
+	"\blease_id\x18\x02 \x01(\x06R\aleaseId\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x03R\x06weight\x12\x14\n" +
+	"\x05class\x18\x04 \x01(\tR\x05class\x12\x1d\n" +
+	"\n" +
+	"process_id\x18\x05 \x01(\tR\tprocessId\x12#\n" +
+	"\rwaiting_since\x18\x06 \x01(\x10R\fwaitingSince\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x10R\texpiresAt\"\x93\x01\n" +
 	"\x11SemaphoreHolderId\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\x06R\taccountId\x12!\n" +
@@ -2741,11 +4108,12 @@ const file_pkg_corepb_semaphores_proto_rawDesc = "" +
 	"\n" +
 	"account_id\x18\x01 \x01(\x06R\taccountId\x12!\n" +
 	"\fnamespace_id\x18\x02 \x01(\x06R\vnamespaceId\x12!\n" +
-	"\fsemaphore_id\x18\x03 \x01(\x06R\vsemaphoreId\"\xd7\x01\n" +
+	"\fsemaphore_id\x18\x03 \x01(\x06R\vsemaphoreId\"\x87\x02\n" +
 	"!SemaphoresGarbageCollectionRecord\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x06R\x02id\x12K\n" +
 	"\fnamespace_id\x18\x02 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdH\x00R\vnamespaceId\x12K\n" +
-	"\fsemaphore_id\x18\x03 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdH\x00R\vsemaphoreIdB\b\n" +
+	"\fsemaphore_id\x18\x03 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdH\x00R\vsemaphoreId\x12.\n" +
+	"\x13parent_semaphore_id\x18\x04 \x01(\x06R\x11parentSemaphoreIdB\b\n" +
 	"\x06record\"o\n" +
 	"\x11SemaphoresCounter\x120\n" +
 	"\x14number_of_semaphores\x18\x01 \x01(\x03R\x12numberOfSemaphores\x12(\n" +
@@ -2753,7 +4121,10 @@ const file_pkg_corepb_semaphores_proto_rawDesc = "" +
 	"\x1aSemaphoresExpirationRecord\x12I\n" +
 	"\fsemaphore_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\vsemaphoreId\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\x02 \x01(\x10R\texpiresAtB&Z$github.com/evrblk/grackle/pkg/corepbb\x06proto3"
+	"expires_at\x18\x02 \x01(\x10R\texpiresAt\"\x82\x01\n" +
+	"\x18SemaphoresDeletionRecord\x12I\n" +
+	"\fsemaphore_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.SemaphoreIdR\vsemaphoreId\x12\x1b\n" +
+	"\tdelete_at\x18\x02 \x01(\x10R\bdeleteAtB&Z$github.com/evrblk/grackle/pkg/corepbb\x06proto3"
 
 var (
 	file_pkg_corepb_semaphores_proto_rawDescOnce sync.Once
@@ -2767,7 +4138,7 @@ func file_pkg_corepb_semaphores_proto_rawDescGZIP() []byte {
 	return file_pkg_corepb_semaphores_proto_rawDescData
 }
 
-var file_pkg_corepb_semaphores_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_pkg_corepb_semaphores_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_pkg_corepb_semaphores_proto_goTypes = []any{
 	(*CreateSemaphoreRequest)(nil),                 // 0: com.evrblk.grackle.corepb.CreateSemaphoreRequest
 	(*CreateSemaphoreResponse)(nil),                // 1: com.evrblk.grackle.corepb.CreateSemaphoreResponse
@@ -2783,109 +4154,143 @@ var file_pkg_corepb_semaphores_proto_goTypes = []any{
 	(*AcquireSemaphoreResponse)(nil),               // 11: com.evrblk.grackle.corepb.AcquireSemaphoreResponse
 	(*ReleaseSemaphoreRequest)(nil),                // 12: com.evrblk.grackle.corepb.ReleaseSemaphoreRequest
 	(*ReleaseSemaphoreResponse)(nil),               // 13: com.evrblk.grackle.corepb.ReleaseSemaphoreResponse
-	(*UpdateSemaphoreRequest)(nil),                 // 14: com.evrblk.grackle.corepb.UpdateSemaphoreRequest
-	(*UpdateSemaphoreResponse)(nil),                // 15: com.evrblk.grackle.corepb.UpdateSemaphoreResponse
-	(*DeleteSemaphoreRequest)(nil),                 // 16: com.evrblk.grackle.corepb.DeleteSemaphoreRequest
-	(*DeleteSemaphoreResponse)(nil),                // 17: com.evrblk.grackle.corepb.DeleteSemaphoreResponse
-	(*ListSemaphoreHoldersRequest)(nil),            // 18: com.evrblk.grackle.corepb.ListSemaphoreHoldersRequest
-	(*ListSemaphoreHoldersResponse)(nil),           // 19: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse
-	(*ListSemaphoreLeasesRequest)(nil),             // 20: com.evrblk.grackle.corepb.ListSemaphoreLeasesRequest
-	(*ListSemaphoreLeasesResponse)(nil),            // 21: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse
-	(*ListSemaphoreLeasesByProcessIdRequest)(nil),  // 22: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdRequest
-	(*ListSemaphoreLeasesByProcessIdResponse)(nil), // 23: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse
-	(*GetSemaphoreLeaseRequest)(nil),               // 24: com.evrblk.grackle.corepb.GetSemaphoreLeaseRequest
-	(*GetSemaphoreLeaseResponse)(nil),              // 25: com.evrblk.grackle.corepb.GetSemaphoreLeaseResponse
-	(*CreateSemaphoreLeaseRequest)(nil),            // 26: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest
-	(*CreateSemaphoreLeaseResponse)(nil),           // 27: com.evrblk.grackle.corepb.CreateSemaphoreLeaseResponse
-	(*RevokeSemaphoreLeaseRequest)(nil),            // 28: com.evrblk.grackle.corepb.RevokeSemaphoreLeaseRequest
-	(*RevokeSemaphoreLeaseResponse)(nil),           // 29: com.evrblk.grackle.corepb.RevokeSemaphoreLeaseResponse
-	(*RefreshSemaphoreLeaseRequest)(nil),           // 30: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseRequest
-	(*RefreshSemaphoreLeaseResponse)(nil),          // 31: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseResponse
-	(*SemaphoresDeleteNamespaceRequest)(nil),       // 32: com.evrblk.grackle.corepb.SemaphoresDeleteNamespaceRequest
-	(*SemaphoresDeleteNamespaceResponse)(nil),      // 33: com.evrblk.grackle.corepb.SemaphoresDeleteNamespaceResponse
-	(*RunSemaphoresGarbageCollectionRequest)(nil),  // 34: com.evrblk.grackle.corepb.RunSemaphoresGarbageCollectionRequest
-	(*RunSemaphoresGarbageCollectionResponse)(nil), // 35: com.evrblk.grackle.corepb.RunSemaphoresGarbageCollectionResponse
-	(*Semaphore)(nil),                              // 36: com.evrblk.grackle.corepb.Semaphore
-	(*SemaphoreHolder)(nil),                        // 37: com.evrblk.grackle.corepb.SemaphoreHolder
-	(*SemaphoreHolderId)(nil),                      // 38: com.evrblk.grackle.corepb.SemaphoreHolderId
-	(*SemaphoreId)(nil),                            // 39: com.evrblk.grackle.corepb.SemaphoreId
-	(*SemaphoresGarbageCollectionRecord)(nil),      // 40: com.evrblk.grackle.corepb.SemaphoresGarbageCollectionRecord
-	(*SemaphoresCounter)(nil),                      // 41: com.evrblk.grackle.corepb.SemaphoresCounter
-	(*SemaphoresExpirationRecord)(nil),             // 42: com.evrblk.grackle.corepb.SemaphoresExpirationRecord
-	nil,                                            // 43: com.evrblk.grackle.corepb.CreateSemaphoreRequest.MetadataEntry
-	nil,                                            // 44: com.evrblk.grackle.corepb.AcquireSemaphoreRequest.MetadataEntry
-	nil,                                            // 45: com.evrblk.grackle.corepb.UpdateSemaphoreRequest.MetadataEntry
-	nil,                                            // 46: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.MetadataEntry
-	nil,                                            // 47: com.evrblk.grackle.corepb.Semaphore.MetadataEntry
-	nil,                                            // 48: com.evrblk.grackle.corepb.SemaphoreHolder.MetadataEntry
-	(*NamespaceId)(nil),                            // 49: com.evrblk.grackle.corepb.NamespaceId
-	(*PaginationToken)(nil),                        // 50: com.evrblk.grackle.corepb.PaginationToken
-	(*LeaseId)(nil),                                // 51: com.evrblk.grackle.corepb.LeaseId
-	(*Lease)(nil),                                  // 52: com.evrblk.grackle.corepb.Lease
+	(*AdjustSemaphoreHoldRequest)(nil),             // 14: com.evrblk.grackle.corepb.AdjustSemaphoreHoldRequest
+	(*AdjustSemaphoreHoldResponse)(nil),            // 15: com.evrblk.grackle.corepb.AdjustSemaphoreHoldResponse
+	(*TransferSemaphoreHoldRequest)(nil),           // 16: com.evrblk.grackle.corepb.TransferSemaphoreHoldRequest
+	(*TransferSemaphoreHoldResponse)(nil),          // 17: com.evrblk.grackle.corepb.TransferSemaphoreHoldResponse
+	(*UpdateSemaphoreRequest)(nil),                 // 18: com.evrblk.grackle.corepb.UpdateSemaphoreRequest
+	(*UpdateSemaphoreResponse)(nil),                // 19: com.evrblk.grackle.corepb.UpdateSemaphoreResponse
+	(*DeleteSemaphoreRequest)(nil),                 // 20: com.evrblk.grackle.corepb.DeleteSemaphoreRequest
+	(*DeleteSemaphoreResponse)(nil),                // 21: com.evrblk.grackle.corepb.DeleteSemaphoreResponse
+	(*ListSemaphoreHoldersRequest)(nil),            // 22: com.evrblk.grackle.corepb.ListSemaphoreHoldersRequest
+	(*ListSemaphoreHoldersResponse)(nil),           // 23: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse
+	(*ListSemaphoreWaitersRequest)(nil),            // 24: com.evrblk.grackle.corepb.ListSemaphoreWaitersRequest
+	(*ListSemaphoreWaitersResponse)(nil),           // 25: com.evrblk.grackle.corepb.ListSemaphoreWaitersResponse
+	(*GetSemaphoreStatsRequest)(nil),               // 26: com.evrblk.grackle.corepb.GetSemaphoreStatsRequest
+	(*GetSemaphoreStatsResponse)(nil),              // 27: com.evrblk.grackle.corepb.GetSemaphoreStatsResponse
+	(*SemaphoreStatsBucket)(nil),                   // 28: com.evrblk.grackle.corepb.SemaphoreStatsBucket
+	(*SemaphoreStatsRecord)(nil),                   // 29: com.evrblk.grackle.corepb.SemaphoreStatsRecord
+	(*ListSemaphoreLeasesRequest)(nil),             // 30: com.evrblk.grackle.corepb.ListSemaphoreLeasesRequest
+	(*ListSemaphoreLeasesResponse)(nil),            // 31: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse
+	(*ListSemaphoreLeasesByProcessIdRequest)(nil),  // 32: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdRequest
+	(*ListSemaphoreLeasesByProcessIdResponse)(nil), // 33: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse
+	(*GetSemaphoreLeaseRequest)(nil),               // 34: com.evrblk.grackle.corepb.GetSemaphoreLeaseRequest
+	(*GetSemaphoreLeaseResponse)(nil),              // 35: com.evrblk.grackle.corepb.GetSemaphoreLeaseResponse
+	(*CreateSemaphoreLeaseRequest)(nil),            // 36: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest
+	(*CreateSemaphoreLeaseResponse)(nil),           // 37: com.evrblk.grackle.corepb.CreateSemaphoreLeaseResponse
+	(*RevokeSemaphoreLeaseRequest)(nil),            // 38: com.evrblk.grackle.corepb.RevokeSemaphoreLeaseRequest
+	(*RevokeSemaphoreLeaseResponse)(nil),           // 39: com.evrblk.grackle.corepb.RevokeSemaphoreLeaseResponse
+	(*RefreshSemaphoreLeaseRequest)(nil),           // 40: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseRequest
+	(*RefreshSemaphoreLeaseResponse)(nil),          // 41: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseResponse
+	(*SemaphoresDeleteNamespaceRequest)(nil),       // 42: com.evrblk.grackle.corepb.SemaphoresDeleteNamespaceRequest
+	(*SemaphoresDeleteNamespaceResponse)(nil),      // 43: com.evrblk.grackle.corepb.SemaphoresDeleteNamespaceResponse
+	(*RunSemaphoresGarbageCollectionRequest)(nil),  // 44: com.evrblk.grackle.corepb.RunSemaphoresGarbageCollectionRequest
+	(*RunSemaphoresGarbageCollectionResponse)(nil), // 45: com.evrblk.grackle.corepb.RunSemaphoresGarbageCollectionResponse
+	(*Semaphore)(nil),                              // 46: com.evrblk.grackle.corepb.Semaphore
+	(*SemaphoreClassSpec)(nil),                     // 47: com.evrblk.grackle.corepb.SemaphoreClassSpec
+	(*SemaphoreClass)(nil),                         // 48: com.evrblk.grackle.corepb.SemaphoreClass
+	(*SemaphoreHolder)(nil),                        // 49: com.evrblk.grackle.corepb.SemaphoreHolder
+	(*SemaphoreWaiter)(nil),                        // 50: com.evrblk.grackle.corepb.SemaphoreWaiter
+	(*SemaphoreHolderId)(nil),                      // 51: com.evrblk.grackle.corepb.SemaphoreHolderId
+	(*SemaphoreId)(nil),                            // 52: com.evrblk.grackle.corepb.SemaphoreId
+	(*SemaphoresGarbageCollectionRecord)(nil),      // 53: com.evrblk.grackle.corepb.SemaphoresGarbageCollectionRecord
+	(*SemaphoresCounter)(nil),                      // 54: com.evrblk.grackle.corepb.SemaphoresCounter
+	(*SemaphoresExpirationRecord)(nil),             // 55: com.evrblk.grackle.corepb.SemaphoresExpirationRecord
+	(*SemaphoresDeletionRecord)(nil),               // 56: com.evrblk.grackle.corepb.SemaphoresDeletionRecord
+	nil,                                            // 57: com.evrblk.grackle.corepb.CreateSemaphoreRequest.MetadataEntry
+	nil,                                            // 58: com.evrblk.grackle.corepb.AcquireSemaphoreRequest.MetadataEntry
+	nil,                                            // 59: com.evrblk.grackle.corepb.UpdateSemaphoreRequest.MetadataEntry
+	nil,                                            // 60: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.MetadataEntry
+	nil,                                            // 61: com.evrblk.grackle.corepb.Semaphore.MetadataEntry
+	nil,                                            // 62: com.evrblk.grackle.corepb.SemaphoreHolder.MetadataEntry
+	(*NamespaceId)(nil),                            // 63: com.evrblk.grackle.corepb.NamespaceId
+	(*PaginationToken)(nil),                        // 64: com.evrblk.grackle.corepb.PaginationToken
+	(*LeaseId)(nil),                                // 65: com.evrblk.grackle.corepb.LeaseId
+	(*Lease)(nil),                                  // 66: com.evrblk.grackle.corepb.Lease
 }
 var file_pkg_corepb_semaphores_proto_depIdxs = []int32{
-	39, // 0: com.evrblk.grackle.corepb.CreateSemaphoreRequest.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
-	43, // 1: com.evrblk.grackle.corepb.CreateSemaphoreRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateSemaphoreRequest.MetadataEntry
-	36, // 2: com.evrblk.grackle.corepb.CreateSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
-	49, // 3: com.evrblk.grackle.corepb.ListSemaphoresRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	50, // 4: com.evrblk.grackle.corepb.ListSemaphoresRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	36, // 5: com.evrblk.grackle.corepb.ListSemaphoresResponse.semaphores:type_name -> com.evrblk.grackle.corepb.Semaphore
-	50, // 6: com.evrblk.grackle.corepb.ListSemaphoresResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	50, // 7: com.evrblk.grackle.corepb.ListSemaphoresResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	51, // 8: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	50, // 9: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	36, // 10: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdResponse.semaphores:type_name -> com.evrblk.grackle.corepb.Semaphore
-	50, // 11: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	50, // 12: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	39, // 13: com.evrblk.grackle.corepb.GetSemaphoreRequest.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
-	36, // 14: com.evrblk.grackle.corepb.GetSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
-	49, // 15: com.evrblk.grackle.corepb.GetSemaphoreByNameRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	36, // 16: com.evrblk.grackle.corepb.GetSemaphoreByNameResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
-	49, // 17: com.evrblk.grackle.corepb.AcquireSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	44, // 18: com.evrblk.grackle.corepb.AcquireSemaphoreRequest.metadata:type_name -> com.evrblk.grackle.corepb.AcquireSemaphoreRequest.MetadataEntry
-	36, // 19: com.evrblk.grackle.corepb.AcquireSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
-	49, // 20: com.evrblk.grackle.corepb.ReleaseSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	36, // 21: com.evrblk.grackle.corepb.ReleaseSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
-	49, // 22: com.evrblk.grackle.corepb.UpdateSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	45, // 23: com.evrblk.grackle.corepb.UpdateSemaphoreRequest.metadata:type_name -> com.evrblk.grackle.corepb.UpdateSemaphoreRequest.MetadataEntry
-	36, // 24: com.evrblk.grackle.corepb.UpdateSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
-	49, // 25: com.evrblk.grackle.corepb.DeleteSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	49, // 26: com.evrblk.grackle.corepb.ListSemaphoreHoldersRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	50, // 27: com.evrblk.grackle.corepb.ListSemaphoreHoldersRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	37, // 28: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse.holders:type_name -> com.evrblk.grackle.corepb.SemaphoreHolder
-	50, // 29: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	50, // 30: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	49, // 31: com.evrblk.grackle.corepb.ListSemaphoreLeasesRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	50, // 32: com.evrblk.grackle.corepb.ListSemaphoreLeasesRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	52, // 33: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
-	50, // 34: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	50, // 35: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	49, // 36: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	50, // 37: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	52, // 38: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
-	50, // 39: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	50, // 40: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	51, // 41: com.evrblk.grackle.corepb.GetSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	52, // 42: com.evrblk.grackle.corepb.GetSemaphoreLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
-	51, // 43: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	46, // 44: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.MetadataEntry
-	52, // 45: com.evrblk.grackle.corepb.CreateSemaphoreLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
-	51, // 46: com.evrblk.grackle.corepb.RevokeSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	51, // 47: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
-	52, // 48: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
-	49, // 49: com.evrblk.grackle.corepb.SemaphoresDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	39, // 50: com.evrblk.grackle.corepb.Semaphore.id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
-	47, // 51: com.evrblk.grackle.corepb.Semaphore.metadata:type_name -> com.evrblk.grackle.corepb.Semaphore.MetadataEntry
-	38, // 52: com.evrblk.grackle.corepb.SemaphoreHolder.id:type_name -> com.evrblk.grackle.corepb.SemaphoreHolderId
-	48, // 53: com.evrblk.grackle.corepb.SemaphoreHolder.metadata:type_name -> com.evrblk.grackle.corepb.SemaphoreHolder.MetadataEntry
-	49, // 54: com.evrblk.grackle.corepb.SemaphoresGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	39, // 55: com.evrblk.grackle.corepb.SemaphoresGarbageCollectionRecord.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
-	39, // 56: com.evrblk.grackle.corepb.SemaphoresExpirationRecord.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
-	57, // [57:57] is the sub-list for method output_type
-	57, // [57:57] is the sub-list for method input_type
-	57, // [57:57] is the sub-list for extension type_name
-	57, // [57:57] is the sub-list for extension extendee
-	0,  // [0:57] is the sub-list for field type_name
+	52, // 0: com.evrblk.grackle.corepb.CreateSemaphoreRequest.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	57, // 1: com.evrblk.grackle.corepb.CreateSemaphoreRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateSemaphoreRequest.MetadataEntry
+	47, // 2: com.evrblk.grackle.corepb.CreateSemaphoreRequest.classes:type_name -> com.evrblk.grackle.corepb.SemaphoreClassSpec
+	46, // 3: com.evrblk.grackle.corepb.CreateSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	63, // 4: com.evrblk.grackle.corepb.ListSemaphoresRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	64, // 5: com.evrblk.grackle.corepb.ListSemaphoresRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	46, // 6: com.evrblk.grackle.corepb.ListSemaphoresResponse.semaphores:type_name -> com.evrblk.grackle.corepb.Semaphore
+	64, // 7: com.evrblk.grackle.corepb.ListSemaphoresResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	64, // 8: com.evrblk.grackle.corepb.ListSemaphoresResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	65, // 9: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	64, // 10: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	46, // 11: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdResponse.semaphores:type_name -> com.evrblk.grackle.corepb.Semaphore
+	64, // 12: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	64, // 13: com.evrblk.grackle.corepb.ListSemaphoresByLeaseIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	52, // 14: com.evrblk.grackle.corepb.GetSemaphoreRequest.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	46, // 15: com.evrblk.grackle.corepb.GetSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	63, // 16: com.evrblk.grackle.corepb.GetSemaphoreByNameRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 17: com.evrblk.grackle.corepb.GetSemaphoreByNameResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	63, // 18: com.evrblk.grackle.corepb.AcquireSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	58, // 19: com.evrblk.grackle.corepb.AcquireSemaphoreRequest.metadata:type_name -> com.evrblk.grackle.corepb.AcquireSemaphoreRequest.MetadataEntry
+	46, // 20: com.evrblk.grackle.corepb.AcquireSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	49, // 21: com.evrblk.grackle.corepb.AcquireSemaphoreResponse.blocking_holders:type_name -> com.evrblk.grackle.corepb.SemaphoreHolder
+	63, // 22: com.evrblk.grackle.corepb.ReleaseSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 23: com.evrblk.grackle.corepb.ReleaseSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	63, // 24: com.evrblk.grackle.corepb.AdjustSemaphoreHoldRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 25: com.evrblk.grackle.corepb.AdjustSemaphoreHoldResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	49, // 26: com.evrblk.grackle.corepb.AdjustSemaphoreHoldResponse.holder:type_name -> com.evrblk.grackle.corepb.SemaphoreHolder
+	63, // 27: com.evrblk.grackle.corepb.TransferSemaphoreHoldRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 28: com.evrblk.grackle.corepb.TransferSemaphoreHoldResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	49, // 29: com.evrblk.grackle.corepb.TransferSemaphoreHoldResponse.holder:type_name -> com.evrblk.grackle.corepb.SemaphoreHolder
+	63, // 30: com.evrblk.grackle.corepb.UpdateSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	59, // 31: com.evrblk.grackle.corepb.UpdateSemaphoreRequest.metadata:type_name -> com.evrblk.grackle.corepb.UpdateSemaphoreRequest.MetadataEntry
+	46, // 32: com.evrblk.grackle.corepb.UpdateSemaphoreResponse.semaphore:type_name -> com.evrblk.grackle.corepb.Semaphore
+	63, // 33: com.evrblk.grackle.corepb.DeleteSemaphoreRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	63, // 34: com.evrblk.grackle.corepb.ListSemaphoreHoldersRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	64, // 35: com.evrblk.grackle.corepb.ListSemaphoreHoldersRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	49, // 36: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse.holders:type_name -> com.evrblk.grackle.corepb.SemaphoreHolder
+	64, // 37: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	64, // 38: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	48, // 39: com.evrblk.grackle.corepb.ListSemaphoreHoldersResponse.classes:type_name -> com.evrblk.grackle.corepb.SemaphoreClass
+	63, // 40: com.evrblk.grackle.corepb.ListSemaphoreWaitersRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	64, // 41: com.evrblk.grackle.corepb.ListSemaphoreWaitersRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	50, // 42: com.evrblk.grackle.corepb.ListSemaphoreWaitersResponse.waiters:type_name -> com.evrblk.grackle.corepb.SemaphoreWaiter
+	64, // 43: com.evrblk.grackle.corepb.ListSemaphoreWaitersResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	64, // 44: com.evrblk.grackle.corepb.ListSemaphoreWaitersResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	63, // 45: com.evrblk.grackle.corepb.GetSemaphoreStatsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	28, // 46: com.evrblk.grackle.corepb.GetSemaphoreStatsResponse.buckets:type_name -> com.evrblk.grackle.corepb.SemaphoreStatsBucket
+	52, // 47: com.evrblk.grackle.corepb.SemaphoreStatsRecord.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	63, // 48: com.evrblk.grackle.corepb.ListSemaphoreLeasesRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	64, // 49: com.evrblk.grackle.corepb.ListSemaphoreLeasesRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	66, // 50: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
+	64, // 51: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	64, // 52: com.evrblk.grackle.corepb.ListSemaphoreLeasesResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	63, // 53: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	64, // 54: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	66, // 55: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse.leases:type_name -> com.evrblk.grackle.corepb.Lease
+	64, // 56: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	64, // 57: com.evrblk.grackle.corepb.ListSemaphoreLeasesByProcessIdResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	65, // 58: com.evrblk.grackle.corepb.GetSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	66, // 59: com.evrblk.grackle.corepb.GetSemaphoreLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
+	65, // 60: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	60, // 61: com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateSemaphoreLeaseRequest.MetadataEntry
+	66, // 62: com.evrblk.grackle.corepb.CreateSemaphoreLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
+	65, // 63: com.evrblk.grackle.corepb.RevokeSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	65, // 64: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseRequest.lease_id:type_name -> com.evrblk.grackle.corepb.LeaseId
+	66, // 65: com.evrblk.grackle.corepb.RefreshSemaphoreLeaseResponse.lease:type_name -> com.evrblk.grackle.corepb.Lease
+	63, // 66: com.evrblk.grackle.corepb.SemaphoresDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	52, // 67: com.evrblk.grackle.corepb.Semaphore.id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	61, // 68: com.evrblk.grackle.corepb.Semaphore.metadata:type_name -> com.evrblk.grackle.corepb.Semaphore.MetadataEntry
+	48, // 69: com.evrblk.grackle.corepb.Semaphore.classes:type_name -> com.evrblk.grackle.corepb.SemaphoreClass
+	51, // 70: com.evrblk.grackle.corepb.SemaphoreHolder.id:type_name -> com.evrblk.grackle.corepb.SemaphoreHolderId
+	62, // 71: com.evrblk.grackle.corepb.SemaphoreHolder.metadata:type_name -> com.evrblk.grackle.corepb.SemaphoreHolder.MetadataEntry
+	52, // 72: com.evrblk.grackle.corepb.SemaphoreWaiter.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	63, // 73: com.evrblk.grackle.corepb.SemaphoresGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	52, // 74: com.evrblk.grackle.corepb.SemaphoresGarbageCollectionRecord.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	52, // 75: com.evrblk.grackle.corepb.SemaphoresExpirationRecord.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	52, // 76: com.evrblk.grackle.corepb.SemaphoresDeletionRecord.semaphore_id:type_name -> com.evrblk.grackle.corepb.SemaphoreId
+	77, // [77:77] is the sub-list for method output_type
+	77, // [77:77] is the sub-list for method input_type
+	77, // [77:77] is the sub-list for extension type_name
+	77, // [77:77] is the sub-list for extension extendee
+	0,  // [0:77] is the sub-list for field type_name
 }
 
 func init() { file_pkg_corepb_semaphores_proto_init() }
@@ -2895,7 +4300,7 @@ func file_pkg_corepb_semaphores_proto_init() {
 	}
 	file_pkg_corepb_common_proto_init()
 	file_pkg_corepb_namespaces_proto_init()
-	file_pkg_corepb_semaphores_proto_msgTypes[40].OneofWrappers = []any{
+	file_pkg_corepb_semaphores_proto_msgTypes[53].OneofWrappers = []any{
 		(*SemaphoresGarbageCollectionRecord_NamespaceId)(nil),
 		(*SemaphoresGarbageCollectionRecord_SemaphoreId)(nil),
 	}
@@ -2905,7 +4310,7 @@ func file_pkg_corepb_semaphores_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_corepb_semaphores_proto_rawDesc), len(file_pkg_corepb_semaphores_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   49,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
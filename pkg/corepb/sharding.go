@@ -65,6 +65,12 @@ func (r *AcquireSemaphoreRequest) ShardKey() cluster.ShardKey {
 	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
 }
 
+// AdjustSemaphoreHoldRequest
+
+func (r *AdjustSemaphoreHoldRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
 // CompleteJobsFromWaitGroupRequest
 
 func (r *CompleteJobsFromWaitGroupRequest) ShardKey() cluster.ShardKey {
@@ -119,6 +125,12 @@ func (r *GetLockRequest) ShardKey() cluster.ShardKey {
 	return sharding.ByAccountAndNamespace(r.LockId.AccountId, r.LockId.NamespaceId)
 }
 
+// ListLockWaitersRequest
+
+func (r *ListLockWaitersRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.LockId.AccountId, r.LockId.NamespaceId)
+}
+
 // GetNamespaceRequest
 
 func (r *GetNamespaceRequest) ShardKey() cluster.ShardKey {
@@ -197,6 +209,18 @@ func (r *ListSemaphoreHoldersRequest) ShardKey() cluster.ShardKey {
 	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
 }
 
+// ListSemaphoreWaitersRequest
+
+func (r *ListSemaphoreWaitersRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
+// GetSemaphoreStatsRequest
+
+func (r *GetSemaphoreStatsRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
 // CreateSemaphoreLeaseRequest
 
 func (r *CreateSemaphoreLeaseRequest) ShardKey() cluster.ShardKey {
@@ -227,6 +251,72 @@ func (r *ListWaitGroupCompletedJobsRequest) ShardKey() cluster.ShardKey {
 	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
 }
 
+// ListWaitGroupPendingJobsRequest
+
+func (r *ListWaitGroupPendingJobsRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
+// AddToWaitGroupRequest
+
+func (r *AddToWaitGroupRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
+// CancelWaitGroupRequest
+
+func (r *CancelWaitGroupRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
+// CompleteJobsFromWaitGroupStripeRequest
+
+func (r *CompleteJobsFromWaitGroupStripeRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByWaitGroupStripe(r.StripeId.AccountId, r.StripeId.NamespaceId, r.StripeId.WaitGroupId, r.StripeId.Stripe)
+}
+
+// AggregateWaitGroupStripesRequest
+
+func (r *AggregateWaitGroupStripesRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.WaitGroupId.AccountId, r.WaitGroupId.NamespaceId)
+}
+
+// GetWaitGroupStripeRequest
+
+func (r *GetWaitGroupStripeRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByWaitGroupStripe(r.StripeId.AccountId, r.StripeId.NamespaceId, r.StripeId.WaitGroupId, r.StripeId.Stripe)
+}
+
+// ExtendWaitGroupStripeRequest
+
+func (r *ExtendWaitGroupStripeRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByWaitGroupStripe(r.StripeId.AccountId, r.StripeId.NamespaceId, r.StripeId.WaitGroupId, r.StripeId.Stripe)
+}
+
+// DeleteWaitGroupStripeRequest
+
+func (r *DeleteWaitGroupStripeRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByWaitGroupStripe(r.StripeId.AccountId, r.StripeId.NamespaceId, r.StripeId.WaitGroupId, r.StripeId.Stripe)
+}
+
+// ListWaitGroupStripeCompletedJobsRequest
+
+func (r *ListWaitGroupStripeCompletedJobsRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByWaitGroupStripe(r.StripeId.AccountId, r.StripeId.NamespaceId, r.StripeId.WaitGroupId, r.StripeId.Stripe)
+}
+
+// UncompleteJobsFromWaitGroupRequest
+
+func (r *UncompleteJobsFromWaitGroupRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
+// AddJobsToWaitGroupRequest
+
+func (r *AddJobsToWaitGroupRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
 // UpdateWaitGroupRequest
 
 func (r *UpdateWaitGroupRequest) ShardKey() cluster.ShardKey {
@@ -245,6 +335,18 @@ func (r *ReleaseSemaphoreRequest) ShardKey() cluster.ShardKey {
 	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
 }
 
+// TransferLockRequest
+
+func (r *TransferLockRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.LockId.AccountId, r.LockId.NamespaceId)
+}
+
+// TransferSemaphoreHoldRequest
+
+func (r *TransferSemaphoreHoldRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
 // UpdateNamespaceRequest
 
 func (r *UpdateNamespaceRequest) ShardKey() cluster.ShardKey {
@@ -299,6 +401,12 @@ func (r *ListBarrierParticipantsRequest) ShardKey() cluster.ShardKey {
 	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
 }
 
+// ListBarrierGenerationsRequest
+
+func (r *ListBarrierGenerationsRequest) ShardKey() cluster.ShardKey {
+	return sharding.ByAccountAndNamespace(r.NamespaceId.AccountId, r.NamespaceId.NamespaceId)
+}
+
 // CreateBarrierRequest
 
 func (r *CreateBarrierRequest) ShardKey() cluster.ShardKey {
@@ -21,12 +21,65 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WaitGroupAggregationFunction int32
+
+const (
+	WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_INVALID WaitGroupAggregationFunction = 0
+	WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM     WaitGroupAggregationFunction = 1
+	WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN     WaitGroupAggregationFunction = 2
+	WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX     WaitGroupAggregationFunction = 3
+)
+
+// Enum value maps for WaitGroupAggregationFunction.
+var (
+	WaitGroupAggregationFunction_name = map[int32]string{
+		0: "WAIT_GROUP_AGGREGATION_FUNCTION_INVALID",
+		1: "WAIT_GROUP_AGGREGATION_FUNCTION_SUM",
+		2: "WAIT_GROUP_AGGREGATION_FUNCTION_MIN",
+		3: "WAIT_GROUP_AGGREGATION_FUNCTION_MAX",
+	}
+	WaitGroupAggregationFunction_value = map[string]int32{
+		"WAIT_GROUP_AGGREGATION_FUNCTION_INVALID": 0,
+		"WAIT_GROUP_AGGREGATION_FUNCTION_SUM":     1,
+		"WAIT_GROUP_AGGREGATION_FUNCTION_MIN":     2,
+		"WAIT_GROUP_AGGREGATION_FUNCTION_MAX":     3,
+	}
+)
+
+func (x WaitGroupAggregationFunction) Enum() *WaitGroupAggregationFunction {
+	p := new(WaitGroupAggregationFunction)
+	*p = x
+	return p
+}
+
+func (x WaitGroupAggregationFunction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WaitGroupAggregationFunction) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_corepb_wait_groups_proto_enumTypes[0].Descriptor()
+}
+
+func (WaitGroupAggregationFunction) Type() protoreflect.EnumType {
+	return &file_pkg_corepb_wait_groups_proto_enumTypes[0]
+}
+
+func (x WaitGroupAggregationFunction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WaitGroupAggregationFunction.Descriptor instead.
+func (WaitGroupAggregationFunction) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{0}
+}
+
 // WaitGroupStatus is the lifecycle state of a wait group. A wait group starts
-// ACTIVE. It becomes COMPLETED once the number of completed jobs reaches the
-// counter, or EXPIRED once expires_at passes while it is still active. Both
-// COMPLETED and EXPIRED are terminal "finished" states after which the wait
-// group is deleted by garbage collection once delete_after_finished_seconds
-// has elapsed.
+// ACTIVE. It becomes COMPLETED once the number of completed and failed jobs
+// reaches the counter, FAILED once the number of failed jobs exceeds
+// max_failed_jobs, EXPIRED once expires_at passes while it is still active, or
+// CANCELLED when it is cancelled via CancelWaitGroup. COMPLETED, FAILED, EXPIRED
+// and CANCELLED are terminal "finished" states after which the wait group is
+// deleted by garbage collection once delete_after_finished_seconds has elapsed.
 type WaitGroupStatus int32
 
 const (
@@ -34,6 +87,8 @@ const (
 	WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE    WaitGroupStatus = 1
 	WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED   WaitGroupStatus = 2
 	WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED WaitGroupStatus = 3
+	WaitGroupStatus_WAIT_GROUP_STATUS_FAILED    WaitGroupStatus = 4
+	WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED WaitGroupStatus = 5
 )
 
 // Enum value maps for WaitGroupStatus.
@@ -43,12 +98,16 @@ var (
 		1: "WAIT_GROUP_STATUS_ACTIVE",
 		2: "WAIT_GROUP_STATUS_EXPIRED",
 		3: "WAIT_GROUP_STATUS_COMPLETED",
+		4: "WAIT_GROUP_STATUS_FAILED",
+		5: "WAIT_GROUP_STATUS_CANCELLED",
 	}
 	WaitGroupStatus_value = map[string]int32{
 		"WAIT_GROUP_STATUS_INVALID":   0,
 		"WAIT_GROUP_STATUS_ACTIVE":    1,
 		"WAIT_GROUP_STATUS_EXPIRED":   2,
 		"WAIT_GROUP_STATUS_COMPLETED": 3,
+		"WAIT_GROUP_STATUS_FAILED":    4,
+		"WAIT_GROUP_STATUS_CANCELLED": 5,
 	}
 )
 
@@ -63,11 +122,11 @@ func (x WaitGroupStatus) String() string {
 }
 
 func (WaitGroupStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_corepb_wait_groups_proto_enumTypes[0].Descriptor()
+	return file_pkg_corepb_wait_groups_proto_enumTypes[1].Descriptor()
 }
 
 func (WaitGroupStatus) Type() protoreflect.EnumType {
-	return &file_pkg_corepb_wait_groups_proto_enumTypes[0]
+	return &file_pkg_corepb_wait_groups_proto_enumTypes[1]
 }
 
 func (x WaitGroupStatus) Number() protoreflect.EnumNumber {
@@ -76,7 +135,67 @@ func (x WaitGroupStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use WaitGroupStatus.Descriptor instead.
 func (WaitGroupStatus) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{0}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{1}
+}
+
+// WaitGroupChildFailurePolicy decides how a child wait group that finished
+// unsuccessfully (EXPIRED, FAILED or CANCELLED) is reported to its parent. A
+// COMPLETED child is always reported as a completed job.
+type WaitGroupChildFailurePolicy int32
+
+const (
+	WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID WaitGroupChildFailurePolicy = 0
+	// Report the child as a failed job, counting toward the parent's
+	// failed_jobs and max_failed_jobs.
+	WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED WaitGroupChildFailurePolicy = 1
+	// Report the child as a completed job anyway.
+	WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED WaitGroupChildFailurePolicy = 2
+	// Do not report the child at all; the parent then cannot complete through
+	// this child.
+	WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE WaitGroupChildFailurePolicy = 3
+)
+
+// Enum value maps for WaitGroupChildFailurePolicy.
+var (
+	WaitGroupChildFailurePolicy_name = map[int32]string{
+		0: "WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID",
+		1: "WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED",
+		2: "WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED",
+		3: "WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE",
+	}
+	WaitGroupChildFailurePolicy_value = map[string]int32{
+		"WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID":          0,
+		"WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED":    1,
+		"WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED": 2,
+		"WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE":           3,
+	}
+)
+
+func (x WaitGroupChildFailurePolicy) Enum() *WaitGroupChildFailurePolicy {
+	p := new(WaitGroupChildFailurePolicy)
+	*p = x
+	return p
+}
+
+func (x WaitGroupChildFailurePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WaitGroupChildFailurePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_corepb_wait_groups_proto_enumTypes[2].Descriptor()
+}
+
+func (WaitGroupChildFailurePolicy) Type() protoreflect.EnumType {
+	return &file_pkg_corepb_wait_groups_proto_enumTypes[2]
+}
+
+func (x WaitGroupChildFailurePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WaitGroupChildFailurePolicy.Descriptor instead.
+func (WaitGroupChildFailurePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{2}
 }
 
 type CreateWaitGroupRequest struct {
@@ -96,8 +215,30 @@ type CreateWaitGroupRequest struct {
 	// Retention period: once finished, the group is auto-deleted this many seconds
 	// after finished_at.
 	DeleteAfterFinishedSeconds int64 `protobuf:"varint,8,opt,name=delete_after_finished_seconds,json=deleteAfterFinishedSeconds,proto3" json:"delete_after_finished_seconds,omitempty"`
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+	// Number of failed jobs tolerated; the group becomes FAILED as soon as
+	// failed_jobs exceeds it. Unset means failures never fail the group.
+	MaxFailedJobs *int64 `protobuf:"varint,9,opt,name=max_failed_jobs,json=maxFailedJobs,proto3,oneof" json:"max_failed_jobs,omitempty"`
+	// Makes the group track a declared set of job ids, registered in batches via
+	// AddJobsToWaitGroup. Completing a job id that was not declared is rejected.
+	DeclaredJobs bool `protobuf:"varint,10,opt,name=declared_jobs,json=declaredJobs,proto3" json:"declared_jobs,omitempty"`
+	// Name of a parent wait group in the same namespace. Once this wait group
+	// finishes it reports itself as a job (named after this wait group) on the
+	// parent. Empty for a top-level wait group.
+	ParentWaitGroupName string `protobuf:"bytes,11,opt,name=parent_wait_group_name,json=parentWaitGroupName,proto3" json:"parent_wait_group_name,omitempty"`
+	// How an EXPIRED, FAILED or CANCELLED wait group is reported to its parent.
+	ChildFailurePolicy WaitGroupChildFailurePolicy `protobuf:"varint,12,opt,name=child_failure_policy,json=childFailurePolicy,proto3,enum=com.evrblk.grackle.corepb.WaitGroupChildFailurePolicy" json:"child_failure_policy,omitempty"`
+	// Running aggregates to maintain over an integer metadata value of the jobs.
+	Aggregations []*WaitGroupAggregationSpec `protobuf:"bytes,13,rep,name=aggregations,proto3" json:"aggregations,omitempty"`
+	// Makes the group use integer job ids 0..counter-1, tracked in compact job
+	// chunks instead of one row per job. Cannot be combined with declared_jobs.
+	IntegerJobIds bool `protobuf:"varint,14,opt,name=integer_job_ids,json=integerJobIds,proto3" json:"integer_job_ids,omitempty"`
+	// Spreads job completions over this many stripes, each of them a separate
+	// sub-counter on the shard chosen by ByWaitGroupStripe. 0 means the wait group
+	// is not striped. Cannot be combined with declared_jobs, integer_job_ids or
+	// aggregations.
+	Stripes       uint32 `protobuf:"varint,15,opt,name=stripes,proto3" json:"stripes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateWaitGroupRequest) Reset() {
@@ -186,6 +327,55 @@ func (x *CreateWaitGroupRequest) GetDeleteAfterFinishedSeconds() int64 {
 	return 0
 }
 
+func (x *CreateWaitGroupRequest) GetMaxFailedJobs() int64 {
+	if x != nil && x.MaxFailedJobs != nil {
+		return *x.MaxFailedJobs
+	}
+	return 0
+}
+
+func (x *CreateWaitGroupRequest) GetDeclaredJobs() bool {
+	if x != nil {
+		return x.DeclaredJobs
+	}
+	return false
+}
+
+func (x *CreateWaitGroupRequest) GetParentWaitGroupName() string {
+	if x != nil {
+		return x.ParentWaitGroupName
+	}
+	return ""
+}
+
+func (x *CreateWaitGroupRequest) GetChildFailurePolicy() WaitGroupChildFailurePolicy {
+	if x != nil {
+		return x.ChildFailurePolicy
+	}
+	return WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID
+}
+
+func (x *CreateWaitGroupRequest) GetAggregations() []*WaitGroupAggregationSpec {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+func (x *CreateWaitGroupRequest) GetIntegerJobIds() bool {
+	if x != nil {
+		return x.IntegerJobIds
+	}
+	return false
+}
+
+func (x *CreateWaitGroupRequest) GetStripes() uint32 {
+	if x != nil {
+		return x.Stripes
+	}
+	return 0
+}
+
 type CreateWaitGroupResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WaitGroup     *WaitGroup             `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
@@ -680,6 +870,110 @@ func (x *GetWaitGroupByNameResponse) GetWaitGroup() *WaitGroup {
 	return nil
 }
 
+type CancelWaitGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupName string                 `protobuf:"bytes,2,opt,name=wait_group_name,json=waitGroupName,proto3" json:"wait_group_name,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWaitGroupRequest) Reset() {
+	*x = CancelWaitGroupRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWaitGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWaitGroupRequest) ProtoMessage() {}
+
+func (x *CancelWaitGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWaitGroupRequest.ProtoReflect.Descriptor instead.
+func (*CancelWaitGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelWaitGroupRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *CancelWaitGroupRequest) GetWaitGroupName() string {
+	if x != nil {
+		return x.WaitGroupName
+	}
+	return ""
+}
+
+func (x *CancelWaitGroupRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelWaitGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitGroup     *WaitGroup             `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWaitGroupResponse) Reset() {
+	*x = CancelWaitGroupResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWaitGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWaitGroupResponse) ProtoMessage() {}
+
+func (x *CancelWaitGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWaitGroupResponse.ProtoReflect.Descriptor instead.
+func (*CancelWaitGroupResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelWaitGroupResponse) GetWaitGroup() *WaitGroup {
+	if x != nil {
+		return x.WaitGroup
+	}
+	return nil
+}
+
 type DeleteWaitGroupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -693,7 +987,7 @@ type DeleteWaitGroupRequest struct {
 
 func (x *DeleteWaitGroupRequest) Reset() {
 	*x = DeleteWaitGroupRequest{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[10]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -705,7 +999,7 @@ func (x *DeleteWaitGroupRequest) String() string {
 func (*DeleteWaitGroupRequest) ProtoMessage() {}
 
 func (x *DeleteWaitGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[10]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -718,7 +1012,7 @@ func (x *DeleteWaitGroupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteWaitGroupRequest.ProtoReflect.Descriptor instead.
 func (*DeleteWaitGroupRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{10}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteWaitGroupRequest) GetNamespaceId() *NamespaceId {
@@ -743,14 +1037,17 @@ func (x *DeleteWaitGroupRequest) GetRecordId() uint64 {
 }
 
 type DeleteWaitGroupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The deleted wait group, unset if it did not exist. The stripes of a striped
+	// wait group are to be deleted with DeleteWaitGroupStripe.
+	WaitGroup     *WaitGroup `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteWaitGroupResponse) Reset() {
 	*x = DeleteWaitGroupResponse{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[11]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -762,7 +1059,7 @@ func (x *DeleteWaitGroupResponse) String() string {
 func (*DeleteWaitGroupResponse) ProtoMessage() {}
 
 func (x *DeleteWaitGroupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[11]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -775,7 +1072,14 @@ func (x *DeleteWaitGroupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteWaitGroupResponse.ProtoReflect.Descriptor instead.
 func (*DeleteWaitGroupResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{11}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteWaitGroupResponse) GetWaitGroup() *WaitGroup {
+	if x != nil {
+		return x.WaitGroup
+	}
+	return nil
 }
 
 type CompleteJobsFromWaitGroupRequest struct {
@@ -789,7 +1093,7 @@ type CompleteJobsFromWaitGroupRequest struct {
 
 func (x *CompleteJobsFromWaitGroupRequest) Reset() {
 	*x = CompleteJobsFromWaitGroupRequest{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[12]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -801,7 +1105,7 @@ func (x *CompleteJobsFromWaitGroupRequest) String() string {
 func (*CompleteJobsFromWaitGroupRequest) ProtoMessage() {}
 
 func (x *CompleteJobsFromWaitGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[12]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -814,7 +1118,7 @@ func (x *CompleteJobsFromWaitGroupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteJobsFromWaitGroupRequest.ProtoReflect.Descriptor instead.
 func (*CompleteJobsFromWaitGroupRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{12}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CompleteJobsFromWaitGroupRequest) GetNamespaceId() *NamespaceId {
@@ -839,16 +1143,19 @@ func (x *CompleteJobsFromWaitGroupRequest) GetJobs() []*CompleteJobRequest {
 }
 
 type CompleteJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	JobId    string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Metadata map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Reports the job as failed rather than succeeded; it counts toward
+	// failed_jobs instead of completed_jobs.
+	Failed        bool `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CompleteJobRequest) Reset() {
 	*x = CompleteJobRequest{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[13]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -860,7 +1167,7 @@ func (x *CompleteJobRequest) String() string {
 func (*CompleteJobRequest) ProtoMessage() {}
 
 func (x *CompleteJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[13]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -873,7 +1180,7 @@ func (x *CompleteJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteJobRequest.ProtoReflect.Descriptor instead.
 func (*CompleteJobRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{13}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CompleteJobRequest) GetJobId() string {
@@ -890,16 +1197,25 @@ func (x *CompleteJobRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *CompleteJobRequest) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
 type CompleteJobsFromWaitGroupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WaitGroup     *WaitGroup             `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A striped wait group is returned untouched; its jobs are to be completed on
+	// its stripes with CompleteJobsFromWaitGroupStripe.
+	WaitGroup     *WaitGroup `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CompleteJobsFromWaitGroupResponse) Reset() {
 	*x = CompleteJobsFromWaitGroupResponse{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[14]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -911,7 +1227,7 @@ func (x *CompleteJobsFromWaitGroupResponse) String() string {
 func (*CompleteJobsFromWaitGroupResponse) ProtoMessage() {}
 
 func (x *CompleteJobsFromWaitGroupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[14]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,7 +1240,7 @@ func (x *CompleteJobsFromWaitGroupResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use CompleteJobsFromWaitGroupResponse.ProtoReflect.Descriptor instead.
 func (*CompleteJobsFromWaitGroupResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{14}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CompleteJobsFromWaitGroupResponse) GetWaitGroup() *WaitGroup {
@@ -934,31 +1250,35 @@ func (x *CompleteJobsFromWaitGroupResponse) GetWaitGroup() *WaitGroup {
 	return nil
 }
 
-type ListWaitGroupCompletedJobsRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
-	WaitGroupName   string                 `protobuf:"bytes,2,opt,name=wait_group_name,json=waitGroupName,proto3" json:"wait_group_name,omitempty"`
-	PaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
-	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+type CompleteJobsFromWaitGroupStripeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	StripeId *WaitGroupStripeId     `protobuf:"bytes,1,opt,name=stripe_id,json=stripeId,proto3" json:"stripe_id,omitempty"`
+	Jobs     []*CompleteJobRequest  `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Earliest time the stripe may be deleted, the latest time its wait group can
+	// be deleted: expires_at + delete_after_finished_seconds.
+	DeleteAt int64 `protobuf:"fixed64,3,opt,name=delete_at,json=deleteAt,proto3" json:"delete_at,omitempty"`
+	// Most jobs the stripe may hold (completed_jobs + failed_jobs): the wait
+	// group counter minus the jobs already aggregated from its other stripes.
+	MaxFinishedJobs int64 `protobuf:"varint,4,opt,name=max_finished_jobs,json=maxFinishedJobs,proto3" json:"max_finished_jobs,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ListWaitGroupCompletedJobsRequest) Reset() {
-	*x = ListWaitGroupCompletedJobsRequest{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[15]
+func (x *CompleteJobsFromWaitGroupStripeRequest) Reset() {
+	*x = CompleteJobsFromWaitGroupStripeRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWaitGroupCompletedJobsRequest) String() string {
+func (x *CompleteJobsFromWaitGroupStripeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWaitGroupCompletedJobsRequest) ProtoMessage() {}
+func (*CompleteJobsFromWaitGroupStripeRequest) ProtoMessage() {}
 
-func (x *ListWaitGroupCompletedJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[15]
+func (x *CompleteJobsFromWaitGroupStripeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -969,63 +1289,61 @@ func (x *ListWaitGroupCompletedJobsRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWaitGroupCompletedJobsRequest.ProtoReflect.Descriptor instead.
-func (*ListWaitGroupCompletedJobsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use CompleteJobsFromWaitGroupStripeRequest.ProtoReflect.Descriptor instead.
+func (*CompleteJobsFromWaitGroupStripeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListWaitGroupCompletedJobsRequest) GetNamespaceId() *NamespaceId {
+func (x *CompleteJobsFromWaitGroupStripeRequest) GetStripeId() *WaitGroupStripeId {
 	if x != nil {
-		return x.NamespaceId
+		return x.StripeId
 	}
 	return nil
 }
 
-func (x *ListWaitGroupCompletedJobsRequest) GetWaitGroupName() string {
+func (x *CompleteJobsFromWaitGroupStripeRequest) GetJobs() []*CompleteJobRequest {
 	if x != nil {
-		return x.WaitGroupName
+		return x.Jobs
 	}
-	return ""
+	return nil
 }
 
-func (x *ListWaitGroupCompletedJobsRequest) GetPaginationToken() *PaginationToken {
+func (x *CompleteJobsFromWaitGroupStripeRequest) GetDeleteAt() int64 {
 	if x != nil {
-		return x.PaginationToken
+		return x.DeleteAt
 	}
-	return nil
+	return 0
 }
 
-func (x *ListWaitGroupCompletedJobsRequest) GetLimit() int32 {
+func (x *CompleteJobsFromWaitGroupStripeRequest) GetMaxFinishedJobs() int64 {
 	if x != nil {
-		return x.Limit
+		return x.MaxFinishedJobs
 	}
 	return 0
 }
 
-type ListWaitGroupCompletedJobsResponse struct {
-	state                   protoimpl.MessageState `protogen:"open.v1"`
-	Jobs                    []*WaitGroupJob        `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
-	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+type CompleteJobsFromWaitGroupStripeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stripe        *WaitGroupStripe       `protobuf:"bytes,1,opt,name=stripe,proto3" json:"stripe,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListWaitGroupCompletedJobsResponse) Reset() {
-	*x = ListWaitGroupCompletedJobsResponse{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[16]
+func (x *CompleteJobsFromWaitGroupStripeResponse) Reset() {
+	*x = CompleteJobsFromWaitGroupStripeResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWaitGroupCompletedJobsResponse) String() string {
+func (x *CompleteJobsFromWaitGroupStripeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWaitGroupCompletedJobsResponse) ProtoMessage() {}
+func (*CompleteJobsFromWaitGroupStripeResponse) ProtoMessage() {}
 
-func (x *ListWaitGroupCompletedJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[16]
+func (x *CompleteJobsFromWaitGroupStripeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,56 +1354,94 @@ func (x *ListWaitGroupCompletedJobsResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWaitGroupCompletedJobsResponse.ProtoReflect.Descriptor instead.
-func (*ListWaitGroupCompletedJobsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use CompleteJobsFromWaitGroupStripeResponse.ProtoReflect.Descriptor instead.
+func (*CompleteJobsFromWaitGroupStripeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ListWaitGroupCompletedJobsResponse) GetJobs() []*WaitGroupJob {
+func (x *CompleteJobsFromWaitGroupStripeResponse) GetStripe() *WaitGroupStripe {
 	if x != nil {
-		return x.Jobs
+		return x.Stripe
 	}
 	return nil
 }
 
-func (x *ListWaitGroupCompletedJobsResponse) GetNextPaginationToken() *PaginationToken {
+type AggregateWaitGroupStripesRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WaitGroupId *WaitGroupId           `protobuf:"bytes,1,opt,name=wait_group_id,json=waitGroupId,proto3" json:"wait_group_id,omitempty"`
+	// Counts of the stripes as returned by CompleteJobsFromWaitGroupStripe or
+	// GetWaitGroupStripe.
+	StripeCounts  []*WaitGroupStripeCounts `protobuf:"bytes,2,rep,name=stripe_counts,json=stripeCounts,proto3" json:"stripe_counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateWaitGroupStripesRequest) Reset() {
+	*x = AggregateWaitGroupStripesRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateWaitGroupStripesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateWaitGroupStripesRequest) ProtoMessage() {}
+
+func (x *AggregateWaitGroupStripesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[19]
 	if x != nil {
-		return x.NextPaginationToken
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateWaitGroupStripesRequest.ProtoReflect.Descriptor instead.
+func (*AggregateWaitGroupStripesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AggregateWaitGroupStripesRequest) GetWaitGroupId() *WaitGroupId {
+	if x != nil {
+		return x.WaitGroupId
 	}
 	return nil
 }
 
-func (x *ListWaitGroupCompletedJobsResponse) GetPreviousPaginationToken() *PaginationToken {
+func (x *AggregateWaitGroupStripesRequest) GetStripeCounts() []*WaitGroupStripeCounts {
 	if x != nil {
-		return x.PreviousPaginationToken
+		return x.StripeCounts
 	}
 	return nil
 }
 
-type RunWaitGroupsGarbageCollectionRequest struct {
-	state                      protoimpl.MessageState `protogen:"open.v1"`
-	GcRecordsPageSize          int64                  `protobuf:"varint,1,opt,name=gc_records_page_size,json=gcRecordsPageSize,proto3" json:"gc_records_page_size,omitempty"`
-	GcRecordWaitGroupsPageSize int64                  `protobuf:"varint,2,opt,name=gc_record_wait_groups_page_size,json=gcRecordWaitGroupsPageSize,proto3" json:"gc_record_wait_groups_page_size,omitempty"`
-	MaxDeletedObjects          int64                  `protobuf:"varint,3,opt,name=max_deleted_objects,json=maxDeletedObjects,proto3" json:"max_deleted_objects,omitempty"`
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+type AggregateWaitGroupStripesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitGroup     *WaitGroup             `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunWaitGroupsGarbageCollectionRequest) Reset() {
-	*x = RunWaitGroupsGarbageCollectionRequest{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[17]
+func (x *AggregateWaitGroupStripesResponse) Reset() {
+	*x = AggregateWaitGroupStripesResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunWaitGroupsGarbageCollectionRequest) String() string {
+func (x *AggregateWaitGroupStripesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunWaitGroupsGarbageCollectionRequest) ProtoMessage() {}
+func (*AggregateWaitGroupStripesResponse) ProtoMessage() {}
 
-func (x *RunWaitGroupsGarbageCollectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[17]
+func (x *AggregateWaitGroupStripesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1096,53 +1452,84 @@ func (x *RunWaitGroupsGarbageCollectionRequest) ProtoReflect() protoreflect.Mess
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunWaitGroupsGarbageCollectionRequest.ProtoReflect.Descriptor instead.
-func (*RunWaitGroupsGarbageCollectionRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use AggregateWaitGroupStripesResponse.ProtoReflect.Descriptor instead.
+func (*AggregateWaitGroupStripesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *RunWaitGroupsGarbageCollectionRequest) GetGcRecordsPageSize() int64 {
+func (x *AggregateWaitGroupStripesResponse) GetWaitGroup() *WaitGroup {
 	if x != nil {
-		return x.GcRecordsPageSize
+		return x.WaitGroup
 	}
-	return 0
+	return nil
 }
 
-func (x *RunWaitGroupsGarbageCollectionRequest) GetGcRecordWaitGroupsPageSize() int64 {
+type GetWaitGroupStripeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StripeId      *WaitGroupStripeId     `protobuf:"bytes,1,opt,name=stripe_id,json=stripeId,proto3" json:"stripe_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWaitGroupStripeRequest) Reset() {
+	*x = GetWaitGroupStripeRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitGroupStripeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitGroupStripeRequest) ProtoMessage() {}
+
+func (x *GetWaitGroupStripeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[21]
 	if x != nil {
-		return x.GcRecordWaitGroupsPageSize
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *RunWaitGroupsGarbageCollectionRequest) GetMaxDeletedObjects() int64 {
+// Deprecated: Use GetWaitGroupStripeRequest.ProtoReflect.Descriptor instead.
+func (*GetWaitGroupStripeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetWaitGroupStripeRequest) GetStripeId() *WaitGroupStripeId {
 	if x != nil {
-		return x.MaxDeletedObjects
+		return x.StripeId
 	}
-	return 0
+	return nil
 }
 
-type RunWaitGroupsGarbageCollectionResponse struct {
+type GetWaitGroupStripeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stripe        *WaitGroupStripe       `protobuf:"bytes,1,opt,name=stripe,proto3" json:"stripe,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunWaitGroupsGarbageCollectionResponse) Reset() {
-	*x = RunWaitGroupsGarbageCollectionResponse{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[18]
+func (x *GetWaitGroupStripeResponse) Reset() {
+	*x = GetWaitGroupStripeResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunWaitGroupsGarbageCollectionResponse) String() string {
+func (x *GetWaitGroupStripeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunWaitGroupsGarbageCollectionResponse) ProtoMessage() {}
+func (*GetWaitGroupStripeResponse) ProtoMessage() {}
 
-func (x *RunWaitGroupsGarbageCollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[18]
+func (x *GetWaitGroupStripeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1153,34 +1540,42 @@ func (x *RunWaitGroupsGarbageCollectionResponse) ProtoReflect() protoreflect.Mes
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunWaitGroupsGarbageCollectionResponse.ProtoReflect.Descriptor instead.
-func (*RunWaitGroupsGarbageCollectionResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetWaitGroupStripeResponse.ProtoReflect.Descriptor instead.
+func (*GetWaitGroupStripeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{22}
 }
 
-type WaitGroupsDeleteNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RecordId      uint64                 `protobuf:"fixed64,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
-	NamespaceId   *NamespaceId           `protobuf:"bytes,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+func (x *GetWaitGroupStripeResponse) GetStripe() *WaitGroupStripe {
+	if x != nil {
+		return x.Stripe
+	}
+	return nil
+}
+
+type ExtendWaitGroupStripeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	StripeId *WaitGroupStripeId     `protobuf:"bytes,1,opt,name=stripe_id,json=stripeId,proto3" json:"stripe_id,omitempty"`
+	// See CompleteJobsFromWaitGroupStripeRequest.delete_at.
+	DeleteAt      int64 `protobuf:"fixed64,2,opt,name=delete_at,json=deleteAt,proto3" json:"delete_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WaitGroupsDeleteNamespaceRequest) Reset() {
-	*x = WaitGroupsDeleteNamespaceRequest{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[19]
+func (x *ExtendWaitGroupStripeRequest) Reset() {
+	*x = ExtendWaitGroupStripeRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WaitGroupsDeleteNamespaceRequest) String() string {
+func (x *ExtendWaitGroupStripeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WaitGroupsDeleteNamespaceRequest) ProtoMessage() {}
+func (*ExtendWaitGroupStripeRequest) ProtoMessage() {}
 
-func (x *WaitGroupsDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[19]
+func (x *ExtendWaitGroupStripeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1191,46 +1586,126 @@ func (x *WaitGroupsDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WaitGroupsDeleteNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*WaitGroupsDeleteNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ExtendWaitGroupStripeRequest.ProtoReflect.Descriptor instead.
+func (*ExtendWaitGroupStripeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *WaitGroupsDeleteNamespaceRequest) GetRecordId() uint64 {
+func (x *ExtendWaitGroupStripeRequest) GetStripeId() *WaitGroupStripeId {
 	if x != nil {
-		return x.RecordId
+		return x.StripeId
+	}
+	return nil
+}
+
+func (x *ExtendWaitGroupStripeRequest) GetDeleteAt() int64 {
+	if x != nil {
+		return x.DeleteAt
 	}
 	return 0
 }
 
-func (x *WaitGroupsDeleteNamespaceRequest) GetNamespaceId() *NamespaceId {
+type ExtendWaitGroupStripeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendWaitGroupStripeResponse) Reset() {
+	*x = ExtendWaitGroupStripeResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendWaitGroupStripeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendWaitGroupStripeResponse) ProtoMessage() {}
+
+func (x *ExtendWaitGroupStripeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[24]
 	if x != nil {
-		return x.NamespaceId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendWaitGroupStripeResponse.ProtoReflect.Descriptor instead.
+func (*ExtendWaitGroupStripeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{24}
+}
+
+type DeleteWaitGroupStripeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StripeId      *WaitGroupStripeId     `protobuf:"bytes,1,opt,name=stripe_id,json=stripeId,proto3" json:"stripe_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWaitGroupStripeRequest) Reset() {
+	*x = DeleteWaitGroupStripeRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWaitGroupStripeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWaitGroupStripeRequest) ProtoMessage() {}
+
+func (x *DeleteWaitGroupStripeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWaitGroupStripeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWaitGroupStripeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteWaitGroupStripeRequest) GetStripeId() *WaitGroupStripeId {
+	if x != nil {
+		return x.StripeId
 	}
 	return nil
 }
 
-type WaitGroupsDeleteNamespaceResponse struct {
+type DeleteWaitGroupStripeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WaitGroupsDeleteNamespaceResponse) Reset() {
-	*x = WaitGroupsDeleteNamespaceResponse{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[20]
+func (x *DeleteWaitGroupStripeResponse) Reset() {
+	*x = DeleteWaitGroupStripeResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WaitGroupsDeleteNamespaceResponse) String() string {
+func (x *DeleteWaitGroupStripeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WaitGroupsDeleteNamespaceResponse) ProtoMessage() {}
+func (*DeleteWaitGroupStripeResponse) ProtoMessage() {}
 
-func (x *WaitGroupsDeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[20]
+func (x *DeleteWaitGroupStripeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1241,37 +1716,952 @@ func (x *WaitGroupsDeleteNamespaceResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WaitGroupsDeleteNamespaceResponse.ProtoReflect.Descriptor instead.
-func (*WaitGroupsDeleteNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use DeleteWaitGroupStripeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWaitGroupStripeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{26}
 }
 
-// WaitGroup tracks completion of a fixed set of jobs — a distributed, durable
-// sync.WaitGroup for fan-in of up to millions of jobs. It starts with counter
-// jobs outstanding; each job reported via CompleteJobsFromWaitGroup increments
-// completed_jobs, and the group becomes COMPLETED once completed_jobs reaches
-// counter (or EXPIRED if expires_at passes first).
-type WaitGroup struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	Id          *WaitGroupId           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	// Creation / last-modification time, Unix nanoseconds.
-	CreatedAt int64 `protobuf:"fixed64,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt int64 `protobuf:"fixed64,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	// Monotonic version, bumped on every successful update. Passed back as
-	// expected_version for optimistic concurrency control.
-	Version int64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
-	// Absolute deadline, Unix nanoseconds; the group becomes EXPIRED if it has not
-	// completed by then.
-	ExpiresAt int64 `protobuf:"fixed64,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	// Total number of jobs required to complete the group.
-	Counter int64 `protobuf:"varint,8,opt,name=counter,proto3" json:"counter,omitempty"`
-	// Number of distinct jobs completed so far (0 <= completed_jobs <= counter).
-	CompletedJobs int64             `protobuf:"varint,9,opt,name=completed_jobs,json=completedJobs,proto3" json:"completed_jobs,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Status        WaitGroupStatus   `protobuf:"varint,11,opt,name=status,proto3,enum=com.evrblk.grackle.corepb.WaitGroupStatus" json:"status,omitempty"`
-	// Retention period: the group is auto-deleted this many seconds after
+type ListWaitGroupStripeCompletedJobsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	StripeId        *WaitGroupStripeId     `protobuf:"bytes,1,opt,name=stripe_id,json=stripeId,proto3" json:"stripe_id,omitempty"`
+	PaginationToken *PaginationToken       `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListWaitGroupStripeCompletedJobsRequest) Reset() {
+	*x = ListWaitGroupStripeCompletedJobsRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitGroupStripeCompletedJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitGroupStripeCompletedJobsRequest) ProtoMessage() {}
+
+func (x *ListWaitGroupStripeCompletedJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitGroupStripeCompletedJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListWaitGroupStripeCompletedJobsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListWaitGroupStripeCompletedJobsRequest) GetStripeId() *WaitGroupStripeId {
+	if x != nil {
+		return x.StripeId
+	}
+	return nil
+}
+
+func (x *ListWaitGroupStripeCompletedJobsRequest) GetPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PaginationToken
+	}
+	return nil
+}
+
+func (x *ListWaitGroupStripeCompletedJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWaitGroupStripeCompletedJobsResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Jobs                    []*WaitGroupJob        `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
+	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ListWaitGroupStripeCompletedJobsResponse) Reset() {
+	*x = ListWaitGroupStripeCompletedJobsResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitGroupStripeCompletedJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitGroupStripeCompletedJobsResponse) ProtoMessage() {}
+
+func (x *ListWaitGroupStripeCompletedJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitGroupStripeCompletedJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListWaitGroupStripeCompletedJobsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListWaitGroupStripeCompletedJobsResponse) GetJobs() []*WaitGroupJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListWaitGroupStripeCompletedJobsResponse) GetNextPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.NextPaginationToken
+	}
+	return nil
+}
+
+func (x *ListWaitGroupStripeCompletedJobsResponse) GetPreviousPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PreviousPaginationToken
+	}
+	return nil
+}
+
+type AddToWaitGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupName string                 `protobuf:"bytes,2,opt,name=wait_group_name,json=waitGroupName,proto3" json:"wait_group_name,omitempty"`
+	// Signed amount to adjust counter by.
+	Delta int64 `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	// Service limit enforced by the core; the resulting counter must not exceed
+	// it.
+	MaxWaitGroupSize int64 `protobuf:"varint,4,opt,name=max_wait_group_size,json=maxWaitGroupSize,proto3" json:"max_wait_group_size,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AddToWaitGroupRequest) Reset() {
+	*x = AddToWaitGroupRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToWaitGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToWaitGroupRequest) ProtoMessage() {}
+
+func (x *AddToWaitGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToWaitGroupRequest.ProtoReflect.Descriptor instead.
+func (*AddToWaitGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AddToWaitGroupRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *AddToWaitGroupRequest) GetWaitGroupName() string {
+	if x != nil {
+		return x.WaitGroupName
+	}
+	return ""
+}
+
+func (x *AddToWaitGroupRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *AddToWaitGroupRequest) GetMaxWaitGroupSize() int64 {
+	if x != nil {
+		return x.MaxWaitGroupSize
+	}
+	return 0
+}
+
+type AddToWaitGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitGroup     *WaitGroup             `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToWaitGroupResponse) Reset() {
+	*x = AddToWaitGroupResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToWaitGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToWaitGroupResponse) ProtoMessage() {}
+
+func (x *AddToWaitGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToWaitGroupResponse.ProtoReflect.Descriptor instead.
+func (*AddToWaitGroupResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AddToWaitGroupResponse) GetWaitGroup() *WaitGroup {
+	if x != nil {
+		return x.WaitGroup
+	}
+	return nil
+}
+
+type UncompleteJobsFromWaitGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupName string                 `protobuf:"bytes,2,opt,name=wait_group_name,json=waitGroupName,proto3" json:"wait_group_name,omitempty"`
+	JobIds        []string               `protobuf:"bytes,3,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	// Allows retracting jobs from a COMPLETED wait group, which makes it ACTIVE
+	// again.
+	Reopen bool `protobuf:"varint,4,opt,name=reopen,proto3" json:"reopen,omitempty"`
+	// Number of most recent retracted completions kept in a job's history; older
+	// ones are dropped. 0 keeps all of them.
+	MaxJobHistoryLength int64 `protobuf:"varint,5,opt,name=max_job_history_length,json=maxJobHistoryLength,proto3" json:"max_job_history_length,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UncompleteJobsFromWaitGroupRequest) Reset() {
+	*x = UncompleteJobsFromWaitGroupRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UncompleteJobsFromWaitGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncompleteJobsFromWaitGroupRequest) ProtoMessage() {}
+
+func (x *UncompleteJobsFromWaitGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncompleteJobsFromWaitGroupRequest.ProtoReflect.Descriptor instead.
+func (*UncompleteJobsFromWaitGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UncompleteJobsFromWaitGroupRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *UncompleteJobsFromWaitGroupRequest) GetWaitGroupName() string {
+	if x != nil {
+		return x.WaitGroupName
+	}
+	return ""
+}
+
+func (x *UncompleteJobsFromWaitGroupRequest) GetJobIds() []string {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+func (x *UncompleteJobsFromWaitGroupRequest) GetReopen() bool {
+	if x != nil {
+		return x.Reopen
+	}
+	return false
+}
+
+func (x *UncompleteJobsFromWaitGroupRequest) GetMaxJobHistoryLength() int64 {
+	if x != nil {
+		return x.MaxJobHistoryLength
+	}
+	return 0
+}
+
+type UncompleteJobsFromWaitGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitGroup     *WaitGroup             `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UncompleteJobsFromWaitGroupResponse) Reset() {
+	*x = UncompleteJobsFromWaitGroupResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UncompleteJobsFromWaitGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncompleteJobsFromWaitGroupResponse) ProtoMessage() {}
+
+func (x *UncompleteJobsFromWaitGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncompleteJobsFromWaitGroupResponse.ProtoReflect.Descriptor instead.
+func (*UncompleteJobsFromWaitGroupResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UncompleteJobsFromWaitGroupResponse) GetWaitGroup() *WaitGroup {
+	if x != nil {
+		return x.WaitGroup
+	}
+	return nil
+}
+
+type AddJobsToWaitGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupName string                 `protobuf:"bytes,2,opt,name=wait_group_name,json=waitGroupName,proto3" json:"wait_group_name,omitempty"`
+	JobIds        []string               `protobuf:"bytes,3,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddJobsToWaitGroupRequest) Reset() {
+	*x = AddJobsToWaitGroupRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddJobsToWaitGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddJobsToWaitGroupRequest) ProtoMessage() {}
+
+func (x *AddJobsToWaitGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddJobsToWaitGroupRequest.ProtoReflect.Descriptor instead.
+func (*AddJobsToWaitGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AddJobsToWaitGroupRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *AddJobsToWaitGroupRequest) GetWaitGroupName() string {
+	if x != nil {
+		return x.WaitGroupName
+	}
+	return ""
+}
+
+func (x *AddJobsToWaitGroupRequest) GetJobIds() []string {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+type AddJobsToWaitGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitGroup     *WaitGroup             `protobuf:"bytes,1,opt,name=wait_group,json=waitGroup,proto3" json:"wait_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddJobsToWaitGroupResponse) Reset() {
+	*x = AddJobsToWaitGroupResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddJobsToWaitGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddJobsToWaitGroupResponse) ProtoMessage() {}
+
+func (x *AddJobsToWaitGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddJobsToWaitGroupResponse.ProtoReflect.Descriptor instead.
+func (*AddJobsToWaitGroupResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AddJobsToWaitGroupResponse) GetWaitGroup() *WaitGroup {
+	if x != nil {
+		return x.WaitGroup
+	}
+	return nil
+}
+
+type ListWaitGroupPendingJobsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupName   string                 `protobuf:"bytes,2,opt,name=wait_group_name,json=waitGroupName,proto3" json:"wait_group_name,omitempty"`
+	PaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListWaitGroupPendingJobsRequest) Reset() {
+	*x = ListWaitGroupPendingJobsRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitGroupPendingJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitGroupPendingJobsRequest) ProtoMessage() {}
+
+func (x *ListWaitGroupPendingJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitGroupPendingJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListWaitGroupPendingJobsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListWaitGroupPendingJobsRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *ListWaitGroupPendingJobsRequest) GetWaitGroupName() string {
+	if x != nil {
+		return x.WaitGroupName
+	}
+	return ""
+}
+
+func (x *ListWaitGroupPendingJobsRequest) GetPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PaginationToken
+	}
+	return nil
+}
+
+func (x *ListWaitGroupPendingJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWaitGroupPendingJobsResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Jobs                    []*WaitGroupJob        `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
+	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ListWaitGroupPendingJobsResponse) Reset() {
+	*x = ListWaitGroupPendingJobsResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitGroupPendingJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitGroupPendingJobsResponse) ProtoMessage() {}
+
+func (x *ListWaitGroupPendingJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitGroupPendingJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListWaitGroupPendingJobsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListWaitGroupPendingJobsResponse) GetJobs() []*WaitGroupJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListWaitGroupPendingJobsResponse) GetNextPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.NextPaginationToken
+	}
+	return nil
+}
+
+func (x *ListWaitGroupPendingJobsResponse) GetPreviousPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PreviousPaginationToken
+	}
+	return nil
+}
+
+type ListWaitGroupCompletedJobsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId     *NamespaceId           `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupName   string                 `protobuf:"bytes,2,opt,name=wait_group_name,json=waitGroupName,proto3" json:"wait_group_name,omitempty"`
+	PaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3" json:"pagination_token,omitempty"`
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListWaitGroupCompletedJobsRequest) Reset() {
+	*x = ListWaitGroupCompletedJobsRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitGroupCompletedJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitGroupCompletedJobsRequest) ProtoMessage() {}
+
+func (x *ListWaitGroupCompletedJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitGroupCompletedJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListWaitGroupCompletedJobsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListWaitGroupCompletedJobsRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *ListWaitGroupCompletedJobsRequest) GetWaitGroupName() string {
+	if x != nil {
+		return x.WaitGroupName
+	}
+	return ""
+}
+
+func (x *ListWaitGroupCompletedJobsRequest) GetPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PaginationToken
+	}
+	return nil
+}
+
+func (x *ListWaitGroupCompletedJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWaitGroupCompletedJobsResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Jobs                    []*WaitGroupJob        `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	NextPaginationToken     *PaginationToken       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3" json:"next_pagination_token,omitempty"`
+	PreviousPaginationToken *PaginationToken       `protobuf:"bytes,3,opt,name=previous_pagination_token,json=previousPaginationToken,proto3" json:"previous_pagination_token,omitempty"`
+	// Set instead of jobs if the wait group is striped; its jobs are to be listed
+	// from its stripes with ListWaitGroupStripeCompletedJobs.
+	StripedWaitGroup *WaitGroup `protobuf:"bytes,4,opt,name=striped_wait_group,json=stripedWaitGroup,proto3" json:"striped_wait_group,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListWaitGroupCompletedJobsResponse) Reset() {
+	*x = ListWaitGroupCompletedJobsResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitGroupCompletedJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitGroupCompletedJobsResponse) ProtoMessage() {}
+
+func (x *ListWaitGroupCompletedJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitGroupCompletedJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListWaitGroupCompletedJobsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListWaitGroupCompletedJobsResponse) GetJobs() []*WaitGroupJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListWaitGroupCompletedJobsResponse) GetNextPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.NextPaginationToken
+	}
+	return nil
+}
+
+func (x *ListWaitGroupCompletedJobsResponse) GetPreviousPaginationToken() *PaginationToken {
+	if x != nil {
+		return x.PreviousPaginationToken
+	}
+	return nil
+}
+
+func (x *ListWaitGroupCompletedJobsResponse) GetStripedWaitGroup() *WaitGroup {
+	if x != nil {
+		return x.StripedWaitGroup
+	}
+	return nil
+}
+
+type RunWaitGroupsGarbageCollectionRequest struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	GcRecordsPageSize          int64                  `protobuf:"varint,1,opt,name=gc_records_page_size,json=gcRecordsPageSize,proto3" json:"gc_records_page_size,omitempty"`
+	GcRecordWaitGroupsPageSize int64                  `protobuf:"varint,2,opt,name=gc_record_wait_groups_page_size,json=gcRecordWaitGroupsPageSize,proto3" json:"gc_record_wait_groups_page_size,omitempty"`
+	MaxDeletedObjects          int64                  `protobuf:"varint,3,opt,name=max_deleted_objects,json=maxDeletedObjects,proto3" json:"max_deleted_objects,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *RunWaitGroupsGarbageCollectionRequest) Reset() {
+	*x = RunWaitGroupsGarbageCollectionRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunWaitGroupsGarbageCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunWaitGroupsGarbageCollectionRequest) ProtoMessage() {}
+
+func (x *RunWaitGroupsGarbageCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunWaitGroupsGarbageCollectionRequest.ProtoReflect.Descriptor instead.
+func (*RunWaitGroupsGarbageCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RunWaitGroupsGarbageCollectionRequest) GetGcRecordsPageSize() int64 {
+	if x != nil {
+		return x.GcRecordsPageSize
+	}
+	return 0
+}
+
+func (x *RunWaitGroupsGarbageCollectionRequest) GetGcRecordWaitGroupsPageSize() int64 {
+	if x != nil {
+		return x.GcRecordWaitGroupsPageSize
+	}
+	return 0
+}
+
+func (x *RunWaitGroupsGarbageCollectionRequest) GetMaxDeletedObjects() int64 {
+	if x != nil {
+		return x.MaxDeletedObjects
+	}
+	return 0
+}
+
+type RunWaitGroupsGarbageCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunWaitGroupsGarbageCollectionResponse) Reset() {
+	*x = RunWaitGroupsGarbageCollectionResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunWaitGroupsGarbageCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunWaitGroupsGarbageCollectionResponse) ProtoMessage() {}
+
+func (x *RunWaitGroupsGarbageCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunWaitGroupsGarbageCollectionResponse.ProtoReflect.Descriptor instead.
+func (*RunWaitGroupsGarbageCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{40}
+}
+
+type WaitGroupsDeleteNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      uint64                 `protobuf:"fixed64,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	NamespaceId   *NamespaceId           `protobuf:"bytes,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupsDeleteNamespaceRequest) Reset() {
+	*x = WaitGroupsDeleteNamespaceRequest{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupsDeleteNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupsDeleteNamespaceRequest) ProtoMessage() {}
+
+func (x *WaitGroupsDeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupsDeleteNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*WaitGroupsDeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *WaitGroupsDeleteNamespaceRequest) GetRecordId() uint64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *WaitGroupsDeleteNamespaceRequest) GetNamespaceId() *NamespaceId {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+type WaitGroupsDeleteNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupsDeleteNamespaceResponse) Reset() {
+	*x = WaitGroupsDeleteNamespaceResponse{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupsDeleteNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupsDeleteNamespaceResponse) ProtoMessage() {}
+
+func (x *WaitGroupsDeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupsDeleteNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*WaitGroupsDeleteNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{42}
+}
+
+// WaitGroup tracks completion of a fixed set of jobs — a distributed, durable
+// sync.WaitGroup for fan-in of up to millions of jobs. It starts with counter
+// jobs outstanding; each job reported via CompleteJobsFromWaitGroup increments
+// completed_jobs (or failed_jobs, if reported as failed), and the group becomes
+// COMPLETED once completed_jobs + failed_jobs reaches counter (or FAILED if
+// failed_jobs exceeds max_failed_jobs, or EXPIRED if expires_at passes first).
+type WaitGroup struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          *WaitGroupId           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Creation / last-modification time, Unix nanoseconds.
+	CreatedAt int64 `protobuf:"fixed64,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64 `protobuf:"fixed64,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Monotonic version, bumped on every successful update. Passed back as
+	// expected_version for optimistic concurrency control.
+	Version int64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// Absolute deadline, Unix nanoseconds; the group becomes EXPIRED if it has not
+	// completed by then.
+	ExpiresAt int64 `protobuf:"fixed64,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Total number of jobs required to complete the group.
+	Counter int64 `protobuf:"varint,8,opt,name=counter,proto3" json:"counter,omitempty"`
+	// Number of distinct jobs completed successfully so far (0 <= completed_jobs +
+	// failed_jobs <= counter).
+	CompletedJobs int64             `protobuf:"varint,9,opt,name=completed_jobs,json=completedJobs,proto3" json:"completed_jobs,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status        WaitGroupStatus   `protobuf:"varint,11,opt,name=status,proto3,enum=com.evrblk.grackle.corepb.WaitGroupStatus" json:"status,omitempty"`
+	// Retention period: the group is auto-deleted this many seconds after
 	// finished_at.
 	DeleteAfterFinishedSeconds int64 `protobuf:"varint,12,opt,name=delete_after_finished_seconds,json=deleteAfterFinishedSeconds,proto3" json:"delete_after_finished_seconds,omitempty"`
 	// finished_at is the timestamp (ns) at which the wait group became finished
@@ -1280,25 +2670,664 @@ type WaitGroup struct {
 	// last_activity_at is the timestamp (ns) of the most recent activity on this
 	// wait group (a job being reported completed). Not affected by reads.
 	LastActivityAt int64 `protobuf:"fixed64,14,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	// Number of distinct jobs reported as failed so far.
+	FailedJobs int64 `protobuf:"varint,15,opt,name=failed_jobs,json=failedJobs,proto3" json:"failed_jobs,omitempty"`
+	// Number of failed jobs tolerated; exceeding it makes the group FAILED. Unset
+	// means failures never fail the group.
+	MaxFailedJobs *int64 `protobuf:"varint,16,opt,name=max_failed_jobs,json=maxFailedJobs,proto3,oneof" json:"max_failed_jobs,omitempty"`
+	// True if the group tracks a declared set of job ids; only declared job ids
+	// can then be completed.
+	DeclaredJobs bool `protobuf:"varint,17,opt,name=declared_jobs,json=declaredJobs,proto3" json:"declared_jobs,omitempty"`
+	// Number of distinct job ids declared via AddJobsToWaitGroup (never more than
+	// counter).
+	NumberOfDeclaredJobs int64 `protobuf:"varint,18,opt,name=number_of_declared_jobs,json=numberOfDeclaredJobs,proto3" json:"number_of_declared_jobs,omitempty"`
+	// Name of the parent wait group this one reports to once finished. Empty for
+	// a top-level wait group.
+	ParentWaitGroupName string `protobuf:"bytes,19,opt,name=parent_wait_group_name,json=parentWaitGroupName,proto3" json:"parent_wait_group_name,omitempty"`
+	// How an EXPIRED, FAILED or CANCELLED wait group is reported to its parent.
+	ChildFailurePolicy WaitGroupChildFailurePolicy `protobuf:"varint,20,opt,name=child_failure_policy,json=childFailurePolicy,proto3,enum=com.evrblk.grackle.corepb.WaitGroupChildFailurePolicy" json:"child_failure_policy,omitempty"`
+	// Reason given to CancelWaitGroup. Empty unless the group is CANCELLED.
+	CancellationReason string `protobuf:"bytes,21,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	// Running aggregates declared at creation, one per aggregation spec, in
+	// declaration order.
+	Aggregates []*WaitGroupAggregate `protobuf:"bytes,22,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+	// True if the group uses integer job ids 0..counter-1, tracked in job chunks.
+	// Only jobs completed with metadata (or retracted before) get a job row.
+	IntegerJobIds bool `protobuf:"varint,23,opt,name=integer_job_ids,json=integerJobIds,proto3" json:"integer_job_ids,omitempty"`
+	// Number of stripes the jobs of the group are completed on, 0 if the group is
+	// not striped. completed_jobs and failed_jobs then add up stripe_counts.
+	Stripes uint32 `protobuf:"varint,24,opt,name=stripes,proto3" json:"stripes,omitempty"`
+	// Last counts reported for each stripe by AggregateWaitGroupStripes, indexed
+	// by stripe.
+	StripeCounts []*WaitGroupStripeCounts `protobuf:"bytes,25,rep,name=stripe_counts,json=stripeCounts,proto3" json:"stripe_counts,omitempty"`
+	// Estimated number of jobs finishing per second, exponentially weighted
+	// towards recent completions, as of completion_rate_updated_at. 0 until the
+	// first jobs are completed.
+	CompletionRate float64 `protobuf:"fixed64,26,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	// Estimated time (ns) the group completes at if jobs keep finishing at
+	// completion_rate, 0 if unknown or the group is no longer active.
+	EstimatedCompletionAt int64 `protobuf:"fixed64,27,opt,name=estimated_completion_at,json=estimatedCompletionAt,proto3" json:"estimated_completion_at,omitempty"`
+	// Time (ns) completion_rate was last updated at.
+	CompletionRateUpdatedAt int64 `protobuf:"fixed64,28,opt,name=completion_rate_updated_at,json=completionRateUpdatedAt,proto3" json:"completion_rate_updated_at,omitempty"`
+	// Number of finished jobs, each weighted by exp(-age / window) as of
+	// completion_rate_updated_at, that completion_rate is derived from.
+	DecayedFinishedJobs float64 `protobuf:"fixed64,29,opt,name=decayed_finished_jobs,json=decayedFinishedJobs,proto3" json:"decayed_finished_jobs,omitempty"`
+	// Id of the parent wait group named by parent_wait_group_name, as of
+	// creation. A parent recreated under the same name is not reported to.
+	ParentWaitGroupId uint64 `protobuf:"fixed64,30,opt,name=parent_wait_group_id,json=parentWaitGroupId,proto3" json:"parent_wait_group_id,omitempty"`
+	// Why the finished group could not be reported to its parent (the parent
+	// was deleted, recreated, already finished or rejected the job). Empty if it
+	// was reported, is still active or is not reported at all.
+	ParentReportError string `protobuf:"bytes,31,opt,name=parent_report_error,json=parentReportError,proto3" json:"parent_report_error,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *WaitGroup) Reset() {
+	*x = WaitGroup{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroup) ProtoMessage() {}
+
+func (x *WaitGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroup.ProtoReflect.Descriptor instead.
+func (*WaitGroup) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WaitGroup) GetId() *WaitGroupId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *WaitGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WaitGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WaitGroup) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetCounter() int64 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetCompletedJobs() int64 {
+	if x != nil {
+		return x.CompletedJobs
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *WaitGroup) GetStatus() WaitGroupStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WaitGroupStatus_WAIT_GROUP_STATUS_INVALID
+}
+
+func (x *WaitGroup) GetDeleteAfterFinishedSeconds() int64 {
+	if x != nil {
+		return x.DeleteAfterFinishedSeconds
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetLastActivityAt() int64 {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetFailedJobs() int64 {
+	if x != nil {
+		return x.FailedJobs
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetMaxFailedJobs() int64 {
+	if x != nil && x.MaxFailedJobs != nil {
+		return *x.MaxFailedJobs
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetDeclaredJobs() bool {
+	if x != nil {
+		return x.DeclaredJobs
+	}
+	return false
+}
+
+func (x *WaitGroup) GetNumberOfDeclaredJobs() int64 {
+	if x != nil {
+		return x.NumberOfDeclaredJobs
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetParentWaitGroupName() string {
+	if x != nil {
+		return x.ParentWaitGroupName
+	}
+	return ""
+}
+
+func (x *WaitGroup) GetChildFailurePolicy() WaitGroupChildFailurePolicy {
+	if x != nil {
+		return x.ChildFailurePolicy
+	}
+	return WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID
+}
+
+func (x *WaitGroup) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *WaitGroup) GetAggregates() []*WaitGroupAggregate {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *WaitGroup) GetIntegerJobIds() bool {
+	if x != nil {
+		return x.IntegerJobIds
+	}
+	return false
+}
+
+func (x *WaitGroup) GetStripes() uint32 {
+	if x != nil {
+		return x.Stripes
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetStripeCounts() []*WaitGroupStripeCounts {
+	if x != nil {
+		return x.StripeCounts
+	}
+	return nil
+}
+
+func (x *WaitGroup) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetEstimatedCompletionAt() int64 {
+	if x != nil {
+		return x.EstimatedCompletionAt
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetCompletionRateUpdatedAt() int64 {
+	if x != nil {
+		return x.CompletionRateUpdatedAt
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetDecayedFinishedJobs() float64 {
+	if x != nil {
+		return x.DecayedFinishedJobs
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetParentWaitGroupId() uint64 {
+	if x != nil {
+		return x.ParentWaitGroupId
+	}
+	return 0
+}
+
+func (x *WaitGroup) GetParentReportError() string {
+	if x != nil {
+		return x.ParentReportError
+	}
+	return ""
+}
+
+// WaitGroupStripe is one sub-counter of a striped wait group. It records the
+// jobs completed on it and is deleted by garbage collection once delete_at
+// passes, independently of its wait group (which may live on another shard).
+type WaitGroupStripe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *WaitGroupStripeId     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CompletedJobs int64                  `protobuf:"varint,2,opt,name=completed_jobs,json=completedJobs,proto3" json:"completed_jobs,omitempty"`
+	FailedJobs    int64                  `protobuf:"varint,3,opt,name=failed_jobs,json=failedJobs,proto3" json:"failed_jobs,omitempty"`
+	CreatedAt     int64                  `protobuf:"fixed64,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"fixed64,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeleteAt      int64                  `protobuf:"fixed64,6,opt,name=delete_at,json=deleteAt,proto3" json:"delete_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupStripe) Reset() {
+	*x = WaitGroupStripe{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupStripe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupStripe) ProtoMessage() {}
+
+func (x *WaitGroupStripe) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupStripe.ProtoReflect.Descriptor instead.
+func (*WaitGroupStripe) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WaitGroupStripe) GetId() *WaitGroupStripeId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *WaitGroupStripe) GetCompletedJobs() int64 {
+	if x != nil {
+		return x.CompletedJobs
+	}
+	return 0
+}
+
+func (x *WaitGroupStripe) GetFailedJobs() int64 {
+	if x != nil {
+		return x.FailedJobs
+	}
+	return 0
+}
+
+func (x *WaitGroupStripe) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *WaitGroupStripe) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *WaitGroupStripe) GetDeleteAt() int64 {
+	if x != nil {
+		return x.DeleteAt
+	}
+	return 0
+}
+
+// WaitGroupStripeId identifies a stripe of a striped wait group; stripe is in
+// 0..stripes-1.
+type WaitGroupStripeId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"fixed64,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	NamespaceId   uint64                 `protobuf:"fixed64,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupId   uint64                 `protobuf:"fixed64,3,opt,name=wait_group_id,json=waitGroupId,proto3" json:"wait_group_id,omitempty"`
+	Stripe        uint32                 `protobuf:"varint,4,opt,name=stripe,proto3" json:"stripe,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupStripeId) Reset() {
+	*x = WaitGroupStripeId{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupStripeId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupStripeId) ProtoMessage() {}
+
+func (x *WaitGroupStripeId) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupStripeId.ProtoReflect.Descriptor instead.
+func (*WaitGroupStripeId) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WaitGroupStripeId) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *WaitGroupStripeId) GetNamespaceId() uint64 {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return 0
+}
+
+func (x *WaitGroupStripeId) GetWaitGroupId() uint64 {
+	if x != nil {
+		return x.WaitGroupId
+	}
+	return 0
+}
+
+func (x *WaitGroupStripeId) GetStripe() uint32 {
+	if x != nil {
+		return x.Stripe
+	}
+	return 0
+}
+
+// WaitGroupStripeCounts are the job counts of one stripe of a striped wait
+// group.
+type WaitGroupStripeCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stripe        uint32                 `protobuf:"varint,1,opt,name=stripe,proto3" json:"stripe,omitempty"`
+	CompletedJobs int64                  `protobuf:"varint,2,opt,name=completed_jobs,json=completedJobs,proto3" json:"completed_jobs,omitempty"`
+	FailedJobs    int64                  `protobuf:"varint,3,opt,name=failed_jobs,json=failedJobs,proto3" json:"failed_jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupStripeCounts) Reset() {
+	*x = WaitGroupStripeCounts{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupStripeCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupStripeCounts) ProtoMessage() {}
+
+func (x *WaitGroupStripeCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupStripeCounts.ProtoReflect.Descriptor instead.
+func (*WaitGroupStripeCounts) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WaitGroupStripeCounts) GetStripe() uint32 {
+	if x != nil {
+		return x.Stripe
+	}
+	return 0
+}
+
+func (x *WaitGroupStripeCounts) GetCompletedJobs() int64 {
+	if x != nil {
+		return x.CompletedJobs
+	}
+	return 0
+}
+
+func (x *WaitGroupStripeCounts) GetFailedJobs() int64 {
+	if x != nil {
+		return x.FailedJobs
+	}
+	return 0
+}
+
+// WaitGroupStripesDeletionRecord schedules the deletion of a wait group stripe
+// and its jobs at delete_at.
+type WaitGroupStripesDeletionRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StripeId      *WaitGroupStripeId     `protobuf:"bytes,1,opt,name=stripe_id,json=stripeId,proto3" json:"stripe_id,omitempty"`
+	DeleteAt      int64                  `protobuf:"fixed64,2,opt,name=delete_at,json=deleteAt,proto3" json:"delete_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupStripesDeletionRecord) Reset() {
+	*x = WaitGroupStripesDeletionRecord{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupStripesDeletionRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupStripesDeletionRecord) ProtoMessage() {}
+
+func (x *WaitGroupStripesDeletionRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupStripesDeletionRecord.ProtoReflect.Descriptor instead.
+func (*WaitGroupStripesDeletionRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WaitGroupStripesDeletionRecord) GetStripeId() *WaitGroupStripeId {
+	if x != nil {
+		return x.StripeId
+	}
+	return nil
+}
+
+func (x *WaitGroupStripesDeletionRecord) GetDeleteAt() int64 {
+	if x != nil {
+		return x.DeleteAt
+	}
+	return 0
+}
+
+// WaitGroupAggregationSpec declares a running aggregate over the job metadata
+// value under metadata_key, parsed as an integer.
+type WaitGroupAggregationSpec struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	MetadataKey   string                       `protobuf:"bytes,1,opt,name=metadata_key,json=metadataKey,proto3" json:"metadata_key,omitempty"`
+	Function      WaitGroupAggregationFunction `protobuf:"varint,2,opt,name=function,proto3,enum=com.evrblk.grackle.corepb.WaitGroupAggregationFunction" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupAggregationSpec) Reset() {
+	*x = WaitGroupAggregationSpec{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupAggregationSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupAggregationSpec) ProtoMessage() {}
+
+func (x *WaitGroupAggregationSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupAggregationSpec.ProtoReflect.Descriptor instead.
+func (*WaitGroupAggregationSpec) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *WaitGroupAggregationSpec) GetMetadataKey() string {
+	if x != nil {
+		return x.MetadataKey
+	}
+	return ""
+}
+
+func (x *WaitGroupAggregationSpec) GetFunction() WaitGroupAggregationFunction {
+	if x != nil {
+		return x.Function
+	}
+	return WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_INVALID
+}
+
+// WaitGroupAggregate is the current value of an aggregation spec over the
+// successfully completed jobs. Failed jobs and jobs whose metadata_key value is
+// missing or not an integer are skipped.
+type WaitGroupAggregate struct {
+	state       protoimpl.MessageState       `protogen:"open.v1"`
+	MetadataKey string                       `protobuf:"bytes,1,opt,name=metadata_key,json=metadataKey,proto3" json:"metadata_key,omitempty"`
+	Function    WaitGroupAggregationFunction `protobuf:"varint,2,opt,name=function,proto3,enum=com.evrblk.grackle.corepb.WaitGroupAggregationFunction" json:"function,omitempty"`
+	// Aggregated value; zero while number_of_values is zero. A SUM that
+	// overflows saturates at the int64 bounds.
+	Value int64 `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Number of job values that went into value.
+	NumberOfValues int64 `protobuf:"varint,4,opt,name=number_of_values,json=numberOfValues,proto3" json:"number_of_values,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *WaitGroup) Reset() {
-	*x = WaitGroup{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[21]
+func (x *WaitGroupAggregate) Reset() {
+	*x = WaitGroupAggregate{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WaitGroup) String() string {
+func (x *WaitGroupAggregate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WaitGroup) ProtoMessage() {}
+func (*WaitGroupAggregate) ProtoMessage() {}
 
-func (x *WaitGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[21]
+func (x *WaitGroupAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1309,137 +3338,223 @@ func (x *WaitGroup) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WaitGroup.ProtoReflect.Descriptor instead.
-func (*WaitGroup) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use WaitGroupAggregate.ProtoReflect.Descriptor instead.
+func (*WaitGroupAggregate) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *WaitGroup) GetId() *WaitGroupId {
+func (x *WaitGroupAggregate) GetMetadataKey() string {
 	if x != nil {
-		return x.Id
+		return x.MetadataKey
 	}
-	return nil
+	return ""
 }
 
-func (x *WaitGroup) GetName() string {
+func (x *WaitGroupAggregate) GetFunction() WaitGroupAggregationFunction {
 	if x != nil {
-		return x.Name
+		return x.Function
 	}
-	return ""
+	return WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_INVALID
 }
 
-func (x *WaitGroup) GetDescription() string {
+func (x *WaitGroupAggregate) GetValue() int64 {
 	if x != nil {
-		return x.Description
+		return x.Value
 	}
-	return ""
+	return 0
 }
 
-func (x *WaitGroup) GetCreatedAt() int64 {
+func (x *WaitGroupAggregate) GetNumberOfValues() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.NumberOfValues
 	}
 	return 0
 }
 
-func (x *WaitGroup) GetUpdatedAt() int64 {
+// WaitGroupJob is one completed job recorded against a wait group. Jobs are
+// identified by a caller-supplied job_id, so reporting the same job twice is
+// idempotent and never double-counts toward the counter. A job that was
+// declared but not yet completed is stored in the pending index with
+// completed_at unset.
+type WaitGroupJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *WaitGroupJobId        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the job was reported complete, Unix nanoseconds.
+	CompletedAt int64             `protobuf:"fixed64,2,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Metadata    map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// True if the job was reported as failed.
+	Failed bool `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Earlier completions of this job that were retracted via
+	// UncompleteJobsFromWaitGroup, oldest first.
+	History       []*WaitGroupJobAttempt `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupJob) Reset() {
+	*x = WaitGroupJob{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupJob) ProtoMessage() {}
+
+func (x *WaitGroupJob) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[50]
 	if x != nil {
-		return x.UpdatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *WaitGroup) GetVersion() int64 {
+// Deprecated: Use WaitGroupJob.ProtoReflect.Descriptor instead.
+func (*WaitGroupJob) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *WaitGroupJob) GetId() *WaitGroupJobId {
 	if x != nil {
-		return x.Version
+		return x.Id
 	}
-	return 0
+	return nil
 }
 
-func (x *WaitGroup) GetExpiresAt() int64 {
+func (x *WaitGroupJob) GetCompletedAt() int64 {
 	if x != nil {
-		return x.ExpiresAt
+		return x.CompletedAt
 	}
 	return 0
 }
 
-func (x *WaitGroup) GetCounter() int64 {
+func (x *WaitGroupJob) GetMetadata() map[string]string {
 	if x != nil {
-		return x.Counter
+		return x.Metadata
 	}
-	return 0
+	return nil
 }
 
-func (x *WaitGroup) GetCompletedJobs() int64 {
+func (x *WaitGroupJob) GetFailed() bool {
 	if x != nil {
-		return x.CompletedJobs
+		return x.Failed
 	}
-	return 0
+	return false
 }
 
-func (x *WaitGroup) GetMetadata() map[string]string {
+func (x *WaitGroupJob) GetHistory() []*WaitGroupJobAttempt {
 	if x != nil {
-		return x.Metadata
+		return x.History
 	}
 	return nil
 }
 
-func (x *WaitGroup) GetStatus() WaitGroupStatus {
+// WaitGroupJobAttempt is one retracted completion of a job.
+type WaitGroupJobAttempt struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the job was reported complete, Unix nanoseconds.
+	CompletedAt int64             `protobuf:"fixed64,1,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Metadata    map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Failed      bool              `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	// When the completion was retracted, Unix nanoseconds.
+	RetractedAt   int64 `protobuf:"fixed64,4,opt,name=retracted_at,json=retractedAt,proto3" json:"retracted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupJobAttempt) Reset() {
+	*x = WaitGroupJobAttempt{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupJobAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupJobAttempt) ProtoMessage() {}
+
+func (x *WaitGroupJobAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[51]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return WaitGroupStatus_WAIT_GROUP_STATUS_INVALID
+	return mi.MessageOf(x)
 }
 
-func (x *WaitGroup) GetDeleteAfterFinishedSeconds() int64 {
+// Deprecated: Use WaitGroupJobAttempt.ProtoReflect.Descriptor instead.
+func (*WaitGroupJobAttempt) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *WaitGroupJobAttempt) GetCompletedAt() int64 {
 	if x != nil {
-		return x.DeleteAfterFinishedSeconds
+		return x.CompletedAt
 	}
 	return 0
 }
 
-func (x *WaitGroup) GetFinishedAt() int64 {
+func (x *WaitGroupJobAttempt) GetMetadata() map[string]string {
 	if x != nil {
-		return x.FinishedAt
+		return x.Metadata
 	}
-	return 0
+	return nil
 }
 
-func (x *WaitGroup) GetLastActivityAt() int64 {
+func (x *WaitGroupJobAttempt) GetFailed() bool {
 	if x != nil {
-		return x.LastActivityAt
+		return x.Failed
+	}
+	return false
+}
+
+func (x *WaitGroupJobAttempt) GetRetractedAt() int64 {
+	if x != nil {
+		return x.RetractedAt
 	}
 	return 0
 }
 
-// WaitGroupJob is one completed job recorded against a wait group. Jobs are
-// identified by a caller-supplied job_id, so reporting the same job twice is
-// idempotent and never double-counts toward the counter.
-type WaitGroupJob struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Id    *WaitGroupJobId        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// When the job was reported complete, Unix nanoseconds.
-	CompletedAt   int64             `protobuf:"fixed64,2,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+// WaitGroupJobChunk tracks which integer job ids of a 65536-id range of a wait
+// group with integer_job_ids are completed and which are failed. Each set is a
+// roaring-style container: a sorted array of big-endian uint16 offsets while it
+// holds fewer than 4096 ids, and an 8192-byte bitmap after that.
+type WaitGroupJobChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *WaitGroupJobChunkId   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CompletedJobs []byte                 `protobuf:"bytes,2,opt,name=completed_jobs,json=completedJobs,proto3" json:"completed_jobs,omitempty"`
+	FailedJobs    []byte                 `protobuf:"bytes,3,opt,name=failed_jobs,json=failedJobs,proto3" json:"failed_jobs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WaitGroupJob) Reset() {
-	*x = WaitGroupJob{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[22]
+func (x *WaitGroupJobChunk) Reset() {
+	*x = WaitGroupJobChunk{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WaitGroupJob) String() string {
+func (x *WaitGroupJobChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WaitGroupJob) ProtoMessage() {}
+func (*WaitGroupJobChunk) ProtoMessage() {}
 
-func (x *WaitGroupJob) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[22]
+func (x *WaitGroupJobChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1450,47 +3565,119 @@ func (x *WaitGroupJob) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WaitGroupJob.ProtoReflect.Descriptor instead.
-func (*WaitGroupJob) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use WaitGroupJobChunk.ProtoReflect.Descriptor instead.
+func (*WaitGroupJobChunk) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *WaitGroupJob) GetId() *WaitGroupJobId {
+func (x *WaitGroupJobChunk) GetId() *WaitGroupJobChunkId {
 	if x != nil {
 		return x.Id
 	}
 	return nil
 }
 
-func (x *WaitGroupJob) GetCompletedAt() int64 {
+func (x *WaitGroupJobChunk) GetCompletedJobs() []byte {
 	if x != nil {
-		return x.CompletedAt
+		return x.CompletedJobs
 	}
-	return 0
+	return nil
 }
 
-func (x *WaitGroupJob) GetMetadata() map[string]string {
+func (x *WaitGroupJobChunk) GetFailedJobs() []byte {
 	if x != nil {
-		return x.Metadata
+		return x.FailedJobs
 	}
 	return nil
 }
 
-// WaitGroupJobId uniquely identifies a completed job within a wait group.
-// job_id is the caller-supplied (free-form) job identifier.
-type WaitGroupJobId struct {
+// WaitGroupJobChunkId identifies a chunk of a wait group; chunk_index is the job
+// id divided by 65536.
+type WaitGroupJobChunkId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     uint64                 `protobuf:"fixed64,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	NamespaceId   uint64                 `protobuf:"fixed64,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
 	WaitGroupId   uint64                 `protobuf:"fixed64,3,opt,name=wait_group_id,json=waitGroupId,proto3" json:"wait_group_id,omitempty"`
-	JobId         string                 `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	ChunkIndex    uint64                 `protobuf:"varint,4,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitGroupJobChunkId) Reset() {
+	*x = WaitGroupJobChunkId{}
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitGroupJobChunkId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitGroupJobChunkId) ProtoMessage() {}
+
+func (x *WaitGroupJobChunkId) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitGroupJobChunkId.ProtoReflect.Descriptor instead.
+func (*WaitGroupJobChunkId) Descriptor() ([]byte, []int) {
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *WaitGroupJobChunkId) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *WaitGroupJobChunkId) GetNamespaceId() uint64 {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return 0
+}
+
+func (x *WaitGroupJobChunkId) GetWaitGroupId() uint64 {
+	if x != nil {
+		return x.WaitGroupId
+	}
+	return 0
+}
+
+func (x *WaitGroupJobChunkId) GetChunkIndex() uint64 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+// WaitGroupJobId uniquely identifies a completed job within a wait group.
+// job_id is the caller-supplied (free-form) job identifier.
+type WaitGroupJobId struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccountId   uint64                 `protobuf:"fixed64,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	NamespaceId uint64                 `protobuf:"fixed64,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WaitGroupId uint64                 `protobuf:"fixed64,3,opt,name=wait_group_id,json=waitGroupId,proto3" json:"wait_group_id,omitempty"`
+	JobId       string                 `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Stripe the job was completed on, for a striped wait group.
+	Stripe        uint32 `protobuf:"varint,5,opt,name=stripe,proto3" json:"stripe,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WaitGroupJobId) Reset() {
 	*x = WaitGroupJobId{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[23]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1502,7 +3689,7 @@ func (x *WaitGroupJobId) String() string {
 func (*WaitGroupJobId) ProtoMessage() {}
 
 func (x *WaitGroupJobId) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[23]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1515,7 +3702,7 @@ func (x *WaitGroupJobId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitGroupJobId.ProtoReflect.Descriptor instead.
 func (*WaitGroupJobId) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{23}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *WaitGroupJobId) GetAccountId() uint64 {
@@ -1546,6 +3733,13 @@ func (x *WaitGroupJobId) GetJobId() string {
 	return ""
 }
 
+func (x *WaitGroupJobId) GetStripe() uint32 {
+	if x != nil {
+		return x.Stripe
+	}
+	return 0
+}
+
 // WaitGroupId uniquely identifies a wait group within an account and namespace.
 type WaitGroupId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1558,7 +3752,7 @@ type WaitGroupId struct {
 
 func (x *WaitGroupId) Reset() {
 	*x = WaitGroupId{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[24]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1570,7 +3764,7 @@ func (x *WaitGroupId) String() string {
 func (*WaitGroupId) ProtoMessage() {}
 
 func (x *WaitGroupId) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[24]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1583,7 +3777,7 @@ func (x *WaitGroupId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitGroupId.ProtoReflect.Descriptor instead.
 func (*WaitGroupId) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{24}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *WaitGroupId) GetAccountId() uint64 {
@@ -1618,7 +3812,7 @@ type WaitGroupsCounter struct {
 
 func (x *WaitGroupsCounter) Reset() {
 	*x = WaitGroupsCounter{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[25]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1630,7 +3824,7 @@ func (x *WaitGroupsCounter) String() string {
 func (*WaitGroupsCounter) ProtoMessage() {}
 
 func (x *WaitGroupsCounter) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[25]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1643,7 +3837,7 @@ func (x *WaitGroupsCounter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitGroupsCounter.ProtoReflect.Descriptor instead.
 func (*WaitGroupsCounter) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{25}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *WaitGroupsCounter) GetNumberOfWaitGroups() int64 {
@@ -1670,7 +3864,7 @@ type WaitGroupsGarbageCollectionRecord struct {
 
 func (x *WaitGroupsGarbageCollectionRecord) Reset() {
 	*x = WaitGroupsGarbageCollectionRecord{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[26]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1682,7 +3876,7 @@ func (x *WaitGroupsGarbageCollectionRecord) String() string {
 func (*WaitGroupsGarbageCollectionRecord) ProtoMessage() {}
 
 func (x *WaitGroupsGarbageCollectionRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[26]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1695,7 +3889,7 @@ func (x *WaitGroupsGarbageCollectionRecord) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use WaitGroupsGarbageCollectionRecord.ProtoReflect.Descriptor instead.
 func (*WaitGroupsGarbageCollectionRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{26}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *WaitGroupsGarbageCollectionRecord) GetId() uint64 {
@@ -1759,7 +3953,7 @@ type WaitGroupsExpirationRecord struct {
 
 func (x *WaitGroupsExpirationRecord) Reset() {
 	*x = WaitGroupsExpirationRecord{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[27]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1771,7 +3965,7 @@ func (x *WaitGroupsExpirationRecord) String() string {
 func (*WaitGroupsExpirationRecord) ProtoMessage() {}
 
 func (x *WaitGroupsExpirationRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[27]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1784,7 +3978,7 @@ func (x *WaitGroupsExpirationRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitGroupsExpirationRecord.ProtoReflect.Descriptor instead.
 func (*WaitGroupsExpirationRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{27}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *WaitGroupsExpirationRecord) GetWaitGroupId() *WaitGroupId {
@@ -1814,7 +4008,7 @@ type WaitGroupsDeletionRecord struct {
 
 func (x *WaitGroupsDeletionRecord) Reset() {
 	*x = WaitGroupsDeletionRecord{}
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[28]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1826,7 +4020,7 @@ func (x *WaitGroupsDeletionRecord) String() string {
 func (*WaitGroupsDeletionRecord) ProtoMessage() {}
 
 func (x *WaitGroupsDeletionRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[28]
+	mi := &file_pkg_corepb_wait_groups_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1839,7 +4033,7 @@ func (x *WaitGroupsDeletionRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitGroupsDeletionRecord.ProtoReflect.Descriptor instead.
 func (*WaitGroupsDeletionRecord) Descriptor() ([]byte, []int) {
-	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{28}
+	return file_pkg_corepb_wait_groups_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *WaitGroupsDeletionRecord) GetWaitGroupId() *WaitGroupId {
@@ -1860,7 +4054,7 @@ var File_pkg_corepb_wait_groups_proto protoreflect.FileDescriptor
 
 const file_pkg_corepb_wait_groups_proto_rawDesc = "" +
 	"\n" +
-	"\x1cpkg/corepb/wait_groups.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\x84\x04\n" +
+	"\x1cpkg/corepb/wait_groups.proto\x12\x19com.evrblk.grackle.corepb\x1a\x17pkg/corepb/common.proto\x1a\x1bpkg/corepb/namespaces.proto\"\xa4\a\n" +
 	"\x16CreateWaitGroupRequest\x12J\n" +
 	"\rwait_group_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.WaitGroupIdR\vwaitGroupId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1870,10 +4064,19 @@ const file_pkg_corepb_wait_groups_proto_rawDesc = "" +
 	"expires_at\x18\x05 \x01(\x10R\texpiresAt\x12[\n" +
 	"\bmetadata\x18\x06 \x03(\v2?.com.evrblk.grackle.corepb.CreateWaitGroupRequest.MetadataEntryR\bmetadata\x12R\n" +
 	"'max_number_of_wait_groups_per_namespace\x18\a \x01(\x03R!maxNumberOfWaitGroupsPerNamespace\x12A\n" +
-	"\x1ddelete_after_finished_seconds\x18\b \x01(\x03R\x1adeleteAfterFinishedSeconds\x1a;\n" +
+	"\x1ddelete_after_finished_seconds\x18\b \x01(\x03R\x1adeleteAfterFinishedSeconds\x12+\n" +
+	"\x0fmax_failed_jobs\x18\t \x01(\x03H\x00R\rmaxFailedJobs\x88\x01\x01\x12#\n" +
+	"\rdeclared_jobs\x18\n" +
+	" \x01(\bR\fdeclaredJobs\x123\n" +
+	"\x16parent_wait_group_name\x18\v \x01(\tR\x13parentWaitGroupName\x12h\n" +
+	"\x14child_failure_policy\x18\f \x01(\x0e26.com.evrblk.grackle.corepb.WaitGroupChildFailurePolicyR\x12childFailurePolicy\x12W\n" +
+	"\faggregations\x18\r \x03(\v23.com.evrblk.grackle.corepb.WaitGroupAggregationSpecR\faggregations\x12&\n" +
+	"\x0finteger_job_ids\x18\x0e \x01(\bR\rintegerJobIds\x12\x18\n" +
+	"\astripes\x18\x0f \x01(\rR\astripes\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"^\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x12\n" +
+	"\x10_max_failed_jobs\"^\n" +
 	"\x17CreateWaitGroupResponse\x12C\n" +
 	"\n" +
 	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\xee\x03\n" +
@@ -1912,34 +4115,110 @@ const file_pkg_corepb_wait_groups_proto_rawDesc = "" +
 	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\"a\n" +
 	"\x1aGetWaitGroupByNameResponse\x12C\n" +
 	"\n" +
+	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\xa3\x01\n" +
+	"\x16CancelWaitGroupRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
+	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"^\n" +
+	"\x17CancelWaitGroupResponse\x12C\n" +
+	"\n" +
 	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\xa8\x01\n" +
 	"\x16DeleteWaitGroupRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
 	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12\x1b\n" +
-	"\trecord_id\x18\x03 \x01(\x06R\brecordId\"\x19\n" +
-	"\x17DeleteWaitGroupResponse\"\xd8\x01\n" +
+	"\trecord_id\x18\x03 \x01(\x06R\brecordId\"^\n" +
+	"\x17DeleteWaitGroupResponse\x12C\n" +
+	"\n" +
+	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\xd8\x01\n" +
 	" CompleteJobsFromWaitGroupRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
 	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12A\n" +
-	"\x04jobs\x18\x03 \x03(\v2-.com.evrblk.grackle.corepb.CompleteJobRequestR\x04jobs\"\xc1\x01\n" +
+	"\x04jobs\x18\x03 \x03(\v2-.com.evrblk.grackle.corepb.CompleteJobRequestR\x04jobs\"\xd9\x01\n" +
 	"\x12CompleteJobRequest\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12W\n" +
-	"\bmetadata\x18\x02 \x03(\v2;.com.evrblk.grackle.corepb.CompleteJobRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x02 \x03(\v2;.com.evrblk.grackle.corepb.CompleteJobRequest.MetadataEntryR\bmetadata\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\bR\x06failed\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"h\n" +
 	"!CompleteJobsFromWaitGroupResponse\x12C\n" +
 	"\n" +
-	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\x83\x02\n" +
+	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\xff\x01\n" +
+	"&CompleteJobsFromWaitGroupStripeRequest\x12I\n" +
+	"\tstripe_id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.WaitGroupStripeIdR\bstripeId\x12A\n" +
+	"\x04jobs\x18\x02 \x03(\v2-.com.evrblk.grackle.corepb.CompleteJobRequestR\x04jobs\x12\x1b\n" +
+	"\tdelete_at\x18\x03 \x01(\x10R\bdeleteAt\x12*\n" +
+	"\x11max_finished_jobs\x18\x04 \x01(\x03R\x0fmaxFinishedJobs\"m\n" +
+	"'CompleteJobsFromWaitGroupStripeResponse\x12B\n" +
+	"\x06stripe\x18\x01 \x01(\v2*.com.evrblk.grackle.corepb.WaitGroupStripeR\x06stripe\"\xc5\x01\n" +
+	" AggregateWaitGroupStripesRequest\x12J\n" +
+	"\rwait_group_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.WaitGroupIdR\vwaitGroupId\x12U\n" +
+	"\rstripe_counts\x18\x02 \x03(\v20.com.evrblk.grackle.corepb.WaitGroupStripeCountsR\fstripeCounts\"h\n" +
+	"!AggregateWaitGroupStripesResponse\x12C\n" +
+	"\n" +
+	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"f\n" +
+	"\x19GetWaitGroupStripeRequest\x12I\n" +
+	"\tstripe_id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.WaitGroupStripeIdR\bstripeId\"`\n" +
+	"\x1aGetWaitGroupStripeResponse\x12B\n" +
+	"\x06stripe\x18\x01 \x01(\v2*.com.evrblk.grackle.corepb.WaitGroupStripeR\x06stripe\"\x86\x01\n" +
+	"\x1cExtendWaitGroupStripeRequest\x12I\n" +
+	"\tstripe_id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.WaitGroupStripeIdR\bstripeId\x12\x1b\n" +
+	"\tdelete_at\x18\x02 \x01(\x10R\bdeleteAt\"\x1f\n" +
+	"\x1dExtendWaitGroupStripeResponse\"i\n" +
+	"\x1cDeleteWaitGroupStripeRequest\x12I\n" +
+	"\tstripe_id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.WaitGroupStripeIdR\bstripeId\"\x1f\n" +
+	"\x1dDeleteWaitGroupStripeResponse\"\xe1\x01\n" +
+	"'ListWaitGroupStripeCompletedJobsRequest\x12I\n" +
+	"\tstripe_id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.WaitGroupStripeIdR\bstripeId\x12U\n" +
+	"\x10pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xaf\x02\n" +
+	"(ListWaitGroupStripeCompletedJobsResponse\x12;\n" +
+	"\x04jobs\x18\x01 \x03(\v2'.com.evrblk.grackle.corepb.WaitGroupJobR\x04jobs\x12^\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\xcf\x01\n" +
+	"\x15AddToWaitGroupRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
+	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x03R\x05delta\x12-\n" +
+	"\x13max_wait_group_size\x18\x04 \x01(\x03R\x10maxWaitGroupSize\"]\n" +
+	"\x16AddToWaitGroupResponse\x12C\n" +
+	"\n" +
+	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\xfd\x01\n" +
+	"\"UncompleteJobsFromWaitGroupRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
+	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12\x17\n" +
+	"\ajob_ids\x18\x03 \x03(\tR\x06jobIds\x12\x16\n" +
+	"\x06reopen\x18\x04 \x01(\bR\x06reopen\x123\n" +
+	"\x16max_job_history_length\x18\x05 \x01(\x03R\x13maxJobHistoryLength\"j\n" +
+	"#UncompleteJobsFromWaitGroupResponse\x12C\n" +
+	"\n" +
+	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\xa7\x01\n" +
+	"\x19AddJobsToWaitGroupRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
+	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12\x17\n" +
+	"\ajob_ids\x18\x03 \x03(\tR\x06jobIds\"a\n" +
+	"\x1aAddJobsToWaitGroupResponse\x12C\n" +
+	"\n" +
+	"wait_group\x18\x01 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\twaitGroup\"\x81\x02\n" +
+	"\x1fListWaitGroupPendingJobsRequest\x12I\n" +
+	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
+	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12U\n" +
+	"\x10pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xa7\x02\n" +
+	" ListWaitGroupPendingJobsResponse\x12;\n" +
+	"\x04jobs\x18\x01 \x03(\v2'.com.evrblk.grackle.corepb.WaitGroupJobR\x04jobs\x12^\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\x83\x02\n" +
 	"!ListWaitGroupCompletedJobsRequest\x12I\n" +
 	"\fnamespace_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\x12&\n" +
 	"\x0fwait_group_name\x18\x02 \x01(\tR\rwaitGroupName\x12U\n" +
 	"\x10pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x0fpaginationToken\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xa9\x02\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xfd\x02\n" +
 	"\"ListWaitGroupCompletedJobsResponse\x12;\n" +
 	"\x04jobs\x18\x01 \x03(\v2'.com.evrblk.grackle.corepb.WaitGroupJobR\x04jobs\x12^\n" +
 	"\x15next_pagination_token\x18\x02 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x13nextPaginationToken\x12f\n" +
-	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\"\xcd\x01\n" +
+	"\x19previous_pagination_token\x18\x03 \x01(\v2*.com.evrblk.grackle.corepb.PaginationTokenR\x17previousPaginationToken\x12R\n" +
+	"\x12striped_wait_group\x18\x04 \x01(\v2$.com.evrblk.grackle.corepb.WaitGroupR\x10stripedWaitGroup\"\xcd\x01\n" +
 	"%RunWaitGroupsGarbageCollectionRequest\x12/\n" +
 	"\x14gc_records_page_size\x18\x01 \x01(\x03R\x11gcRecordsPageSize\x12C\n" +
 	"\x1fgc_record_wait_groups_page_size\x18\x02 \x01(\x03R\x1agcRecordWaitGroupsPageSize\x12.\n" +
@@ -1948,7 +4227,7 @@ const file_pkg_corepb_wait_groups_proto_rawDesc = "" +
 	" WaitGroupsDeleteNamespaceRequest\x12\x1b\n" +
 	"\trecord_id\x18\x01 \x01(\x06R\brecordId\x12I\n" +
 	"\fnamespace_id\x18\x02 \x01(\v2&.com.evrblk.grackle.corepb.NamespaceIdR\vnamespaceId\"#\n" +
-	"!WaitGroupsDeleteNamespaceResponse\"\x90\x05\n" +
+	"!WaitGroupsDeleteNamespaceResponse\"\xb9\f\n" +
 	"\tWaitGroup\x126\n" +
 	"\x02id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.WaitGroupIdR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1968,23 +4247,99 @@ const file_pkg_corepb_wait_groups_proto_rawDesc = "" +
 	"\x1ddelete_after_finished_seconds\x18\f \x01(\x03R\x1adeleteAfterFinishedSeconds\x12\x1f\n" +
 	"\vfinished_at\x18\r \x01(\x10R\n" +
 	"finishedAt\x12(\n" +
-	"\x10last_activity_at\x18\x0e \x01(\x10R\x0elastActivityAt\x1a;\n" +
+	"\x10last_activity_at\x18\x0e \x01(\x10R\x0elastActivityAt\x12\x1f\n" +
+	"\vfailed_jobs\x18\x0f \x01(\x03R\n" +
+	"failedJobs\x12+\n" +
+	"\x0fmax_failed_jobs\x18\x10 \x01(\x03H\x00R\rmaxFailedJobs\x88\x01\x01\x12#\n" +
+	"\rdeclared_jobs\x18\x11 \x01(\bR\fdeclaredJobs\x125\n" +
+	"\x17number_of_declared_jobs\x18\x12 \x01(\x03R\x14numberOfDeclaredJobs\x123\n" +
+	"\x16parent_wait_group_name\x18\x13 \x01(\tR\x13parentWaitGroupName\x12h\n" +
+	"\x14child_failure_policy\x18\x14 \x01(\x0e26.com.evrblk.grackle.corepb.WaitGroupChildFailurePolicyR\x12childFailurePolicy\x12/\n" +
+	"\x13cancellation_reason\x18\x15 \x01(\tR\x12cancellationReason\x12M\n" +
+	"\n" +
+	"aggregates\x18\x16 \x03(\v2-.com.evrblk.grackle.corepb.WaitGroupAggregateR\n" +
+	"aggregates\x12&\n" +
+	"\x0finteger_job_ids\x18\x17 \x01(\bR\rintegerJobIds\x12\x18\n" +
+	"\astripes\x18\x18 \x01(\rR\astripes\x12U\n" +
+	"\rstripe_counts\x18\x19 \x03(\v20.com.evrblk.grackle.corepb.WaitGroupStripeCountsR\fstripeCounts\x12'\n" +
+	"\x0fcompletion_rate\x18\x1a \x01(\x01R\x0ecompletionRate\x126\n" +
+	"\x17estimated_completion_at\x18\x1b \x01(\x10R\x15estimatedCompletionAt\x12;\n" +
+	"\x1acompletion_rate_updated_at\x18\x1c \x01(\x10R\x17completionRateUpdatedAt\x122\n" +
+	"\x15decayed_finished_jobs\x18\x1d \x01(\x01R\x13decayedFinishedJobs\x12/\n" +
+	"\x14parent_wait_group_id\x18\x1e \x01(\x06R\x11parentWaitGroupId\x12.\n" +
+	"\x13parent_report_error\x18\x1f \x01(\tR\x11parentReportError\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfc\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x12\n" +
+	"\x10_max_failed_jobs\"\xf2\x01\n" +
+	"\x0fWaitGroupStripe\x12<\n" +
+	"\x02id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.WaitGroupStripeIdR\x02id\x12%\n" +
+	"\x0ecompleted_jobs\x18\x02 \x01(\x03R\rcompletedJobs\x12\x1f\n" +
+	"\vfailed_jobs\x18\x03 \x01(\x03R\n" +
+	"failedJobs\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x10R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x10R\tupdatedAt\x12\x1b\n" +
+	"\tdelete_at\x18\x06 \x01(\x10R\bdeleteAt\"\x91\x01\n" +
+	"\x11WaitGroupStripeId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x06R\taccountId\x12!\n" +
+	"\fnamespace_id\x18\x02 \x01(\x06R\vnamespaceId\x12\"\n" +
+	"\rwait_group_id\x18\x03 \x01(\x06R\vwaitGroupId\x12\x16\n" +
+	"\x06stripe\x18\x04 \x01(\rR\x06stripe\"w\n" +
+	"\x15WaitGroupStripeCounts\x12\x16\n" +
+	"\x06stripe\x18\x01 \x01(\rR\x06stripe\x12%\n" +
+	"\x0ecompleted_jobs\x18\x02 \x01(\x03R\rcompletedJobs\x12\x1f\n" +
+	"\vfailed_jobs\x18\x03 \x01(\x03R\n" +
+	"failedJobs\"\x88\x01\n" +
+	"\x1eWaitGroupStripesDeletionRecord\x12I\n" +
+	"\tstripe_id\x18\x01 \x01(\v2,.com.evrblk.grackle.corepb.WaitGroupStripeIdR\bstripeId\x12\x1b\n" +
+	"\tdelete_at\x18\x02 \x01(\x10R\bdeleteAt\"\x92\x01\n" +
+	"\x18WaitGroupAggregationSpec\x12!\n" +
+	"\fmetadata_key\x18\x01 \x01(\tR\vmetadataKey\x12S\n" +
+	"\bfunction\x18\x02 \x01(\x0e27.com.evrblk.grackle.corepb.WaitGroupAggregationFunctionR\bfunction\"\xcc\x01\n" +
+	"\x12WaitGroupAggregate\x12!\n" +
+	"\fmetadata_key\x18\x01 \x01(\tR\vmetadataKey\x12S\n" +
+	"\bfunction\x18\x02 \x01(\x0e27.com.evrblk.grackle.corepb.WaitGroupAggregationFunctionR\bfunction\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x03R\x05value\x12(\n" +
+	"\x10number_of_values\x18\x04 \x01(\x03R\x0enumberOfValues\"\xde\x02\n" +
 	"\fWaitGroupJob\x129\n" +
 	"\x02id\x18\x01 \x01(\v2).com.evrblk.grackle.corepb.WaitGroupJobIdR\x02id\x12!\n" +
 	"\fcompleted_at\x18\x02 \x01(\x10R\vcompletedAt\x12Q\n" +
-	"\bmetadata\x18\x03 \x03(\v25.com.evrblk.grackle.corepb.WaitGroupJob.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x03 \x03(\v25.com.evrblk.grackle.corepb.WaitGroupJob.MetadataEntryR\bmetadata\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\bR\x06failed\x12H\n" +
+	"\ahistory\x18\x05 \x03(\v2..com.evrblk.grackle.corepb.WaitGroupJobAttemptR\ahistory\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8a\x02\n" +
+	"\x13WaitGroupJobAttempt\x12!\n" +
+	"\fcompleted_at\x18\x01 \x01(\x10R\vcompletedAt\x12X\n" +
+	"\bmetadata\x18\x02 \x03(\v2<.com.evrblk.grackle.corepb.WaitGroupJobAttempt.MetadataEntryR\bmetadata\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\bR\x06failed\x12!\n" +
+	"\fretracted_at\x18\x04 \x01(\x10R\vretractedAt\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9b\x01\n" +
+	"\x11WaitGroupJobChunk\x12>\n" +
+	"\x02id\x18\x01 \x01(\v2..com.evrblk.grackle.corepb.WaitGroupJobChunkIdR\x02id\x12%\n" +
+	"\x0ecompleted_jobs\x18\x02 \x01(\fR\rcompletedJobs\x12\x1f\n" +
+	"\vfailed_jobs\x18\x03 \x01(\fR\n" +
+	"failedJobs\"\x9c\x01\n" +
+	"\x13WaitGroupJobChunkId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x06R\taccountId\x12!\n" +
+	"\fnamespace_id\x18\x02 \x01(\x06R\vnamespaceId\x12\"\n" +
+	"\rwait_group_id\x18\x03 \x01(\x06R\vwaitGroupId\x12\x1f\n" +
+	"\vchunk_index\x18\x04 \x01(\x04R\n" +
+	"chunkIndex\"\xa5\x01\n" +
 	"\x0eWaitGroupJobId\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\x06R\taccountId\x12!\n" +
 	"\fnamespace_id\x18\x02 \x01(\x06R\vnamespaceId\x12\"\n" +
 	"\rwait_group_id\x18\x03 \x01(\x06R\vwaitGroupId\x12\x15\n" +
-	"\x06job_id\x18\x04 \x01(\tR\x05jobId\"s\n" +
+	"\x06job_id\x18\x04 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06stripe\x18\x05 \x01(\rR\x06stripe\"s\n" +
 	"\vWaitGroupId\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\x06R\taccountId\x12!\n" +
@@ -2003,12 +4358,24 @@ const file_pkg_corepb_wait_groups_proto_rawDesc = "" +
 	"expires_at\x18\x02 \x01(\x10R\texpiresAt\"\x83\x01\n" +
 	"\x18WaitGroupsDeletionRecord\x12J\n" +
 	"\rwait_group_id\x18\x01 \x01(\v2&.com.evrblk.grackle.corepb.WaitGroupIdR\vwaitGroupId\x12\x1b\n" +
-	"\tdelete_at\x18\x02 \x01(\x10R\bdeleteAt*\x8e\x01\n" +
+	"\tdelete_at\x18\x02 \x01(\x10R\bdeleteAt*\xc6\x01\n" +
+	"\x1cWaitGroupAggregationFunction\x12+\n" +
+	"'WAIT_GROUP_AGGREGATION_FUNCTION_INVALID\x10\x00\x12'\n" +
+	"#WAIT_GROUP_AGGREGATION_FUNCTION_SUM\x10\x01\x12'\n" +
+	"#WAIT_GROUP_AGGREGATION_FUNCTION_MIN\x10\x02\x12'\n" +
+	"#WAIT_GROUP_AGGREGATION_FUNCTION_MAX\x10\x03*\xcd\x01\n" +
 	"\x0fWaitGroupStatus\x12\x1d\n" +
 	"\x19WAIT_GROUP_STATUS_INVALID\x10\x00\x12\x1c\n" +
 	"\x18WAIT_GROUP_STATUS_ACTIVE\x10\x01\x12\x1d\n" +
 	"\x19WAIT_GROUP_STATUS_EXPIRED\x10\x02\x12\x1f\n" +
-	"\x1bWAIT_GROUP_STATUS_COMPLETED\x10\x03B&Z$github.com/evrblk/grackle/pkg/corepbb\x06proto3"
+	"\x1bWAIT_GROUP_STATUS_COMPLETED\x10\x03\x12\x1c\n" +
+	"\x18WAIT_GROUP_STATUS_FAILED\x10\x04\x12\x1f\n" +
+	"\x1bWAIT_GROUP_STATUS_CANCELLED\x10\x05*\xdf\x01\n" +
+	"\x1bWaitGroupChildFailurePolicy\x12+\n" +
+	"'WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID\x10\x00\x121\n" +
+	"-WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED\x10\x01\x124\n" +
+	"0WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED\x10\x02\x12*\n" +
+	"&WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE\x10\x03B&Z$github.com/evrblk/grackle/pkg/corepbb\x06proto3"
 
 var (
 	file_pkg_corepb_wait_groups_proto_rawDescOnce sync.Once
@@ -2022,88 +4389,164 @@ func file_pkg_corepb_wait_groups_proto_rawDescGZIP() []byte {
 	return file_pkg_corepb_wait_groups_proto_rawDescData
 }
 
-var file_pkg_corepb_wait_groups_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_corepb_wait_groups_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_pkg_corepb_wait_groups_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_pkg_corepb_wait_groups_proto_msgTypes = make([]protoimpl.MessageInfo, 66)
 var file_pkg_corepb_wait_groups_proto_goTypes = []any{
-	(WaitGroupStatus)(0),                           // 0: com.evrblk.grackle.corepb.WaitGroupStatus
-	(*CreateWaitGroupRequest)(nil),                 // 1: com.evrblk.grackle.corepb.CreateWaitGroupRequest
-	(*CreateWaitGroupResponse)(nil),                // 2: com.evrblk.grackle.corepb.CreateWaitGroupResponse
-	(*UpdateWaitGroupRequest)(nil),                 // 3: com.evrblk.grackle.corepb.UpdateWaitGroupRequest
-	(*UpdateWaitGroupResponse)(nil),                // 4: com.evrblk.grackle.corepb.UpdateWaitGroupResponse
-	(*ListWaitGroupsRequest)(nil),                  // 5: com.evrblk.grackle.corepb.ListWaitGroupsRequest
-	(*ListWaitGroupsResponse)(nil),                 // 6: com.evrblk.grackle.corepb.ListWaitGroupsResponse
-	(*GetWaitGroupRequest)(nil),                    // 7: com.evrblk.grackle.corepb.GetWaitGroupRequest
-	(*GetWaitGroupResponse)(nil),                   // 8: com.evrblk.grackle.corepb.GetWaitGroupResponse
-	(*GetWaitGroupByNameRequest)(nil),              // 9: com.evrblk.grackle.corepb.GetWaitGroupByNameRequest
-	(*GetWaitGroupByNameResponse)(nil),             // 10: com.evrblk.grackle.corepb.GetWaitGroupByNameResponse
-	(*DeleteWaitGroupRequest)(nil),                 // 11: com.evrblk.grackle.corepb.DeleteWaitGroupRequest
-	(*DeleteWaitGroupResponse)(nil),                // 12: com.evrblk.grackle.corepb.DeleteWaitGroupResponse
-	(*CompleteJobsFromWaitGroupRequest)(nil),       // 13: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupRequest
-	(*CompleteJobRequest)(nil),                     // 14: com.evrblk.grackle.corepb.CompleteJobRequest
-	(*CompleteJobsFromWaitGroupResponse)(nil),      // 15: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupResponse
-	(*ListWaitGroupCompletedJobsRequest)(nil),      // 16: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsRequest
-	(*ListWaitGroupCompletedJobsResponse)(nil),     // 17: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse
-	(*RunWaitGroupsGarbageCollectionRequest)(nil),  // 18: com.evrblk.grackle.corepb.RunWaitGroupsGarbageCollectionRequest
-	(*RunWaitGroupsGarbageCollectionResponse)(nil), // 19: com.evrblk.grackle.corepb.RunWaitGroupsGarbageCollectionResponse
-	(*WaitGroupsDeleteNamespaceRequest)(nil),       // 20: com.evrblk.grackle.corepb.WaitGroupsDeleteNamespaceRequest
-	(*WaitGroupsDeleteNamespaceResponse)(nil),      // 21: com.evrblk.grackle.corepb.WaitGroupsDeleteNamespaceResponse
-	(*WaitGroup)(nil),                              // 22: com.evrblk.grackle.corepb.WaitGroup
-	(*WaitGroupJob)(nil),                           // 23: com.evrblk.grackle.corepb.WaitGroupJob
-	(*WaitGroupJobId)(nil),                         // 24: com.evrblk.grackle.corepb.WaitGroupJobId
-	(*WaitGroupId)(nil),                            // 25: com.evrblk.grackle.corepb.WaitGroupId
-	(*WaitGroupsCounter)(nil),                      // 26: com.evrblk.grackle.corepb.WaitGroupsCounter
-	(*WaitGroupsGarbageCollectionRecord)(nil),      // 27: com.evrblk.grackle.corepb.WaitGroupsGarbageCollectionRecord
-	(*WaitGroupsExpirationRecord)(nil),             // 28: com.evrblk.grackle.corepb.WaitGroupsExpirationRecord
-	(*WaitGroupsDeletionRecord)(nil),               // 29: com.evrblk.grackle.corepb.WaitGroupsDeletionRecord
-	nil,                                            // 30: com.evrblk.grackle.corepb.CreateWaitGroupRequest.MetadataEntry
-	nil,                                            // 31: com.evrblk.grackle.corepb.UpdateWaitGroupRequest.MetadataEntry
-	nil,                                            // 32: com.evrblk.grackle.corepb.CompleteJobRequest.MetadataEntry
-	nil,                                            // 33: com.evrblk.grackle.corepb.WaitGroup.MetadataEntry
-	nil,                                            // 34: com.evrblk.grackle.corepb.WaitGroupJob.MetadataEntry
-	(*NamespaceId)(nil),                            // 35: com.evrblk.grackle.corepb.NamespaceId
-	(*PaginationToken)(nil),                        // 36: com.evrblk.grackle.corepb.PaginationToken
+	(WaitGroupAggregationFunction)(0),                // 0: com.evrblk.grackle.corepb.WaitGroupAggregationFunction
+	(WaitGroupStatus)(0),                             // 1: com.evrblk.grackle.corepb.WaitGroupStatus
+	(WaitGroupChildFailurePolicy)(0),                 // 2: com.evrblk.grackle.corepb.WaitGroupChildFailurePolicy
+	(*CreateWaitGroupRequest)(nil),                   // 3: com.evrblk.grackle.corepb.CreateWaitGroupRequest
+	(*CreateWaitGroupResponse)(nil),                  // 4: com.evrblk.grackle.corepb.CreateWaitGroupResponse
+	(*UpdateWaitGroupRequest)(nil),                   // 5: com.evrblk.grackle.corepb.UpdateWaitGroupRequest
+	(*UpdateWaitGroupResponse)(nil),                  // 6: com.evrblk.grackle.corepb.UpdateWaitGroupResponse
+	(*ListWaitGroupsRequest)(nil),                    // 7: com.evrblk.grackle.corepb.ListWaitGroupsRequest
+	(*ListWaitGroupsResponse)(nil),                   // 8: com.evrblk.grackle.corepb.ListWaitGroupsResponse
+	(*GetWaitGroupRequest)(nil),                      // 9: com.evrblk.grackle.corepb.GetWaitGroupRequest
+	(*GetWaitGroupResponse)(nil),                     // 10: com.evrblk.grackle.corepb.GetWaitGroupResponse
+	(*GetWaitGroupByNameRequest)(nil),                // 11: com.evrblk.grackle.corepb.GetWaitGroupByNameRequest
+	(*GetWaitGroupByNameResponse)(nil),               // 12: com.evrblk.grackle.corepb.GetWaitGroupByNameResponse
+	(*CancelWaitGroupRequest)(nil),                   // 13: com.evrblk.grackle.corepb.CancelWaitGroupRequest
+	(*CancelWaitGroupResponse)(nil),                  // 14: com.evrblk.grackle.corepb.CancelWaitGroupResponse
+	(*DeleteWaitGroupRequest)(nil),                   // 15: com.evrblk.grackle.corepb.DeleteWaitGroupRequest
+	(*DeleteWaitGroupResponse)(nil),                  // 16: com.evrblk.grackle.corepb.DeleteWaitGroupResponse
+	(*CompleteJobsFromWaitGroupRequest)(nil),         // 17: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupRequest
+	(*CompleteJobRequest)(nil),                       // 18: com.evrblk.grackle.corepb.CompleteJobRequest
+	(*CompleteJobsFromWaitGroupResponse)(nil),        // 19: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupResponse
+	(*CompleteJobsFromWaitGroupStripeRequest)(nil),   // 20: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupStripeRequest
+	(*CompleteJobsFromWaitGroupStripeResponse)(nil),  // 21: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupStripeResponse
+	(*AggregateWaitGroupStripesRequest)(nil),         // 22: com.evrblk.grackle.corepb.AggregateWaitGroupStripesRequest
+	(*AggregateWaitGroupStripesResponse)(nil),        // 23: com.evrblk.grackle.corepb.AggregateWaitGroupStripesResponse
+	(*GetWaitGroupStripeRequest)(nil),                // 24: com.evrblk.grackle.corepb.GetWaitGroupStripeRequest
+	(*GetWaitGroupStripeResponse)(nil),               // 25: com.evrblk.grackle.corepb.GetWaitGroupStripeResponse
+	(*ExtendWaitGroupStripeRequest)(nil),             // 26: com.evrblk.grackle.corepb.ExtendWaitGroupStripeRequest
+	(*ExtendWaitGroupStripeResponse)(nil),            // 27: com.evrblk.grackle.corepb.ExtendWaitGroupStripeResponse
+	(*DeleteWaitGroupStripeRequest)(nil),             // 28: com.evrblk.grackle.corepb.DeleteWaitGroupStripeRequest
+	(*DeleteWaitGroupStripeResponse)(nil),            // 29: com.evrblk.grackle.corepb.DeleteWaitGroupStripeResponse
+	(*ListWaitGroupStripeCompletedJobsRequest)(nil),  // 30: com.evrblk.grackle.corepb.ListWaitGroupStripeCompletedJobsRequest
+	(*ListWaitGroupStripeCompletedJobsResponse)(nil), // 31: com.evrblk.grackle.corepb.ListWaitGroupStripeCompletedJobsResponse
+	(*AddToWaitGroupRequest)(nil),                    // 32: com.evrblk.grackle.corepb.AddToWaitGroupRequest
+	(*AddToWaitGroupResponse)(nil),                   // 33: com.evrblk.grackle.corepb.AddToWaitGroupResponse
+	(*UncompleteJobsFromWaitGroupRequest)(nil),       // 34: com.evrblk.grackle.corepb.UncompleteJobsFromWaitGroupRequest
+	(*UncompleteJobsFromWaitGroupResponse)(nil),      // 35: com.evrblk.grackle.corepb.UncompleteJobsFromWaitGroupResponse
+	(*AddJobsToWaitGroupRequest)(nil),                // 36: com.evrblk.grackle.corepb.AddJobsToWaitGroupRequest
+	(*AddJobsToWaitGroupResponse)(nil),               // 37: com.evrblk.grackle.corepb.AddJobsToWaitGroupResponse
+	(*ListWaitGroupPendingJobsRequest)(nil),          // 38: com.evrblk.grackle.corepb.ListWaitGroupPendingJobsRequest
+	(*ListWaitGroupPendingJobsResponse)(nil),         // 39: com.evrblk.grackle.corepb.ListWaitGroupPendingJobsResponse
+	(*ListWaitGroupCompletedJobsRequest)(nil),        // 40: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsRequest
+	(*ListWaitGroupCompletedJobsResponse)(nil),       // 41: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse
+	(*RunWaitGroupsGarbageCollectionRequest)(nil),    // 42: com.evrblk.grackle.corepb.RunWaitGroupsGarbageCollectionRequest
+	(*RunWaitGroupsGarbageCollectionResponse)(nil),   // 43: com.evrblk.grackle.corepb.RunWaitGroupsGarbageCollectionResponse
+	(*WaitGroupsDeleteNamespaceRequest)(nil),         // 44: com.evrblk.grackle.corepb.WaitGroupsDeleteNamespaceRequest
+	(*WaitGroupsDeleteNamespaceResponse)(nil),        // 45: com.evrblk.grackle.corepb.WaitGroupsDeleteNamespaceResponse
+	(*WaitGroup)(nil),                                // 46: com.evrblk.grackle.corepb.WaitGroup
+	(*WaitGroupStripe)(nil),                          // 47: com.evrblk.grackle.corepb.WaitGroupStripe
+	(*WaitGroupStripeId)(nil),                        // 48: com.evrblk.grackle.corepb.WaitGroupStripeId
+	(*WaitGroupStripeCounts)(nil),                    // 49: com.evrblk.grackle.corepb.WaitGroupStripeCounts
+	(*WaitGroupStripesDeletionRecord)(nil),           // 50: com.evrblk.grackle.corepb.WaitGroupStripesDeletionRecord
+	(*WaitGroupAggregationSpec)(nil),                 // 51: com.evrblk.grackle.corepb.WaitGroupAggregationSpec
+	(*WaitGroupAggregate)(nil),                       // 52: com.evrblk.grackle.corepb.WaitGroupAggregate
+	(*WaitGroupJob)(nil),                             // 53: com.evrblk.grackle.corepb.WaitGroupJob
+	(*WaitGroupJobAttempt)(nil),                      // 54: com.evrblk.grackle.corepb.WaitGroupJobAttempt
+	(*WaitGroupJobChunk)(nil),                        // 55: com.evrblk.grackle.corepb.WaitGroupJobChunk
+	(*WaitGroupJobChunkId)(nil),                      // 56: com.evrblk.grackle.corepb.WaitGroupJobChunkId
+	(*WaitGroupJobId)(nil),                           // 57: com.evrblk.grackle.corepb.WaitGroupJobId
+	(*WaitGroupId)(nil),                              // 58: com.evrblk.grackle.corepb.WaitGroupId
+	(*WaitGroupsCounter)(nil),                        // 59: com.evrblk.grackle.corepb.WaitGroupsCounter
+	(*WaitGroupsGarbageCollectionRecord)(nil),        // 60: com.evrblk.grackle.corepb.WaitGroupsGarbageCollectionRecord
+	(*WaitGroupsExpirationRecord)(nil),               // 61: com.evrblk.grackle.corepb.WaitGroupsExpirationRecord
+	(*WaitGroupsDeletionRecord)(nil),                 // 62: com.evrblk.grackle.corepb.WaitGroupsDeletionRecord
+	nil,                                              // 63: com.evrblk.grackle.corepb.CreateWaitGroupRequest.MetadataEntry
+	nil,                                              // 64: com.evrblk.grackle.corepb.UpdateWaitGroupRequest.MetadataEntry
+	nil,                                              // 65: com.evrblk.grackle.corepb.CompleteJobRequest.MetadataEntry
+	nil,                                              // 66: com.evrblk.grackle.corepb.WaitGroup.MetadataEntry
+	nil,                                              // 67: com.evrblk.grackle.corepb.WaitGroupJob.MetadataEntry
+	nil,                                              // 68: com.evrblk.grackle.corepb.WaitGroupJobAttempt.MetadataEntry
+	(*NamespaceId)(nil),                              // 69: com.evrblk.grackle.corepb.NamespaceId
+	(*PaginationToken)(nil),                          // 70: com.evrblk.grackle.corepb.PaginationToken
 }
 var file_pkg_corepb_wait_groups_proto_depIdxs = []int32{
-	25, // 0: com.evrblk.grackle.corepb.CreateWaitGroupRequest.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
-	30, // 1: com.evrblk.grackle.corepb.CreateWaitGroupRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateWaitGroupRequest.MetadataEntry
-	22, // 2: com.evrblk.grackle.corepb.CreateWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
-	35, // 3: com.evrblk.grackle.corepb.UpdateWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	31, // 4: com.evrblk.grackle.corepb.UpdateWaitGroupRequest.metadata:type_name -> com.evrblk.grackle.corepb.UpdateWaitGroupRequest.MetadataEntry
-	22, // 5: com.evrblk.grackle.corepb.UpdateWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
-	35, // 6: com.evrblk.grackle.corepb.ListWaitGroupsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	36, // 7: com.evrblk.grackle.corepb.ListWaitGroupsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	22, // 8: com.evrblk.grackle.corepb.ListWaitGroupsResponse.wait_groups:type_name -> com.evrblk.grackle.corepb.WaitGroup
-	36, // 9: com.evrblk.grackle.corepb.ListWaitGroupsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	36, // 10: com.evrblk.grackle.corepb.ListWaitGroupsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	25, // 11: com.evrblk.grackle.corepb.GetWaitGroupRequest.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
-	22, // 12: com.evrblk.grackle.corepb.GetWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
-	35, // 13: com.evrblk.grackle.corepb.GetWaitGroupByNameRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	22, // 14: com.evrblk.grackle.corepb.GetWaitGroupByNameResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
-	35, // 15: com.evrblk.grackle.corepb.DeleteWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	35, // 16: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	14, // 17: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupRequest.jobs:type_name -> com.evrblk.grackle.corepb.CompleteJobRequest
-	32, // 18: com.evrblk.grackle.corepb.CompleteJobRequest.metadata:type_name -> com.evrblk.grackle.corepb.CompleteJobRequest.MetadataEntry
-	22, // 19: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
-	35, // 20: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	36, // 21: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	23, // 22: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse.jobs:type_name -> com.evrblk.grackle.corepb.WaitGroupJob
-	36, // 23: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	36, // 24: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
-	35, // 25: com.evrblk.grackle.corepb.WaitGroupsDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	25, // 26: com.evrblk.grackle.corepb.WaitGroup.id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
-	33, // 27: com.evrblk.grackle.corepb.WaitGroup.metadata:type_name -> com.evrblk.grackle.corepb.WaitGroup.MetadataEntry
-	0,  // 28: com.evrblk.grackle.corepb.WaitGroup.status:type_name -> com.evrblk.grackle.corepb.WaitGroupStatus
-	24, // 29: com.evrblk.grackle.corepb.WaitGroupJob.id:type_name -> com.evrblk.grackle.corepb.WaitGroupJobId
-	34, // 30: com.evrblk.grackle.corepb.WaitGroupJob.metadata:type_name -> com.evrblk.grackle.corepb.WaitGroupJob.MetadataEntry
-	35, // 31: com.evrblk.grackle.corepb.WaitGroupsGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
-	25, // 32: com.evrblk.grackle.corepb.WaitGroupsGarbageCollectionRecord.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
-	25, // 33: com.evrblk.grackle.corepb.WaitGroupsExpirationRecord.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
-	25, // 34: com.evrblk.grackle.corepb.WaitGroupsDeletionRecord.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
-	35, // [35:35] is the sub-list for method output_type
-	35, // [35:35] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	58, // 0: com.evrblk.grackle.corepb.CreateWaitGroupRequest.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
+	63, // 1: com.evrblk.grackle.corepb.CreateWaitGroupRequest.metadata:type_name -> com.evrblk.grackle.corepb.CreateWaitGroupRequest.MetadataEntry
+	2,  // 2: com.evrblk.grackle.corepb.CreateWaitGroupRequest.child_failure_policy:type_name -> com.evrblk.grackle.corepb.WaitGroupChildFailurePolicy
+	51, // 3: com.evrblk.grackle.corepb.CreateWaitGroupRequest.aggregations:type_name -> com.evrblk.grackle.corepb.WaitGroupAggregationSpec
+	46, // 4: com.evrblk.grackle.corepb.CreateWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 5: com.evrblk.grackle.corepb.UpdateWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	64, // 6: com.evrblk.grackle.corepb.UpdateWaitGroupRequest.metadata:type_name -> com.evrblk.grackle.corepb.UpdateWaitGroupRequest.MetadataEntry
+	46, // 7: com.evrblk.grackle.corepb.UpdateWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 8: com.evrblk.grackle.corepb.ListWaitGroupsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	70, // 9: com.evrblk.grackle.corepb.ListWaitGroupsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	46, // 10: com.evrblk.grackle.corepb.ListWaitGroupsResponse.wait_groups:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	70, // 11: com.evrblk.grackle.corepb.ListWaitGroupsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	70, // 12: com.evrblk.grackle.corepb.ListWaitGroupsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	58, // 13: com.evrblk.grackle.corepb.GetWaitGroupRequest.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
+	46, // 14: com.evrblk.grackle.corepb.GetWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 15: com.evrblk.grackle.corepb.GetWaitGroupByNameRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 16: com.evrblk.grackle.corepb.GetWaitGroupByNameResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 17: com.evrblk.grackle.corepb.CancelWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 18: com.evrblk.grackle.corepb.CancelWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 19: com.evrblk.grackle.corepb.DeleteWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 20: com.evrblk.grackle.corepb.DeleteWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 21: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	18, // 22: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupRequest.jobs:type_name -> com.evrblk.grackle.corepb.CompleteJobRequest
+	65, // 23: com.evrblk.grackle.corepb.CompleteJobRequest.metadata:type_name -> com.evrblk.grackle.corepb.CompleteJobRequest.MetadataEntry
+	46, // 24: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	48, // 25: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupStripeRequest.stripe_id:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeId
+	18, // 26: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupStripeRequest.jobs:type_name -> com.evrblk.grackle.corepb.CompleteJobRequest
+	47, // 27: com.evrblk.grackle.corepb.CompleteJobsFromWaitGroupStripeResponse.stripe:type_name -> com.evrblk.grackle.corepb.WaitGroupStripe
+	58, // 28: com.evrblk.grackle.corepb.AggregateWaitGroupStripesRequest.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
+	49, // 29: com.evrblk.grackle.corepb.AggregateWaitGroupStripesRequest.stripe_counts:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeCounts
+	46, // 30: com.evrblk.grackle.corepb.AggregateWaitGroupStripesResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	48, // 31: com.evrblk.grackle.corepb.GetWaitGroupStripeRequest.stripe_id:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeId
+	47, // 32: com.evrblk.grackle.corepb.GetWaitGroupStripeResponse.stripe:type_name -> com.evrblk.grackle.corepb.WaitGroupStripe
+	48, // 33: com.evrblk.grackle.corepb.ExtendWaitGroupStripeRequest.stripe_id:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeId
+	48, // 34: com.evrblk.grackle.corepb.DeleteWaitGroupStripeRequest.stripe_id:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeId
+	48, // 35: com.evrblk.grackle.corepb.ListWaitGroupStripeCompletedJobsRequest.stripe_id:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeId
+	70, // 36: com.evrblk.grackle.corepb.ListWaitGroupStripeCompletedJobsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	53, // 37: com.evrblk.grackle.corepb.ListWaitGroupStripeCompletedJobsResponse.jobs:type_name -> com.evrblk.grackle.corepb.WaitGroupJob
+	70, // 38: com.evrblk.grackle.corepb.ListWaitGroupStripeCompletedJobsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	70, // 39: com.evrblk.grackle.corepb.ListWaitGroupStripeCompletedJobsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	69, // 40: com.evrblk.grackle.corepb.AddToWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 41: com.evrblk.grackle.corepb.AddToWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 42: com.evrblk.grackle.corepb.UncompleteJobsFromWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 43: com.evrblk.grackle.corepb.UncompleteJobsFromWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 44: com.evrblk.grackle.corepb.AddJobsToWaitGroupRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	46, // 45: com.evrblk.grackle.corepb.AddJobsToWaitGroupResponse.wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 46: com.evrblk.grackle.corepb.ListWaitGroupPendingJobsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	70, // 47: com.evrblk.grackle.corepb.ListWaitGroupPendingJobsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	53, // 48: com.evrblk.grackle.corepb.ListWaitGroupPendingJobsResponse.jobs:type_name -> com.evrblk.grackle.corepb.WaitGroupJob
+	70, // 49: com.evrblk.grackle.corepb.ListWaitGroupPendingJobsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	70, // 50: com.evrblk.grackle.corepb.ListWaitGroupPendingJobsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	69, // 51: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	70, // 52: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsRequest.pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	53, // 53: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse.jobs:type_name -> com.evrblk.grackle.corepb.WaitGroupJob
+	70, // 54: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse.next_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	70, // 55: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse.previous_pagination_token:type_name -> com.evrblk.grackle.corepb.PaginationToken
+	46, // 56: com.evrblk.grackle.corepb.ListWaitGroupCompletedJobsResponse.striped_wait_group:type_name -> com.evrblk.grackle.corepb.WaitGroup
+	69, // 57: com.evrblk.grackle.corepb.WaitGroupsDeleteNamespaceRequest.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	58, // 58: com.evrblk.grackle.corepb.WaitGroup.id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
+	66, // 59: com.evrblk.grackle.corepb.WaitGroup.metadata:type_name -> com.evrblk.grackle.corepb.WaitGroup.MetadataEntry
+	1,  // 60: com.evrblk.grackle.corepb.WaitGroup.status:type_name -> com.evrblk.grackle.corepb.WaitGroupStatus
+	2,  // 61: com.evrblk.grackle.corepb.WaitGroup.child_failure_policy:type_name -> com.evrblk.grackle.corepb.WaitGroupChildFailurePolicy
+	52, // 62: com.evrblk.grackle.corepb.WaitGroup.aggregates:type_name -> com.evrblk.grackle.corepb.WaitGroupAggregate
+	49, // 63: com.evrblk.grackle.corepb.WaitGroup.stripe_counts:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeCounts
+	48, // 64: com.evrblk.grackle.corepb.WaitGroupStripe.id:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeId
+	48, // 65: com.evrblk.grackle.corepb.WaitGroupStripesDeletionRecord.stripe_id:type_name -> com.evrblk.grackle.corepb.WaitGroupStripeId
+	0,  // 66: com.evrblk.grackle.corepb.WaitGroupAggregationSpec.function:type_name -> com.evrblk.grackle.corepb.WaitGroupAggregationFunction
+	0,  // 67: com.evrblk.grackle.corepb.WaitGroupAggregate.function:type_name -> com.evrblk.grackle.corepb.WaitGroupAggregationFunction
+	57, // 68: com.evrblk.grackle.corepb.WaitGroupJob.id:type_name -> com.evrblk.grackle.corepb.WaitGroupJobId
+	67, // 69: com.evrblk.grackle.corepb.WaitGroupJob.metadata:type_name -> com.evrblk.grackle.corepb.WaitGroupJob.MetadataEntry
+	54, // 70: com.evrblk.grackle.corepb.WaitGroupJob.history:type_name -> com.evrblk.grackle.corepb.WaitGroupJobAttempt
+	68, // 71: com.evrblk.grackle.corepb.WaitGroupJobAttempt.metadata:type_name -> com.evrblk.grackle.corepb.WaitGroupJobAttempt.MetadataEntry
+	56, // 72: com.evrblk.grackle.corepb.WaitGroupJobChunk.id:type_name -> com.evrblk.grackle.corepb.WaitGroupJobChunkId
+	69, // 73: com.evrblk.grackle.corepb.WaitGroupsGarbageCollectionRecord.namespace_id:type_name -> com.evrblk.grackle.corepb.NamespaceId
+	58, // 74: com.evrblk.grackle.corepb.WaitGroupsGarbageCollectionRecord.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
+	58, // 75: com.evrblk.grackle.corepb.WaitGroupsExpirationRecord.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
+	58, // 76: com.evrblk.grackle.corepb.WaitGroupsDeletionRecord.wait_group_id:type_name -> com.evrblk.grackle.corepb.WaitGroupId
+	77, // [77:77] is the sub-list for method output_type
+	77, // [77:77] is the sub-list for method input_type
+	77, // [77:77] is the sub-list for extension type_name
+	77, // [77:77] is the sub-list for extension extendee
+	0,  // [0:77] is the sub-list for field type_name
 }
 
 func init() { file_pkg_corepb_wait_groups_proto_init() }
@@ -2113,7 +4556,9 @@ func file_pkg_corepb_wait_groups_proto_init() {
 	}
 	file_pkg_corepb_common_proto_init()
 	file_pkg_corepb_namespaces_proto_init()
-	file_pkg_corepb_wait_groups_proto_msgTypes[26].OneofWrappers = []any{
+	file_pkg_corepb_wait_groups_proto_msgTypes[0].OneofWrappers = []any{}
+	file_pkg_corepb_wait_groups_proto_msgTypes[43].OneofWrappers = []any{}
+	file_pkg_corepb_wait_groups_proto_msgTypes[57].OneofWrappers = []any{
 		(*WaitGroupsGarbageCollectionRecord_NamespaceId)(nil),
 		(*WaitGroupsGarbageCollectionRecord_WaitGroupId)(nil),
 	}
@@ -2122,8 +4567,8 @@ func file_pkg_corepb_wait_groups_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_corepb_wait_groups_proto_rawDesc), len(file_pkg_corepb_wait_groups_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   34,
+			NumEnums:      3,
+			NumMessages:   66,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
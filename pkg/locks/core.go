@@ -31,6 +31,7 @@ type Core struct {
 
 	locks     *locksTable
 	ancestors *lockAncestorsTable
+	waiters   *lockWaitersTable
 	counters  *tables.CountersTable[*corepb.LocksCounter, corepb.LocksCounter]
 	gcRecords *tables.GCRecordsTable[*corepb.LocksGarbageCollectionRecord, corepb.LocksGarbageCollectionRecord]
 	leases    *tables.LeasesTable
@@ -56,6 +57,7 @@ func NewCore(badgerStore *store.BadgerStore, replicaPrefix []byte, shardLowerBou
 
 		locks:     newLocksTable(replicaPrefix),
 		ancestors: newLockAncestorsTable(replicaPrefix),
+		waiters:   newLockWaitersTable(replicaPrefix),
 		counters: tables.NewCountersTable[*corepb.LocksCounter, corepb.LocksCounter](
 			utils.ConcatBytes(replicaPrefix, tablePrefixCounters),
 		),
@@ -80,6 +82,7 @@ func (c *Core) snapshotSections() []tables.Section {
 	return []tables.Section{
 		{Name: "Locks", Table: c.locks},
 		{Name: "Ancestors", Table: c.ancestors},
+		{Name: "Waiters", Table: c.waiters},
 		{Name: "Counters", Table: c.counters},
 		{Name: "Leases", Table: c.leases},
 		{Name: "GarbageCollectionRecords", Table: c.gcRecords},
@@ -108,11 +111,18 @@ func (c *Core) Restore(readers ...io.ReadCloser) error {
 // holders are filtered out of the returned lock before it is returned to the
 // caller. This runs on a read-only transaction: expired rows are not deleted
 // here — that is left to the GC. If every holder has expired the returned lock
-// has state UNLOCKED.
+// has state UNLOCKED. The response also counts the leases waiting for the lock,
+// which may be non-zero even for a lock without a record (e.g. one blocked by
+// an ancestor).
 func (c *Core) GetLock(req *coreapis.GetLockRequest) (*coreapis.GetLockResponse, error) {
 	txn := c.badgerStore.View()
 	defer txn.Discard()
 
+	waitersCount, err := c.waiters.Count(txn, req.Payload.LockId)
+	if err != nil {
+		return nil, err
+	}
+
 	lock, err := c.locks.Get(txn, req.Payload.LockId)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
@@ -124,6 +134,7 @@ func (c *Core) GetLock(req *coreapis.GetLockRequest) (*coreapis.GetLockResponse,
 						State:    corepb.LockState_LOCK_STATE_UNLOCKED,
 						LockedAt: 0,
 					},
+					WaitersCount: waitersCount,
 				},
 			}, nil
 		}
@@ -139,7 +150,33 @@ func (c *Core) GetLock(req *coreapis.GetLockRequest) (*coreapis.GetLockResponse,
 
 	return &coreapis.GetLockResponse{
 		Payload: &corepb.GetLockResponse{
-			Lock: updatedLock,
+			Lock:         updatedLock,
+			WaitersCount: waitersCount,
+		},
+	}, nil
+}
+
+// ListLockWaiters returns a page of the leases currently blocked in AcquireLock
+// on the named lock, sorted by lease id. Waiters that lapsed (as observed
+// against req.Now) are filtered out; deleting them is left to the GC.
+func (c *Core) ListLockWaiters(req *coreapis.ListLockWaitersRequest) (*coreapis.ListLockWaitersResponse, error) {
+	txn := c.badgerStore.View()
+	defer txn.Discard()
+
+	result, err := c.waiters.List(txn, req.Payload.LockId, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	waiters := lo.Filter(result.waiters, func(w *corepb.LockWaiter, _ int) bool {
+		return w.ExpiresAt > req.Now
+	})
+
+	return &coreapis.ListLockWaitersResponse{
+		Payload: &corepb.ListLockWaitersResponse{
+			Waiters:                 waiters,
+			NextPaginationToken:     result.nextPaginationToken,
+			PreviousPaginationToken: result.previousPaginationToken,
 		},
 	}, nil
 }
@@ -233,13 +270,28 @@ func (c *Core) DeleteLock(req *coreapis.DeleteLockRequest) (*coreapis.DeleteLock
 
 // AcquireLock attempts to acquire the named lock for the given lease in
 // either shared or exclusive mode. If the lease already holds the lock, its
-// LockedAt is refreshed and the call succeeds. If the lock is held in an
+// LockedAt (and max hold time, when MaxHoldSeconds is set) is refreshed and
+// the call succeeds. If the lock is held in an
 // incompatible mode (e.g. shared lock requested while held exclusively, or
 // any conflicting hierarchical ancestor/descendant lock), Payload.Success is
-// false and no state changes. Returns a NotFound application error if the
-// lease is missing or expired, or ResourceExhausted if creating a new lock
-// would exceed MaxNumberOfLocksPerNamespace.
+// false and the lock does not change; while WaitUntil is in the future the
+// lease is recorded as a waiter on the lock instead (see updateLockWaiter).
+// Returns a NotFound application error if the lease is missing or expired, or
+// ResourceExhausted if creating a new lock would exceed
+// MaxNumberOfLocksPerNamespace.
 func (c *Core) AcquireLock(req *coreapis.AcquireLockRequest) (*coreapis.AcquireLockResponse, error) {
+	if req.Payload.MaxHoldSeconds < 0 {
+		return &coreapis.AcquireLockResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"max hold seconds must not be negative",
+				map[string]string{
+					"max_hold_seconds": fmt.Sprintf("%d", req.Payload.MaxHoldSeconds),
+				},
+			),
+		}, nil
+	}
+
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
 
@@ -330,20 +382,24 @@ func (c *Core) AcquireLock(req *coreapis.AcquireLockRequest) (*coreapis.AcquireL
 	}
 	if conflictReason != corepb.ContentionReason_CONTENTION_REASON_UNSPECIFIED {
 		// Hierarchical conflict detected - return failure
-		return &coreapis.AcquireLockResponse{
-			Payload: &corepb.AcquireLockResponse{
-				Lock:          updatedLock,
-				Success:       false,
-				Reason:        conflictReason,
-				BlockingLocks: blockingLocks,
-			},
-		}, nil
+		return c.acquireLockFailed(txn, req, lease, &corepb.AcquireLockResponse{
+			Lock:          updatedLock,
+			Success:       false,
+			Reason:        conflictReason,
+			BlockingLocks: blockingLocks,
+		})
+	}
+
+	holdExpiresAt := int64(0)
+	if req.Payload.MaxHoldSeconds > 0 {
+		holdExpiresAt = req.Now + req.Payload.MaxHoldSeconds*1e9
 	}
 
 	lockHolder := &corepb.LockHolder{
-		LeaseId:  req.Payload.LeaseId,
-		LockedAt: req.Now,
-		Metadata: req.Payload.Metadata,
+		LeaseId:       req.Payload.LeaseId,
+		LockedAt:      req.Now,
+		Metadata:      req.Payload.Metadata,
+		HoldExpiresAt: holdExpiresAt,
 	}
 
 	switch updatedLock.State {
@@ -359,15 +415,13 @@ func (c *Core) AcquireLock(req *coreapis.AcquireLockRequest) (*coreapis.AcquireL
 		updatedLock.LockedAt = req.Now
 	case corepb.LockState_LOCK_STATE_SHARED_LOCKED:
 		if req.Payload.Exclusive {
-			return &coreapis.AcquireLockResponse{
-				Payload: &corepb.AcquireLockResponse{
-					Lock:    updatedLock,
-					Success: false, // Already locked for reads, cannot be locked for writes.
-					Reason:  corepb.ContentionReason_CONTENTION_REASON_PEER,
-					// BlockingLocks is left empty for PEER: the conflicting lock is
-					// already returned in the Lock field above.
-				},
-			}, nil
+			return c.acquireLockFailed(txn, req, lease, &corepb.AcquireLockResponse{
+				Lock:    updatedLock,
+				Success: false, // Already locked for reads, cannot be locked for writes.
+				Reason:  corepb.ContentionReason_CONTENTION_REASON_PEER,
+				// BlockingLocks is left empty for PEER: the conflicting lock is
+				// already returned in the Lock field above.
+			})
 		}
 
 		// Already locked for reads.
@@ -378,6 +432,7 @@ func (c *Core) AcquireLock(req *coreapis.AcquireLockRequest) (*coreapis.AcquireL
 		if ok {
 			// Update locked_at time (refresh lock acquisition time)
 			existingHolder.LockedAt = req.Now
+			existingHolder.HoldExpiresAt = holdExpiresAt
 		} else {
 			// Add the new lock holder
 			updatedLock.LockHolders = append(updatedLock.LockHolders, lockHolder)
@@ -389,28 +444,25 @@ func (c *Core) AcquireLock(req *coreapis.AcquireLockRequest) (*coreapis.AcquireL
 				// This lease already holds the lock, repeated locks are considered successful
 				// Update locked_at time (refresh lock acquisition time)
 				updatedLock.LockHolders[0].LockedAt = req.Now
+				updatedLock.LockHolders[0].HoldExpiresAt = holdExpiresAt
 			} else {
-				return &coreapis.AcquireLockResponse{
-					Payload: &corepb.AcquireLockResponse{
-						Lock:    updatedLock,
-						Success: false, // The lock is held by another lease
-						Reason:  corepb.ContentionReason_CONTENTION_REASON_PEER,
-						// BlockingLocks is left empty for PEER: the conflicting lock
-						// is already returned in the Lock field above.
-					},
-				}, nil
+				return c.acquireLockFailed(txn, req, lease, &corepb.AcquireLockResponse{
+					Lock:    updatedLock,
+					Success: false, // The lock is held by another lease
+					Reason:  corepb.ContentionReason_CONTENTION_REASON_PEER,
+					// BlockingLocks is left empty for PEER: the conflicting lock
+					// is already returned in the Lock field above.
+				})
 			}
 
 		} else {
-			return &coreapis.AcquireLockResponse{
-				Payload: &corepb.AcquireLockResponse{
-					Lock:    updatedLock,
-					Success: false, // Already locked for writes, cannot be locked for reads.
-					Reason:  corepb.ContentionReason_CONTENTION_REASON_PEER,
-					// BlockingLocks is left empty for PEER: the conflicting lock is
-					// already returned in the Lock field above.
-				},
-			}, nil
+			return c.acquireLockFailed(txn, req, lease, &corepb.AcquireLockResponse{
+				Lock:    updatedLock,
+				Success: false, // Already locked for writes, cannot be locked for reads.
+				Reason:  corepb.ContentionReason_CONTENTION_REASON_PEER,
+				// BlockingLocks is left empty for PEER: the conflicting lock is
+				// already returned in the Lock field above.
+			})
 
 		}
 	default:
@@ -451,6 +503,12 @@ func (c *Core) AcquireLock(req *coreapis.AcquireLockRequest) (*coreapis.AcquireL
 		return nil, err
 	}
 
+	// The lease is not waiting anymore
+	err = c.updateLockWaiter(txn, req, lease, true)
+	if err != nil {
+		return nil, err
+	}
+
 	err = txn.Commit()
 	if err != nil {
 		return nil, err
@@ -464,6 +522,56 @@ func (c *Core) AcquireLock(req *coreapis.AcquireLockRequest) (*coreapis.AcquireL
 	}, nil
 }
 
+// acquireLockFailed returns an unsuccessful acquire. Only the lease's waiter
+// record is saved; the lock and the counters stay as they are.
+func (c *Core) acquireLockFailed(txn *store.Txn, req *coreapis.AcquireLockRequest, lease *corepb.Lease, payload *corepb.AcquireLockResponse) (*coreapis.AcquireLockResponse, error) {
+	err := c.updateLockWaiter(txn, req, lease, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.AcquireLockResponse{
+		Payload: payload,
+	}, nil
+}
+
+// updateLockWaiter keeps the lease's waiter record on the lock in line with an
+// acquire attempt: the lease stays a waiter while the attempt failed and the
+// caller keeps retrying until WaitUntil, and is forgotten otherwise. The record
+// lapses at the end of the wait or of the lease, whichever comes first, in case
+// the caller goes away without a final attempt.
+func (c *Core) updateLockWaiter(txn *store.Txn, req *coreapis.AcquireLockRequest, lease *corepb.Lease, acquired bool) error {
+	waiter, err := c.waiters.Get(txn, req.Payload.LockId, req.Payload.LeaseId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	if acquired || req.Payload.WaitUntil <= req.Now {
+		if waiter == nil {
+			return nil
+		}
+		return c.waiters.Delete(txn, waiter)
+	}
+
+	if waiter == nil {
+		waiter = &corepb.LockWaiter{
+			LockId:       req.Payload.LockId,
+			LeaseId:      req.Payload.LeaseId,
+			WaitingSince: req.Now,
+		}
+	}
+	waiter.Exclusive = req.Payload.Exclusive
+	waiter.ProcessId = lease.ProcessId
+	waiter.ExpiresAt = min(req.Payload.WaitUntil, lease.ExpiresAt)
+
+	return c.waiters.Set(txn, waiter)
+}
+
 // ReleaseLock drops the given lease's hold on the named lock. For shared
 // locks only that lease's holder entry is removed; the lock row is deleted
 // once the last holder leaves. For exclusive locks the row is deleted if
@@ -599,9 +707,130 @@ func (c *Core) ReleaseLock(req *coreapis.ReleaseLockRequest) (*coreapis.ReleaseL
 	}, nil
 }
 
+// TransferLock moves the hold of FromLeaseId on the named lock to ToLeaseId in
+// one step, so the lock is never free in between. The holder keeps its
+// LockedAt, metadata and max hold time; only the owning lease changes. Both
+// leases must be alive. Returns NotFound application errors for a missing or
+// expired lease or when FromLeaseId does not hold the lock, and InvalidRequest
+// when both leases are the same or ToLeaseId already holds the lock.
+func (c *Core) TransferLock(req *coreapis.TransferLockRequest) (*coreapis.TransferLockResponse, error) {
+	if req.Payload.FromLeaseId == req.Payload.ToLeaseId {
+		return &coreapis.TransferLockResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"cannot transfer a lock to the same lease",
+				map[string]string{
+					"lease_id": fmt.Sprintf("%d", req.Payload.FromLeaseId),
+				},
+			),
+		}, nil
+	}
+
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	// Both leases must be alive
+	for _, leaseId := range []uint64{req.Payload.FromLeaseId, req.Payload.ToLeaseId} {
+		lease, err := c.leases.Get(txn, &corepb.LeaseId{
+			AccountId:   req.Payload.LockId.AccountId,
+			NamespaceId: req.Payload.LockId.NamespaceId,
+			LeaseId:     leaseId,
+		})
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+
+		if lease == nil || lease.ExpiresAt <= req.Now {
+			return &coreapis.TransferLockResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"lease not found",
+					map[string]string{
+						"lease_id": fmt.Sprintf("%d", leaseId),
+					},
+				),
+			}, nil
+		}
+	}
+
+	lock, err := c.locks.Get(txn, req.Payload.LockId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	// Expired holders cannot be transferred
+	var updatedLock *corepb.Lock
+	if lock != nil {
+		updatedLock, err = c.checkLockExpiration(txn, lock, req.Now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var holder *corepb.LockHolder
+	heldByTarget := false
+	if updatedLock != nil {
+		for _, h := range updatedLock.LockHolders {
+			switch h.LeaseId {
+			case req.Payload.FromLeaseId:
+				holder = h
+			case req.Payload.ToLeaseId:
+				heldByTarget = true
+			}
+		}
+	}
+
+	if holder == nil {
+		return &coreapis.TransferLockResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.NotFound,
+				"lock hold not found",
+				map[string]string{
+					"lock_name": req.Payload.LockId.LockName,
+					"lease_id":  fmt.Sprintf("%d", req.Payload.FromLeaseId),
+				},
+			),
+		}, nil
+	}
+
+	if heldByTarget {
+		return &coreapis.TransferLockResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"lock is already held by the target lease",
+				map[string]string{
+					"lock_name": req.Payload.LockId.LockName,
+					"lease_id":  fmt.Sprintf("%d", req.Payload.ToLeaseId),
+				},
+			),
+		}, nil
+	}
+
+	// Re-keying the holder moves the lease id index entry along with it
+	holder.LeaseId = req.Payload.ToLeaseId
+	updatedLock.LastActivityAt = req.Now
+
+	err = c.locks.Update(txn, updatedLock)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.TransferLockResponse{
+		Payload: &corepb.TransferLockResponse{
+			Lock: updatedLock,
+		},
+	}, nil
+}
+
 // RunLocksGarbageCollection processes one page of pending GC work: deletes
-// locks tied to namespaces marked for removal, and reaps expired leases
-// (along with any locks they still hold). The amount of work per call is
+// locks tied to namespaces marked for removal, reaps expired leases (along
+// with any locks they still hold), releases holds that reached their max hold
+// time and drops lapsed waiters. The amount of work per call is
 // bounded by req.MaxVisitedLocks; records that fully drain within budget are
 // removed, otherwise they are left for the next GC tick.
 func (c *Core) RunLocksGarbageCollection(req *coreapis.RunLocksGarbageCollectionRequest) (*coreapis.RunLocksGarbageCollectionResponse, error) {
@@ -672,48 +901,11 @@ func (c *Core) RunLocksGarbageCollection(req *coreapis.RunLocksGarbageCollection
 			for _, lock := range locksResult.locks {
 				visitedLocks++
 
-				// Get the lock to update it
-				updatedLock, err := c.checkLockExpiration(txn, lock, req.Now)
+				err = c.releaseExpiredHolders(txn, lock, req.Now)
 				if err != nil {
 					return false, err
 				}
 
-				if updatedLock.State == corepb.LockState_LOCK_STATE_UNLOCKED {
-					// Get counters for lock's namespace
-					counter, err := c.counters.Get(txn, lock.Id.AccountId, lock.Id.NamespaceId)
-					counterNotFound := errors.Is(err, store.ErrNotFound)
-					if err != nil && !counterNotFound {
-						return false, err
-					}
-
-					// Delete the lock
-					err = c.locks.Delete(txn, lock.Id)
-					if err != nil && !errors.Is(err, store.ErrNotFound) {
-						return false, err
-					}
-
-					// Update ancestor entries
-					err = c.decrementAncestors(txn, lock.Id, lock.State == corepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED)
-					if err != nil {
-						return false, err
-					}
-
-					// Update counters only if they exist
-					if !counterNotFound {
-						counter.NumberOfLocks -= 1
-						err = c.counters.Set(txn, lock.Id.AccountId, lock.Id.NamespaceId, counter)
-						if err != nil {
-							return false, err
-						}
-					}
-				} else {
-					// Lock still has unexpired holders, update it
-					err = c.locks.Update(txn, updatedLock)
-					if err != nil {
-						return false, err
-					}
-				}
-
 				if visitedLocks >= req.Payload.MaxVisitedLocks {
 					return false, nil // Stop processing
 				}
@@ -747,6 +939,55 @@ func (c *Core) RunLocksGarbageCollection(req *coreapis.RunLocksGarbageCollection
 		}
 	}
 
+	if visitedLocks < req.Payload.MaxVisitedLocks {
+		// Release holds that reached their max hold time while the lease lives on
+		err = c.locks.ListByHoldExpiration(txn, 0, req.Now, func(record *corepb.LocksHoldExpirationRecord) (bool, error) {
+			visitedLocks++
+
+			lock, err := c.locks.Get(txn, record.LockId)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					// Stale index row pointing at a deleted lock. Drop it and
+					// continue, otherwise it blocks the sweep forever.
+					err = c.locks.DeleteHoldExpiration(txn, record.ExpiresAt, record.LockId)
+					if err != nil {
+						return false, err
+					}
+					return visitedLocks < req.Payload.MaxVisitedLocks, nil
+				}
+				return false, err
+			}
+
+			err = c.releaseExpiredHolders(txn, lock, req.Now)
+			if err != nil {
+				return false, err
+			}
+
+			return visitedLocks < req.Payload.MaxVisitedLocks, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if visitedLocks < req.Payload.MaxVisitedLocks {
+		// Drop waiters left behind by acquires that stopped retrying without a
+		// final attempt
+		err = c.waiters.ListByExpiration(txn, 0, req.Now, func(waiter *corepb.LockWaiter) (bool, error) {
+			visitedLocks++
+
+			err := c.waiters.Delete(txn, waiter)
+			if err != nil {
+				return false, err
+			}
+
+			return visitedLocks < req.Payload.MaxVisitedLocks, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 commit:
 
 	err = txn.Commit()
@@ -1203,7 +1444,8 @@ func (c *Core) revokeLease(txn *store.Txn, lease *corepb.Lease) error {
 	return nil
 }
 
-// checkLockExpiration ensures that the lock is still held at the moment `now`. Returns an updated copy of the lock.
+// checkLockExpiration ensures that the lock is still held at the moment `now`: holders whose lease
+// expired or whose max hold time passed are dropped. Returns an updated copy of the lock.
 func (c *Core) checkLockExpiration(txn *store.Txn, lock *corepb.Lock, now int64) (*corepb.Lock, error) {
 	result := proto.Clone(lock).(*corepb.Lock)
 
@@ -1215,6 +1457,9 @@ func (c *Core) checkLockExpiration(txn *store.Txn, lock *corepb.Lock, now int64)
 		// Filter out holders whose leases have expired
 		newLockHolders := make([]*corepb.LockHolder, 0, len(result.LockHolders))
 		for _, h := range result.LockHolders {
+			if h.HoldExpiresAt != 0 && h.HoldExpiresAt <= now {
+				continue
+			}
 			lease, err := c.leases.Get(txn, &corepb.LeaseId{
 				AccountId:   lock.Id.AccountId,
 				NamespaceId: lock.Id.NamespaceId,
@@ -1234,15 +1479,16 @@ func (c *Core) checkLockExpiration(txn *store.Txn, lock *corepb.Lock, now int64)
 			result.LockedAt = 0
 		}
 	case corepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED:
+		holder := result.LockHolders[0]
 		lease, err := c.leases.Get(txn, &corepb.LeaseId{
 			AccountId:   lock.Id.AccountId,
 			NamespaceId: lock.Id.NamespaceId,
-			LeaseId:     result.LockHolders[0].LeaseId,
+			LeaseId:     holder.LeaseId,
 		})
 		if err != nil {
 			return nil, err
 		}
-		if lease.ExpiresAt <= now {
+		if lease.ExpiresAt <= now || (holder.HoldExpiresAt != 0 && holder.HoldExpiresAt <= now) {
 			result.State = corepb.LockState_LOCK_STATE_UNLOCKED
 			result.LockHolders = nil
 			result.LockedAt = 0
@@ -1254,6 +1500,51 @@ func (c *Core) checkLockExpiration(txn *store.Txn, lock *corepb.Lock, now int64)
 	return result, nil
 }
 
+// releaseExpiredHolders drops the lock's expired holders (see checkLockExpiration) and saves it,
+// deleting the lock and decrementing the namespace counter once no holder is left. A missing
+// counter row is tolerated, since the namespace may already have been GC'd.
+func (c *Core) releaseExpiredHolders(txn *store.Txn, lock *corepb.Lock, now int64) error {
+	updatedLock, err := c.checkLockExpiration(txn, lock, now)
+	if err != nil {
+		return err
+	}
+
+	if updatedLock.State != corepb.LockState_LOCK_STATE_UNLOCKED {
+		// Lock still has unexpired holders, update it
+		return c.locks.Update(txn, updatedLock)
+	}
+
+	// Get counters for lock's namespace
+	counter, err := c.counters.Get(txn, lock.Id.AccountId, lock.Id.NamespaceId)
+	counterNotFound := errors.Is(err, store.ErrNotFound)
+	if err != nil && !counterNotFound {
+		return err
+	}
+
+	// Delete the lock
+	err = c.locks.Delete(txn, lock.Id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	// Update ancestor entries
+	err = c.decrementAncestors(txn, lock.Id, lock.State == corepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED)
+	if err != nil {
+		return err
+	}
+
+	// Update counters only if they exist
+	if !counterNotFound {
+		counter.NumberOfLocks -= 1
+		err = c.counters.Set(txn, lock.Id.AccountId, lock.Id.NamespaceId, counter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // incrementAncestors increments the ancestor counter for each path prefix of the given lock name.
 // Called when a lock transitions from UNLOCKED to LOCKED for the first time (new lock record).
 func (c *Core) incrementAncestors(txn *store.Txn, lockId *corepb.LockId, exclusive bool) error {
@@ -2782,6 +2782,383 @@ func TestCore_LastActivityAt(t *testing.T) {
 	})
 }
 
+func TestCore_AcquireLock_MaxHoldSeconds(t *testing.T) {
+	t.Run("hold is reaped while the lease is alive", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		lease1 := createLease(t, core, accountId, namespaceId, "process-1", now, 60*time.Minute)
+		lease2 := createLease(t, core, accountId, namespaceId, "process-2", now, 60*time.Minute)
+
+		success, lock := acquireLockWithMaxHold(t, core, lockId, lease1.Id, true, 60, now)
+		require.True(t, success)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), lock.LockHolders[0].HoldExpiresAt)
+
+		// Still held right before the deadline
+		lock = getLock(t, core, lockId, now.Add(59*time.Second))
+		require.Equal(t, corepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED, lock.State)
+
+		// Released after the deadline even though the lease is still valid
+		lock = getLock(t, core, lockId, now.Add(time.Minute))
+		require.Equal(t, corepb.LockState_LOCK_STATE_UNLOCKED, lock.State)
+
+		// GC reaps the lock through the hold expiration index
+		gcResponse, err := core.RunLocksGarbageCollection(&coreapis.RunLocksGarbageCollectionRequest{
+			Payload: &corepb.RunLocksGarbageCollectionRequest{
+				GcRecordsPageSize:     100,
+				GcRecordLocksPageSize: 100,
+				MaxVisitedLocks:       1000,
+			},
+			Now: now.Add(time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, gcResponse)
+
+		found := 0
+		err = core.locks.ListByHoldExpiration(core.badgerStore.View(), 0, now.Add(time.Hour).UnixNano(), func(record *corepb.LocksHoldExpirationRecord) (bool, error) {
+			found++
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, found)
+
+		counters, err := core.counters.Get(core.badgerStore.View(), accountId, namespaceId)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, counters.NumberOfLocks)
+
+		// Another lease can take the lock now
+		success, _ = acquireLock(t, core, lockId, lease2.Id, true, now.Add(time.Minute))
+		require.True(t, success)
+	})
+
+	t.Run("re-acquire resets max hold time", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		lease := createLease(t, core, accountId, namespaceId, "process-1", now, 60*time.Minute)
+
+		acquireLockWithMaxHold(t, core, lockId, lease.Id, false, 60, now)
+
+		// Re-acquiring without max hold time clears it
+		success, lock := acquireLock(t, core, lockId, lease.Id, false, now.Add(30*time.Second))
+		require.True(t, success)
+		require.Equal(t, int64(0), lock.LockHolders[0].HoldExpiresAt)
+
+		lock = getLock(t, core, lockId, now.Add(2*time.Minute))
+		require.Equal(t, corepb.LockState_LOCK_STATE_SHARED_LOCKED, lock.State)
+	})
+
+	t.Run("negative max hold seconds is rejected", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		lease := createLease(t, core, accountId, namespaceId, "process-1", now, 60*time.Minute)
+
+		resp, err := core.AcquireLock(&coreapis.AcquireLockRequest{
+			Payload: &corepb.AcquireLockRequest{
+				LockId:                       lockId,
+				LeaseId:                      lease.Id.LeaseId,
+				Exclusive:                    true,
+				MaxNumberOfLocksPerNamespace: 2_000,
+				MaxHoldSeconds:               -1,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+	})
+
+	t.Run("stale hold expiration records are dropped", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		lockId := &corepb.LockId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+			LockName:    "deleted_lock",
+		}
+
+		// An index row left pointing at a lock that no longer exists
+		txn := core.badgerStore.Update()
+		err := core.locks.holdExpirationIndex.Set(txn, core.locks.holdExpirationIndexPK(now.UnixNano(), lockId), &corepb.LocksHoldExpirationRecord{
+			LockId:    lockId,
+			ExpiresAt: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, txn.Commit())
+
+		gcResponse, err := core.RunLocksGarbageCollection(&coreapis.RunLocksGarbageCollectionRequest{
+			Payload: &corepb.RunLocksGarbageCollectionRequest{
+				GcRecordsPageSize:     100,
+				GcRecordLocksPageSize: 100,
+				MaxVisitedLocks:       1000,
+			},
+			Now: now.Add(time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, gcResponse)
+
+		found := 0
+		err = core.locks.ListByHoldExpiration(core.badgerStore.View(), 0, now.Add(time.Hour).UnixNano(), func(record *corepb.LocksHoldExpirationRecord) (bool, error) {
+			found++
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, found)
+	})
+}
+
+func TestCore_TransferLock(t *testing.T) {
+	t.Run("exclusive lock moves to the new lease", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		oldLease := createLease(t, core, accountId, namespaceId, "old-pod", now, 60*time.Minute)
+		newLease := createLease(t, core, accountId, namespaceId, "new-pod", now, 60*time.Minute)
+
+		success, _ := acquireLockWithMaxHold(t, core, lockId, oldLease.Id, true, 600, now)
+		require.True(t, success)
+
+		lock := transferLock(t, core, lockId, oldLease.Id, newLease.Id, now.Add(time.Minute))
+		require.Equal(t, corepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED, lock.State)
+		require.Len(t, lock.LockHolders, 1)
+		require.Equal(t, newLease.Id.LeaseId, lock.LockHolders[0].LeaseId)
+		require.Equal(t, now.UnixNano(), lock.LockHolders[0].LockedAt)
+		require.Equal(t, now.Add(10*time.Minute).UnixNano(), lock.LockHolders[0].HoldExpiresAt)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), lock.LastActivityAt)
+
+		// The lease id index follows the holder
+		require.Empty(t, listLocksByLeaseId(t, core, oldLease.Id, now.Add(time.Minute)).Locks)
+		require.Len(t, listLocksByLeaseId(t, core, newLease.Id, now.Add(time.Minute)).Locks, 1)
+
+		// Revoking the old lease no longer releases the lock
+		revokeLockLease(t, core, oldLease.Id, now.Add(2*time.Minute))
+		lock = getLock(t, core, lockId, now.Add(2*time.Minute))
+		require.Equal(t, corepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED, lock.State)
+		require.Equal(t, newLease.Id.LeaseId, lock.LockHolders[0].LeaseId)
+
+		counters, err := core.counters.Get(core.badgerStore.View(), accountId, namespaceId)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, counters.NumberOfLocks)
+	})
+
+	t.Run("shared holder moves and others stay", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		lease1 := createLease(t, core, accountId, namespaceId, "process-1", now, 60*time.Minute)
+		lease2 := createLease(t, core, accountId, namespaceId, "process-2", now, 60*time.Minute)
+		lease3 := createLease(t, core, accountId, namespaceId, "process-3", now, 60*time.Minute)
+
+		acquireLock(t, core, lockId, lease1.Id, false, now)
+		acquireLock(t, core, lockId, lease2.Id, false, now)
+
+		lock := transferLock(t, core, lockId, lease1.Id, lease3.Id, now)
+		require.Equal(t, corepb.LockState_LOCK_STATE_SHARED_LOCKED, lock.State)
+		leaseIds := []uint64{lock.LockHolders[0].LeaseId, lock.LockHolders[1].LeaseId}
+		require.ElementsMatch(t, []uint64{lease3.Id.LeaseId, lease2.Id.LeaseId}, leaseIds)
+
+		// The target lease holding it already is rejected
+		appErr := transferLockWithError(t, core, lockId, lease2.Id, lease3.Id, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		lease1 := createLease(t, core, accountId, namespaceId, "process-1", now, 60*time.Minute)
+		lease2 := createLease(t, core, accountId, namespaceId, "process-2", now, 60*time.Minute)
+		shortLease := createLease(t, core, accountId, namespaceId, "process-3", now, time.Minute)
+
+		// Nothing held yet
+		appErr := transferLockWithError(t, core, lockId, lease1.Id, lease2.Id, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		acquireLock(t, core, lockId, lease1.Id, true, now)
+
+		appErr = transferLockWithError(t, core, lockId, lease1.Id, lease1.Id, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// The other lease does not hold it
+		appErr = transferLockWithError(t, core, lockId, lease2.Id, lease1.Id, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		// Expired target lease
+		appErr = transferLockWithError(t, core, lockId, lease1.Id, shortLease.Id, now.Add(2*time.Minute))
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		// The lock stays with the original holder
+		lock := getLock(t, core, lockId, now)
+		require.Equal(t, lease1.Id.LeaseId, lock.LockHolders[0].LeaseId)
+	})
+}
+
+func TestCore_LockWaiters(t *testing.T) {
+	t.Run("waiting acquire is listed until it succeeds", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		holderLease := createLease(t, core, accountId, namespaceId, "holder", now, 60*time.Minute)
+		waiterLease := createLease(t, core, accountId, namespaceId, "waiter", now, 60*time.Minute)
+
+		success, _ := acquireLock(t, core, lockId, holderLease.Id, true, now)
+		require.True(t, success)
+
+		// A non-blocking attempt is not a waiter
+		success, _ = acquireLock(t, core, lockId, waiterLease.Id, false, now)
+		require.False(t, success)
+		require.Empty(t, listLockWaiters(t, core, lockId, now).Waiters)
+
+		success = acquireLockWaiting(t, core, lockId, waiterLease.Id, false, now.Add(time.Minute), now)
+		require.False(t, success)
+		success = acquireLockWaiting(t, core, lockId, waiterLease.Id, false, now.Add(time.Minute), now.Add(time.Second))
+		require.False(t, success)
+
+		waiters := listLockWaiters(t, core, lockId, now.Add(time.Second)).Waiters
+		require.Len(t, waiters, 1)
+		require.Equal(t, waiterLease.Id.LeaseId, waiters[0].LeaseId)
+		require.False(t, waiters[0].Exclusive)
+		require.Equal(t, "waiter", waiters[0].ProcessId)
+		require.Equal(t, now.UnixNano(), waiters[0].WaitingSince)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), waiters[0].ExpiresAt)
+		require.EqualValues(t, 1, getLockWaitersCount(t, core, lockId, now.Add(time.Second)))
+
+		// Waiters of a lock whose name extends this one are not mixed in
+		otherLockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock_2",
+		}
+		acquireLock(t, core, otherLockId, holderLease.Id, true, now)
+		acquireLockWaiting(t, core, otherLockId, waiterLease.Id, true, now.Add(time.Minute), now)
+		require.Len(t, listLockWaiters(t, core, lockId, now.Add(time.Second)).Waiters, 1)
+
+		releaseLock(t, core, lockId, holderLease.Id, now.Add(2*time.Second))
+		success = acquireLockWaiting(t, core, lockId, waiterLease.Id, false, now.Add(time.Minute), now.Add(2*time.Second))
+		require.True(t, success)
+
+		require.Empty(t, listLockWaiters(t, core, lockId, now.Add(2*time.Second)).Waiters)
+		require.Zero(t, getLockWaitersCount(t, core, lockId, now.Add(2*time.Second)))
+	})
+
+	t.Run("final attempt after the wait forgets the waiter", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "a/b",
+		}
+
+		holderLease := createLease(t, core, accountId, namespaceId, "holder", now, 60*time.Minute)
+		waiterLease := createLease(t, core, accountId, namespaceId, "waiter", now, 60*time.Minute)
+
+		// Blocked by the ancestor: the waiter is recorded on a lock without a record
+		acquireLock(t, core, &corepb.LockId{AccountId: accountId, NamespaceId: namespaceId, LockName: "a"}, holderLease.Id, true, now)
+		success := acquireLockWaiting(t, core, lockId, waiterLease.Id, true, now.Add(10*time.Second), now)
+		require.False(t, success)
+		require.EqualValues(t, 1, getLockWaitersCount(t, core, lockId, now))
+
+		success = acquireLockWaiting(t, core, lockId, waiterLease.Id, true, now.Add(10*time.Second), now.Add(10*time.Second))
+		require.False(t, success)
+		require.Empty(t, listLockWaiters(t, core, lockId, now.Add(10*time.Second)).Waiters)
+	})
+
+	t.Run("abandoned waiters lapse and are dropped by GC", func(t *testing.T) {
+		core := newLocksCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		lockId := &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespaceId,
+			LockName:    "test_lock",
+		}
+
+		holderLease := createLease(t, core, accountId, namespaceId, "holder", now, 60*time.Minute)
+		// The waiter's lease runs out before its wait does
+		waiterLease := createLease(t, core, accountId, namespaceId, "waiter", now, 30*time.Second)
+
+		acquireLock(t, core, lockId, holderLease.Id, true, now)
+		acquireLockWaiting(t, core, lockId, waiterLease.Id, true, now.Add(time.Minute), now)
+
+		waiters := listLockWaiters(t, core, lockId, now).Waiters
+		require.Len(t, waiters, 1)
+		require.Equal(t, waiterLease.ExpiresAt, waiters[0].ExpiresAt)
+
+		require.Empty(t, listLockWaiters(t, core, lockId, now.Add(30*time.Second)).Waiters)
+		// The lapsed waiter counts until GC drops it
+		require.EqualValues(t, 1, getLockWaitersCount(t, core, lockId, now.Add(30*time.Second)))
+
+		_, err := core.RunLocksGarbageCollection(&coreapis.RunLocksGarbageCollectionRequest{
+			Payload: &corepb.RunLocksGarbageCollectionRequest{
+				GcRecordsPageSize:     100,
+				GcRecordLocksPageSize: 100,
+				MaxVisitedLocks:       100,
+			},
+			Now: now.Add(30 * time.Second).UnixNano(),
+		})
+		require.NoError(t, err)
+
+		waiter, err := core.waiters.Get(core.badgerStore.View(), lockId, waiterLease.Id.LeaseId)
+		require.ErrorIs(t, err, store.ErrNotFound)
+		require.Nil(t, waiter)
+		require.Zero(t, getLockWaitersCount(t, core, lockId, now.Add(30*time.Second)))
+	})
+}
+
 func newLocksCore(t *testing.T) *Core {
 	badgerStore, err := store.NewBadgerInMemoryStore()
 	require.NoError(t, err)
@@ -2835,6 +3212,30 @@ func acquireLock(t *testing.T, core *Core, lockId *corepb.LockId, leaseId *corep
 	return resp.Payload.Success, resp.Payload.Lock
 }
 
+// acquireLockWithMaxHold is like acquireLock but sets MaxHoldSeconds on the request.
+func acquireLockWithMaxHold(t *testing.T, core *Core, lockId *corepb.LockId, leaseId *corepb.LeaseId, exclusive bool, maxHoldSeconds int64, now time.Time) (bool, *corepb.Lock) {
+	t.Helper()
+
+	resp, err := core.AcquireLock(&coreapis.AcquireLockRequest{
+		Payload: &corepb.AcquireLockRequest{
+			LockId:                       lockId,
+			LeaseId:                      leaseId.LeaseId,
+			Exclusive:                    exclusive,
+			MaxNumberOfLocksPerNamespace: 2_000,
+			MaxHoldSeconds:               maxHoldSeconds,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Lock)
+
+	return resp.Payload.Success, resp.Payload.Lock
+}
+
 // acquireLockReason is like acquireLock but also returns the contention reason
 // and blocking locks the core reported for the attempt.
 func acquireLockReason(t *testing.T, core *Core, lockId *corepb.LockId, leaseId *corepb.LeaseId, exclusive bool, now time.Time) (bool, corepb.ContentionReason, []*corepb.Lock) {
@@ -3054,6 +3455,100 @@ func listLockLeasesByProcessId(t *testing.T, core *Core, namespaceId *corepb.Nam
 	return resp.Payload
 }
 
+func transferLock(t *testing.T, core *Core, lockId *corepb.LockId, fromLeaseId *corepb.LeaseId, toLeaseId *corepb.LeaseId, now time.Time) *corepb.Lock {
+	t.Helper()
+
+	resp, err := core.TransferLock(&coreapis.TransferLockRequest{
+		Payload: &corepb.TransferLockRequest{
+			LockId:      lockId,
+			FromLeaseId: fromLeaseId.LeaseId,
+			ToLeaseId:   toLeaseId.LeaseId,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Lock)
+	return resp.Payload.Lock
+}
+
+func transferLockWithError(t *testing.T, core *Core, lockId *corepb.LockId, fromLeaseId *corepb.LeaseId, toLeaseId *corepb.LeaseId, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.TransferLock(&coreapis.TransferLockRequest{
+		Payload: &corepb.TransferLockRequest{
+			LockId:      lockId,
+			FromLeaseId: fromLeaseId.LeaseId,
+			ToLeaseId:   toLeaseId.LeaseId,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+	return resp.ApplicationError
+}
+
+// acquireLockWaiting is like acquireLock for a caller that keeps retrying until waitUntil.
+func acquireLockWaiting(t *testing.T, core *Core, lockId *corepb.LockId, leaseId *corepb.LeaseId, exclusive bool, waitUntil time.Time, now time.Time) bool {
+	t.Helper()
+
+	resp, err := core.AcquireLock(&coreapis.AcquireLockRequest{
+		Payload: &corepb.AcquireLockRequest{
+			LockId:                       lockId,
+			LeaseId:                      leaseId.LeaseId,
+			Exclusive:                    exclusive,
+			MaxNumberOfLocksPerNamespace: 2_000,
+			WaitUntil:                    waitUntil.UnixNano(),
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload.Success
+}
+
+func getLockWaitersCount(t *testing.T, core *Core, lockId *corepb.LockId, now time.Time) int64 {
+	t.Helper()
+
+	resp, err := core.GetLock(&coreapis.GetLockRequest{
+		Payload: &corepb.GetLockRequest{
+			LockId: lockId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload.WaitersCount
+}
+
+func listLockWaiters(t *testing.T, core *Core, lockId *corepb.LockId, now time.Time) *corepb.ListLockWaitersResponse {
+	t.Helper()
+
+	resp, err := core.ListLockWaiters(&coreapis.ListLockWaitersRequest{
+		Payload: &corepb.ListLockWaitersRequest{
+			LockId: lockId,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	return resp.Payload
+}
+
 func listLocksByLeaseId(t *testing.T, core *Core, leaseId *corepb.LeaseId, now time.Time) *corepb.ListLocksByLeaseIdResponse {
 	t.Helper()
 
@@ -29,9 +29,16 @@ import (
 //
 // Lease Id Index Sort Key:
 // 1. lock name
+//
+// Hold Expiration Index Primary Key:
+// 1. earliest hold expiration time of the lock's holders
+// 2. account id
+// 3. namespace id
+// 4. lock name
 type locksTable struct {
-	table        *honey.BinaryTable[*corepb.Lock, corepb.Lock]
-	leaseIdIndex *honey.OneToManySortedIndex
+	table               *honey.BinaryTable[*corepb.Lock, corepb.Lock]
+	leaseIdIndex        *honey.OneToManySortedIndex
+	holdExpirationIndex *honey.BinaryTable[*corepb.LocksHoldExpirationRecord, corepb.LocksHoldExpirationRecord]
 }
 
 // newLocksTable scopes both the table and the lease id index under the
@@ -47,13 +54,16 @@ func newLocksTable(replicaPrefix []byte) *locksTable {
 		leaseIdIndex: honey.NewOneToManySortedIndex(
 			utils.ConcatBytes(replicaPrefix, tablePrefixLocksLeaseIdIndex),
 		),
+		holdExpirationIndex: honey.NewBinaryTable[*corepb.LocksHoldExpirationRecord, corepb.LocksHoldExpirationRecord](
+			utils.ConcatBytes(replicaPrefix, tablePrefixLocksHoldExpirationIndex),
+		),
 	}
 }
 
-// Clear deletes every row this table owns: the primary lock rows and the
-// lease id index.
+// Clear deletes every row this table owns: the primary lock rows, the lease
+// id index and the hold expiration index.
 func (t *locksTable) Clear(badgerStore *store.BadgerStore) error {
-	for _, prefix := range [][]byte{t.table.TableId(), t.leaseIdIndex.TableId()} {
+	for _, prefix := range [][]byte{t.table.TableId(), t.leaseIdIndex.TableId(), t.holdExpirationIndex.TableId()} {
 		if err := badgerStore.DeletePrefix(prefix); err != nil {
 			return err
 		}
@@ -62,14 +72,14 @@ func (t *locksTable) Clear(badgerStore *store.BadgerStore) error {
 }
 
 // EachEntity streams every lock as (canonical key, stored value) — the
-// primary table only; the lease id index is rebuilt from the locks on restore.
+// primary table only; both indexes are rebuilt from the locks on restore.
 func (t *locksTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
 	return t.table.EachEntry(txn, fn)
 }
 
 // RestoreEntity decodes one streamed lock and, if owned, inserts it through
-// Update — re-deriving its keys and rebuilding the lease id index from the
-// lock's own identity fields.
+// Update — re-deriving its keys and rebuilding both indexes from the lock's
+// own fields.
 func (t *locksTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
 	lock := &corepb.Lock{}
 	if err := lock.UnmarshalBinary(value); err != nil {
@@ -151,6 +161,18 @@ func (t *locksTable) Get(txn *store.Txn, lockId *corepb.LockId) (*corepb.Lock, e
 			t.tableSK(lockId.LockName)))
 }
 
+// ListByHoldExpiration calls fn for every lock with a holder whose max hold
+// time falls in [from, to), earliest first, until fn returns false.
+func (t *locksTable) ListByHoldExpiration(txn *store.Txn, from int64, to int64, fn func(record *corepb.LocksHoldExpirationRecord) (bool, error)) error {
+	return t.holdExpirationIndex.ListInRange(txn, t.holdExpirationIndexPrefix(from), t.holdExpirationIndexPrefix(to), false, fn)
+}
+
+// DeleteHoldExpiration removes a single hold expiration index row, for GC to
+// drop a row left pointing at a lock that no longer exists.
+func (t *locksTable) DeleteHoldExpiration(txn *store.Txn, expiresAt int64, lockId *corepb.LockId) error {
+	return t.holdExpirationIndex.Delete(txn, t.holdExpirationIndexPK(expiresAt, lockId))
+}
+
 func (t *locksTable) Update(txn *store.Txn, lock *corepb.Lock) error {
 	tableKey := utils.ConcatBytes(
 		t.tablePK(lock.Id.AccountId, lock.Id.NamespaceId),
@@ -163,11 +185,33 @@ func (t *locksTable) Update(txn *store.Txn, lock *corepb.Lock) error {
 
 	// If lock doesn't exist, treat as a creation (oldLeaseIds will be empty)
 	oldLeaseIds := make(map[uint64]struct{})
+	oldHoldExpiresAt := int64(0)
 	if err == nil {
 		// Lock exists, get old lease IDs
 		for _, holder := range oldLock.LockHolders {
 			oldLeaseIds[holder.LeaseId] = struct{}{}
 		}
+		oldHoldExpiresAt = earliestHoldExpiresAt(oldLock)
+	}
+
+	// Move the hold expiration index entry when the earliest max hold time changed
+	newHoldExpiresAt := earliestHoldExpiresAt(lock)
+	if oldHoldExpiresAt != newHoldExpiresAt {
+		if oldHoldExpiresAt != 0 {
+			err = t.holdExpirationIndex.Delete(txn, t.holdExpirationIndexPK(oldHoldExpiresAt, lock.Id))
+			if err != nil {
+				return err
+			}
+		}
+		if newHoldExpiresAt != 0 {
+			err = t.holdExpirationIndex.Set(txn, t.holdExpirationIndexPK(newHoldExpiresAt, lock.Id), &corepb.LocksHoldExpirationRecord{
+				LockId:    lock.Id,
+				ExpiresAt: newHoldExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	newLeaseIds := make(map[uint64]struct{}, len(lock.LockHolders))
@@ -222,6 +266,13 @@ func (t *locksTable) Delete(txn *store.Txn, lockId *corepb.LockId) error {
 		return err
 	}
 
+	if holdExpiresAt := earliestHoldExpiresAt(lock); holdExpiresAt != 0 {
+		err = t.holdExpirationIndex.Delete(txn, t.holdExpirationIndexPK(holdExpiresAt, lockId))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Remove all lease ID index entries for this lock
 	lockName := []byte(lockId.LockName)
 	for _, holder := range lock.LockHolders {
@@ -258,3 +309,30 @@ func (t *locksTable) leaseIdIndexPK(accountId uint64, namespaceId uint64, leaseI
 		leaseId,
 	)
 }
+
+func (t *locksTable) holdExpirationIndexPK(holdExpiresAt int64, lockId *corepb.LockId) []byte {
+	return utils.ConcatBytes(
+		holdExpiresAt,
+		lockId.AccountId,
+		lockId.NamespaceId,
+		lockId.LockName,
+	)
+}
+
+func (t *locksTable) holdExpirationIndexPrefix(holdExpiresAt int64) []byte {
+	return utils.ConcatBytes(
+		holdExpiresAt,
+	)
+}
+
+// earliestHoldExpiresAt returns the soonest max hold time across the lock's
+// holders, or 0 when none of them has one.
+func earliestHoldExpiresAt(lock *corepb.Lock) int64 {
+	earliest := int64(0)
+	for _, holder := range lock.LockHolders {
+		if holder.HoldExpiresAt != 0 && (earliest == 0 || holder.HoldExpiresAt < earliest) {
+			earliest = holder.HoldExpiresAt
+		}
+	}
+	return earliest
+}
@@ -8,16 +8,17 @@ package locks
 // collide even when they reuse the same table prefix byte.
 //
 // Treat these as constants; never mutate the returned slices.
-//
-// 0x05 is reserved for a future locks ExpirationRecords table and is not yet
-// used.
 var (
-	tablePrefixLocks                 = []byte{0x01}
-	tablePrefixLocksLeaseIdIndex     = []byte{0x02}
-	tablePrefixCounters              = []byte{0x03}
-	tablePrefixGCRecords             = []byte{0x04}
-	tablePrefixAncestors             = []byte{0x05}
-	tablePrefixLeases                = []byte{0x06}
-	tablePrefixLeasesProcessIdIndex  = []byte{0x07}
-	tablePrefixLeasesExpirationIndex = []byte{0x08}
+	tablePrefixLocks                    = []byte{0x01}
+	tablePrefixLocksLeaseIdIndex        = []byte{0x02}
+	tablePrefixCounters                 = []byte{0x03}
+	tablePrefixGCRecords                = []byte{0x04}
+	tablePrefixAncestors                = []byte{0x05}
+	tablePrefixLeases                   = []byte{0x06}
+	tablePrefixLeasesProcessIdIndex     = []byte{0x07}
+	tablePrefixLeasesExpirationIndex    = []byte{0x08}
+	tablePrefixLocksHoldExpirationIndex = []byte{0x09}
+	tablePrefixWaiters                  = []byte{0x0a}
+	tablePrefixWaitersExpirationIndex   = []byte{0x0b}
+	tablePrefixWaitersCounts            = []byte{0x0c}
 )
@@ -0,0 +1,254 @@
+package locks
+
+import (
+	"errors"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/pagination"
+	"github.com/evrblk/grackle/pkg/sharding"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// lockWaitersTable stores the leases blocked in AcquireLock, indexed by lock
+// and by expiration time, along with the number of waiters of each lock. The
+// count lives here rather than on the lock row, which is deleted once the last
+// holder leaves while leases may still be waiting.
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. lock name
+//
+// Table Sort Key:
+// 1. lease id
+//
+// Expiration Index Primary Key:
+// 1. expiration time
+// 2. account id
+// 3. namespace id
+// 4. lease id
+// 5. lock name
+//
+// Counts Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. lock name
+type lockWaitersTable struct {
+	table           *honey.BinaryTable[*corepb.LockWaiter, corepb.LockWaiter]
+	expirationIndex *honey.BinaryTable[*corepb.LockWaiter, corepb.LockWaiter]
+	counts          *honey.Uint64Table
+}
+
+// newLockWaitersTable scopes both tables under the shard-unique prefix; see
+// newLocksTable.
+func newLockWaitersTable(replicaPrefix []byte) *lockWaitersTable {
+	return &lockWaitersTable{
+		table: honey.NewBinaryTable[*corepb.LockWaiter, corepb.LockWaiter](
+			utils.ConcatBytes(replicaPrefix, tablePrefixWaiters),
+		),
+		expirationIndex: honey.NewBinaryTable[*corepb.LockWaiter, corepb.LockWaiter](
+			utils.ConcatBytes(replicaPrefix, tablePrefixWaitersExpirationIndex),
+		),
+		counts: honey.NewUint64Table(
+			utils.ConcatBytes(replicaPrefix, tablePrefixWaitersCounts),
+		),
+	}
+}
+
+// Clear deletes every row this table owns: the primary waiter rows, the
+// expiration index and the counts.
+func (t *lockWaitersTable) Clear(badgerStore *store.BadgerStore) error {
+	for _, prefix := range [][]byte{t.table.TableId(), t.expirationIndex.TableId(), t.counts.TableId()} {
+		if err := badgerStore.DeletePrefix(prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachEntity streams every waiter as (canonical key, stored value) — the
+// primary table only; the expiration index and the counts are rebuilt on
+// restore.
+func (t *lockWaitersTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed waiter and, if owned, inserts it through
+// Set — rebuilding the expiration index and the counts from the waiter's own
+// fields.
+func (t *lockWaitersTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	waiter := &corepb.LockWaiter{}
+	if err := waiter.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(sharding.ByAccountAndNamespace(waiter.LockId.AccountId, waiter.LockId.NamespaceId)) {
+		return false, nil
+	}
+	return true, t.Set(txn, waiter)
+}
+
+func (t *lockWaitersTable) Get(txn *store.Txn, lockId *corepb.LockId, leaseId uint64) (*corepb.LockWaiter, error) {
+	return t.table.Get(txn,
+		utils.ConcatBytes(
+			t.tablePK(lockId.AccountId, lockId.NamespaceId, lockId.LockName),
+			t.tableSK(leaseId)))
+}
+
+func (t *lockWaitersTable) Set(txn *store.Txn, waiter *corepb.LockWaiter) error {
+	key := utils.ConcatBytes(
+		t.tablePK(waiter.LockId.AccountId, waiter.LockId.NamespaceId, waiter.LockId.LockName),
+		t.tableSK(waiter.LeaseId))
+
+	existingWaiter, err := t.table.Get(txn, key)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	if existingWaiter != nil && existingWaiter.ExpiresAt != waiter.ExpiresAt {
+		err = t.expirationIndex.Delete(txn, t.expirationIndexPK(existingWaiter))
+		if err != nil {
+			return err
+		}
+	}
+
+	if existingWaiter == nil {
+		err = t.addToCount(txn, waiter.LockId, 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = t.expirationIndex.Set(txn, t.expirationIndexPK(waiter), waiter)
+	if err != nil {
+		return err
+	}
+
+	return t.table.Set(txn, key, waiter)
+}
+
+func (t *lockWaitersTable) Delete(txn *store.Txn, waiter *corepb.LockWaiter) error {
+	key := utils.ConcatBytes(
+		t.tablePK(waiter.LockId.AccountId, waiter.LockId.NamespaceId, waiter.LockId.LockName),
+		t.tableSK(waiter.LeaseId))
+
+	existingWaiter, err := t.table.Get(txn, key)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			// Waiter doesn't exist, nothing to delete
+			return nil
+		}
+		return err
+	}
+
+	err = t.expirationIndex.Delete(txn, t.expirationIndexPK(existingWaiter))
+	if err != nil {
+		return err
+	}
+
+	err = t.addToCount(txn, waiter.LockId, -1)
+	if err != nil {
+		return err
+	}
+
+	return t.table.Delete(txn, key)
+}
+
+type listLockWaitersResult struct {
+	waiters                 []*corepb.LockWaiter
+	nextPaginationToken     *corepb.PaginationToken
+	previousPaginationToken *corepb.PaginationToken
+}
+
+// List returns a page of the lock's waiters sorted by lease id, including
+// those that have already lapsed.
+func (t *lockWaitersTable) List(txn *store.Txn, lockId *corepb.LockId, paginationToken *corepb.PaginationToken, limit int) (*listLockWaitersResult, error) {
+	result, err := t.table.ListPaginated(txn,
+		t.tablePK(lockId.AccountId, lockId.NamespaceId, lockId.LockName), pagination.CoreToMonstera(paginationToken), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// The lock name is a prefix of longer names sharing it ("a" of "ab"), so
+	// keep only the rows of this exact lock.
+	waiters := make([]*corepb.LockWaiter, 0, len(result.Items))
+	for _, waiter := range result.Items {
+		if waiter.LockId.LockName == lockId.LockName {
+			waiters = append(waiters, waiter)
+		}
+	}
+
+	return &listLockWaitersResult{
+		waiters:                 waiters,
+		nextPaginationToken:     pagination.MonsteraToCore(result.NextPaginationToken),
+		previousPaginationToken: pagination.MonsteraToCore(result.PreviousPaginationToken),
+	}, nil
+}
+
+// Count returns the number of the lock's waiters. A lapsed waiter counts until
+// garbage collection drops it.
+func (t *lockWaitersTable) Count(txn *store.Txn, lockId *corepb.LockId) (int64, error) {
+	count, err := t.counts.Get(txn, t.tablePK(lockId.AccountId, lockId.NamespaceId, lockId.LockName))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return int64(count), nil
+}
+
+// addToCount adjusts the lock's waiters count by delta, dropping the row once
+// it reaches zero.
+func (t *lockWaitersTable) addToCount(txn *store.Txn, lockId *corepb.LockId, delta int64) error {
+	count, err := t.Count(txn, lockId)
+	if err != nil {
+		return err
+	}
+
+	key := t.tablePK(lockId.AccountId, lockId.NamespaceId, lockId.LockName)
+	count += delta
+	if count <= 0 {
+		return t.counts.Delete(txn, key)
+	}
+	return t.counts.Set(txn, key, uint64(count))
+}
+
+// ListByExpiration calls fn for every waiter that lapses in [from, to),
+// earliest first, until fn returns false.
+func (t *lockWaitersTable) ListByExpiration(txn *store.Txn, from int64, to int64, fn func(waiter *corepb.LockWaiter) (bool, error)) error {
+	return t.expirationIndex.ListInRange(txn, t.expirationIndexPrefix(from), t.expirationIndexPrefix(to), false, fn)
+}
+
+func (t *lockWaitersTable) tablePK(accountId uint64, namespaceId uint64, lockName string) []byte {
+	return utils.ConcatBytes(
+		accountId,
+		namespaceId,
+		lockName,
+	)
+}
+
+func (t *lockWaitersTable) tableSK(leaseId uint64) []byte {
+	return utils.ConcatBytes(
+		leaseId,
+	)
+}
+
+func (t *lockWaitersTable) expirationIndexPK(waiter *corepb.LockWaiter) []byte {
+	return utils.ConcatBytes(
+		waiter.ExpiresAt,
+		waiter.LockId.AccountId,
+		waiter.LockId.NamespaceId,
+		waiter.LeaseId,
+		waiter.LockId.LockName,
+	)
+}
+
+func (t *lockWaitersTable) expirationIndexPrefix(expiresAt int64) []byte {
+	return utils.ConcatBytes(
+		expiresAt,
+	)
+}
@@ -0,0 +1,385 @@
+package locks
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestLockWaitersTable_Set(t *testing.T) {
+	t.Run("set and get a waiter", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waiter := &corepb.LockWaiter{
+			LockId:       randomLockId("test_lock"),
+			LeaseId:      rand.Uint64(),
+			Exclusive:    true,
+			ProcessId:    "process_1",
+			WaitingSince: 100,
+			ExpiresAt:    1000,
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		actual, err := table.Get(txn, waiter.LockId, waiter.LeaseId)
+		require.NoError(t, err)
+		require.Equal(t, waiter.Exclusive, actual.Exclusive)
+		require.Equal(t, waiter.ProcessId, actual.ProcessId)
+		require.Equal(t, waiter.WaitingSince, actual.WaitingSince)
+		require.Equal(t, waiter.ExpiresAt, actual.ExpiresAt)
+	})
+
+	t.Run("refreshing a waiter moves its expiration", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waiter := &corepb.LockWaiter{
+			LockId:    randomLockId("test_lock"),
+			LeaseId:   rand.Uint64(),
+			ExpiresAt: 1000,
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		refreshed := &corepb.LockWaiter{
+			LockId:    waiter.LockId,
+			LeaseId:   waiter.LeaseId,
+			ExpiresAt: 2000,
+		}
+
+		txn = badgerStore.Update()
+		require.NoError(t, table.Set(txn, refreshed))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		// Only the new expiration is indexed
+		require.Empty(t, listLockWaitersByExpiration(t, table, txn, 0, 1500))
+		require.Len(t, listLockWaitersByExpiration(t, table, txn, 0, 2500), 1)
+
+		// A refresh is not a new waiter
+		count, err := table.Count(txn, waiter.LockId)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+	})
+}
+
+func TestLockWaitersTable_Delete(t *testing.T) {
+	t.Run("delete a waiter", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waiter := &corepb.LockWaiter{
+			LockId:    randomLockId("test_lock"),
+			LeaseId:   rand.Uint64(),
+			ExpiresAt: 1000,
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.Update()
+		require.NoError(t, table.Delete(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, waiter.LockId, waiter.LeaseId)
+		require.ErrorIs(t, err, store.ErrNotFound)
+		require.Empty(t, listLockWaitersByExpiration(t, table, txn, 0, 2000))
+
+		count, err := table.Count(txn, waiter.LockId)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+
+	t.Run("delete a non-existent waiter", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		lockId := randomLockId("test_lock")
+		waiter := &corepb.LockWaiter{
+			LockId:    lockId,
+			LeaseId:   rand.Uint64(),
+			ExpiresAt: 1000,
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter))
+		require.NoError(t, table.Delete(txn, &corepb.LockWaiter{
+			LockId:    lockId,
+			LeaseId:   waiter.LeaseId + 1,
+			ExpiresAt: 1000,
+		}))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		// The other waiter and the count are untouched
+		_, err = table.Get(txn, lockId, waiter.LeaseId)
+		require.NoError(t, err)
+
+		count, err := table.Count(txn, lockId)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+	})
+}
+
+func TestLockWaitersTable_List(t *testing.T) {
+	t.Run("lists waiters sorted by lease id", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		lockId := randomLockId("test_lock")
+
+		txn := badgerStore.Update()
+		for _, leaseId := range []uint64{30, 10, 20} {
+			require.NoError(t, table.Set(txn, &corepb.LockWaiter{
+				LockId:    lockId,
+				LeaseId:   leaseId,
+				ExpiresAt: int64(leaseId),
+			}))
+		}
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, lockId, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.waiters, 3)
+		require.EqualValues(t, 10, result.waiters[0].LeaseId)
+		require.EqualValues(t, 20, result.waiters[1].LeaseId)
+		require.EqualValues(t, 30, result.waiters[2].LeaseId)
+		require.Nil(t, result.nextPaginationToken)
+	})
+
+	t.Run("lists waiters with pagination", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		lockId := randomLockId("test_lock")
+
+		txn := badgerStore.Update()
+		for leaseId := uint64(1); leaseId <= 5; leaseId++ {
+			require.NoError(t, table.Set(txn, &corepb.LockWaiter{
+				LockId:    lockId,
+				LeaseId:   leaseId,
+				ExpiresAt: 1000,
+			}))
+		}
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		page1, err := table.List(txn, lockId, nil, 3)
+		require.NoError(t, err)
+		require.Len(t, page1.waiters, 3)
+		require.NotNil(t, page1.nextPaginationToken)
+
+		page2, err := table.List(txn, lockId, page1.nextPaginationToken, 3)
+		require.NoError(t, err)
+		require.Len(t, page2.waiters, 2)
+		require.EqualValues(t, 4, page2.waiters[0].LeaseId)
+	})
+
+	t.Run("keeps waiters of locks sharing a name prefix apart", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		lockId := randomLockId("a")
+		otherLockId := &corepb.LockId{
+			AccountId:   lockId.AccountId,
+			NamespaceId: lockId.NamespaceId,
+			LockName:    "ab",
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, &corepb.LockWaiter{LockId: lockId, LeaseId: 1, ExpiresAt: 1000}))
+		require.NoError(t, table.Set(txn, &corepb.LockWaiter{LockId: otherLockId, LeaseId: 2, ExpiresAt: 1000}))
+		require.NoError(t, table.Set(txn, &corepb.LockWaiter{LockId: otherLockId, LeaseId: 3, ExpiresAt: 1000}))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, lockId, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.waiters, 1)
+		require.EqualValues(t, 1, result.waiters[0].LeaseId)
+
+		count, err := table.Count(txn, lockId)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+
+		count, err = table.Count(txn, otherLockId)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+	})
+}
+
+func TestLockWaitersTable_ListByExpiration(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     int64
+		to       int64
+		expected []uint64
+	}{
+		{
+			name:     "lists lapsed waiters earliest first",
+			from:     0,
+			to:       3500,
+			expected: []uint64{2, 3, 1},
+		},
+		{
+			name:     "skips waiters lapsing later",
+			from:     0,
+			to:       2500,
+			expected: []uint64{2, 3},
+		},
+		{
+			name:     "lists nothing before the earliest expiration",
+			from:     0,
+			to:       500,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badgerStore, err := store.NewBadgerInMemoryStore()
+			require.NoError(t, err)
+
+			table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+			txn := badgerStore.Update()
+			require.NoError(t, table.Set(txn, &corepb.LockWaiter{LockId: randomLockId("lock_1"), LeaseId: 1, ExpiresAt: 3000}))
+			require.NoError(t, table.Set(txn, &corepb.LockWaiter{LockId: randomLockId("lock_2"), LeaseId: 2, ExpiresAt: 1000}))
+			require.NoError(t, table.Set(txn, &corepb.LockWaiter{LockId: randomLockId("lock_3"), LeaseId: 3, ExpiresAt: 2000}))
+			require.NoError(t, txn.Commit())
+
+			txn = badgerStore.View()
+			defer txn.Discard()
+
+			var actual []uint64
+			for _, waiter := range listLockWaitersByExpiration(t, table, txn, tt.from, tt.to) {
+				actual = append(actual, waiter.LeaseId)
+			}
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestLockWaitersTable_Count(t *testing.T) {
+	tests := []struct {
+		name     string
+		added    []uint64
+		deleted  []uint64
+		expected int64
+	}{
+		{
+			name:     "no waiters",
+			expected: 0,
+		},
+		{
+			name:     "counts added waiters",
+			added:    []uint64{1, 2, 3},
+			expected: 3,
+		},
+		{
+			name:     "counts each lease once",
+			added:    []uint64{1, 2, 1, 2},
+			expected: 2,
+		},
+		{
+			name:     "deleted waiters are not counted",
+			added:    []uint64{1, 2, 3},
+			deleted:  []uint64{1, 3},
+			expected: 1,
+		},
+		{
+			name:     "all waiters deleted",
+			added:    []uint64{1, 2},
+			deleted:  []uint64{1, 2, 2},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badgerStore, err := store.NewBadgerInMemoryStore()
+			require.NoError(t, err)
+
+			table := newLockWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+			lockId := randomLockId("test_lock")
+
+			txn := badgerStore.Update()
+			for i, leaseId := range tt.added {
+				require.NoError(t, table.Set(txn, &corepb.LockWaiter{LockId: lockId, LeaseId: leaseId, ExpiresAt: int64(1000 + i)}))
+			}
+			for _, leaseId := range tt.deleted {
+				require.NoError(t, table.Delete(txn, &corepb.LockWaiter{LockId: lockId, LeaseId: leaseId}))
+			}
+			require.NoError(t, txn.Commit())
+
+			txn = badgerStore.View()
+			defer txn.Discard()
+
+			count, err := table.Count(txn, lockId)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, count)
+		})
+	}
+}
+
+func randomLockId(lockName string) *corepb.LockId {
+	return &corepb.LockId{
+		AccountId:   rand.Uint64(),
+		NamespaceId: rand.Uint64(),
+		LockName:    lockName,
+	}
+}
+
+func listLockWaitersByExpiration(t *testing.T, table *lockWaitersTable, txn *store.Txn, from int64, to int64) []*corepb.LockWaiter {
+	t.Helper()
+
+	var waiters []*corepb.LockWaiter
+	err := table.ListByExpiration(txn, from, to, func(waiter *corepb.LockWaiter) (bool, error) {
+		waiters = append(waiters, waiter)
+		return true, nil
+	})
+	require.NoError(t, err)
+	return waiters
+}
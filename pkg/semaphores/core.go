@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 
 	"github.com/evrblk/monstera"
 	"github.com/evrblk/monstera/cluster"
@@ -23,7 +24,8 @@ import (
 
 // Core implements the per-shard semaphores state machine on top of a Badger store.
 // It is the Monstera application core for the semaphores service and owns the
-// semaphores, their holders, leases, namespace counters, GC index, and semaphore expiration index.
+// semaphores, their holders and waiters, leases, namespace counters, GC index, semaphore
+// expiration index, and utilization stats.
 type Core struct {
 	badgerStore *store.BadgerStore
 
@@ -33,10 +35,12 @@ type Core struct {
 
 	semaphores        *semaphoresTable
 	holders           *holdersTable
+	waiters           *waitersTable
 	counters          *tables.CountersTable[*corepb.SemaphoresCounter, corepb.SemaphoresCounter]
 	gcRecords         *tables.GCRecordsTable[*corepb.SemaphoresGarbageCollectionRecord, corepb.SemaphoresGarbageCollectionRecord]
 	expirationRecords *expirationRecordsTable
 	leases            *tables.LeasesTable
+	stats             *statsTable
 }
 
 var _ coreapis.GrackleSemaphoresCoreApi = &Core{}
@@ -57,6 +61,7 @@ func NewCore(badgerStore *store.BadgerStore, replicaPrefix []byte, shardLowerBou
 
 		semaphores: newSemaphoresTable(replicaPrefix),
 		holders:    newHoldersTable(replicaPrefix),
+		waiters:    newWaitersTable(replicaPrefix),
 		counters: tables.NewCountersTable[*corepb.SemaphoresCounter, corepb.SemaphoresCounter](
 			utils.ConcatBytes(replicaPrefix, tablePrefixCounters),
 		),
@@ -69,6 +74,7 @@ func NewCore(badgerStore *store.BadgerStore, replicaPrefix []byte, shardLowerBou
 			utils.ConcatBytes(replicaPrefix, tablePrefixLeasesProcessIdIndex),
 			utils.ConcatBytes(replicaPrefix, tablePrefixLeasesExpirationIndex),
 		),
+		stats: newStatsTable(replicaPrefix),
 	}
 }
 
@@ -82,10 +88,12 @@ func (c *Core) snapshotSections() []tables.Section {
 	return []tables.Section{
 		{Name: "Semaphores", Table: c.semaphores},
 		{Name: "Holders", Table: c.holders},
+		{Name: "Waiters", Table: c.waiters},
 		{Name: "Counters", Table: c.counters},
 		{Name: "Leases", Table: c.leases},
 		{Name: "GarbageCollectionRecords", Table: c.gcRecords},
 		{Name: "ExpirationRecords", Table: c.expirationRecords},
+		{Name: "Stats", Table: c.stats},
 	}
 }
 
@@ -106,9 +114,16 @@ func (c *Core) Restore(readers ...io.ReadCloser) error {
 		tables.ShardRange{Lower: c.shardLowerBound, Upper: c.shardUpperBound}, readers...)
 }
 
-// CreateSemaphore creates a new semaphore in the target namespace.
-// Returns a ResourceExhausted application error when the namespace has reached
-// MaxNumberOfSemaphoresPerNamespace, or AlreadyExists when a semaphore with the same name exists.
+// maxSemaphoreDepth bounds the length of a semaphore hierarchy (the semaphore itself plus its
+// ancestors), and with it the parent chain walked on every acquire and release.
+const maxSemaphoreDepth = 8
+
+// CreateSemaphore creates a new semaphore in the target namespace, optionally under a parent
+// semaphore. Returns a ResourceExhausted application error when the namespace has reached
+// MaxNumberOfSemaphoresPerNamespace, AlreadyExists when a semaphore with the same name exists,
+// NotFound when the parent does not exist, or InvalidRequest when the hierarchy would get deeper
+// than maxSemaphoreDepth or the classes reserve more than the permits. With
+// DeleteInactiveAfterSeconds the semaphore is deleted by GC once it has been idle that long.
 func (c *Core) CreateSemaphore(req *coreapis.CreateSemaphoreRequest) (*coreapis.CreateSemaphoreResponse, error) {
 	if req.Payload.Permits == 0 {
 		return &coreapis.CreateSemaphoreResponse{
@@ -122,41 +137,99 @@ func (c *Core) CreateSemaphore(req *coreapis.CreateSemaphoreRequest) (*coreapis.
 		}, nil
 	}
 
-	txn := c.badgerStore.Update()
-	defer txn.Discard()
+	if req.Payload.DeleteInactiveAfterSeconds < 0 {
+		return &coreapis.CreateSemaphoreResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"delete inactive after seconds must not be negative",
+				map[string]string{
+					"delete_inactive_after_seconds": fmt.Sprintf("%d", req.Payload.DeleteInactiveAfterSeconds),
+				},
+			),
+		}, nil
+	}
 
-	// Get counters for that namespace
-	counters, err := c.counters.Get(txn, req.Payload.SemaphoreId.AccountId, req.Payload.SemaphoreId.NamespaceId)
-	if err != nil {
-		return nil, err
+	classes := make([]*corepb.SemaphoreClass, len(req.Payload.Classes))
+	for i, spec := range req.Payload.Classes {
+		classes[i] = &corepb.SemaphoreClass{
+			Name:       spec.Name,
+			MinPermits: spec.MinPermits,
+			MaxPermits: spec.MaxPermits,
+		}
 	}
 
-	// Checking max number of semaphores
-	if counters.NumberOfSemaphores >= req.Payload.MaxNumberOfSemaphoresPerNamespace {
+	if reservedPermits(classes) > req.Payload.Permits {
 		return &coreapis.CreateSemaphoreResponse{
 			ApplicationError: mrpc.NewErrorWithContext(
-				mrpc.ResourceExhausted,
-				"max number of semaphores per namespace reached",
+				mrpc.InvalidRequest,
+				"reserved class permits exceed semaphore permits",
 				map[string]string{
-					"limit": fmt.Sprintf("%d", req.Payload.MaxNumberOfSemaphoresPerNamespace),
+					"reserved_permits": fmt.Sprintf("%d", reservedPermits(classes)),
+					"permits":          fmt.Sprintf("%d", req.Payload.Permits),
 				},
 			),
 		}, nil
 	}
 
-	semaphore := &corepb.Semaphore{
-		Id:             req.Payload.SemaphoreId,
-		Name:           req.Payload.Name,
-		Description:    req.Payload.Description,
-		Permits:        req.Payload.Permits,
-		CreatedAt:      req.Now,
-		UpdatedAt:      req.Now,
-		Metadata:       req.Payload.Metadata,
-		Version:        1,
-		LastActivityAt: req.Now,
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	// The parent must exist and leave room for one more level
+	var parent *corepb.Semaphore
+	if req.Payload.ParentSemaphoreName != "" {
+		var err error
+		parent, err = c.semaphores.GetByName(txn, req.Payload.SemaphoreId.AccountId, req.Payload.SemaphoreId.NamespaceId, req.Payload.ParentSemaphoreName)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return &coreapis.CreateSemaphoreResponse{
+					ApplicationError: mrpc.NewErrorWithContext(
+						mrpc.NotFound,
+						"parent semaphore not found",
+						map[string]string{
+							"parent_semaphore_name": req.Payload.ParentSemaphoreName,
+						},
+					),
+				}, nil
+			}
+
+			return nil, err
+		}
+
+		ancestors, err := c.getSemaphoreAncestors(txn, parent.Id, parent.ParentSemaphoreId)
+		if err != nil {
+			return nil, err
+		}
+
+		// The new semaphore, its parent and the parent's ancestors
+		if len(ancestors)+2 > maxSemaphoreDepth {
+			return &coreapis.CreateSemaphoreResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"semaphore hierarchy is too deep",
+					map[string]string{
+						"parent_semaphore_name": req.Payload.ParentSemaphoreName,
+						"limit":                 fmt.Sprintf("%d", maxSemaphoreDepth),
+					},
+				),
+			}, nil
+		}
 	}
 
-	appError, err := c.semaphores.Create(txn, semaphore)
+	semaphore := &corepb.Semaphore{
+		Id:                         req.Payload.SemaphoreId,
+		Name:                       req.Payload.Name,
+		Description:                req.Payload.Description,
+		Permits:                    req.Payload.Permits,
+		CreatedAt:                  req.Now,
+		UpdatedAt:                  req.Now,
+		Metadata:                   req.Payload.Metadata,
+		Version:                    1,
+		LastActivityAt:             req.Now,
+		Classes:                    classes,
+		DeleteInactiveAfterSeconds: req.Payload.DeleteInactiveAfterSeconds,
+	}
+
+	appError, err := c.createSemaphoreInTransaction(txn, semaphore, parent, req.Payload.MaxNumberOfSemaphoresPerNamespace)
 	if err != nil {
 		return nil, err
 	}
@@ -166,13 +239,6 @@ func (c *Core) CreateSemaphore(req *coreapis.CreateSemaphoreRequest) (*coreapis.
 		}, nil
 	}
 
-	// Update counters
-	counters.NumberOfSemaphores += 1
-	err = c.counters.Set(txn, req.Payload.SemaphoreId.AccountId, req.Payload.SemaphoreId.NamespaceId, counters)
-	if err != nil {
-		return nil, err
-	}
-
 	err = txn.Commit()
 	if err != nil {
 		return nil, err
@@ -185,10 +251,58 @@ func (c *Core) CreateSemaphore(req *coreapis.CreateSemaphoreRequest) (*coreapis.
 	}, nil
 }
 
+// createSemaphoreInTransaction stores a new semaphore under the optional parent and counts it
+// against the namespace. Returns a ResourceExhausted application error when the namespace has
+// reached maxNumberOfSemaphores, or the errors of semaphoresTable.Create.
+func (c *Core) createSemaphoreInTransaction(txn *store.Txn, semaphore *corepb.Semaphore, parent *corepb.Semaphore, maxNumberOfSemaphores int64) (*mrpc.Error, error) {
+	// Get counters for that namespace
+	counters, err := c.counters.Get(txn, semaphore.Id.AccountId, semaphore.Id.NamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checking max number of semaphores
+	if counters.NumberOfSemaphores >= maxNumberOfSemaphores {
+		return mrpc.NewErrorWithContext(
+			mrpc.ResourceExhausted,
+			"max number of semaphores per namespace reached",
+			map[string]string{
+				"limit": fmt.Sprintf("%d", maxNumberOfSemaphores),
+			},
+		), nil
+	}
+
+	if parent != nil {
+		semaphore.ParentSemaphoreId = parent.Id.SemaphoreId
+		semaphore.ParentSemaphoreName = parent.Name
+	}
+
+	appError, err := c.semaphores.Create(txn, semaphore)
+	if err != nil || appError != nil {
+		return appError, err
+	}
+
+	if parent != nil {
+		parent.NumberOfChildren += 1
+		err = c.semaphores.Update(txn, parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Update counters
+	counters.NumberOfSemaphores += 1
+	return nil, c.counters.Set(txn, semaphore.Id.AccountId, semaphore.Id.NamespaceId, counters)
+}
+
 // UpdateSemaphore changes the description and permit count of an existing semaphore.
 // Expired holders are pruned before the check so that a stale ActiveHolds count cannot block
-// a legitimate shrink. Returns NotFound if the semaphore does not exist, or InvalidArgument if
-// the new permit count is below the current ActiveHolds.
+// a legitimate shrink. With AllowDraining the permit count may be lowered below the current
+// ActiveHolds; the semaphore then drains, granting nothing new until enough holders are gone.
+// Returns NotFound if the semaphore does not exist, or InvalidArgument if the permit count is
+// lowered below the current ActiveHolds without AllowDraining or below the permits reserved by
+// the semaphore's classes. A non-zero DeleteInactiveAfterSeconds replaces the inactivity window
+// and -1 turns auto-deletion off; 0 keeps the current window.
 func (c *Core) UpdateSemaphore(req *coreapis.UpdateSemaphoreRequest) (*coreapis.UpdateSemaphoreResponse, error) {
 	if req.Payload.Permits == 0 {
 		return &coreapis.UpdateSemaphoreResponse{
@@ -202,6 +316,18 @@ func (c *Core) UpdateSemaphore(req *coreapis.UpdateSemaphoreRequest) (*coreapis.
 		}, nil
 	}
 
+	if req.Payload.DeleteInactiveAfterSeconds < -1 {
+		return &coreapis.UpdateSemaphoreResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"delete inactive after seconds must be -1 or greater",
+				map[string]string{
+					"delete_inactive_after_seconds": fmt.Sprintf("%d", req.Payload.DeleteInactiveAfterSeconds),
+				},
+			),
+		}, nil
+	}
+
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
 
@@ -236,14 +362,30 @@ func (c *Core) UpdateSemaphore(req *coreapis.UpdateSemaphoreRequest) (*coreapis.
 		}, nil
 	}
 
+	// Class reservations have to fit even while draining
+	if reservedPermits(semaphore.Classes) > req.Payload.Permits {
+		return &coreapis.UpdateSemaphoreResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"reserved class permits exceed semaphore permits",
+				map[string]string{
+					"semaphore_name":   req.Payload.SemaphoreName,
+					"reserved_permits": fmt.Sprintf("%d", reservedPermits(semaphore.Classes)),
+					"new_permits":      fmt.Sprintf("%d", req.Payload.Permits),
+				},
+			),
+		}, nil
+	}
+
 	// Check expired holders
 	updatedSemaphore, _, err := c.deleteExpiredSemaphoreHolders(txn, semaphore, req.Now)
 	if err != nil {
 		return nil, err
 	}
 
-	// If there are currently more holds than the new amount of permits
-	if updatedSemaphore.ActiveHolds > req.Payload.Permits {
+	// If there are currently more holds than the new amount of permits. Keeping or raising the
+	// permits of a semaphore that is already draining is fine.
+	if updatedSemaphore.ActiveHolds > req.Payload.Permits && req.Payload.Permits < updatedSemaphore.Permits && !req.Payload.AllowDraining {
 		return &coreapis.UpdateSemaphoreResponse{
 			ApplicationError: mrpc.NewErrorWithContext(
 				mrpc.InvalidRequest,
@@ -261,6 +403,10 @@ func (c *Core) UpdateSemaphore(req *coreapis.UpdateSemaphoreRequest) (*coreapis.
 	updatedSemaphore.Permits = req.Payload.Permits
 	updatedSemaphore.UpdatedAt = req.Now
 	updatedSemaphore.Metadata = req.Payload.Metadata
+	if req.Payload.DeleteInactiveAfterSeconds != 0 {
+		// -1 turns auto-deletion off
+		updatedSemaphore.DeleteInactiveAfterSeconds = max(0, req.Payload.DeleteInactiveAfterSeconds)
+	}
 	updatedSemaphore.Version += 1
 
 	// Reconcile expirationRecords when the prune changed which holder expires first. Without
@@ -283,6 +429,11 @@ func (c *Core) UpdateSemaphore(req *coreapis.UpdateSemaphoreRequest) (*coreapis.
 		}
 	}
 
+	err = c.recordSemaphoreStats(txn, updatedSemaphore, req.Now, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	err = c.semaphores.Update(txn, updatedSemaphore)
 	if err != nil {
 		return nil, err
@@ -303,7 +454,8 @@ func (c *Core) UpdateSemaphore(req *coreapis.UpdateSemaphoreRequest) (*coreapis.
 // DeleteSemaphore removes a semaphore by name along with its expiration-index entry and decrements
 // the namespace counter. A missing semaphore is treated as success (no error, empty response).
 // Holders are not released synchronously; instead a GC record is created so that
-// RunSemaphoresGarbageCollection can drain them in bounded batches.
+// RunSemaphoresGarbageCollection can drain them in bounded batches, giving their weight back to
+// the parent chain on the way. Returns InvalidRequest for a semaphore that still has children.
 func (c *Core) DeleteSemaphore(req *coreapis.DeleteSemaphoreRequest) (*coreapis.DeleteSemaphoreResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -320,6 +472,40 @@ func (c *Core) DeleteSemaphore(req *coreapis.DeleteSemaphoreRequest) (*coreapis.
 		return nil, err
 	}
 
+	// Children would be left pointing at a missing parent
+	if semaphore.NumberOfChildren > 0 {
+		return &coreapis.DeleteSemaphoreResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"semaphore has child semaphores",
+				map[string]string{
+					"semaphore_name":     req.Payload.SemaphoreName,
+					"number_of_children": fmt.Sprintf("%d", semaphore.NumberOfChildren),
+				},
+			),
+		}, nil
+	}
+
+	if semaphore.ParentSemaphoreId != 0 {
+		parent, err := c.semaphores.Get(txn, &corepb.SemaphoreId{
+			AccountId:   semaphore.Id.AccountId,
+			NamespaceId: semaphore.Id.NamespaceId,
+			SemaphoreId: semaphore.ParentSemaphoreId,
+		})
+		if err != nil {
+			// The parent can only be missing while its namespace is being deleted
+			if !errors.Is(err, store.ErrNotFound) {
+				return nil, err
+			}
+		} else {
+			parent.NumberOfChildren -= 1
+			err = c.semaphores.Update(txn, parent)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Get counters for this namespace
 	counters, err := c.counters.Get(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId)
 	if err != nil {
@@ -340,12 +526,14 @@ func (c *Core) DeleteSemaphore(req *coreapis.DeleteSemaphoreRequest) (*coreapis.
 	}
 
 	// Schedule asynchronous cleanup of leftover holders. The semaphore record itself is already
-	// gone; GC just needs the semaphore_id to drain the remaining holders.
+	// gone; GC just needs the semaphore_id to drain the remaining holders, and the parent to
+	// unwind their weight from the ancestors.
 	err = c.gcRecords.Create(txn, &corepb.SemaphoresGarbageCollectionRecord{
 		Id: req.Payload.RecordId,
 		Record: &corepb.SemaphoresGarbageCollectionRecord_SemaphoreId{
 			SemaphoreId: semaphore.Id,
 		},
+		ParentSemaphoreId: semaphore.ParentSemaphoreId,
 	})
 	if err != nil {
 		return nil, err
@@ -371,8 +559,8 @@ func (c *Core) DeleteSemaphore(req *coreapis.DeleteSemaphoreRequest) (*coreapis.
 // GetSemaphore looks up a semaphore by SemaphoreId. Because it runs on a read-only transaction it
 // cannot remove expired holders; instead it returns a copy of the semaphore with `ActiveHolds`,
 // `ActiveHoldersCount`, and `EarliestHolderExpiresAt` adjusted as if holders that expired by `now`
-// had been removed. Expired rows are cleaned up by GC. Returns NotFound if the semaphore does not
-// exist.
+// had been removed, along with the number of leases waiting for it. Expired rows, lapsed waiters
+// included, are left out and cleaned up by GC. Returns NotFound if the semaphore does not exist.
 func (c *Core) GetSemaphore(req *coreapis.GetSemaphoreRequest) (*coreapis.GetSemaphoreResponse, error) {
 	txn := c.badgerStore.View()
 	defer txn.Discard()
@@ -400,9 +588,15 @@ func (c *Core) GetSemaphore(req *coreapis.GetSemaphoreRequest) (*coreapis.GetSem
 		return nil, err
 	}
 
+	waitersCount, err := c.waiters.CountActive(txn, semaphore.Id, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
 	return &coreapis.GetSemaphoreResponse{
 		Payload: &corepb.GetSemaphoreResponse{
-			Semaphore: updatedSemaphore,
+			Semaphore:    updatedSemaphore,
+			WaitersCount: waitersCount,
 		},
 	}, nil
 }
@@ -410,8 +604,8 @@ func (c *Core) GetSemaphore(req *coreapis.GetSemaphoreRequest) (*coreapis.GetSem
 // GetSemaphoreByName is the by-name counterpart of GetSemaphore. Because it runs on a read-only
 // transaction it cannot remove expired holders; instead it returns a copy of the semaphore with
 // `ActiveHolds`, `ActiveHoldersCount`, and `EarliestHolderExpiresAt` adjusted as if holders that
-// expired by `now` had been removed. Returns NotFound when no semaphore with that name exists in
-// the namespace.
+// expired by `now` had been removed, along with the number of leases waiting for it (lapsed waiters
+// are not counted). Returns NotFound when no semaphore with that name exists in the namespace.
 func (c *Core) GetSemaphoreByName(req *coreapis.GetSemaphoreByNameRequest) (*coreapis.GetSemaphoreByNameResponse, error) {
 	txn := c.badgerStore.View()
 	defer txn.Discard()
@@ -439,17 +633,23 @@ func (c *Core) GetSemaphoreByName(req *coreapis.GetSemaphoreByNameRequest) (*cor
 		return nil, err
 	}
 
+	waitersCount, err := c.waiters.CountActive(txn, semaphore.Id, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
 	return &coreapis.GetSemaphoreByNameResponse{
 		Payload: &corepb.GetSemaphoreByNameResponse{
-			Semaphore: updatedSemaphore,
+			Semaphore:    updatedSemaphore,
+			WaitersCount: waitersCount,
 		},
 	}, nil
 }
 
 // ListSemaphoreHolders returns a page of holders for a semaphore, with holders that have expired
 // by `now` filtered out. The transaction is read-only, so expired entries remain in the store
-// and are cleaned up by GC or by a subsequent write path. Returns NotFound when the semaphore
-// does not exist.
+// and are cleaned up by GC or by a subsequent write path. The semaphore's classes are returned
+// alongside with their current ActiveHolds. Returns NotFound when the semaphore does not exist.
 func (c *Core) ListSemaphoreHolders(req *coreapis.ListSemaphoreHoldersRequest) (*coreapis.ListSemaphoreHoldersResponse, error) {
 	txn := c.badgerStore.View()
 	defer txn.Discard()
@@ -476,15 +676,145 @@ func (c *Core) ListSemaphoreHolders(req *coreapis.ListSemaphoreHoldersRequest) (
 		return nil, err
 	}
 
-	activeHolders := lo.Filter(result.holders, func(h *corepb.SemaphoreHolder, _ int) bool {
-		return h.ExpiresAt > req.Now
-	})
+	activeHolders := make([]*corepb.SemaphoreHolder, 0, len(result.holders))
+	for _, h := range result.holders {
+		if holderExpiresAt(h) > req.Now {
+			activeHolders = append(activeHolders, h)
+		} else if keepsInheritedWeight(h, req.Now) {
+			activeHolders = append(activeHolders, inheritedHolder(h))
+		}
+	}
+
+	// Per-class holds as if expired holders had been removed
+	updatedSemaphore, _, err := c.computeExpiredSemaphoreHolders(txn, semaphore, req.Now)
+	if err != nil {
+		return nil, err
+	}
 
 	return &coreapis.ListSemaphoreHoldersResponse{
 		Payload: &corepb.ListSemaphoreHoldersResponse{
 			Holders:                 activeHolders,
 			NextPaginationToken:     result.nextPaginationToken,
 			PreviousPaginationToken: result.previousPaginationToken,
+			Classes:                 updatedSemaphore.Classes,
+		},
+	}, nil
+}
+
+// ListSemaphoreWaiters returns a page of the leases currently blocked in AcquireSemaphore on a
+// semaphore, sorted by lease id. Waiters that lapsed by `now` are filtered out; deleting them is
+// left to GC. Returns NotFound when the semaphore does not exist.
+func (c *Core) ListSemaphoreWaiters(req *coreapis.ListSemaphoreWaitersRequest) (*coreapis.ListSemaphoreWaitersResponse, error) {
+	txn := c.badgerStore.View()
+	defer txn.Discard()
+
+	semaphore, err := c.semaphores.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.SemaphoreName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.ListSemaphoreWaitersResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"semaphore not found",
+					map[string]string{
+						"semaphore_name": req.Payload.SemaphoreName,
+					},
+				),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := c.waiters.List(txn, semaphore.Id, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	waiters := make([]*corepb.SemaphoreWaiter, 0, len(result.waiters))
+	for _, w := range result.waiters {
+		if w.ExpiresAt > req.Now {
+			waiters = append(waiters, w)
+		}
+	}
+
+	return &coreapis.ListSemaphoreWaitersResponse{
+		Payload: &corepb.ListSemaphoreWaitersResponse{
+			Waiters:                 waiters,
+			NextPaginationToken:     result.nextPaginationToken,
+			PreviousPaginationToken: result.previousPaginationToken,
+		},
+	}, nil
+}
+
+// GetSemaphoreStats returns the semaphore's utilization over the last WindowSeconds (at most
+// a day), one bucket per minute, oldest first. Minutes without any activity are not stored; they
+// are filled in with the active holds carried over from before. Returns NotFound when the
+// semaphore does not exist.
+func (c *Core) GetSemaphoreStats(req *coreapis.GetSemaphoreStatsRequest) (*coreapis.GetSemaphoreStatsResponse, error) {
+	txn := c.badgerStore.View()
+	defer txn.Discard()
+
+	semaphore, err := c.semaphores.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.SemaphoreName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.GetSemaphoreStatsResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"semaphore not found",
+					map[string]string{
+						"semaphore_name": req.Payload.SemaphoreName,
+					},
+				),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	window := min(max(req.Payload.WindowSeconds*int64(time.Second), statsBucketSize), statsRetention-statsBucketSize)
+	from := statsBucketStart(req.Now - window)
+	to := statsBucketStart(req.Now) + statsBucketSize
+
+	records := make(map[int64]*corepb.SemaphoreStatsRecord)
+	var first *corepb.SemaphoreStatsRecord
+	err = c.stats.List(txn, semaphore.Id, from, to, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+		if first == nil {
+			first = record
+		}
+		records[record.StartAt] = record
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without any change in the window the semaphore has been at its current holds all along
+	activeHolds := semaphore.ActiveHolds
+	if first != nil {
+		activeHolds = first.OpeningActiveHolds
+	}
+
+	buckets := make([]*corepb.SemaphoreStatsBucket, 0, (to-from)/statsBucketSize)
+	for startAt := from; startAt < to; startAt += statsBucketSize {
+		end := min(startAt+statsBucketSize, req.Now)
+
+		record, ok := records[startAt]
+		if !ok {
+			buckets = append(buckets, &corepb.SemaphoreStatsBucket{
+				StartAt:            startAt,
+				MaxActiveHolds:     activeHolds,
+				AverageActiveHolds: float64(activeHolds),
+			})
+			continue
+		}
+
+		buckets = append(buckets, statsBucket(record, end))
+		activeHolds = record.ActiveHolds
+	}
+
+	return &coreapis.GetSemaphoreStatsResponse{
+		Payload: &corepb.GetSemaphoreStatsResponse{
+			Buckets: buckets,
 		},
 	}, nil
 }
@@ -523,11 +853,22 @@ func (c *Core) ListSemaphores(req *coreapis.ListSemaphoresRequest) (*coreapis.Li
 // AcquireSemaphore attempts to acquire `Weight` permits on the named semaphore under the given lease.
 // If the lease already holds the semaphore, the existing holder's expiration is extended to the
 // lease's ExpiresAt (the weight is not changed). Expired holders are pruned before the permit
-// check so an expired holder's permits become available immediately.
+// check so an expired holder's permits become available immediately. On a semaphore with a parent
+// the same weight must fit on every ancestor too, and is consumed there as inherited weight of
+// the lease's holder on that ancestor. On a semaphore with classes the weight is acquired in
+// Class: it must stay within the class's maximum and cannot take the permits other classes
+// reserve but do not use. With MaxHoldSeconds the direct hold is released after that time even
+// while the lease is refreshed, together with the weight the ancestors inherit from it;
+// re-acquiring restarts (or clears) it.
+// With DefaultPermits a missing semaphore is created first (counted against
+// MaxNumberOfSemaphoresPerNamespace), so callers need not create per-key semaphores up front.
 // Returns Payload.Success=false (without an application error) when the request is valid but
-// permits are unavailable. Returns NotFound application errors for missing/expired leases or a
-// missing semaphore, and InvalidArgument when Weight == 0 or Weight exceeds the semaphore's
-// permits (a request that could never be satisfied no matter how long the caller waits).
+// permits are unavailable, together with best-effort diagnostics of the shortfall (see
+// semaphoreContention); while WaitUntil is in the future the lease is then recorded as a
+// waiter on the semaphore (see updateSemaphoreWaiter). Returns NotFound application errors for
+// missing/expired leases or a missing semaphore, and InvalidArgument when Weight == 0 or Weight
+// exceeds the semaphore's permits (a request that could never be satisfied no matter how long
+// the caller waits).
 func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapis.AcquireSemaphoreResponse, error) {
 	if req.Payload.Weight == 0 {
 		return &coreapis.AcquireSemaphoreResponse{
@@ -541,6 +882,18 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 		}, nil
 	}
 
+	if req.Payload.MaxHoldSeconds < 0 {
+		return &coreapis.AcquireSemaphoreResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"max hold seconds must not be negative",
+				map[string]string{
+					"max_hold_seconds": fmt.Sprintf("%d", req.Payload.MaxHoldSeconds),
+				},
+			),
+		}, nil
+	}
+
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
 
@@ -582,7 +935,11 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 
 	semaphore, err := c.semaphores.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.SemaphoreName)
 	if err != nil {
-		if errors.Is(err, store.ErrNotFound) {
+		if !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+
+		if req.Payload.DefaultPermits <= 0 {
 			return &coreapis.AcquireSemaphoreResponse{
 				ApplicationError: mrpc.NewErrorWithContext(
 					mrpc.NotFound,
@@ -594,7 +951,30 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 			}, nil
 		}
 
-		return nil, err
+		// Create the missing semaphore on the fly, the way locks spring into existence
+		semaphore = &corepb.Semaphore{
+			Id: &corepb.SemaphoreId{
+				AccountId:   req.Payload.NamespaceId.AccountId,
+				NamespaceId: req.Payload.NamespaceId.NamespaceId,
+				SemaphoreId: req.Payload.NewSemaphoreId,
+			},
+			Name:                       req.Payload.SemaphoreName,
+			Permits:                    req.Payload.DefaultPermits,
+			CreatedAt:                  req.Now,
+			UpdatedAt:                  req.Now,
+			Version:                    1,
+			LastActivityAt:             req.Now,
+			DeleteInactiveAfterSeconds: max(0, req.Payload.DefaultDeleteInactiveAfterSeconds),
+		}
+		appError, err := c.createSemaphoreInTransaction(txn, semaphore, nil, req.Payload.MaxNumberOfSemaphoresPerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if appError != nil {
+			return &coreapis.AcquireSemaphoreResponse{
+				ApplicationError: appError,
+			}, nil
+		}
 	}
 
 	// A weight larger than the semaphore's total permits can never be satisfied,
@@ -613,34 +993,103 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 		}, nil
 	}
 
-	// Check expired holders
+	// The class must exist, and its maximum is another bound the weight can never exceed
+	if req.Payload.Class != "" {
+		class := semaphoreClass(semaphore, req.Payload.Class)
+		if class == nil {
+			return &coreapis.AcquireSemaphoreResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"semaphore class not found",
+					map[string]string{
+						"semaphore_name": req.Payload.SemaphoreName,
+						"class":          req.Payload.Class,
+					},
+				),
+			}, nil
+		}
+
+		if class.MaxPermits > 0 && req.Payload.Weight > class.MaxPermits {
+			return &coreapis.AcquireSemaphoreResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"weight exceeds semaphore class max permits",
+					map[string]string{
+						"weight":      fmt.Sprintf("%d", req.Payload.Weight),
+						"max_permits": fmt.Sprintf("%d", class.MaxPermits),
+						"class":       req.Payload.Class,
+					},
+				),
+			}, nil
+		}
+	}
+
+	// Check expired holders. Ancestors are read afterwards, as a hold past its max hold time
+	// gives back the weight they inherit from it.
 	updatedSemaphore, _, err := c.deleteExpiredSemaphoreHolders(txn, semaphore, req.Now)
 	if err != nil {
 		return nil, err
 	}
 
-	success := false
-
-	// Check if the same process_id already holds the semaphore here.
-	holderId := &corepb.SemaphoreHolderId{
-		AccountId:   req.Payload.NamespaceId.AccountId,
-		NamespaceId: req.Payload.NamespaceId.NamespaceId,
-		SemaphoreId: semaphore.Id.SemaphoreId,
-		LeaseId:     lease.Id.LeaseId,
-	}
-	existingHolder, err := c.holders.Get(txn, holderId)
+	ancestors, err := c.getSemaphoreAncestors(txn, semaphore.Id, semaphore.ParentSemaphoreId)
 	if err != nil {
-		if errors.Is(err, store.ErrNotFound) {
-			// Check if there are enough permits
-			if req.Payload.Weight <= updatedSemaphore.Permits-updatedSemaphore.ActiveHolds {
-				// Add a new lock holder
-				newHolder := &corepb.SemaphoreHolder{
-					Id:        holderId,
-					ExpiresAt: lease.ExpiresAt,
-					LockedAt:  req.Now,
-					Weight:    req.Payload.Weight,
-					Metadata:  req.Payload.Metadata,
-				}
+		return nil, err
+	}
+
+	// The same goes for every ancestor the weight is consumed on
+	for _, ancestor := range ancestors {
+		if req.Payload.Weight > ancestor.Permits {
+			return &coreapis.AcquireSemaphoreResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"weight exceeds ancestor semaphore permits",
+					map[string]string{
+						"weight":                  fmt.Sprintf("%d", req.Payload.Weight),
+						"permits":                 fmt.Sprintf("%d", ancestor.Permits),
+						"ancestor_semaphore_name": ancestor.Name,
+					},
+				),
+			}, nil
+		}
+	}
+
+	updatedAncestors, err := c.deleteExpiredAncestorsHolders(txn, ancestors, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	success := false
+
+	// Permits the acquire needs on top of what the lease already holds
+	needed := req.Payload.Weight
+
+	holdExpiresAt := int64(0)
+	if req.Payload.MaxHoldSeconds > 0 {
+		holdExpiresAt = req.Now + req.Payload.MaxHoldSeconds*1e9
+	}
+
+	// Check if the same process_id already holds the semaphore here.
+	holderId := &corepb.SemaphoreHolderId{
+		AccountId:   req.Payload.NamespaceId.AccountId,
+		NamespaceId: req.Payload.NamespaceId.NamespaceId,
+		SemaphoreId: semaphore.Id.SemaphoreId,
+		LeaseId:     lease.Id.LeaseId,
+	}
+	existingHolder, err := c.holders.Get(txn, holderId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			// Check if there are enough permits
+			if req.Payload.Weight <= freePermits(updatedSemaphore, req.Payload.Class) && havePermits(updatedAncestors, req.Payload.Weight) {
+				// Add a new lock holder
+				newHolder := &corepb.SemaphoreHolder{
+					Id:            holderId,
+					ExpiresAt:     lease.ExpiresAt,
+					LockedAt:      req.Now,
+					Weight:        req.Payload.Weight,
+					Metadata:      req.Payload.Metadata,
+					Class:         req.Payload.Class,
+					HoldExpiresAt: holdExpiresAt,
+				}
 
 				err = c.holders.Create(txn, newHolder)
 				if err != nil {
@@ -649,10 +1098,16 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 
 				updatedSemaphore.ActiveHoldersCount += 1
 				updatedSemaphore.ActiveHolds += req.Payload.Weight
+				addClassHolds(updatedSemaphore, req.Payload.Class, req.Payload.Weight)
 
 				// Update earliest expiration if this is the first holder or expires earlier
-				if updatedSemaphore.EarliestHolderExpiresAt == 0 || newHolder.ExpiresAt < updatedSemaphore.EarliestHolderExpiresAt {
-					updatedSemaphore.EarliestHolderExpiresAt = newHolder.ExpiresAt
+				if updatedSemaphore.EarliestHolderExpiresAt == 0 || holderExpiresAt(newHolder) < updatedSemaphore.EarliestHolderExpiresAt {
+					updatedSemaphore.EarliestHolderExpiresAt = holderExpiresAt(newHolder)
+				}
+
+				err = c.propagateHold(txn, updatedAncestors, lease.Id.LeaseId, lease.ExpiresAt, req.Payload.Weight, req.Now)
+				if err != nil {
+					return nil, err
 				}
 
 				success = true
@@ -666,24 +1121,43 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 		//   - new < old: surplus permits are freed.
 		//   - new > old: only if the extra permits fit under the semaphore's permit cap.
 		// When the request would grow the hold past the cap, leave the existing holder untouched
-		// (weight and expiration unchanged) and report failure.
+		// (weight and expiration unchanged) and report failure. Only the directly acquired part
+		// of the holder is replaced; weight inherited from descendants stays as it is. The hold
+		// cannot move to another class this way.
+		weight := existingHolder.Weight - existingHolder.InheritedWeight
+		if weight > 0 && existingHolder.Class != req.Payload.Class {
+			return &coreapis.AcquireSemaphoreResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"semaphore is held by the lease in another class",
+					map[string]string{
+						"semaphore_name": req.Payload.SemaphoreName,
+						"class":          existingHolder.Class,
+					},
+				),
+			}, nil
+		}
+
 		canAcquire := true
-		if req.Payload.Weight > existingHolder.Weight {
-			delta := req.Payload.Weight - existingHolder.Weight
-			canAcquire = updatedSemaphore.Permits >= updatedSemaphore.ActiveHolds+delta
+		needed = req.Payload.Weight - weight
+		if needed > 0 {
+			canAcquire = needed <= freePermits(updatedSemaphore, req.Payload.Class) && havePermits(updatedAncestors, needed)
 		}
 		if canAcquire {
-			updatedSemaphore.ActiveHolds = updatedSemaphore.ActiveHolds - existingHolder.Weight + req.Payload.Weight
-			existingHolder.Weight = req.Payload.Weight
+			updatedSemaphore.ActiveHolds = updatedSemaphore.ActiveHolds - weight + req.Payload.Weight
+			addClassHolds(updatedSemaphore, req.Payload.Class, req.Payload.Weight-weight)
+			existingHolder.Weight = existingHolder.InheritedWeight + req.Payload.Weight
+			existingHolder.Class = req.Payload.Class
 
 			// Update expiration time (extend lock)
 			existingHolder.ExpiresAt = lease.ExpiresAt
+			existingHolder.HoldExpiresAt = holdExpiresAt
 			existingHolder.LockedAt = req.Now
 			existingHolder.Metadata = req.Payload.Metadata
 
 			// Update earliest expiration if this holder expires earlier
-			if updatedSemaphore.EarliestHolderExpiresAt == 0 || existingHolder.ExpiresAt < updatedSemaphore.EarliestHolderExpiresAt {
-				updatedSemaphore.EarliestHolderExpiresAt = existingHolder.ExpiresAt
+			if updatedSemaphore.EarliestHolderExpiresAt == 0 || holderExpiresAt(existingHolder) < updatedSemaphore.EarliestHolderExpiresAt {
+				updatedSemaphore.EarliestHolderExpiresAt = holderExpiresAt(existingHolder)
 			}
 
 			err := c.holders.Update(txn, existingHolder)
@@ -691,10 +1165,20 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 				return nil, err
 			}
 
+			err = c.propagateHold(txn, updatedAncestors, lease.Id.LeaseId, lease.ExpiresAt, req.Payload.Weight-weight, req.Now)
+			if err != nil {
+				return nil, err
+			}
+
 			success = true
 		}
 	}
 
+	err = c.saveSemaphores(txn, ancestors, updatedAncestors, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
 	if semaphore.EarliestHolderExpiresAt != updatedSemaphore.EarliestHolderExpiresAt {
 		// Remove a semaphore from expirationRecords at old position
 		if semaphore.EarliestHolderExpiresAt != 0 {
@@ -713,9 +1197,37 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 		}
 	}
 
-	// Record the acquire attempt (whether or not it succeeded).
+	var missingPermits, earliestGrantAt int64
+	var blockingHolders []*corepb.SemaphoreHolder
+	if !success {
+		missingPermits, blockingHolders, earliestGrantAt, err = c.semaphoreContention(txn,
+			updatedSemaphore, updatedAncestors, req.Payload.Class, needed, lease.Id.LeaseId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Record the acquire attempt (whether or not it succeeded). A failure the
+	// caller retries is not counted, only its last attempt is.
 	updatedSemaphore.LastActivityAt = req.Now
 
+	acquiresSucceeded, acquiresFailed := int64(1), int64(0)
+	if !success {
+		acquiresSucceeded = 0
+		if !req.Payload.WillRetry {
+			acquiresFailed = 1
+		}
+	}
+	err = c.recordSemaphoreStats(txn, updatedSemaphore, req.Now, acquiresSucceeded, acquiresFailed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.updateSemaphoreWaiter(txn, req, lease, updatedSemaphore, success)
+	if err != nil {
+		return nil, err
+	}
+
 	err = c.semaphores.Update(txn, updatedSemaphore)
 	if err != nil {
 		return nil, err
@@ -728,15 +1240,102 @@ func (c *Core) AcquireSemaphore(req *coreapis.AcquireSemaphoreRequest) (*coreapi
 
 	return &coreapis.AcquireSemaphoreResponse{
 		Payload: &corepb.AcquireSemaphoreResponse{
-			Semaphore: updatedSemaphore,
-			Success:   success,
+			Semaphore:       updatedSemaphore,
+			Success:         success,
+			MissingPermits:  missingPermits,
+			BlockingHolders: blockingHolders,
+			EarliestGrantAt: earliestGrantAt,
 		},
 	}, nil
 }
 
-// ReleaseSemaphore releases the lease's hold on a semaphore, freeing its permits.
-// Releasing a semaphore that the lease does not hold is treated as success (the semaphore is
-// returned unchanged). Returns NotFound for a missing lease or semaphore.
+// maxBlockingHolders bounds how many blocking holders AcquireSemaphore reports back, like
+// maxBlockingLocks does for locks.
+const maxBlockingHolders = 50
+
+// semaphoreContention explains why an acquire needing `needed` more permits did not fit. It picks
+// the semaphore with the largest shortfall (the semaphore itself first, then its ancestors, which
+// only have permits outside of any class to give) and walks its holders in order of expiration,
+// skipping the acquiring lease's own, until their weights cover the shortfall. It returns the
+// shortfall, the holders walked (capped at maxBlockingHolders), and the time the last of them
+// lapses, or 0 when they do not cover it. Holders of other classes are counted even though their
+// release may not free permits for this class; the result is a hint, not a promise.
+func (c *Core) semaphoreContention(txn *store.Txn, semaphore *corepb.Semaphore, ancestors []*corepb.Semaphore, class string, needed int64, leaseId uint64) (int64, []*corepb.SemaphoreHolder, int64, error) {
+	bottleneck := semaphore
+	missingPermits := needed - freePermits(semaphore, class)
+	for _, ancestor := range ancestors {
+		if missing := needed - freePermits(ancestor, ""); missing > missingPermits {
+			bottleneck = ancestor
+			missingPermits = missing
+		}
+	}
+
+	blockingHolders := make([]*corepb.SemaphoreHolder, 0)
+	earliestGrantAt := int64(0)
+	freed := int64(0)
+	err := c.holders.ListByExpiration(txn, bottleneck.Id, 0, math.MaxInt64, func(holder *corepb.SemaphoreHolder) (bool, error) {
+		if holder.Id.LeaseId == leaseId {
+			return true, nil
+		}
+
+		blockingHolders = append(blockingHolders, holder)
+
+		// A holder past its max hold time keeps the weight it inherits from descendants
+		if holder.HoldExpiresAt != 0 && holder.HoldExpiresAt < holder.ExpiresAt {
+			freed += holder.Weight - holder.InheritedWeight
+		} else {
+			freed += holder.Weight
+		}
+
+		if freed >= missingPermits {
+			earliestGrantAt = holderExpiresAt(holder)
+			return false, nil
+		}
+		return len(blockingHolders) < maxBlockingHolders, nil
+	})
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	return missingPermits, blockingHolders, earliestGrantAt, nil
+}
+
+// updateSemaphoreWaiter keeps the lease's waiter record on the semaphore in line with an acquire
+// attempt: the lease stays a waiter while the attempt failed and the caller keeps retrying until
+// WaitUntil, and is forgotten otherwise. The record lapses at the end of the wait or of the lease,
+// whichever comes first, in case the caller goes away without a final attempt.
+func (c *Core) updateSemaphoreWaiter(txn *store.Txn, req *coreapis.AcquireSemaphoreRequest, lease *corepb.Lease, semaphore *corepb.Semaphore, acquired bool) error {
+	waiter, err := c.waiters.Get(txn, semaphore.Id, req.Payload.LeaseId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	if acquired || req.Payload.WaitUntil <= req.Now {
+		if waiter == nil {
+			return nil
+		}
+		return c.waiters.Delete(txn, waiter)
+	}
+
+	if waiter == nil {
+		waiter = &corepb.SemaphoreWaiter{
+			SemaphoreId:  semaphore.Id,
+			LeaseId:      req.Payload.LeaseId,
+			WaitingSince: req.Now,
+		}
+	}
+	waiter.Weight = req.Payload.Weight
+	waiter.Class = req.Payload.Class
+	waiter.ProcessId = lease.ProcessId
+	waiter.ExpiresAt = min(req.Payload.WaitUntil, lease.ExpiresAt)
+
+	return c.waiters.Set(txn, waiter)
+}
+
+// ReleaseSemaphore releases the lease's hold on a semaphore, freeing its permits on the semaphore
+// and on every ancestor. Weight the lease holds through descendant semaphores is kept.
+// Releasing a semaphore that the lease does not hold directly is treated as success (the
+// semaphore is returned unchanged). Returns NotFound for a missing lease or semaphore.
 func (c *Core) ReleaseSemaphore(req *coreapis.ReleaseSemaphoreRequest) (*coreapis.ReleaseSemaphoreResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -812,43 +1411,495 @@ func (c *Core) ReleaseSemaphore(req *coreapis.ReleaseSemaphoreRequest) (*coreapi
 			}, nil
 		}
 
-		return nil, err
+		return nil, err
+	}
+
+	// A holder that only carries weight inherited from descendants is not held directly
+	weight := existingHolder.Weight - existingHolder.InheritedWeight
+	if weight == 0 {
+		return &coreapis.ReleaseSemaphoreResponse{
+			Payload: &corepb.ReleaseSemaphoreResponse{
+				Semaphore: semaphore,
+			},
+		}, nil
+	}
+
+	class := existingHolder.Class
+	if existingHolder.InheritedWeight > 0 {
+		err = c.holders.Update(txn, inheritedHolder(existingHolder))
+	} else {
+		err = c.holders.Delete(txn, existingHolder)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check expired holders
+	updatedSemaphore, _, err := c.deleteExpiredSemaphoreHolders(txn, semaphore, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSemaphore.ActiveHolds -= weight
+	addClassHolds(updatedSemaphore, class, -weight)
+	if existingHolder.InheritedWeight == 0 {
+		updatedSemaphore.ActiveHoldersCount -= 1
+	}
+	updatedSemaphore.LastActivityAt = req.Now
+
+	// Give the weight back to the ancestors
+	ancestors, err := c.getSemaphoreAncestors(txn, semaphore.Id, semaphore.ParentSemaphoreId)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAncestors, err := c.deleteExpiredAncestorsHolders(txn, ancestors, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.propagateHold(txn, updatedAncestors, lease.Id.LeaseId, lease.ExpiresAt, -weight, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.saveSemaphores(txn, ancestors, updatedAncestors, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	if semaphore.EarliestHolderExpiresAt != updatedSemaphore.EarliestHolderExpiresAt {
+		// Remove a semaphore from expirationRecords at old position
+		if semaphore.EarliestHolderExpiresAt != 0 {
+			err = c.expirationRecords.Delete(txn, semaphore.EarliestHolderExpiresAt, semaphore.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if updatedSemaphore.EarliestHolderExpiresAt != 0 {
+			// Add a semaphore into expirationRecords at new position
+			err = c.expirationRecords.Add(txn, updatedSemaphore.EarliestHolderExpiresAt, semaphore.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = c.recordSemaphoreStats(txn, updatedSemaphore, req.Now, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.semaphores.Update(txn, updatedSemaphore)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.ReleaseSemaphoreResponse{
+		Payload: &corepb.ReleaseSemaphoreResponse{
+			Semaphore: updatedSemaphore,
+		},
+	}, nil
+}
+
+// AdjustSemaphoreHold changes the weight of the lease's existing hold on a semaphore in place,
+// keeping its LockedAt. Shrinking always succeeds and frees the difference on the semaphore and its
+// ancestors. Growing succeeds only when the extra permits are free on all of them; otherwise
+// Payload.Success=false is returned (without an application error) and the hold is unchanged.
+// The hold stays in its class. Returns NotFound for a missing lease, semaphore or hold, and
+// InvalidArgument when Weight == 0 or Weight exceeds the permits of the semaphore, an ancestor or
+// the class.
+func (c *Core) AdjustSemaphoreHold(req *coreapis.AdjustSemaphoreHoldRequest) (*coreapis.AdjustSemaphoreHoldResponse, error) {
+	if req.Payload.Weight == 0 {
+		return &coreapis.AdjustSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"weight must be greater than 0",
+				map[string]string{
+					"weight": fmt.Sprintf("%d", req.Payload.Weight),
+				},
+			),
+		}, nil
+	}
+
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	// Validate and get the lease
+	leaseId := &corepb.LeaseId{
+		AccountId:   req.Payload.NamespaceId.AccountId,
+		NamespaceId: req.Payload.NamespaceId.NamespaceId,
+		LeaseId:     req.Payload.LeaseId,
+	}
+	lease, err := c.leases.Get(txn, leaseId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.AdjustSemaphoreHoldResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"lease not found",
+					map[string]string{
+						"lease_id": fmt.Sprintf("%d", req.Payload.LeaseId),
+					},
+				),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	// Check if lease has expired
+	if lease.ExpiresAt <= req.Now {
+		return &coreapis.AdjustSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.NotFound,
+				"lease not found",
+				map[string]string{
+					"lease_id": fmt.Sprintf("%d", req.Payload.LeaseId),
+				},
+			),
+		}, nil
+	}
+
+	semaphore, err := c.semaphores.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.SemaphoreName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.AdjustSemaphoreHoldResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"semaphore not found",
+					map[string]string{
+						"semaphore_name": req.Payload.SemaphoreName,
+					},
+				),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	// A weight that can never be satisfied is rejected the same way as on acquire
+	if req.Payload.Weight > semaphore.Permits {
+		return &coreapis.AdjustSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"weight exceeds semaphore permits",
+				map[string]string{
+					"weight":  fmt.Sprintf("%d", req.Payload.Weight),
+					"permits": fmt.Sprintf("%d", semaphore.Permits),
+				},
+			),
+		}, nil
+	}
+
+	// Check expired holders. Ancestors are read afterwards, as a hold past its max hold time
+	// gives back the weight they inherit from it.
+	updatedSemaphore, _, err := c.deleteExpiredSemaphoreHolders(txn, semaphore, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := c.getSemaphoreAncestors(txn, semaphore.Id, semaphore.ParentSemaphoreId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ancestor := range ancestors {
+		if req.Payload.Weight > ancestor.Permits {
+			return &coreapis.AdjustSemaphoreHoldResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"weight exceeds ancestor semaphore permits",
+					map[string]string{
+						"weight":                  fmt.Sprintf("%d", req.Payload.Weight),
+						"permits":                 fmt.Sprintf("%d", ancestor.Permits),
+						"ancestor_semaphore_name": ancestor.Name,
+					},
+				),
+			}, nil
+		}
+	}
+
+	holderId := &corepb.SemaphoreHolderId{
+		AccountId:   req.Payload.NamespaceId.AccountId,
+		NamespaceId: req.Payload.NamespaceId.NamespaceId,
+		SemaphoreId: semaphore.Id.SemaphoreId,
+		LeaseId:     lease.Id.LeaseId,
+	}
+	holder, err := c.holders.Get(txn, holderId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	// Only a hold acquired directly can be adjusted, not weight inherited from descendants. A hold
+	// past its max hold time is as good as released.
+	if holder == nil || holder.Weight == holder.InheritedWeight || (holder.HoldExpiresAt != 0 && holder.HoldExpiresAt <= req.Now) {
+		return &coreapis.AdjustSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.NotFound,
+				"semaphore hold not found",
+				map[string]string{
+					"semaphore_name": req.Payload.SemaphoreName,
+					"lease_id":       fmt.Sprintf("%d", req.Payload.LeaseId),
+				},
+			),
+		}, nil
+	}
+
+	if class := semaphoreClass(semaphore, holder.Class); class != nil && class.MaxPermits > 0 && req.Payload.Weight > class.MaxPermits {
+		return &coreapis.AdjustSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"weight exceeds semaphore class max permits",
+				map[string]string{
+					"weight":      fmt.Sprintf("%d", req.Payload.Weight),
+					"max_permits": fmt.Sprintf("%d", class.MaxPermits),
+					"class":       holder.Class,
+				},
+			),
+		}, nil
+	}
+
+	updatedAncestors, err := c.deleteExpiredAncestorsHolders(txn, ancestors, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := req.Payload.Weight - (holder.Weight - holder.InheritedWeight)
+	success := delta <= 0 || (delta <= freePermits(updatedSemaphore, holder.Class) && havePermits(updatedAncestors, delta))
+	if success && delta != 0 {
+		holder.Weight += delta
+		updatedSemaphore.ActiveHolds += delta
+		addClassHolds(updatedSemaphore, holder.Class, delta)
+
+		err = c.holders.Update(txn, holder)
+		if err != nil {
+			return nil, err
+		}
+
+		err = c.propagateHold(txn, updatedAncestors, lease.Id.LeaseId, lease.ExpiresAt, delta, req.Now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Record the adjust attempt (whether or not it succeeded).
+	updatedSemaphore.LastActivityAt = req.Now
+
+	err = c.saveSemaphores(txn, append([]*corepb.Semaphore{semaphore}, ancestors...), append([]*corepb.Semaphore{updatedSemaphore}, updatedAncestors...), req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.AdjustSemaphoreHoldResponse{
+		Payload: &corepb.AdjustSemaphoreHoldResponse{
+			Semaphore: updatedSemaphore,
+			Holder:    holder,
+			Success:   success,
+		},
+	}, nil
+}
+
+// TransferSemaphoreHold moves the direct hold of FromLeaseId on a semaphore to ToLeaseId in one
+// step, so its permits are never free in between. The hold keeps its weight, class, LockedAt,
+// metadata and max hold time, and from then on expires with the new lease. Weight the leases
+// inherit from descendants stays where it is; on the ancestors the transferred weight moves from
+// one lease's holder to the other's. Both leases must be alive. Returns NotFound for a missing or
+// expired lease, a missing semaphore or when FromLeaseId does not hold it directly, and
+// InvalidRequest when both leases are the same or ToLeaseId already holds it directly.
+func (c *Core) TransferSemaphoreHold(req *coreapis.TransferSemaphoreHoldRequest) (*coreapis.TransferSemaphoreHoldResponse, error) {
+	if req.Payload.FromLeaseId == req.Payload.ToLeaseId {
+		return &coreapis.TransferSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"cannot transfer a semaphore hold to the same lease",
+				map[string]string{
+					"lease_id": fmt.Sprintf("%d", req.Payload.FromLeaseId),
+				},
+			),
+		}, nil
+	}
+
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	// Both leases must be alive
+	leases := make([]*corepb.Lease, 0, 2)
+	for _, leaseId := range []uint64{req.Payload.FromLeaseId, req.Payload.ToLeaseId} {
+		lease, err := c.leases.Get(txn, &corepb.LeaseId{
+			AccountId:   req.Payload.NamespaceId.AccountId,
+			NamespaceId: req.Payload.NamespaceId.NamespaceId,
+			LeaseId:     leaseId,
+		})
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+
+		if lease == nil || lease.ExpiresAt <= req.Now {
+			return &coreapis.TransferSemaphoreHoldResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"lease not found",
+					map[string]string{
+						"lease_id": fmt.Sprintf("%d", leaseId),
+					},
+				),
+			}, nil
+		}
+
+		leases = append(leases, lease)
+	}
+	fromLease, toLease := leases[0], leases[1]
+
+	semaphore, err := c.semaphores.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.SemaphoreName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.TransferSemaphoreHoldResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"semaphore not found",
+					map[string]string{
+						"semaphore_name": req.Payload.SemaphoreName,
+					},
+				),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	// Check expired holders, so that a hold past its max hold time is not transferred
+	updatedSemaphore, _, err := c.deleteExpiredSemaphoreHolders(txn, semaphore, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := c.getSemaphoreAncestors(txn, semaphore.Id, semaphore.ParentSemaphoreId)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAncestors, err := c.deleteExpiredAncestorsHolders(txn, ancestors, req.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	fromHolder, err := c.holders.Get(txn, &corepb.SemaphoreHolderId{
+		AccountId:   semaphore.Id.AccountId,
+		NamespaceId: semaphore.Id.NamespaceId,
+		SemaphoreId: semaphore.Id.SemaphoreId,
+		LeaseId:     fromLease.Id.LeaseId,
+	})
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	// Only a hold acquired directly can be transferred, not weight inherited from descendants
+	if fromHolder == nil || fromHolder.Weight == fromHolder.InheritedWeight {
+		return &coreapis.TransferSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.NotFound,
+				"semaphore hold not found",
+				map[string]string{
+					"semaphore_name": req.Payload.SemaphoreName,
+					"lease_id":       fmt.Sprintf("%d", req.Payload.FromLeaseId),
+				},
+			),
+		}, nil
+	}
+
+	toHolderId := &corepb.SemaphoreHolderId{
+		AccountId:   semaphore.Id.AccountId,
+		NamespaceId: semaphore.Id.NamespaceId,
+		SemaphoreId: semaphore.Id.SemaphoreId,
+		LeaseId:     toLease.Id.LeaseId,
+	}
+	toHolder, err := c.holders.Get(txn, toHolderId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	if toHolder != nil && toHolder.Weight > toHolder.InheritedWeight {
+		return &coreapis.TransferSemaphoreHoldResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"semaphore is already held by the target lease",
+				map[string]string{
+					"semaphore_name": req.Payload.SemaphoreName,
+					"lease_id":       fmt.Sprintf("%d", req.Payload.ToLeaseId),
+				},
+			),
+		}, nil
+	}
+
+	weight := fromHolder.Weight - fromHolder.InheritedWeight
+
+	// The target lease may already inherit weight here from its holds on descendants; the direct
+	// hold joins that holder then
+	if toHolder == nil {
+		toHolder = &corepb.SemaphoreHolder{
+			Id:            toHolderId,
+			LockedAt:      fromHolder.LockedAt,
+			ExpiresAt:     toLease.ExpiresAt,
+			Weight:        weight,
+			Metadata:      fromHolder.Metadata,
+			Class:         fromHolder.Class,
+			HoldExpiresAt: fromHolder.HoldExpiresAt,
+		}
+		err = c.holders.Create(txn, toHolder)
+		updatedSemaphore.ActiveHoldersCount += 1
+	} else {
+		toHolder.Weight += weight
+		toHolder.LockedAt = fromHolder.LockedAt
+		toHolder.Metadata = fromHolder.Metadata
+		toHolder.Class = fromHolder.Class
+		toHolder.HoldExpiresAt = fromHolder.HoldExpiresAt
+		err = c.holders.Update(txn, toHolder)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if fromHolder.InheritedWeight > 0 {
+		err = c.holders.Update(txn, inheritedHolder(fromHolder))
+	} else {
+		err = c.holders.Delete(txn, fromHolder)
+		updatedSemaphore.ActiveHoldersCount -= 1
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Update earliest expiration if the target holder expires earlier
+	if updatedSemaphore.EarliestHolderExpiresAt == 0 || holderExpiresAt(toHolder) < updatedSemaphore.EarliestHolderExpiresAt {
+		updatedSemaphore.EarliestHolderExpiresAt = holderExpiresAt(toHolder)
 	}
 
-	err = c.holders.Delete(txn, existingHolder)
+	// Move the weight inherited by the ancestors along with the hold
+	err = c.propagateHold(txn, updatedAncestors, fromLease.Id.LeaseId, fromLease.ExpiresAt, -weight, req.Now)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check expired holders
-	updatedSemaphore, _, err := c.deleteExpiredSemaphoreHolders(txn, semaphore, req.Now)
+	err = c.propagateHold(txn, updatedAncestors, toLease.Id.LeaseId, toLease.ExpiresAt, weight, req.Now)
 	if err != nil {
 		return nil, err
 	}
 
-	updatedSemaphore.ActiveHolds -= existingHolder.Weight
-	updatedSemaphore.ActiveHoldersCount -= 1
 	updatedSemaphore.LastActivityAt = req.Now
 
-	if semaphore.EarliestHolderExpiresAt != updatedSemaphore.EarliestHolderExpiresAt {
-		// Remove a semaphore from expirationRecords at old position
-		if semaphore.EarliestHolderExpiresAt != 0 {
-			err = c.expirationRecords.Delete(txn, semaphore.EarliestHolderExpiresAt, semaphore.Id)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		if updatedSemaphore.EarliestHolderExpiresAt != 0 {
-			// Add a semaphore into expirationRecords at new position
-			err = c.expirationRecords.Add(txn, updatedSemaphore.EarliestHolderExpiresAt, semaphore.Id)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	err = c.semaphores.Update(txn, updatedSemaphore)
+	err = c.saveSemaphores(txn, append([]*corepb.Semaphore{semaphore}, ancestors...), append([]*corepb.Semaphore{updatedSemaphore}, updatedAncestors...), req.Now)
 	if err != nil {
 		return nil, err
 	}
@@ -858,9 +1909,10 @@ func (c *Core) ReleaseSemaphore(req *coreapis.ReleaseSemaphoreRequest) (*coreapi
 		return nil, err
 	}
 
-	return &coreapis.ReleaseSemaphoreResponse{
-		Payload: &corepb.ReleaseSemaphoreResponse{
+	return &coreapis.TransferSemaphoreHoldResponse{
+		Payload: &corepb.TransferSemaphoreHoldResponse{
 			Semaphore: updatedSemaphore,
+			Holder:    toHolder,
 		},
 	}, nil
 }
@@ -868,10 +1920,12 @@ func (c *Core) ReleaseSemaphore(req *coreapis.ReleaseSemaphoreRequest) (*coreapi
 // RunSemaphoresGarbageCollection performs a single bounded GC pass. It processes namespace
 // deletion records (deleting holders for every semaphore in the namespace, then the semaphore
 // itself), semaphore deletion records (draining the leftover holders of a previously deleted
-// semaphore), and finally walks the global expiration index to prune expired holders from live
-// semaphores. The pass stops once MaxVisited total records (holders + semaphores + leases) have
-// been touched so that one invocation cannot produce an unbounded transaction. Intended to be
-// invoked periodically by the scheduler.
+// semaphore), walks the global expiration index to prune expired holders from live semaphores,
+// reaps expired leases, deletes semaphores left idle past their inactivity window, drops lapsed
+// waiters, and finally trims stats buckets older than statsRetention. The pass stops once
+// MaxVisited total records (holders + semaphores + leases) have been touched so that one
+// invocation cannot produce an unbounded transaction.
+// Intended to be invoked periodically by the scheduler.
 func (c *Core) RunSemaphoresGarbageCollection(req *coreapis.RunSemaphoresGarbageCollectionRequest) (*coreapis.RunSemaphoresGarbageCollectionResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -904,7 +1958,7 @@ func (c *Core) RunSemaphoresGarbageCollection(req *coreapis.RunSemaphoresGarbage
 			for _, semaphore := range result.semaphores {
 				// Drain one page of holders first; if there are more holders than fit on the page,
 				// leave the semaphore record in place and let a later GC pass continue.
-				holdersDrained, err := c.gcDeleteSemaphoreHolders(txn, semaphore.Id, holdersPageSize, &visited, req.Payload.MaxVisited)
+				holdersDrained, err := c.gcDeleteSemaphoreHolders(txn, semaphore.Id, 0, holdersPageSize, &visited, req.Payload.MaxVisited, req.Now)
 				if err != nil {
 					return nil, err
 				}
@@ -944,7 +1998,7 @@ func (c *Core) RunSemaphoresGarbageCollection(req *coreapis.RunSemaphoresGarbage
 		case *corepb.SemaphoresGarbageCollectionRecord_SemaphoreId:
 			// The semaphore record itself is already deleted by DeleteSemaphore; we just need to
 			// drain whatever holders are still attached to its id.
-			holdersDrained, err := c.gcDeleteSemaphoreHolders(txn, r.SemaphoreId, holdersPageSize, &visited, req.Payload.MaxVisited)
+			holdersDrained, err := c.gcDeleteSemaphoreHolders(txn, r.SemaphoreId, gcRecord.ParentSemaphoreId, holdersPageSize, &visited, req.Payload.MaxVisited, req.Now)
 			if err != nil {
 				return nil, err
 			}
@@ -1008,6 +2062,11 @@ func (c *Core) RunSemaphoresGarbageCollection(req *coreapis.RunSemaphoresGarbage
 				}
 			}
 
+			err = c.recordSemaphoreStats(txn, updatedSemaphore, req.Now, 0, 0)
+			if err != nil {
+				return false, err
+			}
+
 			err = c.semaphores.Update(txn, updatedSemaphore)
 			if err != nil {
 				return false, err
@@ -1040,6 +2099,50 @@ func (c *Core) RunSemaphoresGarbageCollection(req *coreapis.RunSemaphoresGarbage
 		}
 	}
 
+	if visited < req.Payload.MaxVisited {
+		// Delete idle semaphores. Only semaphores without holders and children are indexed for
+		// deletion, so there is nothing to drain; holders pruned above already count.
+		err = c.deleteInactiveSemaphores(txn, req.Now, int(req.Payload.GcRecordSemaphoresPageSize), &visited, req.Payload.MaxVisited)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if visited < req.Payload.MaxVisited {
+		// Drop waiters left behind by acquires that stopped retrying without a final attempt,
+		// including those of semaphores deleted in the meantime.
+		err = c.waiters.ListByExpiration(txn, 0, req.Now, func(waiter *corepb.SemaphoreWaiter) (bool, error) {
+			visited++
+
+			err := c.waiters.Delete(txn, waiter)
+			if err != nil {
+				return false, err
+			}
+
+			return visited < req.Payload.MaxVisited, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if visited < req.Payload.MaxVisited {
+		// Trim stats buckets past the retention, including those of deleted semaphores
+		err = c.stats.ListByStart(txn, 0, req.Now-statsRetention, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+			visited++
+
+			err := c.stats.Delete(txn, record)
+			if err != nil {
+				return false, err
+			}
+
+			return visited < req.Payload.MaxVisited, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 commit:
 
 	err = txn.Commit()
@@ -1052,6 +2155,74 @@ commit:
 	}, nil
 }
 
+// deleteInactiveSemaphores deletes one page of semaphores whose auto-deletion time has passed,
+// giving their slot back to the namespace and to the parent's children count. Such semaphores
+// have no holders by construction (see deletionTime). It shares the GC pass's visit budget.
+func (c *Core) deleteInactiveSemaphores(txn *store.Txn, now int64, pageSize int, visited *int64, maxVisited int64) error {
+	pageSize = pagination.GetLimitWithDefaults(pageSize)
+
+	records := make([]*corepb.SemaphoresDeletionRecord, 0, pageSize)
+	err := c.semaphores.ListByDeletion(txn, 0, now, func(record *corepb.SemaphoresDeletionRecord) (bool, error) {
+		records = append(records, record)
+		return len(records) < pageSize, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if *visited >= maxVisited {
+			return nil
+		}
+
+		semaphore, err := c.semaphores.Get(txn, record.SemaphoreId)
+		if err != nil {
+			return err
+		}
+
+		if semaphore.ParentSemaphoreId != 0 {
+			parent, err := c.semaphores.Get(txn, &corepb.SemaphoreId{
+				AccountId:   semaphore.Id.AccountId,
+				NamespaceId: semaphore.Id.NamespaceId,
+				SemaphoreId: semaphore.ParentSemaphoreId,
+			})
+			if err != nil {
+				// The parent can only be missing while its namespace is being deleted
+				if !errors.Is(err, store.ErrNotFound) {
+					return err
+				}
+			} else {
+				parent.NumberOfChildren -= 1
+				err = c.semaphores.Update(txn, parent)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// Counters are already gone while the namespace is being deleted; do not bring them back
+		counters, err := c.counters.Get(txn, semaphore.Id.AccountId, semaphore.Id.NamespaceId)
+		if err != nil {
+			return err
+		}
+		if counters.NumberOfSemaphores > 0 {
+			counters.NumberOfSemaphores -= 1
+			err = c.counters.Set(txn, semaphore.Id.AccountId, semaphore.Id.NamespaceId, counters)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = c.semaphores.Delete(txn, semaphore.Id)
+		if err != nil {
+			return err
+		}
+		*visited++
+	}
+
+	return nil
+}
+
 // SemaphoresDeleteNamespace marks a namespace for asynchronous deletion by creating a GC record.
 // The actual removal of the namespace's semaphores and counters happens in subsequent
 // RunSemaphoresGarbageCollection passes.
@@ -1414,10 +2585,11 @@ func (c *Core) ListSemaphoresByLeaseId(req *coreapis.ListSemaphoresByLeaseIdRequ
 }
 
 // gcDeleteSemaphoreHolders deletes up to one page of holders for the given semaphore, decrementing
-// the visit budget for each one. Returns true if the semaphore has no remaining holders (every page
-// drained); false if the page-size limit or the visit budget cut the run short. The caller owns
-// the txn lifecycle.
-func (c *Core) gcDeleteSemaphoreHolders(txn *store.Txn, semaphoreId *corepb.SemaphoreId, pageSize int, visited *int64, maxVisited int64) (bool, error) {
+// the visit budget for each one and for every ancestor the holder's weight is given back to
+// (parentSemaphoreId is 0 when there is no parent chain to unwind). Returns true if the semaphore
+// has no remaining holders (every page drained); false if the page-size limit or the visit budget
+// cut the run short. The caller owns the txn lifecycle.
+func (c *Core) gcDeleteSemaphoreHolders(txn *store.Txn, semaphoreId *corepb.SemaphoreId, parentSemaphoreId uint64, pageSize int, visited *int64, maxVisited int64, now int64) (bool, error) {
 	result, err := c.holders.List(txn, semaphoreId.AccountId, semaphoreId.NamespaceId, semaphoreId.SemaphoreId, nil, pageSize)
 	if err != nil {
 		return false, err
@@ -1431,6 +2603,15 @@ func (c *Core) gcDeleteSemaphoreHolders(txn *store.Txn, semaphoreId *corepb.Sema
 			return false, err
 		}
 		*visited++
+
+		if parentSemaphoreId != 0 {
+			updated, err := c.giveBackAncestorsHold(txn, semaphoreId, parentSemaphoreId, holder.Id.LeaseId, holder.ExpiresAt, holder.Weight, now)
+			if err != nil {
+				return false, err
+			}
+			*visited += int64(updated)
+		}
+
 		if *visited >= maxVisited {
 			return false, nil
 		}
@@ -1492,7 +2673,7 @@ func (c *Core) refreshLeaseHolders(txn *store.Txn, lease *corepb.Lease) error {
 			oldEarliest := semaphore.EarliestHolderExpiresAt
 			semaphore.EarliestHolderExpiresAt = 0
 			err = c.holders.ListByExpiration(txn, semaphore.Id, 0, math.MaxInt64, func(h *corepb.SemaphoreHolder) (bool, error) {
-				semaphore.EarliestHolderExpiresAt = h.ExpiresAt
+				semaphore.EarliestHolderExpiresAt = holderExpiresAt(h)
 				return false, nil
 			})
 			if err != nil {
@@ -1588,6 +2769,7 @@ func (c *Core) revokeLeaseInTransactionBounded(txn *store.Txn, lease *corepb.Lea
 
 			semaphore.ActiveHolds -= holder.Weight
 			semaphore.ActiveHoldersCount -= 1
+			addClassHolds(semaphore, holder.Class, -(holder.Weight - holder.InheritedWeight))
 
 			// Capture the current earliest before the recompute so the expirationRecords
 			// cleanup below targets the index entry's *actual* key. Using the removed
@@ -1599,8 +2781,8 @@ func (c *Core) revokeLeaseInTransactionBounded(txn *store.Txn, lease *corepb.Lea
 			// Recalculate earliest expiration time
 			semaphore.EarliestHolderExpiresAt = 0
 			err = c.holders.ListByExpiration(txn, semaphore.Id, 0, math.MaxInt64, func(h *corepb.SemaphoreHolder) (bool, error) {
-				if h.ExpiresAt > now {
-					semaphore.EarliestHolderExpiresAt = h.ExpiresAt
+				if holderExpiresAt(h) > now {
+					semaphore.EarliestHolderExpiresAt = holderExpiresAt(h)
 					return false, nil
 				}
 				return true, nil
@@ -1609,6 +2791,11 @@ func (c *Core) revokeLeaseInTransactionBounded(txn *store.Txn, lease *corepb.Lea
 				return false, err
 			}
 
+			err = c.recordSemaphoreStats(txn, semaphore, now, 0, 0)
+			if err != nil {
+				return false, err
+			}
+
 			err = c.semaphores.Update(txn, semaphore)
 			if err != nil {
 				return false, err
@@ -1670,8 +2857,10 @@ func (c *Core) revokeLeaseInTransactionBounded(txn *store.Txn, lease *corepb.Lea
 }
 
 // computeExpiredSemaphoreHolders walks holders in expiration order and returns a clone of the
-// semaphore with `ActiveHolds`, `ActiveHoldersCount`, and `EarliestHolderExpiresAt` adjusted as if
-// holders that expired by `now` had been removed, along with the list of those expired holders.
+// semaphore with `ActiveHolds` (also per class), `ActiveHoldersCount`, and `EarliestHolderExpiresAt`
+// adjusted as if holders that expired by `now` had been removed, along with the list of those expired holders.
+// A holder whose direct hold ran past its max hold time but which still inherits weight from
+// descendants is only trimmed down to that weight (see keepsInheritedWeight).
 // This function does not mutate the store, so it can be used from both read-only and update transactions.
 func (c *Core) computeExpiredSemaphoreHolders(txn *store.Txn, semaphore *corepb.Semaphore, now int64) (*corepb.Semaphore, []*corepb.SemaphoreHolder, error) {
 	updatedSemaphore := proto.Clone(semaphore).(*corepb.Semaphore)
@@ -1680,12 +2869,24 @@ func (c *Core) computeExpiredSemaphoreHolders(txn *store.Txn, semaphore *corepb.
 	var expired []*corepb.SemaphoreHolder
 
 	err := c.holders.ListByExpiration(txn, semaphore.Id, 0, math.MaxInt64, func(holder *corepb.SemaphoreHolder) (bool, error) {
-		if holder.ExpiresAt > now {
-			updatedSemaphore.EarliestHolderExpiresAt = holder.ExpiresAt
+		if expiresAt := holderExpiresAt(holder); expiresAt > now {
+			if updatedSemaphore.EarliestHolderExpiresAt == 0 || expiresAt < updatedSemaphore.EarliestHolderExpiresAt {
+				updatedSemaphore.EarliestHolderExpiresAt = expiresAt
+			}
 			return false, nil
 		}
 
 		expired = append(expired, holder)
+		addClassHolds(updatedSemaphore, holder.Class, -(holder.Weight - holder.InheritedWeight))
+
+		if keepsInheritedWeight(holder, now) {
+			updatedSemaphore.ActiveHolds -= holder.Weight - holder.InheritedWeight
+			if updatedSemaphore.EarliestHolderExpiresAt == 0 || holder.ExpiresAt < updatedSemaphore.EarliestHolderExpiresAt {
+				updatedSemaphore.EarliestHolderExpiresAt = holder.ExpiresAt
+			}
+			return true, nil
+		}
+
 		updatedSemaphore.ActiveHolds -= holder.Weight
 		updatedSemaphore.ActiveHoldersCount -= 1
 
@@ -1701,20 +2902,329 @@ func (c *Core) computeExpiredSemaphoreHolders(txn *store.Txn, semaphore *corepb.
 // deleteExpiredSemaphoreHolders ensures that the semaphore is still held at the moment `now`.
 // It deletes holders that expire before `now`, calculates `EarliestHolderExpiresAt`, and returns
 // an updated copy of the semaphore together with the number of holders that were pruned.
-// The count lets GC callers credit each removed holder against the visit budget; non-GC callers
-// can ignore it.
+// A direct hold that ran past its max hold time on a semaphore with a parent also gives back the
+// weight the ancestors inherit from it; those ancestors are written here, so callers must read
+// the ancestors they update afterwards. The count lets GC callers credit each removed holder,
+// and each ancestor given weight back, against the visit budget; non-GC callers can ignore it.
 func (c *Core) deleteExpiredSemaphoreHolders(txn *store.Txn, semaphore *corepb.Semaphore, now int64) (*corepb.Semaphore, int, error) {
 	updatedSemaphore, expired, err := c.computeExpiredSemaphoreHolders(txn, semaphore, now)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	count := len(expired)
 	for _, holder := range expired {
-		err = c.holders.Delete(txn, holder)
+		if keepsInheritedWeight(holder, now) {
+			err = c.holders.Update(txn, inheritedHolder(holder))
+		} else {
+			err = c.holders.Delete(txn, holder)
+		}
 		if err != nil {
 			return nil, 0, err
 		}
+
+		// When the lease has expired, its holders on the ancestors expire along with this one
+		if semaphore.ParentSemaphoreId != 0 && holder.ExpiresAt > now {
+			updated, err := c.giveBackAncestorsHold(txn, semaphore.Id, semaphore.ParentSemaphoreId, holder.Id.LeaseId, holder.ExpiresAt, holder.Weight-holder.InheritedWeight, now)
+			if err != nil {
+				return nil, 0, err
+			}
+			count += updated
+		}
+	}
+
+	return updatedSemaphore, count, nil
+}
+
+// getSemaphoreAncestors returns the ancestors of a semaphore with the given parent, nearest parent
+// first. A missing ancestor ends the chain; that only happens while GC drains the holders of a
+// deleted semaphore whose namespace is being deleted as well. The chain is bounded by
+// maxSemaphoreDepth at creation time.
+func (c *Core) getSemaphoreAncestors(txn *store.Txn, semaphoreId *corepb.SemaphoreId, parentSemaphoreId uint64) ([]*corepb.Semaphore, error) {
+	var ancestors []*corepb.Semaphore
+
+	for parentSemaphoreId != 0 {
+		parent, err := c.semaphores.Get(txn, &corepb.SemaphoreId{
+			AccountId:   semaphoreId.AccountId,
+			NamespaceId: semaphoreId.NamespaceId,
+			SemaphoreId: parentSemaphoreId,
+		})
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				break
+			}
+			return nil, err
+		}
+
+		ancestors = append(ancestors, parent)
+		parentSemaphoreId = parent.ParentSemaphoreId
+	}
+
+	return ancestors, nil
+}
+
+// deleteExpiredAncestorsHolders runs deleteExpiredSemaphoreHolders on every ancestor and returns
+// the updated copies in the same order. The caller persists them with saveSemaphores. Pruning an
+// ancestor may give weight back on the ones above it, so those are read again first, in place.
+func (c *Core) deleteExpiredAncestorsHolders(txn *store.Txn, ancestors []*corepb.Semaphore, now int64) ([]*corepb.Semaphore, error) {
+	updatedAncestors := make([]*corepb.Semaphore, len(ancestors))
+	for i, ancestor := range ancestors {
+		if i > 0 {
+			var err error
+			ancestor, err = c.semaphores.Get(txn, ancestor.Id)
+			if err != nil {
+				return nil, err
+			}
+			ancestors[i] = ancestor
+		}
+
+		updatedAncestor, _, err := c.deleteExpiredSemaphoreHolders(txn, ancestor, now)
+		if err != nil {
+			return nil, err
+		}
+		updatedAncestors[i] = updatedAncestor
+	}
+	return updatedAncestors, nil
+}
+
+// propagateHold changes the weight the lease inherits on every ancestor by delta. Missing holders
+// are created on the way up, and holders left without weight are deleted. The ancestors are
+// modified in place; the caller persists them with saveSemaphores.
+func (c *Core) propagateHold(txn *store.Txn, ancestors []*corepb.Semaphore, leaseId uint64, expiresAt int64, delta int64, now int64) error {
+	if delta == 0 {
+		return nil
+	}
+
+	for _, ancestor := range ancestors {
+		holderId := &corepb.SemaphoreHolderId{
+			AccountId:   ancestor.Id.AccountId,
+			NamespaceId: ancestor.Id.NamespaceId,
+			SemaphoreId: ancestor.Id.SemaphoreId,
+			LeaseId:     leaseId,
+		}
+
+		ancestor.LastActivityAt = now
+
+		holder, err := c.holders.Get(txn, holderId)
+		if err != nil {
+			if !errors.Is(err, store.ErrNotFound) {
+				return err
+			}
+			if delta < 0 {
+				// Already pruned as expired, nothing to give back
+				continue
+			}
+
+			holder = &corepb.SemaphoreHolder{
+				Id:              holderId,
+				LockedAt:        now,
+				ExpiresAt:       expiresAt,
+				Weight:          delta,
+				InheritedWeight: delta,
+			}
+			err = c.holders.Create(txn, holder)
+			if err != nil {
+				return err
+			}
+
+			ancestor.ActiveHoldersCount += 1
+			ancestor.ActiveHolds += delta
+			if ancestor.EarliestHolderExpiresAt == 0 || expiresAt < ancestor.EarliestHolderExpiresAt {
+				ancestor.EarliestHolderExpiresAt = expiresAt
+			}
+			continue
+		}
+
+		// Never give back more than was inherited
+		change := max(delta, -holder.InheritedWeight)
+		holder.Weight += change
+		holder.InheritedWeight += change
+		ancestor.ActiveHolds += change
+
+		if holder.Weight > 0 {
+			if change > 0 {
+				holder.ExpiresAt = expiresAt
+				if ancestor.EarliestHolderExpiresAt == 0 || expiresAt < ancestor.EarliestHolderExpiresAt {
+					ancestor.EarliestHolderExpiresAt = expiresAt
+				}
+			}
+			err = c.holders.Update(txn, holder)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = c.holders.Delete(txn, holder)
+		if err != nil {
+			return err
+		}
+		ancestor.ActiveHoldersCount -= 1
+
+		// Recalculate earliest expiration time
+		ancestor.EarliestHolderExpiresAt = 0
+		err = c.holders.ListByExpiration(txn, ancestor.Id, 0, math.MaxInt64, func(h *corepb.SemaphoreHolder) (bool, error) {
+			if holderExpiresAt(h) > now {
+				ancestor.EarliestHolderExpiresAt = holderExpiresAt(h)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// giveBackAncestorsHold gives back weight the lease inherits on every ancestor of a semaphore with
+// the given parent, once the hold it came from is gone, and persists the ancestors. Returns how
+// many ancestors were updated.
+func (c *Core) giveBackAncestorsHold(txn *store.Txn, semaphoreId *corepb.SemaphoreId, parentSemaphoreId uint64, leaseId uint64, expiresAt int64, weight int64, now int64) (int, error) {
+	ancestors, err := c.getSemaphoreAncestors(txn, semaphoreId, parentSemaphoreId)
+	if err != nil {
+		return 0, err
+	}
+	updatedAncestors := make([]*corepb.Semaphore, len(ancestors))
+	for i, ancestor := range ancestors {
+		updatedAncestors[i] = proto.Clone(ancestor).(*corepb.Semaphore)
+	}
+
+	err = c.propagateHold(txn, updatedAncestors, leaseId, expiresAt, -weight, now)
+	if err != nil {
+		return 0, err
+	}
+	err = c.saveSemaphores(txn, ancestors, updatedAncestors, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ancestors), nil
+}
+
+// saveSemaphores persists the updated copies of the given semaphores, moving their
+// expirationRecords entries when the earliest holder changed and recording their stats.
+func (c *Core) saveSemaphores(txn *store.Txn, semaphores []*corepb.Semaphore, updatedSemaphores []*corepb.Semaphore, now int64) error {
+	for i, semaphore := range semaphores {
+		updatedSemaphore := updatedSemaphores[i]
+
+		if semaphore.EarliestHolderExpiresAt != updatedSemaphore.EarliestHolderExpiresAt {
+			if semaphore.EarliestHolderExpiresAt != 0 {
+				err := c.expirationRecords.Delete(txn, semaphore.EarliestHolderExpiresAt, semaphore.Id)
+				if err != nil && !errors.Is(err, store.ErrNotFound) {
+					return err
+				}
+			}
+			if updatedSemaphore.EarliestHolderExpiresAt != 0 {
+				err := c.expirationRecords.Add(txn, updatedSemaphore.EarliestHolderExpiresAt, semaphore.Id)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err := c.recordSemaphoreStats(txn, updatedSemaphore, now, 0, 0)
+		if err != nil {
+			return err
+		}
+
+		err = c.semaphores.Update(txn, updatedSemaphore)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordSemaphoreStats folds the change of the semaphore's active_holds since it was last
+// stored, and the given acquire outcomes, into its utilization stats. It must be called before
+// the semaphore is written back.
+func (c *Core) recordSemaphoreStats(txn *store.Txn, semaphore *corepb.Semaphore, now int64, acquiresSucceeded int64, acquiresFailed int64) error {
+	previousHolds := int64(0)
+	stored, err := c.semaphores.Get(txn, semaphore.Id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if stored != nil {
+		previousHolds = stored.ActiveHolds
+	}
+
+	if previousHolds == semaphore.ActiveHolds && acquiresSucceeded == 0 && acquiresFailed == 0 {
+		return nil
+	}
+
+	return c.stats.Add(txn, semaphore.Id, now, previousHolds, semaphore.ActiveHolds, acquiresSucceeded, acquiresFailed)
+}
+
+// havePermits reports whether every semaphore has room for weight more permits outside of any
+// class, which is where inherited weight goes.
+func havePermits(semaphores []*corepb.Semaphore, weight int64) bool {
+	for _, semaphore := range semaphores {
+		if weight > freePermits(semaphore, "") {
+			return false
+		}
+	}
+	return true
+}
+
+// freePermits returns how many more permits a holder in the given class ("" for none) can take:
+// the free permits, less the unused reservations of the other classes, and at most what is left
+// up to the class's own maximum. It is negative while the semaphore drains.
+func freePermits(semaphore *corepb.Semaphore, class string) int64 {
+	free := semaphore.Permits - semaphore.ActiveHolds
+	for _, c := range semaphore.Classes {
+		if c.Name == class {
+			if c.MaxPermits > 0 {
+				free = min(free, c.MaxPermits-c.ActiveHolds)
+			}
+			continue
+		}
+		free -= max(0, c.MinPermits-c.ActiveHolds)
+	}
+	return free
+}
+
+// semaphoreClass returns the semaphore's class with the given name, or nil.
+func semaphoreClass(semaphore *corepb.Semaphore, name string) *corepb.SemaphoreClass {
+	for _, class := range semaphore.Classes {
+		if class.Name == name {
+			return class
+		}
+	}
+	return nil
+}
+
+// addClassHolds changes the active holds of the named class by delta. Weight outside of any
+// class ("") is not tracked separately.
+func addClassHolds(semaphore *corepb.Semaphore, name string, delta int64) {
+	if class := semaphoreClass(semaphore, name); class != nil {
+		class.ActiveHolds += delta
+	}
+}
+
+// reservedPermits returns the sum of the minimums reserved by the classes.
+func reservedPermits(classes []*corepb.SemaphoreClass) int64 {
+	var reserved int64
+	for _, class := range classes {
+		reserved += class.MinPermits
 	}
+	return reserved
+}
+
+// keepsInheritedWeight reports whether an expired holder only lost its direct hold to the max
+// hold time, while its lease is still alive and it inherits weight from descendants. Such a
+// holder is trimmed down to the inherited weight instead of being removed.
+func keepsInheritedWeight(holder *corepb.SemaphoreHolder, now int64) bool {
+	return holder.ExpiresAt > now && holder.InheritedWeight > 0
+}
 
-	return updatedSemaphore, len(expired), nil
+// inheritedHolder returns a copy of the holder without its direct hold, carrying only the weight
+// inherited from descendants.
+func inheritedHolder(holder *corepb.SemaphoreHolder) *corepb.SemaphoreHolder {
+	updatedHolder := proto.Clone(holder).(*corepb.SemaphoreHolder)
+	updatedHolder.Weight = holder.InheritedWeight
+	updatedHolder.HoldExpiresAt = 0
+	updatedHolder.Class = ""
+	return updatedHolder
 }
@@ -516,6 +516,144 @@ func TestCore_ReleaseSemaphore(t *testing.T) {
 	})
 }
 
+func TestCore_AdjustSemaphoreHold(t *testing.T) {
+	setup := func(t *testing.T, permits int64) (*Core, time.Time, *corepb.NamespaceId, *corepb.SemaphoreId) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		semaphoreId := &corepb.SemaphoreId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			SemaphoreId: rand.Uint64(),
+		}
+		_ = createSemaphore(t, core, semaphoreId, "test_semaphore", permits, now)
+		return core, now, namespaceId, semaphoreId
+	}
+
+	t.Run("shrinking frees permits", func(t *testing.T) {
+		core, now, namespaceId, _ := setup(t, 10)
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "test_semaphore", 8, now)
+		require.True(t, success)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "test_semaphore", 6, now)
+		require.False(t, success)
+
+		adjustTime := now.Add(time.Minute)
+		success, semaphore, holder := adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "test_semaphore", 4, adjustTime)
+		require.True(t, success)
+		require.EqualValues(t, 4, semaphore.ActiveHolds)
+		require.EqualValues(t, 1, semaphore.ActiveHoldersCount)
+		require.Equal(t, adjustTime.UnixNano(), semaphore.LastActivityAt)
+		require.EqualValues(t, 4, holder.Weight)
+		require.Equal(t, now.UnixNano(), holder.LockedAt)
+
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "test_semaphore", 6, adjustTime)
+		require.True(t, success)
+
+		// Shrinking succeeds even on a full semaphore
+		success, semaphore, _ = adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "test_semaphore", 1, adjustTime)
+		require.True(t, success)
+		require.EqualValues(t, 7, semaphore.ActiveHolds)
+
+		holders := listSemaphoreHolders(t, core, namespaceId, "test_semaphore", adjustTime).Holders
+		require.Len(t, holders, 2)
+		require.EqualValues(t, 7, holders[0].Weight+holders[1].Weight)
+	})
+
+	t.Run("growing needs free permits", func(t *testing.T) {
+		core, now, namespaceId, semaphoreId := setup(t, 10)
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "test_semaphore", 4, now)
+		require.True(t, success)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "test_semaphore", 3, now)
+		require.True(t, success)
+
+		success, semaphore, holder := adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "test_semaphore", 8, now)
+		require.False(t, success)
+		require.EqualValues(t, 7, semaphore.ActiveHolds)
+		require.EqualValues(t, 4, holder.Weight)
+
+		success, semaphore, holder = adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "test_semaphore", 7, now)
+		require.True(t, success)
+		require.EqualValues(t, 10, semaphore.ActiveHolds)
+		require.EqualValues(t, 7, holder.Weight)
+
+		// Expired holders make room for the growth
+		lease3 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_3", now, 2*time.Hour)
+		later := now.Add(90 * time.Minute)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease3.Id, "test_semaphore", 1, later)
+		require.True(t, success)
+		success, semaphore, _ = adjustSemaphoreHold(t, core, namespaceId, lease3.Id, "test_semaphore", 10, later)
+		require.True(t, success)
+		require.EqualValues(t, 10, semaphore.ActiveHolds)
+		require.EqualValues(t, 1, getSemaphore(t, core, semaphoreId, later).ActiveHoldersCount)
+	})
+
+	t.Run("adjusts the inherited weight on ancestors", func(t *testing.T) {
+		core, now, namespaceId, parentId := setup(t, 10)
+		_ = createChildSemaphore(t, core, &corepb.SemaphoreId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			SemaphoreId: rand.Uint64(),
+		}, "child", 10, "test_semaphore", now)
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "child", 5, now)
+		require.True(t, success)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "test_semaphore", 4, now)
+		require.True(t, success)
+
+		// Room on the child, but not on the parent
+		success, _, _ = adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "child", 7, now)
+		require.False(t, success)
+
+		success, _, _ = adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "child", 6, now)
+		require.True(t, success)
+		require.EqualValues(t, 10, getSemaphore(t, core, parentId, now).ActiveHolds)
+
+		success, _, _ = adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "child", 2, now)
+		require.True(t, success)
+		require.EqualValues(t, 6, getSemaphore(t, core, parentId, now).ActiveHolds)
+
+		// The weight lease1 inherits on the parent is not a hold of its own
+		appErr := adjustSemaphoreHoldWithError(t, core, namespaceId, lease1.Id, "test_semaphore", 1, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		core, now, namespaceId, _ := setup(t, 10)
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Minute)
+
+		// Not held
+		appErr := adjustSemaphoreHoldWithError(t, core, namespaceId, lease.Id, "test_semaphore", 1, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 2, now)
+		require.True(t, success)
+
+		appErr = adjustSemaphoreHoldWithError(t, core, namespaceId, lease.Id, "test_semaphore", 0, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		appErr = adjustSemaphoreHoldWithError(t, core, namespaceId, lease.Id, "test_semaphore", 11, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		appErr = adjustSemaphoreHoldWithError(t, core, namespaceId, lease.Id, "missing_semaphore", 1, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		// Expired lease
+		appErr = adjustSemaphoreHoldWithError(t, core, namespaceId, lease.Id, "test_semaphore", 1, now.Add(2*time.Minute))
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+}
+
 func TestCore_UpdateSemaphore(t *testing.T) {
 	t.Run("update existing semaphore", func(t *testing.T) {
 		core := newSemaphoresCore(t)
@@ -590,6 +728,63 @@ func TestCore_UpdateSemaphore(t *testing.T) {
 		require.EqualValues(t, 3, semaphore.ActiveHolds)            // All holders should still be there
 	})
 
+	t.Run("draining below active holds", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		accountId := rand.Uint64()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   accountId,
+			NamespaceId: rand.Uint64(),
+		}
+		semaphoreId := &corepb.SemaphoreId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			SemaphoreId: rand.Uint64(),
+		}
+
+		// T+0: Create semaphore with 10 permits, three processes hold 3 each
+		_ = createSemaphore(t, core, semaphoreId, "test_semaphore", 10, now)
+		lease1 := createLease(t, core, accountId, namespaceId.NamespaceId, "process_1", now, 60*time.Minute)
+		lease2 := createLease(t, core, accountId, namespaceId.NamespaceId, "process_2", now, 60*time.Minute)
+		lease3 := createLease(t, core, accountId, namespaceId.NamespaceId, "process_3", now, 60*time.Minute)
+		lease4 := createLease(t, core, accountId, namespaceId.NamespaceId, "process_4", now, 60*time.Minute)
+		for _, lease := range []*corepb.Lease{lease1, lease2, lease3} {
+			success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 3, now)
+			require.True(t, success)
+		}
+
+		// T+1m: Throttle down to 4 permits while 9 are held
+		semaphore := drainSemaphore(t, core, namespaceId, "test_semaphore", 4, 1, now.Add(time.Minute))
+		require.EqualValues(t, 4, semaphore.Permits)
+		require.EqualValues(t, 9, semaphore.ActiveHolds)
+		require.EqualValues(t, 2, semaphore.Version)
+
+		// Nothing new is granted while draining, not even growth of an existing hold
+		success, _ := acquireSemaphore(t, core, namespaceId, lease4.Id, "test_semaphore", 1, now.Add(time.Minute))
+		require.False(t, success)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease1.Id, "test_semaphore", 4, now.Add(time.Minute))
+		require.False(t, success)
+
+		// Updating the description keeps draining, with or without AllowDraining
+		semaphore = updateSemaphore(t, core, namespaceId, "test_semaphore", "still draining", 4, 2, now.Add(time.Minute))
+		require.EqualValues(t, 9, semaphore.ActiveHolds)
+
+		// Lowering further needs AllowDraining again
+		appErr := updateSemaphoreWithError(t, core, namespaceId, "test_semaphore", "still draining", 3, 3, now.Add(time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// T+2m: Two holders leave, 3 permits held out of 4
+		_ = releaseSemaphore(t, core, namespaceId, "test_semaphore", lease1.Id, now.Add(2*time.Minute))
+		semaphore = releaseSemaphore(t, core, namespaceId, "test_semaphore", lease2.Id, now.Add(2*time.Minute))
+		require.EqualValues(t, 3, semaphore.ActiveHolds)
+
+		// Drained, the free permit can be acquired again
+		success, semaphore = acquireSemaphore(t, core, namespaceId, lease4.Id, "test_semaphore", 1, now.Add(2*time.Minute))
+		require.True(t, success)
+		require.EqualValues(t, 4, semaphore.ActiveHolds)
+		require.EqualValues(t, 4, getSemaphore(t, core, semaphoreId, now.Add(2*time.Minute)).Permits)
+	})
+
 	t.Run("update nonexistent semaphore", func(t *testing.T) {
 		core := newSemaphoresCore(t)
 		now := time.Now()
@@ -3780,155 +3975,1932 @@ func TestCore_LastActivityAt(t *testing.T) {
 	})
 }
 
-func newSemaphoresCore(t *testing.T) *Core {
-	t.Helper()
+func TestCore_HierarchicalSemaphores(t *testing.T) {
+	newNamespace := func() (*corepb.NamespaceId, func() *corepb.SemaphoreId) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		return namespaceId, func() *corepb.SemaphoreId {
+			return &corepb.SemaphoreId{
+				AccountId:   namespaceId.AccountId,
+				NamespaceId: namespaceId.NamespaceId,
+				SemaphoreId: rand.Uint64(),
+			}
+		}
+	}
 
-	store, err := store.NewBadgerInMemoryStore()
-	require.NoError(t, err)
-	return NewCore(store, []byte{0x1d, 0x36, 0x00, 0x00}, 0x00000000, 0xffffffff)
-}
+	t.Run("acquire consumes the weight on every ancestor", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
 
-// listExpirationRecords returns the expiration timestamps of every expirationRecords row
-// that targets the given semaphore. The order matches the table's natural ordering
-// (ascending by timestamp). Used by tests to detect stale or duplicated index entries.
-func listExpirationRecords(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId) []int64 {
-	t.Helper()
+		globalId := newSemaphoreId()
+		global := createSemaphore(t, core, globalId, "global", 30, now)
+		tenantA := createChildSemaphore(t, core, newSemaphoreId(), "tenant_a", 20, "global", now)
+		tenantB := createChildSemaphore(t, core, newSemaphoreId(), "tenant_b", 20, "global", now)
+		require.Equal(t, global.Id.SemaphoreId, tenantA.ParentSemaphoreId)
+		require.Equal(t, "global", tenantB.ParentSemaphoreName)
+		require.EqualValues(t, 2, getSemaphore(t, core, globalId, now).NumberOfChildren)
 
-	txn := core.badgerStore.View()
-	defer txn.Discard()
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+		lease3 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_3", now, time.Hour)
 
-	var times []int64
-	err := core.expirationRecords.List(txn, 0, math.MaxInt64, func(record *corepb.SemaphoresExpirationRecord) (bool, error) {
-		if record.SemaphoreId.AccountId == semaphoreId.AccountId &&
-			record.SemaphoreId.NamespaceId == semaphoreId.NamespaceId &&
-			record.SemaphoreId.SemaphoreId == semaphoreId.SemaphoreId {
-			times = append(times, record.ExpiresAt)
-		}
-		return true, nil
-	})
-	require.NoError(t, err)
-	return times
-}
+		success, semaphore := acquireSemaphore(t, core, namespaceId, lease1.Id, "tenant_a", 15, now)
+		require.True(t, success)
+		require.EqualValues(t, 15, semaphore.ActiveHolds)
+
+		global = getSemaphore(t, core, globalId, now)
+		require.EqualValues(t, 15, global.ActiveHolds)
+		require.EqualValues(t, 1, global.ActiveHoldersCount)
+		holders := listSemaphoreHolders(t, core, namespaceId, "global", now).Holders
+		require.Len(t, holders, 1)
+		require.EqualValues(t, 15, holders[0].Weight)
+		require.EqualValues(t, 15, holders[0].InheritedWeight)
+
+		// Fits tenant_b but not what is left of global
+		success, semaphore = acquireSemaphore(t, core, namespaceId, lease2.Id, "tenant_b", 20, now)
+		require.False(t, success)
+		require.EqualValues(t, 0, semaphore.ActiveHolds)
+		require.EqualValues(t, 15, getSemaphore(t, core, globalId, now).ActiveHolds)
 
-func createLease(t *testing.T, core *Core, accountId uint64, namespaceId uint64, processId string, now time.Time, ttl time.Duration) *corepb.Lease {
-	t.Helper()
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "tenant_b", 15, now)
+		require.True(t, success)
+		require.EqualValues(t, 30, getSemaphore(t, core, globalId, now).ActiveHolds)
 
-	leaseId := rand.Uint64()
-	resp, err := core.CreateSemaphoreLease(&coreapis.CreateSemaphoreLeaseRequest{
-		Payload: &corepb.CreateSemaphoreLeaseRequest{
-			LeaseId: &corepb.LeaseId{
-				AccountId:   accountId,
-				NamespaceId: namespaceId,
-				LeaseId:     leaseId,
-			},
-			ProcessId:                  processId,
-			TtlSeconds:                 int64(ttl.Seconds()),
-			MaxNumberOfSemaphoreLeases: 100,
-		},
-		Now: now.UnixNano(),
-	})
-	require.NoError(t, err)
-	require.Nil(t, resp.ApplicationError)
-	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.Lease)
-	require.EqualValues(t, now.UnixNano(), resp.Payload.Lease.CreatedAt)
-	require.EqualValues(t, now.Add(ttl).UnixNano(), resp.Payload.Lease.ExpiresAt)
+		// Global is full for direct holders as well
+		success, _ = acquireSemaphore(t, core, namespaceId, lease3.Id, "global", 1, now)
+		require.False(t, success)
 
-	return resp.Payload.Lease
-}
+		// Growing a hold needs room on the ancestors too
+		success, semaphore = acquireSemaphore(t, core, namespaceId, lease1.Id, "tenant_a", 16, now)
+		require.False(t, success)
+		require.EqualValues(t, 15, semaphore.ActiveHolds)
 
-func acquireSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, now time.Time) (bool, *corepb.Semaphore) {
-	t.Helper()
+		// Shrinking a hold gives the difference back to the ancestors
+		success, _ = acquireSemaphore(t, core, namespaceId, lease1.Id, "tenant_a", 10, now)
+		require.True(t, success)
+		require.EqualValues(t, 25, getSemaphore(t, core, globalId, now).ActiveHolds)
 
-	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
-		Payload: &corepb.AcquireSemaphoreRequest{
-			NamespaceId:   namespaceId,
-			SemaphoreName: semaphoreName,
-			Weight:        weight,
-			LeaseId:       leaseId.LeaseId,
-		},
-		Now: now.UnixNano(),
+		success, _ = acquireSemaphore(t, core, namespaceId, lease3.Id, "global", 5, now)
+		require.True(t, success)
+		require.EqualValues(t, 30, getSemaphore(t, core, globalId, now).ActiveHolds)
 	})
 
-	require.NoError(t, err)
-	require.Nil(t, resp.ApplicationError)
-	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.Semaphore)
+	t.Run("release unwinds the whole chain", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
 
-	return resp.Payload.Success, resp.Payload.Semaphore
-}
+		globalId := newSemaphoreId()
+		tenantId := newSemaphoreId()
+		_ = createSemaphore(t, core, globalId, "global", 100, now)
+		_ = createChildSemaphore(t, core, tenantId, "tenant", 20, "global", now)
+		_ = createChildSemaphore(t, core, newSemaphoreId(), "task", 5, "tenant", now)
 
-func releaseSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, leaseId *corepb.LeaseId, now time.Time) *corepb.Semaphore {
-	t.Helper()
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
 
-	resp, err := core.ReleaseSemaphore(&coreapis.ReleaseSemaphoreRequest{
-		Payload: &corepb.ReleaseSemaphoreRequest{
-			NamespaceId:   namespaceId,
-			SemaphoreName: semaphoreName,
-			LeaseId:       leaseId.LeaseId,
-		},
-		Now: now.UnixNano(),
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "task", 4, now)
+		require.True(t, success)
+		require.EqualValues(t, 4, getSemaphore(t, core, tenantId, now).ActiveHolds)
+		require.EqualValues(t, 4, getSemaphore(t, core, globalId, now).ActiveHolds)
+		require.NotEmpty(t, listExpirationRecords(t, core, globalId))
+
+		releaseTime := now.Add(time.Minute)
+		semaphore := releaseSemaphore(t, core, namespaceId, "task", lease.Id, releaseTime)
+		require.EqualValues(t, 0, semaphore.ActiveHolds)
+
+		for _, semaphoreId := range []*corepb.SemaphoreId{tenantId, globalId} {
+			semaphore := getSemaphore(t, core, semaphoreId, releaseTime)
+			require.EqualValues(t, 0, semaphore.ActiveHolds)
+			require.EqualValues(t, 0, semaphore.ActiveHoldersCount)
+			require.EqualValues(t, 0, semaphore.EarliestHolderExpiresAt)
+			require.Equal(t, releaseTime.UnixNano(), semaphore.LastActivityAt)
+			require.Empty(t, listExpirationRecords(t, core, semaphoreId))
+		}
+		require.Empty(t, listSemaphoreHolders(t, core, namespaceId, "global", releaseTime).Holders)
 	})
 
-	require.NoError(t, err)
-	require.NotNil(t, resp)
-	require.Nil(t, resp.ApplicationError)
-	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.Semaphore)
+	t.Run("a lease holds a parent directly and through a child", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
 
-	return resp.Payload.Semaphore
-}
+		globalId := newSemaphoreId()
+		_ = createSemaphore(t, core, globalId, "global", 10, now)
+		_ = createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
 
-func releaseSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, leaseId *corepb.LeaseId, now time.Time) *mrpc.Error {
-	t.Helper()
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
 
-	resp, err := core.ReleaseSemaphore(&coreapis.ReleaseSemaphoreRequest{
-		Payload: &corepb.ReleaseSemaphoreRequest{
-			NamespaceId:   namespaceId,
-			SemaphoreName: semaphoreName,
-			LeaseId:       leaseId.LeaseId,
-		},
-		Now: now.UnixNano(),
-	})
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "tenant", 5, now)
+		require.True(t, success)
 
-	require.NoError(t, err)
-	require.NotNil(t, resp)
-	require.NotNil(t, resp.ApplicationError)
-	require.Nil(t, resp.Payload)
+		// Not held directly yet
+		semaphore := releaseSemaphore(t, core, namespaceId, "global", lease.Id, now)
+		require.EqualValues(t, 5, semaphore.ActiveHolds)
 
-	return resp.ApplicationError
-}
+		success, semaphore = acquireSemaphore(t, core, namespaceId, lease.Id, "global", 3, now)
+		require.True(t, success)
+		require.EqualValues(t, 8, semaphore.ActiveHolds)
+		require.EqualValues(t, 1, semaphore.ActiveHoldersCount)
+		holders := listSemaphoreHolders(t, core, namespaceId, "global", now).Holders
+		require.Len(t, holders, 1)
+		require.EqualValues(t, 8, holders[0].Weight)
+		require.EqualValues(t, 5, holders[0].InheritedWeight)
 
-func createSemaphore(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, now time.Time) *corepb.Semaphore {
-	t.Helper()
+		// The direct part cannot grow past what is left
+		success, _ = acquireSemaphore(t, core, namespaceId, lease.Id, "global", 6, now)
+		require.False(t, success)
 
-	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
-		Payload: &corepb.CreateSemaphoreRequest{
-			SemaphoreId:                       semaphoreId,
-			Name:                              semaphoreName,
-			Description:                       "test description",
-			Permits:                           permits,
-			MaxNumberOfSemaphoresPerNamespace: 10000,
-		},
-		Now: now.UnixNano(),
+		semaphore = releaseSemaphore(t, core, namespaceId, "global", lease.Id, now)
+		require.EqualValues(t, 5, semaphore.ActiveHolds)
+		require.EqualValues(t, 1, semaphore.ActiveHoldersCount)
+
+		_ = releaseSemaphore(t, core, namespaceId, "tenant", lease.Id, now)
+		semaphore = getSemaphore(t, core, globalId, now)
+		require.EqualValues(t, 0, semaphore.ActiveHolds)
+		require.EqualValues(t, 0, semaphore.ActiveHoldersCount)
 	})
 
-	require.NoError(t, err)
-	require.NotNil(t, resp)
-	require.Nil(t, resp.ApplicationError)
-	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.Semaphore)
-	require.Equal(t, "test description", resp.Payload.Semaphore.Description)
+	t.Run("lease expiry and revoke unwind the whole chain", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		globalId := newSemaphoreId()
+		_ = createSemaphore(t, core, globalId, "global", 10, now)
+		_ = createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+		_ = createChildSemaphore(t, core, newSemaphoreId(), "task", 10, "tenant", now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Minute)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+		lease3 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_3", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "task", 10, now)
+		require.True(t, success)
+
+		// Once the lease expires, its inherited hold on global is gone as well
+		later := now.Add(2 * time.Minute)
+		require.EqualValues(t, 0, getSemaphore(t, core, globalId, later).ActiveHolds)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "tenant", 10, later)
+		require.True(t, success)
+		require.EqualValues(t, 10, getSemaphore(t, core, globalId, later).ActiveHolds)
+
+		// Revoking a lease releases every holder along the chain
+		resp, err := core.RevokeSemaphoreLease(&coreapis.RevokeSemaphoreLeaseRequest{
+			Payload: &corepb.RevokeSemaphoreLeaseRequest{
+				LeaseId: lease2.Id,
+			},
+			Now: later.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.EqualValues(t, 0, getSemaphore(t, core, globalId, later).ActiveHolds)
+
+		success, _ = acquireSemaphore(t, core, namespaceId, lease3.Id, "global", 10, later)
+		require.True(t, success)
+	})
+
+	t.Run("deleting a held child gives its weight back to the ancestors", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		globalId := newSemaphoreId()
+		_ = createSemaphore(t, core, globalId, "global", 10, now)
+		_ = createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "tenant", 7, now)
+		require.True(t, success)
+
+		// A parent cannot be deleted while it has children
+		appErr := deleteSemaphoreWithError(t, core, namespaceId, "global", now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		deleteSemaphore(t, core, namespaceId, "tenant", now)
+		require.EqualValues(t, 0, getSemaphore(t, core, globalId, now).NumberOfChildren)
+		require.EqualValues(t, 7, getSemaphore(t, core, globalId, now).ActiveHolds)
+
+		resp, err := core.RunSemaphoresGarbageCollection(&coreapis.RunSemaphoresGarbageCollectionRequest{
+			Payload: &corepb.RunSemaphoresGarbageCollectionRequest{
+				GcRecordsPageSize:          100,
+				GcRecordSemaphoresPageSize: 100,
+				GcRecordHoldersPageSize:    100,
+				MaxVisited:                 100,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+
+		semaphore := getSemaphore(t, core, globalId, now)
+		require.EqualValues(t, 0, semaphore.ActiveHolds)
+		require.EqualValues(t, 0, semaphore.ActiveHoldersCount)
+		require.Empty(t, listExpirationRecords(t, core, globalId))
+
+		// Without children, the parent can go
+		deleteSemaphore(t, core, namespaceId, "global", now)
+	})
+
+	t.Run("invalid hierarchies and weights are rejected", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		appErr := createChildSemaphoreWithError(t, core, newSemaphoreId(), "orphan", 10, "missing", now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		_ = createSemaphore(t, core, newSemaphoreId(), "level_1", 5, now)
+		for i := 2; i <= maxSemaphoreDepth; i++ {
+			_ = createChildSemaphore(t, core, newSemaphoreId(), fmt.Sprintf("level_%d", i), 10, fmt.Sprintf("level_%d", i-1), now)
+		}
+		appErr = createChildSemaphoreWithError(t, core, newSemaphoreId(), "too_deep", 10, fmt.Sprintf("level_%d", maxSemaphoreDepth), now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Fits the semaphore itself, but never its root
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		appErr = acquireSemaphoreWithError(t, core, namespaceId, lease.Id, fmt.Sprintf("level_%d", maxSemaphoreDepth), 6, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		require.Equal(t, "level_1", appErr.Context["ancestor_semaphore_name"])
+	})
+}
+
+func TestCore_SemaphoreClasses(t *testing.T) {
+	newNamespace := func() (*corepb.NamespaceId, *corepb.SemaphoreId) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		return namespaceId, &corepb.SemaphoreId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			SemaphoreId: rand.Uint64(),
+		}
+	}
+
+	classes := []*corepb.SemaphoreClassSpec{
+		{Name: "interactive", MinPermits: 5},
+		{Name: "batch", MaxPermits: 15},
+	}
+
+	classHolds := func(semaphoreClasses []*corepb.SemaphoreClass) map[string]int64 {
+		holds := make(map[string]int64)
+		for _, class := range semaphoreClasses {
+			holds[class.Name] = class.ActiveHolds
+		}
+		return holds
+	}
+
+	t.Run("classes are granted within their bounds", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, semaphoreId := newNamespace()
+
+		semaphore := createSemaphoreWithClasses(t, core, semaphoreId, "api", 20, classes, now)
+		require.Len(t, semaphore.Classes, 2)
+		require.Equal(t, "interactive", semaphore.Classes[0].Name)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+		lease3 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_3", now, time.Hour)
+		lease4 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_4", now, time.Hour)
+
+		success, semaphore := acquireSemaphoreInClass(t, core, namespaceId, lease1.Id, "api", "batch", 15, now)
+		require.True(t, success)
+		require.Equal(t, map[string]int64{"interactive": 0, "batch": 15}, classHolds(semaphore.Classes))
+
+		// Batch is at its maximum
+		success, _ = acquireSemaphoreInClass(t, core, namespaceId, lease2.Id, "api", "batch", 1, now)
+		require.False(t, success)
+
+		// What is left is reserved for interactive
+		success, _ = acquireSemaphoreInClass(t, core, namespaceId, lease2.Id, "api", "", 1, now)
+		require.False(t, success)
+
+		success, semaphore = acquireSemaphoreInClass(t, core, namespaceId, lease3.Id, "api", "interactive", 5, now)
+		require.True(t, success)
+		require.EqualValues(t, 20, semaphore.ActiveHolds)
+		require.Equal(t, map[string]int64{"interactive": 5, "batch": 15}, classHolds(semaphore.Classes))
+
+		holders := listSemaphoreHolders(t, core, namespaceId, "api", now)
+		require.Equal(t, map[string]int64{"interactive": 5, "batch": 15}, classHolds(holders.Classes))
+		for _, holder := range holders.Holders {
+			if holder.Id.LeaseId == lease1.Id.LeaseId {
+				require.Equal(t, "batch", holder.Class)
+			} else {
+				require.Equal(t, "interactive", holder.Class)
+			}
+		}
+
+		// Releasing batch permits frees them for interactive, which has no maximum
+		success, _, _ = adjustSemaphoreHold(t, core, namespaceId, lease1.Id, "api", 5, now)
+		require.True(t, success)
+		success, semaphore = acquireSemaphoreInClass(t, core, namespaceId, lease4.Id, "api", "interactive", 10, now)
+		require.True(t, success)
+		require.Equal(t, map[string]int64{"interactive": 15, "batch": 5}, classHolds(semaphore.Classes))
+
+		semaphore = releaseSemaphore(t, core, namespaceId, "api", lease4.Id, now)
+		require.Equal(t, map[string]int64{"interactive": 5, "batch": 5}, classHolds(semaphore.Classes))
+
+		// With interactive using its reservation, unclassed holders get the rest
+		success, semaphore = acquireSemaphoreInClass(t, core, namespaceId, lease2.Id, "api", "", 10, now)
+		require.True(t, success)
+		require.EqualValues(t, 20, semaphore.ActiveHolds)
+		require.Equal(t, map[string]int64{"interactive": 5, "batch": 5}, classHolds(semaphore.Classes))
+	})
+
+	t.Run("expired and revoked holders leave their class", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, semaphoreId := newNamespace()
+
+		_ = createSemaphoreWithClasses(t, core, semaphoreId, "api", 20, classes, now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Minute)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphoreInClass(t, core, namespaceId, lease1.Id, "api", "batch", 10, now)
+		require.True(t, success)
+		success, _ = acquireSemaphoreInClass(t, core, namespaceId, lease2.Id, "api", "interactive", 3, now)
+		require.True(t, success)
+
+		later := now.Add(2 * time.Minute)
+		semaphore := getSemaphore(t, core, semaphoreId, later)
+		require.Equal(t, map[string]int64{"interactive": 3, "batch": 0}, classHolds(semaphore.Classes))
+
+		// The expired hold is pruned on the next acquire
+		success, semaphore = acquireSemaphoreInClass(t, core, namespaceId, lease2.Id, "api", "interactive", 4, later)
+		require.True(t, success)
+		require.Equal(t, map[string]int64{"interactive": 4, "batch": 0}, classHolds(semaphore.Classes))
+
+		_, err := core.RevokeSemaphoreLease(&coreapis.RevokeSemaphoreLeaseRequest{
+			Payload: &corepb.RevokeSemaphoreLeaseRequest{
+				LeaseId: lease2.Id,
+			},
+			Now: later.UnixNano(),
+		})
+		require.NoError(t, err)
+
+		semaphore = getSemaphore(t, core, semaphoreId, later)
+		require.EqualValues(t, 0, semaphore.ActiveHolds)
+		require.Equal(t, map[string]int64{"interactive": 0, "batch": 0}, classHolds(semaphore.Classes))
+	})
+
+	t.Run("invalid classes are rejected", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, semaphoreId := newNamespace()
+
+		appErr := createSemaphoreWithClassesError(t, core, semaphoreId, "api", 4, classes, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		_ = createSemaphoreWithClasses(t, core, semaphoreId, "api", 20, classes, now)
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+
+		appErr = acquireSemaphoreInClassWithError(t, core, namespaceId, lease.Id, "api", "missing", 1, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Can never fit under the class maximum
+		appErr = acquireSemaphoreInClassWithError(t, core, namespaceId, lease.Id, "api", "batch", 16, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		success, _ := acquireSemaphoreInClass(t, core, namespaceId, lease.Id, "api", "batch", 2, now)
+		require.True(t, success)
+
+		// A hold keeps its class
+		appErr = acquireSemaphoreInClassWithError(t, core, namespaceId, lease.Id, "api", "interactive", 2, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		appErr = adjustSemaphoreHoldWithError(t, core, namespaceId, lease.Id, "api", 16, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Permits cannot drop below the reservations
+		appErr = updateSemaphoreWithError(t, core, namespaceId, "api", "", 4, 1, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+	})
+}
+
+func TestCore_SemaphoreMaxHold(t *testing.T) {
+	newNamespace := func() (*corepb.NamespaceId, func() *corepb.SemaphoreId) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		return namespaceId, func() *corepb.SemaphoreId {
+			return &corepb.SemaphoreId{
+				AccountId:   namespaceId.AccountId,
+				NamespaceId: namespaceId.NamespaceId,
+				SemaphoreId: rand.Uint64(),
+			}
+		}
+	}
+
+	t.Run("hold is released while the lease is alive", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		semaphoreId := newSemaphoreId()
+		createSemaphore(t, core, semaphoreId, "test_semaphore", 10, now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, semaphore := acquireSemaphoreWithMaxHold(t, core, namespaceId, lease1.Id, "test_semaphore", 6, 60, now)
+		require.True(t, success)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), semaphore.EarliestHolderExpiresAt)
+		require.Equal(t, []int64{now.Add(time.Minute).UnixNano()}, listExpirationRecords(t, core, semaphoreId))
+
+		holders := listSemaphoreHolders(t, core, namespaceId, "test_semaphore", now).Holders
+		require.Len(t, holders, 1)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), holders[0].HoldExpiresAt)
+		require.Equal(t, lease1.ExpiresAt, holders[0].ExpiresAt)
+
+		require.EqualValues(t, 6, getSemaphore(t, core, semaphoreId, now.Add(59*time.Second)).ActiveHolds)
+
+		// Past the max hold time the permits are free again
+		later := now.Add(time.Minute)
+		semaphore = getSemaphore(t, core, semaphoreId, later)
+		require.EqualValues(t, 0, semaphore.ActiveHolds)
+		require.EqualValues(t, 0, semaphore.ActiveHoldersCount)
+		require.Empty(t, listSemaphoreHolders(t, core, namespaceId, "test_semaphore", later).Holders)
+
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "test_semaphore", 10, later)
+		require.True(t, success)
+	})
+
+	t.Run("re-acquire restarts or clears max hold time", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		semaphoreId := newSemaphoreId()
+		createSemaphore(t, core, semaphoreId, "test_semaphore", 10, now)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+
+		acquireSemaphoreWithMaxHold(t, core, namespaceId, lease.Id, "test_semaphore", 5, 60, now)
+
+		success, _ := acquireSemaphoreWithMaxHold(t, core, namespaceId, lease.Id, "test_semaphore", 5, 60, now.Add(30*time.Second))
+		require.True(t, success)
+		require.EqualValues(t, 5, getSemaphore(t, core, semaphoreId, now.Add(time.Minute)).ActiveHolds)
+		require.EqualValues(t, 0, getSemaphore(t, core, semaphoreId, now.Add(90*time.Second)).ActiveHolds)
+
+		success, _ = acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 5, now.Add(2*time.Minute))
+		require.True(t, success)
+		holders := listSemaphoreHolders(t, core, namespaceId, "test_semaphore", now.Add(10*time.Minute)).Holders
+		require.Len(t, holders, 1)
+		require.EqualValues(t, 0, holders[0].HoldExpiresAt)
+	})
+
+	t.Run("weight inherited from descendants outlives the hold", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		globalId := newSemaphoreId()
+		createSemaphore(t, core, globalId, "global", 20, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "tenant", 5, now)
+		require.True(t, success)
+		success, global := acquireSemaphoreWithMaxHold(t, core, namespaceId, lease1.Id, "global", 3, 60, now)
+		require.True(t, success)
+		require.EqualValues(t, 8, global.ActiveHolds)
+
+		// Only the direct part of the holder is released
+		later := now.Add(time.Minute)
+		global = getSemaphore(t, core, globalId, later)
+		require.EqualValues(t, 5, global.ActiveHolds)
+		require.EqualValues(t, 1, global.ActiveHoldersCount)
+		require.Equal(t, lease1.ExpiresAt, global.EarliestHolderExpiresAt)
+
+		holders := listSemaphoreHolders(t, core, namespaceId, "global", later).Holders
+		require.Len(t, holders, 1)
+		require.EqualValues(t, 5, holders[0].Weight)
+		require.EqualValues(t, 5, holders[0].InheritedWeight)
+		require.EqualValues(t, 0, holders[0].HoldExpiresAt)
+
+		success, global = acquireSemaphore(t, core, namespaceId, lease2.Id, "global", 15, later)
+		require.True(t, success)
+		require.EqualValues(t, 20, global.ActiveHolds)
+		require.Equal(t, []int64{lease1.ExpiresAt}, listExpirationRecords(t, core, globalId))
+
+		releaseSemaphore(t, core, namespaceId, "tenant", lease1.Id, later)
+		global = getSemaphore(t, core, globalId, later)
+		require.EqualValues(t, 15, global.ActiveHolds)
+		require.EqualValues(t, 1, global.ActiveHoldersCount)
+	})
+
+	t.Run("hold on a child is released on its ancestors", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		globalId := newSemaphoreId()
+		tenantId := newSemaphoreId()
+		teamId := newSemaphoreId()
+		createSemaphore(t, core, globalId, "global", 20, now)
+		createChildSemaphore(t, core, tenantId, "tenant", 10, "global", now)
+		createChildSemaphore(t, core, teamId, "team", 10, "tenant", now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		// T+0: A bounded hold on the team, and an unbounded one on the tenant
+		success, team := acquireSemaphoreWithMaxHold(t, core, namespaceId, lease1.Id, "team", 5, 60, now)
+		require.True(t, success)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), team.EarliestHolderExpiresAt)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease1.Id, "tenant", 2, now)
+		require.True(t, success)
+		require.EqualValues(t, 7, getSemaphore(t, core, tenantId, now).ActiveHolds)
+		require.EqualValues(t, 7, getSemaphore(t, core, globalId, now).ActiveHolds)
+
+		// T+1m: GC reaps the team hold through the expiration index, and the
+		// ancestors give back the weight they inherited from it
+		runSemaphoresGarbageCollection(t, core, now.Add(time.Minute))
+		require.EqualValues(t, 0, getSemaphore(t, core, teamId, now.Add(time.Minute)).ActiveHolds)
+
+		tenant := getSemaphore(t, core, tenantId, now.Add(time.Minute))
+		require.EqualValues(t, 2, tenant.ActiveHolds)
+		require.EqualValues(t, 1, tenant.ActiveHoldersCount)
+		holders := listSemaphoreHolders(t, core, namespaceId, "tenant", now.Add(time.Minute)).Holders
+		require.Len(t, holders, 1)
+		require.EqualValues(t, 2, holders[0].Weight)
+		require.EqualValues(t, 0, holders[0].InheritedWeight)
+
+		global := getSemaphore(t, core, globalId, now.Add(time.Minute))
+		require.EqualValues(t, 2, global.ActiveHolds)
+		require.Equal(t, []int64{lease1.ExpiresAt}, listExpirationRecords(t, core, globalId))
+
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "global", 18, now.Add(time.Minute))
+		require.True(t, success)
+	})
+
+	t.Run("hold on a child is released on its ancestors when pruned on acquire", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		globalId := newSemaphoreId()
+		createSemaphore(t, core, globalId, "global", 10, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphoreWithMaxHold(t, core, namespaceId, lease1.Id, "tenant", 8, 60, now)
+		require.True(t, success)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "tenant", 8, now.Add(30*time.Second))
+		require.False(t, success)
+
+		// T+1m: The expired hold is pruned before the permits are checked on
+		// the tenant and on the global semaphore
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "tenant", 8, now.Add(time.Minute))
+		require.True(t, success)
+
+		global := getSemaphore(t, core, globalId, now.Add(time.Minute))
+		require.EqualValues(t, 8, global.ActiveHolds)
+		require.EqualValues(t, 1, global.ActiveHoldersCount)
+	})
+
+	t.Run("timed out hold cannot be adjusted", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		createSemaphore(t, core, newSemaphoreId(), "test_semaphore", 10, now)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		acquireSemaphoreWithMaxHold(t, core, namespaceId, lease.Id, "test_semaphore", 5, 60, now)
+
+		appErr := adjustSemaphoreHoldWithError(t, core, namespaceId, lease.Id, "test_semaphore", 6, now.Add(time.Minute))
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+
+	t.Run("invalid max hold seconds", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		createSemaphore(t, core, newSemaphoreId(), "global", 20, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+
+		appErr := acquireSemaphoreWithMaxHoldError(t, core, namespaceId, lease.Id, "global", 1, -1, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		appErr = acquireSemaphoreWithMaxHoldError(t, core, namespaceId, lease.Id, "tenant", 1, -1, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+	})
+}
+
+func TestCore_TransferSemaphoreHold(t *testing.T) {
+	newNamespace := func() (*corepb.NamespaceId, func() *corepb.SemaphoreId) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		return namespaceId, func() *corepb.SemaphoreId {
+			return &corepb.SemaphoreId{
+				AccountId:   namespaceId.AccountId,
+				NamespaceId: namespaceId.NamespaceId,
+				SemaphoreId: rand.Uint64(),
+			}
+		}
+	}
+
+	t.Run("hold moves to the new lease", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		semaphoreId := newSemaphoreId()
+		createSemaphore(t, core, semaphoreId, "test_semaphore", 10, now)
+
+		oldLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "old_pod", now, time.Minute)
+		newLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "new_pod", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, oldLease.Id, "test_semaphore", 4, now)
+		require.True(t, success)
+
+		semaphore, holder := transferSemaphoreHold(t, core, namespaceId, "test_semaphore", oldLease.Id, newLease.Id, now.Add(30*time.Second))
+		require.EqualValues(t, 4, semaphore.ActiveHolds)
+		require.EqualValues(t, 1, semaphore.ActiveHoldersCount)
+		require.Equal(t, newLease.Id.LeaseId, holder.Id.LeaseId)
+		require.Equal(t, newLease.ExpiresAt, holder.ExpiresAt)
+		require.Equal(t, now.UnixNano(), holder.LockedAt)
+		require.EqualValues(t, 4, holder.Weight)
+
+		holders := listSemaphoreHolders(t, core, namespaceId, "test_semaphore", now.Add(30*time.Second)).Holders
+		require.Len(t, holders, 1)
+		require.Equal(t, newLease.Id.LeaseId, holders[0].Id.LeaseId)
+
+		// The hold outlives the old lease
+		semaphore = getSemaphore(t, core, semaphoreId, now.Add(2*time.Minute))
+		require.EqualValues(t, 4, semaphore.ActiveHolds)
+		require.EqualValues(t, 1, semaphore.ActiveHoldersCount)
+	})
+
+	t.Run("inherited weight moves on the ancestors", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		globalId := newSemaphoreId()
+		createSemaphore(t, core, globalId, "global", 20, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		oldLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "old_pod", now, time.Hour)
+		newLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "new_pod", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, oldLease.Id, "tenant", 5, now)
+		require.True(t, success)
+
+		transferSemaphoreHold(t, core, namespaceId, "tenant", oldLease.Id, newLease.Id, now)
+
+		global := getSemaphore(t, core, globalId, now)
+		require.EqualValues(t, 5, global.ActiveHolds)
+		require.EqualValues(t, 1, global.ActiveHoldersCount)
+
+		holders := listSemaphoreHolders(t, core, namespaceId, "global", now).Holders
+		require.Len(t, holders, 1)
+		require.Equal(t, newLease.Id.LeaseId, holders[0].Id.LeaseId)
+		require.EqualValues(t, 5, holders[0].InheritedWeight)
+	})
+
+	t.Run("joins the weight the target lease inherits", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		globalId := newSemaphoreId()
+		createSemaphore(t, core, globalId, "global", 20, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		oldLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "old_pod", now, time.Hour)
+		newLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "new_pod", now, time.Hour)
+
+		acquireSemaphore(t, core, namespaceId, newLease.Id, "tenant", 2, now)
+		acquireSemaphore(t, core, namespaceId, oldLease.Id, "global", 3, now)
+
+		global, holder := transferSemaphoreHold(t, core, namespaceId, "global", oldLease.Id, newLease.Id, now)
+		require.EqualValues(t, 5, global.ActiveHolds)
+		require.EqualValues(t, 1, global.ActiveHoldersCount)
+		require.EqualValues(t, 5, holder.Weight)
+		require.EqualValues(t, 2, holder.InheritedWeight)
+
+		// Releasing the direct hold leaves the inherited weight
+		global = releaseSemaphore(t, core, namespaceId, "global", newLease.Id, now)
+		require.EqualValues(t, 2, global.ActiveHolds)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+
+		createSemaphore(t, core, newSemaphoreId(), "global", 20, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		acquireSemaphore(t, core, namespaceId, lease1.Id, "tenant", 2, now)
+		acquireSemaphore(t, core, namespaceId, lease2.Id, "global", 2, now)
+
+		appErr := transferSemaphoreHoldWithError(t, core, namespaceId, "tenant", lease1.Id, lease1.Id, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Only inherited weight on global
+		appErr = transferSemaphoreHoldWithError(t, core, namespaceId, "global", lease1.Id, lease2.Id, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		// Not held at all
+		appErr = transferSemaphoreHoldWithError(t, core, namespaceId, "tenant", lease2.Id, lease1.Id, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+
+		// The target lease holds it directly already
+		acquireSemaphore(t, core, namespaceId, lease2.Id, "tenant", 1, now)
+		appErr = transferSemaphoreHoldWithError(t, core, namespaceId, "tenant", lease1.Id, lease2.Id, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		appErr = transferSemaphoreHoldWithError(t, core, namespaceId, "missing", lease1.Id, lease2.Id, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+}
+
+func TestCore_SemaphoreWaiters(t *testing.T) {
+	newNamespace := func() (*corepb.NamespaceId, *corepb.SemaphoreId) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		return namespaceId, &corepb.SemaphoreId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			SemaphoreId: rand.Uint64(),
+		}
+	}
+
+	t.Run("waiting acquire is listed until it succeeds", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, semaphoreId := newNamespace()
+		createSemaphore(t, core, semaphoreId, "test_semaphore", 10, now)
+
+		holderLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "holder", now, time.Hour)
+		waiterLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "waiter", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, holderLease.Id, "test_semaphore", 8, now)
+		require.True(t, success)
+
+		// A non-blocking attempt is not a waiter
+		success, _ = acquireSemaphore(t, core, namespaceId, waiterLease.Id, "test_semaphore", 4, now)
+		require.False(t, success)
+		require.Empty(t, listSemaphoreWaiters(t, core, namespaceId, "test_semaphore", now).Waiters)
+
+		success = acquireSemaphoreWaiting(t, core, namespaceId, waiterLease.Id, "test_semaphore", 4, now.Add(time.Minute), now)
+		require.False(t, success)
+		success = acquireSemaphoreWaiting(t, core, namespaceId, waiterLease.Id, "test_semaphore", 4, now.Add(time.Minute), now.Add(time.Second))
+		require.False(t, success)
+
+		waiters := listSemaphoreWaiters(t, core, namespaceId, "test_semaphore", now.Add(time.Second)).Waiters
+		require.Len(t, waiters, 1)
+		require.Equal(t, waiterLease.Id.LeaseId, waiters[0].LeaseId)
+		require.EqualValues(t, 4, waiters[0].Weight)
+		require.Equal(t, "waiter", waiters[0].ProcessId)
+		require.Equal(t, now.UnixNano(), waiters[0].WaitingSince)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), waiters[0].ExpiresAt)
+		require.EqualValues(t, 1, getSemaphoreWaitersCount(t, core, namespaceId, "test_semaphore", now.Add(time.Second)))
+
+		releaseSemaphore(t, core, namespaceId, "test_semaphore", holderLease.Id, now.Add(2*time.Second))
+		success = acquireSemaphoreWaiting(t, core, namespaceId, waiterLease.Id, "test_semaphore", 4, now.Add(time.Minute), now.Add(2*time.Second))
+		require.True(t, success)
+
+		require.Empty(t, listSemaphoreWaiters(t, core, namespaceId, "test_semaphore", now.Add(2*time.Second)).Waiters)
+		require.Zero(t, getSemaphoreWaitersCount(t, core, namespaceId, "test_semaphore", now.Add(2*time.Second)))
+	})
+
+	t.Run("final attempt after the wait forgets the waiter", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, semaphoreId := newNamespace()
+		createSemaphore(t, core, semaphoreId, "test_semaphore", 10, now)
+
+		holderLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "holder", now, time.Hour)
+		waiterLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "waiter", now, time.Hour)
+
+		acquireSemaphore(t, core, namespaceId, holderLease.Id, "test_semaphore", 10, now)
+		acquireSemaphoreWaiting(t, core, namespaceId, waiterLease.Id, "test_semaphore", 1, now.Add(10*time.Second), now)
+		require.Len(t, listSemaphoreWaiters(t, core, namespaceId, "test_semaphore", now).Waiters, 1)
+
+		success := acquireSemaphoreWaiting(t, core, namespaceId, waiterLease.Id, "test_semaphore", 1, now.Add(10*time.Second), now.Add(10*time.Second))
+		require.False(t, success)
+		require.Empty(t, listSemaphoreWaiters(t, core, namespaceId, "test_semaphore", now.Add(10*time.Second)).Waiters)
+	})
+
+	t.Run("abandoned waiters lapse and are dropped by GC", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, semaphoreId := newNamespace()
+		semaphore := createSemaphore(t, core, semaphoreId, "test_semaphore", 10, now)
+
+		holderLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "holder", now, time.Hour)
+		// The waiter's lease runs out before its wait does
+		waiterLease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "waiter", now, 30*time.Second)
+
+		acquireSemaphore(t, core, namespaceId, holderLease.Id, "test_semaphore", 10, now)
+		acquireSemaphoreWaiting(t, core, namespaceId, waiterLease.Id, "test_semaphore", 1, now.Add(time.Minute), now)
+
+		waiters := listSemaphoreWaiters(t, core, namespaceId, "test_semaphore", now).Waiters
+		require.Len(t, waiters, 1)
+		require.Equal(t, waiterLease.ExpiresAt, waiters[0].ExpiresAt)
+
+		require.Empty(t, listSemaphoreWaiters(t, core, namespaceId, "test_semaphore", now.Add(30*time.Second)).Waiters)
+		// The lapsed waiter is not counted even before GC drops it
+		require.Zero(t, getSemaphoreWaitersCount(t, core, namespaceId, "test_semaphore", now.Add(30*time.Second)))
+
+		_, err := core.RunSemaphoresGarbageCollection(&coreapis.RunSemaphoresGarbageCollectionRequest{
+			Payload: &corepb.RunSemaphoresGarbageCollectionRequest{
+				GcRecordsPageSize:          100,
+				GcRecordSemaphoresPageSize: 100,
+				GcRecordHoldersPageSize:    100,
+				MaxVisited:                 100,
+			},
+			Now: now.Add(30 * time.Second).UnixNano(),
+		})
+		require.NoError(t, err)
+
+		waiter, err := core.waiters.Get(core.badgerStore.View(), semaphore.Id, waiterLease.Id.LeaseId)
+		require.ErrorIs(t, err, store.ErrNotFound)
+		require.Nil(t, waiter)
+		require.Zero(t, getSemaphoreWaitersCount(t, core, namespaceId, "test_semaphore", now.Add(30*time.Second)))
+	})
+
+	t.Run("missing semaphore", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, _ := newNamespace()
+
+		resp, err := core.ListSemaphoreWaiters(&coreapis.ListSemaphoreWaitersRequest{
+			Payload: &corepb.ListSemaphoreWaitersRequest{
+				NamespaceId:   namespaceId,
+				SemaphoreName: "missing",
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+	})
+}
+
+func TestCore_SemaphoreContention(t *testing.T) {
+	newNamespace := func() (*corepb.NamespaceId, func() *corepb.SemaphoreId) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		return namespaceId, func() *corepb.SemaphoreId {
+			return &corepb.SemaphoreId{
+				AccountId:   namespaceId.AccountId,
+				NamespaceId: namespaceId.NamespaceId,
+				SemaphoreId: rand.Uint64(),
+			}
+		}
+	}
+
+	t.Run("reports missing permits and the holders expiring soonest", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		createSemaphore(t, core, newSemaphoreId(), "test_semaphore", 10, now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, 10*time.Minute)
+		lease3 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_3", now, 30*time.Minute)
+		lease4 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_4", now, time.Hour)
+
+		for lease, weight := range map[*corepb.Lease]int64{lease1: 3, lease2: 4, lease3: 2} {
+			success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", weight, now)
+			require.True(t, success)
+		}
+
+		// 1 permit is free, so 5 are missing; lease2 and lease3 lapse first and free 6
+		resp := acquireSemaphoreResponse(t, core, namespaceId, lease4.Id, "test_semaphore", 6, now)
+		require.False(t, resp.Success)
+		require.EqualValues(t, 5, resp.MissingPermits)
+		require.Len(t, resp.BlockingHolders, 2)
+		require.Equal(t, lease2.Id.LeaseId, resp.BlockingHolders[0].Id.LeaseId)
+		require.Equal(t, lease3.Id.LeaseId, resp.BlockingHolders[1].Id.LeaseId)
+		require.Equal(t, lease3.ExpiresAt, resp.EarliestGrantAt)
+
+		// A successful acquire carries no diagnostics
+		resp = acquireSemaphoreResponse(t, core, namespaceId, lease4.Id, "test_semaphore", 1, now)
+		require.True(t, resp.Success)
+		require.Zero(t, resp.MissingPermits)
+		require.Empty(t, resp.BlockingHolders)
+		require.Zero(t, resp.EarliestGrantAt)
+	})
+
+	t.Run("growing a hold does not count the lease's own holder", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		createSemaphore(t, core, newSemaphoreId(), "test_semaphore", 3, now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, 10*time.Minute)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "test_semaphore", 2, now)
+		require.True(t, success)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "test_semaphore", 1, now)
+		require.True(t, success)
+
+		resp := acquireSemaphoreResponse(t, core, namespaceId, lease1.Id, "test_semaphore", 3, now)
+		require.False(t, resp.Success)
+		require.EqualValues(t, 1, resp.MissingPermits)
+		require.Len(t, resp.BlockingHolders, 1)
+		require.Equal(t, lease2.Id.LeaseId, resp.BlockingHolders[0].Id.LeaseId)
+		require.Equal(t, lease2.ExpiresAt, resp.EarliestGrantAt)
+	})
+
+	t.Run("reports the ancestor with the largest shortfall", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		createSemaphore(t, core, newSemaphoreId(), "global", 10, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "tenant", 10, "global", now)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "global", 8, now)
+		require.True(t, success)
+
+		// tenant is empty, but global only has 2 permits left
+		resp := acquireSemaphoreResponse(t, core, namespaceId, lease2.Id, "tenant", 5, now)
+		require.False(t, resp.Success)
+		require.EqualValues(t, 3, resp.MissingPermits)
+		require.Len(t, resp.BlockingHolders, 1)
+		require.Equal(t, lease1.Id.LeaseId, resp.BlockingHolders[0].Id.LeaseId)
+		require.Equal(t, lease1.ExpiresAt, resp.EarliestGrantAt)
+	})
+
+	t.Run("blocking holders are capped", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		createSemaphore(t, core, newSemaphoreId(), "test_semaphore", 60, now)
+
+		for i := range 60 {
+			lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, fmt.Sprintf("process_%d", i), now, time.Hour)
+			success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 1, now)
+			require.True(t, success)
+		}
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "waiter", now, time.Hour)
+		resp := acquireSemaphoreResponse(t, core, namespaceId, lease.Id, "test_semaphore", 60, now)
+		require.False(t, resp.Success)
+		require.EqualValues(t, 60, resp.MissingPermits)
+		require.Len(t, resp.BlockingHolders, maxBlockingHolders)
+		// The listed holders do not free enough, so there is no estimate
+		require.Zero(t, resp.EarliestGrantAt)
+	})
+}
+
+func TestCore_AutoCreatedSemaphores(t *testing.T) {
+	newNamespace := func() *corepb.NamespaceId {
+		return &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+	}
+
+	t.Run("missing semaphore is created with default permits", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId := newNamespace()
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", now, time.Hour)
+
+		resp := acquireSemaphoreOrCreate(t, core, namespaceId, lease1.Id, "customer_1", 3, 5, 60, 10, now)
+		require.Nil(t, resp.ApplicationError)
+		require.True(t, resp.Payload.Success)
+		require.EqualValues(t, 5, resp.Payload.Semaphore.Permits)
+		require.EqualValues(t, 60, resp.Payload.Semaphore.DeleteInactiveAfterSeconds)
+		require.EqualValues(t, 3, resp.Payload.Semaphore.ActiveHolds)
+
+		// Defaults are ignored once the semaphore exists
+		resp = acquireSemaphoreOrCreate(t, core, namespaceId, lease2.Id, "customer_1", 3, 10, 0, 10, now)
+		require.Nil(t, resp.ApplicationError)
+		require.False(t, resp.Payload.Success)
+		require.EqualValues(t, 5, resp.Payload.Semaphore.Permits)
+
+		semaphore := getSemaphoreByName(t, core, namespaceId, "customer_1", now)
+		require.EqualValues(t, 60, semaphore.DeleteInactiveAfterSeconds)
+	})
+
+	t.Run("errors leave no semaphore behind", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId := newNamespace()
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+
+		// Without default permits a missing semaphore is still an error
+		resp := acquireSemaphoreOrCreate(t, core, namespaceId, lease.Id, "customer_1", 1, 0, 0, 10, now)
+		require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+
+		resp = acquireSemaphoreOrCreate(t, core, namespaceId, lease.Id, "customer_1", 6, 5, 0, 10, now)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+		require.Equal(t, mrpc.NotFound, getSemaphoreByNameWithError(t, core, namespaceId, "customer_1", now).Code)
+
+		resp = acquireSemaphoreOrCreate(t, core, namespaceId, lease.Id, "customer_1", 1, 5, 0, 0, now)
+		require.Equal(t, mrpc.ResourceExhausted, resp.ApplicationError.Code)
+		require.Equal(t, mrpc.NotFound, getSemaphoreByNameWithError(t, core, namespaceId, "customer_1", now).Code)
+	})
+}
+
+func TestCore_SemaphoreInactivityDeletion(t *testing.T) {
+	newNamespace := func() (*corepb.NamespaceId, func() *corepb.SemaphoreId) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		return namespaceId, func() *corepb.SemaphoreId {
+			return &corepb.SemaphoreId{
+				AccountId:   namespaceId.AccountId,
+				NamespaceId: namespaceId.NamespaceId,
+				SemaphoreId: rand.Uint64(),
+			}
+		}
+	}
+
+	t.Run("idle semaphore is deleted after the window", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		createSemaphoreWithInactivity(t, core, newSemaphoreId(), "test_semaphore", 10, 60, now)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 1, now.Add(30*time.Second))
+		require.True(t, success)
+		releaseSemaphore(t, core, namespaceId, "test_semaphore", lease.Id, now.Add(40*time.Second))
+
+		// The window restarts with the last activity
+		runSemaphoresGarbageCollection(t, core, now.Add(90*time.Second))
+		getSemaphoreByName(t, core, namespaceId, "test_semaphore", now.Add(90*time.Second))
+
+		runSemaphoresGarbageCollection(t, core, now.Add(100*time.Second))
+		require.Equal(t, mrpc.NotFound, getSemaphoreByNameWithError(t, core, namespaceId, "test_semaphore", now.Add(100*time.Second)).Code)
+
+		// The slot is given back to the namespace
+		createSemaphoreWithMax(t, core, newSemaphoreId(), "other_semaphore", 10, 1, now.Add(100*time.Second))
+	})
+
+	t.Run("held semaphores and parents are kept", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		createSemaphoreWithInactivity(t, core, newSemaphoreId(), "held", 10, 60, now)
+		createSemaphoreWithInactivity(t, core, newSemaphoreId(), "parent", 10, 60, now)
+		createChildSemaphore(t, core, newSemaphoreId(), "child", 10, "parent", now)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, time.Hour)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "held", 1, now)
+		require.True(t, success)
+
+		runSemaphoresGarbageCollection(t, core, now.Add(2*time.Minute))
+		getSemaphoreByName(t, core, namespaceId, "held", now.Add(2*time.Minute))
+		getSemaphoreByName(t, core, namespaceId, "parent", now.Add(2*time.Minute))
+
+		// Without its child the parent has long been idle
+		deleteSemaphore(t, core, namespaceId, "child", now.Add(2*time.Minute))
+		runSemaphoresGarbageCollection(t, core, now.Add(2*time.Minute))
+		require.Equal(t, mrpc.NotFound, getSemaphoreByNameWithError(t, core, namespaceId, "parent", now.Add(2*time.Minute)).Code)
+		getSemaphoreByName(t, core, namespaceId, "held", now.Add(2*time.Minute))
+	})
+
+	t.Run("expired holders leave the semaphore idle", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		createSemaphoreWithInactivity(t, core, newSemaphoreId(), "test_semaphore", 10, 60, now)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", now, 30*time.Second)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 1, now)
+		require.True(t, success)
+
+		// GC prunes the holder, which starts the window from the acquire
+		runSemaphoresGarbageCollection(t, core, now.Add(31*time.Second))
+		getSemaphoreByName(t, core, namespaceId, "test_semaphore", now.Add(31*time.Second))
+
+		runSemaphoresGarbageCollection(t, core, now.Add(61*time.Second))
+		require.Equal(t, mrpc.NotFound, getSemaphoreByNameWithError(t, core, namespaceId, "test_semaphore", now.Add(61*time.Second)).Code)
+	})
+
+	t.Run("update without a window keeps it", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		semaphore := createSemaphoreWithInactivity(t, core, newSemaphoreId(), "test_semaphore", 10, 60, now)
+
+		updated := updateSemaphore(t, core, namespaceId, "test_semaphore", "kept", 10, semaphore.Version, now)
+		require.EqualValues(t, 60, updated.DeleteInactiveAfterSeconds)
+
+		runSemaphoresGarbageCollection(t, core, now.Add(time.Hour))
+		require.Equal(t, mrpc.NotFound, getSemaphoreByNameWithError(t, core, namespaceId, "test_semaphore", now.Add(time.Hour)).Code)
+	})
+
+	t.Run("update replaces the window", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		semaphore := createSemaphoreWithInactivity(t, core, newSemaphoreId(), "test_semaphore", 10, 60, now)
+
+		updated := updateSemaphoreWithInactivity(t, core, namespaceId, "test_semaphore", 10, 7200, semaphore.Version, now)
+		require.EqualValues(t, 7200, updated.DeleteInactiveAfterSeconds)
+
+		runSemaphoresGarbageCollection(t, core, now.Add(time.Hour))
+		getSemaphoreByName(t, core, namespaceId, "test_semaphore", now.Add(time.Hour))
+
+		runSemaphoresGarbageCollection(t, core, now.Add(2*time.Hour))
+		require.Equal(t, mrpc.NotFound, getSemaphoreByNameWithError(t, core, namespaceId, "test_semaphore", now.Add(2*time.Hour)).Code)
+	})
+
+	t.Run("update with -1 clears the window", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		now := time.Now()
+		namespaceId, newSemaphoreId := newNamespace()
+		semaphore := createSemaphoreWithInactivity(t, core, newSemaphoreId(), "test_semaphore", 10, 60, now)
+
+		updated := updateSemaphoreWithInactivity(t, core, namespaceId, "test_semaphore", 10, -1, semaphore.Version, now)
+		require.Zero(t, updated.DeleteInactiveAfterSeconds)
+
+		runSemaphoresGarbageCollection(t, core, now.Add(time.Hour))
+		getSemaphoreByName(t, core, namespaceId, "test_semaphore", now.Add(time.Hour))
+	})
+}
+
+func TestCore_SemaphoreStats(t *testing.T) {
+	// Stats buckets are aligned to whole minutes
+	base := time.Unix(1700000040, 0)
+
+	newSemaphore := func(t *testing.T, core *Core, permits int64) (*corepb.NamespaceId, *corepb.Semaphore) {
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		semaphore := createSemaphore(t, core, &corepb.SemaphoreId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			SemaphoreId: rand.Uint64(),
+		}, "test_semaphore", permits, base)
+		return namespaceId, semaphore
+	}
+
+	t.Run("buckets track holds and acquires", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		namespaceId, _ := newSemaphore(t, core, 2)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", base, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", base, time.Hour)
+
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "test_semaphore", 2, base)
+		require.True(t, success)
+		success, _ = acquireSemaphore(t, core, namespaceId, lease2.Id, "test_semaphore", 1, base.Add(15*time.Second))
+		require.False(t, success)
+		releaseSemaphore(t, core, namespaceId, "test_semaphore", lease1.Id, base.Add(30*time.Second))
+
+		buckets := getSemaphoreStats(t, core, namespaceId, "test_semaphore", 120, base.Add(90*time.Second))
+		require.Len(t, buckets, 3)
+
+		// Before the first acquire
+		require.Equal(t, base.Add(-time.Minute).UnixNano(), buckets[0].StartAt)
+		require.EqualValues(t, 0, buckets[0].MaxActiveHolds)
+		require.EqualValues(t, 0, buckets[0].AverageActiveHolds)
+
+		// 2 holds for the first half of the minute
+		require.Equal(t, base.UnixNano(), buckets[1].StartAt)
+		require.EqualValues(t, 2, buckets[1].MaxActiveHolds)
+		require.InDelta(t, 1.0, buckets[1].AverageActiveHolds, 0.0001)
+		require.EqualValues(t, 1, buckets[1].AcquiresSucceeded)
+		require.EqualValues(t, 1, buckets[1].AcquiresFailed)
+
+		// The current minute, without activity
+		require.EqualValues(t, 0, buckets[2].MaxActiveHolds)
+		require.EqualValues(t, 0, buckets[2].AcquiresSucceeded)
+	})
+
+	t.Run("a blocked acquire counts one failure", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		namespaceId, _ := newSemaphore(t, core, 1)
+
+		lease1 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", base, time.Hour)
+		lease2 := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_2", base, time.Hour)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease1.Id, "test_semaphore", 1, base)
+		require.True(t, success)
+
+		// T+0..T+5s: The handler polls once a second until the deadline, its
+		// last attempt is the only one not retried
+		for i := range 6 {
+			resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+				Payload: &corepb.AcquireSemaphoreRequest{
+					NamespaceId:   namespaceId,
+					SemaphoreName: "test_semaphore",
+					Weight:        1,
+					LeaseId:       lease2.Id.LeaseId,
+					WaitUntil:     base.Add(5 * time.Second).UnixNano(),
+					WillRetry:     i < 5,
+				},
+				Now: base.Add(time.Duration(i) * time.Second).UnixNano(),
+			})
+			require.NoError(t, err)
+			require.Nil(t, resp.ApplicationError)
+			require.False(t, resp.Payload.Success)
+		}
+
+		buckets := getSemaphoreStats(t, core, namespaceId, "test_semaphore", 60, base.Add(30*time.Second))
+		require.Len(t, buckets, 2)
+		require.EqualValues(t, 1, buckets[1].AcquiresSucceeded)
+		require.EqualValues(t, 1, buckets[1].AcquiresFailed)
+	})
+
+	t.Run("holds carry over quiet minutes", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		namespaceId, _ := newSemaphore(t, core, 5)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", base, time.Hour)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 3, base.Add(10*time.Second))
+		require.True(t, success)
+
+		buckets := getSemaphoreStats(t, core, namespaceId, "test_semaphore", 180, base.Add(150*time.Second))
+		require.Len(t, buckets, 4)
+		require.InDelta(t, 0.0, buckets[0].AverageActiveHolds, 0.0001)
+		require.InDelta(t, 2.5, buckets[1].AverageActiveHolds, 0.0001)
+		require.EqualValues(t, 3, buckets[2].MaxActiveHolds)
+		require.InDelta(t, 3.0, buckets[2].AverageActiveHolds, 0.0001)
+		require.InDelta(t, 3.0, buckets[3].AverageActiveHolds, 0.0001)
+
+		// Long after the last change the semaphore is at its current holds throughout
+		buckets = getSemaphoreStats(t, core, namespaceId, "test_semaphore", 60, base.Add(time.Hour))
+		require.Len(t, buckets, 2)
+		require.EqualValues(t, 3, buckets[0].MaxActiveHolds)
+		require.EqualValues(t, 3, buckets[1].MaxActiveHolds)
+	})
+
+	t.Run("expired holders are recorded when pruned", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		namespaceId, _ := newSemaphore(t, core, 5)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", base, 30*time.Second)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 4, base)
+		require.True(t, success)
+
+		runSemaphoresGarbageCollection(t, core, base.Add(45*time.Second))
+
+		buckets := getSemaphoreStats(t, core, namespaceId, "test_semaphore", 60, base.Add(90*time.Second))
+		require.Len(t, buckets, 2)
+		require.EqualValues(t, 4, buckets[0].MaxActiveHolds)
+		require.InDelta(t, 3.0, buckets[0].AverageActiveHolds, 0.0001)
+		require.EqualValues(t, 0, buckets[1].MaxActiveHolds)
+	})
+
+	t.Run("GC trims old buckets", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+		namespaceId, _ := newSemaphore(t, core, 5)
+
+		lease := createLease(t, core, namespaceId.AccountId, namespaceId.NamespaceId, "process_1", base, 48*time.Hour)
+		success, _ := acquireSemaphore(t, core, namespaceId, lease.Id, "test_semaphore", 1, base)
+		require.True(t, success)
+
+		countBuckets := func() int {
+			count := 0
+			err := core.stats.ListByStart(core.badgerStore.View(), 0, math.MaxInt64, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+				count++
+				return true, nil
+			})
+			require.NoError(t, err)
+			return count
+		}
+
+		runSemaphoresGarbageCollection(t, core, base.Add(24*time.Hour))
+		require.Equal(t, 1, countBuckets())
+
+		runSemaphoresGarbageCollection(t, core, base.Add(25*time.Hour))
+		require.Equal(t, 0, countBuckets())
+	})
+
+	t.Run("semaphore not found", func(t *testing.T) {
+		core := newSemaphoresCore(t)
+
+		resp, err := core.GetSemaphoreStats(&coreapis.GetSemaphoreStatsRequest{
+			Payload: &corepb.GetSemaphoreStatsRequest{
+				NamespaceId: &corepb.NamespaceId{
+					AccountId:   rand.Uint64(),
+					NamespaceId: rand.Uint64(),
+				},
+				SemaphoreName: "test_semaphore",
+				WindowSeconds: 60,
+			},
+			Now: base.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+	})
+}
+
+func newSemaphoresCore(t *testing.T) *Core {
+	t.Helper()
+
+	store, err := store.NewBadgerInMemoryStore()
+	require.NoError(t, err)
+	return NewCore(store, []byte{0x1d, 0x36, 0x00, 0x00}, 0x00000000, 0xffffffff)
+}
+
+// listExpirationRecords returns the expiration timestamps of every expirationRecords row
+// that targets the given semaphore. The order matches the table's natural ordering
+// (ascending by timestamp). Used by tests to detect stale or duplicated index entries.
+func listExpirationRecords(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId) []int64 {
+	t.Helper()
+
+	txn := core.badgerStore.View()
+	defer txn.Discard()
+
+	var times []int64
+	err := core.expirationRecords.List(txn, 0, math.MaxInt64, func(record *corepb.SemaphoresExpirationRecord) (bool, error) {
+		if record.SemaphoreId.AccountId == semaphoreId.AccountId &&
+			record.SemaphoreId.NamespaceId == semaphoreId.NamespaceId &&
+			record.SemaphoreId.SemaphoreId == semaphoreId.SemaphoreId {
+			times = append(times, record.ExpiresAt)
+		}
+		return true, nil
+	})
+	require.NoError(t, err)
+	return times
+}
+
+func createLease(t *testing.T, core *Core, accountId uint64, namespaceId uint64, processId string, now time.Time, ttl time.Duration) *corepb.Lease {
+	t.Helper()
+
+	leaseId := rand.Uint64()
+	resp, err := core.CreateSemaphoreLease(&coreapis.CreateSemaphoreLeaseRequest{
+		Payload: &corepb.CreateSemaphoreLeaseRequest{
+			LeaseId: &corepb.LeaseId{
+				AccountId:   accountId,
+				NamespaceId: namespaceId,
+				LeaseId:     leaseId,
+			},
+			ProcessId:                  processId,
+			TtlSeconds:                 int64(ttl.Seconds()),
+			MaxNumberOfSemaphoreLeases: 100,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Lease)
+	require.EqualValues(t, now.UnixNano(), resp.Payload.Lease.CreatedAt)
+	require.EqualValues(t, now.Add(ttl).UnixNano(), resp.Payload.Lease.ExpiresAt)
+
+	return resp.Payload.Lease
+}
+
+func acquireSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, now time.Time) (bool, *corepb.Semaphore) {
+	t.Helper()
+
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			Weight:        weight,
+			LeaseId:       leaseId.LeaseId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+
+	return resp.Payload.Success, resp.Payload.Semaphore
+}
+
+// acquireSemaphoreResponse is like acquireSemaphore but returns the whole response, diagnostics
+// included.
+func acquireSemaphoreResponse(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, now time.Time) *corepb.AcquireSemaphoreResponse {
+	t.Helper()
+
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			Weight:        weight,
+			LeaseId:       leaseId.LeaseId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload
+}
+
+// acquireSemaphoreOrCreate is like acquireSemaphore but creates a missing semaphore with
+// defaultPermits, returning the whole response so callers can check application errors too.
+func acquireSemaphoreOrCreate(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, defaultPermits int64, deleteInactiveAfterSeconds int64, maxNumberOfSemaphoresPerNamespace int64, now time.Time) *coreapis.AcquireSemaphoreResponse {
+	t.Helper()
+
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
+			NamespaceId:                       namespaceId,
+			SemaphoreName:                     semaphoreName,
+			Weight:                            weight,
+			LeaseId:                           leaseId.LeaseId,
+			DefaultPermits:                    defaultPermits,
+			DefaultDeleteInactiveAfterSeconds: deleteInactiveAfterSeconds,
+			NewSemaphoreId:                    rand.Uint64(),
+			MaxNumberOfSemaphoresPerNamespace: maxNumberOfSemaphoresPerNamespace,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	return resp
+}
+
+// acquireSemaphoreWaiting is like acquireSemaphore for a caller that keeps retrying until waitUntil.
+func acquireSemaphoreWaiting(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, waitUntil time.Time, now time.Time) bool {
+	t.Helper()
+
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			Weight:        weight,
+			LeaseId:       leaseId.LeaseId,
+			WaitUntil:     waitUntil.UnixNano(),
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload.Success
+}
+
+func getSemaphoreWaitersCount(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) int64 {
+	t.Helper()
+
+	resp, err := core.GetSemaphoreByName(&coreapis.GetSemaphoreByNameRequest{
+		Payload: &corepb.GetSemaphoreByNameRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload.WaitersCount
+}
+
+func listSemaphoreWaiters(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *corepb.ListSemaphoreWaitersResponse {
+	t.Helper()
+
+	resp, err := core.ListSemaphoreWaiters(&coreapis.ListSemaphoreWaitersRequest{
+		Payload: &corepb.ListSemaphoreWaitersRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			Limit:         100,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload
+}
+
+func releaseSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, leaseId *corepb.LeaseId, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.ReleaseSemaphore(&coreapis.ReleaseSemaphoreRequest{
+		Payload: &corepb.ReleaseSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			LeaseId:       leaseId.LeaseId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+
+	return resp.Payload.Semaphore
+}
+
+func releaseSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, leaseId *corepb.LeaseId, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.ReleaseSemaphore(&coreapis.ReleaseSemaphoreRequest{
+		Payload: &corepb.ReleaseSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			LeaseId:       leaseId.LeaseId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+
+	return resp.ApplicationError
+}
+
+func adjustSemaphoreHold(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, now time.Time) (bool, *corepb.Semaphore, *corepb.SemaphoreHolder) {
+	t.Helper()
+
+	resp, err := core.AdjustSemaphoreHold(&coreapis.AdjustSemaphoreHoldRequest{
+		Payload: &corepb.AdjustSemaphoreHoldRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			LeaseId:       leaseId.LeaseId,
+			Weight:        weight,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+	require.NotNil(t, resp.Payload.Holder)
+
+	return resp.Payload.Success, resp.Payload.Semaphore, resp.Payload.Holder
+}
+
+func adjustSemaphoreHoldWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.AdjustSemaphoreHold(&coreapis.AdjustSemaphoreHoldRequest{
+		Payload: &corepb.AdjustSemaphoreHoldRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			LeaseId:       leaseId.LeaseId,
+			Weight:        weight,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+
+	return resp.ApplicationError
+}
+
+func createSemaphore(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
+		Payload: &corepb.CreateSemaphoreRequest{
+			SemaphoreId:                       semaphoreId,
+			Name:                              semaphoreName,
+			Description:                       "test description",
+			Permits:                           permits,
+			MaxNumberOfSemaphoresPerNamespace: 10000,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+	require.Equal(t, "test description", resp.Payload.Semaphore.Description)
 	require.EqualValues(t, permits, resp.Payload.Semaphore.Permits)
 	require.Equal(t, semaphoreName, resp.Payload.Semaphore.Name)
 	require.Equal(t, semaphoreId, resp.Payload.Semaphore.Id)
 	require.EqualValues(t, now.UnixNano(), resp.Payload.Semaphore.CreatedAt)
 	require.EqualValues(t, now.UnixNano(), resp.Payload.Semaphore.UpdatedAt)
 
-	return resp.Payload.Semaphore
+	return resp.Payload.Semaphore
+}
+
+func createSemaphoreWithError(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, maxNumberOfSemaphoresPerNamespace int64, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
+		Payload: &corepb.CreateSemaphoreRequest{
+			SemaphoreId:                       semaphoreId,
+			Name:                              semaphoreName,
+			Description:                       "test description",
+			Permits:                           permits,
+			MaxNumberOfSemaphoresPerNamespace: maxNumberOfSemaphoresPerNamespace,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+
+	return resp.ApplicationError
+}
+
+func getSemaphore(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.GetSemaphore(&coreapis.GetSemaphoreRequest{
+		Payload: &corepb.GetSemaphoreRequest{
+			SemaphoreId: semaphoreId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+
+	return resp.Payload.Semaphore
+}
+
+func getSemaphoreWithError(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.GetSemaphore(&coreapis.GetSemaphoreRequest{
+		Payload: &corepb.GetSemaphoreRequest{
+			SemaphoreId: semaphoreId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+
+	return resp.ApplicationError
+}
+
+func getSemaphoreByName(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.GetSemaphoreByName(&coreapis.GetSemaphoreByNameRequest{
+		Payload: &corepb.GetSemaphoreByNameRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+
+	return resp.Payload.Semaphore
+}
+
+func getSemaphoreByNameWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.GetSemaphoreByName(&coreapis.GetSemaphoreByNameRequest{
+		Payload: &corepb.GetSemaphoreByNameRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+
+	return resp.ApplicationError
+}
+
+func acquireSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			Weight:        weight,
+			LeaseId:       leaseId.LeaseId,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+
+	return resp.ApplicationError
+}
+
+func listSemaphoreHolders(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *corepb.ListSemaphoreHoldersResponse {
+	t.Helper()
+
+	resp, err := core.ListSemaphoreHolders(&coreapis.ListSemaphoreHoldersRequest{
+		Payload: &corepb.ListSemaphoreHoldersRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			Limit:         100,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload
+}
+
+func updateSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, description string, permits int64, version int64, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.UpdateSemaphore(&coreapis.UpdateSemaphoreRequest{
+		Payload: &corepb.UpdateSemaphoreRequest{
+			NamespaceId:     namespaceId,
+			SemaphoreName:   semaphoreName,
+			Description:     description,
+			Permits:         permits,
+			ExpectedVersion: version,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+
+	return resp.Payload.Semaphore
+}
+
+func updateSemaphoreWithInactivity(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, permits int64, deleteInactiveAfterSeconds int64, version int64, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.UpdateSemaphore(&coreapis.UpdateSemaphoreRequest{
+		Payload: &corepb.UpdateSemaphoreRequest{
+			NamespaceId:                namespaceId,
+			SemaphoreName:              semaphoreName,
+			Permits:                    permits,
+			ExpectedVersion:            version,
+			DeleteInactiveAfterSeconds: deleteInactiveAfterSeconds,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+
+	return resp.Payload.Semaphore
+}
+
+func drainSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, permits int64, version int64, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.UpdateSemaphore(&coreapis.UpdateSemaphoreRequest{
+		Payload: &corepb.UpdateSemaphoreRequest{
+			NamespaceId:     namespaceId,
+			SemaphoreName:   semaphoreName,
+			Description:     "draining",
+			Permits:         permits,
+			ExpectedVersion: version,
+			AllowDraining:   true,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+
+	return resp.Payload.Semaphore
+}
+
+func updateSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, description string, permits int64, version int64, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.UpdateSemaphore(&coreapis.UpdateSemaphoreRequest{
+		Payload: &corepb.UpdateSemaphoreRequest{
+			NamespaceId:     namespaceId,
+			SemaphoreName:   semaphoreName,
+			Description:     description,
+			Permits:         permits,
+			ExpectedVersion: version,
+		},
+		Now: now.UnixNano(),
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+
+	return resp.ApplicationError
+}
+
+func createSemaphoreWithInactivity(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, deleteInactiveAfterSeconds int64, now time.Time) *corepb.Semaphore {
+	t.Helper()
+
+	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
+		Payload: &corepb.CreateSemaphoreRequest{
+			SemaphoreId:                       semaphoreId,
+			Name:                              semaphoreName,
+			Description:                       "test description",
+			Permits:                           permits,
+			MaxNumberOfSemaphoresPerNamespace: 10000,
+			DeleteInactiveAfterSeconds:        deleteInactiveAfterSeconds,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+	return resp.Payload.Semaphore
+}
+
+func getSemaphoreStats(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, windowSeconds int64, now time.Time) []*corepb.SemaphoreStatsBucket {
+	t.Helper()
+
+	resp, err := core.GetSemaphoreStats(&coreapis.GetSemaphoreStatsRequest{
+		Payload: &corepb.GetSemaphoreStatsRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			WindowSeconds: windowSeconds,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	return resp.Payload.Buckets
+}
+
+func runSemaphoresGarbageCollection(t *testing.T, core *Core, now time.Time) {
+	t.Helper()
+
+	_, err := core.RunSemaphoresGarbageCollection(&coreapis.RunSemaphoresGarbageCollectionRequest{
+		Payload: &corepb.RunSemaphoresGarbageCollectionRequest{
+			GcRecordsPageSize:          100,
+			GcRecordSemaphoresPageSize: 100,
+			GcRecordHoldersPageSize:    100,
+			MaxVisited:                 100,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
 }
 
-func createSemaphoreWithError(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, maxNumberOfSemaphoresPerNamespace int64, now time.Time) *mrpc.Error {
+func createSemaphoreWithMax(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, maxNumberOfSemaphoresPerNamespace int64, now time.Time) *corepb.Semaphore {
 	t.Helper()
 
 	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
@@ -3941,21 +5913,96 @@ func createSemaphoreWithError(t *testing.T, core *Core, semaphoreId *corepb.Sema
 		},
 		Now: now.UnixNano(),
 	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
+	return resp.Payload.Semaphore
+}
+
+func createLeaseWithMax(t *testing.T, core *Core, accountId uint64, namespaceId uint64, processId string, now time.Time, ttl time.Duration, maxNumberOfSemaphoreLeases int64) *corepb.Lease {
+	t.Helper()
 
+	resp, err := core.CreateSemaphoreLease(&coreapis.CreateSemaphoreLeaseRequest{
+		Payload: &corepb.CreateSemaphoreLeaseRequest{
+			LeaseId: &corepb.LeaseId{
+				AccountId:   accountId,
+				NamespaceId: namespaceId,
+				LeaseId:     rand.Uint64(),
+			},
+			ProcessId:                  processId,
+			TtlSeconds:                 int64(ttl.Seconds()),
+			MaxNumberOfSemaphoreLeases: maxNumberOfSemaphoreLeases,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Lease)
+	require.EqualValues(t, now.UnixNano(), resp.Payload.Lease.CreatedAt)
+	require.EqualValues(t, now.Add(ttl).UnixNano(), resp.Payload.Lease.ExpiresAt)
+	return resp.Payload.Lease
+}
+
+func createLeaseWithError(t *testing.T, core *Core, accountId uint64, namespaceId uint64, processId string, now time.Time, ttl time.Duration, maxNumberOfSemaphoreLeases int64) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.CreateSemaphoreLease(&coreapis.CreateSemaphoreLeaseRequest{
+		Payload: &corepb.CreateSemaphoreLeaseRequest{
+			LeaseId: &corepb.LeaseId{
+				AccountId:   accountId,
+				NamespaceId: namespaceId,
+				LeaseId:     rand.Uint64(),
+			},
+			ProcessId:                  processId,
+			TtlSeconds:                 int64(ttl.Seconds()),
+			MaxNumberOfSemaphoreLeases: maxNumberOfSemaphoreLeases,
+		},
+		Now: now.UnixNano(),
+	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.NotNil(t, resp.ApplicationError)
 	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+	return resp.ApplicationError
+}
 
+func refreshSemaphoreLeaseWithError(t *testing.T, core *Core, leaseId *corepb.LeaseId, ttlSeconds int64, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.RefreshSemaphoreLease(&coreapis.RefreshSemaphoreLeaseRequest{
+		Payload: &corepb.RefreshSemaphoreLeaseRequest{
+			LeaseId:    leaseId,
+			TtlSeconds: ttlSeconds,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
 	return resp.ApplicationError
 }
 
-func getSemaphore(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, now time.Time) *corepb.Semaphore {
+// TestCore_SplitSnapshotRestore proves the portable, bounds-filtered snapshot
+// contract on the semaphores core: a parent core's snapshot is restored into
+// two child cores with disjoint bounds (sharing ONE Badger store with the
+// parent), and each child ends up with exactly its half — with every
+// secondary index (names, lease id, holder expiration, lease process
+// id/expiration) rebuilt and functional.
+func createChildSemaphore(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, parentSemaphoreName string, now time.Time) *corepb.Semaphore {
 	t.Helper()
 
-	resp, err := core.GetSemaphore(&coreapis.GetSemaphoreRequest{
-		Payload: &corepb.GetSemaphoreRequest{
-			SemaphoreId: semaphoreId,
+	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
+		Payload: &corepb.CreateSemaphoreRequest{
+			SemaphoreId:                       semaphoreId,
+			Name:                              semaphoreName,
+			Permits:                           permits,
+			MaxNumberOfSemaphoresPerNamespace: 10000,
+			ParentSemaphoreName:               parentSemaphoreName,
 		},
 		Now: now.UnixNano(),
 	})
@@ -3964,17 +6011,21 @@ func getSemaphore(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, now
 	require.NotNil(t, resp)
 	require.Nil(t, resp.ApplicationError)
 	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.Semaphore)
+	require.Equal(t, parentSemaphoreName, resp.Payload.Semaphore.ParentSemaphoreName)
 
 	return resp.Payload.Semaphore
 }
 
-func getSemaphoreWithError(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, now time.Time) *mrpc.Error {
+func createChildSemaphoreWithError(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, parentSemaphoreName string, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.GetSemaphore(&coreapis.GetSemaphoreRequest{
-		Payload: &corepb.GetSemaphoreRequest{
-			SemaphoreId: semaphoreId,
+	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
+		Payload: &corepb.CreateSemaphoreRequest{
+			SemaphoreId:                       semaphoreId,
+			Name:                              semaphoreName,
+			Permits:                           permits,
+			MaxNumberOfSemaphoresPerNamespace: 10000,
+			ParentSemaphoreName:               parentSemaphoreName,
 		},
 		Now: now.UnixNano(),
 	})
@@ -3987,13 +6038,16 @@ func getSemaphoreWithError(t *testing.T, core *Core, semaphoreId *corepb.Semapho
 	return resp.ApplicationError
 }
 
-func getSemaphoreByName(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *corepb.Semaphore {
+func createSemaphoreWithClasses(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, classes []*corepb.SemaphoreClassSpec, now time.Time) *corepb.Semaphore {
 	t.Helper()
 
-	resp, err := core.GetSemaphoreByName(&coreapis.GetSemaphoreByNameRequest{
-		Payload: &corepb.GetSemaphoreByNameRequest{
-			NamespaceId:   namespaceId,
-			SemaphoreName: semaphoreName,
+	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
+		Payload: &corepb.CreateSemaphoreRequest{
+			SemaphoreId:                       semaphoreId,
+			Name:                              semaphoreName,
+			Permits:                           permits,
+			MaxNumberOfSemaphoresPerNamespace: 10000,
+			Classes:                           classes,
 		},
 		Now: now.UnixNano(),
 	})
@@ -4002,18 +6056,20 @@ func getSemaphoreByName(t *testing.T, core *Core, namespaceId *corepb.NamespaceI
 	require.NotNil(t, resp)
 	require.Nil(t, resp.ApplicationError)
 	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.Semaphore)
 
 	return resp.Payload.Semaphore
 }
 
-func getSemaphoreByNameWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *mrpc.Error {
+func createSemaphoreWithClassesError(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, classes []*corepb.SemaphoreClassSpec, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.GetSemaphoreByName(&coreapis.GetSemaphoreByNameRequest{
-		Payload: &corepb.GetSemaphoreByNameRequest{
-			NamespaceId:   namespaceId,
-			SemaphoreName: semaphoreName,
+	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
+		Payload: &corepb.CreateSemaphoreRequest{
+			SemaphoreId:                       semaphoreId,
+			Name:                              semaphoreName,
+			Permits:                           permits,
+			MaxNumberOfSemaphoresPerNamespace: 10000,
+			Classes:                           classes,
 		},
 		Now: now.UnixNano(),
 	})
@@ -4026,7 +6082,7 @@ func getSemaphoreByNameWithError(t *testing.T, core *Core, namespaceId *corepb.N
 	return resp.ApplicationError
 }
 
-func acquireSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, now time.Time) *mrpc.Error {
+func acquireSemaphoreInClass(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, class string, weight int64, now time.Time) (bool, *corepb.Semaphore) {
 	t.Helper()
 
 	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
@@ -4035,176 +6091,162 @@ func acquireSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.Nam
 			SemaphoreName: semaphoreName,
 			Weight:        weight,
 			LeaseId:       leaseId.LeaseId,
+			Class:         class,
 		},
 		Now: now.UnixNano(),
 	})
 
 	require.NoError(t, err)
-	require.NotNil(t, resp)
-	require.Nil(t, resp.Payload)
-	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.Semaphore)
 
-	return resp.ApplicationError
+	return resp.Payload.Success, resp.Payload.Semaphore
 }
 
-func listSemaphoreHolders(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *corepb.ListSemaphoreHoldersResponse {
+func acquireSemaphoreInClassWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, class string, weight int64, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.ListSemaphoreHolders(&coreapis.ListSemaphoreHoldersRequest{
-		Payload: &corepb.ListSemaphoreHoldersRequest{
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
 			NamespaceId:   namespaceId,
 			SemaphoreName: semaphoreName,
-			Limit:         100,
+			Weight:        weight,
+			LeaseId:       leaseId.LeaseId,
+			Class:         class,
 		},
 		Now: now.UnixNano(),
 	})
 
 	require.NoError(t, err)
-	require.NotNil(t, resp)
-	require.Nil(t, resp.ApplicationError)
-	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
 
-	return resp.Payload
+	return resp.ApplicationError
 }
 
-func updateSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, description string, permits int64, version int64, now time.Time) *corepb.Semaphore {
+func transferSemaphoreHold(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, fromLeaseId *corepb.LeaseId, toLeaseId *corepb.LeaseId, now time.Time) (*corepb.Semaphore, *corepb.SemaphoreHolder) {
 	t.Helper()
 
-	resp, err := core.UpdateSemaphore(&coreapis.UpdateSemaphoreRequest{
-		Payload: &corepb.UpdateSemaphoreRequest{
-			NamespaceId:     namespaceId,
-			SemaphoreName:   semaphoreName,
-			Description:     description,
-			Permits:         permits,
-			ExpectedVersion: version,
+	resp, err := core.TransferSemaphoreHold(&coreapis.TransferSemaphoreHoldRequest{
+		Payload: &corepb.TransferSemaphoreHoldRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			FromLeaseId:   fromLeaseId.LeaseId,
+			ToLeaseId:     toLeaseId.LeaseId,
 		},
 		Now: now.UnixNano(),
 	})
 
 	require.NoError(t, err)
-	require.NotNil(t, resp)
 	require.Nil(t, resp.ApplicationError)
 	require.NotNil(t, resp.Payload)
 	require.NotNil(t, resp.Payload.Semaphore)
+	require.NotNil(t, resp.Payload.Holder)
 
-	return resp.Payload.Semaphore
+	return resp.Payload.Semaphore, resp.Payload.Holder
 }
 
-func updateSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, description string, permits int64, version int64, now time.Time) *mrpc.Error {
+func transferSemaphoreHoldWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, fromLeaseId *corepb.LeaseId, toLeaseId *corepb.LeaseId, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.UpdateSemaphore(&coreapis.UpdateSemaphoreRequest{
-		Payload: &corepb.UpdateSemaphoreRequest{
-			NamespaceId:     namespaceId,
-			SemaphoreName:   semaphoreName,
-			Description:     description,
-			Permits:         permits,
-			ExpectedVersion: version,
+	resp, err := core.TransferSemaphoreHold(&coreapis.TransferSemaphoreHoldRequest{
+		Payload: &corepb.TransferSemaphoreHoldRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			FromLeaseId:   fromLeaseId.LeaseId,
+			ToLeaseId:     toLeaseId.LeaseId,
 		},
 		Now: now.UnixNano(),
 	})
 
 	require.NoError(t, err)
-	require.NotNil(t, resp)
-	require.Nil(t, resp.Payload)
 	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
 
 	return resp.ApplicationError
 }
 
-func createSemaphoreWithMax(t *testing.T, core *Core, semaphoreId *corepb.SemaphoreId, semaphoreName string, permits int64, maxNumberOfSemaphoresPerNamespace int64, now time.Time) *corepb.Semaphore {
+func acquireSemaphoreWithMaxHold(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, maxHoldSeconds int64, now time.Time) (bool, *corepb.Semaphore) {
 	t.Helper()
 
-	resp, err := core.CreateSemaphore(&coreapis.CreateSemaphoreRequest{
-		Payload: &corepb.CreateSemaphoreRequest{
-			SemaphoreId:                       semaphoreId,
-			Name:                              semaphoreName,
-			Description:                       "test description",
-			Permits:                           permits,
-			MaxNumberOfSemaphoresPerNamespace: maxNumberOfSemaphoresPerNamespace,
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
+			NamespaceId:    namespaceId,
+			SemaphoreName:  semaphoreName,
+			Weight:         weight,
+			LeaseId:        leaseId.LeaseId,
+			MaxHoldSeconds: maxHoldSeconds,
 		},
 		Now: now.UnixNano(),
 	})
+
 	require.NoError(t, err)
-	require.NotNil(t, resp)
 	require.Nil(t, resp.ApplicationError)
 	require.NotNil(t, resp.Payload)
 	require.NotNil(t, resp.Payload.Semaphore)
-	return resp.Payload.Semaphore
+
+	return resp.Payload.Success, resp.Payload.Semaphore
 }
 
-func createLeaseWithMax(t *testing.T, core *Core, accountId uint64, namespaceId uint64, processId string, now time.Time, ttl time.Duration, maxNumberOfSemaphoreLeases int64) *corepb.Lease {
+func acquireSemaphoreWithMaxHoldError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, leaseId *corepb.LeaseId, semaphoreName string, weight int64, maxHoldSeconds int64, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.CreateSemaphoreLease(&coreapis.CreateSemaphoreLeaseRequest{
-		Payload: &corepb.CreateSemaphoreLeaseRequest{
-			LeaseId: &corepb.LeaseId{
-				AccountId:   accountId,
-				NamespaceId: namespaceId,
-				LeaseId:     rand.Uint64(),
-			},
-			ProcessId:                  processId,
-			TtlSeconds:                 int64(ttl.Seconds()),
-			MaxNumberOfSemaphoreLeases: maxNumberOfSemaphoreLeases,
+	resp, err := core.AcquireSemaphore(&coreapis.AcquireSemaphoreRequest{
+		Payload: &corepb.AcquireSemaphoreRequest{
+			NamespaceId:    namespaceId,
+			SemaphoreName:  semaphoreName,
+			Weight:         weight,
+			LeaseId:        leaseId.LeaseId,
+			MaxHoldSeconds: maxHoldSeconds,
 		},
 		Now: now.UnixNano(),
 	})
+
 	require.NoError(t, err)
-	require.NotNil(t, resp)
-	require.Nil(t, resp.ApplicationError)
-	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.Lease)
-	require.EqualValues(t, now.UnixNano(), resp.Payload.Lease.CreatedAt)
-	require.EqualValues(t, now.Add(ttl).UnixNano(), resp.Payload.Lease.ExpiresAt)
-	return resp.Payload.Lease
+	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+
+	return resp.ApplicationError
 }
 
-func createLeaseWithError(t *testing.T, core *Core, accountId uint64, namespaceId uint64, processId string, now time.Time, ttl time.Duration, maxNumberOfSemaphoreLeases int64) *mrpc.Error {
+func deleteSemaphore(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) {
 	t.Helper()
 
-	resp, err := core.CreateSemaphoreLease(&coreapis.CreateSemaphoreLeaseRequest{
-		Payload: &corepb.CreateSemaphoreLeaseRequest{
-			LeaseId: &corepb.LeaseId{
-				AccountId:   accountId,
-				NamespaceId: namespaceId,
-				LeaseId:     rand.Uint64(),
-			},
-			ProcessId:                  processId,
-			TtlSeconds:                 int64(ttl.Seconds()),
-			MaxNumberOfSemaphoreLeases: maxNumberOfSemaphoreLeases,
+	resp, err := core.DeleteSemaphore(&coreapis.DeleteSemaphoreRequest{
+		Payload: &corepb.DeleteSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			RecordId:      rand.Uint64(),
 		},
 		Now: now.UnixNano(),
 	})
+
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.Nil(t, resp.Payload)
-	require.NotNil(t, resp.ApplicationError)
-	return resp.ApplicationError
+	require.Nil(t, resp.ApplicationError)
 }
 
-func refreshSemaphoreLeaseWithError(t *testing.T, core *Core, leaseId *corepb.LeaseId, ttlSeconds int64, now time.Time) *mrpc.Error {
+func deleteSemaphoreWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, semaphoreName string, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.RefreshSemaphoreLease(&coreapis.RefreshSemaphoreLeaseRequest{
-		Payload: &corepb.RefreshSemaphoreLeaseRequest{
-			LeaseId:    leaseId,
-			TtlSeconds: ttlSeconds,
+	resp, err := core.DeleteSemaphore(&coreapis.DeleteSemaphoreRequest{
+		Payload: &corepb.DeleteSemaphoreRequest{
+			NamespaceId:   namespaceId,
+			SemaphoreName: semaphoreName,
+			RecordId:      rand.Uint64(),
 		},
 		Now: now.UnixNano(),
 	})
+
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.Nil(t, resp.Payload)
 	require.NotNil(t, resp.ApplicationError)
+	require.Nil(t, resp.Payload)
+
 	return resp.ApplicationError
 }
 
-// TestCore_SplitSnapshotRestore proves the portable, bounds-filtered snapshot
-// contract on the semaphores core: a parent core's snapshot is restored into
-// two child cores with disjoint bounds (sharing ONE Badger store with the
-// parent), and each child ends up with exactly its half — with every
-// secondary index (names, lease id, holder expiration, lease process
-// id/expiration) rebuilt and functional.
 func TestCore_SplitSnapshotRestore(t *testing.T) {
 	now := time.Now()
 	badgerStore, err := store.NewBadgerInMemoryStore()
@@ -27,7 +27,7 @@ import (
 // 3. semaphore id
 //
 // Expiration Index Sort Key:
-// 1. expiration time
+// 1. expiration time (see holderExpiresAt)
 // 2. lease id
 //
 // Lease Id Index Primary Key:
@@ -126,7 +126,7 @@ func (t *holdersTable) Create(txn *store.Txn, holder *corepb.SemaphoreHolder) er
 	err := t.expirationIndex.Add(txn,
 		utils.ConcatBytes(
 			t.expirationIndexPK(holder.Id.AccountId, holder.Id.NamespaceId, holder.Id.SemaphoreId),
-			t.expirationIndexSK(holder.ExpiresAt, holder.Id.LeaseId)),
+			t.expirationIndexSK(holderExpiresAt(holder), holder.Id.LeaseId)),
 	)
 	if err != nil {
 		return err
@@ -156,15 +156,15 @@ func (t *holdersTable) Update(txn *store.Txn, updatedHolder *corepb.SemaphoreHol
 		return err
 	}
 
-	if existingHolder.ExpiresAt != updatedHolder.ExpiresAt {
+	if holderExpiresAt(existingHolder) != holderExpiresAt(updatedHolder) {
 		indexPK := t.expirationIndexPK(updatedHolder.Id.AccountId, updatedHolder.Id.NamespaceId, updatedHolder.Id.SemaphoreId)
 
-		err = t.expirationIndex.Delete(txn, utils.ConcatBytes(indexPK, t.expirationIndexSK(existingHolder.ExpiresAt, updatedHolder.Id.LeaseId)))
+		err = t.expirationIndex.Delete(txn, utils.ConcatBytes(indexPK, t.expirationIndexSK(holderExpiresAt(existingHolder), updatedHolder.Id.LeaseId)))
 		if err != nil {
 			return err
 		}
 
-		err = t.expirationIndex.Add(txn, utils.ConcatBytes(indexPK, t.expirationIndexSK(updatedHolder.ExpiresAt, updatedHolder.Id.LeaseId)))
+		err = t.expirationIndex.Add(txn, utils.ConcatBytes(indexPK, t.expirationIndexSK(holderExpiresAt(updatedHolder), updatedHolder.Id.LeaseId)))
 		if err != nil {
 			return err
 		}
@@ -177,7 +177,7 @@ func (t *holdersTable) Delete(txn *store.Txn, holder *corepb.SemaphoreHolder) er
 	err := t.expirationIndex.Delete(txn,
 		utils.ConcatBytes(
 			t.expirationIndexPK(holder.Id.AccountId, holder.Id.NamespaceId, holder.Id.SemaphoreId),
-			t.expirationIndexSK(holder.ExpiresAt, holder.Id.LeaseId)))
+			t.expirationIndexSK(holderExpiresAt(holder), holder.Id.LeaseId)))
 	if err != nil {
 		return err
 	}
@@ -278,3 +278,12 @@ func (t *holdersTable) expirationIndexSKPrefix(expirationTime int64) []byte {
 		expirationTime,
 	)
 }
+
+// holderExpiresAt returns when the holder stops counting toward the semaphore: the lease's
+// expiration, or the max hold time of the direct hold if that comes first.
+func holderExpiresAt(holder *corepb.SemaphoreHolder) int64 {
+	if holder.HoldExpiresAt != 0 && holder.HoldExpiresAt < holder.ExpiresAt {
+		return holder.HoldExpiresAt
+	}
+	return holder.ExpiresAt
+}
@@ -3,6 +3,7 @@ package semaphores
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	mrpc "github.com/evrblk/monstera/rpc"
 	"github.com/evrblk/monstera/store"
@@ -15,7 +16,8 @@ import (
 	"github.com/evrblk/grackle/pkg/tables"
 )
 
-// semaphoresTable is a table of semaphores indexed by semaphore ID and semaphore name.
+// semaphoresTable is a table of semaphores indexed by semaphore ID, by semaphore name and by
+// auto-deletion time.
 //
 // Table Primary Key:
 // 1. account id
@@ -28,9 +30,16 @@ import (
 // 1. account id
 // 2. namespace id
 // 3. semaphore name
+//
+// Deletion Index Primary Key (only idle semaphores, see deletionTime):
+// 1. deletion time
+// 2. account id
+// 3. namespace id
+// 4. semaphore id
 type semaphoresTable struct {
-	table      *honey.BinaryTable[*corepb.Semaphore, corepb.Semaphore]
-	namesIndex *honey.Uint64Table
+	table         *honey.BinaryTable[*corepb.Semaphore, corepb.Semaphore]
+	namesIndex    *honey.Uint64Table
+	deletionIndex *honey.BinaryTable[*corepb.SemaphoresDeletionRecord, corepb.SemaphoresDeletionRecord]
 }
 
 // newSemaphoresTable scopes both tables under the shard-unique prefix
@@ -46,13 +55,16 @@ func newSemaphoresTable(replicaPrefix []byte) *semaphoresTable {
 		namesIndex: honey.NewUint64Table(
 			utils.ConcatBytes(replicaPrefix, tablePrefixSemaphoresNamesIndex),
 		),
+		deletionIndex: honey.NewBinaryTable[*corepb.SemaphoresDeletionRecord, corepb.SemaphoresDeletionRecord](
+			utils.ConcatBytes(replicaPrefix, tablePrefixSemaphoresDeletionIndex),
+		),
 	}
 }
 
-// Clear deletes every row this table owns: the primary semaphore rows and
-// the names index.
+// Clear deletes every row this table owns: the primary semaphore rows, the
+// names index and the deletion index.
 func (t *semaphoresTable) Clear(badgerStore *store.BadgerStore) error {
-	for _, prefix := range [][]byte{t.table.TableId(), t.namesIndex.TableId()} {
+	for _, prefix := range [][]byte{t.table.TableId(), t.namesIndex.TableId(), t.deletionIndex.TableId()} {
 		if err := badgerStore.DeletePrefix(prefix); err != nil {
 			return err
 		}
@@ -61,8 +73,8 @@ func (t *semaphoresTable) Clear(badgerStore *store.BadgerStore) error {
 }
 
 // EachEntity streams every semaphore as (canonical key, stored value) — the
-// primary table only; the names index is rebuilt from the semaphores on
-// restore.
+// primary table only; the names and deletion indexes are rebuilt from the
+// semaphores on restore.
 func (t *semaphoresTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
 	return t.table.EachEntry(txn, fn)
 }
@@ -70,7 +82,7 @@ func (t *semaphoresTable) EachEntity(txn *store.Txn, fn func(key []byte, value [
 // RestoreEntity decodes one streamed semaphore and, if owned, inserts it
 // directly (bypassing Create's uniqueness gates — the stream is
 // authoritative), rebuilding the names index from the semaphore's own
-// identity fields.
+// identity fields and the deletion index through Update.
 func (t *semaphoresTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
 	semaphore := &corepb.Semaphore{}
 	if err := semaphore.UnmarshalBinary(value); err != nil {
@@ -105,7 +117,28 @@ func (t *semaphoresTable) GetByName(txn *store.Txn, accountId uint64, namespaceI
 	})
 }
 
+// Update stores the semaphore and moves its deletion index entry along with it, so every change
+// of activity, holders or children reschedules (or cancels) its auto-deletion.
 func (t *semaphoresTable) Update(txn *store.Txn, semaphore *corepb.Semaphore) error {
+	existingSemaphore, err := t.Get(txn, semaphore.Id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	if existingSemaphore != nil {
+		if deleteAt := deletionTime(existingSemaphore); deleteAt != 0 && deleteAt != deletionTime(semaphore) {
+			err = t.deletionIndex.Delete(txn, t.deletionIndexPK(deleteAt, existingSemaphore.Id))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = t.setDeletion(txn, semaphore)
+	if err != nil {
+		return err
+	}
+
 	return t.table.Set(txn,
 		utils.ConcatBytes(
 			t.tablePK(semaphore.Id.AccountId, semaphore.Id.NamespaceId),
@@ -127,6 +160,13 @@ func (t *semaphoresTable) Delete(txn *store.Txn, semaphoreId *corepb.SemaphoreId
 		return err
 	}
 
+	if deleteAt := deletionTime(semaphore); deleteAt != 0 {
+		err = t.deletionIndex.Delete(txn, t.deletionIndexPK(deleteAt, semaphore.Id))
+		if err != nil {
+			return err
+		}
+	}
+
 	return t.table.Delete(txn,
 		utils.ConcatBytes(
 			t.tablePK(semaphoreId.AccountId, semaphoreId.NamespaceId),
@@ -172,6 +212,11 @@ func (t *semaphoresTable) Create(txn *store.Txn, semaphore *corepb.Semaphore) (*
 		return nil, err
 	}
 
+	err = t.setDeletion(txn, semaphore)
+	if err != nil {
+		return nil, err
+	}
+
 	return nil, t.table.Set(txn,
 		utils.ConcatBytes(
 			t.tablePK(semaphore.Id.AccountId, semaphore.Id.NamespaceId),
@@ -198,6 +243,33 @@ func (t *semaphoresTable) List(txn *store.Txn, accountId uint64, namespaceId uin
 	}, nil
 }
 
+// ListByDeletion calls fn for every idle semaphore due for deletion in [from, to), earliest
+// first, until fn returns false.
+func (t *semaphoresTable) ListByDeletion(txn *store.Txn, from int64, to int64, fn func(record *corepb.SemaphoresDeletionRecord) (bool, error)) error {
+	return t.deletionIndex.ListInRange(txn, t.deletionIndexPrefix(from), t.deletionIndexPrefix(to), false, fn)
+}
+
+func (t *semaphoresTable) setDeletion(txn *store.Txn, semaphore *corepb.Semaphore) error {
+	deleteAt := deletionTime(semaphore)
+	if deleteAt == 0 {
+		return nil
+	}
+
+	return t.deletionIndex.Set(txn, t.deletionIndexPK(deleteAt, semaphore.Id), &corepb.SemaphoresDeletionRecord{
+		SemaphoreId: semaphore.Id,
+		DeleteAt:    deleteAt,
+	})
+}
+
+// deletionTime returns when an idle semaphore is to be auto-deleted, or 0 while it is not idle:
+// while it has holders or children, or when it has no inactivity window at all.
+func deletionTime(semaphore *corepb.Semaphore) int64 {
+	if semaphore.DeleteInactiveAfterSeconds == 0 || semaphore.ActiveHoldersCount > 0 || semaphore.NumberOfChildren > 0 {
+		return 0
+	}
+	return semaphore.LastActivityAt + semaphore.DeleteInactiveAfterSeconds*int64(time.Second)
+}
+
 func (t *semaphoresTable) tablePK(accountId uint64, namespaceId uint64) []byte {
 	return utils.ConcatBytes(
 		accountId,
@@ -211,6 +283,21 @@ func (t *semaphoresTable) tableSK(semaphoreId uint64) []byte {
 	)
 }
 
+func (t *semaphoresTable) deletionIndexPK(deleteAt int64, semaphoreId *corepb.SemaphoreId) []byte {
+	return utils.ConcatBytes(
+		deleteAt,
+		semaphoreId.AccountId,
+		semaphoreId.NamespaceId,
+		semaphoreId.SemaphoreId,
+	)
+}
+
+func (t *semaphoresTable) deletionIndexPrefix(deleteAt int64) []byte {
+	return utils.ConcatBytes(
+		deleteAt,
+	)
+}
+
 func (t *semaphoresTable) namesIndexPK(accountId uint64, namespaceId uint64, semaphoreName string) []byte {
 	return utils.ConcatBytes(
 		accountId,
@@ -0,0 +1,229 @@
+package semaphores
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/sharding"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+const (
+	// statsBucketSize is the resolution of the semaphore utilization stats.
+	statsBucketSize = int64(time.Minute)
+
+	// statsRetention is how long GC keeps stats buckets: one day, plus the bucket a full day
+	// window starts in.
+	statsRetention = int64(24*time.Hour) + statsBucketSize
+)
+
+// statsTable stores per-minute utilization buckets of semaphores, indexed by semaphore id and by
+// bucket start time. Only buckets with activity are stored.
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. semaphore id
+//
+// Table Sort Key:
+// 1. bucket start time
+//
+// Buckets Index Primary Key:
+// 1. bucket start time
+// 2. account id
+// 3. namespace id
+// 4. semaphore id
+type statsTable struct {
+	table        *honey.BinaryTable[*corepb.SemaphoreStatsRecord, corepb.SemaphoreStatsRecord]
+	bucketsIndex *honey.BinaryTable[*corepb.SemaphoreStatsRecord, corepb.SemaphoreStatsRecord]
+}
+
+// newStatsTable scopes both tables under the shard-unique prefix; see newSemaphoresTable.
+func newStatsTable(replicaPrefix []byte) *statsTable {
+	return &statsTable{
+		table: honey.NewBinaryTable[*corepb.SemaphoreStatsRecord, corepb.SemaphoreStatsRecord](
+			utils.ConcatBytes(replicaPrefix, tablePrefixStats)),
+		bucketsIndex: honey.NewBinaryTable[*corepb.SemaphoreStatsRecord, corepb.SemaphoreStatsRecord](
+			utils.ConcatBytes(replicaPrefix, tablePrefixStatsBucketsIndex)),
+	}
+}
+
+// Clear deletes every row this table owns: the primary stats rows and the buckets index.
+func (t *statsTable) Clear(badgerStore *store.BadgerStore) error {
+	for _, prefix := range [][]byte{t.table.TableId(), t.bucketsIndex.TableId()} {
+		if err := badgerStore.DeletePrefix(prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachEntity streams every stats bucket as (canonical key, stored value) — the primary table
+// only; the buckets index is rebuilt from the buckets on restore.
+func (t *statsTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed stats bucket and, if owned, inserts it through Set —
+// rebuilding the buckets index from the bucket's own fields.
+func (t *statsTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	record := &corepb.SemaphoreStatsRecord{}
+	if err := record.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(sharding.ByAccountAndNamespace(record.SemaphoreId.AccountId, record.SemaphoreId.NamespaceId)) {
+		return false, nil
+	}
+	return true, t.Set(txn, record)
+}
+
+func (t *statsTable) Get(txn *store.Txn, semaphoreId *corepb.SemaphoreId, startAt int64) (*corepb.SemaphoreStatsRecord, error) {
+	return t.table.Get(txn,
+		utils.ConcatBytes(
+			t.tablePK(semaphoreId.AccountId, semaphoreId.NamespaceId, semaphoreId.SemaphoreId),
+			t.tableSK(startAt)))
+}
+
+func (t *statsTable) Set(txn *store.Txn, record *corepb.SemaphoreStatsRecord) error {
+	err := t.bucketsIndex.Set(txn, t.bucketsIndexPK(record), record)
+	if err != nil {
+		return err
+	}
+
+	return t.table.Set(txn,
+		utils.ConcatBytes(
+			t.tablePK(record.SemaphoreId.AccountId, record.SemaphoreId.NamespaceId, record.SemaphoreId.SemaphoreId),
+			t.tableSK(record.StartAt)),
+		record)
+}
+
+func (t *statsTable) Delete(txn *store.Txn, record *corepb.SemaphoreStatsRecord) error {
+	err := t.bucketsIndex.Delete(txn, t.bucketsIndexPK(record))
+	if err != nil {
+		return err
+	}
+
+	return t.table.Delete(txn,
+		utils.ConcatBytes(
+			t.tablePK(record.SemaphoreId.AccountId, record.SemaphoreId.NamespaceId, record.SemaphoreId.SemaphoreId),
+			t.tableSK(record.StartAt)))
+}
+
+// Add folds a change of the semaphore's active_holds from previousHolds to activeHolds at now,
+// together with the given acquire outcomes, into the bucket covering now.
+func (t *statsTable) Add(txn *store.Txn, semaphoreId *corepb.SemaphoreId, now int64, previousHolds int64, activeHolds int64, acquiresSucceeded int64, acquiresFailed int64) error {
+	startAt := statsBucketStart(now)
+
+	record, err := t.Get(txn, semaphoreId, startAt)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if record == nil {
+		// Nothing changed since the bucket started
+		record = &corepb.SemaphoreStatsRecord{
+			SemaphoreId:        semaphoreId,
+			StartAt:            startAt,
+			OpeningActiveHolds: previousHolds,
+			ActiveHolds:        previousHolds,
+			UpdatedAt:          startAt,
+			MaxActiveHolds:     previousHolds,
+		}
+	}
+
+	if now > record.UpdatedAt {
+		record.ActiveHoldsIntegral += record.ActiveHolds * (now - record.UpdatedAt)
+		record.UpdatedAt = now
+	}
+	record.ActiveHolds = activeHolds
+	record.MaxActiveHolds = max(record.MaxActiveHolds, activeHolds)
+	record.AcquiresSucceeded += acquiresSucceeded
+	record.AcquiresFailed += acquiresFailed
+
+	return t.Set(txn, record)
+}
+
+// List calls fn for every stored bucket of the semaphore that starts in [from, to), oldest
+// first, until fn returns false.
+func (t *statsTable) List(txn *store.Txn, semaphoreId *corepb.SemaphoreId, from int64, to int64, fn func(record *corepb.SemaphoreStatsRecord) (bool, error)) error {
+	pk := t.tablePK(semaphoreId.AccountId, semaphoreId.NamespaceId, semaphoreId.SemaphoreId)
+	return t.table.ListInRange(txn,
+		utils.ConcatBytes(pk, t.tableSK(from)),
+		utils.ConcatBytes(pk, t.tableSK(to)),
+		false, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+			if record.StartAt >= to {
+				return false, nil
+			}
+			return fn(record)
+		})
+}
+
+// ListByStart calls fn for every stored bucket of any semaphore that starts in [from, to),
+// oldest first, until fn returns false.
+func (t *statsTable) ListByStart(txn *store.Txn, from int64, to int64, fn func(record *corepb.SemaphoreStatsRecord) (bool, error)) error {
+	return t.bucketsIndex.ListInRange(txn, t.bucketsIndexPrefix(from), t.bucketsIndexPrefix(to), false, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+		if record.StartAt >= to {
+			return false, nil
+		}
+		return fn(record)
+	})
+}
+
+func (t *statsTable) tablePK(accountId uint64, namespaceId uint64, semaphoreId uint64) []byte {
+	return utils.ConcatBytes(
+		accountId,
+		namespaceId,
+		semaphoreId,
+	)
+}
+
+func (t *statsTable) tableSK(startAt int64) []byte {
+	return utils.ConcatBytes(
+		startAt,
+	)
+}
+
+func (t *statsTable) bucketsIndexPK(record *corepb.SemaphoreStatsRecord) []byte {
+	return utils.ConcatBytes(
+		record.StartAt,
+		record.SemaphoreId.AccountId,
+		record.SemaphoreId.NamespaceId,
+		record.SemaphoreId.SemaphoreId,
+	)
+}
+
+func (t *statsTable) bucketsIndexPrefix(startAt int64) []byte {
+	return utils.ConcatBytes(
+		startAt,
+	)
+}
+
+// statsBucket summarizes the stored bucket over [record.StartAt, end), extending its active holds
+// integral from the last change to end.
+func statsBucket(record *corepb.SemaphoreStatsRecord, end int64) *corepb.SemaphoreStatsBucket {
+	integral := record.ActiveHoldsIntegral
+	if end > record.UpdatedAt {
+		integral += record.ActiveHolds * (end - record.UpdatedAt)
+	}
+	average := float64(record.ActiveHolds)
+	if end > record.StartAt {
+		average = float64(integral) / float64(end-record.StartAt)
+	}
+
+	return &corepb.SemaphoreStatsBucket{
+		StartAt:            record.StartAt,
+		MaxActiveHolds:     record.MaxActiveHolds,
+		AverageActiveHolds: average,
+		AcquiresSucceeded:  record.AcquiresSucceeded,
+		AcquiresFailed:     record.AcquiresFailed,
+	}
+}
+
+// statsBucketStart returns the start of the stats bucket covering t.
+func statsBucketStart(t int64) int64 {
+	return t - t%statsBucketSize
+}
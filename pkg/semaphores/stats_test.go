@@ -0,0 +1,332 @@
+package semaphores
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+// statsBase is aligned to a whole stats bucket.
+var statsBase = time.Unix(1700000040, 0).UnixNano()
+
+func TestStatsBucketStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        int64
+		expected int64
+	}{
+		{
+			name:     "start of a bucket",
+			t:        statsBase,
+			expected: statsBase,
+		},
+		{
+			name:     "middle of a bucket",
+			t:        statsBase + int64(30*time.Second),
+			expected: statsBase,
+		},
+		{
+			name:     "last nanosecond of a bucket",
+			t:        statsBase + statsBucketSize - 1,
+			expected: statsBase,
+		},
+		{
+			name:     "start of the next bucket",
+			t:        statsBase + statsBucketSize,
+			expected: statsBase + statsBucketSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, statsBucketStart(tt.t))
+		})
+	}
+}
+
+func TestStatsTable_Add(t *testing.T) {
+	t.Run("first change opens the bucket with the previous holds", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newStatsTable([]byte{0x77, 0x77, 0x77, 0x77})
+		semaphoreId := randomSemaphoreId()
+
+		// 2 holds since before the bucket, 3 from 20s in
+		txn := badgerStore.Update()
+		require.NoError(t, table.Add(txn, semaphoreId, statsBase+int64(20*time.Second), 2, 3, 1, 0))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		record, err := table.Get(txn, semaphoreId, statsBase)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, record.OpeningActiveHolds)
+		require.EqualValues(t, 3, record.ActiveHolds)
+		require.EqualValues(t, 3, record.MaxActiveHolds)
+		require.EqualValues(t, 2*int64(20*time.Second), record.ActiveHoldsIntegral)
+		require.Equal(t, statsBase+int64(20*time.Second), record.UpdatedAt)
+		require.EqualValues(t, 1, record.AcquiresSucceeded)
+		require.Zero(t, record.AcquiresFailed)
+	})
+
+	t.Run("changes within a bucket accumulate", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newStatsTable([]byte{0x77, 0x77, 0x77, 0x77})
+		semaphoreId := randomSemaphoreId()
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Add(txn, semaphoreId, statsBase, 0, 4, 1, 0))
+		require.NoError(t, table.Add(txn, semaphoreId, statsBase+int64(10*time.Second), 4, 1, 0, 2))
+		require.NoError(t, table.Add(txn, semaphoreId, statsBase+int64(40*time.Second), 1, 2, 1, 0))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		record, err := table.Get(txn, semaphoreId, statsBase)
+		require.NoError(t, err)
+		require.Zero(t, record.OpeningActiveHolds)
+		require.EqualValues(t, 2, record.ActiveHolds)
+		require.EqualValues(t, 4, record.MaxActiveHolds)
+		// 4 holds for 10s, then 1 hold for 30s
+		require.EqualValues(t, 4*int64(10*time.Second)+1*int64(30*time.Second), record.ActiveHoldsIntegral)
+		require.EqualValues(t, 2, record.AcquiresSucceeded)
+		require.EqualValues(t, 2, record.AcquiresFailed)
+	})
+
+	t.Run("a change in the next minute rolls over to a new bucket", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newStatsTable([]byte{0x77, 0x77, 0x77, 0x77})
+		semaphoreId := randomSemaphoreId()
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Add(txn, semaphoreId, statsBase+int64(30*time.Second), 0, 5, 1, 0))
+		require.NoError(t, table.Add(txn, semaphoreId, statsBase+statsBucketSize+int64(15*time.Second), 5, 2, 0, 0))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		first, err := table.Get(txn, semaphoreId, statsBase)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, first.ActiveHolds)
+		require.EqualValues(t, 5, first.MaxActiveHolds)
+		require.EqualValues(t, 1, first.AcquiresSucceeded)
+
+		// The new bucket opens with the holds the previous one closed with
+		second, err := table.Get(txn, semaphoreId, statsBase+statsBucketSize)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, second.OpeningActiveHolds)
+		require.EqualValues(t, 2, second.ActiveHolds)
+		require.EqualValues(t, 5, second.MaxActiveHolds)
+		require.EqualValues(t, 5*int64(15*time.Second), second.ActiveHoldsIntegral)
+		require.Zero(t, second.AcquiresSucceeded)
+	})
+}
+
+func TestStatsTable_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     int64
+		to       int64
+		expected []int64
+	}{
+		{
+			name:     "lists every bucket in the range",
+			from:     statsBase,
+			to:       statsBase + 5*statsBucketSize,
+			expected: []int64{statsBase, statsBase + 2*statsBucketSize, statsBase + 4*statsBucketSize},
+		},
+		{
+			name:     "excludes the upper bound",
+			from:     statsBase,
+			to:       statsBase + 4*statsBucketSize,
+			expected: []int64{statsBase, statsBase + 2*statsBucketSize},
+		},
+		{
+			name:     "includes the lower bound",
+			from:     statsBase + 2*statsBucketSize,
+			to:       statsBase + 5*statsBucketSize,
+			expected: []int64{statsBase + 2*statsBucketSize, statsBase + 4*statsBucketSize},
+		},
+		{
+			name:     "lists nothing outside the range",
+			from:     statsBase + 5*statsBucketSize,
+			to:       statsBase + 10*statsBucketSize,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badgerStore, err := store.NewBadgerInMemoryStore()
+			require.NoError(t, err)
+
+			table := newStatsTable([]byte{0x77, 0x77, 0x77, 0x77})
+			semaphoreId := randomSemaphoreId()
+			otherSemaphoreId := randomSemaphoreId()
+
+			txn := badgerStore.Update()
+			for _, startAt := range []int64{statsBase, statsBase + 2*statsBucketSize, statsBase + 4*statsBucketSize} {
+				require.NoError(t, table.Add(txn, semaphoreId, startAt, 0, 1, 1, 0))
+				require.NoError(t, table.Add(txn, otherSemaphoreId, startAt, 0, 1, 1, 0))
+			}
+			require.NoError(t, txn.Commit())
+
+			txn = badgerStore.View()
+			defer txn.Discard()
+
+			var actual []int64
+			err = table.List(txn, semaphoreId, tt.from, tt.to, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+				require.Equal(t, semaphoreId.SemaphoreId, record.SemaphoreId.SemaphoreId)
+				actual = append(actual, record.StartAt)
+				return true, nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestStatsTable_ListByStart(t *testing.T) {
+	t.Run("lists buckets of every semaphore older than the retention window", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newStatsTable([]byte{0x77, 0x77, 0x77, 0x77})
+		semaphoreId1 := randomSemaphoreId()
+		semaphoreId2 := randomSemaphoreId()
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Add(txn, semaphoreId1, statsBase, 0, 1, 1, 0))
+		require.NoError(t, table.Add(txn, semaphoreId2, statsBase+statsBucketSize, 0, 1, 1, 0))
+		require.NoError(t, table.Add(txn, semaphoreId1, statsBase+statsRetention, 1, 2, 1, 0))
+		require.NoError(t, txn.Commit())
+
+		// Trim the way garbage collection does, a day and a bucket after the second bucket
+		now := statsBase + statsBucketSize + statsRetention
+
+		txn = badgerStore.Update()
+		var trimmed []*corepb.SemaphoreStatsRecord
+		err = table.ListByStart(txn, 0, now-statsRetention, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+			trimmed = append(trimmed, record)
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, trimmed, 1)
+		require.Equal(t, semaphoreId1.SemaphoreId, trimmed[0].SemaphoreId.SemaphoreId)
+		require.Equal(t, statsBase, trimmed[0].StartAt)
+
+		for _, record := range trimmed {
+			require.NoError(t, table.Delete(txn, record))
+		}
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		var remaining []int64
+		err = table.ListByStart(txn, 0, now+statsBucketSize, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+			remaining = append(remaining, record.StartAt)
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int64{statsBase + statsBucketSize, statsBase + statsRetention}, remaining)
+
+		// The primary table follows the index
+		var listed int
+		err = table.List(txn, semaphoreId1, 0, now+statsBucketSize, func(record *corepb.SemaphoreStatsRecord) (bool, error) {
+			listed++
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, listed)
+	})
+}
+
+func TestStatsBucket(t *testing.T) {
+	tests := []struct {
+		name            string
+		record          *corepb.SemaphoreStatsRecord
+		end             int64
+		expectedAverage float64
+	}{
+		{
+			name: "full bucket without changes",
+			record: &corepb.SemaphoreStatsRecord{
+				StartAt:     statsBase,
+				ActiveHolds: 3,
+				UpdatedAt:   statsBase,
+			},
+			end:             statsBase + statsBucketSize,
+			expectedAverage: 3,
+		},
+		{
+			name: "integral extended from the last change to the end",
+			record: &corepb.SemaphoreStatsRecord{
+				StartAt: statsBase,
+				// 4 holds for the first 15s, then 2 holds
+				ActiveHolds:         2,
+				UpdatedAt:           statsBase + int64(15*time.Second),
+				ActiveHoldsIntegral: 4 * int64(15*time.Second),
+			},
+			end:             statsBase + statsBucketSize,
+			expectedAverage: 2.5,
+		},
+		{
+			name: "current bucket averages up to now",
+			record: &corepb.SemaphoreStatsRecord{
+				StartAt:             statsBase,
+				ActiveHolds:         0,
+				UpdatedAt:           statsBase + int64(10*time.Second),
+				ActiveHoldsIntegral: 6 * int64(10*time.Second),
+			},
+			end:             statsBase + int64(20*time.Second),
+			expectedAverage: 3,
+		},
+		{
+			name: "empty span reports the current holds",
+			record: &corepb.SemaphoreStatsRecord{
+				StartAt:     statsBase,
+				ActiveHolds: 7,
+				UpdatedAt:   statsBase,
+			},
+			end:             statsBase,
+			expectedAverage: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.record.MaxActiveHolds = 9
+			tt.record.AcquiresSucceeded = 5
+			tt.record.AcquiresFailed = 1
+
+			bucket := statsBucket(tt.record, tt.end)
+			require.Equal(t, tt.record.StartAt, bucket.StartAt)
+			require.InDelta(t, tt.expectedAverage, bucket.AverageActiveHolds, 1e-9)
+			require.EqualValues(t, 9, bucket.MaxActiveHolds)
+			require.EqualValues(t, 5, bucket.AcquiresSucceeded)
+			require.EqualValues(t, 1, bucket.AcquiresFailed)
+		})
+	}
+}
+
+func randomSemaphoreId() *corepb.SemaphoreId {
+	return &corepb.SemaphoreId{
+		AccountId:   rand.Uint64(),
+		NamespaceId: rand.Uint64(),
+		SemaphoreId: rand.Uint64(),
+	}
+}
@@ -9,15 +9,20 @@ package semaphores
 //
 // Treat these as constants; never mutate the returned slices.
 var (
-	tablePrefixSemaphores             = []byte{0x00}
-	tablePrefixSemaphoresNamesIndex   = []byte{0x01}
-	tablePrefixCounters               = []byte{0x03}
-	tablePrefixGCRecords              = []byte{0x04}
-	tablePrefixExpirationRecords      = []byte{0x05}
-	tablePrefixHolders                = []byte{0x06}
-	tablePrefixHoldersExpirationIndex = []byte{0x07}
-	tablePrefixHoldersLeaseIdIndex    = []byte{0x08}
-	tablePrefixLeases                 = []byte{0x09}
-	tablePrefixLeasesProcessIdIndex   = []byte{0x0a}
-	tablePrefixLeasesExpirationIndex  = []byte{0x0b}
+	tablePrefixSemaphores              = []byte{0x00}
+	tablePrefixSemaphoresNamesIndex    = []byte{0x01}
+	tablePrefixCounters                = []byte{0x03}
+	tablePrefixGCRecords               = []byte{0x04}
+	tablePrefixExpirationRecords       = []byte{0x05}
+	tablePrefixHolders                 = []byte{0x06}
+	tablePrefixHoldersExpirationIndex  = []byte{0x07}
+	tablePrefixHoldersLeaseIdIndex     = []byte{0x08}
+	tablePrefixLeases                  = []byte{0x09}
+	tablePrefixLeasesProcessIdIndex    = []byte{0x0a}
+	tablePrefixLeasesExpirationIndex   = []byte{0x0b}
+	tablePrefixWaiters                 = []byte{0x0c}
+	tablePrefixWaitersExpirationIndex  = []byte{0x0d}
+	tablePrefixSemaphoresDeletionIndex = []byte{0x0e}
+	tablePrefixStats                   = []byte{0x0f}
+	tablePrefixStatsBucketsIndex       = []byte{0x10}
 )
@@ -0,0 +1,199 @@
+package semaphores
+
+import (
+	"errors"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/pagination"
+	"github.com/evrblk/grackle/pkg/sharding"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// waitersTable stores the leases blocked in AcquireSemaphore, indexed by semaphore id and by
+// expiration time.
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. semaphore id
+//
+// Table Sort Key:
+// 1. lease id
+//
+// Expiration Index Primary Key:
+// 1. expiration time
+// 2. account id
+// 3. namespace id
+// 4. semaphore id
+// 5. lease id
+type waitersTable struct {
+	table           *honey.BinaryTable[*corepb.SemaphoreWaiter, corepb.SemaphoreWaiter]
+	expirationIndex *honey.BinaryTable[*corepb.SemaphoreWaiter, corepb.SemaphoreWaiter]
+}
+
+// newWaitersTable scopes both tables under the shard-unique prefix; see
+// newSemaphoresTable.
+func newWaitersTable(replicaPrefix []byte) *waitersTable {
+	return &waitersTable{
+		table: honey.NewBinaryTable[*corepb.SemaphoreWaiter, corepb.SemaphoreWaiter](
+			utils.ConcatBytes(replicaPrefix, tablePrefixWaiters)),
+		expirationIndex: honey.NewBinaryTable[*corepb.SemaphoreWaiter, corepb.SemaphoreWaiter](
+			utils.ConcatBytes(replicaPrefix, tablePrefixWaitersExpirationIndex)),
+	}
+}
+
+// Clear deletes every row this table owns: the primary waiter rows and the expiration index.
+func (t *waitersTable) Clear(badgerStore *store.BadgerStore) error {
+	for _, prefix := range [][]byte{t.table.TableId(), t.expirationIndex.TableId()} {
+		if err := badgerStore.DeletePrefix(prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachEntity streams every waiter as (canonical key, stored value) — the primary table only;
+// the expiration index is rebuilt from the waiters on restore.
+func (t *waitersTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed waiter and, if owned, inserts it through Set — rebuilding
+// the expiration index from the waiter's own fields.
+func (t *waitersTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	waiter := &corepb.SemaphoreWaiter{}
+	if err := waiter.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(sharding.ByAccountAndNamespace(waiter.SemaphoreId.AccountId, waiter.SemaphoreId.NamespaceId)) {
+		return false, nil
+	}
+	return true, t.Set(txn, waiter)
+}
+
+func (t *waitersTable) Get(txn *store.Txn, semaphoreId *corepb.SemaphoreId, leaseId uint64) (*corepb.SemaphoreWaiter, error) {
+	return t.table.Get(txn,
+		utils.ConcatBytes(
+			t.tablePK(semaphoreId.AccountId, semaphoreId.NamespaceId, semaphoreId.SemaphoreId),
+			t.tableSK(leaseId)))
+}
+
+func (t *waitersTable) Set(txn *store.Txn, waiter *corepb.SemaphoreWaiter) error {
+	key := utils.ConcatBytes(
+		t.tablePK(waiter.SemaphoreId.AccountId, waiter.SemaphoreId.NamespaceId, waiter.SemaphoreId.SemaphoreId),
+		t.tableSK(waiter.LeaseId))
+
+	existingWaiter, err := t.table.Get(txn, key)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	if existingWaiter != nil && existingWaiter.ExpiresAt != waiter.ExpiresAt {
+		err = t.expirationIndex.Delete(txn, t.expirationIndexPK(existingWaiter))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = t.expirationIndex.Set(txn, t.expirationIndexPK(waiter), waiter)
+	if err != nil {
+		return err
+	}
+
+	return t.table.Set(txn, key, waiter)
+}
+
+func (t *waitersTable) Delete(txn *store.Txn, waiter *corepb.SemaphoreWaiter) error {
+	err := t.expirationIndex.Delete(txn, t.expirationIndexPK(waiter))
+	if err != nil {
+		return err
+	}
+
+	return t.table.Delete(txn,
+		utils.ConcatBytes(
+			t.tablePK(waiter.SemaphoreId.AccountId, waiter.SemaphoreId.NamespaceId, waiter.SemaphoreId.SemaphoreId),
+			t.tableSK(waiter.LeaseId)))
+}
+
+type listWaitersResult struct {
+	waiters                 []*corepb.SemaphoreWaiter
+	nextPaginationToken     *corepb.PaginationToken
+	previousPaginationToken *corepb.PaginationToken
+}
+
+// List returns a page of the semaphore's waiters sorted by lease id, including those that have
+// already lapsed.
+func (t *waitersTable) List(txn *store.Txn, semaphoreId *corepb.SemaphoreId, paginationToken *corepb.PaginationToken, limit int) (*listWaitersResult, error) {
+	result, err := t.table.ListPaginated(txn,
+		t.tablePK(semaphoreId.AccountId, semaphoreId.NamespaceId, semaphoreId.SemaphoreId),
+		pagination.CoreToMonstera(paginationToken),
+		limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listWaitersResult{
+		waiters:                 result.Items,
+		nextPaginationToken:     pagination.MonsteraToCore(result.NextPaginationToken),
+		previousPaginationToken: pagination.MonsteraToCore(result.PreviousPaginationToken),
+	}, nil
+}
+
+// CountActive returns the number of the semaphore's waiters that have not lapsed by now. Lapsed
+// waiters are left for GC to delete, like in List.
+func (t *waitersTable) CountActive(txn *store.Txn, semaphoreId *corepb.SemaphoreId, now int64) (int64, error) {
+	pk := t.tablePK(semaphoreId.AccountId, semaphoreId.NamespaceId, semaphoreId.SemaphoreId)
+
+	count := int64(0)
+	err := t.table.ListInRange(txn, pk, pk, false, func(waiter *corepb.SemaphoreWaiter) (bool, error) {
+		if waiter.ExpiresAt > now {
+			count++
+		}
+		return true, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListByExpiration calls fn for every waiter that lapses in [from, to), earliest first, until fn
+// returns false.
+func (t *waitersTable) ListByExpiration(txn *store.Txn, from int64, to int64, fn func(waiter *corepb.SemaphoreWaiter) (bool, error)) error {
+	return t.expirationIndex.ListInRange(txn, t.expirationIndexPrefix(from), t.expirationIndexPrefix(to), false, fn)
+}
+
+func (t *waitersTable) tablePK(accountId uint64, namespaceId uint64, semaphoreId uint64) []byte {
+	return utils.ConcatBytes(
+		accountId,
+		namespaceId,
+		semaphoreId,
+	)
+}
+
+func (t *waitersTable) tableSK(leaseId uint64) []byte {
+	return utils.ConcatBytes(
+		leaseId,
+	)
+}
+
+func (t *waitersTable) expirationIndexPK(waiter *corepb.SemaphoreWaiter) []byte {
+	return utils.ConcatBytes(
+		waiter.ExpiresAt,
+		waiter.SemaphoreId.AccountId,
+		waiter.SemaphoreId.NamespaceId,
+		waiter.SemaphoreId.SemaphoreId,
+		waiter.LeaseId,
+	)
+}
+
+func (t *waitersTable) expirationIndexPrefix(expiresAt int64) []byte {
+	return utils.ConcatBytes(
+		expiresAt,
+	)
+}
@@ -0,0 +1,321 @@
+package semaphores
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestWaitersTable_Set(t *testing.T) {
+	t.Run("set and get a waiter", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waiter := &corepb.SemaphoreWaiter{
+			SemaphoreId:  randomSemaphoreId(),
+			LeaseId:      rand.Uint64(),
+			Weight:       3,
+			Class:        "batch",
+			ProcessId:    "process_1",
+			WaitingSince: 100,
+			ExpiresAt:    1000,
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		actual, err := table.Get(txn, waiter.SemaphoreId, waiter.LeaseId)
+		require.NoError(t, err)
+		require.Equal(t, waiter.Weight, actual.Weight)
+		require.Equal(t, waiter.Class, actual.Class)
+		require.Equal(t, waiter.ProcessId, actual.ProcessId)
+		require.Equal(t, waiter.WaitingSince, actual.WaitingSince)
+		require.Equal(t, waiter.ExpiresAt, actual.ExpiresAt)
+	})
+
+	t.Run("refreshing a waiter moves its expiration", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waiter := &corepb.SemaphoreWaiter{
+			SemaphoreId: randomSemaphoreId(),
+			LeaseId:     rand.Uint64(),
+			ExpiresAt:   1000,
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.Update()
+		require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{
+			SemaphoreId: waiter.SemaphoreId,
+			LeaseId:     waiter.LeaseId,
+			ExpiresAt:   2000,
+		}))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		// Only the new expiration is indexed
+		require.Empty(t, listWaitersByExpiration(t, table, txn, 0, 1500))
+		require.Len(t, listWaitersByExpiration(t, table, txn, 0, 2500), 1)
+	})
+}
+
+func TestWaitersTable_Delete(t *testing.T) {
+	t.Run("delete a waiter", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waiter := &corepb.SemaphoreWaiter{
+			SemaphoreId: randomSemaphoreId(),
+			LeaseId:     rand.Uint64(),
+			ExpiresAt:   1000,
+		}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.Update()
+		require.NoError(t, table.Delete(txn, waiter))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, waiter.SemaphoreId, waiter.LeaseId)
+		require.ErrorIs(t, err, store.ErrNotFound)
+		require.Empty(t, listWaitersByExpiration(t, table, txn, 0, 2000))
+	})
+
+	t.Run("delete only the targeted waiter", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		semaphoreId := randomSemaphoreId()
+		waiter1 := &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId, LeaseId: 1, ExpiresAt: 1000}
+		waiter2 := &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId, LeaseId: 2, ExpiresAt: 1000}
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, waiter1))
+		require.NoError(t, table.Set(txn, waiter2))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.Update()
+		require.NoError(t, table.Delete(txn, waiter1))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, semaphoreId, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.waiters, 1)
+		require.EqualValues(t, 2, result.waiters[0].LeaseId)
+	})
+}
+
+func TestWaitersTable_List(t *testing.T) {
+	t.Run("lists waiters sorted by lease id", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		semaphoreId := randomSemaphoreId()
+
+		txn := badgerStore.Update()
+		for _, leaseId := range []uint64{30, 10, 20} {
+			require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{
+				SemaphoreId: semaphoreId,
+				LeaseId:     leaseId,
+				ExpiresAt:   int64(leaseId),
+			}))
+		}
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, semaphoreId, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.waiters, 3)
+		require.EqualValues(t, 10, result.waiters[0].LeaseId)
+		require.EqualValues(t, 20, result.waiters[1].LeaseId)
+		require.EqualValues(t, 30, result.waiters[2].LeaseId)
+		require.Nil(t, result.nextPaginationToken)
+	})
+
+	t.Run("lists waiters with pagination", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		semaphoreId := randomSemaphoreId()
+
+		txn := badgerStore.Update()
+		for leaseId := uint64(1); leaseId <= 5; leaseId++ {
+			require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{
+				SemaphoreId: semaphoreId,
+				LeaseId:     leaseId,
+				ExpiresAt:   1000,
+			}))
+		}
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		page1, err := table.List(txn, semaphoreId, nil, 3)
+		require.NoError(t, err)
+		require.Len(t, page1.waiters, 3)
+		require.NotNil(t, page1.nextPaginationToken)
+
+		page2, err := table.List(txn, semaphoreId, page1.nextPaginationToken, 3)
+		require.NoError(t, err)
+		require.Len(t, page2.waiters, 2)
+		require.EqualValues(t, 4, page2.waiters[0].LeaseId)
+	})
+
+	t.Run("lists waiters from different semaphores are isolated", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		semaphoreId1 := randomSemaphoreId()
+		semaphoreId2 := randomSemaphoreId()
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId1, LeaseId: 1, ExpiresAt: 1000}))
+		require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId2, LeaseId: 2, ExpiresAt: 1000}))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, semaphoreId1, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, result.waiters, 1)
+		require.EqualValues(t, 1, result.waiters[0].LeaseId)
+	})
+}
+
+func TestWaitersTable_CountActive(t *testing.T) {
+	t.Run("counts waiters that have not lapsed", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		semaphoreId1 := randomSemaphoreId()
+		semaphoreId2 := randomSemaphoreId()
+
+		txn := badgerStore.Update()
+		require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId1, LeaseId: 1, ExpiresAt: 500}))
+		require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId1, LeaseId: 2, ExpiresAt: 1000}))
+		require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId1, LeaseId: 3, ExpiresAt: 2000}))
+		require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: semaphoreId2, LeaseId: 4, ExpiresAt: 2000}))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		count, err := table.CountActive(txn, semaphoreId1, 0)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, count)
+
+		// A waiter lapses at its ExpiresAt
+		count, err = table.CountActive(txn, semaphoreId1, 1000)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+
+		count, err = table.CountActive(txn, semaphoreId1, 2000)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
+
+func TestWaitersTable_ListByExpiration(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     int64
+		to       int64
+		expected []uint64
+	}{
+		{
+			name:     "lists lapsed waiters earliest first",
+			from:     0,
+			to:       3500,
+			expected: []uint64{2, 3, 1},
+		},
+		{
+			name:     "skips waiters lapsing later",
+			from:     0,
+			to:       2500,
+			expected: []uint64{2, 3},
+		},
+		{
+			name:     "lists nothing before the earliest expiration",
+			from:     0,
+			to:       500,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badgerStore, err := store.NewBadgerInMemoryStore()
+			require.NoError(t, err)
+
+			table := newWaitersTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+			txn := badgerStore.Update()
+			require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: randomSemaphoreId(), LeaseId: 1, ExpiresAt: 3000}))
+			require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: randomSemaphoreId(), LeaseId: 2, ExpiresAt: 1000}))
+			require.NoError(t, table.Set(txn, &corepb.SemaphoreWaiter{SemaphoreId: randomSemaphoreId(), LeaseId: 3, ExpiresAt: 2000}))
+			require.NoError(t, txn.Commit())
+
+			txn = badgerStore.View()
+			defer txn.Discard()
+
+			var actual []uint64
+			for _, waiter := range listWaitersByExpiration(t, table, txn, tt.from, tt.to) {
+				actual = append(actual, waiter.LeaseId)
+			}
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func listWaitersByExpiration(t *testing.T, table *waitersTable, txn *store.Txn, from int64, to int64) []*corepb.SemaphoreWaiter {
+	t.Helper()
+
+	var waiters []*corepb.SemaphoreWaiter
+	err := table.ListByExpiration(txn, from, to, func(waiter *corepb.SemaphoreWaiter) (bool, error) {
+		waiters = append(waiters, waiter)
+		return true, nil
+	})
+	require.NoError(t, err)
+	return waiters
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand/v2"
+	"slices"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -18,6 +20,8 @@ import (
 	"github.com/evrblk/grackle/pkg/corepb"
 	"github.com/evrblk/grackle/pkg/grackle"
 	"github.com/evrblk/grackle/pkg/ids"
+	"github.com/evrblk/grackle/pkg/pagination"
+	"github.com/evrblk/grackle/pkg/sharding"
 )
 
 const (
@@ -235,6 +239,13 @@ func (s *GrackleApiServerHandler) CreateWaitGroup(ctx context.Context, req *grac
 			Metadata:                          req.Metadata,
 			MaxNumberOfWaitGroupsPerNamespace: limits.MaxNumberOfWaitGroupsPerNamespace,
 			DeleteAfterFinishedSeconds:        req.DeleteAfterFinishedSeconds,
+			MaxFailedJobs:                     req.MaxFailedJobs,
+			DeclaredJobs:                      req.DeclaredJobs,
+			ParentWaitGroupName:               req.ParentWaitGroupName,
+			ChildFailurePolicy:                waitGroupChildFailurePolicyToCore(req.ChildFailurePolicy),
+			Aggregations:                      waitGroupAggregationSpecsToCore(req.Aggregations),
+			IntegerJobIds:                     req.IntegerJobIds,
+			Stripes:                           req.Stripes,
 		})
 		if err != nil {
 			if isIDCollision(err) {
@@ -286,6 +297,13 @@ func (s *GrackleApiServerHandler) UpdateWaitGroup(ctx context.Context, req *grac
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
+	// Stripes must outlive the wait group, which may now expire or be deleted
+	// later
+	err = s.extendWaitGroupStripes(ctx, resp1.WaitGroup)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
 	return &gracklepb.UpdateWaitGroupResponse{
 		WaitGroup: waitGroupToFront(resp1.WaitGroup),
 	}, nil
@@ -341,24 +359,21 @@ func (s *GrackleApiServerHandler) WaitForWaitGroup(ctx context.Context, req *gra
 			return nil, mrpc.ErrorToGRPC(err)
 		}
 
-		// Return as soon as the wait group has finished (completed or expired),
-		// or once the deadline passes while it is still active.
-		switch resp1.WaitGroup.Status {
-		case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED:
-			return &gracklepb.WaitForWaitGroupResponse{
-				WaitGroup: waitGroupToFront(resp1.WaitGroup),
-				Outcome:   gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_COMPLETED,
-			}, nil
-		case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED:
+		waitGroup := resp1.WaitGroup
+
+		// Return as soon as the wait group has finished (completed, failed,
+		// expired or cancelled), or once the deadline passes while it is still
+		// active.
+		if outcome, finished := waitGroupWaitOutcomeToFront(waitGroup.Status); finished {
 			return &gracklepb.WaitForWaitGroupResponse{
-				WaitGroup: waitGroupToFront(resp1.WaitGroup),
-				Outcome:   gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_EXPIRED,
+				WaitGroup: waitGroupToFront(waitGroup),
+				Outcome:   outcome,
 			}, nil
 		}
 
 		if time.Now().After(deadline) {
 			return &gracklepb.WaitForWaitGroupResponse{
-				WaitGroup: waitGroupToFront(resp1.WaitGroup),
+				WaitGroup: waitGroupToFront(waitGroup),
 				Outcome:   gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_TIMED_OUT,
 			}, nil
 		}
@@ -396,11 +411,210 @@ func (s *GrackleApiServerHandler) CompleteJobsFromWaitGroup(ctx context.Context,
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
+	// A striped wait group comes back untouched, its jobs are completed on
+	// its stripes
+	if resp1.WaitGroup.Stripes > 0 {
+		waitGroup, err := s.completeJobsOnStripes(ctx, resp1.WaitGroup, completeJobsToCore(req.Jobs))
+		if err != nil {
+			return nil, err
+		}
+
+		return &gracklepb.CompleteJobsFromWaitGroupResponse{
+			WaitGroup: waitGroupToFront(waitGroup),
+		}, nil
+	}
+
 	return &gracklepb.CompleteJobsFromWaitGroupResponse{
 		WaitGroup: waitGroupToFront(resp1.WaitGroup),
 	}, nil
 }
 
+func (s *GrackleApiServerHandler) UncompleteJobsFromWaitGroup(ctx context.Context, req *gracklepb.UncompleteJobsFromWaitGroupRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.UncompleteJobsFromWaitGroupResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Retract completed jobs from the wait group
+	resp1, err := s.grackleClient.UncompleteJobsFromWaitGroup(ctx, &corepb.UncompleteJobsFromWaitGroupRequest{
+		NamespaceId:         namespace.Id,
+		WaitGroupName:       req.WaitGroupName,
+		JobIds:              req.JobIds,
+		Reopen:              req.Reopen,
+		MaxJobHistoryLength: maxJobHistoryLength,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.UncompleteJobsFromWaitGroupResponse{
+		WaitGroup: waitGroupToFront(resp1.WaitGroup),
+	}, nil
+}
+
+func (s *GrackleApiServerHandler) AddJobsToWaitGroup(ctx context.Context, req *gracklepb.AddJobsToWaitGroupRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.AddJobsToWaitGroupResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Declare jobs in the wait group
+	resp1, err := s.grackleClient.AddJobsToWaitGroup(ctx, &corepb.AddJobsToWaitGroupRequest{
+		NamespaceId:   namespace.Id,
+		WaitGroupName: req.WaitGroupName,
+		JobIds:        req.JobIds,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.AddJobsToWaitGroupResponse{
+		WaitGroup: waitGroupToFront(resp1.WaitGroup),
+	}, nil
+}
+
+func (s *GrackleApiServerHandler) AddToWaitGroup(ctx context.Context, req *gracklepb.AddToWaitGroupRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.AddToWaitGroupResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Adjust wait group counter
+	resp1, err := s.grackleClient.AddToWaitGroup(ctx, &corepb.AddToWaitGroupRequest{
+		NamespaceId:      namespace.Id,
+		WaitGroupName:    req.WaitGroupName,
+		Delta:            req.Delta,
+		MaxWaitGroupSize: limits.MaxWaitGroupSize,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Stripes must outlive the wait group, which may now expire later
+	err = s.extendWaitGroupStripes(ctx, resp1.WaitGroup)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.AddToWaitGroupResponse{
+		WaitGroup: waitGroupToFront(resp1.WaitGroup),
+	}, nil
+}
+
+func (s *GrackleApiServerHandler) WaitForWaitGroups(ctx context.Context, req *gracklepb.WaitForWaitGroupsRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.WaitForWaitGroupsResponse, error) {
+	// Resolve namespace by name once to avoid repeated lookups
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Calculate absolute deadline for timeout
+	deadline := time.Now().Add(time.Duration(req.TimeoutSeconds) * time.Second)
+
+	// Initialize polling with exponential backoff
+	pollInterval := 100 * time.Millisecond
+	maxPollInterval := 1 * time.Second
+
+	// Last seen state of every wait group. A finished wait group never goes
+	// back to active, so it is not polled again (and may even be deleted in
+	// the meantime).
+	waitGroups := make([]*corepb.WaitGroup, len(req.WaitGroupNames))
+	finished := 0
+
+	for {
+		// Check if context is cancelled
+		if ctx.Err() != nil {
+			return nil, status.Errorf(codes.Canceled, "req cancelled")
+		}
+
+		// Poll the state of every wait group that has not finished yet
+		for i, waitGroupName := range req.WaitGroupNames {
+			if waitGroups[i] != nil && waitGroups[i].Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+				continue
+			}
+
+			resp1, err := s.grackleClient.GetWaitGroupByName(ctx, &corepb.GetWaitGroupByNameRequest{
+				NamespaceId:   namespace.Id,
+				WaitGroupName: waitGroupName,
+			})
+			if err != nil {
+				return nil, mrpc.ErrorToGRPC(err)
+			}
+
+			waitGroups[i] = resp1.WaitGroup
+			if _, ok := waitGroupWaitOutcomeToFront(waitGroups[i].Status); ok {
+				finished++
+			}
+		}
+
+		// Return once all (or, in ANY mode, any) of the wait groups have
+		// finished, or once the deadline passes
+		done := finished == len(waitGroups) ||
+			(req.Mode == gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ANY && finished > 0)
+		timedOut := !done && time.Now().After(deadline)
+		if done || timedOut {
+			results := make([]*gracklepb.WaitGroupWaitResult, len(waitGroups))
+			for i, waitGroup := range waitGroups {
+				outcome, ok := waitGroupWaitOutcomeToFront(waitGroup.Status)
+				if !ok {
+					if timedOut {
+						outcome = gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_TIMED_OUT
+					} else {
+						outcome = gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_PENDING
+					}
+				}
+				results[i] = &gracklepb.WaitGroupWaitResult{
+					WaitGroup: waitGroupToFront(waitGroup),
+					Outcome:   outcome,
+				}
+			}
+
+			return &gracklepb.WaitForWaitGroupsResponse{
+				Results: results,
+			}, nil
+		}
+
+		// Sleep with exponential backoff, respecting deadline
+		sleepDuration := pollInterval
+		if timeUntilDeadline := time.Until(deadline); timeUntilDeadline < sleepDuration {
+			sleepDuration = timeUntilDeadline
+		}
+
+		select {
+		case <-time.After(sleepDuration):
+			// Increase poll interval with exponential backoff
+			pollInterval = min(pollInterval*2, maxPollInterval)
+		case <-ctx.Done():
+			return nil, status.Errorf(codes.Canceled, "req cancelled")
+		}
+	}
+}
+
+func (s *GrackleApiServerHandler) CancelWaitGroup(ctx context.Context, req *gracklepb.CancelWaitGroupRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.CancelWaitGroupResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Cancel wait group, it is deleted after its retention period
+	resp1, err := s.grackleClient.CancelWaitGroup(ctx, &corepb.CancelWaitGroupRequest{
+		NamespaceId:   namespace.Id,
+		WaitGroupName: req.WaitGroupName,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.CancelWaitGroupResponse{
+		WaitGroup: waitGroupToFront(resp1.WaitGroup),
+	}, nil
+}
+
 func (s *GrackleApiServerHandler) DeleteWaitGroup(ctx context.Context, req *gracklepb.DeleteWaitGroupRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.DeleteWaitGroupResponse, error) {
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
@@ -409,7 +623,7 @@ func (s *GrackleApiServerHandler) DeleteWaitGroup(ctx context.Context, req *grac
 	}
 
 	// Delete wait group and mark for garbage collection
-	_, err = s.grackleClient.DeleteWaitGroup(ctx, &corepb.DeleteWaitGroupRequest{
+	resp1, err := s.grackleClient.DeleteWaitGroup(ctx, &corepb.DeleteWaitGroupRequest{
 		NamespaceId:   namespace.Id,
 		WaitGroupName: req.WaitGroupName,
 		RecordId:      rand.Uint64(),
@@ -418,6 +632,23 @@ func (s *GrackleApiServerHandler) DeleteWaitGroup(ctx context.Context, req *grac
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
+	// Nothing was deleted
+	if resp1.WaitGroup == nil {
+		return &gracklepb.DeleteWaitGroupResponse{}, nil
+	}
+
+	// Stripes live on other shards and are garbage collected there. If this
+	// fails half way, the remaining stripes are still deleted once their own
+	// retention passes.
+	for stripe := range resp1.WaitGroup.Stripes {
+		_, err = s.grackleClient.DeleteWaitGroupStripe(ctx, &corepb.DeleteWaitGroupStripeRequest{
+			StripeId: waitGroupStripeId(resp1.WaitGroup.Id, stripe),
+		})
+		if err != nil {
+			return nil, mrpc.ErrorToGRPC(err)
+		}
+	}
+
 	return &gracklepb.DeleteWaitGroupResponse{}, nil
 }
 
@@ -485,6 +716,14 @@ func (s *GrackleApiServerHandler) ListWaitGroupCompletedJobs(ctx context.Context
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
+	// The jobs of a striped wait group are listed from its stripes
+	if resp1.StripedWaitGroup != nil {
+		resp1, err = s.listStripedWaitGroupCompletedJobs(ctx, resp1.StripedWaitGroup, paginationToken, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Encode pagination tokens for response
 	nextPaginationToken, err := paginationTokenToFront(resp1.NextPaginationToken)
 	if err != nil {
@@ -502,6 +741,47 @@ func (s *GrackleApiServerHandler) ListWaitGroupCompletedJobs(ctx context.Context
 	}, nil
 }
 
+func (s *GrackleApiServerHandler) ListWaitGroupPendingJobs(ctx context.Context, req *gracklepb.ListWaitGroupPendingJobsRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.ListWaitGroupPendingJobsResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Decode pagination token from base64-encoded format
+	paginationToken, err := paginationTokenToCore(req.PaginationToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	// List declared jobs that are not completed yet
+	resp1, err := s.grackleClient.ListWaitGroupPendingJobs(ctx, &corepb.ListWaitGroupPendingJobsRequest{
+		NamespaceId:     namespace.Id,
+		WaitGroupName:   req.WaitGroupName,
+		PaginationToken: paginationToken,
+		Limit:           req.Limit,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Encode pagination tokens for response
+	nextPaginationToken, err := paginationTokenToFront(resp1.NextPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+	previousPaginationToken, err := paginationTokenToFront(resp1.PreviousPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.ListWaitGroupPendingJobsResponse{
+		Jobs:                    waitGroupJobsToFront(resp1.Jobs),
+		NextPaginationToken:     nextPaginationToken,
+		PreviousPaginationToken: previousPaginationToken,
+	}, nil
+}
+
 func (s *GrackleApiServerHandler) AcquireLock(ctx context.Context, req *gracklepb.AcquireLockRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.AcquireLockResponse, error) {
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
@@ -522,6 +802,12 @@ func (s *GrackleApiServerHandler) AcquireLock(ctx context.Context, req *gracklep
 	// Calculate absolute deadline for timeout
 	deadline := time.Now().Add(time.Duration(req.TimeoutSeconds) * time.Second)
 
+	// While retrying, the lease is listed as a waiter on the lock until the deadline
+	waitUntil := int64(0)
+	if req.TimeoutSeconds > 0 {
+		waitUntil = deadline.UnixNano()
+	}
+
 	// Initialize polling with exponential backoff
 	pollInterval := 100 * time.Millisecond
 	maxPollInterval := 1 * time.Second
@@ -543,6 +829,8 @@ func (s *GrackleApiServerHandler) AcquireLock(ctx context.Context, req *gracklep
 			Exclusive:                    req.Exclusive,
 			Metadata:                     req.Metadata,
 			MaxNumberOfLocksPerNamespace: limits.MaxNumberOfLocksPerNamespace,
+			MaxHoldSeconds:               req.MaxHoldSeconds,
+			WaitUntil:                    waitUntil,
 		})
 		if err != nil {
 			return nil, mrpc.ErrorToGRPC(err)
@@ -615,6 +903,49 @@ func (s *GrackleApiServerHandler) ReleaseLock(ctx context.Context, req *gracklep
 	}, nil
 }
 
+func (s *GrackleApiServerHandler) TransferLock(ctx context.Context, req *gracklepb.TransferLockRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.TransferLockResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Decode and validate both lease IDs
+	fromLeaseId, err := ids.DecodeLeaseId(req.FromLeaseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid TransferLockRequest.FromLeaseId: %v", err)
+	}
+
+	toLeaseId, err := ids.DecodeLeaseId(req.ToLeaseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid TransferLockRequest.ToLeaseId: %v", err)
+	}
+
+	for _, leaseId := range []*corepb.LeaseId{fromLeaseId, toLeaseId} {
+		if leaseId.AccountId != accountId || leaseId.NamespaceId != namespace.Id.NamespaceId {
+			return nil, status.Errorf(codes.NotFound, "lease not found")
+		}
+	}
+
+	// Move the hold to the other lease
+	resp1, err := s.grackleClient.TransferLock(ctx, &corepb.TransferLockRequest{
+		LockId: &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespace.Id.NamespaceId,
+			LockName:    req.LockName,
+		},
+		FromLeaseId: fromLeaseId.LeaseId,
+		ToLeaseId:   toLeaseId.LeaseId,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.TransferLockResponse{
+		Lock: lockToFront(resp1.Lock),
+	}, nil
+}
+
 func (s *GrackleApiServerHandler) GetLock(ctx context.Context, req *gracklepb.GetLockRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.GetLockResponse, error) {
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
@@ -635,7 +966,52 @@ func (s *GrackleApiServerHandler) GetLock(ctx context.Context, req *gracklepb.Ge
 	}
 
 	return &gracklepb.GetLockResponse{
-		Lock: lockToFront(resp1.Lock),
+		Lock:         lockToFront(resp1.Lock),
+		WaitersCount: resp1.WaitersCount,
+	}, nil
+}
+
+func (s *GrackleApiServerHandler) ListLockWaiters(ctx context.Context, req *gracklepb.ListLockWaitersRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.ListLockWaitersResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Decode pagination token from base64-encoded format
+	paginationToken, err := paginationTokenToCore(req.PaginationToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	// List leases waiting for the lock with pagination
+	resp1, err := s.grackleClient.ListLockWaiters(ctx, &corepb.ListLockWaitersRequest{
+		LockId: &corepb.LockId{
+			AccountId:   accountId,
+			NamespaceId: namespace.Id.NamespaceId,
+			LockName:    req.LockName,
+		},
+		PaginationToken: paginationToken,
+		Limit:           req.Limit,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Encode pagination tokens for response
+	nextPaginationToken, err := paginationTokenToFront(resp1.NextPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+	previousPaginationToken, err := paginationTokenToFront(resp1.PreviousPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.ListLockWaitersResponse{
+		Waiters:                 lockWaitersToFront(resp1.Waiters),
+		NextPaginationToken:     nextPaginationToken,
+		PreviousPaginationToken: previousPaginationToken,
 	}, nil
 }
 
@@ -727,6 +1103,9 @@ func (s *GrackleApiServerHandler) CreateSemaphore(ctx context.Context, req *grac
 			Permits:                           req.Permits,
 			Metadata:                          req.Metadata,
 			MaxNumberOfSemaphoresPerNamespace: limits.MaxNumberOfSemaphoresPerNamespace,
+			ParentSemaphoreName:               req.ParentSemaphoreName,
+			Classes:                           semaphoreClassSpecsToCore(req.Classes),
+			DeleteInactiveAfterSeconds:        req.DeleteInactiveAfterSeconds,
 		})
 		if err != nil {
 			if isIDCollision(err) {
@@ -776,14 +1155,56 @@ func (s *GrackleApiServerHandler) ListSemaphores(ctx context.Context, req *grack
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
-	return &gracklepb.ListSemaphoresResponse{
-		Semaphores:              semaphoresToFront(resp1.Semaphores),
+	return &gracklepb.ListSemaphoresResponse{
+		Semaphores:              semaphoresToFront(resp1.Semaphores),
+		NextPaginationToken:     nextPaginationToken,
+		PreviousPaginationToken: previousPaginationToken,
+	}, nil
+}
+
+func (s *GrackleApiServerHandler) ListSemaphoreHolders(ctx context.Context, req *gracklepb.ListSemaphoreHoldersRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.ListSemaphoreHoldersResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Decode pagination token from base64-encoded format
+	paginationToken, err := paginationTokenToCore(req.PaginationToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	// List holders for the semaphore with pagination
+	resp1, err := s.grackleClient.ListSemaphoreHolders(ctx, &corepb.ListSemaphoreHoldersRequest{
+		NamespaceId:     namespace.Id,
+		SemaphoreName:   req.SemaphoreName,
+		PaginationToken: paginationToken,
+		Limit:           req.Limit,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Encode pagination tokens for response
+	nextPaginationToken, err := paginationTokenToFront(resp1.NextPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+	previousPaginationToken, err := paginationTokenToFront(resp1.PreviousPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.ListSemaphoreHoldersResponse{
+		Holders:                 semaphoreHoldersToFront(resp1.Holders),
 		NextPaginationToken:     nextPaginationToken,
 		PreviousPaginationToken: previousPaginationToken,
+		Classes:                 semaphoreClassesToFront(resp1.Classes),
 	}, nil
 }
 
-func (s *GrackleApiServerHandler) ListSemaphoreHolders(ctx context.Context, req *gracklepb.ListSemaphoreHoldersRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.ListSemaphoreHoldersResponse, error) {
+func (s *GrackleApiServerHandler) ListSemaphoreWaiters(ctx context.Context, req *gracklepb.ListSemaphoreWaitersRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.ListSemaphoreWaitersResponse, error) {
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
 	if err != nil {
@@ -796,8 +1217,8 @@ func (s *GrackleApiServerHandler) ListSemaphoreHolders(ctx context.Context, req
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
 	}
 
-	// List holders for the semaphore with pagination
-	resp1, err := s.grackleClient.ListSemaphoreHolders(ctx, &corepb.ListSemaphoreHoldersRequest{
+	// List leases waiting for the semaphore with pagination
+	resp1, err := s.grackleClient.ListSemaphoreWaiters(ctx, &corepb.ListSemaphoreWaitersRequest{
 		NamespaceId:     namespace.Id,
 		SemaphoreName:   req.SemaphoreName,
 		PaginationToken: paginationToken,
@@ -817,13 +1238,35 @@ func (s *GrackleApiServerHandler) ListSemaphoreHolders(ctx context.Context, req
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
-	return &gracklepb.ListSemaphoreHoldersResponse{
-		Holders:                 semaphoreHoldersToFront(resp1.Holders),
+	return &gracklepb.ListSemaphoreWaitersResponse{
+		Waiters:                 semaphoreWaitersToFront(resp1.Waiters),
 		NextPaginationToken:     nextPaginationToken,
 		PreviousPaginationToken: previousPaginationToken,
 	}, nil
 }
 
+func (s *GrackleApiServerHandler) GetSemaphoreStats(ctx context.Context, req *gracklepb.GetSemaphoreStatsRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.GetSemaphoreStatsResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Get the per-minute utilization of the semaphore over the window
+	resp1, err := s.grackleClient.GetSemaphoreStats(ctx, &corepb.GetSemaphoreStatsRequest{
+		NamespaceId:   namespace.Id,
+		SemaphoreName: req.SemaphoreName,
+		WindowSeconds: req.WindowSeconds,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.GetSemaphoreStatsResponse{
+		Buckets: semaphoreStatsBucketsToFront(resp1.Buckets),
+	}, nil
+}
+
 func (s *GrackleApiServerHandler) GetSemaphore(ctx context.Context, req *gracklepb.GetSemaphoreRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.GetSemaphoreResponse, error) {
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
@@ -841,11 +1284,17 @@ func (s *GrackleApiServerHandler) GetSemaphore(ctx context.Context, req *grackle
 	}
 
 	return &gracklepb.GetSemaphoreResponse{
-		Semaphore: semaphoreToFront(resp1.Semaphore),
+		Semaphore:    semaphoreToFront(resp1.Semaphore),
+		WaitersCount: resp1.WaitersCount,
 	}, nil
 }
 
 func (s *GrackleApiServerHandler) AcquireSemaphore(ctx context.Context, req *gracklepb.AcquireSemaphoreRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.AcquireSemaphoreResponse, error) {
+	// Validate the size of an auto-created semaphore doesn't exceed account limits
+	if req.DefaultPermits > limits.MaxNumberOfSemaphoreHolders {
+		return nil, status.Errorf(codes.InvalidArgument, "semaphore size is too big, max: %d", limits.MaxNumberOfSemaphoreHolders)
+	}
+
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
 	if err != nil {
@@ -865,25 +1314,55 @@ func (s *GrackleApiServerHandler) AcquireSemaphore(ctx context.Context, req *gra
 	// Calculate absolute deadline for timeout
 	deadline := time.Now().Add(time.Duration(req.TimeoutSeconds) * time.Second)
 
+	// While retrying, the lease is listed as a waiter on the semaphore until the deadline
+	waitUntil := int64(0)
+	if req.TimeoutSeconds > 0 {
+		waitUntil = deadline.UnixNano()
+	}
+
 	// Initialize polling with exponential backoff
 	pollInterval := 100 * time.Millisecond
 	maxPollInterval := 1 * time.Second
 
+	// A missing semaphore is auto-created with a generated ID when DefaultPermits
+	// is set. On the rare ID collision the core returns IDCollision; regenerate
+	// the ID and retry.
+	idCollisions := 0
+
 	for {
 		// Check if context is cancelled
 		if ctx.Err() != nil {
 			return nil, status.Errorf(codes.Canceled, "req cancelled")
 		}
 
+		// The attempt made once the deadline has passed is the last one, only
+		// its failure counts in the semaphore stats
+		lastAttempt := !time.Now().Before(deadline)
+
 		// Attempt to acquire semaphore with specified weight
 		resp1, err := s.grackleClient.AcquireSemaphore(ctx, &corepb.AcquireSemaphoreRequest{
-			NamespaceId:   namespace.Id,
-			SemaphoreName: req.SemaphoreName,
-			LeaseId:       leaseId.LeaseId,
-			Weight:        req.Weight,
-			Metadata:      req.Metadata,
+			NamespaceId:                       namespace.Id,
+			SemaphoreName:                     req.SemaphoreName,
+			LeaseId:                           leaseId.LeaseId,
+			Weight:                            req.Weight,
+			Metadata:                          req.Metadata,
+			Class:                             req.Class,
+			MaxHoldSeconds:                    req.MaxHoldSeconds,
+			WaitUntil:                         waitUntil,
+			DefaultPermits:                    req.DefaultPermits,
+			DefaultDeleteInactiveAfterSeconds: req.DefaultDeleteInactiveAfterSeconds,
+			NewSemaphoreId:                    rand.Uint64(),
+			MaxNumberOfSemaphoresPerNamespace: limits.MaxNumberOfSemaphoresPerNamespace,
+			WillRetry:                         !lastAttempt,
 		})
 		if err != nil {
+			if isIDCollision(err) {
+				idCollisions++
+				if idCollisions < maxIDGenerationAttempts {
+					continue
+				}
+				return nil, status.Error(codes.Internal, "failed to generate a unique semaphore id")
+			}
 			return nil, mrpc.ErrorToGRPC(err)
 		}
 
@@ -894,10 +1373,13 @@ func (s *GrackleApiServerHandler) AcquireSemaphore(ctx context.Context, req *gra
 				Outcome:   gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED,
 			}, nil
 		}
-		if time.Now().After(deadline) {
+		if lastAttempt {
 			return &gracklepb.AcquireSemaphoreResponse{
-				Semaphore: semaphoreToFront(resp1.Semaphore),
-				Outcome:   acquireFailureOutcome(req.TimeoutSeconds),
+				Semaphore:       semaphoreToFront(resp1.Semaphore),
+				Outcome:         acquireFailureOutcome(req.TimeoutSeconds),
+				MissingPermits:  resp1.MissingPermits,
+				BlockingHolders: semaphoreHoldersToFront(resp1.BlockingHolders),
+				EarliestGrantAt: resp1.EarliestGrantAt,
 			}, nil
 		}
 
@@ -950,9 +1432,132 @@ func (s *GrackleApiServerHandler) ReleaseSemaphore(ctx context.Context, req *gra
 	}, nil
 }
 
+func (s *GrackleApiServerHandler) AdjustSemaphoreHold(ctx context.Context, req *gracklepb.AdjustSemaphoreHoldRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.AdjustSemaphoreHoldResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Decode and validate lease ID
+	leaseId, err := ids.DecodeLeaseId(req.LeaseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid AdjustSemaphoreHoldRequest.LeaseId: %v", err)
+	}
+
+	if leaseId.AccountId != accountId || leaseId.NamespaceId != namespace.Id.NamespaceId {
+		return nil, status.Errorf(codes.NotFound, "lease not found")
+	}
+
+	// Shrinking succeeds on the first attempt; growing polls like AcquireSemaphore
+	deadline := time.Now().Add(time.Duration(req.TimeoutSeconds) * time.Second)
+
+	// Initialize polling with exponential backoff
+	pollInterval := 100 * time.Millisecond
+	maxPollInterval := 1 * time.Second
+
+	for {
+		// Check if context is cancelled
+		if ctx.Err() != nil {
+			return nil, status.Errorf(codes.Canceled, "req cancelled")
+		}
+
+		resp1, err := s.grackleClient.AdjustSemaphoreHold(ctx, &corepb.AdjustSemaphoreHoldRequest{
+			NamespaceId:   namespace.Id,
+			SemaphoreName: req.SemaphoreName,
+			LeaseId:       leaseId.LeaseId,
+			Weight:        req.Weight,
+		})
+		if err != nil {
+			return nil, mrpc.ErrorToGRPC(err)
+		}
+
+		// Return as soon as the hold is adjusted, or once the deadline passes.
+		if resp1.Success {
+			return &gracklepb.AdjustSemaphoreHoldResponse{
+				Semaphore: semaphoreToFront(resp1.Semaphore),
+				Holder:    semaphoreHolderToFront(resp1.Holder),
+				Outcome:   gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED,
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			return &gracklepb.AdjustSemaphoreHoldResponse{
+				Semaphore: semaphoreToFront(resp1.Semaphore),
+				Holder:    semaphoreHolderToFront(resp1.Holder),
+				Outcome:   acquireFailureOutcome(req.TimeoutSeconds),
+			}, nil
+		}
+
+		// Sleep with exponential backoff, respecting deadline
+		sleepDuration := pollInterval
+		if timeUntilDeadline := time.Until(deadline); timeUntilDeadline < sleepDuration {
+			sleepDuration = timeUntilDeadline
+		}
+
+		select {
+		case <-time.After(sleepDuration):
+			// Increase poll interval with exponential backoff
+			pollInterval = min(pollInterval*2, maxPollInterval)
+		case <-ctx.Done():
+			return nil, status.Errorf(codes.Canceled, "req cancelled")
+		}
+	}
+}
+
+func (s *GrackleApiServerHandler) TransferSemaphoreHold(ctx context.Context, req *gracklepb.TransferSemaphoreHoldRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.TransferSemaphoreHoldResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Decode and validate both lease IDs
+	fromLeaseId, err := ids.DecodeLeaseId(req.FromLeaseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid TransferSemaphoreHoldRequest.FromLeaseId: %v", err)
+	}
+
+	toLeaseId, err := ids.DecodeLeaseId(req.ToLeaseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid TransferSemaphoreHoldRequest.ToLeaseId: %v", err)
+	}
+
+	for _, leaseId := range []*corepb.LeaseId{fromLeaseId, toLeaseId} {
+		if leaseId.AccountId != accountId || leaseId.NamespaceId != namespace.Id.NamespaceId {
+			return nil, status.Errorf(codes.NotFound, "lease not found")
+		}
+	}
+
+	// Move the hold to the other lease
+	resp1, err := s.grackleClient.TransferSemaphoreHold(ctx, &corepb.TransferSemaphoreHoldRequest{
+		NamespaceId:   namespace.Id,
+		SemaphoreName: req.SemaphoreName,
+		FromLeaseId:   fromLeaseId.LeaseId,
+		ToLeaseId:     toLeaseId.LeaseId,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.TransferSemaphoreHoldResponse{
+		Semaphore: semaphoreToFront(resp1.Semaphore),
+		Holder:    semaphoreHolderToFront(resp1.Holder),
+	}, nil
+}
+
 func (s *GrackleApiServerHandler) UpdateSemaphore(ctx context.Context, req *gracklepb.UpdateSemaphoreRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.UpdateSemaphoreResponse, error) {
+	// A target below the current holds drains the semaphore instead of being rejected. It
+	// replaces permits, so a request must not set the two apart.
+	permits := req.Permits
+	if req.TargetPermits > 0 {
+		if req.Permits != 0 && req.Permits != req.TargetPermits {
+			return nil, status.Errorf(codes.InvalidArgument, "permits and target permits cannot differ, permits: %d, target permits: %d", req.Permits, req.TargetPermits)
+		}
+		permits = req.TargetPermits
+	}
+
 	// Validate semaphore size doesn't exceed account limits
-	if req.Permits > limits.MaxNumberOfSemaphoreHolders {
+	if permits > limits.MaxNumberOfSemaphoreHolders {
 		return nil, status.Errorf(codes.InvalidArgument, "semaphore size is too big, max: %d", limits.MaxNumberOfSemaphoreHolders)
 	}
 
@@ -964,12 +1569,14 @@ func (s *GrackleApiServerHandler) UpdateSemaphore(ctx context.Context, req *grac
 
 	// Update the semaphore
 	resp1, err := s.grackleClient.UpdateSemaphore(ctx, &corepb.UpdateSemaphoreRequest{
-		NamespaceId:     namespace.Id,
-		SemaphoreName:   req.SemaphoreName,
-		Description:     req.Description,
-		Permits:         req.Permits,
-		Metadata:        req.Metadata,
-		ExpectedVersion: req.ExpectedVersion,
+		NamespaceId:                namespace.Id,
+		SemaphoreName:              req.SemaphoreName,
+		Description:                req.Description,
+		Permits:                    permits,
+		Metadata:                   req.Metadata,
+		ExpectedVersion:            req.ExpectedVersion,
+		AllowDraining:              req.TargetPermits > 0,
+		DeleteInactiveAfterSeconds: req.DeleteInactiveAfterSeconds,
 	})
 	if err != nil {
 		return nil, mrpc.ErrorToGRPC(err)
@@ -1022,6 +1629,9 @@ func (s *GrackleApiServerHandler) CreateBarrier(ctx context.Context, req *grackl
 			Metadata:                        req.Metadata,
 			MaxNumberOfBarriersPerNamespace: limits.MaxNumberOfBarriersPerNamespace,
 			DeleteInactiveAfterSeconds:      req.DeleteInactiveAfterSeconds,
+			Roster:                          req.Roster,
+			RetainedGenerations:             req.RetainedGenerations,
+			ReleaseThreshold:                req.ReleaseThreshold,
 		})
 		if err != nil {
 			if isIDCollision(err) {
@@ -1095,7 +1705,8 @@ func (s *GrackleApiServerHandler) GetBarrier(ctx context.Context, req *gracklepb
 	}
 
 	return &gracklepb.GetBarrierResponse{
-		Barrier: barrierToFront(resp1.Barrier),
+		Barrier:           barrierToFront(resp1.Barrier),
+		MissingProcessIds: resp1.MissingProcessIds,
 	}, nil
 }
 
@@ -1142,13 +1753,17 @@ func (s *GrackleApiServerHandler) UpdateBarrier(ctx context.Context, req *grackl
 		Metadata:                   req.Metadata,
 		ExpectedVersion:            req.ExpectedVersion,
 		DeleteInactiveAfterSeconds: req.DeleteInactiveAfterSeconds,
+		Roster:                     req.Roster,
+		RetainedGenerations:        req.RetainedGenerations,
+		ReleaseThreshold:           req.ReleaseThreshold,
 	})
 	if err != nil {
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
 	return &gracklepb.UpdateBarrierResponse{
-		Barrier: barrierToFront(resp2.Barrier),
+		Barrier:           barrierToFront(resp2.Barrier),
+		MissingProcessIds: resp2.MissingProcessIds,
 	}, nil
 }
 
@@ -1166,14 +1781,17 @@ func (s *GrackleApiServerHandler) ArriveAtBarrier(ctx context.Context, req *grac
 		ProcessId:   req.ProcessId,
 		Generation:  req.ExpectedGeneration,
 		Metadata:    req.Metadata,
+		Payload:     req.Payload,
 	})
 	if err != nil {
 		return nil, mrpc.ErrorToGRPC(err)
 	}
 
 	return &gracklepb.ArriveAtBarrierResponse{
-		Barrier:    barrierToFront(resp1.Barrier),
-		AllArrived: resp1.AllArrived,
+		Barrier:           barrierToFront(resp1.Barrier),
+		AllArrived:        resp1.AllArrived,
+		MissingProcessIds: resp1.MissingProcessIds,
+		Late:              resp1.Late,
 	}, nil
 }
 
@@ -1217,17 +1835,27 @@ func (s *GrackleApiServerHandler) WaitAtBarrier(ctx context.Context, req *grackl
 			// no next-generation value is returned. barrier.Generation reflects where
 			// the barrier actually is now — it may already be further ahead if a later
 			// cohort tripped it again while this waiter was between polls.
+			// The participants of the tripped generation carry their payloads
+			// (all-gather).
+			participants, err := s.listAllBarrierParticipants(ctx, namespace.Id, req.BarrierName, req.ExpectedGeneration)
+			if err != nil {
+				return nil, mrpc.ErrorToGRPC(err)
+			}
+
 			return &gracklepb.WaitAtBarrierResponse{
-				Barrier: barrierToFront(resp1.Barrier),
-				Outcome: gracklepb.BarrierWaitOutcome_BARRIER_WAIT_OUTCOME_TRIPPED,
+				Barrier:      barrierToFront(resp1.Barrier),
+				Outcome:      gracklepb.BarrierWaitOutcome_BARRIER_WAIT_OUTCOME_TRIPPED,
+				Participants: barrierParticipantsToFront(participants),
 			}, nil
 		}
 
 		if time.Now().After(deadline) {
-			// Deadline passed without the barrier tripping.
+			// Deadline passed without the barrier tripping. Report the roster
+			// members that still have not arrived, if the barrier has a roster.
 			return &gracklepb.WaitAtBarrierResponse{
-				Barrier: barrierToFront(resp1.Barrier),
-				Outcome: gracklepb.BarrierWaitOutcome_BARRIER_WAIT_OUTCOME_TIMED_OUT,
+				Barrier:           barrierToFront(resp1.Barrier),
+				Outcome:           gracklepb.BarrierWaitOutcome_BARRIER_WAIT_OUTCOME_TIMED_OUT,
+				MissingProcessIds: resp1.MissingProcessIds,
 			}, nil
 		}
 
@@ -1247,6 +1875,34 @@ func (s *GrackleApiServerHandler) WaitAtBarrier(ctx context.Context, req *grackl
 	}
 }
 
+// listAllBarrierParticipants reads every participant of a single barrier
+// generation, following pagination to the end. Generations outside the
+// barrier's retention window have no participants left.
+func (s *GrackleApiServerHandler) listAllBarrierParticipants(ctx context.Context, namespaceId *corepb.NamespaceId, barrierName string, generation int64) ([]*corepb.BarrierParticipant, error) {
+	var participants []*corepb.BarrierParticipant
+	var paginationToken *corepb.PaginationToken
+
+	for {
+		resp, err := s.grackleClient.ListBarrierParticipants(ctx, &corepb.ListBarrierParticipantsRequest{
+			NamespaceId:     namespaceId,
+			BarrierName:     barrierName,
+			Generation:      generation,
+			PaginationToken: paginationToken,
+			Limit:           pagination.MaxPaginationLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		participants = append(participants, resp.Participants...)
+
+		if resp.NextPaginationToken == nil {
+			return participants, nil
+		}
+		paginationToken = resp.NextPaginationToken
+	}
+}
+
 func (s *GrackleApiServerHandler) ListBarrierParticipants(ctx context.Context, req *gracklepb.ListBarrierParticipantsRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.ListBarrierParticipantsResponse, error) {
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
@@ -1289,6 +1945,47 @@ func (s *GrackleApiServerHandler) ListBarrierParticipants(ctx context.Context, r
 	}, nil
 }
 
+func (s *GrackleApiServerHandler) ListBarrierGenerations(ctx context.Context, req *gracklepb.ListBarrierGenerationsRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.ListBarrierGenerationsResponse, error) {
+	// Resolve namespace by name to get its ID
+	namespace, err := s.getNamespace(accountId, req.NamespaceName)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Decode pagination token from base64-encoded format
+	paginationToken, err := paginationTokenToCore(req.PaginationToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	// List the barrier's generation history
+	resp1, err := s.grackleClient.ListBarrierGenerations(ctx, &corepb.ListBarrierGenerationsRequest{
+		NamespaceId:     namespace.Id,
+		BarrierName:     req.BarrierName,
+		PaginationToken: paginationToken,
+		Limit:           req.Limit,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	// Encode pagination tokens for response
+	nextPaginationToken, err := paginationTokenToFront(resp1.NextPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+	previousPaginationToken, err := paginationTokenToFront(resp1.PreviousPaginationToken)
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+
+	return &gracklepb.ListBarrierGenerationsResponse{
+		Generations:             barrierGenerationsToFront(resp1.Generations),
+		NextPaginationToken:     nextPaginationToken,
+		PreviousPaginationToken: previousPaginationToken,
+	}, nil
+}
+
 func (s *GrackleApiServerHandler) CreateSemaphoreLease(ctx context.Context, req *gracklepb.CreateSemaphoreLeaseRequest, accountId uint64, limits grackle.ServiceLimits) (*gracklepb.CreateSemaphoreLeaseResponse, error) {
 	// Resolve namespace by name to get its ID
 	namespace, err := s.getNamespace(accountId, req.NamespaceName)
@@ -1646,6 +2343,169 @@ func (s *GrackleApiServerHandler) getNamespace(accountId uint64, namespaceName s
 	})
 }
 
+// completeJobsOnStripes completes jobs of a striped wait group: every job is
+// recorded on the stripe it hashes to (see sharding.WaitGroupStripe), and the
+// counts of the stripes it touched are then aggregated into the wait group,
+// which finishes it once enough jobs are done. A stripe only takes jobs up to
+// the room left in the wait group by its other stripes, as last aggregated or
+// recorded by this call; if it rejects them, the stripes recorded so far are
+// still aggregated before the error is returned. Reads never look at the
+// stripes, so the wait group only moves on once its stripes are aggregated;
+// every step is idempotent, so a call that fails half way is retried to
+// aggregate the jobs it already recorded.
+func (s *GrackleApiServerHandler) completeJobsOnStripes(ctx context.Context, waitGroup *corepb.WaitGroup, jobs []*corepb.CompleteJobRequest) (*corepb.WaitGroup, error) {
+	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+		return nil, status.Errorf(codes.InvalidArgument, "only active wait groups can accept jobs, status: %s", waitGroup.Status)
+	}
+
+	jobsByStripe := make(map[uint32][]*corepb.CompleteJobRequest)
+	for _, job := range jobs {
+		stripe := sharding.WaitGroupStripe(job.JobId, waitGroup.Stripes)
+		jobsByStripe[stripe] = append(jobsByStripe[stripe], job)
+	}
+
+	var stripeErr error
+	stripeCounts := make([]*corepb.WaitGroupStripeCounts, 0, len(jobsByStripe))
+	for _, stripe := range slices.Sorted(maps.Keys(jobsByStripe)) {
+		resp1, err := s.grackleClient.CompleteJobsFromWaitGroupStripe(ctx, &corepb.CompleteJobsFromWaitGroupStripeRequest{
+			StripeId:        waitGroupStripeId(waitGroup.Id, stripe),
+			Jobs:            jobsByStripe[stripe],
+			DeleteAt:        stripeDeleteAt(waitGroup),
+			MaxFinishedJobs: stripeMaxFinishedJobs(waitGroup, stripe),
+		})
+		if err != nil {
+			var appErr *mrpc.Error
+			if !errors.As(err, &appErr) || len(stripeCounts) == 0 {
+				return nil, mrpc.ErrorToGRPC(err)
+			}
+			stripeErr = err
+			break
+		}
+
+		counts := &corepb.WaitGroupStripeCounts{
+			Stripe:        stripe,
+			CompletedJobs: resp1.Stripe.CompletedJobs,
+			FailedJobs:    resp1.Stripe.FailedJobs,
+		}
+		stripeCounts = append(stripeCounts, counts)
+		waitGroup.StripeCounts[stripe] = counts
+	}
+
+	resp2, err := s.grackleClient.AggregateWaitGroupStripes(ctx, &corepb.AggregateWaitGroupStripesRequest{
+		WaitGroupId:  waitGroup.Id,
+		StripeCounts: stripeCounts,
+	})
+	if err != nil {
+		return nil, mrpc.ErrorToGRPC(err)
+	}
+	if stripeErr != nil {
+		return nil, mrpc.ErrorToGRPC(stripeErr)
+	}
+
+	return resp2.WaitGroup, nil
+}
+
+// extendWaitGroupStripes postpones the deletion of every stripe of a striped
+// wait group to the latest time the wait group itself can be deleted.
+func (s *GrackleApiServerHandler) extendWaitGroupStripes(ctx context.Context, waitGroup *corepb.WaitGroup) error {
+	for stripe := range waitGroup.Stripes {
+		_, err := s.grackleClient.ExtendWaitGroupStripe(ctx, &corepb.ExtendWaitGroupStripeRequest{
+			StripeId: waitGroupStripeId(waitGroup.Id, stripe),
+			DeleteAt: stripeDeleteAt(waitGroup),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listStripedWaitGroupCompletedJobs returns a page of the completed jobs of a
+// striped wait group. Jobs are listed stripe by stripe, the pagination token
+// carrying the stripe it pages in; a page never spans two stripes, and
+// previous pagination tokens only page back within a stripe.
+func (s *GrackleApiServerHandler) listStripedWaitGroupCompletedJobs(ctx context.Context, waitGroup *corepb.WaitGroup, paginationToken *corepb.PaginationToken, limit int32) (*corepb.ListWaitGroupCompletedJobsResponse, error) {
+	stripe := uint32(0)
+	if paginationToken != nil {
+		stripe = paginationToken.Stripe
+		if stripe >= waitGroup.Stripes {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid pagination token")
+		}
+
+		// A token without a position starts at the beginning of its stripe
+		if len(paginationToken.Value) == 0 {
+			paginationToken = nil
+		}
+	}
+
+	withStripe := func(paginationToken *corepb.PaginationToken, stripe uint32) *corepb.PaginationToken {
+		if paginationToken != nil {
+			paginationToken.Stripe = stripe
+		}
+		return paginationToken
+	}
+
+	for {
+		resp1, err := s.grackleClient.ListWaitGroupStripeCompletedJobs(ctx, &corepb.ListWaitGroupStripeCompletedJobsRequest{
+			StripeId:        waitGroupStripeId(waitGroup.Id, stripe),
+			PaginationToken: paginationToken,
+			Limit:           limit,
+		})
+		if err != nil {
+			return nil, mrpc.ErrorToGRPC(err)
+		}
+
+		lastStripe := stripe+1 >= waitGroup.Stripes
+
+		// Skip over stripes without jobs
+		if len(resp1.Jobs) == 0 && resp1.NextPaginationToken == nil && !lastStripe {
+			stripe++
+			paginationToken = nil
+			continue
+		}
+
+		nextPaginationToken := withStripe(resp1.NextPaginationToken, stripe)
+		if nextPaginationToken == nil && !lastStripe {
+			nextPaginationToken = &corepb.PaginationToken{Stripe: stripe + 1}
+		}
+
+		return &corepb.ListWaitGroupCompletedJobsResponse{
+			Jobs:                    resp1.Jobs,
+			NextPaginationToken:     nextPaginationToken,
+			PreviousPaginationToken: withStripe(resp1.PreviousPaginationToken, stripe),
+		}, nil
+	}
+}
+
+// stripeMaxFinishedJobs returns the most jobs a stripe of a striped wait group
+// may hold: its counter minus the jobs aggregated from its other stripes.
+func stripeMaxFinishedJobs(waitGroup *corepb.WaitGroup, stripe uint32) int64 {
+	maxFinishedJobs := waitGroup.Counter
+	for _, counts := range waitGroup.StripeCounts {
+		if counts.Stripe != stripe {
+			maxFinishedJobs -= counts.CompletedJobs + counts.FailedJobs
+		}
+	}
+	return maxFinishedJobs
+}
+
+// stripeDeleteAt returns the latest time a striped wait group can be deleted,
+// which its stripes must outlive: a wait group finishes by expires_at at the
+// latest, and is deleted delete_after_finished_seconds after it finishes.
+func stripeDeleteAt(waitGroup *corepb.WaitGroup) int64 {
+	return waitGroup.ExpiresAt + waitGroup.DeleteAfterFinishedSeconds*int64(time.Second)
+}
+
+func waitGroupStripeId(waitGroupId *corepb.WaitGroupId, stripe uint32) *corepb.WaitGroupStripeId {
+	return &corepb.WaitGroupStripeId{
+		AccountId:   waitGroupId.AccountId,
+		NamespaceId: waitGroupId.NamespaceId,
+		WaitGroupId: waitGroupId.WaitGroupId,
+		Stripe:      stripe,
+	}
+}
+
 // NewGrackleApiServerHandler builds a handler backed by the given core client.
 func NewGrackleApiServerHandler(grackleClient coreapis.GrackleClientApi) *GrackleApiServerHandler {
 	return &GrackleApiServerHandler{
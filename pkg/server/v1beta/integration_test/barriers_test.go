@@ -604,6 +604,56 @@ func TestListBarrierParticipants(t *testing.T) {
 	})
 }
 
+func TestListBarrierGenerations(t *testing.T) {
+	server := setupGrackleApiServer(t)
+	ctx := context.Background()
+
+	// Create namespace
+	_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+		Name: "namespace1",
+	})
+	require.NoError(t, err)
+
+	// Create barrier expecting 2 processes
+	_, err = server.CreateBarrier(ctx, &gracklepb.CreateBarrierRequest{
+		NamespaceName:              "namespace1",
+		BarrierName:                "barrier1",
+		ExpectedProcesses:          2,
+		DeleteInactiveAfterSeconds: int64((10 * time.Minute).Seconds()),
+	})
+	require.NoError(t, err)
+
+	// Trip generation 1
+	for _, processId := range []string{"process_1", "process_2"} {
+		_, err := server.ArriveAtBarrier(ctx, &gracklepb.ArriveAtBarrierRequest{
+			NamespaceName:      "namespace1",
+			BarrierName:        "barrier1",
+			ProcessId:          processId,
+			ExpectedGeneration: 1,
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := server.ListBarrierGenerations(ctx, &gracklepb.ListBarrierGenerationsRequest{
+		NamespaceName: "namespace1",
+		BarrierName:   "barrier1",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Generations, 2)
+	require.EqualValues(t, 1, resp.Generations[0].Generation)
+	require.NotZero(t, resp.Generations[0].TrippedAt)
+	require.Equal(t, "process_2", resp.Generations[0].LastProcessId)
+	require.EqualValues(t, 2, resp.Generations[1].Generation)
+	require.Zero(t, resp.Generations[1].TrippedAt)
+
+	// Nonexistent barrier
+	_, err = server.ListBarrierGenerations(ctx, &gracklepb.ListBarrierGenerationsRequest{
+		NamespaceName: "namespace1",
+		BarrierName:   "barrier2",
+	})
+	require.Error(t, err)
+}
+
 func TestWaitAtBarrier(t *testing.T) {
 	t.Run("validation", func(t *testing.T) {
 		server := setupGrackleApiServer(t)
@@ -200,6 +200,104 @@ func TestReleaseLock(t *testing.T) {
 	})
 }
 
+func TestTransferLock(t *testing.T) {
+	t.Run("handoff", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			server, closeServer := newGrackleApiServer(t)
+			defer closeServer()
+			ctx := context.Background()
+
+			_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+				Name: "test-namespace",
+			})
+			require.NoError(t, err)
+
+			oldLease, err := server.CreateLockLease(ctx, &gracklepb.CreateLockLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "old",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			newLease, err := server.CreateLockLease(ctx, &gracklepb.CreateLockLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "new",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			acqResp, err := server.AcquireLock(ctx, &gracklepb.AcquireLockRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+				LeaseId:       oldLease.Lease.LeaseId,
+				Exclusive:     true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, acqResp.Outcome)
+
+			resp, err := server.TransferLock(ctx, &gracklepb.TransferLockRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+				FromLeaseId:   oldLease.Lease.LeaseId,
+				ToLeaseId:     newLease.Lease.LeaseId,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED, resp.Lock.State)
+			require.Len(t, resp.Lock.LockHolders, 1)
+			require.Equal(t, newLease.Lease.LeaseId, resp.Lock.LockHolders[0].LeaseId)
+
+			// Revoking the old lease no longer releases the lock
+			_, err = server.RevokeLockLease(ctx, &gracklepb.RevokeLockLeaseRequest{
+				NamespaceName: "test-namespace",
+				LeaseId:       oldLease.Lease.LeaseId,
+			})
+			require.NoError(t, err)
+
+			getResp, err := server.GetLock(ctx, &gracklepb.GetLockRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.LockState_LOCK_STATE_EXCLUSIVE_LOCKED, getResp.Lock.State)
+			require.Equal(t, newLease.Lease.LeaseId, getResp.Lock.LockHolders[0].LeaseId)
+		})
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		resp1, err := server.CreateLockLease(ctx, &gracklepb.CreateLockLeaseRequest{
+			NamespaceName: "namespace1",
+			TtlSeconds:    30,
+			ProcessId:     "process_1",
+		})
+		require.NoError(t, err)
+
+		// Invalid request - same lease on both sides
+		_, err = server.TransferLock(ctx, &gracklepb.TransferLockRequest{
+			NamespaceName: "namespace1",
+			LockName:      "lock1",
+			FromLeaseId:   resp1.Lease.LeaseId,
+			ToLeaseId:     resp1.Lease.LeaseId,
+		})
+		require.Error(t, err)
+
+		// Invalid request - missing target lease id
+		_, err = server.TransferLock(ctx, &gracklepb.TransferLockRequest{
+			NamespaceName: "namespace1",
+			LockName:      "lock1",
+			FromLeaseId:   resp1.Lease.LeaseId,
+		})
+		require.Error(t, err)
+	})
+}
+
 func TestGetLock(t *testing.T) {
 	t.Run("validation", func(t *testing.T) {
 		server := setupGrackleApiServer(t)
@@ -228,6 +326,123 @@ func TestGetLock(t *testing.T) {
 	})
 }
 
+func TestListLockWaiters(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		// Valid request
+		resp, err := server.ListLockWaiters(ctx, &gracklepb.ListLockWaitersRequest{
+			NamespaceName: "namespace1",
+			LockName:      "lock1",
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Waiters)
+
+		// Invalid request - invalid namespace name
+		_, err = server.ListLockWaiters(ctx, &gracklepb.ListLockWaitersRequest{
+			NamespaceName: "invalid@namespace",
+			LockName:      "lock1",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("blocking", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			server, closeServer := newGrackleApiServer(t)
+			defer closeServer()
+			ctx := context.Background()
+
+			// Create namespace
+			_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+				Name: "test-namespace",
+			})
+			require.NoError(t, err)
+
+			// Create first lease and acquire the lock exclusively, making it busy
+			holderLease, err := server.CreateLockLease(ctx, &gracklepb.CreateLockLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "holder",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			_, err = server.AcquireLock(ctx, &gracklepb.AcquireLockRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+				LeaseId:       holderLease.Lease.LeaseId,
+				Exclusive:     true,
+			})
+			require.NoError(t, err)
+
+			// Create second lease for the waiter
+			waiterLease, err := server.CreateLockLease(ctx, &gracklepb.CreateLockLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "waiter",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			// Block on the busy lock from another goroutine
+			done := make(chan *gracklepb.AcquireLockResponse)
+			go func() {
+				resp, _ := server.AcquireLock(ctx, &gracklepb.AcquireLockRequest{
+					NamespaceName:  "test-namespace",
+					LockName:       "test-lock",
+					LeaseId:        waiterLease.Lease.LeaseId,
+					Exclusive:      true,
+					TimeoutSeconds: 10,
+				})
+				done <- resp
+			}()
+			time.Sleep(500 * time.Millisecond)
+
+			// The blocked lease is listed as a waiter
+			resp1, err := server.ListLockWaiters(ctx, &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+			})
+			require.NoError(t, err)
+			require.Len(t, resp1.Waiters, 1)
+			require.Equal(t, waiterLease.Lease.LeaseId, resp1.Waiters[0].LeaseId)
+			require.Equal(t, "waiter", resp1.Waiters[0].ProcessId)
+			require.True(t, resp1.Waiters[0].Exclusive)
+
+			resp2, err := server.GetLock(ctx, &gracklepb.GetLockRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+			})
+			require.NoError(t, err)
+			require.Equal(t, int64(1), resp2.WaitersCount)
+
+			// Once the holder releases, the waiter acquires and is no longer listed
+			_, err = server.ReleaseLock(ctx, &gracklepb.ReleaseLockRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+				LeaseId:       holderLease.Lease.LeaseId,
+			})
+			require.NoError(t, err)
+
+			resp3 := <-done
+			require.NotNil(t, resp3)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, resp3.Outcome)
+
+			resp4, err := server.ListLockWaiters(ctx, &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "test-namespace",
+				LockName:      "test-lock",
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp4.Waiters)
+		})
+	})
+}
+
 func TestDeleteLock(t *testing.T) {
 	t.Run("validation", func(t *testing.T) {
 		server := setupGrackleApiServer(t)
@@ -294,6 +294,254 @@ func TestAcquireSemaphore(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_TIMED_OUT, resp.Outcome)
+		// The single permit is missing and only the holder's release would free it
+		require.EqualValues(t, 1, resp.MissingPermits)
+		require.Len(t, resp.BlockingHolders, 1)
+		require.Equal(t, holderLease.Lease.LeaseId, resp.BlockingHolders[0].LeaseId)
+		require.NotZero(t, resp.EarliestGrantAt)
+	})
+
+	t.Run("auto-create", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		// Create lease
+		lease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+			NamespaceName: "namespace1",
+			TtlSeconds:    30,
+			ProcessId:     "process_1",
+		})
+		require.NoError(t, err)
+
+		// Without default permits a missing semaphore is not found
+		_, err = server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+			NamespaceName: "namespace1",
+			SemaphoreName: "customer_1",
+			LeaseId:       lease.Lease.LeaseId,
+			Weight:        1,
+		})
+		require.Error(t, err)
+
+		// With default permits the semaphore is created on first acquire
+		resp, err := server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+			NamespaceName:                     "namespace1",
+			SemaphoreName:                     "customer_1",
+			LeaseId:                           lease.Lease.LeaseId,
+			Weight:                            1,
+			DefaultPermits:                    3,
+			DefaultDeleteInactiveAfterSeconds: 600,
+		})
+		require.NoError(t, err)
+		require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, resp.Outcome)
+		require.EqualValues(t, 3, resp.Semaphore.Permits)
+		require.EqualValues(t, 600, resp.Semaphore.DeleteInactiveAfterSeconds)
+
+		semaphore, err := server.GetSemaphore(ctx, &gracklepb.GetSemaphoreRequest{
+			NamespaceName: "namespace1",
+			SemaphoreName: "customer_1",
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, semaphore.Semaphore.ActiveHolds)
+	})
+}
+
+func TestAdjustSemaphoreHold(t *testing.T) {
+	t.Run("shrink and grow", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			server, closeServer := newGrackleApiServer(t)
+			defer closeServer()
+			ctx := context.Background()
+
+			_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+				Name: "test-namespace",
+			})
+			require.NoError(t, err)
+
+			_, err = server.CreateSemaphore(ctx, &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				Permits:       10,
+			})
+			require.NoError(t, err)
+
+			holderLease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "holder",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			otherLease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "other",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			acqResp, err := server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				LeaseId:       holderLease.Lease.LeaseId,
+				Weight:        8,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, acqResp.Outcome)
+
+			// Shrinking gives back permits right away
+			resp, err := server.AdjustSemaphoreHold(ctx, &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				LeaseId:       holderLease.Lease.LeaseId,
+				Weight:        4,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, resp.Outcome)
+			require.EqualValues(t, 4, resp.Semaphore.ActiveHolds)
+			require.EqualValues(t, 4, resp.Holder.Weight)
+
+			acqResp, err = server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				LeaseId:       otherLease.Lease.LeaseId,
+				Weight:        6,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, acqResp.Outcome)
+
+			// Growing without waiting fails while the permits are taken
+			resp, err = server.AdjustSemaphoreHold(ctx, &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				LeaseId:       holderLease.Lease.LeaseId,
+				Weight:        8,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_UNAVAILABLE, resp.Outcome)
+			require.EqualValues(t, 4, resp.Holder.Weight)
+
+			go func() {
+				time.Sleep(500 * time.Millisecond)
+				_, _ = server.ReleaseSemaphore(ctx, &gracklepb.ReleaseSemaphoreRequest{
+					NamespaceName: "test-namespace",
+					SemaphoreName: "test-semaphore",
+					LeaseId:       otherLease.Lease.LeaseId,
+				})
+			}()
+
+			// Growing blocks until the other holder releases
+			resp, err = server.AdjustSemaphoreHold(ctx, &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName:  "test-namespace",
+				SemaphoreName:  "test-semaphore",
+				LeaseId:        holderLease.Lease.LeaseId,
+				Weight:         8,
+				TimeoutSeconds: 10,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, resp.Outcome)
+			require.EqualValues(t, 8, resp.Semaphore.ActiveHolds)
+		})
+	})
+}
+
+func TestTransferSemaphoreHold(t *testing.T) {
+	t.Run("handoff", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			server, closeServer := newGrackleApiServer(t)
+			defer closeServer()
+			ctx := context.Background()
+
+			_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+				Name: "test-namespace",
+			})
+			require.NoError(t, err)
+
+			_, err = server.CreateSemaphore(ctx, &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				Permits:       10,
+			})
+			require.NoError(t, err)
+
+			oldLease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "old",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			newLease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "new",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			acqResp, err := server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				LeaseId:       oldLease.Lease.LeaseId,
+				Weight:        6,
+			})
+			require.NoError(t, err)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, acqResp.Outcome)
+
+			resp, err := server.TransferSemaphoreHold(ctx, &gracklepb.TransferSemaphoreHoldRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				FromLeaseId:   oldLease.Lease.LeaseId,
+				ToLeaseId:     newLease.Lease.LeaseId,
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 6, resp.Semaphore.ActiveHolds)
+			require.Equal(t, newLease.Lease.LeaseId, resp.Holder.LeaseId)
+			require.EqualValues(t, 6, resp.Holder.Weight)
+
+			// Revoking the old lease leaves the permits with the new one
+			_, err = server.RevokeSemaphoreLease(ctx, &gracklepb.RevokeSemaphoreLeaseRequest{
+				NamespaceName: "test-namespace",
+				LeaseId:       oldLease.Lease.LeaseId,
+			})
+			require.NoError(t, err)
+
+			getResp, err := server.GetSemaphore(ctx, &gracklepb.GetSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 6, getResp.Semaphore.ActiveHolds)
+		})
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		resp1, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+			NamespaceName: "namespace1",
+			TtlSeconds:    30,
+			ProcessId:     "process_1",
+		})
+		require.NoError(t, err)
+
+		// Invalid request - same lease on both sides
+		_, err = server.TransferSemaphoreHold(ctx, &gracklepb.TransferSemaphoreHoldRequest{
+			NamespaceName: "namespace1",
+			SemaphoreName: "semaphore1",
+			FromLeaseId:   resp1.Lease.LeaseId,
+			ToLeaseId:     resp1.Lease.LeaseId,
+		})
+		require.Error(t, err)
 	})
 }
 
@@ -420,6 +668,45 @@ func TestUpdateSemaphore(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), fmt.Sprintf("semaphore size is too big, max: %d", grackle.DefaultServiceLimits.MaxNumberOfSemaphoreHolders))
 	})
+
+	t.Run("permits_and_target_permits", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		// Create semaphore
+		_, err = server.CreateSemaphore(ctx, &gracklepb.CreateSemaphoreRequest{
+			NamespaceName: "namespace1",
+			SemaphoreName: "semaphore1",
+			Permits:       10,
+		})
+		require.NoError(t, err)
+
+		// Invalid request - permits and target permits differ
+		_, err = server.UpdateSemaphore(ctx, &gracklepb.UpdateSemaphoreRequest{
+			NamespaceName:   "namespace1",
+			SemaphoreName:   "semaphore1",
+			Permits:         8,
+			TargetPermits:   5,
+			ExpectedVersion: 1,
+		})
+		require.Error(t, err)
+
+		// Valid request - target permits only
+		resp, err := server.UpdateSemaphore(ctx, &gracklepb.UpdateSemaphoreRequest{
+			NamespaceName:   "namespace1",
+			SemaphoreName:   "semaphore1",
+			TargetPermits:   5,
+			ExpectedVersion: 1,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 5, resp.Semaphore.Permits)
+	})
 }
 
 func TestDeleteSemaphore(t *testing.T) {
@@ -696,6 +983,206 @@ func TestListSemaphoreHolders(t *testing.T) {
 	})
 }
 
+func TestListSemaphoreWaiters(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		// Create semaphore
+		_, err = server.CreateSemaphore(ctx, &gracklepb.CreateSemaphoreRequest{
+			NamespaceName: "namespace1",
+			SemaphoreName: "semaphore1",
+			Permits:       10,
+		})
+		require.NoError(t, err)
+
+		// Valid request
+		resp, err := server.ListSemaphoreWaiters(ctx, &gracklepb.ListSemaphoreWaitersRequest{
+			NamespaceName: "namespace1",
+			SemaphoreName: "semaphore1",
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Waiters)
+
+		// Invalid request - invalid namespace name
+		_, err = server.ListSemaphoreWaiters(ctx, &gracklepb.ListSemaphoreWaitersRequest{
+			NamespaceName: "invalid@namespace",
+			SemaphoreName: "semaphore1",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("blocking", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			server, closeServer := newGrackleApiServer(t)
+			defer closeServer()
+			ctx := context.Background()
+
+			// Create namespace
+			_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+				Name: "test-namespace",
+			})
+			require.NoError(t, err)
+
+			// Create a semaphore with a single permit
+			_, err = server.CreateSemaphore(ctx, &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				Permits:       1,
+			})
+			require.NoError(t, err)
+
+			// Create first lease and take the only permit
+			holderLease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "holder",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			_, err = server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				LeaseId:       holderLease.Lease.LeaseId,
+				Weight:        1,
+			})
+			require.NoError(t, err)
+
+			// Create second lease for the waiter
+			waiterLease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+				NamespaceName: "test-namespace",
+				ProcessId:     "waiter",
+				TtlSeconds:    30,
+			})
+			require.NoError(t, err)
+
+			// Block on the exhausted semaphore from another goroutine
+			done := make(chan *gracklepb.AcquireSemaphoreResponse)
+			go func() {
+				resp, _ := server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+					NamespaceName:  "test-namespace",
+					SemaphoreName:  "test-semaphore",
+					LeaseId:        waiterLease.Lease.LeaseId,
+					Weight:         1,
+					TimeoutSeconds: 10,
+				})
+				done <- resp
+			}()
+			time.Sleep(500 * time.Millisecond)
+
+			// The blocked lease is listed as a waiter
+			resp1, err := server.ListSemaphoreWaiters(ctx, &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+			})
+			require.NoError(t, err)
+			require.Len(t, resp1.Waiters, 1)
+			require.Equal(t, waiterLease.Lease.LeaseId, resp1.Waiters[0].LeaseId)
+			require.Equal(t, "waiter", resp1.Waiters[0].ProcessId)
+			require.Equal(t, int64(1), resp1.Waiters[0].Weight)
+
+			resp2, err := server.GetSemaphore(ctx, &gracklepb.GetSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+			})
+			require.NoError(t, err)
+			require.Equal(t, int64(1), resp2.WaitersCount)
+
+			// Once the holder releases, the waiter acquires and is no longer listed
+			_, err = server.ReleaseSemaphore(ctx, &gracklepb.ReleaseSemaphoreRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+				LeaseId:       holderLease.Lease.LeaseId,
+			})
+			require.NoError(t, err)
+
+			resp3 := <-done
+			require.NotNil(t, resp3)
+			require.Equal(t, gracklepb.AcquireOutcome_ACQUIRE_OUTCOME_ACQUIRED, resp3.Outcome)
+
+			resp4, err := server.ListSemaphoreWaiters(ctx, &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "test-namespace",
+				SemaphoreName: "test-semaphore",
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp4.Waiters)
+		})
+	})
+}
+
+func TestGetSemaphoreStats(t *testing.T) {
+	server := setupGrackleApiServer(t)
+	ctx := context.Background()
+
+	// Create namespace
+	_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+		Name: "namespace1",
+	})
+	require.NoError(t, err)
+
+	// Create semaphore
+	_, err = server.CreateSemaphore(ctx, &gracklepb.CreateSemaphoreRequest{
+		NamespaceName: "namespace1",
+		SemaphoreName: "semaphore1",
+		Permits:       5,
+	})
+	require.NoError(t, err)
+
+	// Create lease and acquire part of the permits
+	lease, err := server.CreateSemaphoreLease(ctx, &gracklepb.CreateSemaphoreLeaseRequest{
+		NamespaceName: "namespace1",
+		TtlSeconds:    30,
+		ProcessId:     "process_1",
+	})
+	require.NoError(t, err)
+
+	_, err = server.AcquireSemaphore(ctx, &gracklepb.AcquireSemaphoreRequest{
+		NamespaceName: "namespace1",
+		SemaphoreName: "semaphore1",
+		LeaseId:       lease.Lease.LeaseId,
+		Weight:        3,
+	})
+	require.NoError(t, err)
+
+	// Valid request
+	resp, err := server.GetSemaphoreStats(ctx, &gracklepb.GetSemaphoreStatsRequest{
+		NamespaceName: "namespace1",
+		SemaphoreName: "semaphore1",
+		WindowSeconds: 3600,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Buckets, 61)
+	// The acquire may have landed in the minute before the current one
+	acquiresSucceeded := int64(0)
+	for _, bucket := range resp.Buckets {
+		acquiresSucceeded += bucket.AcquiresSucceeded
+	}
+	require.EqualValues(t, 1, acquiresSucceeded)
+	require.EqualValues(t, 3, resp.Buckets[len(resp.Buckets)-1].MaxActiveHolds)
+
+	// Invalid request - window too long
+	_, err = server.GetSemaphoreStats(ctx, &gracklepb.GetSemaphoreStatsRequest{
+		NamespaceName: "namespace1",
+		SemaphoreName: "semaphore1",
+		WindowSeconds: 2 * 86400,
+	})
+	require.Error(t, err)
+
+	// Semaphore does not exist
+	_, err = server.GetSemaphoreStats(ctx, &gracklepb.GetSemaphoreStatsRequest{
+		NamespaceName: "namespace1",
+		SemaphoreName: "semaphore2",
+		WindowSeconds: 3600,
+	})
+	require.Error(t, err)
+}
+
 func TestCreateSemaphoreLease(t *testing.T) {
 	t.Run("validation", func(t *testing.T) {
 		server := setupGrackleApiServer(t)
@@ -8,7 +8,9 @@ import (
 
 	gracklepb "github.com/evrblk/evrblk-go/grackle/v1beta"
 	"github.com/evrblk/grackle/pkg/grackle"
+	"github.com/evrblk/grackle/pkg/server/v1beta"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 // completeJobs builds a slice of CompleteJobRequest from plain job ids
@@ -261,6 +263,323 @@ func TestCompleteJobsFromWaitGroup(t *testing.T) {
 		})
 		require.Error(t, err)
 	})
+
+	t.Run("aggregations", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		// Create wait group totalling the rows processed by its jobs
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "namespace1",
+			WaitGroupName:              "waitgroup1",
+			Counter:                    3,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+			Aggregations: []*gracklepb.WaitGroupAggregationSpec{
+				{MetadataKey: "rows", Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+				{MetadataKey: "rows", Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX},
+			},
+		})
+		require.NoError(t, err)
+
+		// Complete jobs, reporting one of them twice
+		for _, jobs := range [][]*gracklepb.CompleteJobRequest{
+			{{JobId: "job1", Metadata: map[string]string{"rows": "100"}}},
+			{{JobId: "job1", Metadata: map[string]string{"rows": "100"}}, {JobId: "job2", Metadata: map[string]string{"rows": "250"}}},
+		} {
+			_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+				NamespaceName: "namespace1",
+				WaitGroupName: "waitgroup1",
+				Jobs:          jobs,
+			})
+			require.NoError(t, err)
+		}
+
+		resp, err := server.GetWaitGroup(ctx, &gracklepb.GetWaitGroupRequest{
+			NamespaceName: "namespace1",
+			WaitGroupName: "waitgroup1",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.WaitGroup.Aggregates, 2)
+		require.EqualValues(t, 350, resp.WaitGroup.Aggregates[0].Value)
+		require.EqualValues(t, 2, resp.WaitGroup.Aggregates[0].NumberOfValues)
+		require.EqualValues(t, 250, resp.WaitGroup.Aggregates[1].Value)
+	})
+
+	t.Run("integer job ids", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		// Create wait group with integer job ids
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "namespace1",
+			WaitGroupName:              "waitgroup1",
+			Counter:                    3,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+			IntegerJobIds:              true,
+		})
+		require.NoError(t, err)
+
+		// Job ids outside of 0..counter-1 are rejected
+		_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceName: "namespace1",
+			WaitGroupName: "waitgroup1",
+			Jobs:          []*gracklepb.CompleteJobRequest{{JobId: "3"}},
+		})
+		require.Error(t, err)
+
+		resp, err := server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceName: "namespace1",
+			WaitGroupName: "waitgroup1",
+			Jobs: []*gracklepb.CompleteJobRequest{
+				{JobId: "2"},
+				{JobId: "0", Metadata: map[string]string{"key": "value"}},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, resp.WaitGroup.IntegerJobIds)
+		require.EqualValues(t, 2, resp.WaitGroup.CompletedJobs)
+
+		jobsResp, err := server.ListWaitGroupCompletedJobs(ctx, &gracklepb.ListWaitGroupCompletedJobsRequest{
+			NamespaceName: "namespace1",
+			WaitGroupName: "waitgroup1",
+		})
+		require.NoError(t, err)
+		require.Len(t, jobsResp.Jobs, 2)
+		require.Equal(t, "0", jobsResp.Jobs[0].JobId)
+		require.Equal(t, "value", jobsResp.Jobs[0].Metadata["key"])
+		require.Equal(t, "2", jobsResp.Jobs[1].JobId)
+	})
+
+	t.Run("striped wait group", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "namespace1",
+		})
+		require.NoError(t, err)
+
+		// Create wait group with 4 stripes
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "namespace1",
+			WaitGroupName:              "waitgroup1",
+			Counter:                    10,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+			Stripes:                    4,
+		})
+		require.NoError(t, err)
+
+		// Completing the same jobs twice counts them once
+		for range 2 {
+			resp, err := server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+				NamespaceName: "namespace1",
+				WaitGroupName: "waitgroup1",
+				Jobs:          completeJobs([]string{"job1", "job2", "job3", "job4", "job5"}),
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 4, resp.WaitGroup.Stripes)
+			require.EqualValues(t, 5, resp.WaitGroup.CompletedJobs)
+			require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, resp.WaitGroup.Status)
+		}
+
+		getResp, err := server.GetWaitGroup(ctx, &gracklepb.GetWaitGroupRequest{
+			NamespaceName: "namespace1",
+			WaitGroupName: "waitgroup1",
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 5, getResp.WaitGroup.CompletedJobs)
+
+		// Completing the rest completes the wait group
+		resp, err := server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceName: "namespace1",
+			WaitGroupName: "waitgroup1",
+			Jobs:          completeJobs([]string{"job6", "job7", "job8", "job9", "job10"}),
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 10, resp.WaitGroup.CompletedJobs)
+		require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, resp.WaitGroup.Status)
+
+		// Page through the jobs of every stripe
+		jobIds := make([]string, 0, 10)
+		paginationToken := ""
+		for {
+			jobsResp, err := server.ListWaitGroupCompletedJobs(ctx, &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName:   "namespace1",
+				WaitGroupName:   "waitgroup1",
+				PaginationToken: paginationToken,
+				Limit:           2,
+			})
+			require.NoError(t, err)
+			for _, job := range jobsResp.Jobs {
+				jobIds = append(jobIds, job.JobId)
+			}
+			if jobsResp.NextPaginationToken == "" {
+				break
+			}
+			paginationToken = jobsResp.NextPaginationToken
+		}
+		require.ElementsMatch(t, []string{"job1", "job2", "job3", "job4", "job5", "job6", "job7", "job8", "job9", "job10"}, jobIds)
+
+		// Deleting it deletes its stripes, and deleting it again is a no-op
+		for range 2 {
+			_, err = server.DeleteWaitGroup(ctx, &gracklepb.DeleteWaitGroupRequest{
+				NamespaceName: "namespace1",
+				WaitGroupName: "waitgroup1",
+			})
+			require.NoError(t, err)
+		}
+
+		_, err = server.GetWaitGroup(ctx, &gracklepb.GetWaitGroupRequest{
+			NamespaceName: "namespace1",
+			WaitGroupName: "waitgroup1",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestUncompleteJobsFromWaitGroup(t *testing.T) {
+	server := setupGrackleApiServer(t)
+	ctx := context.Background()
+
+	// Create namespace
+	_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+		Name: "namespace1",
+	})
+	require.NoError(t, err)
+
+	// Create wait group and complete all of its jobs
+	_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+		NamespaceName:              "namespace1",
+		WaitGroupName:              "waitgroup1",
+		Counter:                    2,
+		DeleteAfterFinishedSeconds: 60,
+		ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+	})
+	require.NoError(t, err)
+	_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		Jobs:          completeJobs([]string{"job1", "job2"}),
+	})
+	require.NoError(t, err)
+
+	// Retracting from a completed wait group without reopen must fail
+	_, err = server.UncompleteJobsFromWaitGroup(ctx, &gracklepb.UncompleteJobsFromWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		JobIds:        []string{"job1"},
+	})
+	require.Error(t, err)
+
+	// Reopen it
+	resp, err := server.UncompleteJobsFromWaitGroup(ctx, &gracklepb.UncompleteJobsFromWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		JobIds:        []string{"job1"},
+		Reopen:        true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, resp.WaitGroup.Status)
+	require.EqualValues(t, 1, resp.WaitGroup.CompletedJobs)
+
+	// Redo the job
+	_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		Jobs:          completeJobs([]string{"job1"}),
+	})
+	require.NoError(t, err)
+
+	// The completed job shows the retracted attempt
+	jobsResp, err := server.ListWaitGroupCompletedJobs(ctx, &gracklepb.ListWaitGroupCompletedJobsRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+	})
+	require.NoError(t, err)
+	require.Len(t, jobsResp.Jobs, 2)
+	require.Equal(t, "job1", jobsResp.Jobs[0].JobId)
+	require.Len(t, jobsResp.Jobs[0].History, 1)
+	require.NotZero(t, jobsResp.Jobs[0].History[0].RetractedAt)
+	require.Empty(t, jobsResp.Jobs[1].History)
+}
+
+func TestAddToWaitGroup(t *testing.T) {
+	server := setupGrackleApiServer(t)
+	ctx := context.Background()
+
+	// Create namespace
+	_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+		Name: "namespace1",
+	})
+	require.NoError(t, err)
+
+	// Create wait group
+	_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+		NamespaceName:              "namespace1",
+		WaitGroupName:              "waitgroup1",
+		Counter:                    2,
+		DeleteAfterFinishedSeconds: 60,
+		ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+	})
+	require.NoError(t, err)
+
+	// Grow the counter without an expected version
+	resp, err := server.AddToWaitGroup(ctx, &gracklepb.AddToWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		Delta:         3,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 5, resp.WaitGroup.Counter)
+
+	// Complete two jobs
+	_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		Jobs:          completeJobs([]string{"job1", "job2"}),
+	})
+	require.NoError(t, err)
+
+	// Shrinking below the completed jobs must fail
+	_, err = server.AddToWaitGroup(ctx, &gracklepb.AddToWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		Delta:         -4,
+	})
+	require.Error(t, err)
+
+	// Shrinking down to the completed jobs completes the wait group
+	resp, err = server.AddToWaitGroup(ctx, &gracklepb.AddToWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+		Delta:         -3,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, resp.WaitGroup.Counter)
+	require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, resp.WaitGroup.Status)
+
+	// Invalid request - zero delta
+	_, err = server.AddToWaitGroup(ctx, &gracklepb.AddToWaitGroupRequest{
+		NamespaceName: "namespace1",
+		WaitGroupName: "waitgroup1",
+	})
+	require.Error(t, err)
 }
 
 func TestDeleteWaitGroup(t *testing.T) {
@@ -511,6 +830,132 @@ func TestListWaitGroupCompletedJobs(t *testing.T) {
 	})
 }
 
+func TestNestedWaitGroups(t *testing.T) {
+	t.Run("children complete the root", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "test-namespace",
+		})
+		require.NoError(t, err)
+
+		// Create a root wait group with two children
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "test-namespace",
+			WaitGroupName:              "root",
+			Counter:                    2,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+		})
+		require.NoError(t, err)
+
+		for _, name := range []string{"shard-1", "shard-2"} {
+			resp, err := server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "test-namespace",
+				WaitGroupName:              name,
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+				ParentWaitGroupName:        "root",
+			})
+			require.NoError(t, err)
+			require.Equal(t, "root", resp.WaitGroup.ParentWaitGroupName)
+			require.Equal(t, gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, resp.WaitGroup.ChildFailurePolicy)
+		}
+
+		// A child with a missing parent is rejected
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "test-namespace",
+			WaitGroupName:              "orphan",
+			Counter:                    1,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+			ParentWaitGroupName:        "nonexistent",
+		})
+		require.Error(t, err)
+
+		// Completing the jobs of both children completes the root
+		for _, name := range []string{"shard-1", "shard-2"} {
+			_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+				NamespaceName: "test-namespace",
+				WaitGroupName: name,
+				Jobs:          completeJobs([]string{"job-1"}),
+			})
+			require.NoError(t, err)
+		}
+
+		resp, err := server.WaitForWaitGroup(ctx, &gracklepb.WaitForWaitGroupRequest{
+			NamespaceName:  "test-namespace",
+			WaitGroupName:  "root",
+			TimeoutSeconds: 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_COMPLETED, resp.Outcome)
+		require.EqualValues(t, 2, resp.WaitGroup.CompletedJobs)
+	})
+}
+
+func TestListWaitGroupPendingJobs(t *testing.T) {
+	t.Run("declared jobs", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "test-namespace",
+		})
+		require.NoError(t, err)
+
+		// Create a wait group with declared jobs
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "test-namespace",
+			WaitGroupName:              "test-waitgroup",
+			Counter:                    3,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+			DeclaredJobs:               true,
+		})
+		require.NoError(t, err)
+
+		// Declare all jobs
+		resp1, err := server.AddJobsToWaitGroup(ctx, &gracklepb.AddJobsToWaitGroupRequest{
+			NamespaceName: "test-namespace",
+			WaitGroupName: "test-waitgroup",
+			JobIds:        []string{"job-1", "job-2", "job-3"},
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, resp1.WaitGroup.NumberOfDeclaredJobs)
+
+		// Complete one of them
+		_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceName: "test-namespace",
+			WaitGroupName: "test-waitgroup",
+			Jobs:          completeJobs([]string{"job-2"}),
+		})
+		require.NoError(t, err)
+
+		// Completing an undeclared job fails
+		_, err = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceName: "test-namespace",
+			WaitGroupName: "test-waitgroup",
+			Jobs:          completeJobs([]string{"job-4"}),
+		})
+		require.Error(t, err)
+
+		// The other two are still pending
+		resp2, err := server.ListWaitGroupPendingJobs(ctx, &gracklepb.ListWaitGroupPendingJobsRequest{
+			NamespaceName: "test-namespace",
+			WaitGroupName: "test-waitgroup",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp2.Jobs, 2)
+		require.Equal(t, "job-1", resp2.Jobs[0].JobId)
+		require.Equal(t, "job-3", resp2.Jobs[1].JobId)
+	})
+}
+
 func TestWaitForWaitGroup(t *testing.T) {
 	t.Run("validation", func(t *testing.T) {
 		server := setupGrackleApiServer(t)
@@ -651,4 +1096,220 @@ func TestWaitForWaitGroup(t *testing.T) {
 		require.EqualValues(t, 10, resp.WaitGroup.Counter)
 		require.EqualValues(t, 5, resp.WaitGroup.CompletedJobs)
 	})
+
+	t.Run("failed", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "test-namespace",
+		})
+		require.NoError(t, err)
+
+		// Create wait group that tolerates a single failed job
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "test-namespace",
+			WaitGroupName:              "test-wg-failed",
+			Counter:                    10,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+			MaxFailedJobs:              proto.Int64(1),
+		})
+		require.NoError(t, err)
+
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			// Fail two jobs, which exceeds the budget
+			_, _ = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+				NamespaceName: "test-namespace",
+				WaitGroupName: "test-wg-failed",
+				Jobs: []*gracklepb.CompleteJobRequest{
+					{JobId: "job1", Failed: true},
+					{JobId: "job2", Failed: true},
+				},
+			})
+		}()
+
+		// Waiting returns as soon as the group fails
+		resp, err := server.WaitForWaitGroup(ctx, &gracklepb.WaitForWaitGroupRequest{
+			NamespaceName:  "test-namespace",
+			WaitGroupName:  "test-wg-failed",
+			TimeoutSeconds: 10,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_FAILED, resp.Outcome)
+		require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, resp.WaitGroup.Status)
+		require.EqualValues(t, 0, resp.WaitGroup.CompletedJobs)
+		require.EqualValues(t, 2, resp.WaitGroup.FailedJobs)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+
+		// Create namespace
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "test-namespace",
+		})
+		require.NoError(t, err)
+
+		// Create wait group
+		_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+			NamespaceName:              "test-namespace",
+			WaitGroupName:              "test-wg-cancelled",
+			Counter:                    10,
+			DeleteAfterFinishedSeconds: 60,
+			ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+		})
+		require.NoError(t, err)
+
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			// Abort the batch
+			_, _ = server.CancelWaitGroup(ctx, &gracklepb.CancelWaitGroupRequest{
+				NamespaceName: "test-namespace",
+				WaitGroupName: "test-wg-cancelled",
+				Reason:        "bad input data",
+			})
+		}()
+
+		// Waiting returns as soon as the group is cancelled
+		resp, err := server.WaitForWaitGroup(ctx, &gracklepb.WaitForWaitGroupRequest{
+			NamespaceName:  "test-namespace",
+			WaitGroupName:  "test-wg-cancelled",
+			TimeoutSeconds: 10,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_CANCELLED, resp.Outcome)
+		require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED, resp.WaitGroup.Status)
+		require.Equal(t, "bad input data", resp.WaitGroup.CancellationReason)
+		require.NotZero(t, resp.WaitGroup.FinishedAt)
+	})
+}
+
+func TestWaitForWaitGroups(t *testing.T) {
+	// createWaitGroups creates a namespace with three wait groups of a single
+	// job each.
+	createWaitGroups := func(t *testing.T, server *v1beta.GrackleApiServer, ctx context.Context) {
+		_, err := server.CreateNamespace(ctx, &gracklepb.CreateNamespaceRequest{
+			Name: "test-namespace",
+		})
+		require.NoError(t, err)
+
+		for _, name := range []string{"shard-0", "shard-1", "shard-2"} {
+			_, err = server.CreateWaitGroup(ctx, &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "test-namespace",
+				WaitGroupName:              name,
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				ExpiresAt:                  time.Now().Add(time.Hour).UnixNano(),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("all", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+		createWaitGroups(t, server, ctx)
+
+		go func() {
+			// Finish the shards one after another
+			for _, name := range []string{"shard-0", "shard-1"} {
+				time.Sleep(300 * time.Millisecond)
+				_, _ = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+					NamespaceName: "test-namespace",
+					WaitGroupName: name,
+					Jobs:          completeJobs([]string{"job1"}),
+				})
+			}
+			time.Sleep(300 * time.Millisecond)
+			_, _ = server.CancelWaitGroup(ctx, &gracklepb.CancelWaitGroupRequest{
+				NamespaceName: "test-namespace",
+				WaitGroupName: "shard-2",
+			})
+		}()
+
+		// Waiting returns once every wait group has finished
+		resp, err := server.WaitForWaitGroups(ctx, &gracklepb.WaitForWaitGroupsRequest{
+			NamespaceName:  "test-namespace",
+			WaitGroupNames: []string{"shard-0", "shard-1", "shard-2"},
+			Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
+			TimeoutSeconds: 10,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		require.Equal(t, "shard-0", resp.Results[0].WaitGroup.Name)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_COMPLETED, resp.Results[0].Outcome)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_COMPLETED, resp.Results[1].Outcome)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_CANCELLED, resp.Results[2].Outcome)
+	})
+
+	t.Run("any", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+		createWaitGroups(t, server, ctx)
+
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			_, _ = server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+				NamespaceName: "test-namespace",
+				WaitGroupName: "shard-1",
+				Jobs:          completeJobs([]string{"job1"}),
+			})
+		}()
+
+		// Waiting returns as soon as one wait group has finished
+		resp, err := server.WaitForWaitGroups(ctx, &gracklepb.WaitForWaitGroupsRequest{
+			NamespaceName:  "test-namespace",
+			WaitGroupNames: []string{"shard-0", "shard-1", "shard-2"},
+			Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ANY,
+			TimeoutSeconds: 10,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_PENDING, resp.Results[0].Outcome)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_COMPLETED, resp.Results[1].Outcome)
+		require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, resp.Results[1].WaitGroup.Status)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_PENDING, resp.Results[2].Outcome)
+		require.Equal(t, gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, resp.Results[2].WaitGroup.Status)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := setupGrackleApiServer(t)
+		ctx := context.Background()
+		createWaitGroups(t, server, ctx)
+
+		// Finish only one of the wait groups
+		_, err := server.CompleteJobsFromWaitGroup(ctx, &gracklepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceName: "test-namespace",
+			WaitGroupName: "shard-0",
+			Jobs:          completeJobs([]string{"job1"}),
+		})
+		require.NoError(t, err)
+
+		// Waiting for all of them times out
+		resp, err := server.WaitForWaitGroups(ctx, &gracklepb.WaitForWaitGroupsRequest{
+			NamespaceName:  "test-namespace",
+			WaitGroupNames: []string{"shard-0", "shard-1"},
+			Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
+			TimeoutSeconds: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_COMPLETED, resp.Results[0].Outcome)
+		require.Equal(t, gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_TIMED_OUT, resp.Results[1].Outcome)
+
+		// Waiting for a nonexistent wait group fails
+		_, err = server.WaitForWaitGroups(ctx, &gracklepb.WaitForWaitGroupsRequest{
+			NamespaceName:  "test-namespace",
+			WaitGroupNames: []string{"shard-0", "nonexistent"},
+			Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ANY,
+			TimeoutSeconds: 1,
+		})
+		require.Error(t, err)
+	})
 }
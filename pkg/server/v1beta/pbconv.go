@@ -53,9 +53,24 @@ func waitGroupJobToFront(job *corepb.WaitGroupJob) *gracklepb.WaitGroupJob {
 		JobId:       job.Id.JobId,
 		CompletedAt: job.CompletedAt,
 		Metadata:    job.Metadata,
+		Failed:      job.Failed,
+		History:     waitGroupJobAttemptsToFront(job.History),
 	}
 }
 
+func waitGroupJobAttemptsToFront(attempts []*corepb.WaitGroupJobAttempt) []*gracklepb.WaitGroupJobAttempt {
+	frontAttempts := make([]*gracklepb.WaitGroupJobAttempt, len(attempts))
+	for i, attempt := range attempts {
+		frontAttempts[i] = &gracklepb.WaitGroupJobAttempt{
+			CompletedAt: attempt.CompletedAt,
+			Metadata:    attempt.Metadata,
+			Failed:      attempt.Failed,
+			RetractedAt: attempt.RetractedAt,
+		}
+	}
+	return frontAttempts
+}
+
 func completeJobToCore(job *gracklepb.CompleteJobRequest) *corepb.CompleteJobRequest {
 	if job == nil {
 		return nil
@@ -64,6 +79,7 @@ func completeJobToCore(job *gracklepb.CompleteJobRequest) *corepb.CompleteJobReq
 	return &corepb.CompleteJobRequest{
 		JobId:    job.JobId,
 		Metadata: job.Metadata,
+		Failed:   job.Failed,
 	}
 }
 
@@ -94,6 +110,95 @@ func waitGroupToFront(waitGroup *corepb.WaitGroup) *gracklepb.WaitGroup {
 		DeleteAfterFinishedSeconds: waitGroup.DeleteAfterFinishedSeconds,
 		FinishedAt:                 waitGroup.FinishedAt,
 		LastActivityAt:             waitGroup.LastActivityAt,
+		FailedJobs:                 waitGroup.FailedJobs,
+		MaxFailedJobs:              waitGroup.MaxFailedJobs,
+		DeclaredJobs:               waitGroup.DeclaredJobs,
+		NumberOfDeclaredJobs:       waitGroup.NumberOfDeclaredJobs,
+		ParentWaitGroupName:        waitGroup.ParentWaitGroupName,
+		ParentReportError:          waitGroup.ParentReportError,
+		ChildFailurePolicy:         waitGroupChildFailurePolicyToFront(waitGroup.ChildFailurePolicy),
+		CancellationReason:         waitGroup.CancellationReason,
+		Aggregates:                 waitGroupAggregatesToFront(waitGroup.Aggregates),
+		IntegerJobIds:              waitGroup.IntegerJobIds,
+		Stripes:                    waitGroup.Stripes,
+		CompletionRate:             waitGroup.CompletionRate,
+		EstimatedCompletionAt:      waitGroup.EstimatedCompletionAt,
+	}
+}
+
+func waitGroupAggregatesToFront(aggregates []*corepb.WaitGroupAggregate) []*gracklepb.WaitGroupAggregate {
+	frontAggregates := make([]*gracklepb.WaitGroupAggregate, len(aggregates))
+	for i, aggregate := range aggregates {
+		frontAggregates[i] = &gracklepb.WaitGroupAggregate{
+			MetadataKey:    aggregate.MetadataKey,
+			Function:       waitGroupAggregationFunctionToFront(aggregate.Function),
+			Value:          aggregate.Value,
+			NumberOfValues: aggregate.NumberOfValues,
+		}
+	}
+	return frontAggregates
+}
+
+func waitGroupAggregationSpecsToCore(specs []*gracklepb.WaitGroupAggregationSpec) []*corepb.WaitGroupAggregationSpec {
+	coreSpecs := make([]*corepb.WaitGroupAggregationSpec, len(specs))
+	for i, spec := range specs {
+		coreSpecs[i] = &corepb.WaitGroupAggregationSpec{
+			MetadataKey: spec.MetadataKey,
+			Function:    waitGroupAggregationFunctionToCore(spec.Function),
+		}
+	}
+	return coreSpecs
+}
+
+func waitGroupAggregationFunctionToFront(function corepb.WaitGroupAggregationFunction) gracklepb.WaitGroupAggregationFunction {
+	switch function {
+	case corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM:
+		return gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM
+	case corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN:
+		return gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN
+	case corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX:
+		return gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX
+	default:
+		return gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_INVALID
+	}
+}
+
+func waitGroupAggregationFunctionToCore(function gracklepb.WaitGroupAggregationFunction) corepb.WaitGroupAggregationFunction {
+	switch function {
+	case gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM:
+		return corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM
+	case gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN:
+		return corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN
+	case gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX:
+		return corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX
+	default:
+		return corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_INVALID
+	}
+}
+
+func waitGroupChildFailurePolicyToFront(policy corepb.WaitGroupChildFailurePolicy) gracklepb.WaitGroupChildFailurePolicy {
+	switch policy {
+	case corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED:
+		return gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED
+	case corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED:
+		return gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED
+	case corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE:
+		return gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE
+	default:
+		return gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID
+	}
+}
+
+// waitGroupChildFailurePolicyToCore maps the front policy to the core one. An
+// unset policy defaults to reporting the child as a failed job.
+func waitGroupChildFailurePolicyToCore(policy gracklepb.WaitGroupChildFailurePolicy) corepb.WaitGroupChildFailurePolicy {
+	switch policy {
+	case gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED:
+		return corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED
+	case gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE:
+		return corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE
+	default:
+		return corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED
 	}
 }
 
@@ -105,11 +210,33 @@ func waitGroupStatusToFront(status corepb.WaitGroupStatus) gracklepb.WaitGroupSt
 		return gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED
 	case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED:
 		return gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED
+	case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED:
+		return gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED
+	case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED:
+		return gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED
 	default:
 		return gracklepb.WaitGroupStatus_WAIT_GROUP_STATUS_INVALID
 	}
 }
 
+// waitGroupWaitOutcomeToFront maps the status of a finished wait group to the
+// outcome reported to its waiters. It returns false for a wait group that is
+// still active.
+func waitGroupWaitOutcomeToFront(status corepb.WaitGroupStatus) (gracklepb.WaitGroupWaitOutcome, bool) {
+	switch status {
+	case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED:
+		return gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_COMPLETED, true
+	case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED:
+		return gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_FAILED, true
+	case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED:
+		return gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_EXPIRED, true
+	case corepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED:
+		return gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_CANCELLED, true
+	default:
+		return gracklepb.WaitGroupWaitOutcome_WAIT_GROUP_WAIT_OUTCOME_INVALID, false
+	}
+}
+
 func waitGroupsToFront(waitGroups []*corepb.WaitGroup) []*gracklepb.WaitGroup {
 	frontWaitGroups := make([]*gracklepb.WaitGroup, len(waitGroups))
 	for i, waitGroup := range waitGroups {
@@ -153,8 +280,9 @@ func lockHolderToFront(lockHolder *corepb.LockHolder, accountId uint64, namespac
 			NamespaceId: namespaceId,
 			LeaseId:     lockHolder.LeaseId,
 		}),
-		LockedAt: lockHolder.LockedAt,
-		Metadata: lockHolder.Metadata,
+		LockedAt:      lockHolder.LockedAt,
+		Metadata:      lockHolder.Metadata,
+		HoldExpiresAt: lockHolder.HoldExpiresAt,
 	}
 }
 
@@ -166,23 +294,80 @@ func lockHoldersToFront(lockHolders []*corepb.LockHolder, accountId uint64, name
 	return frontLockHolders
 }
 
+func lockWaiterToFront(waiter *corepb.LockWaiter) *gracklepb.LockWaiter {
+	if waiter == nil {
+		return nil
+	}
+
+	// waiter.LockId should always be present
+
+	return &gracklepb.LockWaiter{
+		LeaseId: ids.EncodeLeaseId(&corepb.LeaseId{
+			AccountId:   waiter.LockId.AccountId,
+			NamespaceId: waiter.LockId.NamespaceId,
+			LeaseId:     waiter.LeaseId,
+		}),
+		Exclusive:    waiter.Exclusive,
+		ProcessId:    waiter.ProcessId,
+		WaitingSince: waiter.WaitingSince,
+	}
+}
+
+func lockWaitersToFront(waiters []*corepb.LockWaiter) []*gracklepb.LockWaiter {
+	frontWaiters := make([]*gracklepb.LockWaiter, len(waiters))
+	for i, waiter := range waiters {
+		frontWaiters[i] = lockWaiterToFront(waiter)
+	}
+	return frontWaiters
+}
+
 func semaphoreToFront(semaphore *corepb.Semaphore) *gracklepb.Semaphore {
 	if semaphore == nil {
 		return nil
 	}
 
 	return &gracklepb.Semaphore{
-		Name:               semaphore.Name,
-		Description:        semaphore.Description,
-		CreatedAt:          semaphore.CreatedAt,
-		UpdatedAt:          semaphore.UpdatedAt,
-		Permits:            semaphore.Permits,
-		Version:            semaphore.Version,
-		ActiveHolds:        semaphore.ActiveHolds,
-		ActiveHoldersCount: semaphore.ActiveHoldersCount,
-		Metadata:           semaphore.Metadata,
-		LastActivityAt:     semaphore.LastActivityAt,
+		Name:                       semaphore.Name,
+		Description:                semaphore.Description,
+		CreatedAt:                  semaphore.CreatedAt,
+		UpdatedAt:                  semaphore.UpdatedAt,
+		Permits:                    semaphore.Permits,
+		Version:                    semaphore.Version,
+		ActiveHolds:                semaphore.ActiveHolds,
+		ActiveHoldersCount:         semaphore.ActiveHoldersCount,
+		Metadata:                   semaphore.Metadata,
+		LastActivityAt:             semaphore.LastActivityAt,
+		ParentSemaphoreName:        semaphore.ParentSemaphoreName,
+		NumberOfChildren:           semaphore.NumberOfChildren,
+		Draining:                   semaphore.ActiveHolds > semaphore.Permits,
+		Classes:                    semaphoreClassesToFront(semaphore.Classes),
+		DeleteInactiveAfterSeconds: semaphore.DeleteInactiveAfterSeconds,
+	}
+}
+
+func semaphoreClassesToFront(classes []*corepb.SemaphoreClass) []*gracklepb.SemaphoreClass {
+	frontClasses := make([]*gracklepb.SemaphoreClass, len(classes))
+	for i, class := range classes {
+		frontClasses[i] = &gracklepb.SemaphoreClass{
+			Name:        class.Name,
+			MinPermits:  class.MinPermits,
+			MaxPermits:  class.MaxPermits,
+			ActiveHolds: class.ActiveHolds,
+		}
+	}
+	return frontClasses
+}
+
+func semaphoreClassSpecsToCore(specs []*gracklepb.SemaphoreClassSpec) []*corepb.SemaphoreClassSpec {
+	coreSpecs := make([]*corepb.SemaphoreClassSpec, len(specs))
+	for i, spec := range specs {
+		coreSpecs[i] = &corepb.SemaphoreClassSpec{
+			Name:       spec.Name,
+			MinPermits: spec.MinPermits,
+			MaxPermits: spec.MaxPermits,
+		}
 	}
+	return coreSpecs
 }
 
 func semaphoresToFront(semaphores []*corepb.Semaphore) []*gracklepb.Semaphore {
@@ -206,9 +391,12 @@ func semaphoreHolderToFront(holder *corepb.SemaphoreHolder) *gracklepb.Semaphore
 			NamespaceId: holder.Id.NamespaceId,
 			LeaseId:     holder.Id.LeaseId,
 		}),
-		LockedAt: holder.LockedAt,
-		Weight:   holder.Weight,
-		Metadata: holder.Metadata,
+		LockedAt:        holder.LockedAt,
+		Weight:          holder.Weight,
+		Metadata:        holder.Metadata,
+		InheritedWeight: holder.InheritedWeight,
+		Class:           holder.Class,
+		HoldExpiresAt:   holder.HoldExpiresAt,
 	}
 }
 
@@ -220,6 +408,48 @@ func semaphoreHoldersToFront(semaphoreHolders []*corepb.SemaphoreHolder) []*grac
 	return frontSemaphoreHolders
 }
 
+func semaphoreWaiterToFront(waiter *corepb.SemaphoreWaiter) *gracklepb.SemaphoreWaiter {
+	if waiter == nil {
+		return nil
+	}
+
+	// waiter.SemaphoreId should always be present
+
+	return &gracklepb.SemaphoreWaiter{
+		LeaseId: ids.EncodeLeaseId(&corepb.LeaseId{
+			AccountId:   waiter.SemaphoreId.AccountId,
+			NamespaceId: waiter.SemaphoreId.NamespaceId,
+			LeaseId:     waiter.LeaseId,
+		}),
+		Weight:       waiter.Weight,
+		Class:        waiter.Class,
+		ProcessId:    waiter.ProcessId,
+		WaitingSince: waiter.WaitingSince,
+	}
+}
+
+func semaphoreWaitersToFront(waiters []*corepb.SemaphoreWaiter) []*gracklepb.SemaphoreWaiter {
+	frontWaiters := make([]*gracklepb.SemaphoreWaiter, len(waiters))
+	for i, waiter := range waiters {
+		frontWaiters[i] = semaphoreWaiterToFront(waiter)
+	}
+	return frontWaiters
+}
+
+func semaphoreStatsBucketsToFront(buckets []*corepb.SemaphoreStatsBucket) []*gracklepb.SemaphoreStatsBucket {
+	frontBuckets := make([]*gracklepb.SemaphoreStatsBucket, len(buckets))
+	for i, bucket := range buckets {
+		frontBuckets[i] = &gracklepb.SemaphoreStatsBucket{
+			StartAt:            bucket.StartAt,
+			MaxActiveHolds:     bucket.MaxActiveHolds,
+			AverageActiveHolds: bucket.AverageActiveHolds,
+			AcquiresSucceeded:  bucket.AcquiresSucceeded,
+			AcquiresFailed:     bucket.AcquiresFailed,
+		}
+	}
+	return frontBuckets
+}
+
 func barrierToFront(barrier *corepb.Barrier) *gracklepb.Barrier {
 	if barrier == nil {
 		return nil
@@ -237,6 +467,10 @@ func barrierToFront(barrier *corepb.Barrier) *gracklepb.Barrier {
 		Metadata:                   barrier.Metadata,
 		LastActivityAt:             barrier.LastActivityAt,
 		DeleteInactiveAfterSeconds: barrier.DeleteInactiveAfterSeconds,
+		Roster:                     barrier.Roster,
+		RetainedGenerations:        barrier.RetainedGenerations,
+		ReleaseThreshold:           barrier.ReleaseThreshold,
+		LateProcesses:              barrier.LateProcesses,
 	}
 }
 
@@ -257,6 +491,8 @@ func barrierParticipantToFront(participant *corepb.BarrierParticipant) *gracklep
 		ProcessId: participant.ProcessId,
 		ArrivedAt: participant.ArrivedAt,
 		Metadata:  participant.Metadata,
+		Payload:   participant.Payload,
+		Late:      participant.Late,
 	}
 }
 
@@ -268,6 +504,30 @@ func barrierParticipantsToFront(participants []*corepb.BarrierParticipant) []*gr
 	return frontParticipants
 }
 
+func barrierGenerationToFront(generation *corepb.BarrierGeneration) *gracklepb.BarrierGeneration {
+	if generation == nil {
+		return nil
+	}
+
+	return &gracklepb.BarrierGeneration{
+		Generation:       generation.Generation,
+		OpenedAt:         generation.OpenedAt,
+		TrippedAt:        generation.TrippedAt,
+		FirstArrivalAt:   generation.FirstArrivalAt,
+		LastArrivalAt:    generation.LastArrivalAt,
+		LastProcessId:    generation.LastProcessId,
+		ArrivedProcesses: generation.ArrivedProcesses,
+	}
+}
+
+func barrierGenerationsToFront(generations []*corepb.BarrierGeneration) []*gracklepb.BarrierGeneration {
+	frontGenerations := make([]*gracklepb.BarrierGeneration, len(generations))
+	for i, generation := range generations {
+		frontGenerations[i] = barrierGenerationToFront(generation)
+	}
+	return frontGenerations
+}
+
 func paginationTokenToFront(paginationToken *corepb.PaginationToken) (string, error) {
 	if paginationToken == nil {
 		return "", nil
@@ -104,6 +104,46 @@ func (s *GrackleApiServer) CompleteJobsFromWaitGroup(ctx context.Context, req *g
 	return s.handler.CompleteJobsFromWaitGroup(ctx, req, 0, grackle.DefaultServiceLimits)
 }
 
+func (s *GrackleApiServer) UncompleteJobsFromWaitGroup(ctx context.Context, req *gracklepb.UncompleteJobsFromWaitGroupRequest) (*gracklepb.UncompleteJobsFromWaitGroupResponse, error) {
+	if err := ValidateUncompleteJobsFromWaitGroupRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.UncompleteJobsFromWaitGroup(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
+func (s *GrackleApiServer) AddJobsToWaitGroup(ctx context.Context, req *gracklepb.AddJobsToWaitGroupRequest) (*gracklepb.AddJobsToWaitGroupResponse, error) {
+	if err := ValidateAddJobsToWaitGroupRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.AddJobsToWaitGroup(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
+func (s *GrackleApiServer) AddToWaitGroup(ctx context.Context, req *gracklepb.AddToWaitGroupRequest) (*gracklepb.AddToWaitGroupResponse, error) {
+	if err := ValidateAddToWaitGroupRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.AddToWaitGroup(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
+func (s *GrackleApiServer) WaitForWaitGroups(ctx context.Context, req *gracklepb.WaitForWaitGroupsRequest) (*gracklepb.WaitForWaitGroupsResponse, error) {
+	if err := ValidateWaitForWaitGroupsRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.WaitForWaitGroups(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
+func (s *GrackleApiServer) CancelWaitGroup(ctx context.Context, req *gracklepb.CancelWaitGroupRequest) (*gracklepb.CancelWaitGroupResponse, error) {
+	if err := ValidateCancelWaitGroupRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.CancelWaitGroup(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
 func (s *GrackleApiServer) DeleteWaitGroup(ctx context.Context, req *gracklepb.DeleteWaitGroupRequest) (*gracklepb.DeleteWaitGroupResponse, error) {
 	if err := ValidateDeleteWaitGroupRequest(req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
@@ -128,6 +168,14 @@ func (s *GrackleApiServer) ListWaitGroupCompletedJobs(ctx context.Context, req *
 	return s.handler.ListWaitGroupCompletedJobs(ctx, req, 0, grackle.DefaultServiceLimits)
 }
 
+func (s *GrackleApiServer) ListWaitGroupPendingJobs(ctx context.Context, req *gracklepb.ListWaitGroupPendingJobsRequest) (*gracklepb.ListWaitGroupPendingJobsResponse, error) {
+	if err := ValidateListWaitGroupPendingJobsRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.ListWaitGroupPendingJobs(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
 func (s *GrackleApiServer) AcquireLock(ctx context.Context, req *gracklepb.AcquireLockRequest) (*gracklepb.AcquireLockResponse, error) {
 	if err := ValidateAcquireLockRequest(req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
@@ -144,6 +192,14 @@ func (s *GrackleApiServer) ReleaseLock(ctx context.Context, req *gracklepb.Relea
 	return s.handler.ReleaseLock(ctx, req, 0, grackle.DefaultServiceLimits)
 }
 
+func (s *GrackleApiServer) TransferLock(ctx context.Context, req *gracklepb.TransferLockRequest) (*gracklepb.TransferLockResponse, error) {
+	if err := ValidateTransferLockRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.TransferLock(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
 func (s *GrackleApiServer) GetLock(ctx context.Context, req *gracklepb.GetLockRequest) (*gracklepb.GetLockResponse, error) {
 	if err := ValidateGetLockRequest(req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
@@ -152,6 +208,14 @@ func (s *GrackleApiServer) GetLock(ctx context.Context, req *gracklepb.GetLockRe
 	return s.handler.GetLock(ctx, req, 0, grackle.DefaultServiceLimits)
 }
 
+func (s *GrackleApiServer) ListLockWaiters(ctx context.Context, req *gracklepb.ListLockWaitersRequest) (*gracklepb.ListLockWaitersResponse, error) {
+	if err := ValidateListLockWaitersRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.ListLockWaiters(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
 func (s *GrackleApiServer) DeleteLock(ctx context.Context, req *gracklepb.DeleteLockRequest) (*gracklepb.DeleteLockResponse, error) {
 	if err := ValidateDeleteLockRequest(req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
@@ -192,6 +256,22 @@ func (s *GrackleApiServer) ListSemaphoreHolders(ctx context.Context, req *grackl
 	return s.handler.ListSemaphoreHolders(ctx, req, 0, grackle.DefaultServiceLimits)
 }
 
+func (s *GrackleApiServer) ListSemaphoreWaiters(ctx context.Context, req *gracklepb.ListSemaphoreWaitersRequest) (*gracklepb.ListSemaphoreWaitersResponse, error) {
+	if err := ValidateListSemaphoreWaitersRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.ListSemaphoreWaiters(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
+func (s *GrackleApiServer) GetSemaphoreStats(ctx context.Context, req *gracklepb.GetSemaphoreStatsRequest) (*gracklepb.GetSemaphoreStatsResponse, error) {
+	if err := ValidateGetSemaphoreStatsRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.GetSemaphoreStats(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
 func (s *GrackleApiServer) GetSemaphore(ctx context.Context, req *gracklepb.GetSemaphoreRequest) (*gracklepb.GetSemaphoreResponse, error) {
 	if err := ValidateGetSemaphoreRequest(req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
@@ -216,6 +296,22 @@ func (s *GrackleApiServer) ReleaseSemaphore(ctx context.Context, req *gracklepb.
 	return s.handler.ReleaseSemaphore(ctx, req, 0, grackle.DefaultServiceLimits)
 }
 
+func (s *GrackleApiServer) AdjustSemaphoreHold(ctx context.Context, req *gracklepb.AdjustSemaphoreHoldRequest) (*gracklepb.AdjustSemaphoreHoldResponse, error) {
+	if err := ValidateAdjustSemaphoreHoldRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.AdjustSemaphoreHold(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
+func (s *GrackleApiServer) TransferSemaphoreHold(ctx context.Context, req *gracklepb.TransferSemaphoreHoldRequest) (*gracklepb.TransferSemaphoreHoldResponse, error) {
+	if err := ValidateTransferSemaphoreHoldRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.TransferSemaphoreHold(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
 func (s *GrackleApiServer) UpdateSemaphore(ctx context.Context, req *gracklepb.UpdateSemaphoreRequest) (*gracklepb.UpdateSemaphoreResponse, error) {
 	if err := ValidateUpdateSemaphoreRequest(req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
@@ -296,6 +392,14 @@ func (s *GrackleApiServer) ListBarrierParticipants(ctx context.Context, req *gra
 	return s.handler.ListBarrierParticipants(ctx, req, 0, grackle.DefaultServiceLimits)
 }
 
+func (s *GrackleApiServer) ListBarrierGenerations(ctx context.Context, req *gracklepb.ListBarrierGenerationsRequest) (*gracklepb.ListBarrierGenerationsResponse, error) {
+	if err := ValidateListBarrierGenerationsRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return s.handler.ListBarrierGenerations(ctx, req, 0, grackle.DefaultServiceLimits)
+}
+
 func (s *GrackleApiServer) CreateSemaphoreLease(ctx context.Context, req *gracklepb.CreateSemaphoreLeaseRequest) (*gracklepb.CreateSemaphoreLeaseResponse, error) {
 	if err := ValidateCreateSemaphoreLeaseRequest(req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
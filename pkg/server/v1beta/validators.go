@@ -11,21 +11,34 @@ import (
 )
 
 const (
-	maxNamespaceNameLength       = 128
-	maxWaitGroupNameLength       = 128
-	maxLockNameLength            = 256
-	maxSemaphoreNameLength       = 128
-	maxBarrierNameLength         = 128
-	maxProcessIdLength           = 128
-	maxJobIdLength               = 128
-	maxDescriptionLength         = 1024
-	maxCompleteJobBatchSize      = 50
-	maxPaginationTokenLength     = 1024
-	maxTimeoutSeconds            = 300 // 5 minutes
-	maxLeaseIdLength             = 64
-	maxLeaseTtlSeconds           = 300 // 5 minutes
-	minWaitGroupAutoDeletionTime = 60  // 1 minute
-	minBarrierAutoDeletionTime   = 60  // 1 minute
+	maxNamespaceNameLength        = 128
+	maxWaitGroupNameLength        = 128
+	maxLockNameLength             = 256
+	maxSemaphoreNameLength        = 128
+	maxBarrierNameLength          = 128
+	maxProcessIdLength            = 128
+	maxJobIdLength                = 128
+	maxDescriptionLength          = 1024
+	maxCompleteJobBatchSize       = 50
+	maxAddJobBatchSize            = 1000
+	maxWaitForWaitGroupsNames     = 100
+	maxPaginationTokenLength      = 1024
+	maxTimeoutSeconds             = 300 // 5 minutes
+	maxLeaseIdLength              = 64
+	maxLeaseTtlSeconds            = 300 // 5 minutes
+	minWaitGroupAutoDeletionTime  = 60  // 1 minute
+	minBarrierAutoDeletionTime    = 60  // 1 minute
+	minSemaphoreAutoDeletionTime  = 60  // 1 minute
+	maxBarrierRosterSize          = 1000
+	maxBarrierPayloadSize         = 4096 // 4 KiB
+	maxBarrierRetainedGenerations = 100
+	maxWaitGroupAggregations      = 16
+	maxWaitGroupStripes           = 64
+	maxJobHistoryLength           = 10
+	maxSemaphoreClasses           = 16
+	maxHoldSeconds                = 86400 // 1 day
+	minSemaphoreStatsWindow       = 60    // 1 minute
+	maxSemaphoreStatsWindow       = 86400 // 1 day
 
 	maxMetadataEntries     = 32
 	maxMetadataKeyLength   = 128
@@ -120,6 +133,56 @@ func ValidateCreateWaitGroupRequest(req *gracklepb.CreateWaitGroupRequest) error
 		return invalid("CreateWaitGroupRequest.DeleteAfterFinishedSeconds", fmt.Sprintf("must be greater than or equal to %d", minWaitGroupAutoDeletionTime))
 	}
 
+	if req.MaxFailedJobs != nil && *req.MaxFailedJobs < 0 {
+		return invalid("CreateWaitGroupRequest.MaxFailedJobs", "must not be negative")
+	}
+
+	if req.IntegerJobIds && req.DeclaredJobs {
+		return invalid("CreateWaitGroupRequest.IntegerJobIds", "cannot be combined with DeclaredJobs")
+	}
+
+	if req.Stripes == 1 || req.Stripes > maxWaitGroupStripes {
+		return invalid("CreateWaitGroupRequest.Stripes", fmt.Sprintf("must be 0 or between 2 and %d", maxWaitGroupStripes))
+	}
+	if req.Stripes > 0 && (req.DeclaredJobs || req.IntegerJobIds || len(req.Aggregations) > 0) {
+		return invalid("CreateWaitGroupRequest.Stripes", "cannot be combined with DeclaredJobs, IntegerJobIds or Aggregations")
+	}
+
+	if req.ParentWaitGroupName != "" {
+		if err := validateWaitGroupName(req.ParentWaitGroupName, "CreateWaitGroupRequest.ParentWaitGroupName"); err != nil {
+			return err
+		}
+		if req.ParentWaitGroupName == req.WaitGroupName {
+			return invalid("CreateWaitGroupRequest.ParentWaitGroupName", "must differ from WaitGroupName")
+		}
+	}
+
+	if len(req.Aggregations) > maxWaitGroupAggregations {
+		return invalid("CreateWaitGroupRequest.Aggregations", fmt.Sprintf("exceeds max number of aggregations (%d)", maxWaitGroupAggregations))
+	}
+	seen := make(map[string]bool, len(req.Aggregations))
+	for i, aggregation := range req.Aggregations {
+		fieldName := fmt.Sprintf("CreateWaitGroupRequest.Aggregations[%d]", i)
+		if aggregation == nil {
+			return invalid(fieldName, "must not be nil")
+		}
+		if len(aggregation.MetadataKey) == 0 || len(aggregation.MetadataKey) > maxMetadataKeyLength {
+			return invalid(fieldName+".MetadataKey", fmt.Sprintf("length must be between 1 and %d characters", maxMetadataKeyLength))
+		}
+		switch aggregation.Function {
+		case gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM,
+			gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN,
+			gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX:
+		default:
+			return invalid(fieldName+".Function", "must be SUM, MIN or MAX")
+		}
+		key := fmt.Sprintf("%s/%s", aggregation.Function, aggregation.MetadataKey)
+		if seen[key] {
+			return invalid(fieldName, "duplicate aggregation")
+		}
+		seen[key] = true
+	}
+
 	if err := validateMetadata(req.Metadata, "CreateWaitGroupRequest.Metadata"); err != nil {
 		return err
 	}
@@ -187,6 +250,56 @@ func ValidateWaitForWaitGroupRequest(req *gracklepb.WaitForWaitGroupRequest) err
 	return nil
 }
 
+func ValidateWaitForWaitGroupsRequest(req *gracklepb.WaitForWaitGroupsRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "WaitForWaitGroupsRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if len(req.WaitGroupNames) == 0 {
+		return invalid("WaitForWaitGroupsRequest.WaitGroupNames", "must not be empty")
+	}
+	if len(req.WaitGroupNames) > maxWaitForWaitGroupsNames {
+		return invalid("WaitForWaitGroupsRequest.WaitGroupNames", fmt.Sprintf("exceeds max size (%d)", maxWaitForWaitGroupsNames))
+	}
+	seen := make(map[string]bool, len(req.WaitGroupNames))
+	for i, waitGroupName := range req.WaitGroupNames {
+		fieldName := fmt.Sprintf("WaitForWaitGroupsRequest.WaitGroupNames[%d]", i)
+		if err := validateWaitGroupName(waitGroupName, fieldName); err != nil {
+			return err
+		}
+		if seen[waitGroupName] {
+			return invalid(fieldName, "duplicate wait group name")
+		}
+		seen[waitGroupName] = true
+	}
+
+	if req.Mode != gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL && req.Mode != gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ANY {
+		return invalid("WaitForWaitGroupsRequest.Mode", "must be ALL or ANY")
+	}
+
+	if err := validateTimeOutSeconds(req.TimeoutSeconds, "WaitForWaitGroupsRequest.TimeoutSeconds"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateCancelWaitGroupRequest(req *gracklepb.CancelWaitGroupRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "CancelWaitGroupRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateWaitGroupName(req.WaitGroupName, "CancelWaitGroupRequest.WaitGroupName"); err != nil {
+		return err
+	}
+
+	if err := validateDescription(req.Reason, "CancelWaitGroupRequest.Reason"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func ValidateDeleteWaitGroupRequest(req *gracklepb.DeleteWaitGroupRequest) error {
 	if err := validateNamespaceName(req.NamespaceName, "DeleteWaitGroupRequest.NamespaceName"); err != nil {
 		return err
@@ -262,6 +375,84 @@ func ValidateCompleteJobsFromWaitGroupRequest(req *gracklepb.CompleteJobsFromWai
 	return nil
 }
 
+func ValidateUncompleteJobsFromWaitGroupRequest(req *gracklepb.UncompleteJobsFromWaitGroupRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "UncompleteJobsFromWaitGroupRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateWaitGroupName(req.WaitGroupName, "UncompleteJobsFromWaitGroupRequest.WaitGroupName"); err != nil {
+		return err
+	}
+
+	if len(req.JobIds) > maxCompleteJobBatchSize {
+		return invalid("UncompleteJobsFromWaitGroupRequest.JobIds", fmt.Sprintf("exceeds max batch size (%d)", maxCompleteJobBatchSize))
+	}
+	for i, jobId := range req.JobIds {
+		if err := validateJobId(jobId, fmt.Sprintf("UncompleteJobsFromWaitGroupRequest.JobIds[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateAddJobsToWaitGroupRequest(req *gracklepb.AddJobsToWaitGroupRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "AddJobsToWaitGroupRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateWaitGroupName(req.WaitGroupName, "AddJobsToWaitGroupRequest.WaitGroupName"); err != nil {
+		return err
+	}
+
+	if len(req.JobIds) > maxAddJobBatchSize {
+		return invalid("AddJobsToWaitGroupRequest.JobIds", fmt.Sprintf("exceeds max batch size (%d)", maxAddJobBatchSize))
+	}
+	for i, jobId := range req.JobIds {
+		if err := validateJobId(jobId, fmt.Sprintf("AddJobsToWaitGroupRequest.JobIds[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateAddToWaitGroupRequest(req *gracklepb.AddToWaitGroupRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "AddToWaitGroupRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateWaitGroupName(req.WaitGroupName, "AddToWaitGroupRequest.WaitGroupName"); err != nil {
+		return err
+	}
+
+	if req.Delta == 0 {
+		return invalid("AddToWaitGroupRequest.Delta", "must not be 0")
+	}
+
+	return nil
+}
+
+func ValidateListWaitGroupPendingJobsRequest(req *gracklepb.ListWaitGroupPendingJobsRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "ListWaitGroupPendingJobsRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateWaitGroupName(req.WaitGroupName, "ListWaitGroupPendingJobsRequest.WaitGroupName"); err != nil {
+		return err
+	}
+
+	if err := validatePaginationToken(req.PaginationToken, "ListWaitGroupPendingJobsRequest.PaginationToken"); err != nil {
+		return err
+	}
+
+	if err := validateLimit(req.Limit, "ListWaitGroupPendingJobsRequest.Limit"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func ValidateListLocksRequest(req *gracklepb.ListLocksRequest) error {
 	if err := validateNamespaceName(req.NamespaceName, "ListLocksRequest.NamespaceName"); err != nil {
 		return err
@@ -302,6 +493,26 @@ func ValidateGetLockRequest(req *gracklepb.GetLockRequest) error {
 	return nil
 }
 
+func ValidateListLockWaitersRequest(req *gracklepb.ListLockWaitersRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "ListLockWaitersRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateLockName(req.LockName, "ListLockWaitersRequest.LockName"); err != nil {
+		return err
+	}
+
+	if err := validatePaginationToken(req.PaginationToken, "ListLockWaitersRequest.PaginationToken"); err != nil {
+		return err
+	}
+
+	if err := validateLimit(req.Limit, "ListLockWaitersRequest.Limit"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func ValidateReleaseLockRequest(req *gracklepb.ReleaseLockRequest) error {
 	if err := validateNamespaceName(req.NamespaceName, "ReleaseLockRequest.NamespaceName"); err != nil {
 		return err
@@ -318,6 +529,30 @@ func ValidateReleaseLockRequest(req *gracklepb.ReleaseLockRequest) error {
 	return nil
 }
 
+func ValidateTransferLockRequest(req *gracklepb.TransferLockRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "TransferLockRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateLockName(req.LockName, "TransferLockRequest.LockName"); err != nil {
+		return err
+	}
+
+	if err := validateLeaseId(req.FromLeaseId, "TransferLockRequest.FromLeaseId"); err != nil {
+		return err
+	}
+
+	if err := validateLeaseId(req.ToLeaseId, "TransferLockRequest.ToLeaseId"); err != nil {
+		return err
+	}
+
+	if req.FromLeaseId == req.ToLeaseId {
+		return invalid("TransferLockRequest.ToLeaseId", "must differ from FromLeaseId")
+	}
+
+	return nil
+}
+
 func ValidateAcquireLockRequest(req *gracklepb.AcquireLockRequest) error {
 	if err := validateNamespaceName(req.NamespaceName, "AcquireLockRequest.NamespaceName"); err != nil {
 		return err
@@ -335,6 +570,10 @@ func ValidateAcquireLockRequest(req *gracklepb.AcquireLockRequest) error {
 		return err
 	}
 
+	if err := validateMaxHoldSeconds(req.MaxHoldSeconds, "AcquireLockRequest.MaxHoldSeconds"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -359,6 +598,51 @@ func ValidateCreateSemaphoreRequest(req *gracklepb.CreateSemaphoreRequest) error
 		return err
 	}
 
+	if req.ParentSemaphoreName != "" {
+		if err := validateSemaphoreName(req.ParentSemaphoreName, "CreateSemaphoreRequest.ParentSemaphoreName"); err != nil {
+			return err
+		}
+		if req.ParentSemaphoreName == req.SemaphoreName {
+			return invalid("CreateSemaphoreRequest.ParentSemaphoreName", "must differ from SemaphoreName")
+		}
+	}
+
+	if len(req.Classes) > maxSemaphoreClasses {
+		return invalid("CreateSemaphoreRequest.Classes", fmt.Sprintf("exceeds max number of classes (%d)", maxSemaphoreClasses))
+	}
+	seen := make(map[string]bool, len(req.Classes))
+	var reservedPermits int64
+	for i, class := range req.Classes {
+		fieldName := fmt.Sprintf("CreateSemaphoreRequest.Classes[%d]", i)
+		if class == nil {
+			return invalid(fieldName, "must not be nil")
+		}
+		if err := validateSemaphoreClassName(class.Name, fieldName+".Name"); err != nil {
+			return err
+		}
+		if seen[class.Name] {
+			return invalid(fieldName, "duplicate class")
+		}
+		seen[class.Name] = true
+		if class.MinPermits < 0 {
+			return invalid(fieldName+".MinPermits", "must not be negative")
+		}
+		if class.MaxPermits < 0 || class.MaxPermits > req.Permits {
+			return invalid(fieldName+".MaxPermits", "must be between 0 and Permits")
+		}
+		if class.MaxPermits > 0 && class.MinPermits > class.MaxPermits {
+			return invalid(fieldName+".MinPermits", "must not exceed MaxPermits")
+		}
+		reservedPermits += class.MinPermits
+	}
+	if reservedPermits > req.Permits {
+		return invalid("CreateSemaphoreRequest.Classes", "sum of MinPermits must not exceed Permits")
+	}
+
+	if err := validateSemaphoreAutoDeletionTime(req.DeleteInactiveAfterSeconds, "CreateSemaphoreRequest.DeleteInactiveAfterSeconds"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -403,7 +687,14 @@ func ValidateUpdateSemaphoreRequest(req *gracklepb.UpdateSemaphoreRequest) error
 		return err
 	}
 
-	if req.Permits <= 0 {
+	if req.TargetPermits != 0 {
+		if req.TargetPermits < 0 {
+			return invalid("UpdateSemaphoreRequest.TargetPermits", "must be greater than 0")
+		}
+		if req.Permits != 0 {
+			return invalid("UpdateSemaphoreRequest.TargetPermits", "cannot be combined with Permits")
+		}
+	} else if req.Permits <= 0 {
 		return invalid("UpdateSemaphoreRequest.Permits", "must be greater than 0")
 	}
 
@@ -415,6 +706,13 @@ func ValidateUpdateSemaphoreRequest(req *gracklepb.UpdateSemaphoreRequest) error
 		return invalid("UpdateSemaphoreRequest.ExpectedVersion", "must be greater than 0")
 	}
 
+	// -1 turns auto-deletion off, 0 keeps the current window
+	if req.DeleteInactiveAfterSeconds != -1 {
+		if err := validateSemaphoreAutoDeletionTime(req.DeleteInactiveAfterSeconds, "UpdateSemaphoreRequest.DeleteInactiveAfterSeconds"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -466,6 +764,42 @@ func ValidateListSemaphoreHoldersRequest(req *gracklepb.ListSemaphoreHoldersRequ
 	return nil
 }
 
+func ValidateListSemaphoreWaitersRequest(req *gracklepb.ListSemaphoreWaitersRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "ListSemaphoreWaitersRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateSemaphoreName(req.SemaphoreName, "ListSemaphoreWaitersRequest.SemaphoreName"); err != nil {
+		return err
+	}
+
+	if err := validatePaginationToken(req.PaginationToken, "ListSemaphoreWaitersRequest.PaginationToken"); err != nil {
+		return err
+	}
+
+	if err := validateLimit(req.Limit, "ListSemaphoreWaitersRequest.Limit"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateGetSemaphoreStatsRequest(req *gracklepb.GetSemaphoreStatsRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "GetSemaphoreStatsRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateSemaphoreName(req.SemaphoreName, "GetSemaphoreStatsRequest.SemaphoreName"); err != nil {
+		return err
+	}
+
+	if req.WindowSeconds < minSemaphoreStatsWindow || req.WindowSeconds > maxSemaphoreStatsWindow {
+		return invalid("GetSemaphoreStatsRequest.WindowSeconds", fmt.Sprintf("must be between %d and %d", minSemaphoreStatsWindow, maxSemaphoreStatsWindow))
+	}
+
+	return nil
+}
+
 func ValidateAcquireSemaphoreRequest(req *gracklepb.AcquireSemaphoreRequest) error {
 	if err := validateNamespaceName(req.NamespaceName, "AcquireSemaphoreRequest.NamespaceName"); err != nil {
 		return err
@@ -491,6 +825,75 @@ func ValidateAcquireSemaphoreRequest(req *gracklepb.AcquireSemaphoreRequest) err
 		return err
 	}
 
+	if req.Class != "" {
+		if err := validateSemaphoreClassName(req.Class, "AcquireSemaphoreRequest.Class"); err != nil {
+			return err
+		}
+	}
+
+	if err := validateMaxHoldSeconds(req.MaxHoldSeconds, "AcquireSemaphoreRequest.MaxHoldSeconds"); err != nil {
+		return err
+	}
+
+	if req.DefaultPermits < 0 {
+		return invalid("AcquireSemaphoreRequest.DefaultPermits", "must not be negative")
+	}
+
+	if err := validateSemaphoreAutoDeletionTime(req.DefaultDeleteInactiveAfterSeconds, "AcquireSemaphoreRequest.DefaultDeleteInactiveAfterSeconds"); err != nil {
+		return err
+	}
+	if req.DefaultDeleteInactiveAfterSeconds != 0 && req.DefaultPermits == 0 {
+		return invalid("AcquireSemaphoreRequest.DefaultDeleteInactiveAfterSeconds", "requires DefaultPermits")
+	}
+
+	return nil
+}
+
+func ValidateAdjustSemaphoreHoldRequest(req *gracklepb.AdjustSemaphoreHoldRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "AdjustSemaphoreHoldRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateSemaphoreName(req.SemaphoreName, "AdjustSemaphoreHoldRequest.SemaphoreName"); err != nil {
+		return err
+	}
+
+	if err := validateLeaseId(req.LeaseId, "AdjustSemaphoreHoldRequest.LeaseId"); err != nil {
+		return err
+	}
+
+	if err := validateTimeOutSeconds(req.TimeoutSeconds, "AdjustSemaphoreHoldRequest.TimeoutSeconds"); err != nil {
+		return err
+	}
+
+	if req.Weight <= 0 {
+		return invalid("AdjustSemaphoreHoldRequest.Weight", "must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateTransferSemaphoreHoldRequest(req *gracklepb.TransferSemaphoreHoldRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "TransferSemaphoreHoldRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateSemaphoreName(req.SemaphoreName, "TransferSemaphoreHoldRequest.SemaphoreName"); err != nil {
+		return err
+	}
+
+	if err := validateLeaseId(req.FromLeaseId, "TransferSemaphoreHoldRequest.FromLeaseId"); err != nil {
+		return err
+	}
+
+	if err := validateLeaseId(req.ToLeaseId, "TransferSemaphoreHoldRequest.ToLeaseId"); err != nil {
+		return err
+	}
+
+	if req.FromLeaseId == req.ToLeaseId {
+		return invalid("TransferSemaphoreHoldRequest.ToLeaseId", "must differ from FromLeaseId")
+	}
+
 	return nil
 }
 
@@ -519,6 +922,18 @@ func ValidateCreateBarrierRequest(req *gracklepb.CreateBarrierRequest) error {
 		return err
 	}
 
+	if err := validateRoster(req.Roster, req.ExpectedProcesses, "CreateBarrierRequest.Roster"); err != nil {
+		return err
+	}
+
+	if req.RetainedGenerations < 0 || req.RetainedGenerations > maxBarrierRetainedGenerations {
+		return invalid("CreateBarrierRequest.RetainedGenerations", fmt.Sprintf("must be between 0 and %d", maxBarrierRetainedGenerations))
+	}
+
+	if req.ReleaseThreshold < 0 || req.ReleaseThreshold > req.ExpectedProcesses {
+		return invalid("CreateBarrierRequest.ReleaseThreshold", "must be between 0 and ExpectedProcesses")
+	}
+
 	return nil
 }
 
@@ -587,6 +1002,18 @@ func ValidateUpdateBarrierRequest(req *gracklepb.UpdateBarrierRequest) error {
 		return err
 	}
 
+	if err := validateRoster(req.Roster, req.ExpectedProcesses, "UpdateBarrierRequest.Roster"); err != nil {
+		return err
+	}
+
+	if req.RetainedGenerations < 0 || req.RetainedGenerations > maxBarrierRetainedGenerations {
+		return invalid("UpdateBarrierRequest.RetainedGenerations", fmt.Sprintf("must be between 0 and %d", maxBarrierRetainedGenerations))
+	}
+
+	if req.ReleaseThreshold < 0 || req.ReleaseThreshold > req.ExpectedProcesses {
+		return invalid("UpdateBarrierRequest.ReleaseThreshold", "must be between 0 and ExpectedProcesses")
+	}
+
 	if req.ExpectedVersion <= 0 {
 		return invalid("UpdateBarrierRequest.ExpectedVersion", "must be greater than 0")
 	}
@@ -615,6 +1042,10 @@ func ValidateArriveAtBarrierRequest(req *gracklepb.ArriveAtBarrierRequest) error
 		return err
 	}
 
+	if len(req.Payload) > maxBarrierPayloadSize {
+		return invalid("ArriveAtBarrierRequest.Payload", fmt.Sprintf("exceeds max payload size (%d bytes)", maxBarrierPayloadSize))
+	}
+
 	return nil
 }
 
@@ -658,6 +1089,26 @@ func ValidateListBarrierParticipantsRequest(req *gracklepb.ListBarrierParticipan
 	return nil
 }
 
+func ValidateListBarrierGenerationsRequest(req *gracklepb.ListBarrierGenerationsRequest) error {
+	if err := validateNamespaceName(req.NamespaceName, "ListBarrierGenerationsRequest.NamespaceName"); err != nil {
+		return err
+	}
+
+	if err := validateBarrierName(req.BarrierName, "ListBarrierGenerationsRequest.BarrierName"); err != nil {
+		return err
+	}
+
+	if err := validatePaginationToken(req.PaginationToken, "ListBarrierGenerationsRequest.PaginationToken"); err != nil {
+		return err
+	}
+
+	if err := validateLimit(req.Limit, "ListBarrierGenerationsRequest.Limit"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func ValidateCreateSemaphoreLeaseRequest(req *gracklepb.CreateSemaphoreLeaseRequest) error {
 	if err := validateNamespaceName(req.NamespaceName, "CreateSemaphoreLeaseRequest.NamespaceName"); err != nil {
 		return err
@@ -814,6 +1265,36 @@ func validateProcessId(value string, fieldName string) error {
 	return validateString(value, 1, maxProcessIdLength, nameRegex, fieldName)
 }
 
+// validateRoster checks a barrier roster: an empty roster is allowed (any
+// process may arrive); otherwise it must hold unique, valid process ids and at
+// least expectedProcesses of them.
+func validateRoster(roster []string, expectedProcesses int64, fieldName string) error {
+	if len(roster) == 0 {
+		return nil
+	}
+
+	if len(roster) > maxBarrierRosterSize {
+		return invalid(fieldName, fmt.Sprintf("exceeds max roster size (%d)", maxBarrierRosterSize))
+	}
+
+	if int64(len(roster)) < expectedProcesses {
+		return invalid(fieldName, "must contain at least ExpectedProcesses process ids")
+	}
+
+	seen := make(map[string]struct{}, len(roster))
+	for i, processId := range roster {
+		if err := validateProcessId(processId, fmt.Sprintf("%s[%d]", fieldName, i)); err != nil {
+			return err
+		}
+		if _, ok := seen[processId]; ok {
+			return invalid(fmt.Sprintf("%s[%d]", fieldName, i), "duplicate process id")
+		}
+		seen[processId] = struct{}{}
+	}
+
+	return nil
+}
+
 func validateJobId(value string, fieldName string) error {
 	return validateString(value, 1, maxJobIdLength, nameRegex, fieldName)
 }
@@ -826,6 +1307,10 @@ func validateSemaphoreName(value string, fieldName string) error {
 	return validateString(value, 1, maxSemaphoreNameLength, nameRegex, fieldName)
 }
 
+func validateSemaphoreClassName(value string, fieldName string) error {
+	return validateString(value, 1, maxSemaphoreNameLength, nameRegex, fieldName)
+}
+
 func validateBarrierName(value string, fieldName string) error {
 	return validateString(value, 1, maxBarrierNameLength, nameRegex, fieldName)
 }
@@ -900,6 +1385,26 @@ func validateLeaseTtlSeconds(value int64, fieldName string) error {
 	return nil
 }
 
+// validateMaxHoldSeconds accepts 0 (the hold lasts as long as the lease) or a positive
+// number of seconds up to maxHoldSeconds.
+func validateMaxHoldSeconds(value int64, fieldName string) error {
+	if value < 0 || value > maxHoldSeconds {
+		return invalid(fieldName, fmt.Sprintf("must be between 0 and %d", maxHoldSeconds))
+	}
+
+	return nil
+}
+
+// validateSemaphoreAutoDeletionTime allows 0, which keeps the semaphore until it
+// is deleted explicitly.
+func validateSemaphoreAutoDeletionTime(value int64, fieldName string) error {
+	if value != 0 && value < minSemaphoreAutoDeletionTime {
+		return invalid(fieldName, fmt.Sprintf("must be 0 or greater than or equal to %d", minSemaphoreAutoDeletionTime))
+	}
+
+	return nil
+}
+
 func validateTimeOutSeconds(value int32, fieldName string) error {
 	if value < 0 || value > maxTimeoutSeconds {
 		return invalid(fieldName, fmt.Sprintf("must be between 0 and %d", maxTimeoutSeconds))
@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	gracklepb "github.com/evrblk/evrblk-go/grackle/v1beta"
+	"github.com/evrblk/grackle/pkg/corepb"
 	"github.com/evrblk/grackle/pkg/ids"
 )
 
@@ -516,6 +518,17 @@ func TestValidateCreateWaitGroupRequest(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{
+			name: "negative max failed jobs",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				MaxFailedJobs:              proto.Int64(-1),
+			},
+			shouldError: true,
+		},
 		{
 			name: "valid request",
 			request: &gracklepb.CreateWaitGroupRequest{
@@ -526,81 +539,171 @@ func TestValidateCreateWaitGroupRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if test.shouldError {
-				require.Error(t, ValidateCreateWaitGroupRequest(test.request))
-			} else {
-				require.NoError(t, ValidateCreateWaitGroupRequest(test.request))
-			}
-		})
-	}
-}
-
-func TestValidateGetWaitGroupRequest(t *testing.T) {
-	tests := []struct {
-		name        string
-		request     *gracklepb.GetWaitGroupRequest
-		shouldError bool
-	}{
 		{
-			name:        "empty request",
-			request:     &gracklepb.GetWaitGroupRequest{},
+			name: "valid request with zero max failed jobs",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				MaxFailedJobs:              proto.Int64(0),
+			},
+			shouldError: false,
+		},
+		{
+			name: "integer job ids with declared jobs",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				DeclaredJobs:               true,
+				IntegerJobIds:              true,
+			},
 			shouldError: true,
 		},
 		{
-			name: "empty namespace name",
-			request: &gracklepb.GetWaitGroupRequest{
-				WaitGroupName: "validname",
+			name: "valid request with integer job ids",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				IntegerJobIds:              true,
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with stripes",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				Stripes:                    8,
+			},
+			shouldError: false,
+		},
+		{
+			name: "single stripe",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				Stripes:                    1,
 			},
 			shouldError: true,
 		},
 		{
-			name: "empty wait group name",
-			request: &gracklepb.GetWaitGroupRequest{
-				NamespaceName: "validname",
+			name: "too many stripes",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				Stripes:                    maxWaitGroupStripes + 1,
 			},
 			shouldError: true,
 		},
 		{
-			name: "namespace name too long",
-			request: &gracklepb.GetWaitGroupRequest{
-				NamespaceName: string(make([]byte, 129)),
-				WaitGroupName: "validname",
+			name: "stripes with declared jobs",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				DeclaredJobs:               true,
+				Stripes:                    8,
 			},
 			shouldError: true,
 		},
 		{
-			name: "wait group name too long",
-			request: &gracklepb.GetWaitGroupRequest{
-				NamespaceName: "validname",
-				WaitGroupName: string(make([]byte, 129)),
+			name: "invalid parent wait group name",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				ParentWaitGroupName:        "invalid name",
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid namespace name characters",
-			request: &gracklepb.GetWaitGroupRequest{
-				NamespaceName: "invalid name",
-				WaitGroupName: "validname",
+			name: "parent wait group is itself",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				ParentWaitGroupName:        "validwaitgroup",
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid wait group name characters",
-			request: &gracklepb.GetWaitGroupRequest{
-				NamespaceName: "validname",
-				WaitGroupName: "invalid name",
+			name: "valid request with parent wait group",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				ParentWaitGroupName:        "parentwaitgroup",
+				ChildFailurePolicy:         gracklepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE,
+			},
+			shouldError: false,
+		},
+		{
+			name: "aggregation without metadata key",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				Aggregations: []*gracklepb.WaitGroupAggregationSpec{
+					{Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+				},
 			},
 			shouldError: true,
 		},
 		{
-			name: "valid request",
-			request: &gracklepb.GetWaitGroupRequest{
-				NamespaceName: "validname",
-				WaitGroupName: "validwaitgroup",
+			name: "aggregation without function",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				Aggregations: []*gracklepb.WaitGroupAggregationSpec{
+					{MetadataKey: "rows"},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "duplicate aggregation",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				Aggregations: []*gracklepb.WaitGroupAggregationSpec{
+					{MetadataKey: "rows", Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+					{MetadataKey: "rows", Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with aggregations",
+			request: &gracklepb.CreateWaitGroupRequest{
+				NamespaceName:              "validname",
+				WaitGroupName:              "validwaitgroup",
+				Counter:                    1,
+				DeleteAfterFinishedSeconds: 60,
+				Aggregations: []*gracklepb.WaitGroupAggregationSpec{
+					{MetadataKey: "rows", Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+					{MetadataKey: "rows", Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX},
+					{MetadataKey: "bytes", Function: gracklepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+				},
 			},
 			shouldError: false,
 		},
@@ -609,42 +712,42 @@ func TestValidateGetWaitGroupRequest(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			if test.shouldError {
-				require.Error(t, ValidateGetWaitGroupRequest(test.request))
+				require.Error(t, ValidateCreateWaitGroupRequest(test.request))
 			} else {
-				require.NoError(t, ValidateGetWaitGroupRequest(test.request))
+				require.NoError(t, ValidateCreateWaitGroupRequest(test.request))
 			}
 		})
 	}
 }
 
-func TestValidateDeleteWaitGroupRequest(t *testing.T) {
+func TestValidateGetWaitGroupRequest(t *testing.T) {
 	tests := []struct {
 		name        string
-		request     *gracklepb.DeleteWaitGroupRequest
+		request     *gracklepb.GetWaitGroupRequest
 		shouldError bool
 	}{
 		{
 			name:        "empty request",
-			request:     &gracklepb.DeleteWaitGroupRequest{},
+			request:     &gracklepb.GetWaitGroupRequest{},
 			shouldError: true,
 		},
 		{
 			name: "empty namespace name",
-			request: &gracklepb.DeleteWaitGroupRequest{
+			request: &gracklepb.GetWaitGroupRequest{
 				WaitGroupName: "validname",
 			},
 			shouldError: true,
 		},
 		{
 			name: "empty wait group name",
-			request: &gracklepb.DeleteWaitGroupRequest{
+			request: &gracklepb.GetWaitGroupRequest{
 				NamespaceName: "validname",
 			},
 			shouldError: true,
 		},
 		{
 			name: "namespace name too long",
-			request: &gracklepb.DeleteWaitGroupRequest{
+			request: &gracklepb.GetWaitGroupRequest{
 				NamespaceName: string(make([]byte, 129)),
 				WaitGroupName: "validname",
 			},
@@ -652,7 +755,7 @@ func TestValidateDeleteWaitGroupRequest(t *testing.T) {
 		},
 		{
 			name: "wait group name too long",
-			request: &gracklepb.DeleteWaitGroupRequest{
+			request: &gracklepb.GetWaitGroupRequest{
 				NamespaceName: "validname",
 				WaitGroupName: string(make([]byte, 129)),
 			},
@@ -660,7 +763,7 @@ func TestValidateDeleteWaitGroupRequest(t *testing.T) {
 		},
 		{
 			name: "invalid namespace name characters",
-			request: &gracklepb.DeleteWaitGroupRequest{
+			request: &gracklepb.GetWaitGroupRequest{
 				NamespaceName: "invalid name",
 				WaitGroupName: "validname",
 			},
@@ -668,7 +771,7 @@ func TestValidateDeleteWaitGroupRequest(t *testing.T) {
 		},
 		{
 			name: "invalid wait group name characters",
-			request: &gracklepb.DeleteWaitGroupRequest{
+			request: &gracklepb.GetWaitGroupRequest{
 				NamespaceName: "validname",
 				WaitGroupName: "invalid name",
 			},
@@ -676,7 +779,7 @@ func TestValidateDeleteWaitGroupRequest(t *testing.T) {
 		},
 		{
 			name: "valid request",
-			request: &gracklepb.DeleteWaitGroupRequest{
+			request: &gracklepb.GetWaitGroupRequest{
 				NamespaceName: "validname",
 				WaitGroupName: "validwaitgroup",
 			},
@@ -687,119 +790,114 @@ func TestValidateDeleteWaitGroupRequest(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			if test.shouldError {
-				require.Error(t, ValidateDeleteWaitGroupRequest(test.request))
+				require.Error(t, ValidateGetWaitGroupRequest(test.request))
 			} else {
-				require.NoError(t, ValidateDeleteWaitGroupRequest(test.request))
+				require.NoError(t, ValidateGetWaitGroupRequest(test.request))
 			}
 		})
 	}
 }
 
-func TestValidateWaitForWaitGroupRequest(t *testing.T) {
+func TestValidateWaitForWaitGroupsRequest(t *testing.T) {
+	tooManyNames := make([]string, 101)
+	for i := range tooManyNames {
+		tooManyNames[i] = fmt.Sprintf("waitgroup%d", i)
+	}
+
 	tests := []struct {
 		name        string
-		request     *gracklepb.WaitForWaitGroupRequest
+		request     *gracklepb.WaitForWaitGroupsRequest
 		shouldError bool
 	}{
 		{
 			name:        "empty request",
-			request:     &gracklepb.WaitForWaitGroupRequest{},
+			request:     &gracklepb.WaitForWaitGroupsRequest{},
 			shouldError: true,
 		},
 		{
 			name: "missing namespace name",
-			request: &gracklepb.WaitForWaitGroupRequest{
-				WaitGroupName:  "validname",
+			request: &gracklepb.WaitForWaitGroupsRequest{
+				WaitGroupNames: []string{"waitgroup1"},
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
 				TimeoutSeconds: 10,
 			},
 			shouldError: true,
 		},
 		{
-			name: "missing wait group name",
-			request: &gracklepb.WaitForWaitGroupRequest{
+			name: "no wait group names",
+			request: &gracklepb.WaitForWaitGroupsRequest{
 				NamespaceName:  "validname",
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
 				TimeoutSeconds: 10,
 			},
 			shouldError: true,
 		},
 		{
-			name: "namespace name too long",
-			request: &gracklepb.WaitForWaitGroupRequest{
-				NamespaceName:  string(make([]byte, 129)),
-				WaitGroupName:  "validname",
-				TimeoutSeconds: 10,
-			},
-			shouldError: true,
-		},
-		{
-			name: "wait group name too long",
-			request: &gracklepb.WaitForWaitGroupRequest{
+			name: "too many wait group names",
+			request: &gracklepb.WaitForWaitGroupsRequest{
 				NamespaceName:  "validname",
-				WaitGroupName:  string(make([]byte, 129)),
+				WaitGroupNames: tooManyNames,
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
 				TimeoutSeconds: 10,
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid namespace name characters",
-			request: &gracklepb.WaitForWaitGroupRequest{
-				NamespaceName:  "invalid name",
-				WaitGroupName:  "validname",
+			name: "invalid wait group name",
+			request: &gracklepb.WaitForWaitGroupsRequest{
+				NamespaceName:  "validname",
+				WaitGroupNames: []string{"waitgroup1", "invalid@name"},
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
 				TimeoutSeconds: 10,
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid wait group name characters",
-			request: &gracklepb.WaitForWaitGroupRequest{
+			name: "duplicate wait group name",
+			request: &gracklepb.WaitForWaitGroupsRequest{
 				NamespaceName:  "validname",
-				WaitGroupName:  "invalid name",
+				WaitGroupNames: []string{"waitgroup1", "waitgroup1"},
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
 				TimeoutSeconds: 10,
 			},
 			shouldError: true,
 		},
 		{
-			name: "timeout seconds zero",
-			request: &gracklepb.WaitForWaitGroupRequest{
-				NamespaceName:  "validname",
-				WaitGroupName:  "validname",
-				TimeoutSeconds: 0,
-			},
-			shouldError: false,
-		},
-		{
-			name: "timeout seconds negative",
-			request: &gracklepb.WaitForWaitGroupRequest{
+			name: "missing mode",
+			request: &gracklepb.WaitForWaitGroupsRequest{
 				NamespaceName:  "validname",
-				WaitGroupName:  "validname",
-				TimeoutSeconds: -1,
+				WaitGroupNames: []string{"waitgroup1"},
+				TimeoutSeconds: 10,
 			},
 			shouldError: true,
 		},
 		{
-			name: "timeout seconds too high",
-			request: &gracklepb.WaitForWaitGroupRequest{
+			name: "invalid timeout",
+			request: &gracklepb.WaitForWaitGroupsRequest{
 				NamespaceName:  "validname",
-				WaitGroupName:  "validname",
+				WaitGroupNames: []string{"waitgroup1"},
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ANY,
 				TimeoutSeconds: 301,
 			},
 			shouldError: true,
 		},
 		{
-			name: "valid request",
-			request: &gracklepb.WaitForWaitGroupRequest{
+			name: "valid ALL request",
+			request: &gracklepb.WaitForWaitGroupsRequest{
 				NamespaceName:  "validname",
-				WaitGroupName:  "validname",
+				WaitGroupNames: []string{"waitgroup1", "waitgroup2"},
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ALL,
 				TimeoutSeconds: 10,
 			},
 			shouldError: false,
 		},
 		{
-			name: "valid request with maximum timeout",
-			request: &gracklepb.WaitForWaitGroupRequest{
+			name: "valid ANY request",
+			request: &gracklepb.WaitForWaitGroupsRequest{
 				NamespaceName:  "validname",
-				WaitGroupName:  "validname",
-				TimeoutSeconds: 300,
+				WaitGroupNames: []string{"waitgroup1", "waitgroup2"},
+				Mode:           gracklepb.WaitGroupsWaitMode_WAIT_GROUPS_WAIT_MODE_ANY,
+				TimeoutSeconds: 10,
 			},
 			shouldError: false,
 		},
@@ -808,48 +906,311 @@ func TestValidateWaitForWaitGroupRequest(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			if test.shouldError {
-				require.Error(t, ValidateWaitForWaitGroupRequest(test.request))
+				require.Error(t, ValidateWaitForWaitGroupsRequest(test.request))
 			} else {
-				require.NoError(t, ValidateWaitForWaitGroupRequest(test.request))
+				require.NoError(t, ValidateWaitForWaitGroupsRequest(test.request))
 			}
 		})
 	}
 }
 
-func TestValidateListWaitGroupsRequest(t *testing.T) {
+func TestValidateCancelWaitGroupRequest(t *testing.T) {
 	tests := []struct {
 		name        string
-		request     *gracklepb.ListWaitGroupsRequest
+		request     *gracklepb.CancelWaitGroupRequest
 		shouldError bool
 	}{
 		{
-			name:        "empty request - should fail due to missing namespace name",
-			request:     &gracklepb.ListWaitGroupsRequest{},
+			name:        "empty request",
+			request:     &gracklepb.CancelWaitGroupRequest{},
 			shouldError: true,
 		},
 		{
-			name: "empty namespace name",
-			request: &gracklepb.ListWaitGroupsRequest{
-				NamespaceName: "",
+			name: "missing namespace name",
+			request: &gracklepb.CancelWaitGroupRequest{
+				WaitGroupName: "validname",
 			},
 			shouldError: true,
 		},
 		{
-			name: "namespace name too long",
-			request: &gracklepb.ListWaitGroupsRequest{
-				NamespaceName: string(make([]byte, 129)),
+			name: "missing wait group name",
+			request: &gracklepb.CancelWaitGroupRequest{
+				NamespaceName: "validname",
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid namespace name characters",
-			request: &gracklepb.ListWaitGroupsRequest{
-				NamespaceName: "invalid name",
+			name: "reason too long",
+			request: &gracklepb.CancelWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Reason:        string(make([]byte, 1025)),
 			},
 			shouldError: true,
 		},
 		{
-			name: "valid request with no pagination",
+			name: "valid request without reason",
+			request: &gracklepb.CancelWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with reason",
+			request: &gracklepb.CancelWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Reason:        "bad input data",
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateCancelWaitGroupRequest(test.request))
+			} else {
+				require.NoError(t, ValidateCancelWaitGroupRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateDeleteWaitGroupRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.DeleteWaitGroupRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.DeleteWaitGroupRequest{},
+			shouldError: true,
+		},
+		{
+			name: "empty namespace name",
+			request: &gracklepb.DeleteWaitGroupRequest{
+				WaitGroupName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "empty wait group name",
+			request: &gracklepb.DeleteWaitGroupRequest{
+				NamespaceName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "namespace name too long",
+			request: &gracklepb.DeleteWaitGroupRequest{
+				NamespaceName: string(make([]byte, 129)),
+				WaitGroupName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "wait group name too long",
+			request: &gracklepb.DeleteWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: string(make([]byte, 129)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid namespace name characters",
+			request: &gracklepb.DeleteWaitGroupRequest{
+				NamespaceName: "invalid name",
+				WaitGroupName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid wait group name characters",
+			request: &gracklepb.DeleteWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "invalid name",
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.DeleteWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validwaitgroup",
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateDeleteWaitGroupRequest(test.request))
+			} else {
+				require.NoError(t, ValidateDeleteWaitGroupRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateWaitForWaitGroupRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.WaitForWaitGroupRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.WaitForWaitGroupRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				WaitGroupName:  "validname",
+				TimeoutSeconds: 10,
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing wait group name",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				TimeoutSeconds: 10,
+			},
+			shouldError: true,
+		},
+		{
+			name: "namespace name too long",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  string(make([]byte, 129)),
+				WaitGroupName:  "validname",
+				TimeoutSeconds: 10,
+			},
+			shouldError: true,
+		},
+		{
+			name: "wait group name too long",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				WaitGroupName:  string(make([]byte, 129)),
+				TimeoutSeconds: 10,
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid namespace name characters",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "invalid name",
+				WaitGroupName:  "validname",
+				TimeoutSeconds: 10,
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid wait group name characters",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				WaitGroupName:  "invalid name",
+				TimeoutSeconds: 10,
+			},
+			shouldError: true,
+		},
+		{
+			name: "timeout seconds zero",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				WaitGroupName:  "validname",
+				TimeoutSeconds: 0,
+			},
+			shouldError: false,
+		},
+		{
+			name: "timeout seconds negative",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				WaitGroupName:  "validname",
+				TimeoutSeconds: -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "timeout seconds too high",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				WaitGroupName:  "validname",
+				TimeoutSeconds: 301,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				WaitGroupName:  "validname",
+				TimeoutSeconds: 10,
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with maximum timeout",
+			request: &gracklepb.WaitForWaitGroupRequest{
+				NamespaceName:  "validname",
+				WaitGroupName:  "validname",
+				TimeoutSeconds: 300,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateWaitForWaitGroupRequest(test.request))
+			} else {
+				require.NoError(t, ValidateWaitForWaitGroupRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateListWaitGroupsRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.ListWaitGroupsRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request - should fail due to missing namespace name",
+			request:     &gracklepb.ListWaitGroupsRequest{},
+			shouldError: true,
+		},
+		{
+			name: "empty namespace name",
+			request: &gracklepb.ListWaitGroupsRequest{
+				NamespaceName: "",
+			},
+			shouldError: true,
+		},
+		{
+			name: "namespace name too long",
+			request: &gracklepb.ListWaitGroupsRequest{
+				NamespaceName: string(make([]byte, 129)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid namespace name characters",
+			request: &gracklepb.ListWaitGroupsRequest{
+				NamespaceName: "invalid name",
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with no pagination",
 			request: &gracklepb.ListWaitGroupsRequest{
 				NamespaceName:   "validname",
 				PaginationToken: "",
@@ -1210,7 +1571,131 @@ func TestValidateGetLockRequest(t *testing.T) {
 	}
 }
 
-func TestValidateReleaseLockRequest(t *testing.T) {
+func TestValidateListLockWaitersRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.ListLockWaitersRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request - should fail due to missing namespace name",
+			request:     &gracklepb.ListLockWaitersRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.ListLockWaitersRequest{
+				LockName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing lock name",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid namespace name characters",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "invalid name",
+				LockName:      "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid lock name characters",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "validname",
+				LockName:      "invalid name",
+			},
+			shouldError: true,
+		},
+		{
+			name: "namespace name too long",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: string(make([]byte, 129)),
+				LockName:      "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "lock name too long",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "validname",
+				LockName:      string(make([]byte, 129)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid pagination token (not base64)",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName:   "validname",
+				LockName:        "validname",
+				PaginationToken: "invalid-base64!@#",
+			},
+			shouldError: true,
+		},
+		{
+			name: "pagination token too long",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName:   "validname",
+				LockName:        "validname",
+				PaginationToken: string(make([]byte, 1025)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "limit too high",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "validname",
+				LockName:      "validname",
+				Limit:         251,
+			},
+			shouldError: true,
+		},
+		{
+			name: "negative limit",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "validname",
+				LockName:      "validname",
+				Limit:         -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName: "validname",
+				LockName:      "validname",
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with pagination",
+			request: &gracklepb.ListLockWaitersRequest{
+				NamespaceName:   "validname",
+				LockName:        "validname",
+				PaginationToken: "dGVzdA==",
+				Limit:           50,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateListLockWaitersRequest(test.request))
+			} else {
+				require.NoError(t, ValidateListLockWaitersRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateReleaseLockRequest(t *testing.T) {
 	tests := []struct {
 		name        string
 		request     *gracklepb.ReleaseLockRequest
@@ -1321,6 +1806,81 @@ func TestValidateReleaseLockRequest(t *testing.T) {
 	}
 }
 
+func TestValidateTransferLockRequest(t *testing.T) {
+	fromLeaseId := "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE"
+	toLeaseId := ids.EncodeLeaseId(&corepb.LeaseId{AccountId: 1, NamespaceId: 2, LeaseId: 3})
+
+	tests := []struct {
+		name        string
+		request     *gracklepb.TransferLockRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.TransferLockRequest{},
+			shouldError: true,
+		},
+		{
+			name: "empty namespace name",
+			request: &gracklepb.TransferLockRequest{
+				LockName:    "validlock",
+				FromLeaseId: fromLeaseId,
+				ToLeaseId:   toLeaseId,
+			},
+			shouldError: true,
+		},
+		{
+			name: "empty from lease id",
+			request: &gracklepb.TransferLockRequest{
+				NamespaceName: "validname",
+				LockName:      "validlock",
+				ToLeaseId:     toLeaseId,
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid to lease id",
+			request: &gracklepb.TransferLockRequest{
+				NamespaceName: "validname",
+				LockName:      "validlock",
+				FromLeaseId:   fromLeaseId,
+				ToLeaseId:     "invalid lease id",
+			},
+			shouldError: true,
+		},
+		{
+			name: "same lease",
+			request: &gracklepb.TransferLockRequest{
+				NamespaceName: "validname",
+				LockName:      "validlock",
+				FromLeaseId:   fromLeaseId,
+				ToLeaseId:     fromLeaseId,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.TransferLockRequest{
+				NamespaceName: "validname",
+				LockName:      "validlock",
+				FromLeaseId:   fromLeaseId,
+				ToLeaseId:     toLeaseId,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateTransferLockRequest(test.request))
+			} else {
+				require.NoError(t, ValidateTransferLockRequest(test.request))
+			}
+		})
+	}
+}
+
 func TestValidateAcquireLockRequest(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1419,6 +1979,36 @@ func TestValidateAcquireLockRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "negative max hold seconds",
+			request: &gracklepb.AcquireLockRequest{
+				NamespaceName:  "validname",
+				LockName:       "validlock",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				MaxHoldSeconds: -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "max hold seconds too large",
+			request: &gracklepb.AcquireLockRequest{
+				NamespaceName:  "validname",
+				LockName:       "validlock",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				MaxHoldSeconds: 86401,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with max hold seconds",
+			request: &gracklepb.AcquireLockRequest{
+				NamespaceName:  "validname",
+				LockName:       "validlock",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				MaxHoldSeconds: 60,
+			},
+			shouldError: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -1511,6 +2101,28 @@ func TestValidateCreateSemaphoreRequest(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{
+			name: "invalid parent semaphore name characters",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName:       "validname",
+				SemaphoreName:       "validsemaphore",
+				Description:         "validdescription",
+				Permits:             1,
+				ParentSemaphoreName: "invalid name",
+			},
+			shouldError: true,
+		},
+		{
+			name: "parent semaphore name equals semaphore name",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName:       "validname",
+				SemaphoreName:       "validsemaphore",
+				Description:         "validdescription",
+				Permits:             1,
+				ParentSemaphoreName: "validsemaphore",
+			},
+			shouldError: true,
+		},
 		{
 			name: "valid request",
 			request: &gracklepb.CreateSemaphoreRequest{
@@ -1521,6 +2133,100 @@ func TestValidateCreateSemaphoreRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "valid request with parent semaphore",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName:       "validname",
+				SemaphoreName:       "validsemaphore",
+				Description:         "validdescription",
+				Permits:             1,
+				ParentSemaphoreName: "parentsemaphore",
+			},
+			shouldError: false,
+		},
+		{
+			name: "duplicate class",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validsemaphore",
+				Permits:       20,
+				Classes: []*gracklepb.SemaphoreClassSpec{
+					{Name: "batch", MaxPermits: 15},
+					{Name: "batch", MinPermits: 5},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "class max permits above permits",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validsemaphore",
+				Permits:       20,
+				Classes: []*gracklepb.SemaphoreClassSpec{
+					{Name: "batch", MaxPermits: 25},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "class min permits above max permits",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validsemaphore",
+				Permits:       20,
+				Classes: []*gracklepb.SemaphoreClassSpec{
+					{Name: "batch", MinPermits: 10, MaxPermits: 5},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "class reservations above permits",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validsemaphore",
+				Permits:       20,
+				Classes: []*gracklepb.SemaphoreClassSpec{
+					{Name: "interactive", MinPermits: 15},
+					{Name: "batch", MinPermits: 10},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with classes",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validsemaphore",
+				Permits:       20,
+				Classes: []*gracklepb.SemaphoreClassSpec{
+					{Name: "interactive", MinPermits: 5},
+					{Name: "batch", MaxPermits: 15},
+				},
+			},
+			shouldError: false,
+		},
+		{
+			name: "delete inactive after seconds too small",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName:              "validname",
+				SemaphoreName:              "validsemaphore",
+				Permits:                    20,
+				DeleteInactiveAfterSeconds: 59,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with delete inactive after seconds",
+			request: &gracklepb.CreateSemaphoreRequest{
+				NamespaceName:              "validname",
+				SemaphoreName:              "validsemaphore",
+				Permits:                    20,
+				DeleteInactiveAfterSeconds: 60,
+			},
+			shouldError: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -1830,25 +2536,103 @@ func TestValidateUpdateSemaphoreRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if test.shouldError {
-				require.Error(t, ValidateUpdateSemaphoreRequest(test.request))
-			} else {
-				require.NoError(t, ValidateUpdateSemaphoreRequest(test.request))
-			}
-		})
-	}
-}
-
-func TestValidateDeleteSemaphoreRequest(t *testing.T) {
-	tests := []struct {
-		name        string
-		request     *gracklepb.DeleteSemaphoreRequest
-		shouldError bool
-	}{
+		{
+			name: "valid request with target permits",
+			request: &gracklepb.UpdateSemaphoreRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validsemaphore",
+				Description:     "validdescription",
+				TargetPermits:   1,
+				ExpectedVersion: 1,
+			},
+			shouldError: false,
+		},
+		{
+			name: "negative target permits",
+			request: &gracklepb.UpdateSemaphoreRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validsemaphore",
+				Description:     "validdescription",
+				TargetPermits:   -1,
+				ExpectedVersion: 1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "target permits combined with permits",
+			request: &gracklepb.UpdateSemaphoreRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validsemaphore",
+				Description:     "validdescription",
+				Permits:         5,
+				TargetPermits:   1,
+				ExpectedVersion: 1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "delete inactive after seconds too small",
+			request: &gracklepb.UpdateSemaphoreRequest{
+				NamespaceName:              "validname",
+				SemaphoreName:              "validsemaphore",
+				Permits:                    5,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: 30,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with delete inactive after seconds",
+			request: &gracklepb.UpdateSemaphoreRequest{
+				NamespaceName:              "validname",
+				SemaphoreName:              "validsemaphore",
+				Permits:                    5,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: 3600,
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request turning auto-deletion off",
+			request: &gracklepb.UpdateSemaphoreRequest{
+				NamespaceName:              "validname",
+				SemaphoreName:              "validsemaphore",
+				Permits:                    5,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: -1,
+			},
+			shouldError: false,
+		},
+		{
+			name: "delete inactive after seconds negative",
+			request: &gracklepb.UpdateSemaphoreRequest{
+				NamespaceName:              "validname",
+				SemaphoreName:              "validsemaphore",
+				Permits:                    5,
+				ExpectedVersion:            1,
+				DeleteInactiveAfterSeconds: -2,
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateUpdateSemaphoreRequest(test.request))
+			} else {
+				require.NoError(t, ValidateUpdateSemaphoreRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateDeleteSemaphoreRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.DeleteSemaphoreRequest
+		shouldError bool
+	}{
 		{
 			name:        "empty request",
 			request:     &gracklepb.DeleteSemaphoreRequest{},
@@ -2364,127 +3148,190 @@ func TestValidateAcquireSemaphoreRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if test.shouldError {
-				require.Error(t, ValidateAcquireSemaphoreRequest(test.request))
-			} else {
-				require.NoError(t, ValidateAcquireSemaphoreRequest(test.request))
-			}
-		})
-	}
-}
-
-func TestValidateListWaitGroupCompletedJobsRequest(t *testing.T) {
-	tests := []struct {
-		name        string
-		request     *gracklepb.ListWaitGroupCompletedJobsRequest
-		shouldError bool
-	}{
 		{
-			name:        "empty request - should fail due to missing namespace name",
-			request:     &gracklepb.ListWaitGroupCompletedJobsRequest{},
+			name: "invalid class characters",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         1,
+				Class:          "invalid class",
+			},
 			shouldError: true,
 		},
 		{
-			name: "missing namespace name",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				WaitGroupName: "validname",
+			name: "valid request with class",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         1,
+				Class:          "batch",
 			},
-			shouldError: true,
+			shouldError: false,
 		},
 		{
-			name: "missing wait group name",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: "validname",
+			name: "negative max hold seconds",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         1,
+				MaxHoldSeconds: -1,
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid namespace name characters",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: "invalid name",
-				WaitGroupName: "validname",
+			name: "max hold seconds too large",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         1,
+				MaxHoldSeconds: 86401,
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid wait group name characters",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: "validname",
-				WaitGroupName: "invalid name",
+			name: "valid request with max hold seconds",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         1,
+				MaxHoldSeconds: 86400,
+			},
+			shouldError: false,
+		},
+		{
+			name: "negative default permits",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         1,
+				DefaultPermits: -1,
 			},
 			shouldError: true,
 		},
 		{
-			name: "namespace name too long",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: string(make([]byte, 129)),
-				WaitGroupName: "validname",
+			name: "default delete inactive after seconds too small",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:                     "validname",
+				SemaphoreName:                     "validname",
+				LeaseId:                           "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds:                    10,
+				Weight:                            1,
+				DefaultPermits:                    5,
+				DefaultDeleteInactiveAfterSeconds: 10,
 			},
 			shouldError: true,
 		},
 		{
-			name: "wait group name too long",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: "validname",
-				WaitGroupName: string(make([]byte, 129)),
+			name: "default delete inactive after seconds without default permits",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:                     "validname",
+				SemaphoreName:                     "validname",
+				LeaseId:                           "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds:                    10,
+				Weight:                            1,
+				DefaultDeleteInactiveAfterSeconds: 60,
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid pagination token (not base64)",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName:   "validname",
-				WaitGroupName:   "validname",
-				PaginationToken: "invalid-base64!@#",
+			name: "valid request with defaults",
+			request: &gracklepb.AcquireSemaphoreRequest{
+				NamespaceName:                     "validname",
+				SemaphoreName:                     "validname",
+				LeaseId:                           "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds:                    10,
+				Weight:                            1,
+				DefaultPermits:                    5,
+				DefaultDeleteInactiveAfterSeconds: 60,
 			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateAcquireSemaphoreRequest(test.request))
+			} else {
+				require.NoError(t, ValidateAcquireSemaphoreRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateAdjustSemaphoreHoldRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.AdjustSemaphoreHoldRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.AdjustSemaphoreHoldRequest{},
 			shouldError: true,
 		},
 		{
-			name: "pagination token too long",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName:   "validname",
-				WaitGroupName:   "validname",
-				PaginationToken: string(make([]byte, 1025)),
+			name: "empty semaphore name",
+			request: &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         1,
 			},
 			shouldError: true,
 		},
 		{
-			name: "limit too high",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: "validname",
-				WaitGroupName: "validname",
-				Limit:         251,
+			name: "empty lease id",
+			request: &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				TimeoutSeconds: 10,
+				Weight:         1,
 			},
 			shouldError: true,
 		},
 		{
-			name: "negative limit",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: "validname",
-				WaitGroupName: "validname",
-				Limit:         -1,
+			name: "negative timeout",
+			request: &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: -1,
+				Weight:         1,
 			},
 			shouldError: true,
 		},
 		{
-			name: "valid request",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName: "validname",
-				WaitGroupName: "validname",
+			name: "zero weight",
+			request: &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         0,
 			},
-			shouldError: false,
+			shouldError: true,
 		},
 		{
-			name: "valid request with pagination",
-			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
-				NamespaceName:   "validname",
-				WaitGroupName:   "validname",
-				PaginationToken: "dGVzdA==",
-				Limit:           50,
+			name: "valid request",
+			request: &gracklepb.AdjustSemaphoreHoldRequest{
+				NamespaceName:  "validname",
+				SemaphoreName:  "validname",
+				LeaseId:        "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE",
+				TimeoutSeconds: 10,
+				Weight:         4,
 			},
 			shouldError: false,
 		},
@@ -2493,28 +3340,514 @@ func TestValidateListWaitGroupCompletedJobsRequest(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			if test.shouldError {
-				require.Error(t, ValidateListWaitGroupCompletedJobsRequest(test.request))
+				require.Error(t, ValidateAdjustSemaphoreHoldRequest(test.request))
 			} else {
-				require.NoError(t, ValidateListWaitGroupCompletedJobsRequest(test.request))
+				require.NoError(t, ValidateAdjustSemaphoreHoldRequest(test.request))
 			}
 		})
 	}
 }
 
-func TestValidateListSemaphoreHoldersRequest(t *testing.T) {
+func TestValidateTransferSemaphoreHoldRequest(t *testing.T) {
+	fromLeaseId := "ls_1fM5oldgzaB3TfUzFNzQfMP8ek3XbnFQE"
+	toLeaseId := ids.EncodeLeaseId(&corepb.LeaseId{AccountId: 1, NamespaceId: 2, LeaseId: 3})
+
 	tests := []struct {
 		name        string
-		request     *gracklepb.ListSemaphoreHoldersRequest
+		request     *gracklepb.TransferSemaphoreHoldRequest
 		shouldError bool
 	}{
 		{
-			name:        "empty request - should fail due to missing namespace name",
-			request:     &gracklepb.ListSemaphoreHoldersRequest{},
+			name:        "empty request",
+			request:     &gracklepb.TransferSemaphoreHoldRequest{},
 			shouldError: true,
 		},
 		{
-			name: "missing namespace name",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
+			name: "empty namespace name",
+			request: &gracklepb.TransferSemaphoreHoldRequest{
+				SemaphoreName: "validname",
+				FromLeaseId:   fromLeaseId,
+				ToLeaseId:     toLeaseId,
+			},
+			shouldError: true,
+		},
+		{
+			name: "empty from lease id",
+			request: &gracklepb.TransferSemaphoreHoldRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				ToLeaseId:     toLeaseId,
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid to lease id",
+			request: &gracklepb.TransferSemaphoreHoldRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				FromLeaseId:   fromLeaseId,
+				ToLeaseId:     "invalid lease id",
+			},
+			shouldError: true,
+		},
+		{
+			name: "same lease",
+			request: &gracklepb.TransferSemaphoreHoldRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				FromLeaseId:   fromLeaseId,
+				ToLeaseId:     fromLeaseId,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.TransferSemaphoreHoldRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				FromLeaseId:   fromLeaseId,
+				ToLeaseId:     toLeaseId,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateTransferSemaphoreHoldRequest(test.request))
+			} else {
+				require.NoError(t, ValidateTransferSemaphoreHoldRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateListWaitGroupCompletedJobsRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.ListWaitGroupCompletedJobsRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request - should fail due to missing namespace name",
+			request:     &gracklepb.ListWaitGroupCompletedJobsRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				WaitGroupName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing wait group name",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid namespace name characters",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: "invalid name",
+				WaitGroupName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid wait group name characters",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "invalid name",
+			},
+			shouldError: true,
+		},
+		{
+			name: "namespace name too long",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: string(make([]byte, 129)),
+				WaitGroupName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "wait group name too long",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: "validname",
+				WaitGroupName: string(make([]byte, 129)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid pagination token (not base64)",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName:   "validname",
+				WaitGroupName:   "validname",
+				PaginationToken: "invalid-base64!@#",
+			},
+			shouldError: true,
+		},
+		{
+			name: "pagination token too long",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName:   "validname",
+				WaitGroupName:   "validname",
+				PaginationToken: string(make([]byte, 1025)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "limit too high",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Limit:         251,
+			},
+			shouldError: true,
+		},
+		{
+			name: "negative limit",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Limit:         -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with pagination",
+			request: &gracklepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceName:   "validname",
+				WaitGroupName:   "validname",
+				PaginationToken: "dGVzdA==",
+				Limit:           50,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateListWaitGroupCompletedJobsRequest(test.request))
+			} else {
+				require.NoError(t, ValidateListWaitGroupCompletedJobsRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateUncompleteJobsFromWaitGroupRequest(t *testing.T) {
+	tooManyJobIds := make([]string, 51)
+	for i := range tooManyJobIds {
+		tooManyJobIds[i] = fmt.Sprintf("job%d", i)
+	}
+
+	tests := []struct {
+		name        string
+		request     *gracklepb.UncompleteJobsFromWaitGroupRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.UncompleteJobsFromWaitGroupRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.UncompleteJobsFromWaitGroupRequest{
+				WaitGroupName: "validname",
+				JobIds:        []string{"job1"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing wait group name",
+			request: &gracklepb.UncompleteJobsFromWaitGroupRequest{
+				NamespaceName: "validname",
+				JobIds:        []string{"job1"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "empty job id",
+			request: &gracklepb.UncompleteJobsFromWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        []string{"job1", ""},
+			},
+			shouldError: true,
+		},
+		{
+			name: "too many job ids",
+			request: &gracklepb.UncompleteJobsFromWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        tooManyJobIds,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.UncompleteJobsFromWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        []string{"job1", "job2"},
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with reopen",
+			request: &gracklepb.UncompleteJobsFromWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        []string{"job1"},
+				Reopen:        true,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateUncompleteJobsFromWaitGroupRequest(test.request))
+			} else {
+				require.NoError(t, ValidateUncompleteJobsFromWaitGroupRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateAddJobsToWaitGroupRequest(t *testing.T) {
+	tooManyJobIds := make([]string, 1001)
+	for i := range tooManyJobIds {
+		tooManyJobIds[i] = fmt.Sprintf("job%d", i)
+	}
+
+	tests := []struct {
+		name        string
+		request     *gracklepb.AddJobsToWaitGroupRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.AddJobsToWaitGroupRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.AddJobsToWaitGroupRequest{
+				WaitGroupName: "validname",
+				JobIds:        []string{"job1"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing wait group name",
+			request: &gracklepb.AddJobsToWaitGroupRequest{
+				NamespaceName: "validname",
+				JobIds:        []string{"job1"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "empty job id",
+			request: &gracklepb.AddJobsToWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        []string{"job1", ""},
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid job id characters",
+			request: &gracklepb.AddJobsToWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        []string{"job 1"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "too many job ids",
+			request: &gracklepb.AddJobsToWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        tooManyJobIds,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.AddJobsToWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				JobIds:        []string{"job1", "job2"},
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateAddJobsToWaitGroupRequest(test.request))
+			} else {
+				require.NoError(t, ValidateAddJobsToWaitGroupRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateAddToWaitGroupRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.AddToWaitGroupRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.AddToWaitGroupRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.AddToWaitGroupRequest{
+				WaitGroupName: "validname",
+				Delta:         1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing wait group name",
+			request: &gracklepb.AddToWaitGroupRequest{
+				NamespaceName: "validname",
+				Delta:         1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "zero delta",
+			request: &gracklepb.AddToWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid positive delta",
+			request: &gracklepb.AddToWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Delta:         5,
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid negative delta",
+			request: &gracklepb.AddToWaitGroupRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Delta:         -5,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateAddToWaitGroupRequest(test.request))
+			} else {
+				require.NoError(t, ValidateAddToWaitGroupRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateListWaitGroupPendingJobsRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.ListWaitGroupPendingJobsRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.ListWaitGroupPendingJobsRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing wait group name",
+			request: &gracklepb.ListWaitGroupPendingJobsRequest{
+				NamespaceName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "pagination token too long",
+			request: &gracklepb.ListWaitGroupPendingJobsRequest{
+				NamespaceName:   "validname",
+				WaitGroupName:   "validname",
+				PaginationToken: string(make([]byte, 1025)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "limit too high",
+			request: &gracklepb.ListWaitGroupPendingJobsRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Limit:         251,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.ListWaitGroupPendingJobsRequest{
+				NamespaceName: "validname",
+				WaitGroupName: "validname",
+				Limit:         50,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateListWaitGroupPendingJobsRequest(test.request))
+			} else {
+				require.NoError(t, ValidateListWaitGroupPendingJobsRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateListSemaphoreHoldersRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.ListSemaphoreHoldersRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request - should fail due to missing namespace name",
+			request:     &gracklepb.ListSemaphoreHoldersRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
 				SemaphoreName: "validname",
 			},
 			shouldError: true,
@@ -2543,72 +3876,280 @@ func TestValidateListSemaphoreHoldersRequest(t *testing.T) {
 			shouldError: true,
 		},
 		{
-			name: "namespace name too long",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
-				NamespaceName: string(make([]byte, 129)),
+			name: "namespace name too long",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName: string(make([]byte, 129)),
+				SemaphoreName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "semaphore name too long",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: string(make([]byte, 129)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid pagination token (not base64)",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validname",
+				PaginationToken: "invalid-base64!@#",
+			},
+			shouldError: true,
+		},
+		{
+			name: "pagination token too long",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validname",
+				PaginationToken: string(make([]byte, 1025)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "limit too high",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				Limit:         251,
+			},
+			shouldError: true,
+		},
+		{
+			name: "negative limit",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				Limit:         -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with pagination",
+			request: &gracklepb.ListSemaphoreHoldersRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validname",
+				PaginationToken: "dGVzdA==",
+				Limit:           50,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateListSemaphoreHoldersRequest(test.request))
+			} else {
+				require.NoError(t, ValidateListSemaphoreHoldersRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateListSemaphoreWaitersRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.ListSemaphoreWaitersRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request - should fail due to missing namespace name",
+			request:     &gracklepb.ListSemaphoreWaitersRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				SemaphoreName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing semaphore name",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid namespace name characters",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "invalid name",
+				SemaphoreName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid semaphore name characters",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "invalid name",
+			},
+			shouldError: true,
+		},
+		{
+			name: "namespace name too long",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: string(make([]byte, 129)),
+				SemaphoreName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "semaphore name too long",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: string(make([]byte, 129)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid pagination token (not base64)",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validname",
+				PaginationToken: "invalid-base64!@#",
+			},
+			shouldError: true,
+		},
+		{
+			name: "pagination token too long",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validname",
+				PaginationToken: string(make([]byte, 1025)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "limit too high",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				Limit:         251,
+			},
+			shouldError: true,
+		},
+		{
+			name: "negative limit",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+				Limit:         -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with pagination",
+			request: &gracklepb.ListSemaphoreWaitersRequest{
+				NamespaceName:   "validname",
+				SemaphoreName:   "validname",
+				PaginationToken: "dGVzdA==",
+				Limit:           50,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateListSemaphoreWaitersRequest(test.request))
+			} else {
+				require.NoError(t, ValidateListSemaphoreWaitersRequest(test.request))
+			}
+		})
+	}
+}
+
+func TestValidateGetSemaphoreStatsRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.GetSemaphoreStatsRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request",
+			request:     &gracklepb.GetSemaphoreStatsRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.GetSemaphoreStatsRequest{
 				SemaphoreName: "validname",
+				WindowSeconds: 3600,
 			},
 			shouldError: true,
 		},
 		{
-			name: "semaphore name too long",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
+			name: "missing semaphore name",
+			request: &gracklepb.GetSemaphoreStatsRequest{
 				NamespaceName: "validname",
-				SemaphoreName: string(make([]byte, 129)),
+				WindowSeconds: 3600,
 			},
 			shouldError: true,
 		},
 		{
-			name: "invalid pagination token (not base64)",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
-				NamespaceName:   "validname",
-				SemaphoreName:   "validname",
-				PaginationToken: "invalid-base64!@#",
+			name: "invalid semaphore name characters",
+			request: &gracklepb.GetSemaphoreStatsRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "invalid name",
+				WindowSeconds: 3600,
 			},
 			shouldError: true,
 		},
 		{
-			name: "pagination token too long",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
-				NamespaceName:   "validname",
-				SemaphoreName:   "validname",
-				PaginationToken: string(make([]byte, 1025)),
+			name: "missing window",
+			request: &gracklepb.GetSemaphoreStatsRequest{
+				NamespaceName: "validname",
+				SemaphoreName: "validname",
 			},
 			shouldError: true,
 		},
 		{
-			name: "limit too high",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
+			name: "window too short",
+			request: &gracklepb.GetSemaphoreStatsRequest{
 				NamespaceName: "validname",
 				SemaphoreName: "validname",
-				Limit:         251,
+				WindowSeconds: 59,
 			},
 			shouldError: true,
 		},
 		{
-			name: "negative limit",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
+			name: "window too long",
+			request: &gracklepb.GetSemaphoreStatsRequest{
 				NamespaceName: "validname",
 				SemaphoreName: "validname",
-				Limit:         -1,
+				WindowSeconds: 86401,
 			},
 			shouldError: true,
 		},
 		{
 			name: "valid request",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
+			request: &gracklepb.GetSemaphoreStatsRequest{
 				NamespaceName: "validname",
 				SemaphoreName: "validname",
-			},
-			shouldError: false,
-		},
-		{
-			name: "valid request with pagination",
-			request: &gracklepb.ListSemaphoreHoldersRequest{
-				NamespaceName:   "validname",
-				SemaphoreName:   "validname",
-				PaginationToken: "dGVzdA==",
-				Limit:           50,
+				WindowSeconds: 86400,
 			},
 			shouldError: false,
 		},
@@ -2617,9 +4158,9 @@ func TestValidateListSemaphoreHoldersRequest(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			if test.shouldError {
-				require.Error(t, ValidateListSemaphoreHoldersRequest(test.request))
+				require.Error(t, ValidateGetSemaphoreStatsRequest(test.request))
 			} else {
-				require.NoError(t, ValidateListSemaphoreHoldersRequest(test.request))
+				require.NoError(t, ValidateGetSemaphoreStatsRequest(test.request))
 			}
 		})
 	}
@@ -2756,6 +4297,94 @@ func TestValidateCreateBarrierRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "roster smaller than expected processes",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          3,
+				DeleteInactiveAfterSeconds: 3600,
+				Roster:                     []string{"shard-0", "shard-1"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "roster with duplicate process id",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          2,
+				DeleteInactiveAfterSeconds: 3600,
+				Roster:                     []string{"shard-0", "shard-0", "shard-1"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "roster with invalid process id",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          1,
+				DeleteInactiveAfterSeconds: 3600,
+				Roster:                     []string{"invalid id"},
+			},
+			shouldError: true,
+		},
+		{
+			name: "release threshold above expected processes",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          3,
+				DeleteInactiveAfterSeconds: 3600,
+				ReleaseThreshold:           4,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with release threshold",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          5,
+				DeleteInactiveAfterSeconds: 3600,
+				ReleaseThreshold:           3,
+			},
+			shouldError: false,
+		},
+		{
+			name: "retained generations negative",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          3,
+				DeleteInactiveAfterSeconds: 3600,
+				RetainedGenerations:        -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "retained generations too large",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          3,
+				DeleteInactiveAfterSeconds: 3600,
+				RetainedGenerations:        101,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request with roster",
+			request: &gracklepb.CreateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          2,
+				DeleteInactiveAfterSeconds: 3600,
+				Roster:                     []string{"shard-0", "shard-1", "shard-2"},
+			},
+			shouldError: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -3139,6 +4768,18 @@ func TestValidateUpdateBarrierRequest(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{
+			name: "roster smaller than expected processes",
+			request: &gracklepb.UpdateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          5,
+				DeleteInactiveAfterSeconds: 3600,
+				ExpectedVersion:            1,
+				Roster:                     []string{"shard-0"},
+			},
+			shouldError: true,
+		},
 		{
 			name: "valid request",
 			request: &gracklepb.UpdateBarrierRequest{
@@ -3151,6 +4792,18 @@ func TestValidateUpdateBarrierRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "valid request with roster",
+			request: &gracklepb.UpdateBarrierRequest{
+				NamespaceName:              "validname",
+				BarrierName:                "validname",
+				ExpectedProcesses:          2,
+				DeleteInactiveAfterSeconds: 3600,
+				ExpectedVersion:            1,
+				Roster:                     []string{"shard-0", "shard-1"},
+			},
+			shouldError: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -3272,6 +4925,17 @@ func TestValidateArriveAtBarrierRequest(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{
+			name: "payload too large",
+			request: &gracklepb.ArriveAtBarrierRequest{
+				NamespaceName:      "validname",
+				BarrierName:        "validname",
+				ProcessId:          "proc1",
+				ExpectedGeneration: 1,
+				Payload:            make([]byte, 4097),
+			},
+			shouldError: true,
+		},
 		{
 			name: "valid request",
 			request: &gracklepb.ArriveAtBarrierRequest{
@@ -3282,6 +4946,17 @@ func TestValidateArriveAtBarrierRequest(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "valid request with payload",
+			request: &gracklepb.ArriveAtBarrierRequest{
+				NamespaceName:      "validname",
+				BarrierName:        "validname",
+				ProcessId:          "proc1",
+				ExpectedGeneration: 1,
+				Payload:            make([]byte, 4096),
+			},
+			shouldError: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -3570,6 +5245,130 @@ func TestValidateListBarrierParticipantsRequest(t *testing.T) {
 	}
 }
 
+func TestValidateListBarrierGenerationsRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *gracklepb.ListBarrierGenerationsRequest
+		shouldError bool
+	}{
+		{
+			name:        "empty request - should fail due to missing namespace name",
+			request:     &gracklepb.ListBarrierGenerationsRequest{},
+			shouldError: true,
+		},
+		{
+			name: "missing namespace name",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				BarrierName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing barrier name",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid namespace name characters",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: "invalid name",
+				BarrierName:   "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid barrier name characters",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: "validname",
+				BarrierName:   "invalid name",
+			},
+			shouldError: true,
+		},
+		{
+			name: "namespace name too long",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: string(make([]byte, 129)),
+				BarrierName:   "validname",
+			},
+			shouldError: true,
+		},
+		{
+			name: "barrier name too long",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: "validname",
+				BarrierName:   string(make([]byte, 129)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid pagination token (not base64)",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName:   "validname",
+				BarrierName:     "validname",
+				PaginationToken: "invalid-base64!@#",
+			},
+			shouldError: true,
+		},
+		{
+			name: "pagination token too long",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName:   "validname",
+				BarrierName:     "validname",
+				PaginationToken: string(make([]byte, 1025)),
+			},
+			shouldError: true,
+		},
+		{
+			name: "limit too high",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: "validname",
+				BarrierName:   "validname",
+				Limit:         251,
+			},
+			shouldError: true,
+		},
+		{
+			name: "negative limit",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: "validname",
+				BarrierName:   "validname",
+				Limit:         -1,
+			},
+			shouldError: true,
+		},
+		{
+			name: "valid request",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName: "validname",
+				BarrierName:   "validname",
+			},
+			shouldError: false,
+		},
+		{
+			name: "valid request with pagination",
+			request: &gracklepb.ListBarrierGenerationsRequest{
+				NamespaceName:   "validname",
+				BarrierName:     "validname",
+				PaginationToken: "dGVzdA==",
+				Limit:           50,
+			},
+			shouldError: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.shouldError {
+				require.Error(t, ValidateListBarrierGenerationsRequest(test.request))
+			} else {
+				require.NoError(t, ValidateListBarrierGenerationsRequest(test.request))
+			}
+		})
+	}
+}
+
 func TestValidateCreateSemaphoreLeaseRequest(t *testing.T) {
 	tests := []struct {
 		name        string
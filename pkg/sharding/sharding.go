@@ -1,6 +1,8 @@
 package sharding
 
 import (
+	"hash/fnv"
+
 	"github.com/evrblk/monstera/cluster"
 	"github.com/evrblk/monstera/utils"
 )
@@ -12,3 +14,17 @@ func ByAccount(accountId uint64) cluster.ShardKey {
 func ByAccountAndNamespace(accountId uint64, namespaceId uint64) cluster.ShardKey {
 	return utils.GetShardKey(utils.ConcatBytes(accountId, namespaceId))
 }
+
+// ByWaitGroupStripe spreads the stripes of a striped wait group over shards,
+// independently of the shard of its namespace.
+func ByWaitGroupStripe(accountId uint64, namespaceId uint64, waitGroupId uint64, stripe uint32) cluster.ShardKey {
+	return utils.GetShardKey(utils.ConcatBytes(accountId, namespaceId, waitGroupId, uint64(stripe)))
+}
+
+// WaitGroupStripe returns the stripe of a striped wait group with the given
+// number of stripes that a job is completed on.
+func WaitGroupStripe(jobId string, stripes uint32) uint32 {
+	h := fnv.New64a()
+	h.Write([]byte(jobId))
+	return uint32(h.Sum64() % uint64(stripes))
+}
@@ -250,3 +250,50 @@ func TestByAccountAndNamespace(t *testing.T) {
 	// 	fmt.Printf("{%d, %d, []byte{%d, %d, %d, %d}},\n", a, n, key[0], key[1], key[2], key[3])
 	// }
 }
+
+func TestByWaitGroupStripe(t *testing.T) {
+	shardKeys := []struct {
+		accountId   uint64
+		namespaceId uint64
+		waitGroupId uint64
+		stripe      uint32
+		shardKey    cluster.ShardKey
+	}{
+		{506947439580894941, 11237939793831718935, 8965188916112390099, 55, 0x2682860d},
+		{1671128954004970364, 9115240317157478286, 6006483292681103419, 32, 0x931f4a9e},
+		{7312312094531930658, 8428601371007407779, 6606098908700596771, 21, 0x0917a990},
+		{10161098715808189816, 6356679244858162814, 11109583474515602465, 25, 0xfaa73336},
+		{5718077459476490765, 9980121671321496172, 676453776509077141, 63, 0xcde9f4e5},
+		{17008175385451619891, 9178183755023910542, 16404742618055799363, 48, 0x3542e1c9},
+		{15016645805250199245, 34821844697859367, 6293997028057671403, 33, 0x4f7f9fc6},
+		{6331946648700181823, 14978907022735106088, 9445720305467256816, 59, 0xa1a4fd3b},
+		{15416211202708720644, 9985179851767544675, 2735586358363159502, 18, 0x3077a828},
+		{10423229262775099356, 9927961400261668122, 8687506913169382730, 4, 0x3c432ccf},
+	}
+
+	for _, d := range shardKeys {
+		actual := ByWaitGroupStripe(d.accountId, d.namespaceId, d.waitGroupId, d.stripe)
+		require.Equal(t, d.shardKey, actual)
+	}
+}
+
+func TestWaitGroupStripe(t *testing.T) {
+	stripes := []struct {
+		jobId   string
+		stripes uint32
+		stripe  uint32
+	}{
+		{"job_1", 16, 8},
+		{"job_2", 16, 1},
+		{"shard-0", 16, 6},
+		{"shard-1", 16, 9},
+		{"0", 16, 15},
+		{"1", 16, 12},
+		{"12345", 16, 8},
+	}
+
+	for _, d := range stripes {
+		actual := WaitGroupStripe(d.jobId, d.stripes)
+		require.Equal(t, d.stripe, actual)
+	}
+}
@@ -0,0 +1,191 @@
+package waitgroups
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"slices"
+	"strconv"
+)
+
+// Integer job ids are tracked in chunks of jobChunkSize consecutive ids, and
+// the job ids of a chunk in roaring-style containers of 16-bit offsets. A
+// container is either a sorted array of big-endian uint16 offsets (while it
+// holds fewer than arrayContainerMaxSize offsets) or a bitmap of
+// bitmapContainerBytes bytes, told apart by their length: an array container
+// is always shorter than a bitmap container.
+const (
+	jobChunkSize          = 1 << 16
+	arrayContainerMaxSize = 4096
+	bitmapContainerBytes  = jobChunkSize / 8
+)
+
+// parseIntegerJobId parses a job id of a wait group with integer job ids. Only
+// the canonical decimal form is accepted, so every integer job id has exactly
+// one spelling.
+func parseIntegerJobId(jobId string) (uint64, bool) {
+	id, err := strconv.ParseUint(jobId, 10, 64)
+	if err != nil || strconv.FormatUint(id, 10) != jobId {
+		return 0, false
+	}
+	return id, true
+}
+
+// formatIntegerJobId is the inverse of parseIntegerJobId.
+func formatIntegerJobId(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+func isBitmapContainer(container []byte) bool {
+	return len(container) == bitmapContainerBytes
+}
+
+// containerCardinality returns the number of offsets in the container.
+func containerCardinality(container []byte) int {
+	if !isBitmapContainer(container) {
+		return len(container) / 2
+	}
+
+	cardinality := 0
+	for i := 0; i < bitmapContainerBytes; i += 8 {
+		cardinality += bits.OnesCount64(binary.LittleEndian.Uint64(container[i:]))
+	}
+	return cardinality
+}
+
+// containerContains reports whether the offset is in the container.
+func containerContains(container []byte, offset uint16) bool {
+	if isBitmapContainer(container) {
+		return container[offset/8]&(1<<(offset%8)) != 0
+	}
+
+	_, found := arraySearch(container, offset)
+	return found
+}
+
+// containerAdd adds the offset to the container and returns the updated
+// container, which may share memory with the given one. An array container
+// that reaches arrayContainerMaxSize offsets is converted to a bitmap.
+func containerAdd(container []byte, offset uint16) []byte {
+	if isBitmapContainer(container) {
+		container[offset/8] |= 1 << (offset % 8)
+		return container
+	}
+
+	i, found := arraySearch(container, offset)
+	if found {
+		return container
+	}
+
+	if len(container)/2+1 >= arrayContainerMaxSize {
+		bitmap := make([]byte, bitmapContainerBytes)
+		for j := 0; j < len(container); j += 2 {
+			o := binary.BigEndian.Uint16(container[j:])
+			bitmap[o/8] |= 1 << (o % 8)
+		}
+		bitmap[offset/8] |= 1 << (offset % 8)
+		return bitmap
+	}
+
+	return slices.Insert(container, i*2, byte(offset>>8), byte(offset))
+}
+
+// containerRemove removes the offset from the container and returns the
+// updated container, which may share memory with the given one. A bitmap
+// container that drops below arrayContainerMaxSize offsets is converted back
+// to an array.
+func containerRemove(container []byte, offset uint16) []byte {
+	if isBitmapContainer(container) {
+		container[offset/8] &^= 1 << (offset % 8)
+		if containerCardinality(container) >= arrayContainerMaxSize {
+			return container
+		}
+
+		array := make([]byte, 0, 2*(arrayContainerMaxSize-1))
+		for o := containerNext(container, 0); o >= 0; o = containerNext(container, o+1) {
+			array = append(array, byte(o>>8), byte(o))
+		}
+		return array
+	}
+
+	i, found := arraySearch(container, offset)
+	if !found {
+		return container
+	}
+	return slices.Delete(container, i*2, i*2+2)
+}
+
+// containerNext returns the smallest offset in the container that is not less
+// than from, or -1 if there is none.
+func containerNext(container []byte, from int) int {
+	if from >= jobChunkSize {
+		return -1
+	}
+
+	if !isBitmapContainer(container) {
+		i, _ := arraySearch(container, uint16(from))
+		if i*2 >= len(container) {
+			return -1
+		}
+		return int(binary.BigEndian.Uint16(container[i*2:]))
+	}
+
+	for word := from / 64; word < bitmapContainerBytes/8; word++ {
+		w := binary.LittleEndian.Uint64(container[word*8:])
+		if word == from/64 {
+			w &= ^uint64(0) << (from % 64)
+		}
+		if w != 0 {
+			return word*64 + bits.TrailingZeros64(w)
+		}
+	}
+	return -1
+}
+
+// containerPrevious returns the largest offset in the container that is less
+// than before, or -1 if there is none.
+func containerPrevious(container []byte, before int) int {
+	if before <= 0 {
+		return -1
+	}
+	if before > jobChunkSize {
+		before = jobChunkSize
+	}
+
+	if !isBitmapContainer(container) {
+		i := len(container) / 2
+		if before < jobChunkSize {
+			i, _ = arraySearch(container, uint16(before))
+		}
+		if i == 0 {
+			return -1
+		}
+		return int(binary.BigEndian.Uint16(container[(i-1)*2:]))
+	}
+
+	last := before - 1
+	for word := last / 64; word >= 0; word-- {
+		w := binary.LittleEndian.Uint64(container[word*8:])
+		if word == last/64 {
+			w &= ^uint64(0) >> (63 - last%64)
+		}
+		if w != 0 {
+			return word*64 + 63 - bits.LeadingZeros64(w)
+		}
+	}
+	return -1
+}
+
+// arraySearch returns the index of the offset in an array container, or the
+// index it would be inserted at if it is not there.
+func arraySearch(container []byte, offset uint16) (int, bool) {
+	lo, hi := 0, len(container)/2
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if binary.BigEndian.Uint16(container[mid*2:]) < offset {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(container)/2 && binary.BigEndian.Uint16(container[lo*2:]) == offset
+}
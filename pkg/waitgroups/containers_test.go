@@ -0,0 +1,119 @@
+package waitgroups
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIntegerJobId(t *testing.T) {
+	for _, jobId := range []string{"0", "1", "65536", "18446744073709551615"} {
+		id, ok := parseIntegerJobId(jobId)
+		require.True(t, ok, jobId)
+		require.Equal(t, jobId, formatIntegerJobId(id))
+	}
+
+	for _, jobId := range []string{"", "01", "+1", "-1", "1.0", "job_1", "18446744073709551616"} {
+		_, ok := parseIntegerJobId(jobId)
+		require.False(t, ok, jobId)
+	}
+}
+
+func TestContainers(t *testing.T) {
+	t.Run("switch between array and bitmap", func(t *testing.T) {
+		var container []byte
+
+		// The array holds up to arrayContainerMaxSize-1 offsets
+		for offset := 0; offset < 2*(arrayContainerMaxSize-1); offset += 2 {
+			container = containerAdd(container, uint16(offset))
+		}
+		require.False(t, isBitmapContainer(container))
+		require.Equal(t, arrayContainerMaxSize-1, containerCardinality(container))
+
+		// Adding an offset twice is a no-op
+		container = containerAdd(container, 0)
+		require.False(t, isBitmapContainer(container))
+
+		// One more turns it into a bitmap
+		container = containerAdd(container, 1)
+		require.True(t, isBitmapContainer(container))
+		require.Equal(t, arrayContainerMaxSize, containerCardinality(container))
+		require.True(t, containerContains(container, 1))
+		require.True(t, containerContains(container, 2))
+		require.False(t, containerContains(container, 3))
+
+		// Removing one turns it back into an array
+		container = containerRemove(container, 1)
+		require.False(t, isBitmapContainer(container))
+		require.Equal(t, arrayContainerMaxSize-1, containerCardinality(container))
+		require.False(t, containerContains(container, 1))
+		require.True(t, containerContains(container, 2))
+	})
+
+	t.Run("random offsets", func(t *testing.T) {
+		var container []byte
+		expected := make(map[uint16]bool)
+
+		for i := 0; i < 20000; i++ {
+			offset := uint16(rand.IntN(jobChunkSize))
+			if rand.IntN(4) == 0 {
+				container = containerRemove(container, offset)
+				delete(expected, offset)
+			} else {
+				container = containerAdd(container, offset)
+				expected[offset] = true
+			}
+		}
+		require.Equal(t, len(expected), containerCardinality(container))
+
+		sorted := make([]int, 0, len(expected))
+		for offset := range expected {
+			sorted = append(sorted, int(offset))
+		}
+		slices.Sort(sorted)
+
+		// Walk the container forward and backward
+		var forward []int
+		for offset := containerNext(container, 0); offset >= 0; offset = containerNext(container, offset+1) {
+			forward = append(forward, offset)
+		}
+		require.Equal(t, sorted, forward)
+
+		var backward []int
+		for offset := containerPrevious(container, jobChunkSize); offset >= 0; offset = containerPrevious(container, offset) {
+			backward = append(backward, offset)
+		}
+		slices.Reverse(backward)
+		require.Equal(t, sorted, backward)
+
+		// And remove everything
+		for _, offset := range sorted {
+			container = containerRemove(container, uint16(offset))
+		}
+		require.Empty(t, container)
+		require.Equal(t, -1, containerNext(container, 0))
+		require.Equal(t, -1, containerPrevious(container, jobChunkSize))
+	})
+
+	t.Run("bitmap edges", func(t *testing.T) {
+		container := make([]byte, bitmapContainerBytes)
+		container = containerAdd(container, 0)
+		container = containerAdd(container, 63)
+		container = containerAdd(container, 64)
+		container = containerAdd(container, jobChunkSize-1)
+
+		require.Equal(t, 0, containerNext(container, 0))
+		require.Equal(t, 63, containerNext(container, 1))
+		require.Equal(t, 64, containerNext(container, 64))
+		require.Equal(t, jobChunkSize-1, containerNext(container, 65))
+		require.Equal(t, -1, containerNext(container, jobChunkSize))
+
+		require.Equal(t, jobChunkSize-1, containerPrevious(container, jobChunkSize))
+		require.Equal(t, 64, containerPrevious(container, jobChunkSize-1))
+		require.Equal(t, 63, containerPrevious(container, 64))
+		require.Equal(t, 0, containerPrevious(container, 63))
+		require.Equal(t, -1, containerPrevious(container, 0))
+	})
+}
@@ -1,9 +1,13 @@
 package waitgroups
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/evrblk/monstera"
@@ -28,10 +32,17 @@ type Core struct {
 
 	waitGroups        *waitGroupsTable
 	jobs              *jobsTable
+	pendingJobs       *pendingJobsTable
+	retractedJobs     *retractedJobsTable
+	jobChunks         *jobChunksTable
+	stripes           *stripesTable
+	stripeJobs        *stripeJobsTable
 	counters          *tables.CountersTable[*corepb.WaitGroupsCounter, corepb.WaitGroupsCounter]
 	gcRecords         *tables.GCRecordsTable[*corepb.WaitGroupsGarbageCollectionRecord, corepb.WaitGroupsGarbageCollectionRecord]
 	expirationRecords *expirationRecordsTable
 	deletionRecords   *deletionRecordsTable
+
+	stripeDeletionRecords *stripeDeletionRecordsTable
 }
 
 var _ coreapis.GrackleWaitGroupsCoreApi = &Core{}
@@ -50,8 +61,13 @@ func NewCore(badgerStore *store.BadgerStore, replicaPrefix []byte, shardLowerBou
 		shardLowerBound: shardLowerBound,
 		shardUpperBound: shardUpperBound,
 
-		waitGroups: newWaitGroupsTable(replicaPrefix),
-		jobs:       newJobsTable(replicaPrefix),
+		waitGroups:    newWaitGroupsTable(replicaPrefix),
+		jobs:          newJobsTable(replicaPrefix),
+		pendingJobs:   newPendingJobsTable(replicaPrefix),
+		retractedJobs: newRetractedJobsTable(replicaPrefix),
+		jobChunks:     newJobChunksTable(replicaPrefix),
+		stripes:       newStripesTable(replicaPrefix),
+		stripeJobs:    newStripeJobsTable(replicaPrefix),
 		counters: tables.NewCountersTable[*corepb.WaitGroupsCounter, corepb.WaitGroupsCounter](
 			utils.ConcatBytes(replicaPrefix, tablePrefixCounters),
 		),
@@ -60,6 +76,8 @@ func NewCore(badgerStore *store.BadgerStore, replicaPrefix []byte, shardLowerBou
 		),
 		expirationRecords: newExpirationRecordsTable(replicaPrefix),
 		deletionRecords:   newDeletionRecordsTable(replicaPrefix),
+
+		stripeDeletionRecords: newStripeDeletionRecordsTable(replicaPrefix),
 	}
 }
 
@@ -73,10 +91,16 @@ func (c *Core) snapshotSections() []tables.Section {
 	return []tables.Section{
 		{Name: "WaitGroups", Table: c.waitGroups},
 		{Name: "Jobs", Table: c.jobs},
+		{Name: "PendingJobs", Table: c.pendingJobs},
+		{Name: "RetractedJobs", Table: c.retractedJobs},
+		{Name: "JobChunks", Table: c.jobChunks},
 		{Name: "Counters", Table: c.counters},
 		{Name: "GarbageCollectionRecords", Table: c.gcRecords},
 		{Name: "ExpirationRecords", Table: c.expirationRecords},
 		{Name: "DeletionRecords", Table: c.deletionRecords},
+		{Name: "Stripes", Table: c.stripes},
+		{Name: "StripeJobs", Table: c.stripeJobs},
+		{Name: "StripeDeletionRecords", Table: c.stripeDeletionRecords},
 	}
 }
 
@@ -119,6 +143,8 @@ func (c *Core) GetWaitGroup(req *coreapis.GetWaitGroupRequest) (*coreapis.GetWai
 		return nil, err
 	}
 
+	decayCompletionRate(waitGroup, req.Now)
+
 	return &coreapis.GetWaitGroupResponse{
 		Payload: &corepb.GetWaitGroupResponse{
 			WaitGroup: waitGroup,
@@ -149,6 +175,8 @@ func (c *Core) GetWaitGroupByName(req *coreapis.GetWaitGroupByNameRequest) (*cor
 		return nil, err
 	}
 
+	decayCompletionRate(waitGroup, req.Now)
+
 	return &coreapis.GetWaitGroupByNameResponse{
 		Payload: &corepb.GetWaitGroupByNameResponse{
 			WaitGroup: waitGroup,
@@ -168,6 +196,10 @@ func (c *Core) ListWaitGroups(req *coreapis.ListWaitGroupsRequest) (*coreapis.Li
 		return nil, err
 	}
 
+	for _, waitGroup := range result.waitGroups {
+		decayCompletionRate(waitGroup, req.Now)
+	}
+
 	return &coreapis.ListWaitGroupsResponse{
 		Payload: &corepb.ListWaitGroupsResponse{
 			WaitGroups:              result.waitGroups,
@@ -179,7 +211,9 @@ func (c *Core) ListWaitGroups(req *coreapis.ListWaitGroupsRequest) (*coreapis.Li
 
 // ListWaitGroupCompletedJobs returns a page of completed jobs for the named wait
 // group. Returns a NotFound application error if the wait group does not
-// exist.
+// exist. The jobs of a wait group with integer job ids are decoded from its job
+// chunks, see listIntegerJobs. A striped wait group is returned as
+// StripedWaitGroup instead, its jobs live on its stripes.
 func (c *Core) ListWaitGroupCompletedJobs(req *coreapis.ListWaitGroupCompletedJobsRequest) (*coreapis.ListWaitGroupCompletedJobsResponse, error) {
 	txn := c.badgerStore.View()
 	defer txn.Discard()
@@ -200,7 +234,32 @@ func (c *Core) ListWaitGroupCompletedJobs(req *coreapis.ListWaitGroupCompletedJo
 		return nil, err
 	}
 
-	result, err := c.jobs.List(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, waitGroup.Id.WaitGroupId, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	if waitGroup.Stripes > 0 {
+		return &coreapis.ListWaitGroupCompletedJobsResponse{
+			Payload: &corepb.ListWaitGroupCompletedJobsResponse{
+				StripedWaitGroup: waitGroup,
+			},
+		}, nil
+	}
+
+	var result *listWaitGroupJobsResult
+	if waitGroup.IntegerJobIds {
+		// Pagination tokens of integer job ids carry a job id, see listIntegerJobs
+		if req.Payload.PaginationToken != nil && len(req.Payload.PaginationToken.Value) != 8 {
+			return &coreapis.ListWaitGroupCompletedJobsResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"invalid pagination token",
+					map[string]string{
+						"wait_group_name": req.Payload.WaitGroupName,
+					}),
+			}, nil
+		}
+
+		result, err = c.listIntegerJobs(txn, waitGroup, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	} else {
+		result, err = c.jobs.List(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, waitGroup.Id.WaitGroupId, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -214,14 +273,96 @@ func (c *Core) ListWaitGroupCompletedJobs(req *coreapis.ListWaitGroupCompletedJo
 	}, nil
 }
 
+// ListWaitGroupPendingJobs returns a page of declared jobs of the named wait
+// group that have not been completed yet, ordered by job id. Returns a
+// NotFound application error if the wait group does not exist. A wait group
+// without declared jobs has no pending jobs.
+func (c *Core) ListWaitGroupPendingJobs(req *coreapis.ListWaitGroupPendingJobsRequest) (*coreapis.ListWaitGroupPendingJobsResponse, error) {
+	txn := c.badgerStore.View()
+	defer txn.Discard()
+
+	waitGroup, err := c.waitGroups.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.WaitGroupName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.ListWaitGroupPendingJobsResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"wait group not found",
+					map[string]string{
+						"wait_group_name": req.Payload.WaitGroupName,
+					}),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	result, err := c.pendingJobs.List(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, waitGroup.Id.WaitGroupId, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.ListWaitGroupPendingJobsResponse{
+		Payload: &corepb.ListWaitGroupPendingJobsResponse{
+			Jobs:                    result.jobs,
+			NextPaginationToken:     result.nextPaginationToken,
+			PreviousPaginationToken: result.previousPaginationToken,
+		},
+	}, nil
+}
+
 // CreateWaitGroup creates a new wait group with the given counter and bumps
 // the per-namespace wait-group counter. Returns AlreadyExists if a wait group
-// with the same name already exists in the namespace, or ResourceExhausted
-// if creating it would exceed MaxNumberOfWaitGroupsPerNamespace.
+// with the same name already exists in the namespace, ResourceExhausted if
+// creating it would exceed MaxNumberOfWaitGroupsPerNamespace, InvalidRequest
+// if MaxFailedJobs is negative, declared jobs are combined with integer job
+// ids, or stripes are combined with declared jobs, integer job ids or
+// aggregations, or, when a parent wait group is given, NotFound if the parent
+// does not exist and InvalidRequest if it is not active, uses integer job ids
+// or is striped (child wait groups are reported to it by name). Every
+// aggregation spec starts an empty running aggregate on the wait group, and
+// every stripe starts with zero counts.
 func (c *Core) CreateWaitGroup(req *coreapis.CreateWaitGroupRequest) (*coreapis.CreateWaitGroupResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
 
+	if req.Payload.MaxFailedJobs != nil && *req.Payload.MaxFailedJobs < 0 {
+		return &coreapis.CreateWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"max failed jobs must not be negative",
+				map[string]string{
+					"wait_group_name": req.Payload.Name,
+					"max_failed_jobs": fmt.Sprintf("%d", *req.Payload.MaxFailedJobs),
+				}),
+		}, nil
+	}
+
+	// Integer job ids are implied by the counter, there is nothing to declare
+	if req.Payload.DeclaredJobs && req.Payload.IntegerJobIds {
+		return &coreapis.CreateWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"declared jobs cannot be combined with integer job ids",
+				map[string]string{
+					"wait_group_name": req.Payload.Name,
+				}),
+		}, nil
+	}
+
+	// Stripes only count jobs, they do not see the whole wait group
+	if req.Payload.Stripes > 0 && (req.Payload.DeclaredJobs || req.Payload.IntegerJobIds || len(req.Payload.Aggregations) > 0) {
+		return &coreapis.CreateWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"stripes cannot be combined with declared jobs, integer job ids or aggregations",
+				map[string]string{
+					"wait_group_name": req.Payload.Name,
+					"stripes":         fmt.Sprintf("%d", req.Payload.Stripes),
+				}),
+		}, nil
+	}
+
 	// Check name uniqueness
 	_, err := c.waitGroups.GetByName(txn, req.Payload.WaitGroupId.AccountId, req.Payload.WaitGroupId.NamespaceId, req.Payload.Name)
 	if err != nil {
@@ -239,6 +380,62 @@ func (c *Core) CreateWaitGroup(req *coreapis.CreateWaitGroupRequest) (*coreapis.
 		}, nil
 	}
 
+	// The parent must exist and still accept jobs
+	var parentWaitGroupId uint64
+	if req.Payload.ParentWaitGroupName != "" {
+		parent, err := c.waitGroups.GetByName(txn, req.Payload.WaitGroupId.AccountId, req.Payload.WaitGroupId.NamespaceId, req.Payload.ParentWaitGroupName)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return &coreapis.CreateWaitGroupResponse{
+					ApplicationError: mrpc.NewErrorWithContext(
+						mrpc.NotFound,
+						"parent wait group not found",
+						map[string]string{
+							"parent_wait_group_name": req.Payload.ParentWaitGroupName,
+						}),
+				}, nil
+			}
+
+			return nil, err
+		}
+
+		if parent.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+			return &coreapis.CreateWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"parent wait group is not active",
+					map[string]string{
+						"parent_wait_group_name": req.Payload.ParentWaitGroupName,
+						"status":                 parent.Status.String(),
+					}),
+			}, nil
+		}
+
+		if parent.IntegerJobIds {
+			return &coreapis.CreateWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"parent wait group uses integer job ids",
+					map[string]string{
+						"parent_wait_group_name": req.Payload.ParentWaitGroupName,
+					}),
+			}, nil
+		}
+
+		if parent.Stripes > 0 {
+			return &coreapis.CreateWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"parent wait group is striped",
+					map[string]string{
+						"parent_wait_group_name": req.Payload.ParentWaitGroupName,
+					}),
+			}, nil
+		}
+
+		parentWaitGroupId = parent.Id.WaitGroupId
+	}
+
 	// Get counters for that namespace
 	counters, err := c.counters.Get(txn, req.Payload.WaitGroupId.AccountId, req.Payload.WaitGroupId.NamespaceId)
 	if err != nil {
@@ -288,6 +485,15 @@ func (c *Core) CreateWaitGroup(req *coreapis.CreateWaitGroupRequest) (*coreapis.
 		Status:                     corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE,
 		DeleteAfterFinishedSeconds: req.Payload.DeleteAfterFinishedSeconds,
 		LastActivityAt:             req.Now,
+		MaxFailedJobs:              req.Payload.MaxFailedJobs,
+		DeclaredJobs:               req.Payload.DeclaredJobs,
+		ParentWaitGroupName:        req.Payload.ParentWaitGroupName,
+		ParentWaitGroupId:          parentWaitGroupId,
+		ChildFailurePolicy:         req.Payload.ChildFailurePolicy,
+		Aggregates:                 newAggregates(req.Payload.Aggregations),
+		IntegerJobIds:              req.Payload.IntegerJobIds,
+		Stripes:                    req.Payload.Stripes,
+		StripeCounts:               newStripeCounts(req.Payload.Stripes),
 	}
 
 	err = c.waitGroups.Create(txn, waitGroup)
@@ -320,13 +526,14 @@ func (c *Core) CreateWaitGroup(req *coreapis.CreateWaitGroupRequest) (*coreapis.
 }
 
 // UpdateWaitGroup updates the description, expiration time, counter, and metadata
-// of an existing wait group. The wait group's name, and completed count are
-// immutable and are left untouched. When ExpiresAt changes the global
-// expiration index is reconciled (the old entry is removed and a new one
+// of an existing wait group. The wait group's name, and completed and failed
+// counts are immutable and are left untouched. When ExpiresAt changes the
+// global expiration index is reconciled (the old entry is removed and a new one
 // added) so that garbage collection fires at the new time rather than the old
-// one. It is not allowed to shrink counter below the current number of completed
-// jobs. Returns NotFound if the wait group does not exist. Only active wait groups
-// can be updated.
+// one. It is not allowed to shrink counter below the current number of
+// completed and failed jobs, nor below the number of declared jobs. Returns
+// NotFound if the wait group does not exist. Only active wait groups can be
+// updated.
 func (c *Core) UpdateWaitGroup(req *coreapis.UpdateWaitGroupRequest) (*coreapis.UpdateWaitGroupResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
@@ -374,7 +581,7 @@ func (c *Core) UpdateWaitGroup(req *coreapis.UpdateWaitGroupRequest) (*coreapis.
 		}, nil
 	}
 
-	if waitGroup.CompletedJobs > req.Payload.Counter {
+	if finishedJobs(waitGroup) > req.Payload.Counter {
 		return &coreapis.UpdateWaitGroupResponse{
 			ApplicationError: mrpc.NewErrorWithContext(
 				mrpc.InvalidRequest,
@@ -382,12 +589,27 @@ func (c *Core) UpdateWaitGroup(req *coreapis.UpdateWaitGroupRequest) (*coreapis.
 				map[string]string{
 					"wait_group_name": req.Payload.WaitGroupName,
 					"completed_jobs":  fmt.Sprintf("%d", waitGroup.CompletedJobs),
+					"failed_jobs":     fmt.Sprintf("%d", waitGroup.FailedJobs),
 					"new_counter":     fmt.Sprintf("%d", req.Payload.Counter),
 				},
 			),
 		}, nil
 	}
 
+	if waitGroup.NumberOfDeclaredJobs > req.Payload.Counter {
+		return &coreapis.UpdateWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"there are currently more declared jobs than the new counter",
+				map[string]string{
+					"wait_group_name":         req.Payload.WaitGroupName,
+					"number_of_declared_jobs": fmt.Sprintf("%d", waitGroup.NumberOfDeclaredJobs),
+					"new_counter":             fmt.Sprintf("%d", req.Payload.Counter),
+				},
+			),
+		}, nil
+	}
+
 	// Reconcile the global expiration index when expires_at changes. Without
 	// this the index keeps pointing at the old timestamp and GC would fire at
 	// the wrong time. Only active wait groups reach this point (finished ones
@@ -411,9 +633,12 @@ func (c *Core) UpdateWaitGroup(req *coreapis.UpdateWaitGroupRequest) (*coreapis.
 	waitGroup.Counter = req.Payload.Counter
 	waitGroup.DeleteAfterFinishedSeconds = req.Payload.DeleteAfterFinishedSeconds
 
-	// Lowering the counter down to the number of completed jobs finishes the
-	// wait group.
-	if waitGroup.CompletedJobs == waitGroup.Counter {
+	// A new counter changes the number of remaining jobs
+	estimateCompletion(waitGroup)
+
+	// Lowering the counter down to the number of completed and failed jobs
+	// finishes the wait group.
+	if finishedJobs(waitGroup) == waitGroup.Counter {
 		err = c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, req.Now)
 		if err != nil {
 			return nil, err
@@ -437,62 +662,124 @@ func (c *Core) UpdateWaitGroup(req *coreapis.UpdateWaitGroupRequest) (*coreapis.
 	}, nil
 }
 
-// DeleteWaitGroup removes the named wait group and schedules its completed
-// jobs for asynchronous deletion via a GC record. Deleting a wait group that
-// does not exist is a no-op and returns success.
-func (c *Core) DeleteWaitGroup(req *coreapis.DeleteWaitGroupRequest) (*coreapis.DeleteWaitGroupResponse, error) {
+// AddToWaitGroup adjusts the counter of an active wait group by a signed delta,
+// the distributed sync.WaitGroup.Add. Unlike UpdateWaitGroup it does not need
+// an expected version, so concurrent producers can grow the same wait group
+// without retrying; it still bumps the version. Returns NotFound if the wait
+// group does not exist, or InvalidRequest if it is not active or the resulting
+// counter would be non-positive, above MaxWaitGroupSize, or below the number of
+// completed and failed jobs or of declared jobs. Lowering the counter down to
+// the number of completed and failed jobs finishes the wait group.
+func (c *Core) AddToWaitGroup(req *coreapis.AddToWaitGroupRequest) (*coreapis.AddToWaitGroupResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
 
 	waitGroup, err := c.waitGroups.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.WaitGroupName)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
-			// No wait group exists, do nothing
-			return &coreapis.DeleteWaitGroupResponse{
-				Payload: &corepb.DeleteWaitGroupResponse{},
+			return &coreapis.AddToWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"wait group not found",
+					map[string]string{
+						"wait_group_name": req.Payload.WaitGroupName,
+					}),
 			}, nil
 		}
 
 		return nil, err
 	}
 
-	// Get counters for this namespace
-	counters, err := c.counters.Get(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId)
-	if err != nil {
-		return nil, err
+	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+		return &coreapis.AddToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"only active wait groups can be updated",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+					"status":          waitGroup.Status.String(),
+				},
+			),
+		}, nil
 	}
 
-	err = c.waitGroups.Delete(txn, waitGroup.Id)
-	if err != nil {
-		return nil, err
+	counter := waitGroup.Counter + req.Payload.Delta
+
+	if counter <= 0 {
+		return &coreapis.AddToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"counter must stay greater than 0",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+					"counter":         fmt.Sprintf("%d", waitGroup.Counter),
+					"delta":           fmt.Sprintf("%d", req.Payload.Delta),
+				},
+			),
+		}, nil
 	}
 
-	// Remove any pending expiration / deletion index entries for this wait
-	// group. These are no-ops if the corresponding record does not exist (e.g.
-	// an active wait group has no deletion record yet).
-	err = c.expirationRecords.Delete(txn, waitGroup.ExpiresAt, waitGroup.Id)
-	if err != nil {
-		return nil, err
+	if counter > req.Payload.MaxWaitGroupSize {
+		return &coreapis.AddToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"wait group size is too big",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+					"counter":         fmt.Sprintf("%d", waitGroup.Counter),
+					"delta":           fmt.Sprintf("%d", req.Payload.Delta),
+					"limit":           fmt.Sprintf("%d", req.Payload.MaxWaitGroupSize),
+				},
+			),
+		}, nil
 	}
-	err = c.deletionRecords.Delete(txn, deletionTime(waitGroup.FinishedAt, waitGroup.DeleteAfterFinishedSeconds), waitGroup.Id)
-	if err != nil {
-		return nil, err
+
+	if finishedJobs(waitGroup) > counter {
+		return &coreapis.AddToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"there are currently more completed jobs than the new counter",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+					"completed_jobs":  fmt.Sprintf("%d", waitGroup.CompletedJobs),
+					"failed_jobs":     fmt.Sprintf("%d", waitGroup.FailedJobs),
+					"new_counter":     fmt.Sprintf("%d", counter),
+				},
+			),
+		}, nil
 	}
 
-	// Mark the wait group's jobs for deletion
-	err = c.gcRecords.Create(txn, &corepb.WaitGroupsGarbageCollectionRecord{
-		Id: req.Payload.RecordId,
-		Record: &corepb.WaitGroupsGarbageCollectionRecord_WaitGroupId{
-			WaitGroupId: waitGroup.Id,
-		},
-	})
-	if err != nil {
-		return nil, err
+	if waitGroup.NumberOfDeclaredJobs > counter {
+		return &coreapis.AddToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"there are currently more declared jobs than the new counter",
+				map[string]string{
+					"wait_group_name":         req.Payload.WaitGroupName,
+					"number_of_declared_jobs": fmt.Sprintf("%d", waitGroup.NumberOfDeclaredJobs),
+					"new_counter":             fmt.Sprintf("%d", counter),
+				},
+			),
+		}, nil
 	}
 
-	// Update counters
-	counters.NumberOfWaitGroups -= 1
-	err = c.counters.Set(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, counters)
+	waitGroup.Counter = counter
+	waitGroup.UpdatedAt = req.Now
+	waitGroup.Version += 1
+
+	// A new counter changes the number of remaining jobs
+	estimateCompletion(waitGroup)
+
+	// Lowering the counter down to the number of completed and failed jobs
+	// finishes the wait group.
+	if finishedJobs(waitGroup) == waitGroup.Counter {
+		err = c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, req.Now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.waitGroups.Update(txn, waitGroup)
 	if err != nil {
 		return nil, err
 	}
@@ -502,25 +789,27 @@ func (c *Core) DeleteWaitGroup(req *coreapis.DeleteWaitGroupRequest) (*coreapis.
 		return nil, err
 	}
 
-	return &coreapis.DeleteWaitGroupResponse{
-		Payload: &corepb.DeleteWaitGroupResponse{},
+	return &coreapis.AddToWaitGroupResponse{
+		Payload: &corepb.AddToWaitGroupResponse{
+			WaitGroup: waitGroup,
+		},
 	}, nil
 }
 
-// CompleteJobsFromWaitGroup marks each given job id as completed in the
-// named wait group, incrementing CompletedJobs once per previously
-// unseen job id (re-completing an already-completed job is a no-op).
-// Returns NotFound if the wait group does not exist, or InvalidArgument if
-// the call would push CompletedJobs above Counter — in the latter case the
-// transaction is discarded and no jobs are persisted.
-func (c *Core) CompleteJobsFromWaitGroup(req *coreapis.CompleteJobsFromWaitGroupRequest) (*coreapis.CompleteJobsFromWaitGroupResponse, error) {
+// CancelWaitGroup moves an active wait group to the terminal CANCELLED status
+// with the given reason, so that waiters get a meaningful outcome instead of
+// NotFound. Like any finished wait group it is retained for
+// DeleteAfterFinishedSeconds and reported to its parent, if any. Cancelling an
+// already cancelled wait group is a no-op. Returns NotFound if the wait group
+// does not exist, or InvalidRequest if it has already finished otherwise.
+func (c *Core) CancelWaitGroup(req *coreapis.CancelWaitGroupRequest) (*coreapis.CancelWaitGroupResponse, error) {
 	txn := c.badgerStore.Update()
 	defer txn.Discard()
 
 	waitGroup, err := c.waitGroups.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.WaitGroupName)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
-			return &coreapis.CompleteJobsFromWaitGroupResponse{
+			return &coreapis.CancelWaitGroupResponse{
 				ApplicationError: mrpc.NewErrorWithContext(
 					mrpc.NotFound,
 					"wait group not found",
@@ -533,11 +822,20 @@ func (c *Core) CompleteJobsFromWaitGroup(req *coreapis.CompleteJobsFromWaitGroup
 		return nil, err
 	}
 
+	// Already cancelled, do nothing
+	if waitGroup.Status == corepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED {
+		return &coreapis.CancelWaitGroupResponse{
+			Payload: &corepb.CancelWaitGroupResponse{
+				WaitGroup: waitGroup,
+			},
+		}, nil
+	}
+
 	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
-		return &coreapis.CompleteJobsFromWaitGroupResponse{
+		return &coreapis.CancelWaitGroupResponse{
 			ApplicationError: mrpc.NewErrorWithContext(
 				mrpc.InvalidRequest,
-				"only active wait groups can accept jobs",
+				"only active wait groups can be cancelled",
 				map[string]string{
 					"wait_group_name": req.Payload.WaitGroupName,
 					"status":          waitGroup.Status.String(),
@@ -546,194 +844,1630 @@ func (c *Core) CompleteJobsFromWaitGroup(req *coreapis.CompleteJobsFromWaitGroup
 		}, nil
 	}
 
-	for _, job := range req.Payload.Jobs {
-		waitGroupJobId := &corepb.WaitGroupJobId{
-			AccountId:   req.Payload.NamespaceId.AccountId,
-			NamespaceId: req.Payload.NamespaceId.NamespaceId,
-			WaitGroupId: waitGroup.Id.WaitGroupId,
-			JobId:       job.JobId,
-		}
-		_, err := c.jobs.Get(txn, waitGroupJobId)
-		if err != nil {
-			if errors.Is(err, store.ErrNotFound) {
-				waitGroupJob := &corepb.WaitGroupJob{
-					Id:          waitGroupJobId,
-					CompletedAt: req.Now,
-					Metadata:    job.Metadata,
-				}
-				err := c.jobs.Create(txn, waitGroupJob)
-				if err != nil {
-					return nil, err
-				}
+	waitGroup.CancellationReason = req.Payload.Reason
+	waitGroup.UpdatedAt = req.Now
+	waitGroup.Version += 1
 
-				// Increment counter only if we haven't seen this job_id before
-				waitGroup.CompletedJobs++
-			} else {
-				return nil, err
-			}
-		}
+	err = c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED, req.Now)
+	if err != nil {
+		return nil, err
 	}
 
-	// Reject if completing these jobs would overflow the wait group counter.
-	if waitGroup.CompletedJobs > waitGroup.Counter {
-		return &coreapis.CompleteJobsFromWaitGroupResponse{
-			ApplicationError: mrpc.NewErrorWithContext(
-				mrpc.InvalidRequest,
-				"too many jobs to be marked completed",
-				map[string]string{
-					"wait_group_name": req.Payload.WaitGroupName,
-					"counter":         fmt.Sprintf("%d", waitGroup.Counter),
-					"completed_jobs":  fmt.Sprintf("%d", waitGroup.CompletedJobs),
-				}),
-		}, nil
+	err = c.waitGroups.Update(txn, waitGroup)
+	if err != nil {
+		return nil, err
 	}
 
-	waitGroup.LastActivityAt = req.Now
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
 
-	// When all jobs are completed the wait group becomes finished. Mark it as
-	// completed and schedule its deletion after delete_after_finished_seconds.
-	if waitGroup.CompletedJobs == waitGroup.Counter {
-		err = c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, req.Now)
+	return &coreapis.CancelWaitGroupResponse{
+		Payload: &corepb.CancelWaitGroupResponse{
+			WaitGroup: waitGroup,
+		},
+	}, nil
+}
+
+// DeleteWaitGroup removes the named wait group and schedules its completed
+// jobs for asynchronous deletion via a GC record. Deleting a wait group that
+// does not exist is a no-op and returns success. The deleted wait group is
+// returned so that the caller can delete its stripes.
+func (c *Core) DeleteWaitGroup(req *coreapis.DeleteWaitGroupRequest) (*coreapis.DeleteWaitGroupResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	waitGroup, err := c.waitGroups.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.WaitGroupName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			// No wait group exists, do nothing
+			return &coreapis.DeleteWaitGroupResponse{
+				Payload: &corepb.DeleteWaitGroupResponse{},
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	// Get counters for this namespace
+	counters, err := c.counters.Get(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.waitGroups.Delete(txn, waitGroup.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove any pending expiration / deletion index entries for this wait
+	// group. These are no-ops if the corresponding record does not exist (e.g.
+	// an active wait group has no deletion record yet).
+	err = c.expirationRecords.Delete(txn, waitGroup.ExpiresAt, waitGroup.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = c.deletionRecords.Delete(txn, deletionTime(waitGroup.FinishedAt, waitGroup.DeleteAfterFinishedSeconds), waitGroup.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mark the wait group's jobs for deletion
+	err = c.gcRecords.Create(txn, &corepb.WaitGroupsGarbageCollectionRecord{
+		Id: req.Payload.RecordId,
+		Record: &corepb.WaitGroupsGarbageCollectionRecord_WaitGroupId{
+			WaitGroupId: waitGroup.Id,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Update counters
+	counters.NumberOfWaitGroups -= 1
+	err = c.counters.Set(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.DeleteWaitGroupResponse{
+		Payload: &corepb.DeleteWaitGroupResponse{
+			WaitGroup: waitGroup,
+		},
+	}, nil
+}
+
+// CompleteJobsFromWaitGroup marks each given job id as completed in the
+// named wait group, incrementing CompletedJobs (or FailedJobs, for a job
+// reported as failed) once per previously unseen job id (re-completing an
+// already-completed job is a no-op, even with a different outcome). Once
+// FailedJobs exceeds MaxFailedJobs the wait group becomes FAILED; otherwise it
+// becomes COMPLETED when CompletedJobs + FailedJobs reaches Counter. Returns
+// NotFound if the wait group does not exist, or InvalidArgument if the call
+// would push CompletedJobs + FailedJobs above Counter, for a wait group with
+// declared jobs, if a job id was never declared or, for a wait group with
+// integer job ids, if a job id is not an integer below Counter — in the latter
+// cases the transaction is discarded and no jobs are persisted. Completing a
+// declared job removes it from the pending index, and every newly recorded job
+// is folded into the running aggregates. A wait group with a parent reports
+// itself to the parent once it finishes, see reportToParent. A striped wait
+// group is returned untouched; its jobs are completed with
+// CompleteJobsFromWaitGroupStripe instead.
+func (c *Core) CompleteJobsFromWaitGroup(req *coreapis.CompleteJobsFromWaitGroupRequest) (*coreapis.CompleteJobsFromWaitGroupResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	waitGroup, err := c.waitGroups.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.WaitGroupName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.CompleteJobsFromWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"wait group not found",
+					map[string]string{
+						"wait_group_name": req.Payload.WaitGroupName,
+					}),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	if waitGroup.Stripes > 0 {
+		return &coreapis.CompleteJobsFromWaitGroupResponse{
+			Payload: &corepb.CompleteJobsFromWaitGroupResponse{
+				WaitGroup: waitGroup,
+			},
+		}, nil
+	}
+
+	applicationError, err := c.completeJobs(txn, waitGroup, req.Payload.Jobs, req.Now)
+	if err != nil {
+		return nil, err
+	}
+	if applicationError != nil {
+		return &coreapis.CompleteJobsFromWaitGroupResponse{
+			ApplicationError: applicationError,
+		}, nil
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.CompleteJobsFromWaitGroupResponse{
+		Payload: &corepb.CompleteJobsFromWaitGroupResponse{
+			WaitGroup: waitGroup,
+		},
+	}, nil
+}
+
+// UncompleteJobsFromWaitGroup retracts the completion of each given job id in
+// the named wait group, deleting its job record and decrementing CompletedJobs
+// (or FailedJobs, for a job reported as failed). Job ids that are not completed
+// are skipped. The retracted completion is appended to the job's history, which
+// the job record carries once the job is completed again; a retracted declared
+// job is pending again. Returns NotFound if the wait group does not exist, or
+// InvalidRequest if it is striped or not active. A COMPLETED wait group can be reopened
+// (made ACTIVE again, bumping its Version) with Reopen, as long as it has no
+// parent, has not expired yet, is still within its DeleteAfterFinishedSeconds
+// retention period and at least one of the jobs is completed.
+func (c *Core) UncompleteJobsFromWaitGroup(req *coreapis.UncompleteJobsFromWaitGroupRequest) (*coreapis.UncompleteJobsFromWaitGroupResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	waitGroup, err := c.waitGroups.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.WaitGroupName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.UncompleteJobsFromWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"wait group not found",
+					map[string]string{
+						"wait_group_name": req.Payload.WaitGroupName,
+					}),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	// Jobs of a striped wait group are recorded on its stripes, out of reach
+	// of this core
+	if waitGroup.Stripes > 0 {
+		return &coreapis.UncompleteJobsFromWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"jobs of a striped wait group cannot be uncompleted",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+				},
+			),
+		}, nil
+	}
+
+	reopen := waitGroup.Status == corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED && req.Payload.Reopen
+	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE && !reopen {
+		return &coreapis.UncompleteJobsFromWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"only active wait groups can have jobs uncompleted, completed ones must be reopened",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+					"status":          waitGroup.Status.String(),
+				},
+			),
+		}, nil
+	}
+
+	if reopen {
+		// The parent has already counted this wait group as a finished job
+		if waitGroup.ParentWaitGroupName != "" {
+			return &coreapis.UncompleteJobsFromWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"wait groups with a parent cannot be reopened",
+					map[string]string{
+						"wait_group_name":        req.Payload.WaitGroupName,
+						"parent_wait_group_name": waitGroup.ParentWaitGroupName,
+					},
+				),
+			}, nil
+		}
+
+		if waitGroup.ExpiresAt <= req.Now {
+			return &coreapis.UncompleteJobsFromWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"wait group has already expired",
+					map[string]string{
+						"wait_group_name": req.Payload.WaitGroupName,
+						"expires_at":      fmt.Sprintf("%d", waitGroup.ExpiresAt),
+					},
+				),
+			}, nil
+		}
+
+		// The wait group may still exist after its retention period only
+		// because garbage collection has not caught up yet
+		if deletionTime(waitGroup.FinishedAt, waitGroup.DeleteAfterFinishedSeconds) <= req.Now {
+			return &coreapis.UncompleteJobsFromWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"wait group retention period is over",
+					map[string]string{
+						"wait_group_name":               req.Payload.WaitGroupName,
+						"finished_at":                   fmt.Sprintf("%d", waitGroup.FinishedAt),
+						"delete_after_finished_seconds": fmt.Sprintf("%d", waitGroup.DeleteAfterFinishedSeconds),
+					},
+				),
+			}, nil
+		}
+	}
+
+	var chunks *jobChunksBatch
+	if waitGroup.IntegerJobIds {
+		chunks = newJobChunksBatch(c.jobChunks, waitGroup.Id)
+	}
+
+	retracted := 0
+	seen := make(map[string]bool, len(req.Payload.JobIds))
+	for _, jobId := range req.Payload.JobIds {
+		if seen[jobId] {
+			continue
+		}
+		seen[jobId] = true
+
+		waitGroupJobId := &corepb.WaitGroupJobId{
+			AccountId:   waitGroup.Id.AccountId,
+			NamespaceId: waitGroup.Id.NamespaceId,
+			WaitGroupId: waitGroup.Id.WaitGroupId,
+			JobId:       jobId,
+		}
+
+		// Uncompleting a job that is not completed is a no-op
+		job, err := c.getCompletedJob(txn, waitGroup, chunks, waitGroupJobId)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil {
+			continue
+		}
+
+		if waitGroup.IntegerJobIds {
+			id, _ := parseIntegerJobId(jobId)
+			err = chunks.remove(txn, id)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = c.jobs.Delete(txn, waitGroupJobId)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keep the retracted completion until the job is completed again,
+		// dropping the oldest attempts beyond the history bound
+		history := append(job.History, &corepb.WaitGroupJobAttempt{
+			CompletedAt: job.CompletedAt,
+			Metadata:    job.Metadata,
+			Failed:      job.Failed,
+			RetractedAt: req.Now,
+		})
+		if maxLength := req.Payload.MaxJobHistoryLength; maxLength > 0 && int64(len(history)) > maxLength {
+			history = history[int64(len(history))-maxLength:]
+		}
+
+		err = c.retractedJobs.Create(txn, &corepb.WaitGroupJob{
+			Id:      waitGroupJobId,
+			History: history,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// A retracted declared job is pending again
+		if waitGroup.DeclaredJobs {
+			err = c.pendingJobs.Create(txn, &corepb.WaitGroupJob{
+				Id: waitGroupJobId,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if job.Failed {
+			waitGroup.FailedJobs--
+		} else {
+			waitGroup.CompletedJobs--
+			unaggregateJob(waitGroup, job.Metadata)
+		}
+		retracted++
+	}
+
+	// A completed wait group is only reopened to take back jobs
+	if retracted == 0 && reopen {
+		return &coreapis.UncompleteJobsFromWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"none of the jobs is completed, the wait group is not reopened",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+				},
+			),
+		}, nil
+	}
+
+	// Nothing was retracted, the wait group stays as it is
+	if retracted == 0 {
+		return &coreapis.UncompleteJobsFromWaitGroupResponse{
+			Payload: &corepb.UncompleteJobsFromWaitGroupResponse{
+				WaitGroup: waitGroup,
+			},
+		}, nil
+	}
+
+	if chunks != nil {
+		err = chunks.flush(txn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	waitGroup.LastActivityAt = req.Now
+
+	if reopen {
+		// Cancel the scheduled deletion and make the wait group expire again
+		err = c.deletionRecords.Delete(txn, deletionTime(waitGroup.FinishedAt, waitGroup.DeleteAfterFinishedSeconds), waitGroup.Id)
+		if err != nil {
+			return nil, err
+		}
+		err = c.expirationRecords.Add(txn, waitGroup.ExpiresAt, waitGroup.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		waitGroup.Status = corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE
+		waitGroup.FinishedAt = 0
+		waitGroup.UpdatedAt = req.Now
+		waitGroup.Version += 1
+	}
+
+	// Retracted jobs are remaining again
+	estimateCompletion(waitGroup)
+
+	err = c.waitGroups.Update(txn, waitGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.UncompleteJobsFromWaitGroupResponse{
+		Payload: &corepb.UncompleteJobsFromWaitGroupResponse{
+			WaitGroup: waitGroup,
+		},
+	}, nil
+}
+
+// AddJobsToWaitGroup declares a batch of job ids for the named wait group,
+// adding every previously unseen id to the pending index and incrementing
+// NumberOfDeclaredJobs (declaring an already declared or completed job is a
+// no-op). Returns NotFound if the wait group does not exist, or InvalidArgument
+// if the wait group is not active, was not created with declared jobs, or the
+// call would push NumberOfDeclaredJobs above Counter — in the latter case the
+// transaction is discarded and no jobs are persisted.
+func (c *Core) AddJobsToWaitGroup(req *coreapis.AddJobsToWaitGroupRequest) (*coreapis.AddJobsToWaitGroupResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	waitGroup, err := c.waitGroups.GetByName(txn, req.Payload.NamespaceId.AccountId, req.Payload.NamespaceId.NamespaceId, req.Payload.WaitGroupName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.AddJobsToWaitGroupResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"wait group not found",
+					map[string]string{
+						"wait_group_name": req.Payload.WaitGroupName,
+					}),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	if !waitGroup.DeclaredJobs {
+		return &coreapis.AddJobsToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"wait group was not created with declared jobs",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+				},
+			),
+		}, nil
+	}
+
+	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+		return &coreapis.AddJobsToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"only active wait groups can accept jobs",
+				map[string]string{
+					"wait_group_name": req.Payload.WaitGroupName,
+					"status":          waitGroup.Status.String(),
+				},
+			),
+		}, nil
+	}
+
+	for _, jobId := range req.Payload.JobIds {
+		waitGroupJobId := &corepb.WaitGroupJobId{
+			AccountId:   req.Payload.NamespaceId.AccountId,
+			NamespaceId: req.Payload.NamespaceId.NamespaceId,
+			WaitGroupId: waitGroup.Id.WaitGroupId,
+			JobId:       jobId,
+		}
+
+		// Skip jobs that are already declared, either still pending or already
+		// completed.
+		declared, err := c.isJobDeclared(txn, waitGroupJobId)
+		if err != nil {
+			return nil, err
+		}
+		if declared {
+			continue
+		}
+
+		err = c.pendingJobs.Create(txn, &corepb.WaitGroupJob{
+			Id: waitGroupJobId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		waitGroup.NumberOfDeclaredJobs++
+	}
+
+	// Reject if declaring these jobs would overflow the wait group counter.
+	if waitGroup.NumberOfDeclaredJobs > waitGroup.Counter {
+		return &coreapis.AddJobsToWaitGroupResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"too many jobs to be declared",
+				map[string]string{
+					"wait_group_name":         req.Payload.WaitGroupName,
+					"counter":                 fmt.Sprintf("%d", waitGroup.Counter),
+					"number_of_declared_jobs": fmt.Sprintf("%d", waitGroup.NumberOfDeclaredJobs),
+				}),
+		}, nil
+	}
+
+	err = c.waitGroups.Update(txn, waitGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.AddJobsToWaitGroupResponse{
+		Payload: &corepb.AddJobsToWaitGroupResponse{
+			WaitGroup: waitGroup,
+		},
+	}, nil
+}
+
+// CompleteJobsFromWaitGroupStripe records each given job id as completed on a
+// stripe of a striped wait group, incrementing the stripe's CompletedJobs (or
+// FailedJobs, for a job reported as failed) once per previously unseen job id;
+// re-completing a job already recorded on the stripe is a no-op. The stripe is
+// created on first use, and its deletion is scheduled no earlier than
+// DeleteAt. The stripe knows nothing about its wait group: the caller routes
+// every job to its stripe (see sharding.WaitGroupStripe), checks the wait group
+// is active, passes the room left in it as MaxFinishedJobs, and reports the
+// returned counts with AggregateWaitGroupStripes. Returns InvalidRequest, and
+// records nothing, if the stripe would then hold more than MaxFinishedJobs
+// jobs.
+func (c *Core) CompleteJobsFromWaitGroupStripe(req *coreapis.CompleteJobsFromWaitGroupStripeRequest) (*coreapis.CompleteJobsFromWaitGroupStripeResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	stripe, err := c.stripes.Get(txn, req.Payload.StripeId)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+		stripe = &corepb.WaitGroupStripe{
+			Id:        req.Payload.StripeId,
+			CreatedAt: req.Now,
+		}
+	}
+
+	err = c.extendStripe(txn, stripe, req.Payload.DeleteAt)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.Payload.Jobs))
+	for _, job := range req.Payload.Jobs {
+		if seen[job.JobId] {
+			continue
+		}
+		seen[job.JobId] = true
+
+		waitGroupJobId := &corepb.WaitGroupJobId{
+			AccountId:   stripe.Id.AccountId,
+			NamespaceId: stripe.Id.NamespaceId,
+			WaitGroupId: stripe.Id.WaitGroupId,
+			JobId:       job.JobId,
+			Stripe:      stripe.Id.Stripe,
+		}
+
+		// Re-completing an already completed job is a no-op
+		_, err := c.stripeJobs.Get(txn, waitGroupJobId)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+
+		err = c.stripeJobs.Create(txn, &corepb.WaitGroupJob{
+			Id:          waitGroupJobId,
+			CompletedAt: req.Now,
+			Metadata:    job.Metadata,
+			Failed:      job.Failed,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if job.Failed {
+			stripe.FailedJobs++
+		} else {
+			stripe.CompletedJobs++
+		}
+	}
+
+	// Reject if completing these jobs would overflow the wait group counter.
+	if stripe.CompletedJobs+stripe.FailedJobs > req.Payload.MaxFinishedJobs {
+		return &coreapis.CompleteJobsFromWaitGroupStripeResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"too many jobs to be marked completed",
+				map[string]string{
+					"stripe":            fmt.Sprintf("%d", stripe.Id.Stripe),
+					"max_finished_jobs": fmt.Sprintf("%d", req.Payload.MaxFinishedJobs),
+					"completed_jobs":    fmt.Sprintf("%d", stripe.CompletedJobs),
+					"failed_jobs":       fmt.Sprintf("%d", stripe.FailedJobs),
+				}),
+		}, nil
+	}
+
+	stripe.UpdatedAt = req.Now
+
+	err = c.stripes.Set(txn, stripe)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.CompleteJobsFromWaitGroupStripeResponse{
+		Payload: &corepb.CompleteJobsFromWaitGroupStripeResponse{
+			Stripe: stripe,
+		},
+	}, nil
+}
+
+// AggregateWaitGroupStripes folds the reported stripe counts into a striped
+// wait group and recomputes its CompletedJobs and FailedJobs as their sums.
+// Stripe counts never decrease, so a report older than the one already folded
+// in is ignored and reports can be applied in any order and more than once.
+// An active wait group then becomes FAILED once FailedJobs exceeds
+// MaxFailedJobs, and otherwise COMPLETED once CompletedJobs + FailedJobs
+// reaches Counter. Returns NotFound if the wait group does not exist, or
+// InvalidRequest if it is not striped, a stripe is out of range, or the
+// stripes together hold more jobs than Counter.
+func (c *Core) AggregateWaitGroupStripes(req *coreapis.AggregateWaitGroupStripesRequest) (*coreapis.AggregateWaitGroupStripesResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	waitGroup, err := c.waitGroups.Get(txn, req.Payload.WaitGroupId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.AggregateWaitGroupStripesResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"wait group not found",
+					map[string]string{
+						"wait_group_id": ids.EncodeWaitGroupId(req.Payload.WaitGroupId),
+					}),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	if waitGroup.Stripes == 0 {
+		return &coreapis.AggregateWaitGroupStripesResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"wait group is not striped",
+				map[string]string{
+					"wait_group_name": waitGroup.Name,
+				}),
+		}, nil
+	}
+
+	for _, counts := range req.Payload.StripeCounts {
+		if counts.Stripe >= waitGroup.Stripes {
+			return &coreapis.AggregateWaitGroupStripesResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"stripe is out of range",
+					map[string]string{
+						"wait_group_name": waitGroup.Name,
+						"stripe":          fmt.Sprintf("%d", counts.Stripe),
+						"stripes":         fmt.Sprintf("%d", waitGroup.Stripes),
+					}),
+			}, nil
+		}
+
+		current := waitGroup.StripeCounts[counts.Stripe]
+		current.CompletedJobs = max(current.CompletedJobs, counts.CompletedJobs)
+		current.FailedJobs = max(current.FailedJobs, counts.FailedJobs)
+	}
+
+	previouslyFinishedJobs := finishedJobs(waitGroup)
+	sumStripeCounts(waitGroup)
+
+	// Every stripe is bounded by the room left in the wait group when it
+	// records jobs, so the sums can only overflow Counter if stripes raced
+	// each other.
+	if finishedJobs(waitGroup) > waitGroup.Counter {
+		return &coreapis.AggregateWaitGroupStripesResponse{
+			ApplicationError: mrpc.NewErrorWithContext(
+				mrpc.InvalidRequest,
+				"too many jobs to be marked completed",
+				map[string]string{
+					"wait_group_name": waitGroup.Name,
+					"counter":         fmt.Sprintf("%d", waitGroup.Counter),
+					"completed_jobs":  fmt.Sprintf("%d", waitGroup.CompletedJobs),
+					"failed_jobs":     fmt.Sprintf("%d", waitGroup.FailedJobs),
+				}),
+		}, nil
+	}
+
+	// Same transitions as completeJobs
+	if waitGroup.Status == corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+		waitGroup.LastActivityAt = req.Now
+		updateCompletionRate(waitGroup, finishedJobs(waitGroup)-previouslyFinishedJobs, req.Now)
+
+		if waitGroup.MaxFailedJobs != nil && waitGroup.FailedJobs > *waitGroup.MaxFailedJobs {
+			err := c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, req.Now)
+			if err != nil {
+				return nil, err
+			}
+		} else if finishedJobs(waitGroup) == waitGroup.Counter {
+			err := c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, req.Now)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = c.waitGroups.Update(txn, waitGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.AggregateWaitGroupStripesResponse{
+		Payload: &corepb.AggregateWaitGroupStripesResponse{
+			WaitGroup: waitGroup,
+		},
+	}, nil
+}
+
+// GetWaitGroupStripe looks up a stripe of a striped wait group. Returns a
+// NotFound application error if no job has been completed on the stripe yet,
+// or if it has already been deleted.
+func (c *Core) GetWaitGroupStripe(req *coreapis.GetWaitGroupStripeRequest) (*coreapis.GetWaitGroupStripeResponse, error) {
+	txn := c.badgerStore.View()
+	defer txn.Discard()
+
+	stripe, err := c.stripes.Get(txn, req.Payload.StripeId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.GetWaitGroupStripeResponse{
+				ApplicationError: mrpc.NewErrorWithContext(
+					mrpc.NotFound,
+					"wait group stripe not found",
+					map[string]string{
+						"wait_group_id": ids.EncodeWaitGroupId(waitGroupIdOfStripe(req.Payload.StripeId)),
+						"stripe":        fmt.Sprintf("%d", req.Payload.StripeId.Stripe),
+					}),
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	return &coreapis.GetWaitGroupStripeResponse{
+		Payload: &corepb.GetWaitGroupStripeResponse{
+			Stripe: stripe,
+		},
+	}, nil
+}
+
+// ListWaitGroupStripeCompletedJobs returns a page of the jobs completed on a
+// stripe of a striped wait group, ordered by job id. A stripe without any jobs
+// yields an empty page.
+func (c *Core) ListWaitGroupStripeCompletedJobs(req *coreapis.ListWaitGroupStripeCompletedJobsRequest) (*coreapis.ListWaitGroupStripeCompletedJobsResponse, error) {
+	txn := c.badgerStore.View()
+	defer txn.Discard()
+
+	result, err := c.stripeJobs.List(txn, req.Payload.StripeId, req.Payload.PaginationToken, pagination.GetLimitWithDefaults(int(req.Payload.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.ListWaitGroupStripeCompletedJobsResponse{
+		Payload: &corepb.ListWaitGroupStripeCompletedJobsResponse{
+			Jobs:                    result.jobs,
+			NextPaginationToken:     result.nextPaginationToken,
+			PreviousPaginationToken: result.previousPaginationToken,
+		},
+	}, nil
+}
+
+// ExtendWaitGroupStripe postpones the deletion of a stripe to DeleteAt, for a
+// wait group whose expiration or retention was extended. The deletion is never
+// brought forward, and a stripe that does not exist is left alone (it is
+// scheduled when its first job is completed).
+func (c *Core) ExtendWaitGroupStripe(req *coreapis.ExtendWaitGroupStripeRequest) (*coreapis.ExtendWaitGroupStripeResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	stripe, err := c.stripes.Get(txn, req.Payload.StripeId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.ExtendWaitGroupStripeResponse{
+				Payload: &corepb.ExtendWaitGroupStripeResponse{},
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	if stripe.DeleteAt < req.Payload.DeleteAt {
+		err = c.extendStripe(txn, stripe, req.Payload.DeleteAt)
+		if err != nil {
+			return nil, err
+		}
+		stripe.UpdatedAt = req.Now
+
+		err = c.stripes.Set(txn, stripe)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.ExtendWaitGroupStripeResponse{
+		Payload: &corepb.ExtendWaitGroupStripeResponse{},
+	}, nil
+}
+
+// DeleteWaitGroupStripe schedules a stripe and its jobs for deletion on the
+// next garbage collection tick, for a wait group that was deleted. Deleting a
+// stripe that does not exist is a no-op.
+func (c *Core) DeleteWaitGroupStripe(req *coreapis.DeleteWaitGroupStripeRequest) (*coreapis.DeleteWaitGroupStripeResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	stripe, err := c.stripes.Get(txn, req.Payload.StripeId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &coreapis.DeleteWaitGroupStripeResponse{
+				Payload: &corepb.DeleteWaitGroupStripeResponse{},
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	if stripe.DeleteAt > req.Now {
+		err = c.stripeDeletionRecords.Delete(txn, stripe.DeleteAt, stripe.Id)
+		if err != nil {
+			return nil, err
+		}
+		err = c.stripeDeletionRecords.Add(txn, req.Now, stripe.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		stripe.DeleteAt = req.Now
+		stripe.UpdatedAt = req.Now
+
+		err = c.stripes.Set(txn, stripe)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.DeleteWaitGroupStripeResponse{
+		Payload: &corepb.DeleteWaitGroupStripeResponse{},
+	}, nil
+}
+
+// RunWaitGroupsGarbageCollection processes one page of pending GC records,
+// deleting the wait groups and jobs they reference. Each record is bounded
+// by req.MaxDeletedObjects across the whole call; records that fully drain
+// within their budget are themselves removed, otherwise they remain and are
+// resumed on the next GC tick.
+//
+// On every tick it also: (1) marks active wait groups whose expires_at has
+// passed as expired and schedules their deletion, and (2) deletes finished
+// wait groups (completed, failed or expired) whose scheduled deletion time has
+// passed, and (3) deletes wait group stripes whose scheduled deletion time has
+// passed.
+func (c *Core) RunWaitGroupsGarbageCollection(req *coreapis.RunWaitGroupsGarbageCollectionRequest) (*coreapis.RunWaitGroupsGarbageCollectionResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	totalDeletedObjects := 0
+
+	// Mark expired wait groups (expires_at <= now) and schedule their deletion.
+	err := c.expireWaitGroups(txn, req.Now, int(req.Payload.GcRecordsPageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete finished wait groups whose retention period has elapsed.
+	deletedFinished, err := c.deleteFinishedWaitGroups(txn, req.Now, int(req.Payload.GcRecordsPageSize), int(req.Payload.MaxDeletedObjects)-totalDeletedObjects)
+	if err != nil {
+		return nil, err
+	}
+	totalDeletedObjects = totalDeletedObjects + deletedFinished
+
+	// Delete wait group stripes whose retention period has elapsed.
+	deletedStripes, err := c.deleteExpiredStripes(txn, req.Now, int(req.Payload.GcRecordsPageSize), int(req.Payload.MaxDeletedObjects)-totalDeletedObjects)
+	if err != nil {
+		return nil, err
+	}
+	totalDeletedObjects = totalDeletedObjects + deletedStripes
+
+	// List one page of GC records
+	gcRecords, err := c.gcRecords.List(txn, int(req.Payload.GcRecordsPageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, gcRecord := range gcRecords {
+		limit := int(req.Payload.MaxDeletedObjects) - totalDeletedObjects
+		deletedObjects := 0
+
+		switch r := gcRecord.Record.(type) {
+		case *corepb.WaitGroupsGarbageCollectionRecord_NamespaceId:
+			deletedObjects, err = c.deleteNamespace(txn, r.NamespaceId, int(req.Payload.GcRecordWaitGroupsPageSize), limit)
+			if err != nil {
+				return nil, err
+			}
+
+		case *corepb.WaitGroupsGarbageCollectionRecord_WaitGroupId:
+			deletedObjects, err = c.deleteWaitGroupJobs(txn, r.WaitGroupId, limit)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		totalDeletedObjects = totalDeletedObjects + deletedObjects
+
+		// If the number of actually deleted objects is less than the limit, then we have removed everything related to
+		// that garbage collection record.
+		if deletedObjects < limit {
+			// Remove this GC record since it is completed
+			err := c.gcRecords.Delete(txn, gcRecord)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.RunWaitGroupsGarbageCollectionResponse{
+		Payload: &corepb.RunWaitGroupsGarbageCollectionResponse{},
+	}, nil
+}
+
+// WaitGroupsDeleteNamespace records a GC marker that will, on subsequent
+// RunWaitGroupsGarbageCollection ticks, delete every wait group and job
+// belonging to the given namespace. The deletion itself is asynchronous;
+// this call only enqueues the request.
+func (c *Core) WaitGroupsDeleteNamespace(req *coreapis.WaitGroupsDeleteNamespaceRequest) (*coreapis.WaitGroupsDeleteNamespaceResponse, error) {
+	txn := c.badgerStore.Update()
+	defer txn.Discard()
+
+	// Mark the namespace as deleted
+	err := c.gcRecords.Create(txn, &corepb.WaitGroupsGarbageCollectionRecord{
+		Id: req.Payload.RecordId,
+		Record: &corepb.WaitGroupsGarbageCollectionRecord_NamespaceId{
+			NamespaceId: req.Payload.NamespaceId,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreapis.WaitGroupsDeleteNamespaceResponse{
+		Payload: &corepb.WaitGroupsDeleteNamespaceResponse{},
+	}, nil
+}
+
+// deletionTime returns the timestamp (ns) at which a finished wait group should
+// be deleted, given when it finished and its retention period in seconds.
+func deletionTime(finishedAt int64, deleteAfterFinishedSeconds int64) int64 {
+	return finishedAt + deleteAfterFinishedSeconds*int64(time.Second)
+}
+
+// completionRateWindow is the time constant of the completion rate of wait
+// groups: a job finished completionRateWindow ago weighs 1/e as much as one
+// finished just now.
+const completionRateWindow = 5 * time.Minute
+
+// updateCompletionRate folds jobs that just finished into the completion rate
+// of the wait group and re-estimates its completion time. The rate is the
+// exponentially decayed number of finished jobs per completionRateWindow,
+// corrected for wait groups younger than the window, which would otherwise be
+// underestimated.
+func updateCompletionRate(waitGroup *corepb.WaitGroup, finished int64, now int64) {
+	window := float64(completionRateWindow)
+
+	if waitGroup.CompletionRateUpdatedAt > 0 && now > waitGroup.CompletionRateUpdatedAt {
+		waitGroup.DecayedFinishedJobs *= math.Exp(-float64(now-waitGroup.CompletionRateUpdatedAt) / window)
+	}
+	waitGroup.DecayedFinishedJobs += float64(finished)
+	waitGroup.CompletionRateUpdatedAt = max(now, waitGroup.CompletionRateUpdatedAt)
+
+	waitGroup.CompletionRate = 0
+	if age := waitGroup.CompletionRateUpdatedAt - waitGroup.CreatedAt; age > 0 {
+		weight := 1 - math.Exp(-float64(age)/window)
+		waitGroup.CompletionRate = waitGroup.DecayedFinishedJobs / (weight * completionRateWindow.Seconds())
+	}
+
+	estimateCompletion(waitGroup)
+}
+
+// decayCompletionRate brings the completion rate and the estimated completion
+// time of an active wait group up to now, as if no jobs finished since the last
+// completion, so that reads do not report a stale rate once jobs stop
+// finishing. It is applied to wait groups being read and never persisted.
+func decayCompletionRate(waitGroup *corepb.WaitGroup, now int64) {
+	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE || now <= waitGroup.CompletionRateUpdatedAt {
+		return
+	}
+
+	updateCompletionRate(waitGroup, 0, now)
+}
+
+// estimateCompletion estimates when the remaining jobs of an active wait group
+// finish at its current completion rate.
+func estimateCompletion(waitGroup *corepb.WaitGroup) {
+	waitGroup.EstimatedCompletionAt = 0
+	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE || waitGroup.CompletionRate <= 0 {
+		return
+	}
+
+	remaining := float64(max(waitGroup.Counter-finishedJobs(waitGroup), 0))
+	estimatedCompletionAt := float64(waitGroup.CompletionRateUpdatedAt) + remaining/waitGroup.CompletionRate*float64(time.Second)
+	if estimatedCompletionAt < math.MaxInt64 {
+		waitGroup.EstimatedCompletionAt = int64(estimatedCompletionAt)
+	}
+}
+
+// sumStripeCounts sets CompletedJobs and FailedJobs of a striped wait group to
+// the sums of its stripe counts.
+func sumStripeCounts(waitGroup *corepb.WaitGroup) {
+	waitGroup.CompletedJobs = 0
+	waitGroup.FailedJobs = 0
+	for _, counts := range waitGroup.StripeCounts {
+		waitGroup.CompletedJobs += counts.CompletedJobs
+		waitGroup.FailedJobs += counts.FailedJobs
+	}
+}
+
+// newStripeCounts returns zero counts for each of the given number of stripes.
+func newStripeCounts(stripes uint32) []*corepb.WaitGroupStripeCounts {
+	if stripes == 0 {
+		return nil
+	}
+
+	counts := make([]*corepb.WaitGroupStripeCounts, stripes)
+	for stripe := range counts {
+		counts[stripe] = &corepb.WaitGroupStripeCounts{Stripe: uint32(stripe)}
+	}
+	return counts
+}
+
+// extendStripe postpones the deletion of the stripe to deleteAt, moving its
+// deletion record. The deletion is never brought forward. The caller is
+// responsible for persisting the stripe itself.
+func (c *Core) extendStripe(txn *store.Txn, stripe *corepb.WaitGroupStripe, deleteAt int64) error {
+	if deleteAt <= stripe.DeleteAt {
+		return nil
+	}
+
+	// A new stripe has no deletion record yet
+	if stripe.DeleteAt != 0 {
+		err := c.stripeDeletionRecords.Delete(txn, stripe.DeleteAt, stripe.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	stripe.DeleteAt = deleteAt
+	return c.stripeDeletionRecords.Add(txn, deleteAt, stripe.Id)
+}
+
+func waitGroupIdOfStripe(stripeId *corepb.WaitGroupStripeId) *corepb.WaitGroupId {
+	return &corepb.WaitGroupId{
+		AccountId:   stripeId.AccountId,
+		NamespaceId: stripeId.NamespaceId,
+		WaitGroupId: stripeId.WaitGroupId,
+	}
+}
+
+// finishedJobs returns the number of jobs reported for the wait group, whether
+// they succeeded or failed.
+func finishedJobs(waitGroup *corepb.WaitGroup) int64 {
+	return waitGroup.CompletedJobs + waitGroup.FailedJobs
+}
+
+// newAggregates starts an empty running aggregate for every aggregation spec.
+func newAggregates(specs []*corepb.WaitGroupAggregationSpec) []*corepb.WaitGroupAggregate {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	aggregates := make([]*corepb.WaitGroupAggregate, len(specs))
+	for i, spec := range specs {
+		aggregates[i] = &corepb.WaitGroupAggregate{
+			MetadataKey: spec.MetadataKey,
+			Function:    spec.Function,
+		}
+	}
+	return aggregates
+}
+
+// aggregateJob folds the metadata of a newly completed job into the running
+// aggregates of the wait group. Values that are missing or not an integer are
+// skipped. A SUM that would overflow saturates at the int64 bounds.
+func aggregateJob(waitGroup *corepb.WaitGroup, metadata map[string]string) {
+	for _, aggregate := range waitGroup.Aggregates {
+		value, ok := aggregationValue(metadata, aggregate.MetadataKey)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case aggregate.NumberOfValues == 0:
+			aggregate.Value = value
+		case aggregate.Function == corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM:
+			aggregate.Value = saturatingAdd(aggregate.Value, value)
+		case aggregate.Function == corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN:
+			aggregate.Value = min(aggregate.Value, value)
+		case aggregate.Function == corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX:
+			aggregate.Value = max(aggregate.Value, value)
+		}
+		aggregate.NumberOfValues++
+	}
+}
+
+// unaggregateJob takes the metadata of a retracted job back out of the running
+// sums of the wait group. A minimum or maximum cannot be undone without
+// rescanning every job, so MIN and MAX aggregates keep covering retracted jobs.
+// A saturated sum has lost its exact value and stays saturated.
+func unaggregateJob(waitGroup *corepb.WaitGroup, metadata map[string]string) {
+	for _, aggregate := range waitGroup.Aggregates {
+		if aggregate.Function != corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM {
+			continue
+		}
+
+		value, ok := aggregationValue(metadata, aggregate.MetadataKey)
+		if !ok {
+			continue
+		}
+
+		if aggregate.Value != math.MaxInt64 && aggregate.Value != math.MinInt64 {
+			aggregate.Value = saturatingSub(aggregate.Value, value)
+		}
+		aggregate.NumberOfValues--
+	}
+}
+
+// saturatingAdd returns a + b, clamped to the int64 range instead of wrapping.
+func saturatingAdd(a int64, b int64) int64 {
+	sum := a + b
+	switch {
+	case a > 0 && b > 0 && sum < 0:
+		return math.MaxInt64
+	case a < 0 && b < 0 && sum >= 0:
+		return math.MinInt64
+	}
+	return sum
+}
+
+// saturatingSub returns a - b, clamped to the int64 range instead of wrapping.
+func saturatingSub(a int64, b int64) int64 {
+	diff := a - b
+	switch {
+	case a >= 0 && b < 0 && diff < 0:
+		return math.MaxInt64
+	case a < 0 && b > 0 && diff >= 0:
+		return math.MinInt64
+	}
+	return diff
+}
+
+// aggregationValue parses the job metadata value under key as an integer.
+func aggregationValue(metadata map[string]string, key string) (int64, bool) {
+	raw, ok := metadata[key]
+	if !ok {
+		return 0, false
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// isJobDeclared reports whether the job is known to the wait group, either
+// pending in the pending index or already completed.
+func (c *Core) isJobDeclared(txn *store.Txn, waitGroupJobId *corepb.WaitGroupJobId) (bool, error) {
+	_, err := c.pendingJobs.Get(txn, waitGroupJobId)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return false, err
+	}
+
+	_, err = c.jobs.Get(txn, waitGroupJobId)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// finishedJob is a completed (or failed) integer job id decoded from a job chunk.
+type finishedJob struct {
+	id     uint64
+	failed bool
+}
+
+// listIntegerJobs returns a page of the completed jobs of a wait group with
+// integer job ids in job id order, decoded from its job chunks. A job without a
+// job row is listed without metadata and completion time. A NEXT pagination
+// token holds the job id its page starts at, a PREVIOUS one the job id its page
+// ends before, both as 8 big-endian bytes.
+func (c *Core) listIntegerJobs(txn *store.Txn, waitGroup *corepb.WaitGroup, paginationToken *corepb.PaginationToken, limit int) (*listWaitGroupJobsResult, error) {
+	var from uint64
+	reverse := false
+	if paginationToken != nil {
+		from = binary.BigEndian.Uint64(paginationToken.Value)
+		reverse = paginationToken.Type == corepb.PaginationToken_PREVIOUS
+	}
+
+	page, err := c.scanFinishedJobs(txn, waitGroup.Id, from, reverse, limit)
+	if err != nil {
+		return nil, err
+	}
+	if reverse {
+		slices.Reverse(page)
+	}
+
+	result := &listWaitGroupJobsResult{
+		jobs: make([]*corepb.WaitGroupJob, 0, len(page)),
+	}
+	if len(page) == 0 {
+		return result, nil
+	}
+
+	for _, finished := range page {
+		waitGroupJobId := &corepb.WaitGroupJobId{
+			AccountId:   waitGroup.Id.AccountId,
+			NamespaceId: waitGroup.Id.NamespaceId,
+			WaitGroupId: waitGroup.Id.WaitGroupId,
+			JobId:       formatIntegerJobId(finished.id),
+		}
+
+		job, err := c.jobs.Get(txn, waitGroupJobId)
+		if err != nil {
+			if !errors.Is(err, store.ErrNotFound) {
+				return nil, err
+			}
+			job = &corepb.WaitGroupJob{
+				Id:     waitGroupJobId,
+				Failed: finished.failed,
+			}
+		}
+		result.jobs = append(result.jobs, job)
+	}
+
+	first := page[0].id
+	before, err := c.scanFinishedJobs(txn, waitGroup.Id, first, true, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(before) > 0 {
+		result.previousPaginationToken = &corepb.PaginationToken{
+			Type:  corepb.PaginationToken_PREVIOUS,
+			Value: binary.BigEndian.AppendUint64(nil, first),
+		}
+	}
+
+	next := page[len(page)-1].id + 1
+	after, err := c.scanFinishedJobs(txn, waitGroup.Id, next, false, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(after) > 0 {
+		result.nextPaginationToken = &corepb.PaginationToken{
+			Type:  corepb.PaginationToken_NEXT,
+			Value: binary.BigEndian.AppendUint64(nil, next),
+		}
+	}
+
+	return result, nil
+}
+
+// scanFinishedJobs decodes up to limit finished integer job ids of a wait group
+// from its job chunks: the smallest ones not less than from, in ascending order,
+// or, with reverse, the largest ones less than from, in descending order.
+func (c *Core) scanFinishedJobs(txn *store.Txn, waitGroupId *corepb.WaitGroupId, from uint64, reverse bool, limit int) ([]finishedJob, error) {
+	result := make([]finishedJob, 0, limit)
+
+	if reverse {
+		if from == 0 {
+			return result, nil
+		}
+
+		last := from - 1
+		err := c.jobChunks.ListInRange(txn, waitGroupId, 0, last/jobChunkSize, true, func(chunk *corepb.WaitGroupJobChunk) (bool, error) {
+			before := jobChunkSize
+			if chunk.Id.ChunkIndex == last/jobChunkSize {
+				before = int(last%jobChunkSize) + 1
+			}
+
+			for len(result) < limit {
+				offset, failed := previousFinishedJob(chunk, before)
+				if offset < 0 {
+					break
+				}
+				result = append(result, finishedJob{id: chunk.Id.ChunkIndex*jobChunkSize + uint64(offset), failed: failed})
+				before = offset
+			}
+			return len(result) < limit, nil
+		})
+		return result, err
+	}
+
+	err := c.jobChunks.ListInRange(txn, waitGroupId, from/jobChunkSize, math.MaxUint64, false, func(chunk *corepb.WaitGroupJobChunk) (bool, error) {
+		next := 0
+		if chunk.Id.ChunkIndex == from/jobChunkSize {
+			next = int(from % jobChunkSize)
+		}
+
+		for len(result) < limit {
+			offset, failed := nextFinishedJob(chunk, next)
+			if offset < 0 {
+				break
+			}
+			result = append(result, finishedJob{id: chunk.Id.ChunkIndex*jobChunkSize + uint64(offset), failed: failed})
+			next = offset + 1
+		}
+		return len(result) < limit, nil
+	})
+	return result, err
+}
+
+// getCompletedJob returns the record of a completed job, or nil if the job is
+// not completed. With integer job ids the job chunk tells whether the job is
+// completed, and a job completed without metadata or history has no job row: its
+// record is rebuilt from the chunk, without a completion time.
+func (c *Core) getCompletedJob(txn *store.Txn, waitGroup *corepb.WaitGroup, chunks *jobChunksBatch, waitGroupJobId *corepb.WaitGroupJobId) (*corepb.WaitGroupJob, error) {
+	if waitGroup.IntegerJobIds {
+		id, ok := parseIntegerJobId(waitGroupJobId.JobId)
+		if !ok {
+			return nil, nil
+		}
+
+		finished, failed, err := chunks.status(txn, id)
+		if err != nil || !finished {
+			return nil, err
+		}
+
+		job, err := c.jobs.Get(txn, waitGroupJobId)
 		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return &corepb.WaitGroupJob{
+					Id:     waitGroupJobId,
+					Failed: failed,
+				}, nil
+			}
 			return nil, err
 		}
+		return job, nil
 	}
 
-	err = c.waitGroups.Update(txn, waitGroup)
-	if err != nil {
-		return nil, err
-	}
-
-	err = txn.Commit()
+	job, err := c.jobs.Get(txn, waitGroupJobId)
 	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, nil
+		}
 		return nil, err
 	}
-
-	return &coreapis.CompleteJobsFromWaitGroupResponse{
-		Payload: &corepb.CompleteJobsFromWaitGroupResponse{
-			WaitGroup: waitGroup,
-		},
-	}, nil
+	return job, nil
 }
 
-// RunWaitGroupsGarbageCollection processes one page of pending GC records,
-// deleting the wait groups and jobs they reference. Each record is bounded
-// by req.MaxDeletedObjects across the whole call; records that fully drain
-// within their budget are themselves removed, otherwise they remain and are
-// resumed on the next GC tick.
-//
-// On every tick it also: (1) marks active wait groups whose expires_at has
-// passed as expired and schedules their deletion, and (2) deletes finished
-// wait groups (completed or expired) whose scheduled deletion time has passed.
-func (c *Core) RunWaitGroupsGarbageCollection(req *coreapis.RunWaitGroupsGarbageCollectionRequest) (*coreapis.RunWaitGroupsGarbageCollectionResponse, error) {
-	txn := c.badgerStore.Update()
-	defer txn.Discard()
-
-	totalDeletedObjects := 0
-
-	// Mark expired wait groups (expires_at <= now) and schedule their deletion.
-	err := c.expireWaitGroups(txn, req.Now, int(req.Payload.GcRecordsPageSize))
-	if err != nil {
-		return nil, err
+// completeJobs records a batch of jobs against an active wait group and
+// persists it, finishing it (and reporting it to its parent) once enough jobs
+// are in. Nothing is written when an application error is returned: every job
+// is checked (declared or a valid integer job id, not overflowing the counter)
+// before any is recorded. With integer job ids a job is recorded in its job
+// chunk, and only gets a job row if it carries metadata or history.
+func (c *Core) completeJobs(txn *store.Txn, waitGroup *corepb.WaitGroup, jobs []*corepb.CompleteJobRequest, now int64) (*mrpc.Error, error) {
+	if waitGroup.Stripes > 0 {
+		return mrpc.NewErrorWithContext(
+			mrpc.InvalidRequest,
+			"jobs of a striped wait group are completed on its stripes",
+			map[string]string{
+				"wait_group_name": waitGroup.Name,
+				"stripes":         fmt.Sprintf("%d", waitGroup.Stripes),
+			},
+		), nil
 	}
 
-	// Delete finished wait groups whose retention period has elapsed.
-	deletedFinished, err := c.deleteFinishedWaitGroups(txn, req.Now, int(req.Payload.GcRecordsPageSize), int(req.Payload.MaxDeletedObjects)-totalDeletedObjects)
-	if err != nil {
-		return nil, err
+	if waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+		return mrpc.NewErrorWithContext(
+			mrpc.InvalidRequest,
+			"only active wait groups can accept jobs",
+			map[string]string{
+				"wait_group_name": waitGroup.Name,
+				"status":          waitGroup.Status.String(),
+			},
+		), nil
 	}
-	totalDeletedObjects = totalDeletedObjects + deletedFinished
 
-	// List one page of GC records
-	gcRecords, err := c.gcRecords.List(txn, int(req.Payload.GcRecordsPageSize))
-	if err != nil {
-		return nil, err
+	var chunks *jobChunksBatch
+	if waitGroup.IntegerJobIds {
+		chunks = newJobChunksBatch(c.jobChunks, waitGroup.Id)
 	}
 
-	for _, gcRecord := range gcRecords {
-		limit := int(req.Payload.MaxDeletedObjects) - totalDeletedObjects
-		deletedObjects := 0
+	newJobs := make([]*corepb.WaitGroupJob, 0, len(jobs))
+	seen := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		if seen[job.JobId] {
+			continue
+		}
+		seen[job.JobId] = true
 
-		switch r := gcRecord.Record.(type) {
-		case *corepb.WaitGroupsGarbageCollectionRecord_NamespaceId:
-			deletedObjects, err = c.deleteNamespace(txn, r.NamespaceId, int(req.Payload.GcRecordWaitGroupsPageSize), limit)
+		waitGroupJobId := &corepb.WaitGroupJobId{
+			AccountId:   waitGroup.Id.AccountId,
+			NamespaceId: waitGroup.Id.NamespaceId,
+			WaitGroupId: waitGroup.Id.WaitGroupId,
+			JobId:       job.JobId,
+		}
+
+		// Re-completing an already completed job is a no-op
+		if waitGroup.IntegerJobIds {
+			id, ok := parseIntegerJobId(job.JobId)
+			if !ok || id >= uint64(waitGroup.Counter) {
+				return mrpc.NewErrorWithContext(
+					mrpc.InvalidRequest,
+					"job id must be an integer between 0 and counter-1",
+					map[string]string{
+						"wait_group_name": waitGroup.Name,
+						"job_id":          job.JobId,
+						"counter":         fmt.Sprintf("%d", waitGroup.Counter),
+					}), nil
+			}
+
+			finished, _, err := chunks.status(txn, id)
 			if err != nil {
 				return nil, err
 			}
+			if finished {
+				continue
+			}
+		} else {
+			_, err := c.jobs.Get(txn, waitGroupJobId)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, store.ErrNotFound) {
+				return nil, err
+			}
+		}
 
-		case *corepb.WaitGroupsGarbageCollectionRecord_WaitGroupId:
-			deletedObjects, err = c.deleteWaitGroupJobs(txn, r.WaitGroupId, limit)
+		// Only declared jobs can be completed
+		if waitGroup.DeclaredJobs {
+			_, err := c.pendingJobs.Get(txn, waitGroupJobId)
 			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					return mrpc.NewErrorWithContext(
+						mrpc.InvalidRequest,
+						"job was not declared in the wait group",
+						map[string]string{
+							"wait_group_name": waitGroup.Name,
+							"job_id":          job.JobId,
+						}), nil
+				}
 				return nil, err
 			}
 		}
 
-		totalDeletedObjects = totalDeletedObjects + deletedObjects
+		// A job completed again after a retraction carries over the history
+		// of its earlier completions
+		var history []*corepb.WaitGroupJobAttempt
+		retractedJob, err := c.retractedJobs.Get(txn, waitGroupJobId)
+		if err == nil {
+			history = retractedJob.History
+		} else if !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
 
-		// If the number of actually deleted objects is less than the limit, then we have removed everything related to
-		// that garbage collection record.
-		if deletedObjects < limit {
-			// Remove this GC record since it is completed
-			err := c.gcRecords.Delete(txn, gcRecord)
+		newJobs = append(newJobs, &corepb.WaitGroupJob{
+			Id:          waitGroupJobId,
+			CompletedAt: now,
+			Metadata:    job.Metadata,
+			Failed:      job.Failed,
+			History:     history,
+		})
+
+		if job.Failed {
+			waitGroup.FailedJobs++
+		} else {
+			waitGroup.CompletedJobs++
+		}
+	}
+
+	// Reject if completing these jobs would overflow the wait group counter.
+	if finishedJobs(waitGroup) > waitGroup.Counter {
+		return mrpc.NewErrorWithContext(
+			mrpc.InvalidRequest,
+			"too many jobs to be marked completed",
+			map[string]string{
+				"wait_group_name": waitGroup.Name,
+				"counter":         fmt.Sprintf("%d", waitGroup.Counter),
+				"completed_jobs":  fmt.Sprintf("%d", waitGroup.CompletedJobs),
+				"failed_jobs":     fmt.Sprintf("%d", waitGroup.FailedJobs),
+			}), nil
+	}
+
+	for _, job := range newJobs {
+		if waitGroup.IntegerJobIds {
+			id, _ := parseIntegerJobId(job.Id.JobId)
+			err := chunks.add(txn, id, job.Failed)
 			if err != nil {
 				return nil, err
 			}
 		}
-	}
 
-	err = txn.Commit()
-	if err != nil {
-		return nil, err
-	}
+		if !waitGroup.IntegerJobIds || len(job.Metadata) > 0 || len(job.History) > 0 {
+			err := c.jobs.Create(txn, job)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	return &coreapis.RunWaitGroupsGarbageCollectionResponse{
-		Payload: &corepb.RunWaitGroupsGarbageCollectionResponse{},
-	}, nil
-}
+		// A completed declared job is no longer pending
+		if waitGroup.DeclaredJobs {
+			err := c.pendingJobs.Delete(txn, job.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-// WaitGroupsDeleteNamespace records a GC marker that will, on subsequent
-// RunWaitGroupsGarbageCollection ticks, delete every wait group and job
-// belonging to the given namespace. The deletion itself is asynchronous;
-// this call only enqueues the request.
-func (c *Core) WaitGroupsDeleteNamespace(req *coreapis.WaitGroupsDeleteNamespaceRequest) (*coreapis.WaitGroupsDeleteNamespaceResponse, error) {
-	txn := c.badgerStore.Update()
-	defer txn.Discard()
+		if len(job.History) > 0 {
+			err := c.retractedJobs.Delete(txn, job.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	// Mark the namespace as deleted
-	err := c.gcRecords.Create(txn, &corepb.WaitGroupsGarbageCollectionRecord{
-		Id: req.Payload.RecordId,
-		Record: &corepb.WaitGroupsGarbageCollectionRecord_NamespaceId{
-			NamespaceId: req.Payload.NamespaceId,
-		},
-	})
-	if err != nil {
-		return nil, err
+		// Only successful jobs are aggregated
+		if !job.Failed {
+			aggregateJob(waitGroup, job.Metadata)
+		}
 	}
 
-	err = txn.Commit()
-	if err != nil {
-		return nil, err
+	if chunks != nil {
+		err := chunks.flush(txn)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return &coreapis.WaitGroupsDeleteNamespaceResponse{
-		Payload: &corepb.WaitGroupsDeleteNamespaceResponse{},
-	}, nil
-}
+	waitGroup.LastActivityAt = now
+	updateCompletionRate(waitGroup, int64(len(newJobs)), now)
 
-// deletionTime returns the timestamp (ns) at which a finished wait group should
-// be deleted, given when it finished and its retention period in seconds.
-func deletionTime(finishedAt int64, deleteAfterFinishedSeconds int64) int64 {
-	return finishedAt + deleteAfterFinishedSeconds*int64(time.Second)
+	// Too many failures fail the wait group, even if this batch also reported
+	// its last outstanding jobs. Otherwise, when all jobs are completed the
+	// wait group becomes finished. Either way, schedule its deletion after
+	// delete_after_finished_seconds.
+	if waitGroup.MaxFailedJobs != nil && waitGroup.FailedJobs > *waitGroup.MaxFailedJobs {
+		err := c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, now)
+		if err != nil {
+			return nil, err
+		}
+	} else if finishedJobs(waitGroup) == waitGroup.Counter {
+		err := c.markWaitGroupFinished(txn, waitGroup, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, c.waitGroups.Update(txn, waitGroup)
 }
 
 // markWaitGroupFinished transitions a wait group to a terminal (finished) state
-// (completed or expired). It records the finish time, removes the now-obsolete
-// expiration index entry, and schedules the wait group for deletion after
-// delete_after_finished_seconds. The caller is responsible for persisting the
-// wait group itself.
+// (completed, failed, expired or cancelled). It records the finish time, removes the now-obsolete
+// expiration index entry, schedules the wait group for deletion after
+// delete_after_finished_seconds, and reports it to its parent wait group, if
+// any. The caller is responsible for persisting the wait group itself.
 func (c *Core) markWaitGroupFinished(txn *store.Txn, waitGroup *corepb.WaitGroup, status corepb.WaitGroupStatus, finishedAt int64) error {
 	waitGroup.Status = status
 	waitGroup.FinishedAt = finishedAt
+	waitGroup.EstimatedCompletionAt = 0
 
 	// A finished wait group no longer expires; drop its expiration index entry.
 	err := c.expirationRecords.Delete(txn, waitGroup.ExpiresAt, waitGroup.Id)
@@ -742,7 +2476,73 @@ func (c *Core) markWaitGroupFinished(txn *store.Txn, waitGroup *corepb.WaitGroup
 	}
 
 	// Schedule deletion after the retention period.
-	return c.deletionRecords.Add(txn, deletionTime(finishedAt, waitGroup.DeleteAfterFinishedSeconds), waitGroup.Id)
+	err = c.deletionRecords.Add(txn, deletionTime(finishedAt, waitGroup.DeleteAfterFinishedSeconds), waitGroup.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.reportToParent(txn, waitGroup, finishedAt)
+}
+
+// reportToParent reports a just finished wait group as a job (named after the
+// child) on its parent wait group. A COMPLETED child is reported as a completed
+// job; an EXPIRED, FAILED or CANCELLED child is reported according to its
+// ChildFailurePolicy. The report is skipped if the parent no longer exists, was
+// recreated under the same name or is already finished, or if the parent
+// rejects the job (for example because it would overflow the parent's counter);
+// the reason is recorded in the child's ParentReportError. Finishing the parent
+// in turn reports it to its own parent. The caller is responsible for
+// persisting the child.
+func (c *Core) reportToParent(txn *store.Txn, waitGroup *corepb.WaitGroup, now int64) error {
+	if waitGroup.ParentWaitGroupName == "" {
+		return nil
+	}
+
+	failed := waitGroup.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED
+	if failed {
+		switch waitGroup.ChildFailurePolicy {
+		case corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE:
+			return nil
+		case corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED:
+			failed = false
+		}
+	}
+
+	parent, err := c.waitGroups.GetByName(txn, waitGroup.Id.AccountId, waitGroup.Id.NamespaceId, waitGroup.ParentWaitGroupName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			waitGroup.ParentReportError = "parent wait group not found"
+			return nil
+		}
+		return err
+	}
+
+	// The parent was deleted and another wait group took its name
+	if parent.Id.WaitGroupId != waitGroup.ParentWaitGroupId {
+		waitGroup.ParentReportError = "parent wait group not found"
+		return nil
+	}
+
+	if parent.Status != corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE {
+		waitGroup.ParentReportError = "parent wait group is not active"
+		return nil
+	}
+
+	// An application error leaves the parent untouched; the child still
+	// finishes and records why it was not reported.
+	appErr, err := c.completeJobs(txn, parent, []*corepb.CompleteJobRequest{
+		{
+			JobId:  waitGroup.Name,
+			Failed: failed,
+		},
+	}, now)
+	if err != nil {
+		return err
+	}
+	if appErr != nil {
+		waitGroup.ParentReportError = appErr.Message
+	}
+	return nil
 }
 
 // expireWaitGroups marks active wait groups whose expires_at has passed as
@@ -870,6 +2670,79 @@ func (c *Core) deleteFinishedWaitGroups(txn *store.Txn, now int64, pageSize int,
 	return deletedObjects, nil
 }
 
+// deleteExpiredStripes deletes wait group stripes, and their jobs, whose
+// scheduled deletion time (delete_at <= now) has passed. Like
+// deleteFinishedWaitGroups it is bounded by maxDeletedObjects across the whole
+// call, and a stripe whose jobs do not fully drain is resumed on the next GC
+// tick. Returns the number of objects deleted.
+func (c *Core) deleteExpiredStripes(txn *store.Txn, now int64, pageSize int, maxDeletedObjects int) (int, error) {
+	deletedObjects := 0
+	if maxDeletedObjects <= 0 {
+		return deletedObjects, nil
+	}
+
+	pageSize = pagination.GetLimitWithDefaults(pageSize)
+
+	records := make([]*corepb.WaitGroupStripesDeletionRecord, 0, pageSize)
+	err := c.stripeDeletionRecords.ListByDeletion(txn, 0, now, func(record *corepb.WaitGroupStripesDeletionRecord) (bool, error) {
+		records = append(records, record)
+		return len(records) < pageSize, nil
+	})
+	if err != nil {
+		return deletedObjects, err
+	}
+
+	for _, record := range records {
+		// -2 reserves budget for removing the deletion record and the stripe
+		// row.
+		limit := maxDeletedObjects - deletedObjects - 2
+		if limit <= 0 {
+			break
+		}
+
+		_, err := c.stripes.Get(txn, record.StripeId)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				// Stripe already gone; drop the stale deletion record.
+				if err := c.stripeDeletionRecords.Delete(txn, record.DeleteAt, record.StripeId); err != nil {
+					return deletedObjects, err
+				}
+				deletedObjects++
+				continue
+			}
+			return deletedObjects, err
+		}
+
+		// Delete one page of the stripe's jobs.
+		jobsPage, err := c.stripeJobs.List(txn, record.StripeId, nil, limit)
+		if err != nil {
+			return deletedObjects, err
+		}
+		for _, job := range jobsPage.jobs {
+			if err := c.stripeJobs.Delete(txn, job.Id); err != nil {
+				return deletedObjects, err
+			}
+			deletedObjects++
+		}
+
+		// If fewer jobs were deleted than the limit, then all jobs are drained
+		// and the stripe itself can be removed.
+		if len(jobsPage.jobs) < limit {
+			if err := c.stripes.Delete(txn, record.StripeId); err != nil {
+				return deletedObjects, err
+			}
+			deletedObjects++
+
+			if err := c.stripeDeletionRecords.Delete(txn, record.DeleteAt, record.StripeId); err != nil {
+				return deletedObjects, err
+			}
+			deletedObjects++
+		}
+	}
+
+	return deletedObjects, nil
+}
+
 func (c *Core) deleteWaitGroupJobs(txn *store.Txn, waitGroupId *corepb.WaitGroupId, waitGroupJobsPageSize int) (int, error) {
 	deletedObjects := 0
 
@@ -887,6 +2760,61 @@ func (c *Core) deleteWaitGroupJobs(txn *store.Txn, waitGroupId *corepb.WaitGroup
 		deletedObjects++
 	}
 
+	// Then on the job chunks of integer job ids, each of them holding up to
+	// jobChunkSize jobs
+	if deletedObjects < waitGroupJobsPageSize {
+		var chunkIds []*corepb.WaitGroupJobChunkId
+		err := c.jobChunks.ListInRange(txn, waitGroupId, 0, math.MaxUint64, false, func(chunk *corepb.WaitGroupJobChunk) (bool, error) {
+			chunkIds = append(chunkIds, chunk.Id)
+			return deletedObjects+len(chunkIds) < waitGroupJobsPageSize, nil
+		})
+		if err != nil {
+			return deletedObjects, err
+		}
+		for _, chunkId := range chunkIds {
+			err := c.jobChunks.Delete(txn, chunkId)
+			if err != nil {
+				return deletedObjects, err
+			}
+
+			deletedObjects++
+		}
+	}
+
+	// Then spend what is left of the page on declared jobs that were never
+	// completed
+	if deletedObjects < waitGroupJobsPageSize {
+		pendingJobsPage, err := c.pendingJobs.List(txn, waitGroupId.AccountId, waitGroupId.NamespaceId, waitGroupId.WaitGroupId, nil, waitGroupJobsPageSize-deletedObjects)
+		if err != nil {
+			return deletedObjects, err
+		}
+		for _, pendingJob := range pendingJobsPage.jobs {
+			err := c.pendingJobs.Delete(txn, pendingJob.Id)
+			if err != nil {
+				return deletedObjects, err
+			}
+
+			deletedObjects++
+		}
+	}
+
+	// And finally on the history of retracted jobs that were never completed
+	// again
+	if deletedObjects < waitGroupJobsPageSize {
+		retractedJobsPage, err := c.retractedJobs.List(txn, waitGroupId.AccountId, waitGroupId.NamespaceId, waitGroupId.WaitGroupId, nil, waitGroupJobsPageSize-deletedObjects)
+		if err != nil {
+			return deletedObjects, err
+		}
+		for _, retractedJob := range retractedJobsPage.jobs {
+			err := c.retractedJobs.Delete(txn, retractedJob.Id)
+			if err != nil {
+				return deletedObjects, err
+			}
+
+			deletedObjects++
+		}
+	}
+
 	// deletedObjects holds the amount of objects that were actually deleted, can be less than waitGroupJobsPageSize.
 	return deletedObjects, nil
 }
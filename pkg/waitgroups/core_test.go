@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"math/rand/v2"
+	"strconv"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/evrblk/monstera/store"
 	"github.com/evrblk/monstera/utils"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/evrblk/grackle/pkg/coreapis"
 	"github.com/evrblk/grackle/pkg/corepb"
@@ -1093,687 +1096,2812 @@ func TestCore_CompleteJobsWithMetadata(t *testing.T) {
 	require.Equal(t, map[string]string{"worker": "w2"}, byId["job_2"])
 }
 
-func TestCore_SnapshotAndRestore(t *testing.T) {
-	now := time.Now()
-	waitGroupId := &corepb.WaitGroupId{
-		AccountId:   rand.Uint64(),
-		NamespaceId: rand.Uint64(),
-		WaitGroupId: rand.Uint64(),
-	}
+func TestCore_FailedJobs(t *testing.T) {
+	t.Run("failures count toward finishing", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
 
-	// Create two wait group cores for testing snapshot and restore
-	core1 := newWaitGroupsCore(t)
-	core2 := newWaitGroupsCore(t)
+		// T+0: Create wait group without a failure limit
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 3, 100, now.Add(time.Hour), now)
 
-	// T+0: Create wait group
-	resp1, err := core1.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
-		Payload: &corepb.CreateWaitGroupRequest{
-			WaitGroupId:                       waitGroupId,
-			Name:                              "test_wait_group",
-			Description:                       "test description",
-			Counter:                           10,
-			ExpiresAt:                         now.Add(time.Hour).UnixNano(),
-			MaxNumberOfWaitGroupsPerNamespace: 100,
-		},
-		Now: now.UnixNano(),
-	})
-	require.NoError(t, err)
-	require.NotNil(t, resp1)
-	require.Nil(t, resp1.ApplicationError)
-	require.NotNil(t, resp1.Payload)
+		// T+1m: Two jobs succeed, one fails
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
 
-	// T+1m: Complete some jobs
-	resp2, err := core1.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-			NamespaceId: &corepb.NamespaceId{
-				AccountId:   waitGroupId.AccountId,
-				NamespaceId: waitGroupId.NamespaceId,
-			},
-			WaitGroupName: "test_wait_group",
-			Jobs:          completeJobRequests([]string{"job_1", "job_2", "job_3"}),
-		},
-		Now: now.Add(time.Minute).UnixNano(),
+		wg = failJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_3"}, now.Add(time.Minute))
+		require.EqualValues(t, 2, wg.CompletedJobs)
+		require.EqualValues(t, 1, wg.FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+
+		// The failed job is listed with its outcome
+		jobsList := ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group")
+		require.Len(t, jobsList.Jobs, 3)
+		for _, job := range jobsList.Jobs {
+			require.Equal(t, job.Id.JobId == "job_3", job.Failed)
+		}
 	})
-	require.NoError(t, err)
-	require.NotNil(t, resp2)
-	require.Nil(t, resp2.ApplicationError)
-	require.NotNil(t, resp2.Payload)
 
-	// Take snapshot at this point
-	snapshot := core1.Snapshot()
+	t.Run("exceeding max failed jobs fails the wait group", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
 
-	// T+2m: Complete more jobs (after snapshot)
-	resp3, err := core1.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-			NamespaceId: &corepb.NamespaceId{
-				AccountId:   waitGroupId.AccountId,
-				NamespaceId: waitGroupId.NamespaceId,
-			},
-			WaitGroupName: "test_wait_group",
-			Jobs:          completeJobRequests([]string{"job_4", "job_5"}),
-		},
-		Now: now.Add(2 * time.Minute).UnixNano(),
-	})
-	require.NoError(t, err)
-	require.NotNil(t, resp3)
-	require.Nil(t, resp3.ApplicationError)
-	require.NotNil(t, resp3.Payload)
+		// T+0: Create wait group tolerating one failure
+		wg := createWaitGroupWithMaxFailedJobs(t, core, waitGroupId, "test_wait_group", 10, 1, now.Add(time.Hour), now)
+		require.EqualValues(t, 1, *wg.MaxFailedJobs)
 
-	// Write snapshot to buffer
-	buf := bytes.NewBuffer(nil)
-	err = snapshot.Write(buf)
-	require.NoError(t, err)
+		// T+1m: The first failure is tolerated
+		wg = failJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
 
-	// Restore snapshot to second core
-	err = core2.Restore(io.NopCloser(buf))
-	require.NoError(t, err)
+		// Re-reporting the same job, even as a success, changes nothing
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(time.Minute))
+		require.EqualValues(t, 0, wg.CompletedJobs)
+		require.EqualValues(t, 1, wg.FailedJobs)
 
-	// T+4m: Check that the restored state matches the snapshot state
-	// The wait group should exist with 3 completed jobs out of 10 total
-	resp5, err := core2.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupId,
-		},
-	})
-	require.NoError(t, err)
-	require.NotNil(t, resp5)
-	require.Nil(t, resp5.ApplicationError)
-	require.NotNil(t, resp5.Payload)
-	require.NotNil(t, resp5.Payload.WaitGroup)
-	require.EqualValues(t, 10, resp5.Payload.WaitGroup.Counter)
-	require.EqualValues(t, 3, resp5.Payload.WaitGroup.CompletedJobs)
+		// T+2m: The second failure exceeds the limit
+		wg = failJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_2"}, now.Add(2*time.Minute))
+		require.EqualValues(t, 2, wg.FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, wg.Status)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), wg.FinishedAt)
 
-	// T+5m: Complete more jobs in restored state
-	resp6, err := core2.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-			NamespaceId: &corepb.NamespaceId{
-				AccountId:   waitGroupId.AccountId,
-				NamespaceId: waitGroupId.NamespaceId,
-			},
-			WaitGroupName: "test_wait_group",
-			Jobs:          completeJobRequests([]string{"job_6", "job_7"}),
-		},
-		Now: now.Add(5 * time.Minute).UnixNano(),
-	})
-	require.NoError(t, err)
-	require.NotNil(t, resp6)
-	require.Nil(t, resp6.ApplicationError)
-	require.NotNil(t, resp6.Payload)
+		// A failed wait group accepts no more jobs
+		appErr := completeJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_3"}, now.Add(3*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
 
-	// T+6m: Verify state in restored core
-	resp7, err := core2.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupId,
-		},
+		// And is deleted after the retention period like any finished wait group
+		runWaitGroupsGC(t, core, now.Add(2*time.Minute).Add(3601*time.Second))
+		requireWaitGroupNotFound(t, core, waitGroupId)
 	})
-	require.NoError(t, err)
-	require.NotNil(t, resp7)
-	require.Nil(t, resp7.ApplicationError)
-	require.NotNil(t, resp7.Payload)
-	require.EqualValues(t, 10, resp7.Payload.WaitGroup.Counter)
-	require.EqualValues(t, 5, resp7.Payload.WaitGroup.CompletedJobs)
 
-	// T+7m: Complete more jobs in restored state
-	resp8, err := core2.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-			NamespaceId: &corepb.NamespaceId{
-				AccountId:   waitGroupId.AccountId,
-				NamespaceId: waitGroupId.NamespaceId,
-			},
-			WaitGroupName: "test_wait_group",
-			Jobs:          completeJobRequests([]string{"job_8", "job_9"}),
-		},
-		Now: now.Add(8 * time.Minute).UnixNano(),
-	})
-	require.NoError(t, err)
-	require.NotNil(t, resp8)
-	require.Nil(t, resp8.ApplicationError)
-	require.NotNil(t, resp8.Payload)
+	t.Run("zero max failed jobs fails on the first failure", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
 
-	// T+8m: Verify final state in restored core
-	resp9, err := core2.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupId,
-		},
-	})
-	require.NoError(t, err)
-	require.NotNil(t, resp9)
-	require.Nil(t, resp9.ApplicationError)
-	require.NotNil(t, resp9.Payload)
-	require.EqualValues(t, 10, resp9.Payload.WaitGroup.Counter)
-	require.EqualValues(t, 7, resp9.Payload.WaitGroup.CompletedJobs)
+		_ = createWaitGroupWithMaxFailedJobs(t, core, waitGroupId, "test_wait_group", 1, 0, now.Add(time.Hour), now)
 
-	// Verify that the original core has different state (it should have more completed jobs)
-	resp10, err := core1.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupId,
-		},
+		// The last outstanding job failing fails the wait group rather than completing it
+		wg := failJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, wg.Status)
 	})
-	require.NoError(t, err)
-	require.NotNil(t, resp10)
-	require.Nil(t, resp10.ApplicationError)
-	require.NotNil(t, resp10.Payload)
-	require.EqualValues(t, 10, resp10.Payload.WaitGroup.Counter)      // 10 + 5 added after snapshot
-	require.EqualValues(t, 5, resp10.Payload.WaitGroup.CompletedJobs) // 3 + 2 completed after snapshot
-}
 
-func TestCore_RunWaitGroupsGarbageCollection(t *testing.T) {
-	core := newWaitGroupsCore(t)
-	now := time.Now()
+	t.Run("failures count toward counter overflow", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
 
-	// Test parameters - use smaller limits to force multiple runs
-	maxDeletedObjects := int64(1000)
-	gcRecordsPageSize := int64(100)
-	gcRecordWaitGroupsPageSize := int64(1000)
+		wg := createWaitGroup(t, core, waitGroupId, "test_wait_group", 3, 100, now.Add(time.Hour), now)
+		_ = failJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
 
-	// Create test data for account 1
-	accountId1 := rand.Uint64()
-	namespaceId1 := &corepb.NamespaceId{
-		AccountId:   accountId1,
-		NamespaceId: rand.Uint64(),
-	}
+		// Shrinking the counter below completed + failed jobs is rejected
+		resp, err := core.UpdateWaitGroup(&coreapis.UpdateWaitGroupRequest{
+			Payload: &corepb.UpdateWaitGroupRequest{
+				NamespaceId:                namespaceId,
+				WaitGroupName:              "test_wait_group",
+				ExpiresAt:                  wg.ExpiresAt,
+				Counter:                    1,
+				ExpectedVersion:            wg.Version,
+				DeleteAfterFinishedSeconds: wg.DeleteAfterFinishedSeconds,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
 
-	// Create multiple wait groups for account 1 with many jobs each
-	numWaitGroups := 3
-	jobsPerGroup := 500 // 1500 total jobs, exceeding MaxDeletedObjects
-	waitGroupIds := make([]*corepb.WaitGroupId, numWaitGroups)
+		// Two more jobs on top of two failures overflow a counter of 3
+		appErr := completeJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_3", "job_4"}, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+	})
 
-	for i := range numWaitGroups {
-		waitGroupName := fmt.Sprintf("test_wait_group_%d", i)
-		waitGroupIds[i] = &corepb.WaitGroupId{
-			AccountId:   namespaceId1.AccountId,
-			NamespaceId: namespaceId1.NamespaceId,
-			WaitGroupId: rand.Uint64(),
-		}
+	t.Run("negative max failed jobs", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
 
-		// Create wait group with many jobs
-		resp1, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+		resp, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
 			Payload: &corepb.CreateWaitGroupRequest{
-				WaitGroupId:                       waitGroupIds[i],
-				Name:                              waitGroupName,
-				Description:                       fmt.Sprintf("test description %d", i),
-				Counter:                           int64(jobsPerGroup),
+				WaitGroupId: &corepb.WaitGroupId{
+					AccountId:   rand.Uint64(),
+					NamespaceId: rand.Uint64(),
+					WaitGroupId: rand.Uint64(),
+				},
+				Name:                              "test_wait_group",
+				Counter:                           1,
 				ExpiresAt:                         now.Add(time.Hour).UnixNano(),
 				MaxNumberOfWaitGroupsPerNamespace: 100,
+				DeleteAfterFinishedSeconds:        3600,
+				MaxFailedJobs:                     proto.Int64(-1),
 			},
 			Now: now.UnixNano(),
 		})
 		require.NoError(t, err)
-		require.NotNil(t, resp1)
-		require.Nil(t, resp1.ApplicationError)
-		require.NotNil(t, resp1.Payload)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+	})
+}
 
-		// Complete all jobs to create job records
-		jobIds := make([]string, jobsPerGroup)
-		for j := range jobsPerGroup {
-			jobIds[j] = fmt.Sprintf("job_%d_%d", i, j)
+func TestCore_DeclaredJobs(t *testing.T) {
+	t.Run("pending jobs shrink as declared jobs complete", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
 		}
 
-		resp2, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-			Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-				NamespaceId: &corepb.NamespaceId{
-					AccountId:   waitGroupIds[i].AccountId,
-					NamespaceId: waitGroupIds[i].NamespaceId,
-				},
-				WaitGroupName: waitGroupName,
-				Jobs:          completeJobRequests(jobIds),
-			},
-			Now: now.Add(time.Minute).UnixNano(),
-		})
-		require.NoError(t, err)
-		require.NotNil(t, resp2)
-		require.Nil(t, resp2.ApplicationError)
-		require.NotNil(t, resp2.Payload)
-	}
+		// T+0: Create wait group with declared jobs and declare them in two batches
+		wg := createWaitGroupWithDeclaredJobs(t, core, waitGroupId, "test_wait_group", 3, now.Add(time.Hour), now)
+		require.True(t, wg.DeclaredJobs)
+		require.EqualValues(t, 0, wg.NumberOfDeclaredJobs)
 
-	// Create test data for account 2 with multiple namespaces
-	accountId2 := rand.Uint64()
-	numNamespaces := 2
-	waitGroupsPerNamespace := 3
-	jobsPerWaitGroup := 400 // 1200 jobs per namespace
-	namespaceIds := make([]*corepb.NamespaceId, numNamespaces)
+		wg = addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+		require.EqualValues(t, 2, wg.NumberOfDeclaredJobs)
+		wg = addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_3"}, now)
+		require.EqualValues(t, 3, wg.NumberOfDeclaredJobs)
 
-	for ns := range numNamespaces {
-		namespaceIds[ns] = &corepb.NamespaceId{
-			AccountId:   accountId2,
+		pending := listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group")
+		require.Len(t, pending.Jobs, 3)
+
+		// T+1m: Complete one job, it is no longer pending
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_2"}, now.Add(time.Minute))
+		require.EqualValues(t, 1, wg.CompletedJobs)
+
+		pending = listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group")
+		require.Len(t, pending.Jobs, 2)
+		require.Equal(t, "job_1", pending.Jobs[0].Id.JobId)
+		require.Equal(t, "job_3", pending.Jobs[1].Id.JobId)
+
+		// T+2m: Complete the rest
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_3"}, now.Add(2*time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+
+		pending = listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group")
+		require.Len(t, pending.Jobs, 0)
+	})
+
+	t.Run("completing an undeclared job is rejected", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
 			NamespaceId: rand.Uint64(),
 		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
 
-		// Create multiple wait groups for this namespace
-		for wg := range waitGroupsPerNamespace {
-			waitGroupName := fmt.Sprintf("test_wait_group_%d", wg)
-			waitGroupId := &corepb.WaitGroupId{
-				AccountId:   namespaceIds[ns].AccountId,
-				NamespaceId: namespaceIds[ns].NamespaceId,
-				WaitGroupId: uint64(wg),
-			}
+		// T+0: Create wait group and declare one job
+		_ = createWaitGroupWithDeclaredJobs(t, core, waitGroupId, "test_wait_group", 3, now.Add(time.Hour), now)
+		_ = addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
 
-			// Create wait group with many jobs
-			resp3, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
-				Payload: &corepb.CreateWaitGroupRequest{
-					WaitGroupId:                       waitGroupId,
-					Name:                              waitGroupName,
-					Description:                       fmt.Sprintf("test description namespace %d wg %d", ns, wg),
-					Counter:                           int64(jobsPerWaitGroup),
-					ExpiresAt:                         now.Add(time.Hour).UnixNano(),
-					MaxNumberOfWaitGroupsPerNamespace: 100,
-				},
-				Now: now.UnixNano(),
-			})
-			require.NoError(t, err)
-			require.NotNil(t, resp3)
-			require.Nil(t, resp3.ApplicationError)
-			require.NotNil(t, resp3.Payload)
+		// T+1m: A batch with an unknown job is rejected as a whole
+		appErr := completeJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now.Add(time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		require.Equal(t, "job_2", appErr.Context["job_id"])
 
-			// Complete all jobs to create job records
-			jobIds := make([]string, jobsPerWaitGroup)
-			for j := range jobsPerWaitGroup {
-				jobIds[j] = fmt.Sprintf("job_ns%d_wg%d_%d", ns, wg, j)
-			}
+		wg := getWaitGroup(t, core, waitGroupId)
+		require.EqualValues(t, 0, wg.CompletedJobs)
 
-			resp4, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-				Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-					NamespaceId: &corepb.NamespaceId{
-						AccountId:   waitGroupId.AccountId,
-						NamespaceId: waitGroupId.NamespaceId,
-					},
-					WaitGroupName: waitGroupName,
-					Jobs:          completeJobRequests(jobIds),
-				},
-				Now: now.Add(time.Minute).UnixNano(),
-			})
-			require.NoError(t, err)
-			require.NotNil(t, resp4)
-			require.Nil(t, resp4.ApplicationError)
-			require.NotNil(t, resp4.Payload)
+		pending := listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group")
+		require.Len(t, pending.Jobs, 1)
+	})
+
+	t.Run("declaring is idempotent and bounded by counter", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
 		}
-	}
 
-	// Delete one wait group from account 1 (this will create a GC record for individual wait group)
-	resp5, err := core.DeleteWaitGroup(&coreapis.DeleteWaitGroupRequest{
-		Payload: &corepb.DeleteWaitGroupRequest{
-			RecordId: rand.Uint64(),
-			NamespaceId: &corepb.NamespaceId{
-				AccountId:   waitGroupIds[0].AccountId,
-				NamespaceId: waitGroupIds[0].NamespaceId,
+		// T+0: Create wait group and declare two jobs, one of them twice
+		_ = createWaitGroupWithDeclaredJobs(t, core, waitGroupId, "test_wait_group", 2, now.Add(time.Hour), now)
+		wg := addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_1"}, now)
+		require.EqualValues(t, 1, wg.NumberOfDeclaredJobs)
+
+		// Re-declaring a completed job changes nothing
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+		wg = addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+		require.EqualValues(t, 2, wg.NumberOfDeclaredJobs)
+
+		pending := listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group")
+		require.Len(t, pending.Jobs, 1)
+		require.Equal(t, "job_2", pending.Jobs[0].Id.JobId)
+
+		// Declaring more jobs than the counter is rejected
+		appErr := addJobsToWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_3"}, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// And the counter cannot be lowered below the number of declared jobs
+		resp, err := core.UpdateWaitGroup(&coreapis.UpdateWaitGroupRequest{
+			Payload: &corepb.UpdateWaitGroupRequest{
+				NamespaceId:     namespaceId,
+				WaitGroupName:   "test_wait_group",
+				ExpiresAt:       now.Add(time.Hour).UnixNano(),
+				ExpectedVersion: 1,
+				Counter:         1,
 			},
-			WaitGroupName: "test_wait_group_0",
-		},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
 	})
-	require.NoError(t, err)
-	require.NotNil(t, resp5)
-	require.Nil(t, resp5.ApplicationError)
-	require.NotNil(t, resp5.Payload)
 
-	// Delete one namespace from account 2 (this will create a GC record for entire namespace)
-	namespaceToDelete := namespaceIds[1]
+	t.Run("adding jobs requires declared jobs", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
 
-	resp6, err := core.WaitGroupsDeleteNamespace(&coreapis.WaitGroupsDeleteNamespaceRequest{
-		Payload: &corepb.WaitGroupsDeleteNamespaceRequest{
-			RecordId:    rand.Uint64(),
-			NamespaceId: namespaceToDelete,
-		},
-		Now: now.UnixNano(),
+		// T+0: Create a plain wait group
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 3, 100, now.Add(time.Hour), now)
+
+		appErr := addJobsToWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Any job id can still be completed
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"anything"}, now)
+		require.EqualValues(t, 1, wg.CompletedJobs)
+
+		// Adding jobs to a nonexistent wait group is NotFound
+		appErr = addJobsToWaitGroupWithError(t, core, namespaceId, "nonexistent", []string{"job_1"}, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
 	})
-	require.NoError(t, err)
-	require.NotNil(t, resp6)
-	require.Nil(t, resp6.ApplicationError)
-	require.NotNil(t, resp6.Payload)
 
-	// Run garbage collection multiple times to process all objects
-	// The first run should process some objects but not all due to MaxDeletedObjects limit
-	runCount := 0
-	maxRuns := 10 // Prevent infinite loop
+	t.Run("garbage collection drains pending jobs", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
 
-	for runCount < maxRuns {
-		runCount++
-		t.Logf("Running garbage collection iteration %d", runCount)
+		// T+0: Create wait group, declare jobs and complete one of them
+		_ = createWaitGroupWithDeclaredJobs(t, core, waitGroupId, "test_wait_group", 3, now.Add(time.Hour), now)
+		_ = addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2", "job_3"}, now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
 
-		resp7, err := core.RunWaitGroupsGarbageCollection(&coreapis.RunWaitGroupsGarbageCollectionRequest{
-			Payload: &corepb.RunWaitGroupsGarbageCollectionRequest{
-				GcRecordsPageSize:          gcRecordsPageSize,
-				GcRecordWaitGroupsPageSize: gcRecordWaitGroupsPageSize,
-				MaxDeletedObjects:          maxDeletedObjects,
+		// T+1m: Delete the wait group and run GC
+		resp, err := core.DeleteWaitGroup(&coreapis.DeleteWaitGroupRequest{
+			Payload: &corepb.DeleteWaitGroupRequest{
+				NamespaceId:   namespaceId,
+				WaitGroupName: "test_wait_group",
+				RecordId:      rand.Uint64(),
 			},
-			Now: now.UnixNano(),
+			Now: now.Add(time.Minute).UnixNano(),
 		})
 		require.NoError(t, err)
-		require.NotNil(t, resp7)
-		require.Nil(t, resp7.ApplicationError)
-		require.NotNil(t, resp7.Payload)
+		require.Nil(t, resp.ApplicationError)
 
-		// Check if both expected deletions have been completed
-		deletedWaitGroupAccessible := true
-		deletedNamespaceAccessible := true
+		runWaitGroupsGC(t, core, now.Add(2*time.Minute))
 
-		// Check if the deleted wait group is still accessible
-		resp8, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-			Payload: &corepb.GetWaitGroupRequest{
-				WaitGroupId: waitGroupIds[0],
-			},
-		})
+		txn := core.badgerStore.View()
+		defer txn.Discard()
+
+		jobs, err := core.jobs.List(txn, namespaceId.AccountId, namespaceId.NamespaceId, waitGroupId.WaitGroupId, nil, 100)
 		require.NoError(t, err)
-		require.NotNil(t, resp8)
+		require.Len(t, jobs.jobs, 0)
 
-		if resp8.ApplicationError != nil && resp8.ApplicationError.Code == mrpc.NotFound {
-			deletedWaitGroupAccessible = false
-			t.Logf("Deleted wait group is no longer accessible after run %d", runCount)
-		}
+		pendingJobs, err := core.pendingJobs.List(txn, namespaceId.AccountId, namespaceId.NamespaceId, waitGroupId.WaitGroupId, nil, 100)
+		require.NoError(t, err)
+		require.Len(t, pendingJobs.jobs, 0)
+	})
+}
 
-		// Check if the deleted namespace's wait groups are still accessible
-		deletedNamespaceWaitGroupId := &corepb.WaitGroupId{
-			AccountId:   namespaceToDelete.AccountId,
-			NamespaceId: namespaceToDelete.NamespaceId,
-			WaitGroupId: 0,
+func TestCore_NestedWaitGroups(t *testing.T) {
+	t.Run("completed children complete the parent", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
 		}
-		resp9, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-			Payload: &corepb.GetWaitGroupRequest{
-				WaitGroupId: deletedNamespaceWaitGroupId,
-			},
-		})
-		require.NoError(t, err)
-		require.NotNil(t, resp8)
+		rootId := newWaitGroupId(namespaceId)
 
-		if resp9.ApplicationError != nil && resp9.ApplicationError.Code == mrpc.NotFound {
-			deletedNamespaceAccessible = false
-			t.Logf("Deleted namespace is no longer accessible after run %d", runCount)
+		// T+0: Create a root with two children, one of which has a child of its own
+		_ = createWaitGroup(t, core, rootId, "root", 2, 100, now.Add(time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "shard_1", 2, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "shard_2", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "shard_2_part_1", 1, "shard_2", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+
+		// T+1m: Finish the first shard
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "shard_1", []string{"job_1", "job_2"}, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Empty(t, wg.ParentReportError)
+
+		root := getWaitGroup(t, core, rootId)
+		require.EqualValues(t, 1, root.CompletedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, root.Status)
+
+		// T+2m: Finishing the grandchild completes the second shard and the root
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "shard_2_part_1", []string{"job_1"}, now.Add(2*time.Minute))
+
+		root = getWaitGroup(t, core, rootId)
+		require.EqualValues(t, 2, root.CompletedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, root.Status)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), root.FinishedAt)
+
+		// Children are listed on the root as jobs named after them
+		jobsList := ListWaitGroupCompletedJobs(t, core, namespaceId, "root")
+		require.Len(t, jobsList.Jobs, 2)
+		require.Equal(t, "shard_1", jobsList.Jobs[0].Id.JobId)
+		require.Equal(t, "shard_2", jobsList.Jobs[1].Id.JobId)
+	})
+
+	t.Run("expired child is reported according to its policy", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
 		}
+		rootId := newWaitGroupId(namespaceId)
+
+		// T+0: Create a root and one child per policy, all expiring before the root
+		_ = createWaitGroup(t, core, rootId, "root", 3, 100, now.Add(time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "report_failed", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Minute), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "report_completed", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED, now.Add(time.Minute), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "ignore", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_IGNORE, now.Add(time.Minute), now)
+
+		// T+2m: GC expires the children
+		runWaitGroupsGC(t, core, now.Add(2*time.Minute))
+
+		root := getWaitGroup(t, core, rootId)
+		require.EqualValues(t, 1, root.CompletedJobs)
+		require.EqualValues(t, 1, root.FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, root.Status)
+
+		jobsList := ListWaitGroupCompletedJobs(t, core, namespaceId, "root")
+		require.Len(t, jobsList.Jobs, 2)
+		require.Equal(t, "report_completed", jobsList.Jobs[0].Id.JobId)
+		require.False(t, jobsList.Jobs[0].Failed)
+		require.Equal(t, "report_failed", jobsList.Jobs[1].Id.JobId)
+		require.True(t, jobsList.Jobs[1].Failed)
+	})
 
-		// If both deletions are complete, we can stop
-		if !deletedWaitGroupAccessible && !deletedNamespaceAccessible {
+	t.Run("failed child can fail the parent", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		rootId := newWaitGroupId(namespaceId)
+
+		// T+0: Create a root and a child that both tolerate no failures
+		_ = createWaitGroupWithMaxFailedJobs(t, core, rootId, "root", 2, 0, now.Add(time.Hour), now)
+
+		req := newChildWaitGroupRequest(newWaitGroupId(namespaceId), "child", 2, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+		req.Payload.MaxFailedJobs = proto.Int64(0)
+		resp, err := core.CreateWaitGroup(req)
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+
+		// T+1m: A failed job fails the child, which fails the root
+		wg := failJobsFromWaitGroup(t, core, namespaceId, "child", []string{"job_1"}, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, wg.Status)
+
+		root := getWaitGroup(t, core, rootId)
+		require.EqualValues(t, 1, root.FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, root.Status)
+	})
+
+	t.Run("parent must exist and be active", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		resp, err := core.CreateWaitGroup(newChildWaitGroupRequest(newWaitGroupId(namespaceId), "child", 1, "nonexistent", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now))
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+
+		// A completed parent no longer accepts children
+		_ = createWaitGroup(t, core, newWaitGroupId(namespaceId), "root", 1, 100, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "root", []string{"job_1"}, now)
+
+		resp, err = core.CreateWaitGroup(newChildWaitGroupRequest(newWaitGroupId(namespaceId), "child", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now))
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+	})
+
+	t.Run("rejected report leaves the parent untouched", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		rootId := newWaitGroupId(namespaceId)
+
+		// T+0: Create a root with declared jobs that does not declare the child
+		_ = createWaitGroupWithDeclaredJobs(t, core, rootId, "root", 2, now.Add(time.Hour), now)
+		_ = addJobsToWaitGroup(t, core, namespaceId, "root", []string{"other"}, now)
+		childId := newWaitGroupId(namespaceId)
+		_ = createChildWaitGroup(t, core, childId, "child", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+
+		// T+1m: The child completes, but the root does not accept it
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "child", []string{"job_1"}, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Equal(t, "job was not declared in the wait group", wg.ParentReportError)
+
+		root := getWaitGroup(t, core, rootId)
+		require.EqualValues(t, 0, root.CompletedJobs)
+		require.Len(t, ListWaitGroupCompletedJobs(t, core, namespaceId, "root").Jobs, 0)
+
+		// The rejected report is recorded on the child
+		child := getWaitGroup(t, core, childId)
+		require.Equal(t, "job was not declared in the wait group", child.ParentReportError)
+	})
+
+	t.Run("recreated parent is not reported to", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		rootId := newWaitGroupId(namespaceId)
+
+		// T+0: Create a root with a child
+		root := createWaitGroup(t, core, rootId, "root", 1, 100, now.Add(time.Hour), now)
+		childId := newWaitGroupId(namespaceId)
+		child := createChildWaitGroup(t, core, childId, "child", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+		require.Equal(t, root.Id.WaitGroupId, child.ParentWaitGroupId)
+
+		// T+1m: Delete the root and create another one under the same name
+		resp, err := core.DeleteWaitGroup(&coreapis.DeleteWaitGroupRequest{
+			Payload: &corepb.DeleteWaitGroupRequest{
+				NamespaceId:   namespaceId,
+				WaitGroupName: "root",
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		newRootId := newWaitGroupId(namespaceId)
+		_ = createWaitGroup(t, core, newRootId, "root", 1, 100, now.Add(time.Hour), now.Add(time.Minute))
+
+		// T+2m: The child completes, but the new root does not hear about it
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "child", []string{"job_1"}, now.Add(2*time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Equal(t, "parent wait group not found", wg.ParentReportError)
+
+		newRoot := getWaitGroup(t, core, newRootId)
+		require.EqualValues(t, 0, newRoot.CompletedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, newRoot.Status)
+	})
+
+	t.Run("finished parent is recorded on the child", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		// T+0: Create a root with a child, then cancel the root
+		_ = createWaitGroup(t, core, newWaitGroupId(namespaceId), "root", 2, 100, now.Add(time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "child", 1, "root", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+		_ = cancelWaitGroup(t, core, namespaceId, "root", "not needed", now)
+
+		// T+1m: The child completes after its parent finished
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "child", []string{"job_1"}, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Equal(t, "parent wait group is not active", wg.ParentReportError)
+	})
+}
+
+func TestCore_AddToWaitGroup(t *testing.T) {
+	t.Run("grows and shrinks the counter", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 2, 100, now.Add(time.Hour), now)
+
+		// T+1m: Two producers discover more work without knowing the version
+		wg := addToWaitGroup(t, core, namespaceId, "test_wait_group", 3, now.Add(time.Minute))
+		require.EqualValues(t, 5, wg.Counter)
+		require.EqualValues(t, 2, wg.Version)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), wg.UpdatedAt)
+
+		wg = addToWaitGroup(t, core, namespaceId, "test_wait_group", 2, now.Add(time.Minute))
+		require.EqualValues(t, 7, wg.Counter)
+		require.EqualValues(t, 3, wg.Version)
+
+		// T+2m: A negative delta shrinks it
+		wg = addToWaitGroup(t, core, namespaceId, "test_wait_group", -4, now.Add(2*time.Minute))
+		require.EqualValues(t, 3, wg.Counter)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
+	})
+
+	t.Run("shrinking down to the finished jobs completes the wait group", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group and complete two of its jobs
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 5, 100, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+
+		// Shrinking below the completed jobs is rejected
+		appErr := addToWaitGroupWithError(t, core, namespaceId, "test_wait_group", -4, 1000, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// T+1m: Shrinking exactly to them completes the wait group
+		wg := addToWaitGroup(t, core, namespaceId, "test_wait_group", -3, now.Add(time.Minute))
+		require.EqualValues(t, 2, wg.Counter)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), wg.FinishedAt)
+
+		// A finished wait group cannot be adjusted anymore
+		appErr = addToWaitGroupWithError(t, core, namespaceId, "test_wait_group", 1, 1000, now.Add(time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with declared jobs
+		_ = createWaitGroupWithDeclaredJobs(t, core, waitGroupId, "test_wait_group", 3, now.Add(time.Hour), now)
+		_ = addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+
+		// Growing past the max wait group size is rejected
+		appErr := addToWaitGroupWithError(t, core, namespaceId, "test_wait_group", 8, 10, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Shrinking below the declared jobs is rejected
+		appErr = addToWaitGroupWithError(t, core, namespaceId, "test_wait_group", -2, 10, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// The counter must stay positive
+		appErr = addToWaitGroupWithError(t, core, namespaceId, "test_wait_group", -3, 10, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Nothing was changed by the rejected calls
+		wg := getWaitGroup(t, core, waitGroupId)
+		require.EqualValues(t, 3, wg.Counter)
+		require.EqualValues(t, 1, wg.Version)
+
+		// Adjusting a nonexistent wait group is NotFound
+		appErr = addToWaitGroupWithError(t, core, namespaceId, "nonexistent", 1, 10, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+}
+
+func TestCore_UncompleteJobsFromWaitGroup(t *testing.T) {
+	t.Run("retracts completed and failed jobs", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group, complete two jobs and fail one
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 5, 100, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+		_ = failJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_3"}, now)
+
+		// T+1m: Retract one completed job, the failed job and a job that was
+		// never completed
+		wg := uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_3", "job_4", "job_1"}, false, now.Add(time.Minute))
+		require.EqualValues(t, 1, wg.CompletedJobs)
+		require.EqualValues(t, 0, wg.FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), wg.LastActivityAt)
+
+		jobs := ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group").Jobs
+		require.Len(t, jobs, 1)
+		require.Equal(t, "job_2", jobs[0].Id.JobId)
+
+		// Retracting again is a no-op
+		wg = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now.Add(time.Minute))
+		require.EqualValues(t, 1, wg.CompletedJobs)
+
+		// Nonexistent wait group
+		appErr := uncompleteJobsFromWaitGroupWithError(t, core, namespaceId, "nonexistent", []string{"job_1"}, false, now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+
+	t.Run("completing a retracted job again records its history", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group and complete a job with metadata
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 5, 100, now.Add(time.Hour), now)
+		resp, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+			Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+				NamespaceId:   namespaceId,
+				WaitGroupName: "test_wait_group",
+				Jobs: []*corepb.CompleteJobRequest{
+					{JobId: "job_1", Metadata: map[string]string{"output": "corrupt"}},
+				},
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+
+		// T+1m: Retract it, T+2m: complete it again, T+3m: retract it again,
+		// T+4m: complete it once more
+		_ = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now.Add(time.Minute))
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(2*time.Minute))
+		_ = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now.Add(3*time.Minute))
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(4*time.Minute))
+		require.EqualValues(t, 1, wg.CompletedJobs)
+
+		jobs := ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group").Jobs
+		require.Len(t, jobs, 1)
+		require.Equal(t, now.Add(4*time.Minute).UnixNano(), jobs[0].CompletedAt)
+		require.Len(t, jobs[0].History, 2)
+		require.Equal(t, now.UnixNano(), jobs[0].History[0].CompletedAt)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), jobs[0].History[0].RetractedAt)
+		require.Equal(t, map[string]string{"output": "corrupt"}, jobs[0].History[0].Metadata)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), jobs[0].History[1].CompletedAt)
+		require.Equal(t, now.Add(3*time.Minute).UnixNano(), jobs[0].History[1].RetractedAt)
+
+		// The retracted history row moved back into the job record
+		rows := countOwnedRows(t, core)
+		_ = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now.Add(5*time.Minute))
+		require.Equal(t, rows, countOwnedRows(t, core))
+	})
+
+	t.Run("retracting keeps only the most recent attempts", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group and complete a job
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 5, 100, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+
+		// Retract and complete the job again 4 times with a history bound of 2:
+		// retracted at T+1m, T+3m, T+5m and T+7m
+		for i := 0; i < 4; i++ {
+			resp, err := core.UncompleteJobsFromWaitGroup(&coreapis.UncompleteJobsFromWaitGroupRequest{
+				Payload: &corepb.UncompleteJobsFromWaitGroupRequest{
+					NamespaceId:         namespaceId,
+					WaitGroupName:       "test_wait_group",
+					JobIds:              []string{"job_1"},
+					MaxJobHistoryLength: 2,
+				},
+				Now: now.Add(time.Duration(2*i+1) * time.Minute).UnixNano(),
+			})
+			require.NoError(t, err)
+			require.Nil(t, resp.ApplicationError)
+
+			_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(time.Duration(2*i+2)*time.Minute))
+		}
+
+		// Only the last 2 attempts are kept, oldest first
+		jobs := ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group").Jobs
+		require.Len(t, jobs, 1)
+		require.Len(t, jobs[0].History, 2)
+		require.Equal(t, now.Add(4*time.Minute).UnixNano(), jobs[0].History[0].CompletedAt)
+		require.Equal(t, now.Add(5*time.Minute).UnixNano(), jobs[0].History[0].RetractedAt)
+		require.Equal(t, now.Add(6*time.Minute).UnixNano(), jobs[0].History[1].CompletedAt)
+		require.Equal(t, now.Add(7*time.Minute).UnixNano(), jobs[0].History[1].RetractedAt)
+	})
+
+	t.Run("reopens a completed wait group", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create and complete wait group
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 2, 100, now.Add(time.Hour), now)
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+
+		// Without reopen a completed wait group is rejected
+		appErr := uncompleteJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now.Add(time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Nor is it reopened without taking back a completed job
+		appErr = uncompleteJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_3"}, true, now.Add(time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, getWaitGroup(t, core, waitGroupId).Status)
+
+		// T+1m: Reopen it
+		version := wg.Version
+		wg = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, true, now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
+		require.EqualValues(t, 0, wg.FinishedAt)
+		require.EqualValues(t, 1, wg.CompletedJobs)
+		require.Equal(t, version+1, wg.Version)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), wg.UpdatedAt)
+
+		// The scheduled deletion was cancelled: GC well past the retention
+		// period leaves the wait group alone
+		runWaitGroupsGC(t, core, now.Add(30*time.Minute))
+		_ = getWaitGroup(t, core, waitGroupId)
+
+		// T+2m: Complete the job again
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(2*time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Equal(t, now.Add(2*time.Minute).UnixNano(), wg.FinishedAt)
+	})
+
+	t.Run("reopened wait group expires again", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create and complete wait group, T+1m: reopen it
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 1, 100, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+		_ = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, true, now.Add(time.Minute))
+
+		// T+1h: It expires like any active wait group
+		runWaitGroupsGC(t, core, now.Add(time.Hour))
+		wg := getWaitGroup(t, core, waitGroupId)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED, wg.Status)
+
+		// An expired wait group cannot be reopened
+		appErr := uncompleteJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_1"}, true, now.Add(time.Hour))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+	})
+
+	t.Run("reopen limits", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		// T+0: Create and complete a wait group that expires in 3 hours
+		_ = createWaitGroup(t, core, newWaitGroupId(namespaceId), "test_wait_group", 1, 100, now.Add(3*time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+
+		// T+1h: Its retention period (an hour) is over, even if not GCed yet
+		appErr := uncompleteJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_1"}, true, now.Add(time.Hour))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// A completed child cannot be reopened, its parent already counted it
+		_ = createWaitGroup(t, core, newWaitGroupId(namespaceId), "parent", 2, 100, now.Add(3*time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "child", 1, "parent", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(3*time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "child", []string{"job_1"}, now)
+
+		appErr = uncompleteJobsFromWaitGroupWithError(t, core, namespaceId, "child", []string{"job_1"}, true, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+	})
+
+	t.Run("retracted declared job is pending again", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with declared jobs and complete one
+		_ = createWaitGroupWithDeclaredJobs(t, core, waitGroupId, "test_wait_group", 2, now.Add(time.Hour), now)
+		_ = addJobsToWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+		require.Len(t, listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group").Jobs, 1)
+
+		// T+1m: Retract it
+		_ = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now.Add(time.Minute))
+		require.Len(t, listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group").Jobs, 2)
+
+		// It can still be completed, being declared
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now.Add(2*time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Empty(t, listWaitGroupPendingJobs(t, core, namespaceId, "test_wait_group").Jobs)
+	})
+
+	t.Run("deleting the wait group removes retracted jobs", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group, complete and retract a job
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 3, 100, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, now)
+		_ = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now)
+
+		// Delete the wait group and run GC
+		resp, err := core.DeleteWaitGroup(&coreapis.DeleteWaitGroupRequest{
+			Payload: &corepb.DeleteWaitGroupRequest{
+				NamespaceId:   namespaceId,
+				WaitGroupName: "test_wait_group",
+				RecordId:      rand.Uint64(),
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		runWaitGroupsGC(t, core, now)
+
+		// Only the namespace counters row is left
+		require.Equal(t, 1, countOwnedRows(t, core))
+	})
+}
+
+func TestCore_CancelWaitGroup(t *testing.T) {
+	t.Run("cancels an active wait group", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group and complete a job
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 3, 100, now.Add(2*time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+		version := getWaitGroup(t, core, waitGroupId).Version
+
+		// T+1m: Cancel it
+		wg := cancelWaitGroup(t, core, namespaceId, "test_wait_group", "bad input data", now.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED, wg.Status)
+		require.Equal(t, "bad input data", wg.CancellationReason)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), wg.FinishedAt)
+		require.EqualValues(t, 1, wg.CompletedJobs)
+		require.Equal(t, version+1, wg.Version)
+
+		// T+2m: Cancelling again is a no-op
+		wg = cancelWaitGroup(t, core, namespaceId, "test_wait_group", "another reason", now.Add(2*time.Minute))
+		require.Equal(t, "bad input data", wg.CancellationReason)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), wg.FinishedAt)
+		require.Equal(t, version+1, wg.Version)
+
+		// A cancelled wait group no longer accepts jobs
+		appErr := completeJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_2"}, now.Add(2*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// T+1h: Still retained
+		runWaitGroupsGC(t, core, now.Add(time.Hour))
+		wg = getWaitGroup(t, core, waitGroupId)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_CANCELLED, wg.Status)
+
+		// T+1h1m: Deleted once the retention period is over
+		runWaitGroupsGC(t, core, now.Add(time.Hour+time.Minute))
+		requireWaitGroupNotFound(t, core, waitGroupId)
+	})
+
+	t.Run("only active wait groups can be cancelled", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		// T+0: Create and complete a wait group
+		_ = createWaitGroup(t, core, newWaitGroupId(namespaceId), "test_wait_group", 1, 100, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+
+		appErr := cancelWaitGroupWithError(t, core, namespaceId, "test_wait_group", now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		appErr = cancelWaitGroupWithError(t, core, namespaceId, "nonexistent", now)
+		require.Equal(t, mrpc.NotFound, appErr.Code)
+	})
+
+	t.Run("cancelled child is reported to its parent", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		parentId := newWaitGroupId(namespaceId)
+
+		// T+0: Create parent and two children
+		_ = createWaitGroup(t, core, parentId, "parent", 2, 100, now.Add(time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "child_1", 1, "parent", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now)
+		_ = createChildWaitGroup(t, core, newWaitGroupId(namespaceId), "child_2", 1, "parent", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_COMPLETED, now.Add(time.Hour), now)
+
+		// T+1m: Cancel both children
+		_ = cancelWaitGroup(t, core, namespaceId, "child_1", "", now.Add(time.Minute))
+		_ = cancelWaitGroup(t, core, namespaceId, "child_2", "", now.Add(time.Minute))
+
+		parent := getWaitGroup(t, core, parentId)
+		require.EqualValues(t, 1, parent.FailedJobs)
+		require.EqualValues(t, 1, parent.CompletedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, parent.Status)
+	})
+}
+
+func TestCore_Aggregations(t *testing.T) {
+	t.Run("sum, min and max over job metadata", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with aggregations
+		wg := createWaitGroupWithAggregations(t, core, waitGroupId, "test_wait_group", 10, now)
+		require.Len(t, wg.Aggregates, 3)
+		require.EqualValues(t, 0, wg.Aggregates[0].NumberOfValues)
+
+		// T+1m: Complete jobs, one of them twice, and fail one, which is not
+		// aggregated
+		wg = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_1": {"rows": "100"},
+			"job_2": {"rows": "-20"},
+		}, false, now.Add(time.Minute))
+		wg = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_1": {"rows": "100"},
+			"job_3": {"rows": "300"},
+		}, false, now.Add(time.Minute))
+		wg = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_4": {"rows": "5"},
+		}, true, now.Add(time.Minute))
+
+		// Values that are missing or not integers are skipped
+		wg = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_5": {"rows": "many"},
+			"job_6": {"other": "1"},
+		}, false, now.Add(time.Minute))
+		require.EqualValues(t, 5, wg.CompletedJobs)
+
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, 380, 3)
+		requireAggregate(t, wg.Aggregates[1], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN, -20, 3)
+		requireAggregate(t, wg.Aggregates[2], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX, 300, 3)
+
+		// Aggregates are persisted with the wait group
+		wg = getWaitGroup(t, core, waitGroupId)
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, 380, 3)
+	})
+
+	t.Run("failed jobs are not aggregated", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with aggregations and fail a job
+		_ = createWaitGroupWithAggregations(t, core, waitGroupId, "test_wait_group", 10, now)
+		wg := completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_1": {"rows": "100"},
+		}, true, now)
+		require.EqualValues(t, 1, wg.FailedJobs)
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, 0, 0)
+
+		// T+1m: Retracting the failed job leaves the aggregates alone
+		wg = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now.Add(time.Minute))
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, 0, 0)
+
+		// T+2m: Redoing it successfully aggregates it
+		wg = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_1": {"rows": "100"},
+		}, false, now.Add(2*time.Minute))
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, 100, 1)
+	})
+
+	t.Run("sum saturates instead of overflowing", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with aggregations and push the sum past the max
+		_ = createWaitGroupWithAggregations(t, core, waitGroupId, "test_wait_group", 10, now)
+		_ = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_1": {"rows": strconv.FormatInt(math.MaxInt64, 10)},
+		}, false, now)
+		wg := completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_2": {"rows": "10"},
+		}, false, now)
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, math.MaxInt64, 2)
+
+		// T+1m: A saturated sum stays saturated when a job is retracted
+		wg = uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_2"}, false, now.Add(time.Minute))
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, math.MaxInt64, 1)
+	})
+
+	t.Run("retracting a job takes it out of the sum", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with aggregations and complete two jobs
+		_ = createWaitGroupWithAggregations(t, core, waitGroupId, "test_wait_group", 10, now)
+		_ = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_1": {"rows": "100"},
+			"job_2": {"rows": "300"},
+		}, false, now)
+
+		// T+1m: Retract the second one
+		wg := uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_2"}, false, now.Add(time.Minute))
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, 100, 1)
+		// MIN and MAX keep covering the retracted job
+		requireAggregate(t, wg.Aggregates[2], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX, 300, 2)
+
+		// T+2m: Redo it with a different value
+		wg = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"job_2": {"rows": "250"},
+		}, false, now.Add(2*time.Minute))
+		requireAggregate(t, wg.Aggregates[0], corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM, 350, 2)
+	})
+}
+
+func TestCore_IntegerJobIds(t *testing.T) {
+	t.Run("complete and list jobs", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with integer job ids spanning several chunks
+		wg := createWaitGroupWithIntegerJobIds(t, core, waitGroupId, "test_wait_group", 200000, now)
+		require.True(t, wg.IntegerJobIds)
+
+		// T+1m: Complete jobs in different chunks, one of them twice, fail one
+		// and complete one with metadata
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"199999", "0", "70000", "1", "1"}, now.Add(time.Minute))
+		require.EqualValues(t, 4, wg.CompletedJobs)
+		wg = failJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"5", "0"}, now.Add(time.Minute))
+		require.EqualValues(t, 4, wg.CompletedJobs)
+		require.EqualValues(t, 1, wg.FailedJobs)
+		wg = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"2": {"key": "value"},
+		}, false, now.Add(time.Minute))
+		require.EqualValues(t, 5, wg.CompletedJobs)
+
+		// Job ids must be canonical integers below the counter
+		for _, jobId := range []string{"200000", "01", "-1", "job_1"} {
+			appErr := completeJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"3", jobId}, now.Add(2*time.Minute))
+			require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		}
+		require.EqualValues(t, 5, getWaitGroup(t, core, waitGroupId).CompletedJobs)
+
+		// Jobs are listed in job id order, only those completed with metadata
+		// have a completion time
+		jobs := ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group").Jobs
+		require.Len(t, jobs, 6)
+		for i, jobId := range []string{"0", "1", "2", "5", "70000", "199999"} {
+			require.Equal(t, jobId, jobs[i].Id.JobId)
+			require.Equal(t, jobId == "5", jobs[i].Failed)
+		}
+		require.Equal(t, "value", jobs[2].Metadata["key"])
+		require.Equal(t, now.Add(time.Minute).UnixNano(), jobs[2].CompletedAt)
+		require.Zero(t, jobs[0].CompletedAt)
+	})
+
+	t.Run("paginate over array and bitmap chunks", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Complete enough jobs for the first chunk to become a bitmap, and
+		// a few in the second one
+		_ = createWaitGroupWithIntegerJobIds(t, core, waitGroupId, "test_wait_group", 100000, now)
+		jobIds := append(integerJobIds(0, 5000), integerJobIds(65536, 65546)...)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", jobIds, now)
+
+		// Page forward through every job
+		var listed []string
+		var previous *corepb.PaginationToken
+		var token *corepb.PaginationToken
+		for {
+			page := listWaitGroupCompletedJobsPage(t, core, namespaceId, "test_wait_group", token, 250)
+			for _, job := range page.Jobs {
+				listed = append(listed, job.Id.JobId)
+			}
+			if token == nil {
+				require.Nil(t, page.PreviousPaginationToken)
+			}
+			previous = page.PreviousPaginationToken
+			token = page.NextPaginationToken
+			if token == nil {
+				break
+			}
+		}
+		require.Equal(t, jobIds, listed)
+
+		// And back from the last page
+		page := listWaitGroupCompletedJobsPage(t, core, namespaceId, "test_wait_group", previous, 250)
+		require.Len(t, page.Jobs, 250)
+		require.Equal(t, jobIds[len(jobIds)-250-len(jobIds)%250], page.Jobs[0].Id.JobId)
+		require.NotNil(t, page.NextPaginationToken)
+
+		// Malformed tokens are rejected
+		resp, err := core.ListWaitGroupCompletedJobs(&coreapis.ListWaitGroupCompletedJobsRequest{
+			Payload: &corepb.ListWaitGroupCompletedJobsRequest{
+				NamespaceId:     namespaceId,
+				WaitGroupName:   "test_wait_group",
+				PaginationToken: &corepb.PaginationToken{Type: corepb.PaginationToken_NEXT, Value: []byte("job_1")},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+	})
+
+	t.Run("uncomplete jobs", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group and complete jobs
+		_ = createWaitGroupWithIntegerJobIds(t, core, waitGroupId, "test_wait_group", 10, now)
+		_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", integerJobIds(0, 4), now)
+
+		// T+1m: Retract a job, skipping ones that are not completed
+		wg := uncompleteJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"1", "7", "job_1"}, false, now.Add(time.Minute))
+		require.EqualValues(t, 3, wg.CompletedJobs)
+		jobs := ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group").Jobs
+		require.Len(t, jobs, 3)
+		require.Equal(t, "2", jobs[1].Id.JobId)
+
+		// T+2m: Complete it again, it carries over its history
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"1"}, now.Add(2*time.Minute))
+		require.EqualValues(t, 4, wg.CompletedJobs)
+		jobs = ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group").Jobs
+		require.Equal(t, "1", jobs[1].Id.JobId)
+		require.Len(t, jobs[1].History, 1)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), jobs[1].History[0].RetractedAt)
+	})
+
+	t.Run("cannot be combined with declared jobs or parents", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		req := newIntegerJobIdsWaitGroupRequest(newWaitGroupId(namespaceId), "declared", 10, now)
+		req.Payload.DeclaredJobs = true
+		resp, err := core.CreateWaitGroup(req)
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+
+		// Children are reported to their parent by name
+		_ = createWaitGroupWithIntegerJobIds(t, core, newWaitGroupId(namespaceId), "parent", 10, now)
+		resp, err = core.CreateWaitGroup(newChildWaitGroupRequest(newWaitGroupId(namespaceId), "child", 1, "parent", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_INVALID, now.Add(time.Hour), now))
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+	})
+
+	t.Run("deleting the wait group removes job chunks", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Complete every job, one of them with metadata
+		_ = createWaitGroupWithIntegerJobIds(t, core, waitGroupId, "test_wait_group", 200000, now)
+		_ = completeJobsWithMetadata(t, core, namespaceId, "test_wait_group", map[string]map[string]string{
+			"0": {"key": "value"},
+		}, false, now)
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", integerJobIds(1, 200000), now)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+
+		// Wait group, names index, counters, deletion record, 4 chunks and the
+		// one job row
+		require.Equal(t, 9, countOwnedRows(t, core))
+
+		// After the retention period everything but the namespace counters row
+		// is gone
+		runWaitGroupsGC(t, core, now.Add(2*time.Hour))
+		requireWaitGroupNotFound(t, core, waitGroupId)
+		require.Equal(t, 1, countOwnedRows(t, core))
+	})
+}
+
+func TestCore_StripedWaitGroups(t *testing.T) {
+	t.Run("complete jobs on stripes and aggregate them", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Create wait group with 4 stripes
+		wg := createStripedWaitGroup(t, core, waitGroupId, "test_wait_group", 5, 4, now)
+		require.EqualValues(t, 4, wg.Stripes)
+		require.Len(t, wg.StripeCounts, 4)
+
+		// Jobs are not completed on the wait group itself, which is returned
+		// untouched
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now)
+		require.EqualValues(t, 4, wg.Stripes)
+		require.EqualValues(t, 0, wg.CompletedJobs)
+		require.Equal(t, wg.Version, getWaitGroup(t, core, waitGroupId).Version)
+		appErr := uncompleteJobsFromWaitGroupWithError(t, core, namespaceId, "test_wait_group", []string{"job_1"}, false, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		// Nor are they listed from it
+		list := ListWaitGroupCompletedJobs(t, core, namespaceId, "test_wait_group")
+		require.Empty(t, list.Jobs)
+		require.NotNil(t, list.StripedWaitGroup)
+		require.EqualValues(t, 4, list.StripedWaitGroup.Stripes)
+
+		// T+1m: Complete jobs on two stripes, one of them twice
+		deleteAt := now.Add(2 * time.Hour)
+		stripe0 := completeJobsFromWaitGroupStripe(t, core, waitGroupId, 0, []string{"job_1", "job_2", "job_1"}, false, deleteAt, now.Add(time.Minute))
+		require.EqualValues(t, 2, stripe0.CompletedJobs)
+		stripe0 = completeJobsFromWaitGroupStripe(t, core, waitGroupId, 0, []string{"job_2"}, true, deleteAt, now.Add(time.Minute))
+		require.EqualValues(t, 2, stripe0.CompletedJobs)
+		require.EqualValues(t, 0, stripe0.FailedJobs)
+		stripe3 := completeJobsFromWaitGroupStripe(t, core, waitGroupId, 3, []string{"job_3"}, true, deleteAt, now.Add(time.Minute))
+		require.EqualValues(t, 1, stripe3.FailedJobs)
+
+		wg = aggregateWaitGroupStripes(t, core, waitGroupId, []*corepb.WaitGroupStripe{stripe0, stripe3}, now.Add(time.Minute))
+		require.EqualValues(t, 2, wg.CompletedJobs)
+		require.EqualValues(t, 1, wg.FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
+
+		// Stripes are listed with their own jobs only
+		jobs := listWaitGroupStripeCompletedJobs(t, core, waitGroupId, 0)
+		require.Len(t, jobs, 2)
+		require.Equal(t, "job_1", jobs[0].Id.JobId)
+		require.EqualValues(t, 0, jobs[0].Id.Stripe)
+		require.Len(t, listWaitGroupStripeCompletedJobs(t, core, waitGroupId, 1), 0)
+
+		// T+2m: An outdated report does not lower the counts
+		stripe1 := completeJobsFromWaitGroupStripe(t, core, waitGroupId, 1, []string{"job_4"}, false, deleteAt, now.Add(2*time.Minute))
+		wg = aggregateWaitGroupStripes(t, core, waitGroupId, []*corepb.WaitGroupStripe{stripe1, {
+			Id:            stripe0.Id,
+			CompletedJobs: 1,
+		}}, now.Add(2*time.Minute))
+		require.EqualValues(t, 3, wg.CompletedJobs)
+		require.EqualValues(t, 1, wg.FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
+
+		// T+3m: Reaching the counter completes the wait group
+		stripe2 := completeJobsFromWaitGroupStripe(t, core, waitGroupId, 2, []string{"job_5"}, false, deleteAt, now.Add(3*time.Minute))
+		wg = aggregateWaitGroupStripes(t, core, waitGroupId, []*corepb.WaitGroupStripe{stripe2}, now.Add(3*time.Minute))
+		require.EqualValues(t, 4, wg.CompletedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Equal(t, now.Add(3*time.Minute).UnixNano(), wg.FinishedAt)
+
+		// A late report past the counter is rejected by its stripe
+		appErr = completeJobsFromWaitGroupStripeWithError(t, core, waitGroupId, 2, []string{"job_6"}, false, deleteAt, now.Add(4*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		require.EqualValues(t, 1, getWaitGroupStripe(t, core, waitGroupId, 2).CompletedJobs)
+	})
+
+	t.Run("jobs past the counter are rejected", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+		deleteAt := now.Add(2 * time.Hour)
+
+		// T+0: Create wait group of 3 jobs with 2 stripes
+		_ = createStripedWaitGroup(t, core, waitGroupId, "test_wait_group", 3, 2, now)
+
+		// A stripe never takes more jobs than the counter
+		appErr := completeJobsFromWaitGroupStripeWithError(t, core, waitGroupId, 0, []string{"job_1", "job_2", "job_3", "job_4"}, false, deleteAt, now)
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		requireWaitGroupStripeNotFound(t, core, waitGroupId, 0)
+
+		// T+1m: Both stripes take jobs before either is aggregated, together
+		// past the counter
+		stripe0 := completeJobsFromWaitGroupStripe(t, core, waitGroupId, 0, []string{"job_1", "job_2"}, false, deleteAt, now.Add(time.Minute))
+		stripe1 := completeJobsFromWaitGroupStripe(t, core, waitGroupId, 1, []string{"job_3", "job_4"}, true, deleteAt, now.Add(time.Minute))
+
+		// Stripe 0 is aggregated, stripe 1 then no longer fits and is rejected
+		wg := aggregateWaitGroupStripes(t, core, waitGroupId, []*corepb.WaitGroupStripe{stripe0}, now.Add(time.Minute))
+		require.EqualValues(t, 2, wg.CompletedJobs)
+		appErr = aggregateWaitGroupStripesWithError(t, core, waitGroupId, []*corepb.WaitGroupStripe{stripe1}, now.Add(time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+
+		wg = getWaitGroup(t, core, waitGroupId)
+		require.EqualValues(t, 2, wg.CompletedJobs)
+		require.EqualValues(t, 0, wg.FailedJobs)
+		require.EqualValues(t, 0, wg.StripeCounts[1].FailedJobs)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_ACTIVE, wg.Status)
+
+		// T+2m: Stripe 1 now only has room left for one job
+		appErr = completeJobsFromWaitGroupStripeWithError(t, core, waitGroupId, 1, []string{"job_5"}, false, deleteAt, now.Add(2*time.Minute))
+		require.Equal(t, mrpc.InvalidRequest, appErr.Code)
+		require.EqualValues(t, 2, getWaitGroupStripe(t, core, waitGroupId, 1).FailedJobs)
+	})
+
+	t.Run("deleting returns the wait group with its stripes", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+		_ = createStripedWaitGroup(t, core, waitGroupId, "test_wait_group", 10, 3, now)
+
+		resp, err := core.DeleteWaitGroup(&coreapis.DeleteWaitGroupRequest{
+			Payload: &corepb.DeleteWaitGroupRequest{
+				NamespaceId:   namespaceId,
+				WaitGroupName: "test_wait_group",
+				RecordId:      rand.Uint64(),
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.NotNil(t, resp.Payload.WaitGroup)
+		require.EqualValues(t, 3, resp.Payload.WaitGroup.Stripes)
+
+		// Deleting it again returns nothing
+		resp, err = core.DeleteWaitGroup(&coreapis.DeleteWaitGroupRequest{
+			Payload: &corepb.DeleteWaitGroupRequest{
+				NamespaceId:   namespaceId,
+				WaitGroupName: "test_wait_group",
+				RecordId:      rand.Uint64(),
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.Nil(t, resp.Payload.WaitGroup)
+	})
+
+	t.Run("failed jobs fail the wait group", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		req := newStripedWaitGroupRequest(waitGroupId, "test_wait_group", 10, 2, now)
+		req.Payload.MaxFailedJobs = proto.Int64(0)
+		resp, err := core.CreateWaitGroup(req)
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+
+		stripe := completeJobsFromWaitGroupStripe(t, core, waitGroupId, 1, []string{"job_1"}, true, now.Add(2*time.Hour), now)
+		wg := aggregateWaitGroupStripes(t, core, waitGroupId, []*corepb.WaitGroupStripe{stripe}, now)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_FAILED, wg.Status)
+	})
+
+	t.Run("stripes cannot be combined with other job tracking", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		for _, modify := range []func(req *corepb.CreateWaitGroupRequest){
+			func(req *corepb.CreateWaitGroupRequest) { req.DeclaredJobs = true },
+			func(req *corepb.CreateWaitGroupRequest) { req.IntegerJobIds = true },
+			func(req *corepb.CreateWaitGroupRequest) {
+				req.Aggregations = []*corepb.WaitGroupAggregationSpec{
+					{MetadataKey: "bytes", Function: corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+				}
+			},
+		} {
+			req := newStripedWaitGroupRequest(newWaitGroupId(namespaceId), "test_wait_group", 10, 2, now)
+			modify(req.Payload)
+			resp, err := core.CreateWaitGroup(req)
+			require.NoError(t, err)
+			require.NotNil(t, resp.ApplicationError)
+			require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+		}
+
+		// A striped wait group cannot be a parent
+		_ = createStripedWaitGroup(t, core, newWaitGroupId(namespaceId), "parent", 10, 2, now)
+		resp, err := core.CreateWaitGroup(newChildWaitGroupRequest(newWaitGroupId(namespaceId), "child", 1, "parent", corepb.WaitGroupChildFailurePolicy_WAIT_GROUP_CHILD_FAILURE_POLICY_REPORT_FAILED, now.Add(time.Hour), now))
+		require.NoError(t, err)
+		require.NotNil(t, resp.ApplicationError)
+		require.Equal(t, mrpc.InvalidRequest, resp.ApplicationError.Code)
+	})
+
+	t.Run("aggregate rejects unknown wait groups and stripes", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+
+		stripedId := newWaitGroupId(namespaceId)
+		_ = createStripedWaitGroup(t, core, stripedId, "striped", 10, 2, now)
+		unstripedId := newWaitGroupId(namespaceId)
+		_ = createWaitGroup(t, core, unstripedId, "unstriped", 10, 100, now.Add(time.Hour), now)
+
+		for _, tc := range []struct {
+			waitGroupId *corepb.WaitGroupId
+			stripe      uint32
+			code        mrpc.ErrorCode
+		}{
+			{newWaitGroupId(namespaceId), 0, mrpc.NotFound},
+			{unstripedId, 0, mrpc.InvalidRequest},
+			{stripedId, 2, mrpc.InvalidRequest},
+		} {
+			resp, err := core.AggregateWaitGroupStripes(&coreapis.AggregateWaitGroupStripesRequest{
+				Payload: &corepb.AggregateWaitGroupStripesRequest{
+					WaitGroupId:  tc.waitGroupId,
+					StripeCounts: []*corepb.WaitGroupStripeCounts{{Stripe: tc.stripe, CompletedJobs: 1}},
+				},
+				Now: now.UnixNano(),
+			})
+			require.NoError(t, err)
+			require.NotNil(t, resp.ApplicationError)
+			require.Equal(t, tc.code, resp.ApplicationError.Code)
+		}
+		require.EqualValues(t, 0, getWaitGroup(t, core, stripedId).CompletedJobs)
+	})
+
+	t.Run("stripes are garbage collected after their retention", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: Complete jobs on two stripes, to be deleted at T+1h
+		_ = createStripedWaitGroup(t, core, waitGroupId, "test_wait_group", 10, 2, now)
+		_ = completeJobsFromWaitGroupStripe(t, core, waitGroupId, 0, []string{"job_1", "job_2"}, false, now.Add(time.Hour), now)
+		_ = completeJobsFromWaitGroupStripe(t, core, waitGroupId, 1, []string{"job_3"}, false, now.Add(time.Hour), now)
+
+		// Stripe 0 is extended to T+2h, an earlier deletion time is ignored
+		for _, deleteAt := range []time.Time{now.Add(2 * time.Hour), now.Add(30 * time.Minute)} {
+			resp, err := core.ExtendWaitGroupStripe(&coreapis.ExtendWaitGroupStripeRequest{
+				Payload: &corepb.ExtendWaitGroupStripeRequest{
+					StripeId: newStripeId(waitGroupId, 0),
+					DeleteAt: deleteAt.UnixNano(),
+				},
+				Now: now.UnixNano(),
+			})
+			require.NoError(t, err)
+			require.Nil(t, resp.ApplicationError)
+		}
+		require.Equal(t, now.Add(2*time.Hour).UnixNano(), getWaitGroupStripe(t, core, waitGroupId, 0).DeleteAt)
+
+		// T+90m: Only stripe 1 is deleted
+		runWaitGroupsGC(t, core, now.Add(90*time.Minute))
+		require.EqualValues(t, 2, getWaitGroupStripe(t, core, waitGroupId, 0).CompletedJobs)
+		requireWaitGroupStripeNotFound(t, core, waitGroupId, 1)
+		require.Len(t, listWaitGroupStripeCompletedJobs(t, core, waitGroupId, 1), 0)
+
+		// Deleting stripe 0 makes it go on the next tick
+		resp, err := core.DeleteWaitGroupStripe(&coreapis.DeleteWaitGroupStripeRequest{
+			Payload: &corepb.DeleteWaitGroupStripeRequest{
+				StripeId: newStripeId(waitGroupId, 0),
+			},
+			Now: now.Add(90 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+
+		runWaitGroupsGC(t, core, now.Add(90*time.Minute))
+		requireWaitGroupStripeNotFound(t, core, waitGroupId, 0)
+		require.Len(t, listWaitGroupStripeCompletedJobs(t, core, waitGroupId, 0), 0)
+
+		// No deletion records are left behind
+		txn := core.badgerStore.View()
+		defer txn.Discard()
+		err = core.stripeDeletionRecords.ListByDeletion(txn, 0, math.MaxInt64, func(record *corepb.WaitGroupStripesDeletionRecord) (bool, error) {
+			require.Fail(t, "unexpected stripe deletion record")
+			return true, nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestCore_SnapshotAndRestore(t *testing.T) {
+	now := time.Now()
+	waitGroupId := &corepb.WaitGroupId{
+		AccountId:   rand.Uint64(),
+		NamespaceId: rand.Uint64(),
+		WaitGroupId: rand.Uint64(),
+	}
+
+	// Create two wait group cores for testing snapshot and restore
+	core1 := newWaitGroupsCore(t)
+	core2 := newWaitGroupsCore(t)
+
+	// T+0: Create wait group
+	resp1, err := core1.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+		Payload: &corepb.CreateWaitGroupRequest{
+			WaitGroupId:                       waitGroupId,
+			Name:                              "test_wait_group",
+			Description:                       "test description",
+			Counter:                           10,
+			ExpiresAt:                         now.Add(time.Hour).UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: 100,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp1)
+	require.Nil(t, resp1.ApplicationError)
+	require.NotNil(t, resp1.Payload)
+
+	// T+1m: Complete some jobs
+	resp2, err := core1.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId: &corepb.NamespaceId{
+				AccountId:   waitGroupId.AccountId,
+				NamespaceId: waitGroupId.NamespaceId,
+			},
+			WaitGroupName: "test_wait_group",
+			Jobs:          completeJobRequests([]string{"job_1", "job_2", "job_3"}),
+		},
+		Now: now.Add(time.Minute).UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp2)
+	require.Nil(t, resp2.ApplicationError)
+	require.NotNil(t, resp2.Payload)
+
+	// Take snapshot at this point
+	snapshot := core1.Snapshot()
+
+	// T+2m: Complete more jobs (after snapshot)
+	resp3, err := core1.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId: &corepb.NamespaceId{
+				AccountId:   waitGroupId.AccountId,
+				NamespaceId: waitGroupId.NamespaceId,
+			},
+			WaitGroupName: "test_wait_group",
+			Jobs:          completeJobRequests([]string{"job_4", "job_5"}),
+		},
+		Now: now.Add(2 * time.Minute).UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp3)
+	require.Nil(t, resp3.ApplicationError)
+	require.NotNil(t, resp3.Payload)
+
+	// Write snapshot to buffer
+	buf := bytes.NewBuffer(nil)
+	err = snapshot.Write(buf)
+	require.NoError(t, err)
+
+	// Restore snapshot to second core
+	err = core2.Restore(io.NopCloser(buf))
+	require.NoError(t, err)
+
+	// T+4m: Check that the restored state matches the snapshot state
+	// The wait group should exist with 3 completed jobs out of 10 total
+	resp5, err := core2.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupId,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp5)
+	require.Nil(t, resp5.ApplicationError)
+	require.NotNil(t, resp5.Payload)
+	require.NotNil(t, resp5.Payload.WaitGroup)
+	require.EqualValues(t, 10, resp5.Payload.WaitGroup.Counter)
+	require.EqualValues(t, 3, resp5.Payload.WaitGroup.CompletedJobs)
+
+	// T+5m: Complete more jobs in restored state
+	resp6, err := core2.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId: &corepb.NamespaceId{
+				AccountId:   waitGroupId.AccountId,
+				NamespaceId: waitGroupId.NamespaceId,
+			},
+			WaitGroupName: "test_wait_group",
+			Jobs:          completeJobRequests([]string{"job_6", "job_7"}),
+		},
+		Now: now.Add(5 * time.Minute).UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp6)
+	require.Nil(t, resp6.ApplicationError)
+	require.NotNil(t, resp6.Payload)
+
+	// T+6m: Verify state in restored core
+	resp7, err := core2.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupId,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp7)
+	require.Nil(t, resp7.ApplicationError)
+	require.NotNil(t, resp7.Payload)
+	require.EqualValues(t, 10, resp7.Payload.WaitGroup.Counter)
+	require.EqualValues(t, 5, resp7.Payload.WaitGroup.CompletedJobs)
+
+	// T+7m: Complete more jobs in restored state
+	resp8, err := core2.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId: &corepb.NamespaceId{
+				AccountId:   waitGroupId.AccountId,
+				NamespaceId: waitGroupId.NamespaceId,
+			},
+			WaitGroupName: "test_wait_group",
+			Jobs:          completeJobRequests([]string{"job_8", "job_9"}),
+		},
+		Now: now.Add(8 * time.Minute).UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp8)
+	require.Nil(t, resp8.ApplicationError)
+	require.NotNil(t, resp8.Payload)
+
+	// T+8m: Verify final state in restored core
+	resp9, err := core2.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupId,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp9)
+	require.Nil(t, resp9.ApplicationError)
+	require.NotNil(t, resp9.Payload)
+	require.EqualValues(t, 10, resp9.Payload.WaitGroup.Counter)
+	require.EqualValues(t, 7, resp9.Payload.WaitGroup.CompletedJobs)
+
+	// Verify that the original core has different state (it should have more completed jobs)
+	resp10, err := core1.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupId,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp10)
+	require.Nil(t, resp10.ApplicationError)
+	require.NotNil(t, resp10.Payload)
+	require.EqualValues(t, 10, resp10.Payload.WaitGroup.Counter)      // 10 + 5 added after snapshot
+	require.EqualValues(t, 5, resp10.Payload.WaitGroup.CompletedJobs) // 3 + 2 completed after snapshot
+}
+
+func TestCore_RunWaitGroupsGarbageCollection(t *testing.T) {
+	core := newWaitGroupsCore(t)
+	now := time.Now()
+
+	// Test parameters - use smaller limits to force multiple runs
+	maxDeletedObjects := int64(1000)
+	gcRecordsPageSize := int64(100)
+	gcRecordWaitGroupsPageSize := int64(1000)
+
+	// Create test data for account 1
+	accountId1 := rand.Uint64()
+	namespaceId1 := &corepb.NamespaceId{
+		AccountId:   accountId1,
+		NamespaceId: rand.Uint64(),
+	}
+
+	// Create multiple wait groups for account 1 with many jobs each
+	numWaitGroups := 3
+	jobsPerGroup := 500 // 1500 total jobs, exceeding MaxDeletedObjects
+	waitGroupIds := make([]*corepb.WaitGroupId, numWaitGroups)
+
+	for i := range numWaitGroups {
+		waitGroupName := fmt.Sprintf("test_wait_group_%d", i)
+		waitGroupIds[i] = &corepb.WaitGroupId{
+			AccountId:   namespaceId1.AccountId,
+			NamespaceId: namespaceId1.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
+
+		// Create wait group with many jobs
+		resp1, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+			Payload: &corepb.CreateWaitGroupRequest{
+				WaitGroupId:                       waitGroupIds[i],
+				Name:                              waitGroupName,
+				Description:                       fmt.Sprintf("test description %d", i),
+				Counter:                           int64(jobsPerGroup),
+				ExpiresAt:                         now.Add(time.Hour).UnixNano(),
+				MaxNumberOfWaitGroupsPerNamespace: 100,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp1)
+		require.Nil(t, resp1.ApplicationError)
+		require.NotNil(t, resp1.Payload)
+
+		// Complete all jobs to create job records
+		jobIds := make([]string, jobsPerGroup)
+		for j := range jobsPerGroup {
+			jobIds[j] = fmt.Sprintf("job_%d_%d", i, j)
+		}
+
+		resp2, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+			Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+				NamespaceId: &corepb.NamespaceId{
+					AccountId:   waitGroupIds[i].AccountId,
+					NamespaceId: waitGroupIds[i].NamespaceId,
+				},
+				WaitGroupName: waitGroupName,
+				Jobs:          completeJobRequests(jobIds),
+			},
+			Now: now.Add(time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp2)
+		require.Nil(t, resp2.ApplicationError)
+		require.NotNil(t, resp2.Payload)
+	}
+
+	// Create test data for account 2 with multiple namespaces
+	accountId2 := rand.Uint64()
+	numNamespaces := 2
+	waitGroupsPerNamespace := 3
+	jobsPerWaitGroup := 400 // 1200 jobs per namespace
+	namespaceIds := make([]*corepb.NamespaceId, numNamespaces)
+
+	for ns := range numNamespaces {
+		namespaceIds[ns] = &corepb.NamespaceId{
+			AccountId:   accountId2,
+			NamespaceId: rand.Uint64(),
+		}
+
+		// Create multiple wait groups for this namespace
+		for wg := range waitGroupsPerNamespace {
+			waitGroupName := fmt.Sprintf("test_wait_group_%d", wg)
+			waitGroupId := &corepb.WaitGroupId{
+				AccountId:   namespaceIds[ns].AccountId,
+				NamespaceId: namespaceIds[ns].NamespaceId,
+				WaitGroupId: uint64(wg),
+			}
+
+			// Create wait group with many jobs
+			resp3, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+				Payload: &corepb.CreateWaitGroupRequest{
+					WaitGroupId:                       waitGroupId,
+					Name:                              waitGroupName,
+					Description:                       fmt.Sprintf("test description namespace %d wg %d", ns, wg),
+					Counter:                           int64(jobsPerWaitGroup),
+					ExpiresAt:                         now.Add(time.Hour).UnixNano(),
+					MaxNumberOfWaitGroupsPerNamespace: 100,
+				},
+				Now: now.UnixNano(),
+			})
+			require.NoError(t, err)
+			require.NotNil(t, resp3)
+			require.Nil(t, resp3.ApplicationError)
+			require.NotNil(t, resp3.Payload)
+
+			// Complete all jobs to create job records
+			jobIds := make([]string, jobsPerWaitGroup)
+			for j := range jobsPerWaitGroup {
+				jobIds[j] = fmt.Sprintf("job_ns%d_wg%d_%d", ns, wg, j)
+			}
+
+			resp4, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+				Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+					NamespaceId: &corepb.NamespaceId{
+						AccountId:   waitGroupId.AccountId,
+						NamespaceId: waitGroupId.NamespaceId,
+					},
+					WaitGroupName: waitGroupName,
+					Jobs:          completeJobRequests(jobIds),
+				},
+				Now: now.Add(time.Minute).UnixNano(),
+			})
+			require.NoError(t, err)
+			require.NotNil(t, resp4)
+			require.Nil(t, resp4.ApplicationError)
+			require.NotNil(t, resp4.Payload)
+		}
+	}
+
+	// Delete one wait group from account 1 (this will create a GC record for individual wait group)
+	resp5, err := core.DeleteWaitGroup(&coreapis.DeleteWaitGroupRequest{
+		Payload: &corepb.DeleteWaitGroupRequest{
+			RecordId: rand.Uint64(),
+			NamespaceId: &corepb.NamespaceId{
+				AccountId:   waitGroupIds[0].AccountId,
+				NamespaceId: waitGroupIds[0].NamespaceId,
+			},
+			WaitGroupName: "test_wait_group_0",
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp5)
+	require.Nil(t, resp5.ApplicationError)
+	require.NotNil(t, resp5.Payload)
+
+	// Delete one namespace from account 2 (this will create a GC record for entire namespace)
+	namespaceToDelete := namespaceIds[1]
+
+	resp6, err := core.WaitGroupsDeleteNamespace(&coreapis.WaitGroupsDeleteNamespaceRequest{
+		Payload: &corepb.WaitGroupsDeleteNamespaceRequest{
+			RecordId:    rand.Uint64(),
+			NamespaceId: namespaceToDelete,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp6)
+	require.Nil(t, resp6.ApplicationError)
+	require.NotNil(t, resp6.Payload)
+
+	// Run garbage collection multiple times to process all objects
+	// The first run should process some objects but not all due to MaxDeletedObjects limit
+	runCount := 0
+	maxRuns := 10 // Prevent infinite loop
+
+	for runCount < maxRuns {
+		runCount++
+		t.Logf("Running garbage collection iteration %d", runCount)
+
+		resp7, err := core.RunWaitGroupsGarbageCollection(&coreapis.RunWaitGroupsGarbageCollectionRequest{
+			Payload: &corepb.RunWaitGroupsGarbageCollectionRequest{
+				GcRecordsPageSize:          gcRecordsPageSize,
+				GcRecordWaitGroupsPageSize: gcRecordWaitGroupsPageSize,
+				MaxDeletedObjects:          maxDeletedObjects,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp7)
+		require.Nil(t, resp7.ApplicationError)
+		require.NotNil(t, resp7.Payload)
+
+		// Check if both expected deletions have been completed
+		deletedWaitGroupAccessible := true
+		deletedNamespaceAccessible := true
+
+		// Check if the deleted wait group is still accessible
+		resp8, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+			Payload: &corepb.GetWaitGroupRequest{
+				WaitGroupId: waitGroupIds[0],
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp8)
+
+		if resp8.ApplicationError != nil && resp8.ApplicationError.Code == mrpc.NotFound {
+			deletedWaitGroupAccessible = false
+			t.Logf("Deleted wait group is no longer accessible after run %d", runCount)
+		}
+
+		// Check if the deleted namespace's wait groups are still accessible
+		deletedNamespaceWaitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceToDelete.AccountId,
+			NamespaceId: namespaceToDelete.NamespaceId,
+			WaitGroupId: 0,
+		}
+		resp9, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+			Payload: &corepb.GetWaitGroupRequest{
+				WaitGroupId: deletedNamespaceWaitGroupId,
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp8)
+
+		if resp9.ApplicationError != nil && resp9.ApplicationError.Code == mrpc.NotFound {
+			deletedNamespaceAccessible = false
+			t.Logf("Deleted namespace is no longer accessible after run %d", runCount)
+		}
+
+		// If both deletions are complete, we can stop
+		if !deletedWaitGroupAccessible && !deletedNamespaceAccessible {
 			t.Logf("Both deletions completed after %d runs", runCount)
 			break
 		}
 	}
 
-	require.Less(t, runCount, maxRuns, "Garbage collection did not complete within expected number of runs")
+	require.Less(t, runCount, maxRuns, "Garbage collection did not complete within expected number of runs")
+
+	// Verify that the deleted wait group is no longer accessible
+	resp10, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupIds[0],
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp10)
+	require.NotNil(t, resp10.ApplicationError)
+	require.Equal(t, mrpc.NotFound, resp10.ApplicationError.Code)
+
+	// Verify that the deleted namespace's wait groups are no longer accessible
+	resp11, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: &corepb.WaitGroupId{
+				AccountId:   namespaceToDelete.AccountId,
+				NamespaceId: namespaceToDelete.NamespaceId,
+				WaitGroupId: 0,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp11)
+	require.NotNil(t, resp11.ApplicationError)
+	require.Equal(t, mrpc.NotFound, resp11.ApplicationError.Code)
+
+	// Verify that other wait groups from account 1 are still accessible
+	resp12, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupIds[1],
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp12)
+	require.Nil(t, resp12.ApplicationError)
+	require.NotNil(t, resp12.Payload)
+
+	// Verify that the non-deleted namespace from account 2 is still accessible
+	// namespaceIds[0] should still be accessible (only namespaceIds[1] was deleted)
+	remainingNamespaceWaitGroupId := &corepb.WaitGroupId{
+		AccountId:   namespaceIds[0].AccountId,
+		NamespaceId: namespaceIds[0].NamespaceId,
+		WaitGroupId: 0, // First wait group in that namespace
+	}
+	resp13, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: remainingNamespaceWaitGroupId,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp13)
+	require.Nil(t, resp13.ApplicationError)
+	require.NotNil(t, resp13.Payload)
+
+	t.Logf("Garbage collection completed successfully in %d runs", runCount)
+}
+
+func TestCore_DeleteAfterFinished(t *testing.T) {
+	t.Run("completed wait group is marked completed and deleted after retention", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		deleteAfterSeconds := int64(600) // 10 minutes
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
+
+		_, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+			Payload: &corepb.CreateWaitGroupRequest{
+				WaitGroupId:                       waitGroupId,
+				Name:                              "test_wait_group",
+				Description:                       "desc",
+				Counter:                           2,
+				ExpiresAt:                         now.Add(time.Hour).UnixNano(),
+				MaxNumberOfWaitGroupsPerNamespace: 100,
+				DeleteAfterFinishedSeconds:        deleteAfterSeconds,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+
+		// Completing all jobs finishes the wait group as COMPLETED.
+		finishedAt := now.Add(time.Minute)
+		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, finishedAt)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Equal(t, finishedAt.UnixNano(), wg.FinishedAt)
+		require.EqualValues(t, 2, wg.CompletedJobs)
+
+		// GC before the retention period elapses must not delete the wait group.
+		runWaitGroupsGC(t, core, finishedAt.Add(5*time.Minute))
+		wg = getWaitGroup(t, core, waitGroupId)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+
+		// GC after the retention period deletes the wait group and its jobs.
+		runWaitGroupsGC(t, core, finishedAt.Add(time.Duration(deleteAfterSeconds)*time.Second).Add(time.Minute))
+		requireWaitGroupNotFound(t, core, waitGroupId)
+	})
+
+	t.Run("expired wait group is marked expired then deleted after retention", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		deleteAfterSeconds := int64(600) // 10 minutes
+		expiresAt := now.Add(time.Hour)
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
+
+		_, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+			Payload: &corepb.CreateWaitGroupRequest{
+				WaitGroupId:                       waitGroupId,
+				Name:                              "test_wait_group",
+				Description:                       "desc",
+				Counter:                           5,
+				ExpiresAt:                         expiresAt.UnixNano(),
+				MaxNumberOfWaitGroupsPerNamespace: 100,
+				DeleteAfterFinishedSeconds:        deleteAfterSeconds,
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+
+		// Only partially complete it so it never reaches its counter.
+		completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(time.Minute))
+
+		// GC after expires_at but before the retention period marks the wait group
+		// EXPIRED without deleting it.
+		runWaitGroupsGC(t, core, expiresAt.Add(5*time.Minute))
+		wg := getWaitGroup(t, core, waitGroupId)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED, wg.Status)
+		require.Equal(t, expiresAt.UnixNano(), wg.FinishedAt)
+
+		// GC after the retention period (finished_at + delete_after) deletes it.
+		runWaitGroupsGC(t, core, expiresAt.Add(time.Duration(deleteAfterSeconds)*time.Second).Add(time.Minute))
+		requireWaitGroupNotFound(t, core, waitGroupId)
+	})
+
+	t.Run("expires_at no longer deletes directly", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		expiresAt := now.Add(time.Hour)
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
+
+		_, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+			Payload: &corepb.CreateWaitGroupRequest{
+				WaitGroupId:                       waitGroupId,
+				Name:                              "test_wait_group",
+				Description:                       "desc",
+				Counter:                           5,
+				ExpiresAt:                         expiresAt.UnixNano(),
+				MaxNumberOfWaitGroupsPerNamespace: 100,
+				// Large retention: even though the wait group expires, it stays
+				// around long after expires_at.
+				DeleteAfterFinishedSeconds: int64((24 * time.Hour).Seconds()),
+			},
+			Now: now.UnixNano(),
+		})
+		require.NoError(t, err)
+
+		// Way past expires_at, but well within the retention window.
+		runWaitGroupsGC(t, core, expiresAt.Add(time.Hour))
+		wg := getWaitGroup(t, core, waitGroupId)
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED, wg.Status)
+	})
+}
+
+// runWaitGroupsGC runs a garbage collection pass at the given wall-clock time
+// with generous page sizes.
+func runWaitGroupsGC(t *testing.T, core *Core, now time.Time) {
+	t.Helper()
+
+	resp, err := core.RunWaitGroupsGarbageCollection(&coreapis.RunWaitGroupsGarbageCollectionRequest{
+		Payload: &corepb.RunWaitGroupsGarbageCollectionRequest{
+			GcRecordsPageSize:          100,
+			GcRecordWaitGroupsPageSize: 1000,
+			MaxDeletedObjects:          1000,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+}
+
+// requireWaitGroupNotFound asserts that the wait group no longer exists.
+func requireWaitGroupNotFound(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId) {
+	t.Helper()
+
+	resp, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupId,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotNil(t, resp.ApplicationError)
+	require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+}
+
+func TestCore_LastActivityAt(t *testing.T) {
+	t.Run("create sets it, completing jobs updates it, update does not", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   namespaceId.AccountId,
+			NamespaceId: namespaceId.NamespaceId,
+			WaitGroupId: rand.Uint64(),
+		}
+
+		// Create records the creation time as the initial activity.
+		wg := createWaitGroup(t, core, waitGroupId, "test_wait_group", 10, 100, now.Add(time.Hour), now)
+		require.Equal(t, now.UnixNano(), wg.LastActivityAt)
+
+		// Update* must NOT touch last_activity_at.
+		updateResp, err := core.UpdateWaitGroup(&coreapis.UpdateWaitGroupRequest{
+			Payload: &corepb.UpdateWaitGroupRequest{
+				NamespaceId:     namespaceId,
+				WaitGroupName:   "test_wait_group",
+				Description:     "new description",
+				Counter:         10,
+				ExpiresAt:       now.Add(2 * time.Hour).UnixNano(),
+				ExpectedVersion: 1,
+			},
+			Now: now.Add(time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, updateResp.ApplicationError)
+		require.Equal(t, now.UnixNano(), updateResp.Payload.WaitGroup.LastActivityAt)
+
+		// Reporting a completed job is activity and updates the timestamp.
+		completeTime := now.Add(2 * time.Minute)
+		wg2 := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, completeTime)
+		require.Equal(t, completeTime.UnixNano(), wg2.LastActivityAt)
+	})
+}
+
+func TestCore_CompletionRate(t *testing.T) {
+	t.Run("estimate rate and completion time", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+0: No jobs finished yet, so nothing to estimate
+		wg := createWaitGroup(t, core, waitGroupId, "test_wait_group", 1000, 100, now.Add(24*time.Hour), now)
+		require.Zero(t, wg.CompletionRate)
+		require.Zero(t, wg.EstimatedCompletionAt)
+
+		// T+10s: Already the first batch estimates half a job per second
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", integerJobIds(0, 5), now.Add(10*time.Second))
+		require.InDelta(t, 0.5, wg.CompletionRate, 0.025)
+
+		// T+20m: 5 jobs every 10 seconds, 600 in total
+		var at time.Time
+		for i := 1; i < 120; i++ {
+			at = now.Add(time.Duration(i+1) * 10 * time.Second)
+			wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", integerJobIds(i*5, i*5+5), at)
+		}
+		require.InDelta(t, 0.5, wg.CompletionRate, 0.025)
+		require.Equal(t, at.UnixNano(), wg.CompletionRateUpdatedAt)
+		require.InDelta(t, at.Add(800*time.Second).UnixNano(), wg.EstimatedCompletionAt, float64(40*time.Second))
+
+		// T+30m: Ten minutes without new jobs slow the rate down
+		at = now.Add(30 * time.Minute)
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"0"}, at)
+		require.Less(t, wg.CompletionRate, 0.1)
+		estimate := wg.EstimatedCompletionAt
+		require.Greater(t, estimate, at.Add(time.Hour).UnixNano())
+
+		// A lower counter brings the estimate forward
+		wg = addToWaitGroup(t, core, namespaceId, "test_wait_group", -200, at)
+		require.Less(t, wg.EstimatedCompletionAt, estimate)
+
+		// A finished wait group has no estimate
+		wg = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", integerJobIds(600, 800), at.Add(time.Minute))
+		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+		require.Greater(t, wg.CompletionRate, 0.0)
+		require.Zero(t, wg.EstimatedCompletionAt)
+	})
+
+	t.Run("reads decay the rate to now", func(t *testing.T) {
+		core := newWaitGroupsCore(t)
+		now := time.Now()
+		namespaceId := &corepb.NamespaceId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+		}
+		waitGroupId := newWaitGroupId(namespaceId)
+
+		// T+10m: 5 jobs every 10 seconds, 300 in total
+		_ = createWaitGroup(t, core, waitGroupId, "test_wait_group", 1000, 100, now.Add(24*time.Hour), now)
+		var at time.Time
+		for i := 0; i < 60; i++ {
+			at = now.Add(time.Duration(i+1) * 10 * time.Second)
+			_ = completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", integerJobIds(i*5, i*5+5), at)
+		}
+
+		// Reading right away matches the rate as of the last completion
+		wg := getWaitGroupAt(t, core, waitGroupId, at)
+		require.InDelta(t, 0.5, wg.CompletionRate, 0.025)
+		estimate := wg.EstimatedCompletionAt
+
+		// T+20m: Ten minutes without new jobs slow the rate down and push the
+		// estimate back, by id and by name
+		wg = getWaitGroupAt(t, core, waitGroupId, at.Add(10*time.Minute))
+		require.Less(t, wg.CompletionRate, 0.1)
+		require.Greater(t, wg.EstimatedCompletionAt, estimate)
+
+		resp, err := core.GetWaitGroupByName(&coreapis.GetWaitGroupByNameRequest{
+			Payload: &corepb.GetWaitGroupByNameRequest{
+				NamespaceId:   namespaceId,
+				WaitGroupName: "test_wait_group",
+			},
+			Now: at.Add(10 * time.Minute).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.ApplicationError)
+		require.Equal(t, wg.CompletionRate, resp.Payload.WaitGroup.CompletionRate)
+		require.Equal(t, wg.EstimatedCompletionAt, resp.Payload.WaitGroup.EstimatedCompletionAt)
+
+		// Reads do not persist the decayed rate
+		wg = getWaitGroupAt(t, core, waitGroupId, at)
+		require.InDelta(t, 0.5, wg.CompletionRate, 0.025)
+		require.Equal(t, estimate, wg.EstimatedCompletionAt)
+	})
+}
+
+// completeJobRequests builds a slice of CompleteJobRequest from plain job ids
+// (without metadata), which is the common case in these tests.
+func completeJobRequests(jobIds []string) []*corepb.CompleteJobRequest {
+	jobs := make([]*corepb.CompleteJobRequest, len(jobIds))
+	for i, jobId := range jobIds {
+		jobs[i] = &corepb.CompleteJobRequest{JobId: jobId}
+	}
+	return jobs
+}
+
+func newWaitGroupsCore(t *testing.T) *Core {
+	store, err := store.NewBadgerInMemoryStore()
+	require.NoError(t, err)
+	return NewCore(store, []byte{0x1d, 0x36, 0x00, 0x00}, 0x00000000, 0xffffffff)
+}
+
+func createWaitGroup(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, maxNumberOfWaitGroupsPerNamespace int64, expiresAt time.Time, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+		Payload: &corepb.CreateWaitGroupRequest{
+			WaitGroupId:                       waitGroupId,
+			Name:                              name,
+			Description:                       "test description",
+			Counter:                           counter,
+			ExpiresAt:                         expiresAt.UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: maxNumberOfWaitGroupsPerNamespace,
+			DeleteAfterFinishedSeconds:        3600,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+func createWaitGroupWithMaxFailedJobs(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, maxFailedJobs int64, expiresAt time.Time, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+		Payload: &corepb.CreateWaitGroupRequest{
+			WaitGroupId:                       waitGroupId,
+			Name:                              name,
+			Description:                       "test description",
+			Counter:                           counter,
+			ExpiresAt:                         expiresAt.UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: 100,
+			DeleteAfterFinishedSeconds:        3600,
+			MaxFailedJobs:                     proto.Int64(maxFailedJobs),
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+func createWaitGroupWithError(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, maxNumberOfWaitGroupsPerNamespace int64, expiresAt time.Time, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+		Payload: &corepb.CreateWaitGroupRequest{
+			WaitGroupId:                       waitGroupId,
+			Name:                              name,
+			Description:                       "test description",
+			Counter:                           counter,
+			ExpiresAt:                         expiresAt.UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: maxNumberOfWaitGroupsPerNamespace,
+			DeleteAfterFinishedSeconds:        3600,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+	return resp.ApplicationError
+}
+
+func completeJobsFromWaitGroup(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			Jobs:          completeJobRequests(jobIds),
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+func failJobsFromWaitGroup(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	jobs := completeJobRequests(jobIds)
+	for _, job := range jobs {
+		job.Failed = true
+	}
+
+	resp, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			Jobs:          jobs,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+func completeJobsFromWaitGroupWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			Jobs:          completeJobRequests(jobIds),
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+	return resp.ApplicationError
+}
+
+func getWaitGroup(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+		Payload: &corepb.GetWaitGroupRequest{
+			WaitGroupId: waitGroupId,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+func getWaitGroupAt(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, now time.Time) *corepb.WaitGroup {
+	t.Helper()
 
-	// Verify that the deleted wait group is no longer accessible
-	resp10, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
+	resp, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
 		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupIds[0],
+			WaitGroupId: waitGroupId,
 		},
+		Now: now.UnixNano(),
 	})
 	require.NoError(t, err)
-	require.NotNil(t, resp10)
-	require.NotNil(t, resp10.ApplicationError)
-	require.Equal(t, mrpc.NotFound, resp10.ApplicationError.Code)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
 
-	// Verify that the deleted namespace's wait groups are no longer accessible
-	resp11, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: &corepb.WaitGroupId{
-				AccountId:   namespaceToDelete.AccountId,
-				NamespaceId: namespaceToDelete.NamespaceId,
-				WaitGroupId: 0,
-			},
+func ListWaitGroupCompletedJobs(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string) *corepb.ListWaitGroupCompletedJobsResponse {
+	t.Helper()
+
+	resp, err := core.ListWaitGroupCompletedJobs(&coreapis.ListWaitGroupCompletedJobsRequest{
+		Payload: &corepb.ListWaitGroupCompletedJobsRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
 		},
 	})
 	require.NoError(t, err)
-	require.NotNil(t, resp11)
-	require.NotNil(t, resp11.ApplicationError)
-	require.Equal(t, mrpc.NotFound, resp11.ApplicationError.Code)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	return resp.Payload
+}
 
-	// Verify that other wait groups from account 1 are still accessible
-	resp12, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupIds[1],
+func createWaitGroupWithDeclaredJobs(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, expiresAt time.Time, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+		Payload: &corepb.CreateWaitGroupRequest{
+			WaitGroupId:                       waitGroupId,
+			Name:                              name,
+			Description:                       "test description",
+			Counter:                           counter,
+			ExpiresAt:                         expiresAt.UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: 100,
+			DeleteAfterFinishedSeconds:        3600,
+			DeclaredJobs:                      true,
 		},
+		Now: now.UnixNano(),
 	})
 	require.NoError(t, err)
-	require.NotNil(t, resp12)
-	require.Nil(t, resp12.ApplicationError)
-	require.NotNil(t, resp12.Payload)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
 
-	// Verify that the non-deleted namespace from account 2 is still accessible
-	// namespaceIds[0] should still be accessible (only namespaceIds[1] was deleted)
-	remainingNamespaceWaitGroupId := &corepb.WaitGroupId{
-		AccountId:   namespaceIds[0].AccountId,
-		NamespaceId: namespaceIds[0].NamespaceId,
-		WaitGroupId: 0, // First wait group in that namespace
-	}
-	resp13, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: remainingNamespaceWaitGroupId,
+func addJobsToWaitGroup(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.AddJobsToWaitGroup(&coreapis.AddJobsToWaitGroupRequest{
+		Payload: &corepb.AddJobsToWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			JobIds:        jobIds,
 		},
+		Now: now.UnixNano(),
 	})
 	require.NoError(t, err)
-	require.NotNil(t, resp13)
-	require.Nil(t, resp13.ApplicationError)
-	require.NotNil(t, resp13.Payload)
-
-	t.Logf("Garbage collection completed successfully in %d runs", runCount)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
 }
 
-func TestCore_DeleteAfterFinished(t *testing.T) {
-	t.Run("completed wait group is marked completed and deleted after retention", func(t *testing.T) {
-		core := newWaitGroupsCore(t)
-		now := time.Now()
-		deleteAfterSeconds := int64(600) // 10 minutes
-		namespaceId := &corepb.NamespaceId{
-			AccountId:   rand.Uint64(),
-			NamespaceId: rand.Uint64(),
-		}
-		waitGroupId := &corepb.WaitGroupId{
-			AccountId:   namespaceId.AccountId,
-			NamespaceId: namespaceId.NamespaceId,
-			WaitGroupId: rand.Uint64(),
-		}
-
-		_, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
-			Payload: &corepb.CreateWaitGroupRequest{
-				WaitGroupId:                       waitGroupId,
-				Name:                              "test_wait_group",
-				Description:                       "desc",
-				Counter:                           2,
-				ExpiresAt:                         now.Add(time.Hour).UnixNano(),
-				MaxNumberOfWaitGroupsPerNamespace: 100,
-				DeleteAfterFinishedSeconds:        deleteAfterSeconds,
-			},
-			Now: now.UnixNano(),
-		})
-		require.NoError(t, err)
+func addJobsToWaitGroupWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, now time.Time) *mrpc.Error {
+	t.Helper()
 
-		// Completing all jobs finishes the wait group as COMPLETED.
-		finishedAt := now.Add(time.Minute)
-		wg := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1", "job_2"}, finishedAt)
-		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
-		require.Equal(t, finishedAt.UnixNano(), wg.FinishedAt)
-		require.EqualValues(t, 2, wg.CompletedJobs)
+	resp, err := core.AddJobsToWaitGroup(&coreapis.AddJobsToWaitGroupRequest{
+		Payload: &corepb.AddJobsToWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			JobIds:        jobIds,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+	return resp.ApplicationError
+}
 
-		// GC before the retention period elapses must not delete the wait group.
-		runWaitGroupsGC(t, core, finishedAt.Add(5*time.Minute))
-		wg = getWaitGroup(t, core, waitGroupId)
-		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_COMPLETED, wg.Status)
+func listWaitGroupPendingJobs(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string) *corepb.ListWaitGroupPendingJobsResponse {
+	t.Helper()
 
-		// GC after the retention period deletes the wait group and its jobs.
-		runWaitGroupsGC(t, core, finishedAt.Add(time.Duration(deleteAfterSeconds)*time.Second).Add(time.Minute))
-		requireWaitGroupNotFound(t, core, waitGroupId)
+	resp, err := core.ListWaitGroupPendingJobs(&coreapis.ListWaitGroupPendingJobsRequest{
+		Payload: &corepb.ListWaitGroupPendingJobsRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+		},
 	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	return resp.Payload
+}
 
-	t.Run("expired wait group is marked expired then deleted after retention", func(t *testing.T) {
-		core := newWaitGroupsCore(t)
-		now := time.Now()
-		deleteAfterSeconds := int64(600) // 10 minutes
-		expiresAt := now.Add(time.Hour)
-		namespaceId := &corepb.NamespaceId{
-			AccountId:   rand.Uint64(),
-			NamespaceId: rand.Uint64(),
-		}
-		waitGroupId := &corepb.WaitGroupId{
-			AccountId:   namespaceId.AccountId,
-			NamespaceId: namespaceId.NamespaceId,
-			WaitGroupId: rand.Uint64(),
-		}
+func newWaitGroupId(namespaceId *corepb.NamespaceId) *corepb.WaitGroupId {
+	return &corepb.WaitGroupId{
+		AccountId:   namespaceId.AccountId,
+		NamespaceId: namespaceId.NamespaceId,
+		WaitGroupId: rand.Uint64(),
+	}
+}
 
-		_, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
-			Payload: &corepb.CreateWaitGroupRequest{
-				WaitGroupId:                       waitGroupId,
-				Name:                              "test_wait_group",
-				Description:                       "desc",
-				Counter:                           5,
-				ExpiresAt:                         expiresAt.UnixNano(),
-				MaxNumberOfWaitGroupsPerNamespace: 100,
-				DeleteAfterFinishedSeconds:        deleteAfterSeconds,
-			},
-			Now: now.UnixNano(),
-		})
-		require.NoError(t, err)
+func newChildWaitGroupRequest(waitGroupId *corepb.WaitGroupId, name string, counter int64, parentName string, policy corepb.WaitGroupChildFailurePolicy, expiresAt time.Time, now time.Time) *coreapis.CreateWaitGroupRequest {
+	return &coreapis.CreateWaitGroupRequest{
+		Payload: &corepb.CreateWaitGroupRequest{
+			WaitGroupId:                       waitGroupId,
+			Name:                              name,
+			Description:                       "test description",
+			Counter:                           counter,
+			ExpiresAt:                         expiresAt.UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: 100,
+			DeleteAfterFinishedSeconds:        3600,
+			ParentWaitGroupName:               parentName,
+			ChildFailurePolicy:                policy,
+		},
+		Now: now.UnixNano(),
+	}
+}
 
-		// Only partially complete it so it never reaches its counter.
-		completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, now.Add(time.Minute))
+func createChildWaitGroup(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, parentName string, policy corepb.WaitGroupChildFailurePolicy, expiresAt time.Time, now time.Time) *corepb.WaitGroup {
+	t.Helper()
 
-		// GC after expires_at but before the retention period marks the wait group
-		// EXPIRED without deleting it.
-		runWaitGroupsGC(t, core, expiresAt.Add(5*time.Minute))
-		wg := getWaitGroup(t, core, waitGroupId)
-		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED, wg.Status)
-		require.Equal(t, expiresAt.UnixNano(), wg.FinishedAt)
+	resp, err := core.CreateWaitGroup(newChildWaitGroupRequest(waitGroupId, name, counter, parentName, policy, expiresAt, now))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	require.Equal(t, parentName, resp.Payload.WaitGroup.ParentWaitGroupName)
+	return resp.Payload.WaitGroup
+}
 
-		// GC after the retention period (finished_at + delete_after) deletes it.
-		runWaitGroupsGC(t, core, expiresAt.Add(time.Duration(deleteAfterSeconds)*time.Second).Add(time.Minute))
-		requireWaitGroupNotFound(t, core, waitGroupId)
-	})
+func addToWaitGroup(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, delta int64, now time.Time) *corepb.WaitGroup {
+	t.Helper()
 
-	t.Run("expires_at no longer deletes directly", func(t *testing.T) {
-		core := newWaitGroupsCore(t)
-		now := time.Now()
-		expiresAt := now.Add(time.Hour)
-		namespaceId := &corepb.NamespaceId{
-			AccountId:   rand.Uint64(),
-			NamespaceId: rand.Uint64(),
-		}
-		waitGroupId := &corepb.WaitGroupId{
-			AccountId:   namespaceId.AccountId,
-			NamespaceId: namespaceId.NamespaceId,
-			WaitGroupId: rand.Uint64(),
-		}
+	resp, err := core.AddToWaitGroup(&coreapis.AddToWaitGroupRequest{
+		Payload: &corepb.AddToWaitGroupRequest{
+			NamespaceId:      namespaceId,
+			WaitGroupName:    waitGroupName,
+			Delta:            delta,
+			MaxWaitGroupSize: 1000,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
 
-		_, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
-			Payload: &corepb.CreateWaitGroupRequest{
-				WaitGroupId:                       waitGroupId,
-				Name:                              "test_wait_group",
-				Description:                       "desc",
-				Counter:                           5,
-				ExpiresAt:                         expiresAt.UnixNano(),
-				MaxNumberOfWaitGroupsPerNamespace: 100,
-				// Large retention: even though the wait group expires, it stays
-				// around long after expires_at.
-				DeleteAfterFinishedSeconds: int64((24 * time.Hour).Seconds()),
-			},
-			Now: now.UnixNano(),
-		})
-		require.NoError(t, err)
+func addToWaitGroupWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, delta int64, maxWaitGroupSize int64, now time.Time) *mrpc.Error {
+	t.Helper()
 
-		// Way past expires_at, but well within the retention window.
-		runWaitGroupsGC(t, core, expiresAt.Add(time.Hour))
-		wg := getWaitGroup(t, core, waitGroupId)
-		require.Equal(t, corepb.WaitGroupStatus_WAIT_GROUP_STATUS_EXPIRED, wg.Status)
+	resp, err := core.AddToWaitGroup(&coreapis.AddToWaitGroupRequest{
+		Payload: &corepb.AddToWaitGroupRequest{
+			NamespaceId:      namespaceId,
+			WaitGroupName:    waitGroupName,
+			Delta:            delta,
+			MaxWaitGroupSize: maxWaitGroupSize,
+		},
+		Now: now.UnixNano(),
 	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+	return resp.ApplicationError
 }
 
-// runWaitGroupsGC runs a garbage collection pass at the given wall-clock time
-// with generous page sizes.
-func runWaitGroupsGC(t *testing.T, core *Core, now time.Time) {
+func uncompleteJobsFromWaitGroup(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, reopen bool, now time.Time) *corepb.WaitGroup {
 	t.Helper()
 
-	resp, err := core.RunWaitGroupsGarbageCollection(&coreapis.RunWaitGroupsGarbageCollectionRequest{
-		Payload: &corepb.RunWaitGroupsGarbageCollectionRequest{
-			GcRecordsPageSize:          100,
-			GcRecordWaitGroupsPageSize: 1000,
-			MaxDeletedObjects:          1000,
+	resp, err := core.UncompleteJobsFromWaitGroup(&coreapis.UncompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.UncompleteJobsFromWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			JobIds:        jobIds,
+			Reopen:        reopen,
 		},
 		Now: now.UnixNano(),
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
 }
 
-// requireWaitGroupNotFound asserts that the wait group no longer exists.
-func requireWaitGroupNotFound(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId) {
+func uncompleteJobsFromWaitGroupWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, reopen bool, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupId,
+	resp, err := core.UncompleteJobsFromWaitGroup(&coreapis.UncompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.UncompleteJobsFromWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			JobIds:        jobIds,
+			Reopen:        reopen,
 		},
+		Now: now.UnixNano(),
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
 	require.NotNil(t, resp.ApplicationError)
-	require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+	return resp.ApplicationError
 }
 
-func TestCore_LastActivityAt(t *testing.T) {
-	t.Run("create sets it, completing jobs updates it, update does not", func(t *testing.T) {
-		core := newWaitGroupsCore(t)
-		now := time.Now()
-		namespaceId := &corepb.NamespaceId{
-			AccountId:   rand.Uint64(),
-			NamespaceId: rand.Uint64(),
-		}
-		waitGroupId := &corepb.WaitGroupId{
-			AccountId:   namespaceId.AccountId,
-			NamespaceId: namespaceId.NamespaceId,
-			WaitGroupId: rand.Uint64(),
-		}
-
-		// Create records the creation time as the initial activity.
-		wg := createWaitGroup(t, core, waitGroupId, "test_wait_group", 10, 100, now.Add(time.Hour), now)
-		require.Equal(t, now.UnixNano(), wg.LastActivityAt)
-
-		// Update* must NOT touch last_activity_at.
-		updateResp, err := core.UpdateWaitGroup(&coreapis.UpdateWaitGroupRequest{
-			Payload: &corepb.UpdateWaitGroupRequest{
-				NamespaceId:     namespaceId,
-				WaitGroupName:   "test_wait_group",
-				Description:     "new description",
-				Counter:         10,
-				ExpiresAt:       now.Add(2 * time.Hour).UnixNano(),
-				ExpectedVersion: 1,
-			},
-			Now: now.Add(time.Minute).UnixNano(),
-		})
-		require.NoError(t, err)
-		require.Nil(t, updateResp.ApplicationError)
-		require.Equal(t, now.UnixNano(), updateResp.Payload.WaitGroup.LastActivityAt)
+func cancelWaitGroup(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, reason string, now time.Time) *corepb.WaitGroup {
+	t.Helper()
 
-		// Reporting a completed job is activity and updates the timestamp.
-		completeTime := now.Add(2 * time.Minute)
-		wg2 := completeJobsFromWaitGroup(t, core, namespaceId, "test_wait_group", []string{"job_1"}, completeTime)
-		require.Equal(t, completeTime.UnixNano(), wg2.LastActivityAt)
+	resp, err := core.CancelWaitGroup(&coreapis.CancelWaitGroupRequest{
+		Payload: &corepb.CancelWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			Reason:        reason,
+		},
+		Now: now.UnixNano(),
 	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
 }
 
-// completeJobRequests builds a slice of CompleteJobRequest from plain job ids
-// (without metadata), which is the common case in these tests.
-func completeJobRequests(jobIds []string) []*corepb.CompleteJobRequest {
-	jobs := make([]*corepb.CompleteJobRequest, len(jobIds))
-	for i, jobId := range jobIds {
-		jobs[i] = &corepb.CompleteJobRequest{JobId: jobId}
-	}
-	return jobs
-}
+func cancelWaitGroupWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, now time.Time) *mrpc.Error {
+	t.Helper()
 
-func newWaitGroupsCore(t *testing.T) *Core {
-	store, err := store.NewBadgerInMemoryStore()
+	resp, err := core.CancelWaitGroup(&coreapis.CancelWaitGroupRequest{
+		Payload: &corepb.CancelWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+		},
+		Now: now.UnixNano(),
+	})
 	require.NoError(t, err)
-	return NewCore(store, []byte{0x1d, 0x36, 0x00, 0x00}, 0x00000000, 0xffffffff)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Payload)
+	require.NotNil(t, resp.ApplicationError)
+	return resp.ApplicationError
 }
 
-func createWaitGroup(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, maxNumberOfWaitGroupsPerNamespace int64, expiresAt time.Time, now time.Time) *corepb.WaitGroup {
+func createWaitGroupWithAggregations(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, now time.Time) *corepb.WaitGroup {
 	t.Helper()
 
 	resp, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
 		Payload: &corepb.CreateWaitGroupRequest{
 			WaitGroupId:                       waitGroupId,
 			Name:                              name,
-			Description:                       "test description",
 			Counter:                           counter,
-			ExpiresAt:                         expiresAt.UnixNano(),
-			MaxNumberOfWaitGroupsPerNamespace: maxNumberOfWaitGroupsPerNamespace,
+			ExpiresAt:                         now.Add(time.Hour).UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: 100,
 			DeleteAfterFinishedSeconds:        3600,
+			Aggregations: []*corepb.WaitGroupAggregationSpec{
+				{MetadataKey: "rows", Function: corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_SUM},
+				{MetadataKey: "rows", Function: corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MIN},
+				{MetadataKey: "rows", Function: corepb.WaitGroupAggregationFunction_WAIT_GROUP_AGGREGATION_FUNCTION_MAX},
+			},
 		},
 		Now: now.UnixNano(),
 	})
@@ -1785,95 +3913,265 @@ func createWaitGroup(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId,
 	return resp.Payload.WaitGroup
 }
 
-func createWaitGroupWithError(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, maxNumberOfWaitGroupsPerNamespace int64, expiresAt time.Time, now time.Time) *mrpc.Error {
+func completeJobsWithMetadata(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobs map[string]map[string]string, failed bool, now time.Time) *corepb.WaitGroup {
 	t.Helper()
 
-	resp, err := core.CreateWaitGroup(&coreapis.CreateWaitGroupRequest{
+	requests := make([]*corepb.CompleteJobRequest, 0, len(jobs))
+	for jobId, metadata := range jobs {
+		requests = append(requests, &corepb.CompleteJobRequest{
+			JobId:    jobId,
+			Metadata: metadata,
+			Failed:   failed,
+		})
+	}
+
+	resp, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
+			NamespaceId:   namespaceId,
+			WaitGroupName: waitGroupName,
+			Jobs:          requests,
+		},
+		Now: now.UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+func requireAggregate(t *testing.T, aggregate *corepb.WaitGroupAggregate, function corepb.WaitGroupAggregationFunction, value int64, numberOfValues int64) {
+	t.Helper()
+
+	require.Equal(t, "rows", aggregate.MetadataKey)
+	require.Equal(t, function, aggregate.Function)
+	require.Equal(t, value, aggregate.Value)
+	require.Equal(t, numberOfValues, aggregate.NumberOfValues)
+}
+
+func newIntegerJobIdsWaitGroupRequest(waitGroupId *corepb.WaitGroupId, name string, counter int64, now time.Time) *coreapis.CreateWaitGroupRequest {
+	return &coreapis.CreateWaitGroupRequest{
 		Payload: &corepb.CreateWaitGroupRequest{
 			WaitGroupId:                       waitGroupId,
 			Name:                              name,
-			Description:                       "test description",
 			Counter:                           counter,
-			ExpiresAt:                         expiresAt.UnixNano(),
-			MaxNumberOfWaitGroupsPerNamespace: maxNumberOfWaitGroupsPerNamespace,
+			ExpiresAt:                         now.Add(time.Hour).UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: 100,
 			DeleteAfterFinishedSeconds:        3600,
+			IntegerJobIds:                     true,
 		},
 		Now: now.UnixNano(),
+	}
+}
+
+func createWaitGroupWithIntegerJobIds(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.CreateWaitGroup(newIntegerJobIdsWaitGroupRequest(waitGroupId, name, counter, now))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+// integerJobIds returns the integer job ids from (inclusive) to to (exclusive).
+func integerJobIds(from int, to int) []string {
+	jobIds := make([]string, 0, to-from)
+	for id := from; id < to; id++ {
+		jobIds = append(jobIds, strconv.Itoa(id))
+	}
+	return jobIds
+}
+
+func listWaitGroupCompletedJobsPage(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, paginationToken *corepb.PaginationToken, limit int32) *corepb.ListWaitGroupCompletedJobsResponse {
+	t.Helper()
+
+	resp, err := core.ListWaitGroupCompletedJobs(&coreapis.ListWaitGroupCompletedJobsRequest{
+		Payload: &corepb.ListWaitGroupCompletedJobsRequest{
+			NamespaceId:     namespaceId,
+			WaitGroupName:   waitGroupName,
+			PaginationToken: paginationToken,
+			Limit:           limit,
+		},
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.Nil(t, resp.Payload)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	return resp.Payload
+}
+
+func newStripedWaitGroupRequest(waitGroupId *corepb.WaitGroupId, name string, counter int64, stripes uint32, now time.Time) *coreapis.CreateWaitGroupRequest {
+	return &coreapis.CreateWaitGroupRequest{
+		Payload: &corepb.CreateWaitGroupRequest{
+			WaitGroupId:                       waitGroupId,
+			Name:                              name,
+			Counter:                           counter,
+			ExpiresAt:                         now.Add(time.Hour).UnixNano(),
+			MaxNumberOfWaitGroupsPerNamespace: 100,
+			DeleteAfterFinishedSeconds:        3600,
+			Stripes:                           stripes,
+		},
+		Now: now.UnixNano(),
+	}
+}
+
+func createStripedWaitGroup(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, name string, counter int64, stripes uint32, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp, err := core.CreateWaitGroup(newStripedWaitGroupRequest(waitGroupId, name, counter, stripes, now))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	require.NotNil(t, resp.Payload.WaitGroup)
+	return resp.Payload.WaitGroup
+}
+
+func newStripeId(waitGroupId *corepb.WaitGroupId, stripe uint32) *corepb.WaitGroupStripeId {
+	return &corepb.WaitGroupStripeId{
+		AccountId:   waitGroupId.AccountId,
+		NamespaceId: waitGroupId.NamespaceId,
+		WaitGroupId: waitGroupId.WaitGroupId,
+		Stripe:      stripe,
+	}
+}
+
+func completeJobsFromWaitGroupStripe(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripe uint32, jobIds []string, failed bool, deleteAt time.Time, now time.Time) *corepb.WaitGroupStripe {
+	t.Helper()
+
+	resp := completeJobsFromWaitGroupStripeResponse(t, core, waitGroupId, stripe, jobIds, failed, deleteAt, now)
+	require.Nil(t, resp.ApplicationError)
+	require.NotNil(t, resp.Payload)
+	return resp.Payload.Stripe
+}
+
+func completeJobsFromWaitGroupStripeWithError(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripe uint32, jobIds []string, failed bool, deleteAt time.Time, now time.Time) *mrpc.Error {
+	t.Helper()
+
+	resp := completeJobsFromWaitGroupStripeResponse(t, core, waitGroupId, stripe, jobIds, failed, deleteAt, now)
 	require.NotNil(t, resp.ApplicationError)
 	return resp.ApplicationError
 }
 
-func completeJobsFromWaitGroup(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, now time.Time) *corepb.WaitGroup {
+// completeJobsFromWaitGroupStripeResponse completes jobs on a stripe bounded, like the
+// handler does, by the room its other stripes left in the wait group.
+func completeJobsFromWaitGroupStripeResponse(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripe uint32, jobIds []string, failed bool, deleteAt time.Time, now time.Time) *coreapis.CompleteJobsFromWaitGroupStripeResponse {
 	t.Helper()
 
-	resp, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-			NamespaceId:   namespaceId,
-			WaitGroupName: waitGroupName,
-			Jobs:          completeJobRequests(jobIds),
+	jobs := completeJobRequests(jobIds)
+	for _, job := range jobs {
+		job.Failed = failed
+	}
+
+	waitGroup := getWaitGroup(t, core, waitGroupId)
+	maxFinishedJobs := waitGroup.Counter
+	for _, counts := range waitGroup.StripeCounts {
+		if counts.Stripe != stripe {
+			maxFinishedJobs -= counts.CompletedJobs + counts.FailedJobs
+		}
+	}
+
+	resp, err := core.CompleteJobsFromWaitGroupStripe(&coreapis.CompleteJobsFromWaitGroupStripeRequest{
+		Payload: &corepb.CompleteJobsFromWaitGroupStripeRequest{
+			StripeId:        newStripeId(waitGroupId, stripe),
+			Jobs:            jobs,
+			DeleteAt:        deleteAt.UnixNano(),
+			MaxFinishedJobs: maxFinishedJobs,
 		},
 		Now: now.UnixNano(),
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
+	return resp
+}
+
+func aggregateWaitGroupStripes(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripes []*corepb.WaitGroupStripe, now time.Time) *corepb.WaitGroup {
+	t.Helper()
+
+	resp := aggregateWaitGroupStripesResponse(t, core, waitGroupId, stripes, now)
 	require.Nil(t, resp.ApplicationError)
 	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.WaitGroup)
 	return resp.Payload.WaitGroup
 }
 
-func completeJobsFromWaitGroupWithError(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string, jobIds []string, now time.Time) *mrpc.Error {
+func aggregateWaitGroupStripesWithError(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripes []*corepb.WaitGroupStripe, now time.Time) *mrpc.Error {
 	t.Helper()
 
-	resp, err := core.CompleteJobsFromWaitGroup(&coreapis.CompleteJobsFromWaitGroupRequest{
-		Payload: &corepb.CompleteJobsFromWaitGroupRequest{
-			NamespaceId:   namespaceId,
-			WaitGroupName: waitGroupName,
-			Jobs:          completeJobRequests(jobIds),
+	resp := aggregateWaitGroupStripesResponse(t, core, waitGroupId, stripes, now)
+	require.NotNil(t, resp.ApplicationError)
+	return resp.ApplicationError
+}
+
+func aggregateWaitGroupStripesResponse(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripes []*corepb.WaitGroupStripe, now time.Time) *coreapis.AggregateWaitGroupStripesResponse {
+	t.Helper()
+
+	stripeCounts := make([]*corepb.WaitGroupStripeCounts, 0, len(stripes))
+	for _, stripe := range stripes {
+		stripeCounts = append(stripeCounts, &corepb.WaitGroupStripeCounts{
+			Stripe:        stripe.Id.Stripe,
+			CompletedJobs: stripe.CompletedJobs,
+			FailedJobs:    stripe.FailedJobs,
+		})
+	}
+
+	resp, err := core.AggregateWaitGroupStripes(&coreapis.AggregateWaitGroupStripesRequest{
+		Payload: &corepb.AggregateWaitGroupStripesRequest{
+			WaitGroupId:  waitGroupId,
+			StripeCounts: stripeCounts,
 		},
 		Now: now.UnixNano(),
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.Nil(t, resp.Payload)
-	require.NotNil(t, resp.ApplicationError)
-	return resp.ApplicationError
+	return resp
 }
 
-func getWaitGroup(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId) *corepb.WaitGroup {
+func getWaitGroupStripe(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripe uint32) *corepb.WaitGroupStripe {
 	t.Helper()
 
-	resp, err := core.GetWaitGroup(&coreapis.GetWaitGroupRequest{
-		Payload: &corepb.GetWaitGroupRequest{
-			WaitGroupId: waitGroupId,
+	resp, err := core.GetWaitGroupStripe(&coreapis.GetWaitGroupStripeRequest{
+		Payload: &corepb.GetWaitGroupStripeRequest{
+			StripeId: newStripeId(waitGroupId, stripe),
 		},
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	require.Nil(t, resp.ApplicationError)
 	require.NotNil(t, resp.Payload)
-	require.NotNil(t, resp.Payload.WaitGroup)
-	return resp.Payload.WaitGroup
+	return resp.Payload.Stripe
 }
 
-func ListWaitGroupCompletedJobs(t *testing.T, core *Core, namespaceId *corepb.NamespaceId, waitGroupName string) *corepb.ListWaitGroupCompletedJobsResponse {
+// requireWaitGroupStripeNotFound asserts that the stripe does not exist.
+func requireWaitGroupStripeNotFound(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripe uint32) {
 	t.Helper()
 
-	resp, err := core.ListWaitGroupCompletedJobs(&coreapis.ListWaitGroupCompletedJobsRequest{
-		Payload: &corepb.ListWaitGroupCompletedJobsRequest{
-			NamespaceId:   namespaceId,
-			WaitGroupName: waitGroupName,
+	resp, err := core.GetWaitGroupStripe(&coreapis.GetWaitGroupStripeRequest{
+		Payload: &corepb.GetWaitGroupStripeRequest{
+			StripeId: newStripeId(waitGroupId, stripe),
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.ApplicationError)
+	require.Equal(t, mrpc.NotFound, resp.ApplicationError.Code)
+}
+
+func listWaitGroupStripeCompletedJobs(t *testing.T, core *Core, waitGroupId *corepb.WaitGroupId, stripe uint32) []*corepb.WaitGroupJob {
+	t.Helper()
+
+	resp, err := core.ListWaitGroupStripeCompletedJobs(&coreapis.ListWaitGroupStripeCompletedJobsRequest{
+		Payload: &corepb.ListWaitGroupStripeCompletedJobsRequest{
+			StripeId: newStripeId(waitGroupId, stripe),
 		},
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	require.Nil(t, resp.ApplicationError)
 	require.NotNil(t, resp.Payload)
-	return resp.Payload
+	return resp.Payload.Jobs
 }
 
 // TestCore_SplitSnapshotRestore proves the portable, bounds-filtered snapshot
@@ -1974,6 +4272,12 @@ var ownedTableNames = [][]byte{
 	tablePrefixGCRecords,
 	tablePrefixExpirationRecords,
 	tablePrefixDeletionRecords,
+	tablePrefixPendingJobs,
+	tablePrefixRetractedJobs,
+	tablePrefixJobChunks,
+	tablePrefixStripes,
+	tablePrefixStripeJobs,
+	tablePrefixStripeDeletion,
 }
 
 // countOwnedRows counts the physical rows under every storage prefix the core
@@ -0,0 +1,240 @@
+package waitgroups
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/sharding"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// jobChunksTable stores the completed and failed job ids of wait groups with
+// integer job ids, one chunk per jobChunkSize consecutive ids. A chunk without
+// any job ids is deleted rather than stored empty.
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. wait group id
+//
+// Table Sort Key:
+// 1. chunk index
+type jobChunksTable struct {
+	table *honey.BinaryTable[*corepb.WaitGroupJobChunk, corepb.WaitGroupJobChunk]
+}
+
+// newJobChunksTable scopes the table under the shard-unique prefix; see
+// newWaitGroupsTable.
+func newJobChunksTable(replicaPrefix []byte) *jobChunksTable {
+	return &jobChunksTable{
+		table: honey.NewBinaryTable[*corepb.WaitGroupJobChunk, corepb.WaitGroupJobChunk](
+			utils.ConcatBytes(replicaPrefix, tablePrefixJobChunks),
+		),
+	}
+}
+
+// Clear deletes every job chunk.
+func (t *jobChunksTable) Clear(badgerStore *store.BadgerStore) error {
+	return badgerStore.DeletePrefix(t.table.TableId())
+}
+
+// EachEntity streams every job chunk as (canonical key, stored value).
+func (t *jobChunksTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed job chunk and, if owned, inserts it
+// through Set — re-deriving its key from the chunk's own identity fields.
+func (t *jobChunksTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	chunk := &corepb.WaitGroupJobChunk{}
+	if err := chunk.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(sharding.ByAccountAndNamespace(chunk.Id.AccountId, chunk.Id.NamespaceId)) {
+		return false, nil
+	}
+	return true, t.Set(txn, chunk)
+}
+
+func (t *jobChunksTable) Get(txn *store.Txn, chunkId *corepb.WaitGroupJobChunkId) (*corepb.WaitGroupJobChunk, error) {
+	return t.table.Get(txn,
+		utils.ConcatBytes(
+			tablePK(chunkId.AccountId, chunkId.NamespaceId, chunkId.WaitGroupId),
+			chunkIndexSK(chunkId.ChunkIndex)))
+}
+
+func (t *jobChunksTable) Set(txn *store.Txn, chunk *corepb.WaitGroupJobChunk) error {
+	return t.table.Set(txn,
+		utils.ConcatBytes(
+			tablePK(chunk.Id.AccountId, chunk.Id.NamespaceId, chunk.Id.WaitGroupId),
+			chunkIndexSK(chunk.Id.ChunkIndex)),
+		chunk)
+}
+
+func (t *jobChunksTable) Delete(txn *store.Txn, chunkId *corepb.WaitGroupJobChunkId) error {
+	return t.table.Delete(txn,
+		utils.ConcatBytes(
+			tablePK(chunkId.AccountId, chunkId.NamespaceId, chunkId.WaitGroupId),
+			chunkIndexSK(chunkId.ChunkIndex)))
+}
+
+// ListInRange calls fn for every stored chunk of the wait group with an index
+// between from and to (inclusive), in ascending order or, with reverse, in
+// descending order, until fn returns false.
+func (t *jobChunksTable) ListInRange(txn *store.Txn, waitGroupId *corepb.WaitGroupId, from uint64, to uint64, reverse bool, fn func(chunk *corepb.WaitGroupJobChunk) (bool, error)) error {
+	pk := tablePK(waitGroupId.AccountId, waitGroupId.NamespaceId, waitGroupId.WaitGroupId)
+	return t.table.ListInRange(txn, utils.ConcatBytes(pk, chunkIndexSK(from)), utils.ConcatBytes(pk, chunkIndexSK(to)), reverse, fn)
+}
+
+func chunkIndexSK(chunkIndex uint64) []byte {
+	return utils.ConcatBytes(
+		chunkIndex,
+	)
+}
+
+// jobChunksBatch caches the job chunks of one wait group that a single request
+// reads and modifies, so that each chunk is loaded and written at most once.
+type jobChunksBatch struct {
+	table       *jobChunksTable
+	waitGroupId *corepb.WaitGroupId
+	chunks      map[uint64]*corepb.WaitGroupJobChunk
+	modified    map[uint64]bool
+}
+
+func newJobChunksBatch(table *jobChunksTable, waitGroupId *corepb.WaitGroupId) *jobChunksBatch {
+	return &jobChunksBatch{
+		table:       table,
+		waitGroupId: waitGroupId,
+		chunks:      make(map[uint64]*corepb.WaitGroupJobChunk),
+		modified:    make(map[uint64]bool),
+	}
+}
+
+// get returns the chunk holding the job id, an empty one if it is not stored.
+func (b *jobChunksBatch) get(txn *store.Txn, jobId uint64) (*corepb.WaitGroupJobChunk, error) {
+	chunkIndex := jobId / jobChunkSize
+	if chunk, ok := b.chunks[chunkIndex]; ok {
+		return chunk, nil
+	}
+
+	chunkId := &corepb.WaitGroupJobChunkId{
+		AccountId:   b.waitGroupId.AccountId,
+		NamespaceId: b.waitGroupId.NamespaceId,
+		WaitGroupId: b.waitGroupId.WaitGroupId,
+		ChunkIndex:  chunkIndex,
+	}
+	chunk, err := b.table.Get(txn, chunkId)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+		chunk = &corepb.WaitGroupJobChunk{Id: chunkId}
+	}
+
+	// Containers are modified in place, so they must not share memory with
+	// the store
+	chunk.CompletedJobs = bytes.Clone(chunk.CompletedJobs)
+	chunk.FailedJobs = bytes.Clone(chunk.FailedJobs)
+
+	b.chunks[chunkIndex] = chunk
+	return chunk, nil
+}
+
+// status reports whether the job id is finished and, if so, whether it failed.
+func (b *jobChunksBatch) status(txn *store.Txn, jobId uint64) (finished bool, failed bool, err error) {
+	chunk, err := b.get(txn, jobId)
+	if err != nil {
+		return false, false, err
+	}
+
+	offset := uint16(jobId % jobChunkSize)
+	if containerContains(chunk.FailedJobs, offset) {
+		return true, true, nil
+	}
+	return containerContains(chunk.CompletedJobs, offset), false, nil
+}
+
+// add records the job id as completed or failed.
+func (b *jobChunksBatch) add(txn *store.Txn, jobId uint64, failed bool) error {
+	chunk, err := b.get(txn, jobId)
+	if err != nil {
+		return err
+	}
+
+	offset := uint16(jobId % jobChunkSize)
+	if failed {
+		chunk.FailedJobs = containerAdd(chunk.FailedJobs, offset)
+	} else {
+		chunk.CompletedJobs = containerAdd(chunk.CompletedJobs, offset)
+	}
+	b.modified[chunk.Id.ChunkIndex] = true
+	return nil
+}
+
+// remove forgets the job id, whether it was completed or failed.
+func (b *jobChunksBatch) remove(txn *store.Txn, jobId uint64) error {
+	chunk, err := b.get(txn, jobId)
+	if err != nil {
+		return err
+	}
+
+	offset := uint16(jobId % jobChunkSize)
+	chunk.CompletedJobs = containerRemove(chunk.CompletedJobs, offset)
+	chunk.FailedJobs = containerRemove(chunk.FailedJobs, offset)
+	b.modified[chunk.Id.ChunkIndex] = true
+	return nil
+}
+
+// flush writes back every modified chunk, deleting the ones left empty.
+func (b *jobChunksBatch) flush(txn *store.Txn) error {
+	chunkIndexes := make([]uint64, 0, len(b.modified))
+	for chunkIndex := range b.modified {
+		chunkIndexes = append(chunkIndexes, chunkIndex)
+	}
+	slices.Sort(chunkIndexes)
+
+	for _, chunkIndex := range chunkIndexes {
+		chunk := b.chunks[chunkIndex]
+
+		var err error
+		if len(chunk.CompletedJobs) == 0 && len(chunk.FailedJobs) == 0 {
+			err = b.table.Delete(txn, chunk.Id)
+		} else {
+			err = b.table.Set(txn, chunk)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	clear(b.modified)
+	return nil
+}
+
+// nextFinishedJob returns the smallest offset of a finished (completed or
+// failed) job in the chunk that is not less than from, or -1 if there is none.
+func nextFinishedJob(chunk *corepb.WaitGroupJobChunk, from int) (offset int, failed bool) {
+	completed := containerNext(chunk.CompletedJobs, from)
+	failedOffset := containerNext(chunk.FailedJobs, from)
+	if failedOffset >= 0 && (completed < 0 || failedOffset < completed) {
+		return failedOffset, true
+	}
+	return completed, false
+}
+
+// previousFinishedJob returns the largest offset of a finished (completed or
+// failed) job in the chunk that is less than before, or -1 if there is none.
+func previousFinishedJob(chunk *corepb.WaitGroupJobChunk, before int) (offset int, failed bool) {
+	completed := containerPrevious(chunk.CompletedJobs, before)
+	failedOffset := containerPrevious(chunk.FailedJobs, before)
+	if failedOffset > completed {
+		return failedOffset, true
+	}
+	return completed, false
+}
@@ -0,0 +1,113 @@
+package waitgroups
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestJobChunksTable_SetGetDelete(t *testing.T) {
+	t.Run("set, get and delete job chunk", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newJobChunksTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		chunk := &corepb.WaitGroupJobChunk{
+			Id: &corepb.WaitGroupJobChunkId{
+				AccountId:   rand.Uint64(),
+				NamespaceId: rand.Uint64(),
+				WaitGroupId: rand.Uint64(),
+				ChunkIndex:  3,
+			},
+			CompletedJobs: containerAdd(nil, 42),
+			FailedJobs:    containerAdd(nil, 7),
+		}
+
+		txn := badgerStore.Update()
+		err = table.Set(txn, chunk)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify chunk was stored
+		txn = badgerStore.View()
+		actual, err := table.Get(txn, chunk.Id)
+		require.NoError(t, err)
+		require.True(t, containerContains(actual.CompletedJobs, 42))
+		require.True(t, containerContains(actual.FailedJobs, 7))
+		txn.Discard()
+
+		// Delete chunk
+		txn = badgerStore.Update()
+		err = table.Delete(txn, chunk.Id)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify chunk is gone
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, chunk.Id)
+		require.ErrorIs(t, err, store.ErrNotFound)
+	})
+}
+
+func TestJobChunksBatch(t *testing.T) {
+	t.Run("add, remove and flush job ids", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newJobChunksTable([]byte{0x77, 0x77, 0x77, 0x77})
+		waitGroupId := &corepb.WaitGroupId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+			WaitGroupId: rand.Uint64(),
+		}
+
+		// Record job ids in two chunks
+		txn := badgerStore.Update()
+		batch := newJobChunksBatch(table, waitGroupId)
+		require.NoError(t, batch.add(txn, 1, false))
+		require.NoError(t, batch.add(txn, 2, true))
+		require.NoError(t, batch.add(txn, jobChunkSize+1, false))
+		require.NoError(t, batch.flush(txn))
+		require.NoError(t, txn.Commit())
+
+		// A new batch reads them back
+		txn = badgerStore.Update()
+		batch = newJobChunksBatch(table, waitGroupId)
+		finished, failed, err := batch.status(txn, 1)
+		require.NoError(t, err)
+		require.True(t, finished)
+		require.False(t, failed)
+		finished, failed, err = batch.status(txn, 2)
+		require.NoError(t, err)
+		require.True(t, finished)
+		require.True(t, failed)
+		finished, _, err = batch.status(txn, 3)
+		require.NoError(t, err)
+		require.False(t, finished)
+
+		// Emptying a chunk deletes it
+		require.NoError(t, batch.remove(txn, jobChunkSize+1))
+		require.NoError(t, batch.flush(txn))
+		require.NoError(t, txn.Commit())
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		var chunkIndexes []uint64
+		err = table.ListInRange(txn, waitGroupId, 0, 10, false, func(chunk *corepb.WaitGroupJobChunk) (bool, error) {
+			chunkIndexes = append(chunkIndexes, chunk.Id.ChunkIndex)
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []uint64{0}, chunkIndexes)
+	})
+}
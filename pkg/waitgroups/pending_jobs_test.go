@@ -0,0 +1,110 @@
+package waitgroups
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestPendingJobsTable_CreateGetDelete(t *testing.T) {
+	t.Run("create, get and delete pending job", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newPendingJobsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waitGroupJob := &corepb.WaitGroupJob{
+			Id: &corepb.WaitGroupJobId{
+				AccountId:   rand.Uint64(),
+				NamespaceId: rand.Uint64(),
+				WaitGroupId: rand.Uint64(),
+				JobId:       "job_123",
+			},
+		}
+
+		txn := badgerStore.Update()
+		err = table.Create(txn, waitGroupJob)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify job was created
+		txn = badgerStore.View()
+		actual, err := table.Get(txn, waitGroupJob.Id)
+		require.NoError(t, err)
+		require.Equal(t, waitGroupJob.Id.JobId, actual.Id.JobId)
+		require.EqualValues(t, 0, actual.CompletedAt)
+		txn.Discard()
+
+		// Delete job
+		txn = badgerStore.Update()
+		err = table.Delete(txn, waitGroupJob.Id)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify job is gone
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, waitGroupJob.Id)
+		require.ErrorIs(t, err, store.ErrNotFound)
+	})
+}
+
+func TestPendingJobsTable_List(t *testing.T) {
+	t.Run("list pending jobs from different wait groups are isolated", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newPendingJobsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		accountId := rand.Uint64()
+		namespaceId := rand.Uint64()
+		waitGroupId1 := rand.Uint64()
+		waitGroupId2 := rand.Uint64()
+
+		txn := badgerStore.Update()
+		for i := range 3 {
+			err := table.Create(txn, &corepb.WaitGroupJob{
+				Id: &corepb.WaitGroupJobId{
+					AccountId:   accountId,
+					NamespaceId: namespaceId,
+					WaitGroupId: waitGroupId1,
+					JobId:       fmt.Sprintf("job_%d", i),
+				},
+			})
+			require.NoError(t, err)
+		}
+		err = table.Create(txn, &corepb.WaitGroupJob{
+			Id: &corepb.WaitGroupJobId{
+				AccountId:   accountId,
+				NamespaceId: namespaceId,
+				WaitGroupId: waitGroupId2,
+				JobId:       "job_0",
+			},
+		})
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, accountId, namespaceId, waitGroupId1, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, result.jobs, 3)
+		for i, job := range result.jobs {
+			require.Equal(t, fmt.Sprintf("job_%d", i), job.Id.JobId)
+		}
+
+		result, err = table.List(txn, accountId, namespaceId, waitGroupId2, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, result.jobs, 1)
+	})
+}
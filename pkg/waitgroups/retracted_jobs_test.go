@@ -0,0 +1,67 @@
+package waitgroups
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestRetractedJobsTable_CreateGetDelete(t *testing.T) {
+	t.Run("create, get and delete retracted job", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newRetractedJobsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		waitGroupJob := &corepb.WaitGroupJob{
+			Id: &corepb.WaitGroupJobId{
+				AccountId:   rand.Uint64(),
+				NamespaceId: rand.Uint64(),
+				WaitGroupId: rand.Uint64(),
+				JobId:       "job_123",
+			},
+			History: []*corepb.WaitGroupJobAttempt{
+				{
+					CompletedAt: 100,
+					RetractedAt: 200,
+					Metadata:    map[string]string{"key": "value"},
+				},
+			},
+		}
+
+		txn := badgerStore.Update()
+		err = table.Create(txn, waitGroupJob)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify job was created with its history
+		txn = badgerStore.View()
+		actual, err := table.Get(txn, waitGroupJob.Id)
+		require.NoError(t, err)
+		require.Equal(t, waitGroupJob.Id.JobId, actual.Id.JobId)
+		require.Len(t, actual.History, 1)
+		require.EqualValues(t, 100, actual.History[0].CompletedAt)
+		require.EqualValues(t, 200, actual.History[0].RetractedAt)
+		require.Equal(t, "value", actual.History[0].Metadata["key"])
+		txn.Discard()
+
+		// Delete job
+		txn = badgerStore.Update()
+		err = table.Delete(txn, waitGroupJob.Id)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify job is gone
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, waitGroupJob.Id)
+		require.ErrorIs(t, err, store.ErrNotFound)
+	})
+}
@@ -0,0 +1,91 @@
+package waitgroups
+
+import (
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// stripeDeletionRecordsTable stores wait group stripe deletion records indexed
+// by deletion time and stripe ID. A stripe is scheduled for deletion when it is
+// created, and rescheduled whenever its wait group's retention is extended;
+// garbage collection deletes the stripe and its jobs once delete_at passes.
+//
+// Table Primary Key:
+// 1. timestamp (delete_at)
+// 2. account id
+// 3. namespace id
+// 4. wait group id
+// 5. stripe
+//
+// Table Prefix:
+// 1. timestamp (delete_at)
+type stripeDeletionRecordsTable struct {
+	table *honey.BinaryTable[*corepb.WaitGroupStripesDeletionRecord, corepb.WaitGroupStripesDeletionRecord]
+}
+
+func newStripeDeletionRecordsTable(replicaPrefix []byte) *stripeDeletionRecordsTable {
+	return &stripeDeletionRecordsTable{
+		table: honey.NewBinaryTable[*corepb.WaitGroupStripesDeletionRecord, corepb.WaitGroupStripesDeletionRecord](
+			utils.ConcatBytes(replicaPrefix, tablePrefixStripeDeletion),
+		),
+	}
+}
+
+// Clear deletes every stripe deletion record row of this shard.
+func (t *stripeDeletionRecordsTable) Clear(badgerStore *store.BadgerStore) error {
+	return badgerStore.DeletePrefix(t.table.TableId())
+}
+
+// EachEntity streams every stripe deletion record as (canonical key, stored
+// value).
+func (t *stripeDeletionRecordsTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed stripe deletion record and, if owned,
+// inserts it through Add — which re-derives its key under this table's own
+// prefix.
+func (t *stripeDeletionRecordsTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	record := &corepb.WaitGroupStripesDeletionRecord{}
+	if err := record.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(stripeShardKey(record.StripeId)) {
+		return false, nil
+	}
+	return true, t.Add(txn, record.DeleteAt, record.StripeId)
+}
+
+func (t *stripeDeletionRecordsTable) Delete(txn *store.Txn, deleteAt int64, stripeId *corepb.WaitGroupStripeId) error {
+	return t.table.Delete(txn, t.tablePK(deleteAt, stripeId))
+}
+
+func (t *stripeDeletionRecordsTable) Add(txn *store.Txn, deleteAt int64, stripeId *corepb.WaitGroupStripeId) error {
+	return t.table.Set(txn,
+		t.tablePK(deleteAt, stripeId),
+		&corepb.WaitGroupStripesDeletionRecord{
+			DeleteAt: deleteAt,
+			StripeId: stripeId,
+		})
+}
+
+func (t *stripeDeletionRecordsTable) ListByDeletion(txn *store.Txn, from int64, to int64, fn func(record *corepb.WaitGroupStripesDeletionRecord) (bool, error)) error {
+	return t.table.ListInRange(txn, t.tablePrefix(from), t.tablePrefix(to), false, fn)
+}
+
+func (t *stripeDeletionRecordsTable) tablePK(time int64, stripeId *corepb.WaitGroupStripeId) []byte {
+	return utils.ConcatBytes(
+		time,
+		stripeKey(stripeId),
+	)
+}
+
+func (t *stripeDeletionRecordsTable) tablePrefix(time int64) []byte {
+	return utils.ConcatBytes(
+		time,
+	)
+}
@@ -0,0 +1,66 @@
+package waitgroups
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestStripeDeletionRecordsTable(t *testing.T) {
+	t.Run("add, list and delete stripe deletion records", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newStripeDeletionRecordsTable([]byte{0x1d, 0x36, 0x00, 0x00})
+
+		now := time.Now()
+		waitGroupId := rand.Uint64()
+
+		txn := badgerStore.Update()
+		for i := range 5 {
+			err := table.Add(txn, now.Add(time.Duration(i)*time.Hour).UnixNano(), &corepb.WaitGroupStripeId{
+				AccountId:   1,
+				NamespaceId: 2,
+				WaitGroupId: waitGroupId,
+				Stripe:      uint32(i),
+			})
+			require.NoError(t, err)
+		}
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Only records with delete_at in [now, now+2h] are returned, ordered
+		// by deletion time
+		list := func() []*corepb.WaitGroupStripesDeletionRecord {
+			txn := badgerStore.View()
+			defer txn.Discard()
+
+			result := make([]*corepb.WaitGroupStripesDeletionRecord, 0)
+			err := table.ListByDeletion(txn, now.UnixNano(), now.Add(2*time.Hour).UnixNano(), func(record *corepb.WaitGroupStripesDeletionRecord) (bool, error) {
+				result = append(result, record)
+				return true, nil
+			})
+			require.NoError(t, err)
+			return result
+		}
+
+		result := list()
+		require.Len(t, result, 3)
+		for i, record := range result {
+			require.Equal(t, uint32(i), record.StripeId.Stripe)
+		}
+
+		txn = badgerStore.Update()
+		err = table.Delete(txn, result[0].DeleteAt, result[0].StripeId)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		require.Len(t, list(), 2)
+	})
+}
@@ -0,0 +1,104 @@
+package waitgroups
+
+import (
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/pagination"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// stripeJobsTable stores the completed jobs of the stripes of striped wait
+// groups, indexed by job ID. Jobs live on the shard of their stripe.
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. wait group id
+// 4. stripe
+//
+// Table Sort Key:
+// 1. job id
+type stripeJobsTable struct {
+	table *honey.BinaryTable[*corepb.WaitGroupJob, corepb.WaitGroupJob]
+}
+
+// newStripeJobsTable scopes the table under the shard-unique prefix; see
+// newWaitGroupsTable.
+func newStripeJobsTable(replicaPrefix []byte) *stripeJobsTable {
+	return &stripeJobsTable{
+		table: honey.NewBinaryTable[*corepb.WaitGroupJob, corepb.WaitGroupJob](
+			utils.ConcatBytes(replicaPrefix, tablePrefixStripeJobs),
+		),
+	}
+}
+
+// Clear deletes every stripe job row.
+func (t *stripeJobsTable) Clear(badgerStore *store.BadgerStore) error {
+	return badgerStore.DeletePrefix(t.table.TableId())
+}
+
+// EachEntity streams every stripe job as (canonical key, stored value).
+func (t *stripeJobsTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed stripe job and, if owned, inserts it
+// through Create — re-deriving its key from the job's own identity fields.
+// Ownership is decided by the ByWaitGroupStripe key of the job's stripe.
+func (t *stripeJobsTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	job := &corepb.WaitGroupJob{}
+	if err := job.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(stripeShardKey(stripeIdOfJob(job.Id))) {
+		return false, nil
+	}
+	return true, t.Create(txn, job)
+}
+
+func (t *stripeJobsTable) List(txn *store.Txn, stripeId *corepb.WaitGroupStripeId, paginationToken *corepb.PaginationToken, limit int) (*listWaitGroupJobsResult, error) {
+	result, err := t.table.ListPaginated(txn, stripeKey(stripeId), pagination.CoreToMonstera(paginationToken), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listWaitGroupJobsResult{
+		jobs:                    result.Items,
+		nextPaginationToken:     pagination.MonsteraToCore(result.NextPaginationToken),
+		previousPaginationToken: pagination.MonsteraToCore(result.PreviousPaginationToken),
+	}, nil
+}
+
+func (t *stripeJobsTable) Get(txn *store.Txn, waitGroupJobId *corepb.WaitGroupJobId) (*corepb.WaitGroupJob, error) {
+	return t.table.Get(txn,
+		utils.ConcatBytes(
+			stripeKey(stripeIdOfJob(waitGroupJobId)),
+			tableSK(waitGroupJobId.JobId)))
+}
+
+func (t *stripeJobsTable) Create(txn *store.Txn, waitGroupJob *corepb.WaitGroupJob) error {
+	return t.table.Set(txn,
+		utils.ConcatBytes(
+			stripeKey(stripeIdOfJob(waitGroupJob.Id)),
+			tableSK(waitGroupJob.Id.JobId)),
+		waitGroupJob)
+}
+
+func (t *stripeJobsTable) Delete(txn *store.Txn, waitGroupJobId *corepb.WaitGroupJobId) error {
+	return t.table.Delete(txn,
+		utils.ConcatBytes(
+			stripeKey(stripeIdOfJob(waitGroupJobId)),
+			tableSK(waitGroupJobId.JobId)))
+}
+
+func stripeIdOfJob(waitGroupJobId *corepb.WaitGroupJobId) *corepb.WaitGroupStripeId {
+	return &corepb.WaitGroupStripeId{
+		AccountId:   waitGroupJobId.AccountId,
+		NamespaceId: waitGroupJobId.NamespaceId,
+		WaitGroupId: waitGroupJobId.WaitGroupId,
+		Stripe:      waitGroupJobId.Stripe,
+	}
+}
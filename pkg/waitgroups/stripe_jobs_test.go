@@ -0,0 +1,59 @@
+package waitgroups
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestStripeJobsTable_List(t *testing.T) {
+	t.Run("list jobs of one stripe only", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newStripeJobsTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		stripeId := &corepb.WaitGroupStripeId{
+			AccountId:   rand.Uint64(),
+			NamespaceId: rand.Uint64(),
+			WaitGroupId: rand.Uint64(),
+			Stripe:      1,
+		}
+
+		txn := badgerStore.Update()
+		for stripe := uint32(0); stripe < 3; stripe++ {
+			for _, jobId := range []string{"a", "b"} {
+				err := table.Create(txn, &corepb.WaitGroupJob{
+					Id: &corepb.WaitGroupJobId{
+						AccountId:   stripeId.AccountId,
+						NamespaceId: stripeId.NamespaceId,
+						WaitGroupId: stripeId.WaitGroupId,
+						JobId:       jobId,
+						Stripe:      stripe,
+					},
+				})
+				require.NoError(t, err)
+			}
+		}
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		result, err := table.List(txn, stripeId, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, result.jobs, 2)
+		for _, job := range result.jobs {
+			require.Equal(t, uint32(1), job.Id.Stripe)
+		}
+
+		job, err := table.Get(txn, result.jobs[0].Id)
+		require.NoError(t, err)
+		require.Equal(t, "a", job.Id.JobId)
+	})
+}
@@ -0,0 +1,84 @@
+package waitgroups
+
+import (
+	"github.com/evrblk/monstera/cluster"
+	"github.com/evrblk/monstera/store"
+	"github.com/evrblk/monstera/utils"
+	"github.com/evrblk/yellowstone-common/honey"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+	"github.com/evrblk/grackle/pkg/sharding"
+	"github.com/evrblk/grackle/pkg/tables"
+)
+
+// stripesTable stores the stripes of striped wait groups. A stripe lives on the
+// shard of its own ByWaitGroupStripe key, which usually is not the shard of its
+// wait group.
+//
+// Table Primary Key:
+// 1. account id
+// 2. namespace id
+// 3. wait group id
+//
+// Table Sort Key:
+// 1. stripe
+type stripesTable struct {
+	table *honey.BinaryTable[*corepb.WaitGroupStripe, corepb.WaitGroupStripe]
+}
+
+// newStripesTable scopes the table under the shard-unique prefix; see
+// newWaitGroupsTable.
+func newStripesTable(replicaPrefix []byte) *stripesTable {
+	return &stripesTable{
+		table: honey.NewBinaryTable[*corepb.WaitGroupStripe, corepb.WaitGroupStripe](
+			utils.ConcatBytes(replicaPrefix, tablePrefixStripes),
+		),
+	}
+}
+
+// Clear deletes every stripe row.
+func (t *stripesTable) Clear(badgerStore *store.BadgerStore) error {
+	return badgerStore.DeletePrefix(t.table.TableId())
+}
+
+// EachEntity streams every stripe as (canonical key, stored value).
+func (t *stripesTable) EachEntity(txn *store.Txn, fn func(key []byte, value []byte) (bool, error)) error {
+	return t.table.EachEntry(txn, fn)
+}
+
+// RestoreEntity decodes one streamed stripe and, if owned, inserts it through
+// Set — re-deriving its key from the stripe's own identity fields. Ownership is
+// decided by the stripe's ByWaitGroupStripe key.
+func (t *stripesTable) RestoreEntity(txn *store.Txn, key []byte, value []byte, bounds tables.ShardRange) (bool, error) {
+	stripe := &corepb.WaitGroupStripe{}
+	if err := stripe.UnmarshalBinary(value); err != nil {
+		return false, err
+	}
+	if !bounds.Owns(stripeShardKey(stripe.Id)) {
+		return false, nil
+	}
+	return true, t.Set(txn, stripe)
+}
+
+func (t *stripesTable) Get(txn *store.Txn, stripeId *corepb.WaitGroupStripeId) (*corepb.WaitGroupStripe, error) {
+	return t.table.Get(txn, stripeKey(stripeId))
+}
+
+func (t *stripesTable) Set(txn *store.Txn, stripe *corepb.WaitGroupStripe) error {
+	return t.table.Set(txn, stripeKey(stripe.Id), stripe)
+}
+
+func (t *stripesTable) Delete(txn *store.Txn, stripeId *corepb.WaitGroupStripeId) error {
+	return t.table.Delete(txn, stripeKey(stripeId))
+}
+
+func stripeKey(stripeId *corepb.WaitGroupStripeId) []byte {
+	return utils.ConcatBytes(
+		tablePK(stripeId.AccountId, stripeId.NamespaceId, stripeId.WaitGroupId),
+		uint64(stripeId.Stripe),
+	)
+}
+
+func stripeShardKey(stripeId *corepb.WaitGroupStripeId) cluster.ShardKey {
+	return sharding.ByWaitGroupStripe(stripeId.AccountId, stripeId.NamespaceId, stripeId.WaitGroupId, stripeId.Stripe)
+}
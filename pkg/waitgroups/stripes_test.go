@@ -0,0 +1,67 @@
+package waitgroups
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/evrblk/monstera/store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evrblk/grackle/pkg/corepb"
+)
+
+func TestStripesTable_SetGetDelete(t *testing.T) {
+	t.Run("set, get and delete stripe", func(t *testing.T) {
+		badgerStore, err := store.NewBadgerInMemoryStore()
+		require.NoError(t, err)
+
+		table := newStripesTable([]byte{0x77, 0x77, 0x77, 0x77})
+
+		stripe := &corepb.WaitGroupStripe{
+			Id: &corepb.WaitGroupStripeId{
+				AccountId:   rand.Uint64(),
+				NamespaceId: rand.Uint64(),
+				WaitGroupId: rand.Uint64(),
+				Stripe:      5,
+			},
+			CompletedJobs: 3,
+			FailedJobs:    1,
+		}
+
+		txn := badgerStore.Update()
+		err = table.Set(txn, stripe)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify stripe was stored, and only under its own stripe
+		txn = badgerStore.View()
+		actual, err := table.Get(txn, stripe.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), actual.CompletedJobs)
+		require.Equal(t, int64(1), actual.FailedJobs)
+
+		_, err = table.Get(txn, &corepb.WaitGroupStripeId{
+			AccountId:   stripe.Id.AccountId,
+			NamespaceId: stripe.Id.NamespaceId,
+			WaitGroupId: stripe.Id.WaitGroupId,
+			Stripe:      4,
+		})
+		require.ErrorIs(t, err, store.ErrNotFound)
+		txn.Discard()
+
+		// Delete stripe
+		txn = badgerStore.Update()
+		err = table.Delete(txn, stripe.Id)
+		require.NoError(t, err)
+		err = txn.Commit()
+		require.NoError(t, err)
+
+		// Verify stripe is gone
+		txn = badgerStore.View()
+		defer txn.Discard()
+
+		_, err = table.Get(txn, stripe.Id)
+		require.ErrorIs(t, err, store.ErrNotFound)
+	})
+}
@@ -16,4 +16,10 @@ var (
 	tablePrefixGCRecords            = []byte{0x04}
 	tablePrefixExpirationRecords    = []byte{0x05}
 	tablePrefixDeletionRecords      = []byte{0x06}
+	tablePrefixPendingJobs          = []byte{0x07}
+	tablePrefixRetractedJobs        = []byte{0x08}
+	tablePrefixJobChunks            = []byte{0x09}
+	tablePrefixStripes              = []byte{0x0A}
+	tablePrefixStripeJobs           = []byte{0x0B}
+	tablePrefixStripeDeletion       = []byte{0x0C}
 )
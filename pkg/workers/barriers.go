@@ -14,14 +14,18 @@ import (
 )
 
 type GrackleBarriersGCWorker struct {
-	coreApiClient coreapis.GrackleClientApi
-	worker        *workers.IntervalWorker
+	coreApiClient            coreapis.GrackleClientApi
+	worker                   *workers.IntervalWorker
+	maxGenerationsPerBarrier int64
 }
 
-func NewGrackleBarriersGCWorker(coreApiClient coreapis.GrackleClientApi) *GrackleBarriersGCWorker {
+// NewGrackleBarriersGCWorker creates a worker that keeps the maxGenerationsPerBarrier most
+// recent generation history rows of every barrier (0 keeps the whole history).
+func NewGrackleBarriersGCWorker(coreApiClient coreapis.GrackleClientApi, maxGenerationsPerBarrier int64) *GrackleBarriersGCWorker {
 	return &GrackleBarriersGCWorker{
-		coreApiClient: coreApiClient,
-		worker:        workers.NewIntervalWorker(time.Duration(5) * time.Second),
+		coreApiClient:            coreApiClient,
+		worker:                   workers.NewIntervalWorker(time.Duration(5) * time.Second),
+		maxGenerationsPerBarrier: maxGenerationsPerBarrier,
 	}
 }
 
@@ -63,6 +67,8 @@ func (w *GrackleBarriersGCWorker) runGarbageCollection(shardId string, now time.
 		GcRecordBarriersPageSize:     1000,
 		GcRecordParticipantsPageSize: 1000,
 		MaxVisited:                   1000,
+		MaxGenerationsPerBarrier:     w.maxGenerationsPerBarrier,
+		GenerationTrimsPageSize:      100,
 	}, shardId)
 	if err != nil {
 		grackleBarriersGCWorkerErrorsTotal.WithLabelValues(shardId).Inc()